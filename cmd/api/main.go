@@ -1,15 +1,24 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/bernardofernandezz/scheduling-api/internal/api/routes"
 	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/metrics"
 	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+	"github.com/bernardofernandezz/scheduling-api/pkg/buildinfo"
+	"github.com/bernardofernandezz/scheduling-api/pkg/jobs"
+	"github.com/bernardofernandezz/scheduling-api/pkg/leader"
 )
 
 func main() {
-	log.Println("Starting Scheduling API server...")
+	log.Printf("Starting Scheduling API server %s...", buildinfo.Get())
 
 	// Load application configuration
 	cfg, err := config.Load()
@@ -32,8 +41,51 @@ func main() {
 	}
 	log.Println("Database migration completed successfully")
 
-	// Initialize router
-	router := routes.SetupRouter(repos, cfg)
+	// Install the default notification templates and update system templates of older releases
+	seeded, err := service.SeedNotificationTemplates(repos.TemplateRepo)
+	if err != nil {
+		log.Fatalf("Failed to seed notification templates: %v", err)
+	}
+	log.Printf("Notification templates seeded: %d created, %d updated, %d kept", seeded.Created, seeded.Updated, seeded.Kept)
+
+	// Elect the replica running scheduled jobs; followers take over when the leader goes away
+	var elector *leader.Elector
+	if cfg.Leader.Enabled {
+		elector = leader.New(repos.NewLeaderLock(cfg.Leader.LockName), cfg.Jobs.Instance, cfg.Leader.CheckInterval)
+		metrics.Leader.WithLabelValues(elector.Identity()).Set(0)
+		elector.OnChange(func(leading bool) {
+			role, value := "follower", 0.0
+			if leading {
+				role, value = "leader", 1.0
+			}
+			metrics.Leader.WithLabelValues(elector.Identity()).Set(value)
+			metrics.LeaderTransitions.WithLabelValues(elector.Identity(), role).Inc()
+		})
+	}
+
+	// Initialize router and the background jobs its services register
+	scheduler := jobs.NewScheduler()
+	router := routes.SetupRouter(repos, cfg, scheduler, elector)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if elector != nil {
+		go elector.Run(ctx)
+	}
+	scheduler.Start(ctx)
+
+	// Reload the CORS and security header settings on SIGHUP, keeping the current ones if the new are invalid
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	go func() {
+		for range hangup {
+			if err := cfg.Security.Reload(); err != nil {
+				log.Printf("Failed to reload security settings, keeping the current ones: %v", err)
+				continue
+			}
+			log.Printf("Security settings reloaded for profile %s", cfg.Security.Current().Profile)
+		}
+	}()
 
 	// Start server
 	log.Printf("Server starting on %s in %s mode", cfg.Server.Address, cfg.Server.Mode)
@@ -0,0 +1,77 @@
+// Command consistency looks for records left inconsistent with each other: open appointments
+// outside their operation's hours or delivering inactive products, queue items whose notification
+// is gone or already sent, and calendar sync records without an event. It prints what it finds;
+// with -repair it also cancels the orphaned queue items and deletes the calendar sync records,
+// leaving the appointments for staff to move or cancel. Run it without -repair first to see what
+// it would change.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+)
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	repair := flag.Bool("repair", false, "repair what can be repaired safely instead of only reporting")
+	asJSON := flag.Bool("json", false, "print the report as JSON")
+	flag.IntVar(&cfg.Consistency.MaxFindings, "max", cfg.Consistency.MaxFindings, "most findings of each kind to report")
+	flag.Parse()
+
+	db, err := repository.NewDBConnection(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	repos := repository.NewRepositories(db)
+
+	consistencyService := service.NewConsistencyService(repos.ConsistencyRepo, cfg.Consistency, clock.System{})
+	report := consistencyService.Check(context.Background(), *repair)
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			log.Fatalf("Failed to write report: %v", err)
+		}
+	} else {
+		for _, finding := range report.Findings {
+			status := ""
+			if finding.Repaired {
+				status = " [repaired]"
+			}
+			log.Printf("%s: %s %d: %s%s", finding.Check, finding.Record, finding.RecordID, finding.Detail, status)
+		}
+	}
+
+	failed := false
+	for _, summary := range report.Summary {
+		if summary.Error != "" {
+			log.Printf("%s: check failed: %s", summary.Check, summary.Error)
+			failed = true
+			continue
+		}
+		more := ""
+		if summary.Truncated {
+			more = " (more not listed)"
+		}
+		log.Printf("%s: %d found%s, %d repaired", summary.Check, summary.Found, more, summary.Repaired)
+	}
+	if !*repair {
+		log.Println("Report only: nothing was repaired")
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,244 @@
+// Command loadtest drives check-availability and appointment creation against a running API
+// under contention: every worker books into the same small set of slots, so most creates race
+// for the same rows. It reports latency percentiles and status codes per endpoint and exits
+// non-zero when an endpoint's p95 exceeds its budget or requests fail unexpectedly.
+//
+// The same scenario is available for k6 in scripts/loadtest/booking.js.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// endpoint names used in the report
+const (
+	endpointAvailability = "check-availability"
+	endpointCreate       = "create"
+)
+
+// options configures a run
+type options struct {
+	baseURL     string
+	token       string
+	duration    time.Duration
+	concurrency int
+	slots       int
+	createRatio float64
+
+	supplierID  uint
+	employeeID  uint
+	operationID uint
+	productID   uint
+
+	availabilityBudget time.Duration
+	createBudget       time.Duration
+}
+
+// result is the outcome of one request
+type result struct {
+	endpoint string
+	status   int
+	latency  time.Duration
+	err      error
+}
+
+// stats aggregates the results of one endpoint
+type stats struct {
+	latencies []time.Duration
+	statuses  map[int]int
+	errors    int
+}
+
+func main() {
+	opts := options{}
+	flag.StringVar(&opts.baseURL, "base-url", "http://localhost:8080/api/v1", "API base URL")
+	flag.StringVar(&opts.token, "token", os.Getenv("LOADTEST_TOKEN"), "bearer token of a supplier or admin user (or LOADTEST_TOKEN)")
+	flag.DurationVar(&opts.duration, "duration", 30*time.Second, "how long to run")
+	flag.IntVar(&opts.concurrency, "concurrency", 20, "number of concurrent workers")
+	flag.IntVar(&opts.slots, "slots", 4, "number of one-hour slots the workers compete for")
+	flag.Float64Var(&opts.createRatio, "create-ratio", 0.3, "share of requests that try to create an appointment")
+	flag.UintVar(&opts.supplierID, "supplier", 1, "supplier ID to book for")
+	flag.UintVar(&opts.employeeID, "employee", 1, "employee ID to book with")
+	flag.UintVar(&opts.operationID, "operation", 1, "operation ID to book at")
+	flag.UintVar(&opts.productID, "product", 1, "product ID to deliver")
+	flag.DurationVar(&opts.availabilityBudget, "availability-p95", 150*time.Millisecond, "p95 latency budget of check-availability")
+	flag.DurationVar(&opts.createBudget, "create-p95", 300*time.Millisecond, "p95 latency budget of create")
+	flag.Parse()
+
+	if opts.token == "" {
+		log.Fatal("A bearer token is required: pass -token or set LOADTEST_TOKEN")
+	}
+
+	log.Printf("Running %d workers for %s against %s", opts.concurrency, opts.duration, opts.baseURL)
+	results := run(opts)
+
+	byEndpoint := map[string]*stats{}
+	for r := range results {
+		s, ok := byEndpoint[r.endpoint]
+		if !ok {
+			s = &stats{statuses: map[int]int{}}
+			byEndpoint[r.endpoint] = s
+		}
+		if r.err != nil {
+			s.errors++
+			continue
+		}
+		s.latencies = append(s.latencies, r.latency)
+		s.statuses[r.status]++
+	}
+
+	budgets := map[string]time.Duration{
+		endpointAvailability: opts.availabilityBudget,
+		endpointCreate:       opts.createBudget,
+	}
+	failed := false
+	for _, endpoint := range []string{endpointAvailability, endpointCreate} {
+		s, ok := byEndpoint[endpoint]
+		if !ok {
+			continue
+		}
+		if !report(endpoint, s, budgets[endpoint], opts.duration) {
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// run starts the workers and returns a channel with every result, closed when the run ends
+func run(opts options) <-chan result {
+	results := make(chan result, opts.concurrency*16)
+	client := &http.Client{Timeout: 10 * time.Second}
+	deadline := time.Now().Add(opts.duration)
+
+	// All workers compete for the same slots, starting on the next weekday morning
+	day := time.Now().AddDate(0, 0, 1)
+	for day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+		day = day.AddDate(0, 0, 1)
+	}
+	first := time.Date(day.Year(), day.Month(), day.Day(), 9, 0, 0, 0, time.Local)
+
+	var wg sync.WaitGroup
+	for i := 0; i < opts.concurrency; i++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(seed))
+			for time.Now().Before(deadline) {
+				start := first.Add(time.Duration(rng.Intn(opts.slots)) * time.Hour)
+				if rng.Float64() < opts.createRatio {
+					results <- post(client, opts, endpointCreate, "/appointments", map[string]interface{}{
+						"supplier_id":         opts.supplierID,
+						"employee_id":         opts.employeeID,
+						"operation_id":        opts.operationID,
+						"product_id":          opts.productID,
+						"scheduled_start":     start,
+						"scheduled_end":       start.Add(time.Hour),
+						"quantity_to_deliver": 1,
+						"notes":               "load test",
+					})
+					continue
+				}
+				results <- post(client, opts, endpointAvailability, "/appointments/check-availability", map[string]interface{}{
+					"operation_id":    opts.operationID,
+					"employee_id":     opts.employeeID,
+					"scheduled_start": start,
+					"scheduled_end":   start.Add(time.Hour),
+				})
+			}
+		}(time.Now().UnixNano() + int64(i))
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+	return results
+}
+
+// post sends one JSON request and times it
+func post(client *http.Client, opts options, endpoint, path string, body interface{}) result {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return result{endpoint: endpoint, err: err}
+	}
+	req, err := http.NewRequest(http.MethodPost, opts.baseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return result{endpoint: endpoint, err: err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+opts.token)
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return result{endpoint: endpoint, err: err}
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	return result{endpoint: endpoint, status: resp.StatusCode, latency: time.Since(start)}
+}
+
+// report prints an endpoint's statistics and reports whether it met its budget. Conflicts
+// (400 for a taken slot) are expected under contention; server errors and transport errors
+// fail the run, as does a p95 over budget.
+func report(endpoint string, s *stats, budget, duration time.Duration) bool {
+	sort.Slice(s.latencies, func(i, j int) bool { return s.latencies[i] < s.latencies[j] })
+	p50, p95, p99 := percentile(s.latencies, 50), percentile(s.latencies, 95), percentile(s.latencies, 99)
+
+	total := len(s.latencies) + s.errors
+	fmt.Printf("%s: %d requests (%.1f/s), p50 %s, p95 %s, p99 %s, budget p95 %s\n",
+		endpoint, total, float64(total)/duration.Seconds(),
+		p50.Round(time.Millisecond), p95.Round(time.Millisecond), p99.Round(time.Millisecond), budget)
+
+	codes := make([]int, 0, len(s.statuses))
+	for code := range s.statuses {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	serverErrors := 0
+	for _, code := range codes {
+		fmt.Printf("  %d: %d\n", code, s.statuses[code])
+		if code >= http.StatusInternalServerError {
+			serverErrors += s.statuses[code]
+		}
+	}
+	if s.errors > 0 {
+		fmt.Printf("  transport errors: %d\n", s.errors)
+	}
+
+	ok := true
+	if budget > 0 && p95 > budget {
+		fmt.Printf("  FAIL: p95 %s is over the %s budget\n", p95.Round(time.Millisecond), budget)
+		ok = false
+	}
+	if serverErrors > 0 || s.errors > 0 {
+		fmt.Printf("  FAIL: %d server errors, %d transport errors\n", serverErrors, s.errors)
+		ok = false
+	}
+	return ok
+}
+
+// percentile returns the p-th percentile of sorted latencies
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	index := (len(sorted)*p+99)/100 - 1
+	if index < 0 {
+		index = 0
+	}
+	return sorted[index]
+}
@@ -0,0 +1,116 @@
+// Command phones rewrites the phone numbers stored in users and notification preferences in
+// E.164, the format registration now stores them in. Numbers that can't be parsed are logged and
+// left as they are for someone to fix by hand. Run it with -dry-run first to see what it would
+// change.
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/phone"
+	"gorm.io/gorm"
+)
+
+// batchSize is how many rows are read at a time
+const batchSize = 500
+
+// backfillResult counts what happened to the numbers of one table
+type backfillResult struct {
+	updated   int
+	unchanged int
+	invalid   int
+}
+
+// phoneRow is a row holding a phone number
+type phoneRow struct {
+	ID    uint
+	Phone string
+}
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	region := flag.String("region", cfg.Phone.DefaultRegion, "country of numbers stored without a calling code")
+	dryRun := flag.Bool("dry-run", false, "report the changes without writing them")
+	flag.Parse()
+
+	if !phone.SupportedRegion(*region) {
+		log.Fatalf("Unsupported phone region %q", *region)
+	}
+
+	db, err := repository.NewDBConnection(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	tables := []struct {
+		model  interface{}
+		name   string
+		column string
+	}{
+		{&models.User{}, "user", "phone"},
+		{&models.NotificationPreference{}, "notification preference", "phone_number"},
+	}
+	for _, table := range tables {
+		result, err := backfill(db, table.model, table.name, table.column, *region, *dryRun)
+		if err != nil {
+			log.Fatalf("Failed to normalize %s phone numbers: %v", table.name, err)
+		}
+		log.Printf("%s phone numbers: %d normalized, %d already normalized, %d invalid", table.name, result.updated, result.unchanged, result.invalid)
+	}
+	if *dryRun {
+		log.Println("Dry run: nothing was written")
+	}
+}
+
+// backfill normalizes the phone numbers of one table, a batch of rows at a time
+func backfill(db *gorm.DB, model interface{}, name, column, region string, dryRun bool) (backfillResult, error) {
+	var result backfillResult
+	var lastID uint
+
+	for {
+		var rows []phoneRow
+		err := db.Model(model).
+			Select("id, "+column+" AS phone").
+			Where("id > ? AND "+column+" <> ''", lastID).
+			Order("id ASC").
+			Limit(batchSize).
+			Scan(&rows).Error
+		if err != nil {
+			return result, err
+		}
+		if len(rows) == 0 {
+			return result, nil
+		}
+		lastID = rows[len(rows)-1].ID
+
+		for _, row := range rows {
+			normalized, err := phone.Normalize(row.Phone, region)
+			if err != nil {
+				log.Printf("%s %d: %q: %v", name, row.ID, row.Phone, err)
+				result.invalid++
+				continue
+			}
+			if normalized == row.Phone {
+				result.unchanged++
+				continue
+			}
+
+			result.updated++
+			if dryRun {
+				log.Printf("%s %d: %q -> %q", name, row.ID, row.Phone, normalized)
+				continue
+			}
+			if err := db.Model(model).Where("id = ?", row.ID).UpdateColumn(column, normalized).Error; err != nil {
+				return result, err
+			}
+		}
+	}
+}
@@ -7,8 +7,12 @@ require (
 	github.com/gin-gonic/gin v1.9.1
 	github.com/golang-jwt/jwt/v5 v5.0.0
 	github.com/joho/godotenv v1.5.1
+	github.com/prometheus/client_golang v1.16.0
+	github.com/shopspring/decimal v1.3.1
+	github.com/stretchr/testify v1.9.0
 	golang.org/x/crypto v0.14.0
 	golang.org/x/time v0.3.0
+	gopkg.in/yaml.v3 v3.0.1
 	gorm.io/driver/postgres v1.5.2
 	gorm.io/gorm v1.25.4
 )
@@ -1,37 +1,57 @@
 package handlers
 
 import (
+	"errors"
+	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
 	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
 	"github.com/bernardofernandezz/scheduling-api/internal/service"
+	"github.com/bernardofernandezz/scheduling-api/pkg/i18n"
 )
 
 // AppointmentHandler handles appointment-related requests
 type AppointmentHandler struct {
-	appointmentService service.AppointmentService
+	appointmentService  service.AppointmentService
+	availabilityService service.AvailabilityService
+	locationService     service.LocationService
+	visibilityService   service.VisibilityService
 }
 
 // NewAppointmentHandler creates a new appointment handler
-func NewAppointmentHandler(appointmentService service.AppointmentService) *AppointmentHandler {
+func NewAppointmentHandler(
+	appointmentService service.AppointmentService,
+	availabilityService service.AvailabilityService,
+	locationService service.LocationService,
+	visibilityService service.VisibilityService,
+) *AppointmentHandler {
 	return &AppointmentHandler{
-		appointmentService: appointmentService,
+		appointmentService:  appointmentService,
+		availabilityService: availabilityService,
+		locationService:     locationService,
+		visibilityService:   visibilityService,
 	}
 }
 
 // CreateAppointmentRequest is the request body for creating an appointment
 type CreateAppointmentRequest struct {
-	SupplierID        uint      `json:"supplier_id" binding:"required"`
-	EmployeeID        uint      `json:"employee_id" binding:"required"`
-	OperationID       uint      `json:"operation_id" binding:"required"`
-	ProductID         uint      `json:"product_id" binding:"required"`
-	ScheduledStart    time.Time `json:"scheduled_start" binding:"required"`
-	ScheduledEnd      time.Time `json:"scheduled_end" binding:"required"`
-	Notes             string    `json:"notes"`
-	QuantityToDeliver int       `json:"quantity_to_deliver" binding:"required,min=1"`
+	SupplierID        uint                   `json:"supplier_id" binding:"required"`
+	EmployeeID        uint                   `json:"employee_id"` // assigned a qualified, open employee when omitted
+	OperationID       uint                   `json:"operation_id" binding:"required"`
+	Type              models.AppointmentType `json:"type"`
+	ProductID         *uint                  `json:"product_id"`
+	ScheduledStart    time.Time              `json:"scheduled_start" binding:"required"`
+	ScheduledEnd      time.Time              `json:"scheduled_end" binding:"required"`
+	Notes             string                 `json:"notes"`
+	QuantityToDeliver int                    `json:"quantity_to_deliver" binding:"min=0"`
+	CostCenter        string                 `json:"cost_center"`
+	BillingCode       string                 `json:"billing_code"`
 }
 
 // UpdateAppointmentRequest is the request body for updating an appointment
@@ -46,6 +66,14 @@ type UpdateAppointmentRequest struct {
 	Notes             string                 `json:"notes"`
 	QuantityToDeliver int                    `json:"quantity_to_deliver" binding:"min=1"`
 	CancellationReason string                `json:"cancellation_reason"`
+	CostCenter        string                 `json:"cost_center"`
+	BillingCode       string                 `json:"billing_code"`
+}
+
+// SetBillingRequest is the request body for charging an appointment to a cost center and billing code
+type SetBillingRequest struct {
+	CostCenter  string `json:"cost_center"`
+	BillingCode string `json:"billing_code"`
 }
 
 // UpdateStatusRequest is the request body for updating an appointment status
@@ -54,12 +82,94 @@ type UpdateStatusRequest struct {
 	Reason string                  `json:"reason"`
 }
 
+// CompleteAppointmentRequest is the request body for completing an appointment
+type CompleteAppointmentRequest struct {
+	ReceivedQuantity *int                    `json:"received_quantity" binding:"required,min=0"`
+	ProofOfDelivery  *ProofOfDeliveryRequest `json:"proof_of_delivery"`
+}
+
+// ProofAttachmentRequest is a signed document or photo proving a delivery
+type ProofAttachmentRequest struct {
+	Kind        models.ProofAttachmentKind `json:"kind" binding:"required"`
+	FileURL     string                     `json:"file_url" binding:"required,url"`
+	ContentType string                     `json:"content_type"`
+	Caption     string                     `json:"caption"`
+}
+
+// ProofOfDeliveryRequest is the request body for recording who received a delivery
+type ProofOfDeliveryRequest struct {
+	ReceiverName string                   `json:"receiver_name" binding:"required"`
+	SignatureURL string                   `json:"signature_url" binding:"omitempty,url"`
+	SignedAt     *time.Time               `json:"signed_at"`
+	Notes        string                   `json:"notes"`
+	Attachments  []ProofAttachmentRequest `json:"attachments" binding:"dive"`
+}
+
+// AddProofAttachmentsRequest is the request body for attaching files to a proof of delivery
+type AddProofAttachmentsRequest struct {
+	Attachments []ProofAttachmentRequest `json:"attachments" binding:"required,min=1,dive"`
+}
+
+// TypeCapacitiesRequest is the request body for setting an operation's appointment type capacities
+type TypeCapacitiesRequest struct {
+	Capacities []struct {
+		Type          models.AppointmentType `json:"type" binding:"required"`
+		MaxConcurrent int                    `json:"max_concurrent"`
+		MaxPerDay     int                    `json:"max_per_day"`
+	} `json:"capacities"`
+}
+
+// LinkInboundRequest is the request body for linking a pickup to an inbound delivery
+type LinkInboundRequest struct {
+	InboundID uint `json:"inbound_id" binding:"required"`
+}
+
+// DeclareDelayRequest is the request body for a supplier declaring a delay
+type DeclareDelayRequest struct {
+	ETA            time.Time `json:"eta" binding:"required"`
+	Reason         string    `json:"reason"`
+	AutoReschedule bool      `json:"auto_reschedule"`
+}
+
+// SubmitFeedbackRequest is the request body for rating a completed appointment
+type SubmitFeedbackRequest struct {
+	Rating  int    `json:"rating" binding:"required,min=1,max=5"`
+	Comment string `json:"comment" binding:"max=2000"`
+}
+
+// IncidentAttachmentRequest is a photo or file attached to an incident
+type IncidentAttachmentRequest struct {
+	FileURL     string `json:"file_url" binding:"required,url"`
+	ContentType string `json:"content_type"`
+	Caption     string `json:"caption"`
+}
+
+// ReportIncidentRequest is the request body for logging a problem on an appointment
+type ReportIncidentRequest struct {
+	Category    models.IncidentCategory     `json:"category" binding:"required"`
+	Severity    models.IncidentSeverity     `json:"severity" binding:"required"`
+	Description string                      `json:"description" binding:"required"`
+	Attachments []IncidentAttachmentRequest `json:"attachments" binding:"dive"`
+}
+
+// ResolveIncidentRequest is the request body for resolving an incident
+type ResolveIncidentRequest struct {
+	Resolution string `json:"resolution" binding:"required"`
+}
+
+// AddIncidentAttachmentsRequest is the request body for attaching files to an incident
+type AddIncidentAttachmentsRequest struct {
+	Attachments []IncidentAttachmentRequest `json:"attachments" binding:"required,min=1,dive"`
+}
+
 // CheckAvailabilityRequest is the request body for checking appointment availability
 type CheckAvailabilityRequest struct {
-	OperationID    uint      `json:"operation_id" binding:"required"`
-	EmployeeID     uint      `json:"employee_id" binding:"required"`
-	ScheduledStart time.Time `json:"scheduled_start" binding:"required"`
-	ScheduledEnd   time.Time `json:"scheduled_end" binding:"required"`
+	OperationID    uint                   `json:"operation_id" binding:"required"`
+	EmployeeID     uint                   `json:"employee_id" binding:"required"`
+	ProductID      *uint                  `json:"product_id"` // checks the employee's skills against the product's category
+	Type           models.AppointmentType `json:"type"`       // capacity limits checked for, delivery when left out
+	ScheduledStart time.Time              `json:"scheduled_start" binding:"required"`
+	ScheduledEnd   time.Time              `json:"scheduled_end" binding:"required"`
 }
 
 // GetAppointmentFilters parses appointment filters from query parameters
@@ -83,6 +193,12 @@ func GetAppointmentFilters(c *gin.Context) repository.AppointmentFilters {
 		filters.Status = &appointmentStatus
 	}
 
+	// Parse type filter
+	if appointmentType := c.Query("type"); appointmentType != "" {
+		t := models.AppointmentType(appointmentType)
+		filters.Type = &t
+	}
+
 	// Parse date filters
 	if startDateStr := c.Query("start_date"); startDateStr != "" {
 		if startDate, err := time.Parse(time.RFC3339, startDateStr); err == nil {
@@ -96,13 +212,16 @@ func GetAppointmentFilters(c *gin.Context) repository.AppointmentFilters {
 		}
 	}
 
+	// Parse booking code search
+	filters.BookingCode = c.Query("booking_code")
+
 	return filters
 }
 
 // Create handles creating a new appointment
 func (h *AppointmentHandler) Create(c *gin.Context) {
 	var req CreateAppointmentRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := middleware.BindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
 		return
 	}
@@ -134,26 +253,85 @@ func (h *AppointmentHandler) Create(c *gin.Context) {
 		}
 	}
 
+	// Without an employee, book with one who holds the required skills and is free for the slot
+	employeeID := req.EmployeeID
+	if employeeID == 0 {
+		employee, err := h.availabilityService.AssignEmployee(req.OperationID, req.ProductID, req.ScheduledStart, req.ScheduledEnd)
+		if err != nil {
+			status := http.StatusBadRequest
+			if errors.Is(err, service.ErrNoQualifiedEmployee) {
+				status = http.StatusConflict
+			}
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+		employeeID = employee.ID
+	}
+
 	// Create appointment model from request
 	appointment := &models.Appointment{
 		SupplierID:        req.SupplierID,
-		EmployeeID:        req.EmployeeID,
+		EmployeeID:        employeeID,
 		OperationID:       req.OperationID,
+		Type:              req.Type,
 		ProductID:         req.ProductID,
 		ScheduledStart:    req.ScheduledStart,
 		ScheduledEnd:      req.ScheduledEnd,
 		Notes:             req.Notes,
 		QuantityToDeliver: req.QuantityToDeliver,
+		CostCenter:        req.CostCenter,
+		BillingCode:       req.BillingCode,
 		Status:            models.StatusPending,
 	}
 
 	// Create appointment
 	if err := h.appointmentService.Create(appointment); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		status := http.StatusBadRequest
+		if errors.Is(err, service.ErrDepositRequest) {
+			status = http.StatusBadGateway
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"appointment": appointment})
+	// Warn, without blocking the booking, when the supplier cannot travel between this
+	// appointment and a neighbouring one at another operation in time
+	response := gin.H{"appointment": viewAppointment(h.visibilityService, appointment, user)}
+	warnings, err := h.locationService.CheckTravel(appointment)
+	if err != nil {
+		log.Printf("Failed to check travel time for appointment %d: %v", appointment.ID, err)
+	} else if len(warnings) > 0 {
+		response["travel_warnings"] = warnings
+	}
+
+	// At operations charging a deposit the appointment stays reserved until it is paid; the
+	// deposit holds what the supplier's client needs to pay it
+	if appointment.Status == models.StatusReserved {
+		deposit, err := h.appointmentService.GetDeposit(appointment.ID)
+		if err != nil {
+			log.Printf("Failed to load the deposit of appointment %d: %v", appointment.ID, err)
+		} else {
+			response["deposit"] = deposit
+		}
+	}
+
+	// Operations that only warn about their per-supplier limit accept the booking
+	limitWarning, err := h.appointmentService.CheckSupplierLimit(appointment)
+	if err != nil {
+		log.Printf("Failed to check supplier limit for appointment %d: %v", appointment.ID, err)
+	} else if limitWarning != nil {
+		response["supplier_limit_warning"] = limitWarning
+	}
+
+	// Bookings that leave a time band or daily limit almost full are accepted with a warning
+	capacityWarnings, err := h.appointmentService.CapacityWarnings(appointment)
+	if err != nil {
+		log.Printf("Failed to check capacity warnings for appointment %d: %v", appointment.ID, err)
+	} else if len(capacityWarnings) > 0 {
+		response["capacity_warnings"] = capacityWarnings
+	}
+
+	c.JSON(http.StatusCreated, response)
 }
 
 // Get handles getting an appointment by ID
@@ -211,7 +389,23 @@ func (h *AppointmentHandler) Get(c *gin.Context) {
 		}
 	}
 
-	c.JSON(http.StatusOK, gin.H{"appointment": appointment})
+	respond(c, http.StatusOK, &AppointmentDetailResponse{
+		Appointment: viewAppointment(h.visibilityService, appointment, user),
+		Labels:      appointmentLabels(c, appointment),
+	})
+}
+
+// appointmentLabels returns the status, type and schedule of an appointment in the request's
+// language and timezone
+func appointmentLabels(c *gin.Context, appointment *models.Appointment) *AppointmentLabels {
+	preferences := middleware.RequestPreferences(c)
+	formatter := preferences.Formatter()
+	return &AppointmentLabels{
+		Status: i18n.Label(preferences.Locale, i18n.KindStatus, string(appointment.Status)),
+		Type:   i18n.Label(preferences.Locale, i18n.KindType, string(appointment.Type)),
+		Scheduled: formatter.FormatDateTime(appointment.ScheduledStart, i18n.StyleMedium) +
+			" - " + formatter.FormatTime(appointment.ScheduledEnd),
+	}
 }
 
 // Update handles updating an appointment
@@ -261,10 +455,11 @@ func (h *AppointmentHandler) Update(c *gin.Context) {
 
 	// Parse request
 	var req UpdateAppointmentRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := middleware.BindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
 		return
 	}
+	previous := *existingAppointment
 
 	// Update appointment fields that were provided
 	if req.SupplierID != 0 {
@@ -277,7 +472,8 @@ func (h *AppointmentHandler) Update(c *gin.Context) {
 		existingAppointment.OperationID = req.OperationID
 	}
 	if req.ProductID != 0 {
-		existingAppointment.ProductID = req.ProductID
+		productID := req.ProductID
+		existingAppointment.ProductID = &productID
 	}
 	if !req.ScheduledStart.IsZero() {
 		existingAppointment.ScheduledStart = req.ScheduledStart
@@ -297,14 +493,30 @@ func (h *AppointmentHandler) Update(c *gin.Context) {
 	if req.CancellationReason != "" {
 		existingAppointment.CancellationReason = req.CancellationReason
 	}
+	if req.CostCenter != "" {
+		existingAppointment.CostCenter = req.CostCenter
+	}
+	if req.BillingCode != "" {
+		existingAppointment.BillingCode = req.BillingCode
+	}
 
 	// Update appointment
 	if err := h.appointmentService.Update(existingAppointment); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	h.appointmentService.RecordUpdate(&previous, existingAppointment, service.StatusChange{
+		ActorID: &user.ID,
+		Source:  models.StatusSourceUser,
+		Reason:  req.CancellationReason,
+	})
+
+	// Move or cancel cross-dock pickups that depend on this appointment
+	if err := h.appointmentService.PropagateToLinked(&previous, existingAppointment); err != nil {
+		log.Printf("Failed to propagate changes of appointment %d to linked pickups: %v", existingAppointment.ID, err)
+	}
 
-	c.JSON(http.StatusOK, gin.H{"appointment": existingAppointment})
+	c.JSON(http.StatusOK, gin.H{"appointment": viewAppointment(h.visibilityService, existingAppointment, user)})
 }
 
 // Delete handles deleting an appointment
@@ -392,12 +604,17 @@ func (h *AppointmentHandler) List(c *gin.Context) {
 		// based on the authenticated user, but we're keeping it simple here
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"appointments": appointments,
-		"total":        total,
-		"page":         filters.Page,
-		"limit":        filters.Limit,
-		"total_pages":  (total + int64(filters.Limit) - 1) / int64(filters.Limit),
+	respond(c, http.StatusOK, newAppointmentList(appointments, total, filters))
+}
+
+// Export handles exporting every appointment matching the list filters, however many, streamed
+// in JSON or CSV as the Accept header prefers. Pagination and sorting are ignored: appointments
+// come in the order they were created.
+func (h *AppointmentHandler) Export(c *gin.Context) {
+	stream(c, &appointmentExport{
+		appointments: h.appointmentService,
+		filters:      GetAppointmentFilters(c),
+		location:     middleware.RequestTimezone(c),
 	})
 }
 
@@ -433,7 +650,7 @@ func (h *AppointmentHandler) UpdateStatus(c *gin.Context) {
 
 	// Parse request
 	var req UpdateStatusRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := middleware.BindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
 		return
 	}
@@ -444,34 +661,37 @@ func (h *AppointmentHandler) UpdateStatus(c *gin.Context) {
 		return
 	}
 
-	// Update status
-	if err := h.appointmentService.UpdateStatus(uint(id), req.Status, req.Reason); err != nil {
+	// Update status, recording the transition in the appointment's history
+	updatedAppointment, err := h.appointmentService.ChangeStatus(uint(id), req.Status, req.Reason, user.ID)
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Get updated appointment
-	updatedAppointment, err := h.appointmentService.GetByID(uint(id))
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve updated appointment"})
-		return
+	// Cancel cross-dock pickups that depend on this appointment
+	if err := h.appointmentService.PropagateToLinked(existingAppointment, updatedAppointment); err != nil {
+		log.Printf("Failed to propagate status of appointment %d to linked pickups: %v", updatedAppointment.ID, err)
 	}
 
-	c.JSON(http.StatusOK, gin.H{"appointment": updatedAppointment})
+	c.JSON(http.StatusOK, gin.H{"appointment": viewAppointment(h.visibilityService, updatedAppointment, user)})
 }
 
-// GetBySupplier handles getting appointments for a specific supplier
-func (h *AppointmentHandler) GetBySupplier(c *gin.Context) {
-	// Parse supplier ID from path
-	idStr := c.Param("supplier_id")
+// Complete handles completing an appointment with the quantity actually received
+func (h *AppointmentHandler) Complete(c *gin.Context) {
+	// Parse appointment ID from path
+	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 32)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid supplier ID"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid appointment ID"})
 		return
 	}
 
-	// Get filters from query parameters
-	filters := GetAppointmentFilters(c)
+	// Get existing appointment
+	existingAppointment, err := h.appointmentService.GetByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
 
 	// Authorization check
 	userObj, exists := c.Get("user")
@@ -486,265 +706,781 @@ func (h *AppointmentHandler) GetBySupplier(c *gin.Context) {
 		return
 	}
 
-	// Only admins and the supplier themselves can view their appointments
-	if user.Role != "admin" {
-		if user.Role == "supplier" {
-			// In a real app, you'd fetch supplier ID for this user
-			var supplierID uint = 0
-			if supplierID != 0 && supplierID != uint(id) {
-				c.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to view these appointments"})
-				return
-			}
-		} else {
-			// Employees can view appointments for any supplier
-			// This might be adjusted based on business rules
-		}
+	// Parse request
+	var req CompleteAppointmentRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
 	}
 
-	// Get appointments for the supplier
-	appointments, total, err := h.appointmentService.GetBySupplier(uint(id), filters)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	// Completing follows the same rules as changing the status to completed
+	if !hasStatusChangePermission(user, existingAppointment, models.StatusCompleted) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to complete this appointment"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"appointments": appointments,
-		"total":        total,
-		"page":         filters.Page,
-		"limit":        filters.Limit,
-		"total_pages":  (total + int64(filters.Limit) - 1) / int64(filters.Limit),
-	})
-}
+	var proof *models.ProofOfDelivery
+	if req.ProofOfDelivery != nil {
+		proof = proofOfDelivery(req.ProofOfDelivery)
+	}
 
-// GetByEmployee handles getting appointments for a specific employee
-func (h *AppointmentHandler) GetByEmployee(c *gin.Context) {
-	// Parse employee ID from path
-	idStr := c.Param("employee_id")
-	id, err := strconv.ParseUint(idStr, 10, 32)
+	appointment, followUp, err := h.appointmentService.Complete(uint(id), *req.ReceivedQuantity, user.ID, proof)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Get filters from query parameters
-	filters := GetAppointmentFilters(c)
-
-	// Authorization check
-	userObj, exists := c.Get("user")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
-		return
+	response := gin.H{
+		"appointment": viewAppointment(h.visibilityService, appointment, user),
+		"follow_up":   viewAppointment(h.visibilityService, followUp, user),
 	}
+	if proof != nil && proof.ID != 0 {
+		response["proof_of_delivery"] = proof
+	}
+	c.JSON(http.StatusOK, response)
+}
 
-	user, ok := userObj.(*models.User)
+// RecordProofOfDelivery handles dock staff recording the proof of delivery of an appointment
+// completed without one
+func (h *AppointmentHandler) RecordProofOfDelivery(c *gin.Context) {
+	appointment, ok := h.authorizeAppointment(c)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user object"})
 		return
 	}
 
-	// Only admins and the employee themselves can view their appointments
-	if user.Role != "admin" {
-		if user.Role == "employee" {
-			// In a real app, you'd fetch employee ID for this user
-			var employeeID uint = 0
-			if employeeID != 0 && employeeID != uint(id) {
-				c.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to view these appointments"})
-				return
-			}
-		} else if user.Role == "supplier" {
-			// Suppliers can only view appointments they're involved in
-			// This filtering should ideally happen at the service level
-		}
+	var req ProofOfDeliveryRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
 	}
 
-	// Get appointments for the employee
-	appointments, total, err := h.appointmentService.GetByEmployee(uint(id), filters)
+	user, _ := currentUser(c)
+	proof := proofOfDelivery(&req)
+	proof.CapturedByID = user.ID
+	recorded, err := h.appointmentService.RecordProofOfDelivery(appointment.ID, proof)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(proofOfDeliveryErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"appointments": appointments,
-		"total":        total,
-		"page":         filters.Page,
-		"limit":        filters.Limit,
-		"total_pages":  (total + int64(filters.Limit) - 1) / int64(filters.Limit),
-	})
+	c.JSON(http.StatusCreated, gin.H{"proof_of_delivery": recorded})
 }
 
-// GetByOperation handles getting appointments for a specific operation
-func (h *AppointmentHandler) GetByOperation(c *gin.Context) {
-	// Parse operation ID from path
-	idStr := c.Param("operation_id")
-	id, err := strconv.ParseUint(idStr, 10, 32)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+// GetProofOfDelivery handles showing who received a delivery, with the signed documents and photos
+func (h *AppointmentHandler) GetProofOfDelivery(c *gin.Context) {
+	appointment, ok := h.authorizeAppointment(c)
+	if !ok {
 		return
 	}
 
-	// Get filters from query parameters
-	filters := GetAppointmentFilters(c)
-
-	// Authorization check
-	userObj, exists := c.Get("user")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+	proof, err := h.appointmentService.GetProofOfDelivery(appointment.ID)
+	if err != nil {
+		c.JSON(proofOfDeliveryErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	user, ok := userObj.(*models.User)
+	c.JSON(http.StatusOK, gin.H{"proof_of_delivery": proof})
+}
+
+// AddProofOfDeliveryAttachments handles dock staff attaching documents or photos to a proof of delivery
+func (h *AppointmentHandler) AddProofOfDeliveryAttachments(c *gin.Context) {
+	appointment, ok := h.authorizeAppointment(c)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user object"})
 		return
 	}
 
-	// Admins can view all operations
-	// Employees can view operations they're assigned to
-	// Suppliers can view operations they're delivering to
-	if user.Role != "admin" {
-		// In a real app, you would check if the user has access to this operation
-		// This might involve checking if an employee belongs to this operation
-		// or if a supplier has any appointments at this operation
+	var req AddProofAttachmentsRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
 	}
 
-	// Get appointments for the operation
-	appointments, total, err := h.appointmentService.GetByOperation(uint(id), filters)
+	proof, err := h.appointmentService.AddProofOfDeliveryAttachments(appointment.ID, proofAttachments(req.Attachments))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(proofOfDeliveryErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"appointments": appointments,
-		"total":        total,
-		"page":         filters.Page,
-		"limit":        filters.Limit,
-		"total_pages":  (total + int64(filters.Limit) - 1) / int64(filters.Limit),
-	})
+	c.JSON(http.StatusOK, gin.H{"proof_of_delivery": proof})
 }
 
-// GetByDateRange handles getting appointments within a date range
-func (h *AppointmentHandler) GetByDateRange(c *gin.Context) {
-	// Parse date range parameters
-	startDateStr := c.Query("start_date")
-	if startDateStr == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "start_date is required"})
+// SetBilling handles finance charging an appointment to a cost center and billing code, at any
+// status. Empty values clear them.
+func (h *AppointmentHandler) SetBilling(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid appointment ID"})
 		return
 	}
 
-	endDateStr := c.Query("end_date")
-	if endDateStr == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "end_date is required"})
+	var req SetBillingRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
 		return
 	}
 
-	startDate, err := time.Parse(time.RFC3339, startDateStr)
+	appointment, err := h.appointmentService.SetBilling(uint(id), req.CostCenter, req.BillingCode)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start_date format. Use RFC3339 format (e.g., 2025-05-23T10:00:00Z)"})
+		status := http.StatusBadRequest
+		if errors.Is(err, repository.ErrAppointmentNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
 
-	endDate, err := time.Parse(time.RFC3339, endDateStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end_date format. Use RFC3339 format (e.g., 2025-05-23T18:00:00Z)"})
+	user, _ := currentUser(c)
+	c.JSON(http.StatusOK, gin.H{"appointment": viewAppointment(h.visibilityService, appointment, user)})
+}
+
+// LinkInbound handles linking a cross-dock pickup to the inbound delivery it depends on
+func (h *AppointmentHandler) LinkInbound(c *gin.Context) {
+	pickup, ok := h.authorizeAppointment(c)
+	if !ok {
 		return
 	}
 
-	if startDate.After(endDate) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "start_date must be before end_date"})
+	var req LinkInboundRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
 		return
 	}
 
-	// Get filters from query parameters
-	filters := GetAppointmentFilters(c)
-
-	// Authorization check
-	userObj, exists := c.Get("user")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+	linked, err := h.appointmentService.LinkInbound(pickup.ID, req.InboundID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	user, ok := userObj.(*models.User)
+	user, _ := currentUser(c)
+	c.JSON(http.StatusOK, gin.H{"appointment": viewAppointment(h.visibilityService, linked, user)})
+}
+
+// UnlinkInbound handles removing the inbound link of a pickup
+func (h *AppointmentHandler) UnlinkInbound(c *gin.Context) {
+	pickup, ok := h.authorizeAppointment(c)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user object"})
 		return
 	}
 
-	// For non-admin users, we might want to limit the date range to prevent too many results
-	if user.Role != "admin" {
-		// Limit to a maximum of 31 days for non-admin users
-		maxDateRange := 31 * 24 * time.Hour
-		if endDate.Sub(startDate) > maxDateRange {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "Date range cannot exceed 31 days for non-admin users"})
-			return
-		}
-	}
-
-	// Get appointments within the date range
-	appointments, total, err := h.appointmentService.GetByDateRange(startDate, endDate, filters)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if err := h.appointmentService.UnlinkInbound(pickup.ID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"appointments": appointments,
-		"total":        total,
-		"page":         filters.Page,
-		"limit":        filters.Limit,
-		"total_pages":  (total + int64(filters.Limit) - 1) / int64(filters.Limit),
-		"start_date":   startDate,
-		"end_date":     endDate,
-	})
+	c.JSON(http.StatusOK, gin.H{"message": "Inbound link removed successfully"})
 }
 
-// GetUpcoming handles getting upcoming appointments
-func (h *AppointmentHandler) GetUpcoming(c *gin.Context) {
-	// Parse limit parameter
-	limitStr := c.DefaultQuery("limit", "10")
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit <= 0 {
-		limit = 10 // Default to 10 if invalid
+// GetLinkedPickups handles listing the pickups that depend on an inbound delivery
+func (h *AppointmentHandler) GetLinkedPickups(c *gin.Context) {
+	inbound, ok := h.authorizeAppointment(c)
+	if !ok {
+		return
 	}
 
-	// Authorization check
-	userObj, exists := c.Get("user")
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+	pickups, err := h.appointmentService.GetLinkedPickups(inbound.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	user, ok := userObj.(*models.User)
+	c.JSON(http.StatusOK, gin.H{"pickups": newAppointmentSummaries(pickups)})
+}
+
+// DeclareDelay handles a supplier declaring a delay with a new ETA
+func (h *AppointmentHandler) DeclareDelay(c *gin.Context) {
+	appointment, ok := h.authorizeAppointment(c)
 	if !ok {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user object"})
 		return
 	}
 
-	// Get upcoming appointments
-	appointments, err := h.appointmentService.GetUpcoming(limit)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	var req DeclareDelayRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
 		return
 	}
 
-	// Filter appointments based on user role if not admin
-	// In a real app, this filtering would be done at the service layer
+	user, _ := currentUser(c)
+	result, err := h.appointmentService.DeclareDelay(appointment.ID, service.DelayDeclaration{
+		ETA:            req.ETA,
+		Reason:         req.Reason,
+		AutoReschedule: req.AutoReschedule,
+		DeclaredByID:   user.ID,
+	})
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, service.ErrAppointmentClosed) || errors.Is(err, service.ErrAlreadyCheckedIn) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetDelays handles listing the delays declared for an appointment
+func (h *AppointmentHandler) GetDelays(c *gin.Context) {
+	appointment, ok := h.authorizeAppointment(c)
+	if !ok {
+		return
+	}
+
+	delays, err := h.appointmentService.GetDelays(appointment.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"delays": delays})
+}
+
+// GetDeposit handles getting the deposit that reserves an appointment at an operation charging one
+func (h *AppointmentHandler) GetDeposit(c *gin.Context) {
+	appointment, ok := h.authorizeAppointment(c)
+	if !ok {
+		return
+	}
+
+	deposit, err := h.appointmentService.GetDeposit(appointment.ID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, repository.ErrDepositNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deposit": deposit})
+}
+
+// GetStatusHistory handles listing every status transition of an appointment
+func (h *AppointmentHandler) GetStatusHistory(c *gin.Context) {
+	appointment, ok := h.authorizeAppointment(c)
+	if !ok {
+		return
+	}
+
+	events, err := h.appointmentService.GetStatusHistory(appointment.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"history": events})
+}
+
+// SubmitFeedback handles a supplier or employee rating a completed appointment
+func (h *AppointmentHandler) SubmitFeedback(c *gin.Context) {
+	appointment, ok := h.authorizeAppointment(c)
+	if !ok {
+		return
+	}
+
+	user, _ := currentUser(c)
+
+	var req SubmitFeedbackRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	feedback, err := h.appointmentService.SubmitFeedback(appointment.ID, service.FeedbackSubmission{
+		AuthorID:   user.ID,
+		AuthorRole: user.Role,
+		Rating:     req.Rating,
+		Comment:    req.Comment,
+	})
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, service.ErrFeedbackNotOpen) || errors.Is(err, service.ErrFeedbackAlreadySubmitted) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"feedback": feedback})
+}
+
+// GetFeedback handles listing the feedback left on an appointment
+func (h *AppointmentHandler) GetFeedback(c *gin.Context) {
+	appointment, ok := h.authorizeAppointment(c)
+	if !ok {
+		return
+	}
+
+	feedback, err := h.appointmentService.GetFeedback(appointment.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"feedback": feedback})
+}
+
+// ReportIncident handles dock staff logging a problem on an appointment
+func (h *AppointmentHandler) ReportIncident(c *gin.Context) {
+	appointment, ok := h.authorizeAppointment(c)
+	if !ok {
+		return
+	}
+
+	var req ReportIncidentRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	user, _ := currentUser(c)
+	incident := &models.AppointmentIncident{
+		Category:     req.Category,
+		Severity:     req.Severity,
+		Description:  req.Description,
+		ReportedByID: user.ID,
+		Attachments:  incidentAttachments(req.Attachments),
+	}
+	if err := h.appointmentService.ReportIncident(appointment.ID, incident); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"incident": incident})
+}
+
+// GetIncidents handles listing the incidents logged on an appointment
+func (h *AppointmentHandler) GetIncidents(c *gin.Context) {
+	appointment, ok := h.authorizeAppointment(c)
+	if !ok {
+		return
+	}
+
+	incidents, err := h.appointmentService.GetIncidents(appointment.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"incidents": incidents})
+}
+
+// ResolveIncident handles dock staff closing an incident
+func (h *AppointmentHandler) ResolveIncident(c *gin.Context) {
+	appointment, ok := h.authorizeAppointment(c)
+	if !ok {
+		return
+	}
+	incidentID, err := strconv.ParseUint(c.Param("incident_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid incident ID"})
+		return
+	}
+
+	var req ResolveIncidentRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	user, _ := currentUser(c)
+	incident, err := h.appointmentService.ResolveIncident(appointment.ID, uint(incidentID), user.ID, req.Resolution)
+	if err != nil {
+		c.JSON(incidentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"incident": incident})
+}
+
+// AddIncidentAttachments handles dock staff attaching photos or files to an incident
+func (h *AppointmentHandler) AddIncidentAttachments(c *gin.Context) {
+	appointment, ok := h.authorizeAppointment(c)
+	if !ok {
+		return
+	}
+	incidentID, err := strconv.ParseUint(c.Param("incident_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid incident ID"})
+		return
+	}
+
+	var req AddIncidentAttachmentsRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	incident, err := h.appointmentService.AddIncidentAttachments(appointment.ID, uint(incidentID), incidentAttachments(req.Attachments))
+	if err != nil {
+		c.JSON(incidentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"incident": incident})
+}
+
+// UndoAutoComplete handles staff reopening an appointment the overdue job completed
+func (h *AppointmentHandler) UndoAutoComplete(c *gin.Context) {
+	appointment, ok := h.authorizeAppointment(c)
+	if !ok {
+		return
+	}
+
+	user, _ := currentUser(c)
+
+	reopened, err := h.appointmentService.UndoAutoComplete(appointment.ID, user.ID)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, service.ErrNotAutoCompleted) || errors.Is(err, service.ErrUndoWindowExpired) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"appointment": viewAppointment(h.visibilityService, reopened, user)})
+}
+
+// incidentAttachments converts attachment requests to models
+func incidentAttachments(requests []IncidentAttachmentRequest) []models.IncidentAttachment {
+	attachments := make([]models.IncidentAttachment, 0, len(requests))
+	for _, req := range requests {
+		attachments = append(attachments, models.IncidentAttachment{
+			FileURL:     req.FileURL,
+			ContentType: req.ContentType,
+			Caption:     req.Caption,
+		})
+	}
+	return attachments
+}
+
+// proofOfDelivery converts a proof of delivery request to a model
+func proofOfDelivery(req *ProofOfDeliveryRequest) *models.ProofOfDelivery {
+	return &models.ProofOfDelivery{
+		ReceiverName: req.ReceiverName,
+		SignatureURL: req.SignatureURL,
+		SignedAt:     req.SignedAt,
+		Notes:        req.Notes,
+		Attachments:  proofAttachments(req.Attachments),
+	}
+}
+
+// proofAttachments converts proof of delivery attachment requests to models
+func proofAttachments(requests []ProofAttachmentRequest) []models.ProofOfDeliveryAttachment {
+	attachments := make([]models.ProofOfDeliveryAttachment, 0, len(requests))
+	for _, req := range requests {
+		attachments = append(attachments, models.ProofOfDeliveryAttachment{
+			Kind:        req.Kind,
+			FileURL:     req.FileURL,
+			ContentType: req.ContentType,
+			Caption:     req.Caption,
+		})
+	}
+	return attachments
+}
+
+// proofOfDeliveryErrorStatus maps proof of delivery errors to HTTP statuses
+func proofOfDeliveryErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrProofOfDeliveryNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrProofOfDeliveryExists), errors.Is(err, service.ErrProofRequiresCompletion):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// incidentErrorStatus maps incident errors to HTTP statuses
+func incidentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrIncidentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrIncidentResolved):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// authorizeAppointment loads the appointment from the path and checks the user may manage it.
+// Admins and employees may manage any appointment, suppliers only their own.
+func (h *AppointmentHandler) authorizeAppointment(c *gin.Context) (*models.Appointment, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid appointment ID"})
+		return nil, false
+	}
+
+	user, ok := currentUser(c)
+	if !ok {
+		return nil, false
+	}
+
+	appointment, err := h.appointmentService.GetByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	if user.Role == "supplier" && appointment.Supplier.UserID != user.ID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to manage this appointment"})
+		return nil, false
+	}
+
+	return appointment, true
+}
+
+// GetByBookingCode handles looking up an appointment by its booking code
+func (h *AppointmentHandler) GetByBookingCode(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	appointment, err := h.appointmentService.GetByBookingCode(c.Param("code"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Suppliers only see their own bookings; answer as if the code did not exist
+	if user.Role == "supplier" && appointment.Supplier.UserID != user.ID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "appointment not found"})
+		return
+	}
+
+	respond(c, http.StatusOK, &AppointmentDetailResponse{Appointment: viewAppointment(h.visibilityService, appointment, user)})
+}
+
+// GetBySupplier handles getting appointments for a specific supplier
+func (h *AppointmentHandler) GetBySupplier(c *gin.Context) {
+	// Parse supplier ID from path
+	idStr := c.Param("supplier_id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid supplier ID"})
+		return
+	}
+
+	// Get filters from query parameters
+	filters := GetAppointmentFilters(c)
+
+	// Authorization check
+	userObj, exists := c.Get("user")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	user, ok := userObj.(*models.User)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user object"})
+		return
+	}
+
+	// Only admins and the supplier themselves can view their appointments
+	if user.Role != "admin" {
+		if user.Role == "supplier" {
+			// In a real app, you'd fetch supplier ID for this user
+			var supplierID uint = 0
+			if supplierID != 0 && supplierID != uint(id) {
+				c.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to view these appointments"})
+				return
+			}
+		} else {
+			// Employees can view appointments for any supplier
+			// This might be adjusted based on business rules
+		}
+	}
+
+	// Get appointments for the supplier
+	appointments, total, err := h.appointmentService.GetBySupplier(uint(id), filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respond(c, http.StatusOK, newAppointmentList(appointments, total, filters))
+}
+
+// GetByEmployee handles getting appointments for a specific employee
+func (h *AppointmentHandler) GetByEmployee(c *gin.Context) {
+	// Parse employee ID from path
+	idStr := c.Param("employee_id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
+		return
+	}
+
+	// Get filters from query parameters
+	filters := GetAppointmentFilters(c)
+
+	// Authorization check
+	userObj, exists := c.Get("user")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	user, ok := userObj.(*models.User)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user object"})
+		return
+	}
+
+	// Only admins and the employee themselves can view their appointments
+	if user.Role != "admin" {
+		if user.Role == "employee" {
+			// In a real app, you'd fetch employee ID for this user
+			var employeeID uint = 0
+			if employeeID != 0 && employeeID != uint(id) {
+				c.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to view these appointments"})
+				return
+			}
+		} else if user.Role == "supplier" {
+			// Suppliers can only view appointments they're involved in
+			// This filtering should ideally happen at the service level
+		}
+	}
+
+	// Get appointments for the employee
+	appointments, total, err := h.appointmentService.GetByEmployee(uint(id), filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respond(c, http.StatusOK, newAppointmentList(appointments, total, filters))
+}
+
+// GetByOperation handles getting appointments for a specific operation
+func (h *AppointmentHandler) GetByOperation(c *gin.Context) {
+	// Parse operation ID from path
+	idStr := c.Param("operation_id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+		return
+	}
+
+	// Get filters from query parameters
+	filters := GetAppointmentFilters(c)
+
+	// Authorization check
+	userObj, exists := c.Get("user")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	user, ok := userObj.(*models.User)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user object"})
+		return
+	}
+
+	// Admins can view all operations
+	// Employees can view operations they're assigned to
+	// Suppliers can view operations they're delivering to
+	if user.Role != "admin" {
+		// In a real app, you would check if the user has access to this operation
+		// This might involve checking if an employee belongs to this operation
+		// or if a supplier has any appointments at this operation
+	}
+
+	// Get appointments for the operation
+	appointments, total, err := h.appointmentService.GetByOperation(uint(id), filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	respond(c, http.StatusOK, newAppointmentList(appointments, total, filters))
+}
+
+// GetByDateRange handles getting appointments within a date range
+func (h *AppointmentHandler) GetByDateRange(c *gin.Context) {
+	// Parse date range parameters
+	startDateStr := c.Query("start_date")
+	if startDateStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "start_date is required"})
+		return
+	}
+
+	endDateStr := c.Query("end_date")
+	if endDateStr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "end_date is required"})
+		return
+	}
+
+	startDate, err := time.Parse(time.RFC3339, startDateStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start_date format. Use RFC3339 format (e.g., 2025-05-23T10:00:00Z)"})
+		return
+	}
+
+	endDate, err := time.Parse(time.RFC3339, endDateStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end_date format. Use RFC3339 format (e.g., 2025-05-23T18:00:00Z)"})
+		return
+	}
+
+	if startDate.After(endDate) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "start_date must be before end_date"})
+		return
+	}
+
+	// Get filters from query parameters
+	filters := GetAppointmentFilters(c)
+
+	// Authorization check
+	userObj, exists := c.Get("user")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	user, ok := userObj.(*models.User)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user object"})
+		return
+	}
+
+	// For non-admin users, we might want to limit the date range to prevent too many results
 	if user.Role != "admin" {
-		// Just a placeholder for role-based filtering
+		// Limit to a maximum of 31 days for non-admin users
+		maxDateRange := 31 * 24 * time.Hour
+		if endDate.Sub(startDate) > maxDateRange {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Date range cannot exceed 31 days for non-admin users"})
+			return
+		}
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"appointments": appointments,
-		"count":        len(appointments),
-	})
+	// Get appointments within the date range
+	appointments, total, err := h.appointmentService.GetByDateRange(startDate, endDate, filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	list := newAppointmentList(appointments, total, filters)
+	list.StartDate, list.EndDate = &startDate, &endDate
+	respond(c, http.StatusOK, list)
 }
 
-// GetStatistics handles getting appointment statistics
-func (h *AppointmentHandler) GetStatistics(c *gin.Context) {
-	// Authorization check - only admins can view statistics
+// GetUpcoming handles getting upcoming appointments
+func (h *AppointmentHandler) GetUpcoming(c *gin.Context) {
+	// Parse limit parameter
+	limitStr := c.DefaultQuery("limit", "10")
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit <= 0 {
+		limit = 10 // Default to 10 if invalid
+	}
+
+	// Authorization check
 	userObj, exists := c.Get("user")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
@@ -757,8 +1493,70 @@ func (h *AppointmentHandler) GetStatistics(c *gin.Context) {
 		return
 	}
 
+	// For one operation, also count what is left of today in the operation's timezone
+	if operationIDStr := c.Query("operation_id"); operationIDStr != "" {
+		operationID, err := strconv.ParseUint(operationIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+			return
+		}
+		upcoming, err := h.appointmentService.GetOperationUpcoming(uint(operationID), limit)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"appointments": newAppointmentSummaries(upcoming.Appointments),
+			"count":        len(upcoming.Appointments),
+			"today":        upcoming.Today,
+			"today_count":  upcoming.TodayCount,
+		})
+		return
+	}
+
+	// Get upcoming appointments
+	appointments, err := h.appointmentService.GetUpcoming(limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Filter appointments based on user role if not admin
+	// In a real app, this filtering would be done at the service layer
 	if user.Role != "admin" {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Only administrators can view statistics"})
+		// Just a placeholder for role-based filtering
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"appointments": newAppointmentSummaries(appointments),
+		"count":        len(appointments),
+	})
+}
+
+// GetStatistics handles getting appointment statistics
+func (h *AppointmentHandler) GetStatistics(c *gin.Context) {
+	// For one operation, count its last days in the operation's timezone
+	if operationIDStr := c.Query("operation_id"); operationIDStr != "" {
+		operationID, err := strconv.ParseUint(operationIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+			return
+		}
+		days, err := strconv.Atoi(c.DefaultQuery("days", "7"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid days"})
+			return
+		}
+		statistics, err := h.appointmentService.GetOperationStatistics(uint(operationID), days)
+		if err != nil {
+			status := http.StatusNotFound
+			if errors.Is(err, service.ErrInvalidStatisticsDays) {
+				status = http.StatusBadRequest
+			}
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"statistics": statistics})
 		return
 	}
 
@@ -772,10 +1570,272 @@ func (h *AppointmentHandler) GetStatistics(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"statistics": statistics})
 }
 
+// GetDeliveryReport handles comparing delivered and scheduled quantities per supplier and product
+func (h *AppointmentHandler) GetDeliveryReport(c *gin.Context) {
+	filters := repository.DeliveryReportFilters{}
+
+	if operationIDStr := c.Query("operation_id"); operationIDStr != "" {
+		operationID, err := strconv.ParseUint(operationIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+			return
+		}
+		id := uint(operationID)
+		filters.OperationID = &id
+	}
+	if supplierIDStr := c.Query("supplier_id"); supplierIDStr != "" {
+		supplierID, err := strconv.ParseUint(supplierIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid supplier ID"})
+			return
+		}
+		id := uint(supplierID)
+		filters.SupplierID = &id
+	}
+	if startDateStr := c.Query("start_date"); startDateStr != "" {
+		if startDate, err := time.Parse(time.RFC3339, startDateStr); err == nil {
+			filters.StartDate = &startDate
+		}
+	}
+	if endDateStr := c.Query("end_date"); endDateStr != "" {
+		if endDate, err := time.Parse(time.RFC3339, endDateStr); err == nil {
+			filters.EndDate = &endDate
+		}
+	}
+
+	report, err := h.appointmentService.GetDeliveryReport(filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deliveries": report})
+}
+
+// GetFeedbackScores handles aggregating appointment feedback per operation
+func (h *AppointmentHandler) GetFeedbackScores(c *gin.Context) {
+	filters := repository.FeedbackFilters{}
+
+	if operationIDStr := c.Query("operation_id"); operationIDStr != "" {
+		operationID, err := strconv.ParseUint(operationIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+			return
+		}
+		id := uint(operationID)
+		filters.OperationID = &id
+	}
+	if startDateStr := c.Query("start_date"); startDateStr != "" {
+		if startDate, err := time.Parse(time.RFC3339, startDateStr); err == nil {
+			filters.StartDate = &startDate
+		}
+	}
+	if endDateStr := c.Query("end_date"); endDateStr != "" {
+		if endDate, err := time.Parse(time.RFC3339, endDateStr); err == nil {
+			filters.EndDate = &endDate
+		}
+	}
+
+	scores, err := h.appointmentService.GetFeedbackScores(filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"operations": scores})
+}
+
+// GetSupplierPerformance handles summarizing appointments, delays and incidents per supplier
+func (h *AppointmentHandler) GetSupplierPerformance(c *gin.Context) {
+	filters := repository.DeliveryReportFilters{}
+
+	if operationIDStr := c.Query("operation_id"); operationIDStr != "" {
+		operationID, err := strconv.ParseUint(operationIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+			return
+		}
+		id := uint(operationID)
+		filters.OperationID = &id
+	}
+	if supplierIDStr := c.Query("supplier_id"); supplierIDStr != "" {
+		supplierID, err := strconv.ParseUint(supplierIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid supplier ID"})
+			return
+		}
+		id := uint(supplierID)
+		filters.SupplierID = &id
+	}
+	if startDateStr := c.Query("start_date"); startDateStr != "" {
+		if startDate, err := time.Parse(time.RFC3339, startDateStr); err == nil {
+			filters.StartDate = &startDate
+		}
+	}
+	if endDateStr := c.Query("end_date"); endDateStr != "" {
+		if endDate, err := time.Parse(time.RFC3339, endDateStr); err == nil {
+			filters.EndDate = &endDate
+		}
+	}
+
+	performance, err := h.appointmentService.GetSupplierPerformance(filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"suppliers": performance})
+}
+
+// GetStatusTransitions handles counting the status transitions made in a period
+func (h *AppointmentHandler) GetStatusTransitions(c *gin.Context) {
+	filters := repository.DeliveryReportFilters{}
+
+	if operationIDStr := c.Query("operation_id"); operationIDStr != "" {
+		operationID, err := strconv.ParseUint(operationIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+			return
+		}
+		id := uint(operationID)
+		filters.OperationID = &id
+	}
+	if supplierIDStr := c.Query("supplier_id"); supplierIDStr != "" {
+		supplierID, err := strconv.ParseUint(supplierIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid supplier ID"})
+			return
+		}
+		id := uint(supplierID)
+		filters.SupplierID = &id
+	}
+	if startDateStr := c.Query("start_date"); startDateStr != "" {
+		if startDate, err := time.Parse(time.RFC3339, startDateStr); err == nil {
+			filters.StartDate = &startDate
+		}
+	}
+	if endDateStr := c.Query("end_date"); endDateStr != "" {
+		if endDate, err := time.Parse(time.RFC3339, endDateStr); err == nil {
+			filters.EndDate = &endDate
+		}
+	}
+
+	transitions, err := h.appointmentService.GetStatusTransitions(filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"transitions": transitions})
+}
+
+// GetDenials handles counting the bookings refused for lack of room, grouped by the dimensions
+// listed in group_by, e.g. "operation,hour" for the time bands turning suppliers away
+func (h *AppointmentHandler) GetDenials(c *gin.Context) {
+	filters := repository.DenialFilters{
+		Reason: models.DenialReason(c.Query("reason")),
+	}
+
+	if operationIDStr := c.Query("operation_id"); operationIDStr != "" {
+		operationID, err := strconv.ParseUint(operationIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+			return
+		}
+		id := uint(operationID)
+		filters.OperationID = &id
+	}
+	if supplierIDStr := c.Query("supplier_id"); supplierIDStr != "" {
+		supplierID, err := strconv.ParseUint(supplierIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid supplier ID"})
+			return
+		}
+		id := uint(supplierID)
+		filters.SupplierID = &id
+	}
+	if startDateStr := c.Query("start_date"); startDateStr != "" {
+		if startDate, err := time.Parse(time.RFC3339, startDateStr); err == nil {
+			filters.StartDate = &startDate
+		}
+	}
+	if endDateStr := c.Query("end_date"); endDateStr != "" {
+		if endDate, err := time.Parse(time.RFC3339, endDateStr); err == nil {
+			filters.EndDate = &endDate
+		}
+	}
+	for _, group := range strings.Split(c.DefaultQuery("group_by", "operation,reason"), ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			filters.GroupBy = append(filters.GroupBy, group)
+		}
+	}
+	filters.Limit, _ = strconv.Atoi(c.Query("limit"))
+
+	denials, err := h.appointmentService.GetDenials(filters)
+	if err != nil {
+		if errors.Is(err, service.ErrDenialGroup) || errors.Is(err, service.ErrDenialReason) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"denials": denials})
+}
+
+// GetTypeCapacities handles getting the appointment type capacity rules of an operation
+func (h *AppointmentHandler) GetTypeCapacities(c *gin.Context) {
+	operationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+		return
+	}
+
+	capacities, err := h.appointmentService.GetTypeCapacities(uint(operationID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"capacities": capacities})
+}
+
+// SetTypeCapacities handles replacing the appointment type capacity rules of an operation
+func (h *AppointmentHandler) SetTypeCapacities(c *gin.Context) {
+	operationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+		return
+	}
+
+	var req TypeCapacitiesRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	capacities := make([]models.AppointmentTypeCapacity, 0, len(req.Capacities))
+	for _, capacity := range req.Capacities {
+		capacities = append(capacities, models.AppointmentTypeCapacity{
+			Type:          capacity.Type,
+			MaxConcurrent: capacity.MaxConcurrent,
+			MaxPerDay:     capacity.MaxPerDay,
+		})
+	}
+
+	if err := h.appointmentService.SetTypeCapacities(uint(operationID), capacities); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"capacities": capacities})
+}
+
 // CheckAvailability handles checking if a time slot is available
 func (h *AppointmentHandler) CheckAvailability(c *gin.Context) {
 	var req CheckAvailabilityRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := middleware.BindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
 		return
 	}
@@ -792,12 +1852,6 @@ func (h *AppointmentHandler) CheckAvailability(c *gin.Context) {
 		return
 	}
 
-	// Check if the start time is in the future
-	if req.ScheduledStart.Before(time.Now()) {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Appointment must be scheduled for a future date"})
-		return
-	}
-
 	// Check availability
 	available, err := h.appointmentService.CheckAvailability(
 		req.OperationID,
@@ -810,12 +1864,42 @@ func (h *AppointmentHandler) CheckAvailability(c *gin.Context) {
 		return
 	}
 
+	// A free employee without the skills the operation and product require doesn't match
+	missing, err := h.appointmentService.MissingSkills(req.EmployeeID, req.OperationID, req.ProductID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Meetings in the employee's connected work calendar make them unavailable too
+	busy, err := h.availabilityService.CalendarBusy(req.EmployeeID, req.ScheduledStart, req.ScheduledEnd)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Limits the slot would leave almost full, so tight days show before booking
+	warnings, err := h.appointmentService.CapacityWarnings(&models.Appointment{
+		OperationID:    req.OperationID,
+		EmployeeID:     req.EmployeeID,
+		Type:           req.Type,
+		ScheduledStart: req.ScheduledStart,
+		ScheduledEnd:   req.ScheduledEnd,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"available":       available,
-		"scheduled_start": req.ScheduledStart,
-		"scheduled_end":   req.ScheduledEnd,
-		"operation_id":    req.OperationID,
-		"employee_id":     req.EmployeeID,
+		"available":         available && len(missing) == 0 && len(busy) == 0,
+		"missing_skills":    missing,
+		"calendar_busy":     busy,
+		"capacity_warnings": warnings,
+		"scheduled_start":   req.ScheduledStart,
+		"scheduled_end":     req.ScheduledEnd,
+		"operation_id":      req.OperationID,
+		"employee_id":       req.EmployeeID,
 	})
 }
 
@@ -838,8 +1922,8 @@ func hasStatusChangePermission(user *models.User, appointment *models.Appointmen
             return user.ID == appointment.SupplierID
         }
     case models.StatusConfirmed:
-        // Confirmed can be completed by employee or cancelled/rescheduled by supplier
-        if newStatus == models.StatusCompleted && user.Role == "employee" {
+        // Confirmed can be completed or marked no-show by employee or cancelled/rescheduled by supplier
+        if (newStatus == models.StatusCompleted || newStatus == models.StatusNoShow) && user.Role == "employee" {
             return user.ID == appointment.EmployeeID
         }
         if (newStatus == models.StatusCancelled || newStatus == models.StatusRescheduled) && user.Role == "supplier" {
@@ -848,9 +1932,14 @@ func hasStatusChangePermission(user *models.User, appointment *models.Appointmen
     case models.StatusCancelled:
         // Cancelled appointments cannot transition to any other status
         return false
-    case models.StatusCompleted:
-        // Completed appointments cannot transition to any other status
+    case models.StatusCompleted, models.StatusPartiallyCompleted, models.StatusNoShow:
+        // Completed and no-show appointments cannot transition to any other status
         return false
+    case models.StatusReserved:
+        // Reserved appointments wait for their deposit, but the supplier may cancel them
+        if newStatus == models.StatusCancelled && user.Role == "supplier" {
+            return user.ID == appointment.SupplierID
+        }
     case models.StatusRescheduled:
         // Rescheduled appointments can only go back to pending
         if newStatus == models.StatusPending && user.Role == "supplier" {
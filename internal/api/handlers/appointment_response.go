@@ -0,0 +1,480 @@
+package handlers
+
+import (
+	"encoding/xml"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/api/render"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+	"github.com/shopspring/decimal"
+)
+
+// ContactResponse is how to reach the person behind a supplier or employee
+type ContactResponse struct {
+	Name  string `json:"name" xml:"name"`
+	Email string `json:"email,omitempty" xml:"email,omitempty"`
+	Phone string `json:"phone,omitempty" xml:"phone,omitempty"`
+}
+
+// SupplierResponse is the supplier of an appointment
+type SupplierResponse struct {
+	ID          uint             `json:"id" xml:"id"`
+	CompanyName string           `json:"company_name" xml:"company_name"`
+	CNPJ        string           `json:"cnpj,omitempty" xml:"cnpj,omitempty"`
+	Category    string           `json:"category,omitempty" xml:"category,omitempty"`
+	Contact     *ContactResponse `json:"contact,omitempty" xml:"contact,omitempty"`
+}
+
+// EmployeeResponse is the employee receiving an appointment
+type EmployeeResponse struct {
+	ID         uint             `json:"id" xml:"id"`
+	Department string           `json:"department,omitempty" xml:"department,omitempty"`
+	Position   string           `json:"position,omitempty" xml:"position,omitempty"`
+	Contact    *ContactResponse `json:"contact,omitempty" xml:"contact,omitempty"`
+}
+
+// OperationResponse is the operation an appointment takes place at
+type OperationResponse struct {
+	ID       uint   `json:"id" xml:"id"`
+	Code     string `json:"code" xml:"code"`
+	Name     string `json:"name" xml:"name"`
+	Address  string `json:"address,omitempty" xml:"address,omitempty"`
+	City     string `json:"city,omitempty" xml:"city,omitempty"`
+	State    string `json:"state,omitempty" xml:"state,omitempty"`
+	Timezone string `json:"timezone,omitempty" xml:"timezone,omitempty"`
+}
+
+// ProductResponse is the product an appointment moves
+type ProductResponse struct {
+	ID       uint             `json:"id" xml:"id"`
+	Name     string           `json:"name" xml:"name"`
+	SKU      string           `json:"sku" xml:"sku"`
+	Price    *decimal.Decimal `json:"price,omitempty" xml:"price,omitempty"`
+	Currency string           `json:"currency,omitempty" xml:"currency,omitempty"`
+}
+
+// AppointmentSummaryResponse is an appointment in a list: what it is, when, and who is involved,
+// without contact details
+type AppointmentSummaryResponse struct {
+	ID                uint                     `json:"id" xml:"id"`
+	BookingCode       string                   `json:"booking_code" xml:"booking_code"`
+	Type              models.AppointmentType   `json:"type" xml:"type"`
+	Status            models.AppointmentStatus `json:"status" xml:"status"`
+	ScheduledStart    time.Time                `json:"scheduled_start" xml:"scheduled_start"`
+	ScheduledEnd      time.Time                `json:"scheduled_end" xml:"scheduled_end"`
+	QuantityToDeliver int                      `json:"quantity_to_deliver" xml:"quantity_to_deliver"`
+	SupplierID        uint                     `json:"supplier_id" xml:"supplier_id"`
+	Supplier          *SupplierResponse        `json:"supplier,omitempty" xml:"supplier,omitempty"`
+	EmployeeID        uint                     `json:"employee_id" xml:"employee_id"`
+	Employee          *EmployeeResponse        `json:"employee,omitempty" xml:"employee,omitempty"`
+	OperationID       uint                     `json:"operation_id" xml:"operation_id"`
+	Operation         *OperationResponse       `json:"operation,omitempty" xml:"operation,omitempty"`
+	ProductID         *uint                    `json:"product_id,omitempty" xml:"product_id,omitempty"`
+	Product           *ProductResponse         `json:"product,omitempty" xml:"product,omitempty"`
+}
+
+// AppointmentResponse is a single appointment with its timeline, the contacts of the people
+// involved and the product price, as far as the visibility policy lets the viewer see them
+type AppointmentResponse struct {
+	AppointmentSummaryResponse
+	Notes              string     `json:"notes" xml:"notes"`
+	ReceivedQuantity   *int       `json:"received_quantity" xml:"received_quantity"`
+	ConfirmedAt        *time.Time `json:"confirmed_at" xml:"confirmed_at"`
+	CancelledAt        *time.Time `json:"cancelled_at" xml:"cancelled_at"`
+	CompletedAt        *time.Time `json:"completed_at" xml:"completed_at"`
+	CancellationReason string     `json:"cancellation_reason" xml:"cancellation_reason"`
+	CheckedInAt        *time.Time `json:"checked_in_at" xml:"checked_in_at"`
+	FollowUpOfID       *uint      `json:"follow_up_of_id,omitempty" xml:"follow_up_of_id,omitempty"`
+	LinkedInboundID    *uint      `json:"linked_inbound_id,omitempty" xml:"linked_inbound_id,omitempty"`
+	EstimatedArrival   *time.Time `json:"estimated_arrival" xml:"estimated_arrival"`
+	AutoCompletedAt    *time.Time `json:"auto_completed_at" xml:"auto_completed_at"`
+	OverdueFlaggedAt   *time.Time `json:"overdue_flagged_at" xml:"overdue_flagged_at"`
+	CostCenter         string     `json:"cost_center,omitempty" xml:"cost_center,omitempty"`
+	BillingCode        string     `json:"billing_code,omitempty" xml:"billing_code,omitempty"`
+	ExternalRef        string     `json:"external_ref,omitempty" xml:"external_ref,omitempty"`
+	PurchaseOrder      string     `json:"purchase_order,omitempty" xml:"purchase_order,omitempty"`
+	CreatedAt          time.Time  `json:"created_at" xml:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at" xml:"updated_at"`
+}
+
+// AppointmentLabels are the status, type and schedule of an appointment in the request's
+// language and timezone
+type AppointmentLabels struct {
+	Status    string `json:"status" xml:"status"`
+	Type      string `json:"type" xml:"type"`
+	Scheduled string `json:"scheduled" xml:"scheduled"`
+}
+
+// AppointmentDetailResponse is the response for a single appointment
+type AppointmentDetailResponse struct {
+	XMLName     xml.Name             `json:"-" xml:"appointment_detail"`
+	Appointment *AppointmentResponse `json:"appointment" xml:"appointment"`
+	Labels      *AppointmentLabels   `json:"labels,omitempty" xml:"labels,omitempty"`
+}
+
+// AppointmentListResponse is the response for a page of appointments
+type AppointmentListResponse struct {
+	XMLName      xml.Name                     `json:"-" xml:"appointments"`
+	Appointments []AppointmentSummaryResponse `json:"appointments" xml:"appointment"`
+	Total        int64                        `json:"total" xml:"total,attr"`
+	Page         int                          `json:"page" xml:"page,attr"`
+	Limit        int                          `json:"limit" xml:"limit,attr"`
+	TotalPages   int64                        `json:"total_pages" xml:"total_pages,attr"`
+	StartDate    *time.Time                   `json:"start_date,omitempty" xml:"start_date,attr,omitempty"`
+	EndDate      *time.Time                   `json:"end_date,omitempty" xml:"end_date,attr,omitempty"`
+}
+
+// newAppointmentList maps a page of appointments for a list response
+func newAppointmentList(appointments []models.Appointment, total int64, filters repository.AppointmentFilters) *AppointmentListResponse {
+	return &AppointmentListResponse{
+		Appointments: newAppointmentSummaries(appointments),
+		Total:        total,
+		Page:         filters.Page,
+		Limit:        filters.Limit,
+		TotalPages:   (total + int64(filters.Limit) - 1) / int64(filters.Limit),
+	}
+}
+
+// appointmentSummaryColumns are the CSV columns of an appointment in a list
+var appointmentSummaryColumns = []string{
+	"id", "booking_code", "type", "status", "scheduled_start", "scheduled_end", "quantity_to_deliver",
+	"supplier_id", "supplier_name", "employee_id", "employee_name", "operation_id", "operation_code",
+	"product_id", "product_sku",
+}
+
+// appointmentDetailColumns are the CSV columns a single appointment adds to the list ones
+var appointmentDetailColumns = []string{
+	"notes", "received_quantity", "confirmed_at", "cancelled_at", "completed_at", "cancellation_reason",
+	"checked_in_at", "estimated_arrival", "cost_center", "billing_code", "external_ref", "purchase_order",
+	"created_at", "updated_at",
+}
+
+// Header returns the CSV columns of the list
+func (r *AppointmentListResponse) Header() []string {
+	return appointmentSummaryColumns
+}
+
+// Records returns a CSV row per appointment of the page
+func (r *AppointmentListResponse) Records() [][]string {
+	records := make([][]string, 0, len(r.Appointments))
+	for i := range r.Appointments {
+		records = append(records, r.Appointments[i].record())
+	}
+	return records
+}
+
+// Header returns the CSV columns of the appointment
+func (r *AppointmentDetailResponse) Header() []string {
+	return append(append([]string{}, appointmentSummaryColumns...), appointmentDetailColumns...)
+}
+
+// Records returns the appointment as a single CSV row
+func (r *AppointmentDetailResponse) Records() [][]string {
+	if r.Appointment == nil {
+		return nil
+	}
+	a := r.Appointment
+	record := append(a.AppointmentSummaryResponse.record(),
+		a.Notes,
+		csvInt(a.ReceivedQuantity),
+		csvTime(a.ConfirmedAt),
+		csvTime(a.CancelledAt),
+		csvTime(a.CompletedAt),
+		a.CancellationReason,
+		csvTime(a.CheckedInAt),
+		csvTime(a.EstimatedArrival),
+		a.CostCenter,
+		a.BillingCode,
+		a.ExternalRef,
+		a.PurchaseOrder,
+		a.CreatedAt.Format(time.RFC3339),
+		a.UpdatedAt.Format(time.RFC3339),
+	)
+	return [][]string{record}
+}
+
+// Record returns the CSV row of an appointment in an export
+func (r *AppointmentSummaryResponse) Record() []string {
+	return r.record()
+}
+
+// appointmentExport streams the appointments matching the filters as they would be listed, with
+// times in a timezone
+type appointmentExport struct {
+	appointments service.AppointmentService
+	filters      repository.AppointmentFilters
+	location     *time.Location
+}
+
+// Header returns the CSV columns of the export, the same as a list's
+func (e *appointmentExport) Header() []string {
+	return appointmentSummaryColumns
+}
+
+// Each calls fn with each batch of appointments read for the export
+func (e *appointmentExport) Each(fn func(rows []render.Row) error) error {
+	return e.appointments.Export(e.filters, func(appointments []models.Appointment) error {
+		rows := make([]render.Row, 0, len(appointments))
+		for i := range appointments {
+			summary := newAppointmentSummary(&appointments[i])
+			summary.localize(e.location)
+			rows = append(rows, &summary)
+		}
+		return fn(rows)
+	})
+}
+
+// record returns the CSV row of an appointment in a list
+func (r *AppointmentSummaryResponse) record() []string {
+	var supplierName, employeeName, operationCode, productSKU string
+	if r.Supplier != nil {
+		supplierName = r.Supplier.CompanyName
+	}
+	if r.Employee != nil && r.Employee.Contact != nil {
+		employeeName = r.Employee.Contact.Name
+	}
+	if r.Operation != nil {
+		operationCode = r.Operation.Code
+	}
+	if r.Product != nil {
+		productSKU = r.Product.SKU
+	}
+	var productID string
+	if r.ProductID != nil {
+		productID = strconv.FormatUint(uint64(*r.ProductID), 10)
+	}
+
+	return []string{
+		strconv.FormatUint(uint64(r.ID), 10),
+		r.BookingCode,
+		string(r.Type),
+		string(r.Status),
+		r.ScheduledStart.Format(time.RFC3339),
+		r.ScheduledEnd.Format(time.RFC3339),
+		strconv.Itoa(r.QuantityToDeliver),
+		strconv.FormatUint(uint64(r.SupplierID), 10),
+		supplierName,
+		strconv.FormatUint(uint64(r.EmployeeID), 10),
+		employeeName,
+		strconv.FormatUint(uint64(r.OperationID), 10),
+		operationCode,
+		productID,
+		productSKU,
+	}
+}
+
+// localize converts the times of the list to a timezone
+func (r *AppointmentListResponse) localize(location *time.Location) {
+	for i := range r.Appointments {
+		r.Appointments[i].localize(location)
+	}
+	r.StartDate = localTime(r.StartDate, location)
+	r.EndDate = localTime(r.EndDate, location)
+}
+
+// localize converts the times of the appointment to a timezone
+func (r *AppointmentDetailResponse) localize(location *time.Location) {
+	if r.Appointment == nil {
+		return
+	}
+	a := r.Appointment
+	a.AppointmentSummaryResponse.localize(location)
+	a.ConfirmedAt = localTime(a.ConfirmedAt, location)
+	a.CancelledAt = localTime(a.CancelledAt, location)
+	a.CompletedAt = localTime(a.CompletedAt, location)
+	a.CheckedInAt = localTime(a.CheckedInAt, location)
+	a.EstimatedArrival = localTime(a.EstimatedArrival, location)
+	a.AutoCompletedAt = localTime(a.AutoCompletedAt, location)
+	a.OverdueFlaggedAt = localTime(a.OverdueFlaggedAt, location)
+	a.CreatedAt = a.CreatedAt.In(location)
+	a.UpdatedAt = a.UpdatedAt.In(location)
+}
+
+// localize converts the times of an appointment in a list to a timezone
+func (r *AppointmentSummaryResponse) localize(location *time.Location) {
+	r.ScheduledStart = r.ScheduledStart.In(location)
+	r.ScheduledEnd = r.ScheduledEnd.In(location)
+}
+
+// localTime converts an optional time to a timezone. The result is a copy, since the time may
+// be shared with the model it was mapped from.
+func localTime(t *time.Time, location *time.Location) *time.Time {
+	if t == nil {
+		return nil
+	}
+	local := t.In(location)
+	return &local
+}
+
+// csvTime formats an optional time for a CSV cell, empty when unset
+func csvTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// csvInt formats an optional number for a CSV cell, empty when unset
+func csvInt(n *int) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.Itoa(*n)
+}
+
+// newAppointmentSummaries maps a page of appointments for a list
+func newAppointmentSummaries(appointments []models.Appointment) []AppointmentSummaryResponse {
+	summaries := make([]AppointmentSummaryResponse, 0, len(appointments))
+	for i := range appointments {
+		summaries = append(summaries, newAppointmentSummary(&appointments[i]))
+	}
+	return summaries
+}
+
+// newAppointmentSummary maps an appointment for a list
+func newAppointmentSummary(appointment *models.Appointment) AppointmentSummaryResponse {
+	summary := AppointmentSummaryResponse{
+		ID:                appointment.ID,
+		BookingCode:       appointment.BookingCode,
+		Type:              appointment.Type,
+		Status:            appointment.Status,
+		ScheduledStart:    appointment.ScheduledStart,
+		ScheduledEnd:      appointment.ScheduledEnd,
+		QuantityToDeliver: appointment.QuantityToDeliver,
+		SupplierID:        appointment.SupplierID,
+		EmployeeID:        appointment.EmployeeID,
+		OperationID:       appointment.OperationID,
+		ProductID:         appointment.ProductID,
+	}
+
+	// Nested entities are only included when they were loaded
+	if appointment.Supplier.ID != 0 {
+		summary.Supplier = &SupplierResponse{ID: appointment.Supplier.ID, CompanyName: appointment.Supplier.CompanyName}
+	}
+	if appointment.Employee.ID != 0 {
+		summary.Employee = &EmployeeResponse{ID: appointment.Employee.ID}
+		if appointment.Employee.User.ID != 0 {
+			summary.Employee.Contact = &ContactResponse{Name: appointment.Employee.User.Name}
+		}
+	}
+	if appointment.Operation.ID != 0 {
+		summary.Operation = &OperationResponse{
+			ID:   appointment.Operation.ID,
+			Code: appointment.Operation.Code,
+			Name: appointment.Operation.Name,
+		}
+	}
+	if appointment.Product.ID != 0 {
+		summary.Product = &ProductResponse{
+			ID:   appointment.Product.ID,
+			Name: appointment.Product.Name,
+			SKU:  appointment.Product.SKU,
+		}
+	}
+	return summary
+}
+
+// viewAppointment maps an appointment for the viewer under the visibility policy of its
+// operation. The default policy applies when the operation's policy can't be loaded.
+func viewAppointment(visibility service.VisibilityService, appointment *models.Appointment, viewer *models.User) *AppointmentResponse {
+	if appointment == nil {
+		return nil
+	}
+
+	var policy *service.VisibilityPolicy
+	if visibility != nil {
+		loaded, err := visibility.PolicyFor(appointment.OperationID)
+		if err != nil {
+			log.Printf("Failed to load the visibility policy of operation %d: %v", appointment.OperationID, err)
+		} else {
+			policy = loaded
+		}
+	}
+	return newAppointmentResponse(appointment, viewer, policy)
+}
+
+// newAppointmentResponse maps an appointment for the viewer, leaving out the fields the policy
+// hides from their role. Without a viewer the supplier view is used.
+func newAppointmentResponse(appointment *models.Appointment, viewer *models.User, policy *service.VisibilityPolicy) *AppointmentResponse {
+	role := "supplier"
+	if viewer != nil {
+		role = viewer.Role
+	}
+	hidden := func(field string) bool {
+		return policy.Hidden(role, field)
+	}
+
+	response := &AppointmentResponse{
+		AppointmentSummaryResponse: newAppointmentSummary(appointment),
+		Notes:                      appointment.Notes,
+		ReceivedQuantity:           appointment.ReceivedQuantity,
+		ConfirmedAt:                appointment.ConfirmedAt,
+		CancelledAt:                appointment.CancelledAt,
+		CompletedAt:                appointment.CompletedAt,
+		CancellationReason:         appointment.CancellationReason,
+		CheckedInAt:                appointment.CheckedInAt,
+		FollowUpOfID:               appointment.FollowUpOfID,
+		LinkedInboundID:            appointment.LinkedInboundID,
+		EstimatedArrival:           appointment.EstimatedArrival,
+		AutoCompletedAt:            appointment.AutoCompletedAt,
+		OverdueFlaggedAt:           appointment.OverdueFlaggedAt,
+		ExternalRef:                appointment.ExternalRef,
+		PurchaseOrder:              appointment.PurchaseOrder,
+		CreatedAt:                  appointment.CreatedAt,
+		UpdatedAt:                  appointment.UpdatedAt,
+	}
+
+	if supplier := response.Supplier; supplier != nil {
+		if !hidden(models.FieldSupplierCNPJ) {
+			supplier.CNPJ = appointment.Supplier.CNPJ
+		}
+		supplier.Category = appointment.Supplier.Category
+		if appointment.Supplier.User.ID != 0 {
+			supplier.Contact = newContactResponse(&appointment.Supplier.User)
+			if hidden(models.FieldSupplierEmail) {
+				supplier.Contact.Email = ""
+			}
+			if hidden(models.FieldSupplierPhone) {
+				supplier.Contact.Phone = ""
+			}
+		}
+	}
+	if employee := response.Employee; employee != nil {
+		employee.Department = appointment.Employee.Department
+		employee.Position = appointment.Employee.Position
+		if appointment.Employee.User.ID != 0 {
+			employee.Contact = newContactResponse(&appointment.Employee.User)
+			if hidden(models.FieldEmployeeEmail) {
+				employee.Contact.Email = ""
+			}
+			if hidden(models.FieldEmployeePhone) {
+				employee.Contact.Phone = ""
+			}
+		}
+	}
+	if operation := response.Operation; operation != nil {
+		operation.Address = appointment.Operation.Address
+		operation.City = appointment.Operation.City
+		operation.State = appointment.Operation.State
+		operation.Timezone = appointment.Operation.Timezone
+	}
+	if !hidden(models.FieldBilling) {
+		response.CostCenter = appointment.CostCenter
+		response.BillingCode = appointment.BillingCode
+	}
+	if product := response.Product; product != nil && !hidden(models.FieldProductPrice) {
+		price := appointment.Product.Price
+		product.Price = &price
+		product.Currency = appointment.Product.Currency
+	}
+	return response
+}
+
+// newContactResponse maps the contact details of a user
+func newContactResponse(user *models.User) *ContactResponse {
+	return &ContactResponse{Name: user.Name, Email: user.Email, Phone: user.Phone}
+}
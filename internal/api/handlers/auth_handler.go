@@ -1,26 +1,32 @@
 package handlers
 
 import (
+	"log"
 	"net/http"
-	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
 	"github.com/bernardofernandezz/scheduling-api/internal/models"
 	"github.com/bernardofernandezz/scheduling-api/internal/service"
 	"github.com/bernardofernandezz/scheduling-api/pkg/auth"
+	"github.com/bernardofernandezz/scheduling-api/pkg/phone"
 )
 
 // AuthHandler handles authentication related requests
 type AuthHandler struct {
 	userService service.UserService
 	jwtManager  *auth.JWTManager
+	phoneRegion string // country of phone numbers given without a calling code
+	stripPlus   bool   // whether +tags are removed from email addresses
 }
 
 // NewAuthHandler creates a new authentication handler
-func NewAuthHandler(userService service.UserService, jwtManager *auth.JWTManager) *AuthHandler {
+func NewAuthHandler(userService service.UserService, jwtManager *auth.JWTManager, phoneRegion string, stripPlus bool) *AuthHandler {
 	return &AuthHandler{
 		userService: userService,
 		jwtManager:  jwtManager,
+		phoneRegion: phoneRegion,
+		stripPlus:   stripPlus,
 	}
 }
 
@@ -65,18 +71,25 @@ type AuthResponse struct {
 // Register handles user registration
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req RegisterRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := middleware.BindJSON(c, &req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
 		return
 	}
 
+	// Phone numbers are stored in E.164 so SMS providers get them in one format
+	phoneNumber, err := phone.Normalize(req.Phone, h.phoneRegion)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid phone number: " + err.Error()})
+		return
+	}
+
 	// Create user model from request
 	user := &models.User{
 		Name:         req.Name,
-		Email:        strings.ToLower(req.Email),
+		Email:        models.NormalizeEmail(req.Email, h.stripPlus),
 		PasswordHash: req.Password, // This will be hashed in the service
 		Role:         req.Role,
-		Phone:        req.Phone,
+		Phone:        phoneNumber,
 		Active:       true,
 	}
 
@@ -112,6 +125,118 @@ func (h *AuthHandler) Register(c *gin.Context) {
 // Login handles user login
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req LoginRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	// Authenticate user
+	user, err := h.userService.Login(models.NormalizeEmail(req.Email, h.stripPlus), req.Password)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid email or password"})
+		return
+	}
+
+	h.respondWithTokens(c, http.StatusOK, user)
+}
+
+// RefreshToken handles issuing new tokens for a valid refresh token. Revoked refresh tokens are
+// refused before this runs.
+func (h *AuthHandler) RefreshToken(c *gin.Context) {
+	var req RefreshTokenRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	// Validate refresh token; access tokens can't be traded for new ones
+	claims, err := h.jwtManager.Verify(req.RefreshToken)
+	if err != nil || claims.TokenType != auth.RefreshToken {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh token"})
+		return
+	}
+	user, err := h.userService.GetByID(claims.UserID)
+	if err != nil || !user.Active {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid refresh token"})
+		return
+	}
+
+	h.respondWithTokens(c, http.StatusOK, user)
+}
+
+// RequestPasswordReset handles requesting a password reset link. The response is the same
+// whether or not the email belongs to an account, so it can't be used to find users.
+func (h *AuthHandler) RequestPasswordReset(c *gin.Context) {
+	var req PasswordResetRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	if err := h.userService.RequestPasswordReset(models.NormalizeEmail(req.Email, h.stripPlus)); err != nil {
+		log.Printf("Failed to request a password reset: %v", err)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "If the email belongs to an account, a reset link has been sent"})
+}
+
+// Profile handles getting the signed-in user's profile
+func (h *AuthHandler) Profile(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	// Set password to empty for response
+	profile := *user
+	profile.PasswordHash = ""
 
+	c.JSON(http.StatusOK, gin.H{"user": &profile})
+}
+
+// ChangePassword handles changing the signed-in user's password. Tokens issued before are
+// revoked by the route's middleware once this succeeds.
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req PasswordChangeRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	if err := h.userService.ChangePassword(user.ID, req.OldPassword, req.NewPassword); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password changed successfully"})
+}
+
+// respondWithTokens writes a user with a new access and refresh token
+func (h *AuthHandler) respondWithTokens(c *gin.Context, status int, user *models.User) {
+	// Generate tokens
+	token, err := h.jwtManager.GenerateToken(user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return
+	}
+
+	refreshToken, err := h.jwtManager.GenerateRefreshToken(user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate refresh token"})
+		return
+	}
+
+	// Set password to empty for response
+	user.PasswordHash = ""
+
+	c.JSON(status, AuthResponse{
+		User:         user,
+		Token:        token,
+		RefreshToken: refreshToken,
+	})
+}
@@ -0,0 +1,354 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// AvailabilityHandler handles employee availability exceptions, open slots and schedule tools
+type AvailabilityHandler struct {
+	availabilityService service.AvailabilityService
+}
+
+// NewAvailabilityHandler creates a new availability handler
+func NewAvailabilityHandler(availabilityService service.AvailabilityService) *AvailabilityHandler {
+	return &AvailabilityHandler{availabilityService: availabilityService}
+}
+
+// AvailabilityExceptionRequest represents the request body for recording an absence
+type AvailabilityExceptionRequest struct {
+	OperationID *uint                              `json:"operation_id"`
+	StartsAt    time.Time                          `json:"starts_at" binding:"required"`
+	EndsAt      time.Time                          `json:"ends_at" binding:"required"`
+	Reason      models.AvailabilityExceptionReason `json:"reason" binding:"required"`
+	Notes       string                             `json:"notes"`
+}
+
+// CopyAvailabilityRequest represents the request body for copying a weekly schedule
+type CopyAvailabilityRequest struct {
+	EmployeeIDs []uint `json:"employee_ids" binding:"required,min=1"`
+	OperationID *uint  `json:"operation_id"` // copy only the slots at this operation
+	Replace     bool   `json:"replace"`      // remove the targets' weekly slots at the copied operations first
+}
+
+// DefaultScheduleRequest represents the request body for setting an operation's default schedule
+type DefaultScheduleRequest struct {
+	Slots []struct {
+		DayOfWeek int    `json:"day_of_week" binding:"min=0,max=6"`
+		StartTime string `json:"start_time" binding:"required"`
+		EndTime   string `json:"end_time" binding:"required"`
+	} `json:"slots"`
+}
+
+// ApplyDefaultScheduleRequest represents the request body for applying a default schedule
+type ApplyDefaultScheduleRequest struct {
+	EmployeeIDs []uint `json:"employee_ids" binding:"required,min=1"`
+	Replace     bool   `json:"replace"` // also overwrite employees who already have a weekly schedule
+}
+
+// ShiftSlotsRequest represents the request body for moving an operation's weekly slots
+type ShiftSlotsRequest struct {
+	Minutes     int    `json:"minutes" binding:"required"`
+	EmployeeIDs []uint `json:"employee_ids"` // empty shifts every employee's slots
+}
+
+// CreateException handles recording a period an employee is unavailable. The response lists the
+// appointments already booked with the employee in that period.
+func (h *AvailabilityHandler) CreateException(c *gin.Context) {
+	employee, ok := h.authorizeEmployee(c)
+	if !ok {
+		return
+	}
+
+	var req AvailabilityExceptionRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	user, _ := currentUser(c)
+	report, err := h.availabilityService.CreateException(&models.AvailabilityException{
+		EmployeeID:  employee.ID,
+		OperationID: req.OperationID,
+		StartsAt:    req.StartsAt,
+		EndsAt:      req.EndsAt,
+		Reason:      req.Reason,
+		Notes:       req.Notes,
+		CreatedByID: user.ID,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
+
+// ListExceptions handles listing an employee's availability exceptions
+func (h *AvailabilityHandler) ListExceptions(c *gin.Context) {
+	employee, ok := h.authorizeEmployee(c)
+	if !ok {
+		return
+	}
+
+	exceptions, err := h.availabilityService.ListExceptions(employee.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"exceptions": exceptions})
+}
+
+// GetExceptionConflicts handles listing the booked appointments an exception conflicts with
+func (h *AvailabilityHandler) GetExceptionConflicts(c *gin.Context) {
+	employee, ok := h.authorizeEmployee(c)
+	if !ok {
+		return
+	}
+	exceptionID, err := strconv.ParseUint(c.Param("exception_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid exception ID"})
+		return
+	}
+
+	report, err := h.availabilityService.GetExceptionConflicts(employee.ID, uint(exceptionID))
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrExceptionNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// DeleteException handles removing an availability exception
+func (h *AvailabilityHandler) DeleteException(c *gin.Context) {
+	employee, ok := h.authorizeEmployee(c)
+	if !ok {
+		return
+	}
+	exceptionID, err := strconv.ParseUint(c.Param("exception_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid exception ID"})
+		return
+	}
+
+	if err := h.availabilityService.DeleteException(employee.ID, uint(exceptionID)); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrExceptionNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Availability exception deleted successfully"})
+}
+
+// OpenSlots handles listing when an employee can take appointments
+func (h *AvailabilityHandler) OpenSlots(c *gin.Context) {
+	employeeID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
+		return
+	}
+
+	from, err := time.Parse(time.RFC3339, c.Query("start_date"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "start_date must be an RFC 3339 timestamp"})
+		return
+	}
+	to, err := time.Parse(time.RFC3339, c.Query("end_date"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "end_date must be an RFC 3339 timestamp"})
+		return
+	}
+
+	var operationID *uint
+	if operationIDStr := c.Query("operation_id"); operationIDStr != "" {
+		parsed, err := strconv.ParseUint(operationIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+			return
+		}
+		id := uint(parsed)
+		operationID = &id
+	}
+
+	slots, err := h.availabilityService.OpenSlots(uint(employeeID), operationID, from, to)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrInvalidSlotRange) || errors.Is(err, service.ErrSlotRangeTooLong) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"slots": slots})
+}
+
+// CopyAvailability handles copying an employee's weekly schedule to other employees
+func (h *AvailabilityHandler) CopyAvailability(c *gin.Context) {
+	sourceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
+		return
+	}
+
+	var req CopyAvailabilityRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	result, err := h.availabilityService.CopyWeeklyAvailability(uint(sourceID), req.EmployeeIDs, req.OperationID, req.Replace)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrNoSlotsToCopy) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetDefaultSchedule handles getting the weekly schedule an operation gives new employees
+func (h *AvailabilityHandler) GetDefaultSchedule(c *gin.Context) {
+	operationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+		return
+	}
+
+	slots, err := h.availabilityService.GetDefaultSchedule(uint(operationID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"slots": slots})
+}
+
+// SetDefaultSchedule handles replacing an operation's default weekly schedule
+func (h *AvailabilityHandler) SetDefaultSchedule(c *gin.Context) {
+	operationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+		return
+	}
+
+	var req DefaultScheduleRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	slots := make([]models.OperationDefaultSlot, 0, len(req.Slots))
+	for _, slot := range req.Slots {
+		slots = append(slots, models.OperationDefaultSlot{
+			DayOfWeek: slot.DayOfWeek,
+			StartTime: slot.StartTime,
+			EndTime:   slot.EndTime,
+		})
+	}
+
+	saved, err := h.availabilityService.SetDefaultSchedule(uint(operationID), slots)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"slots": saved})
+}
+
+// ApplyDefaultSchedule handles giving employees an operation's default weekly schedule
+func (h *AvailabilityHandler) ApplyDefaultSchedule(c *gin.Context) {
+	operationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+		return
+	}
+
+	var req ApplyDefaultScheduleRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	result, err := h.availabilityService.ApplyDefaultSchedule(uint(operationID), req.EmployeeIDs, req.Replace)
+	if err != nil {
+		status := http.StatusNotFound
+		if errors.Is(err, service.ErrNoDefaultSchedule) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ShiftSlots handles moving an operation's weekly slots by a number of minutes
+func (h *AvailabilityHandler) ShiftSlots(c *gin.Context) {
+	operationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+		return
+	}
+
+	var req ShiftSlotsRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	shifted, err := h.availabilityService.ShiftSlots(uint(operationID), req.Minutes, req.EmployeeIDs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"shifted": shifted})
+}
+
+// authorizeEmployee loads the employee from the path and checks the user may manage their
+// availability. Admins may manage any employee, employees only themselves.
+func (h *AvailabilityHandler) authorizeEmployee(c *gin.Context) (*models.Employee, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid employee ID"})
+		return nil, false
+	}
+
+	user, ok := currentUser(c)
+	if !ok {
+		return nil, false
+	}
+
+	employee, err := h.availabilityService.GetEmployee(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	if user.Role != "admin" && employee.UserID != user.ID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to manage this employee's availability"})
+		return nil, false
+	}
+
+	return employee, true
+}
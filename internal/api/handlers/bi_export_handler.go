@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// BIExportHandler handles the anonymized appointment export for the data warehouse
+type BIExportHandler struct {
+	biExportService service.BIExportService
+}
+
+// NewBIExportHandler creates a new BI export handler
+func NewBIExportHandler(biExportService service.BIExportService) *BIExportHandler {
+	return &BIExportHandler{
+		biExportService: biExportService,
+	}
+}
+
+// Run handles starting an export outside of the nightly schedule. It returns as soon as the run
+// is recorded; its progress is read from GetRun.
+func (h *BIExportHandler) Run(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	run, err := h.biExportService.Trigger(user.ID)
+	if err != nil {
+		c.JSON(biExportErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, run)
+}
+
+// ListRuns handles listing the latest export runs
+func (h *BIExportHandler) ListRuns(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	runs, err := h.biExportService.ListRuns(limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"runs": runs})
+}
+
+// GetRun handles getting an export run
+func (h *BIExportHandler) GetRun(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid BI export run ID"})
+		return
+	}
+
+	run, err := h.biExportService.GetRun(uint(id))
+	if err != nil {
+		c.JSON(biExportErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+// Schema handles describing the columns of the exported files
+func (h *BIExportHandler) Schema(c *gin.Context) {
+	c.JSON(http.StatusOK, h.biExportService.Schema())
+}
+
+// biExportErrorStatus maps BI export errors to HTTP statuses
+func biExportErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrBIExportDisabled):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, service.ErrBIExportRunning):
+		return http.StatusConflict
+	case errors.Is(err, repository.ErrBIExportRunNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// BillingHandler handles the cost center and billing code list and the monthly billing export
+type BillingHandler struct {
+	billingService service.BillingService
+}
+
+// NewBillingHandler creates a new billing handler
+func NewBillingHandler(billingService service.BillingService) *BillingHandler {
+	return &BillingHandler{
+		billingService: billingService,
+	}
+}
+
+// CreateBillingCodeRequest represents the request body for adding a cost center or billing code
+type CreateBillingCodeRequest struct {
+	Type models.BillingCodeType `json:"type" binding:"required"`
+	Code string                 `json:"code" binding:"required"`
+	Name string                 `json:"name"`
+}
+
+// UpdateBillingCodeRequest represents the request body for renaming, activating or deactivating a code
+type UpdateBillingCodeRequest struct {
+	Name   *string `json:"name"`
+	Active *bool   `json:"active"`
+}
+
+// ListCodes handles listing the cost centers and billing codes, optionally of one type
+func (h *BillingHandler) ListCodes(c *gin.Context) {
+	codes, err := h.billingService.ListCodes(models.BillingCodeType(c.Query("type")))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"codes": codes})
+}
+
+// CreateCode handles adding a cost center or billing code to the list
+func (h *BillingHandler) CreateCode(c *gin.Context) {
+	var req CreateBillingCodeRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	code := &models.BillingCode{Type: req.Type, Code: req.Code, Name: req.Name}
+	if err := h.billingService.CreateCode(code); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, service.ErrBillingCodeExists) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"code": code})
+}
+
+// UpdateCode handles renaming a code or activating and deactivating it
+func (h *BillingHandler) UpdateCode(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid billing code ID"})
+		return
+	}
+
+	var req UpdateBillingCodeRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	code, err := h.billingService.UpdateCode(uint(id), service.BillingCodeUpdate{Name: req.Name, Active: req.Active})
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"code": code})
+}
+
+// Export handles the monthly billing export: the dock time of completed appointments and the fees
+// charged per supplier and cost center, as JSON or CSV. The month starts and ends in the
+// request's timezone.
+func (h *BillingHandler) Export(c *gin.Context) {
+	month, err := time.ParseInLocation("2006-01", c.Query("month"), middleware.RequestTimezone(c))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid month. Use YYYY-MM"})
+		return
+	}
+
+	var operationID *uint
+	if value := c.Query("operation_id"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+			return
+		}
+		parsed := uint(id)
+		operationID = &parsed
+	}
+
+	export, err := h.billingService.MonthlyExport(month, operationID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("billing-%s", export.Month)
+	switch c.DefaultQuery("format", "json") {
+	case "csv":
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s.csv", filename))
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Status(http.StatusOK)
+
+		// Dock time and fees share one sheet; the item column tells them apart
+		w := csv.NewWriter(c.Writer)
+		_ = w.Write([]string{"month", "item", "supplier_id", "supplier_name", "cost_center", "count", "dock_minutes", "amount", "currency"})
+		for _, row := range export.Rows {
+			_ = w.Write([]string{
+				export.Month,
+				"dock_time",
+				strconv.FormatUint(uint64(row.SupplierID), 10),
+				row.SupplierName,
+				row.CostCenter,
+				strconv.FormatInt(row.Appointments, 10),
+				strconv.FormatFloat(row.DockMinutes, 'f', 1, 64),
+				"",
+				"",
+			})
+		}
+		for _, row := range export.Fees {
+			_ = w.Write([]string{
+				export.Month,
+				string(row.Kind) + "_fee",
+				strconv.FormatUint(uint64(row.SupplierID), 10),
+				row.SupplierName,
+				row.CostCenter,
+				strconv.FormatInt(row.Fees, 10),
+				"",
+				row.Amount.String(),
+				row.Currency,
+			})
+		}
+		w.Flush()
+	case "json":
+		c.JSON(http.StatusOK, export)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format. Use json or csv"})
+	}
+}
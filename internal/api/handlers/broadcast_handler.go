@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// BroadcastHandler handles the ad-hoc messages admins send to an audience
+type BroadcastHandler struct {
+	broadcastService service.BroadcastService
+}
+
+// NewBroadcastHandler creates a new broadcast handler
+func NewBroadcastHandler(broadcastService service.BroadcastService) *BroadcastHandler {
+	return &BroadcastHandler{
+		broadcastService: broadcastService,
+	}
+}
+
+// BroadcastRequest represents the request body for previewing or sending a broadcast
+type BroadcastRequest struct {
+	Audience    models.BroadcastAudience `json:"audience" binding:"required"`
+	OperationID *uint                    `json:"operation_id"` // required for operation_suppliers, optional for suppliers_in_range
+	From        *time.Time               `json:"from"`         // range of suppliers_in_range
+	To          *time.Time               `json:"to"`
+	Channel     models.NotificationType  `json:"channel"` // email (default) or sms
+	Subject     string                   `json:"subject" binding:"required"`
+	Body        string                   `json:"body" binding:"required"`
+	SendAt      *time.Time               `json:"send_at"` // now when left out
+}
+
+// broadcast returns the broadcast the request describes
+func (r *BroadcastRequest) broadcast() *models.Broadcast {
+	broadcast := &models.Broadcast{
+		Audience:    r.Audience,
+		OperationID: r.OperationID,
+		RangeFrom:   r.From,
+		RangeTo:     r.To,
+		Channel:     r.Channel,
+		Subject:     r.Subject,
+		Body:        r.Body,
+	}
+	if r.SendAt != nil {
+		broadcast.SendAt = *r.SendAt
+	}
+	return broadcast
+}
+
+// Preview handles counting the audience of a broadcast and rendering it for its first
+// recipients, without sending anything
+func (h *BroadcastHandler) Preview(c *gin.Context) {
+	var req BroadcastRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	preview, err := h.broadcastService.Preview(req.broadcast())
+	if err != nil {
+		respondBroadcastError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// Send handles queueing a broadcast for its audience, held until its send time
+func (h *BroadcastHandler) Send(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req BroadcastRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	broadcast, err := h.broadcastService.Send(req.broadcast(), user)
+	if err != nil {
+		respondBroadcastError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"broadcast": broadcast})
+}
+
+// List handles listing the latest broadcasts
+func (h *BroadcastHandler) List(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	broadcasts, err := h.broadcastService.List(limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"broadcasts": broadcasts})
+}
+
+// respondBroadcastError answers a failed broadcast request with the status matching its error
+func respondBroadcastError(c *gin.Context, err error) {
+	status := http.StatusBadRequest
+	switch {
+	case errors.Is(err, repository.ErrOperationNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrBroadcastAudienceEmpty), errors.Is(err, service.ErrBroadcastAudienceLimit):
+		status = http.StatusUnprocessableEntity
+	}
+	c.JSON(status, gin.H{"error": err.Error()})
+}
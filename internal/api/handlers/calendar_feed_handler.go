@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// CalendarFeedHandler handles the filtered iCalendar feeds users subscribe to from calendar apps
+type CalendarFeedHandler struct {
+	calendarFeedService service.CalendarFeedService
+}
+
+// NewCalendarFeedHandler creates a new calendar feed handler
+func NewCalendarFeedHandler(calendarFeedService service.CalendarFeedService) *CalendarFeedHandler {
+	return &CalendarFeedHandler{
+		calendarFeedService: calendarFeedService,
+	}
+}
+
+// CalendarFeedRequest represents the request body for creating or replacing a calendar feed.
+// Filters left out don't restrict the feed.
+type CalendarFeedRequest struct {
+	Name         string                    `json:"name" binding:"required"`
+	OperationID  *uint                     `json:"operation_id"`
+	Status       *models.AppointmentStatus `json:"status"`
+	Type         *models.AppointmentType   `json:"type"`
+	AssignedOnly bool                      `json:"assigned_only"` // only appointments booked with the signed-in employee
+}
+
+// feed returns the calendar feed the request describes
+func (r *CalendarFeedRequest) feed() *models.CalendarFeed {
+	return &models.CalendarFeed{
+		Name:         r.Name,
+		OperationID:  r.OperationID,
+		Status:       r.Status,
+		Type:         r.Type,
+		AssignedOnly: r.AssignedOnly,
+	}
+}
+
+// Create handles adding a calendar feed. The response holds the subscription link, which can't
+// be shown again later.
+func (h *CalendarFeedHandler) Create(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req CalendarFeedRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	issued, err := h.calendarFeedService.CreateFeed(user, req.feed())
+	if err != nil {
+		respondCalendarFeedError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, issued)
+}
+
+// List handles listing the signed-in user's calendar feeds
+func (h *CalendarFeedHandler) List(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	feeds, err := h.calendarFeedService.ListFeeds(user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"feeds": feeds})
+}
+
+// Get handles getting one of the signed-in user's calendar feeds
+func (h *CalendarFeedHandler) Get(c *gin.Context) {
+	user, id, ok := h.feedRequest(c)
+	if !ok {
+		return
+	}
+
+	feed, err := h.calendarFeedService.GetFeed(user, id)
+	if err != nil {
+		respondCalendarFeedError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"feed": feed})
+}
+
+// Update handles renaming a calendar feed and replacing its filters; its link stays the same
+func (h *CalendarFeedHandler) Update(c *gin.Context) {
+	user, id, ok := h.feedRequest(c)
+	if !ok {
+		return
+	}
+
+	var req CalendarFeedRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	feed, err := h.calendarFeedService.UpdateFeed(user, id, req.feed())
+	if err != nil {
+		respondCalendarFeedError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"feed": feed})
+}
+
+// RotateToken handles replacing the link of a calendar feed. The old link stops working.
+func (h *CalendarFeedHandler) RotateToken(c *gin.Context) {
+	user, id, ok := h.feedRequest(c)
+	if !ok {
+		return
+	}
+
+	issued, err := h.calendarFeedService.RotateToken(user, id)
+	if err != nil {
+		respondCalendarFeedError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, issued)
+}
+
+// Delete handles deleting a calendar feed
+func (h *CalendarFeedHandler) Delete(c *gin.Context) {
+	user, id, ok := h.feedRequest(c)
+	if !ok {
+		return
+	}
+
+	if err := h.calendarFeedService.DeleteFeed(user, id); err != nil {
+		respondCalendarFeedError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Calendar feed deleted successfully"})
+}
+
+// Subscribe handles a calendar app fetching a feed through its link. The token is the secret, so
+// the route is public; the .ics extension some apps expect is accepted.
+func (h *CalendarFeedHandler) Subscribe(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	calendar, err := h.calendarFeedService.RenderFeed(token)
+	if err != nil {
+		respondCalendarFeedError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Header("Content-Disposition", `inline; filename="appointments.ics"`)
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", calendar)
+}
+
+// feedRequest returns the signed-in user and the feed ID from the path
+func (h *CalendarFeedHandler) feedRequest(c *gin.Context) (*models.User, uint, bool) {
+	user, ok := currentUser(c)
+	if !ok {
+		return nil, 0, false
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid calendar feed ID"})
+		return nil, 0, false
+	}
+	return user, uint(id), true
+}
+
+// respondCalendarFeedError answers a failed calendar feed request with the status matching its error
+func respondCalendarFeedError(c *gin.Context, err error) {
+	status := http.StatusBadRequest
+	switch {
+	case errors.Is(err, service.ErrCalendarFeedNotFound), errors.Is(err, repository.ErrOperationNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrCalendarFeedLimit):
+		status = http.StatusConflict
+	case errors.Is(err, service.ErrCalendarFeedAssignments):
+		status = http.StatusForbidden
+	}
+	c.JSON(status, gin.H{"error": err.Error()})
+}
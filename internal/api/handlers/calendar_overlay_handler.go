@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// CalendarOverlayHandler handles the work calendars employees connect so their meetings count as
+// unavailable
+type CalendarOverlayHandler struct {
+	calendarOverlayService service.CalendarOverlayService
+}
+
+// NewCalendarOverlayHandler creates a new work calendar handler
+func NewCalendarOverlayHandler(calendarOverlayService service.CalendarOverlayService) *CalendarOverlayHandler {
+	return &CalendarOverlayHandler{
+		calendarOverlayService: calendarOverlayService,
+	}
+}
+
+// CalendarConnectionRequest represents the request body for connecting a work calendar with the
+// OAuth token the client obtained from the provider
+type CalendarConnectionRequest struct {
+	Provider     string     `json:"provider" binding:"required"` // google or microsoft
+	CalendarID   string     `json:"calendar_id"`                 // Google calendar ID, "primary" when empty, or the Microsoft 365 mailbox
+	AccessToken  string     `json:"access_token"`
+	RefreshToken string     `json:"refresh_token"` // lets busy times be read after the access token expires
+	Expiry       *time.Time `json:"expiry"`        // when the access token expires
+}
+
+// Get handles showing the signed-in employee's calendar connection and when it was read last
+func (h *CalendarOverlayHandler) Get(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	calendar, err := h.calendarOverlayService.GetConnection(user)
+	if err != nil {
+		respondCalendarOverlayError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"calendar": calendar})
+}
+
+// Connect handles connecting the signed-in employee's work calendar, replacing the one connected
+// before. The calendar is read right away.
+func (h *CalendarOverlayHandler) Connect(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req CalendarConnectionRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	calendar := &models.EmployeeCalendar{
+		Provider:     req.Provider,
+		CalendarID:   req.CalendarID,
+		AccessToken:  req.AccessToken,
+		RefreshToken: req.RefreshToken,
+	}
+	if req.Expiry != nil {
+		calendar.TokenExpiry = *req.Expiry
+	}
+
+	calendar, err := h.calendarOverlayService.Connect(c.Request.Context(), user, calendar)
+	if err != nil {
+		respondCalendarOverlayError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"calendar": calendar})
+}
+
+// Disconnect handles disconnecting the signed-in employee's work calendar
+func (h *CalendarOverlayHandler) Disconnect(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.calendarOverlayService.Disconnect(user); err != nil {
+		respondCalendarOverlayError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Calendar disconnected successfully"})
+}
+
+// respondCalendarOverlayError answers a failed work calendar request with the status matching
+// its error
+func respondCalendarOverlayError(c *gin.Context, err error) {
+	status := http.StatusBadRequest
+	switch {
+	case errors.Is(err, service.ErrCalendarNotConnected):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrCalendarEmployee):
+		status = http.StatusForbidden
+	case errors.Is(err, service.ErrCalendarUnreadable):
+		status = http.StatusUnprocessableEntity
+	}
+	c.JSON(status, gin.H{"error": err.Error()})
+}
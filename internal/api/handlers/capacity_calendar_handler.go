@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// CapacityCalendarHandler handles viewing and editing an operation's capacity per date and time
+// band
+type CapacityCalendarHandler struct {
+	capacityCalendarService service.CapacityCalendarService
+}
+
+// NewCapacityCalendarHandler creates a new capacity calendar handler
+func NewCapacityCalendarHandler(capacityCalendarService service.CapacityCalendarService) *CapacityCalendarHandler {
+	return &CapacityCalendarHandler{
+		capacityCalendarService: capacityCalendarService,
+	}
+}
+
+// CapacityDayRequest is the request body for replacing the capacity bands of a date
+type CapacityDayRequest struct {
+	Bands []struct {
+		StartTime     string                 `json:"start_time" binding:"required"`
+		EndTime       string                 `json:"end_time" binding:"required"`
+		Type          models.AppointmentType `json:"type"` // empty limits every type together
+		MaxConcurrent *int                   `json:"max_concurrent" binding:"required"`
+		Reason        string                 `json:"reason"`
+	} `json:"bands"`
+}
+
+// Calendar handles getting an operation's capacity between two dates, four weeks from today by
+// default
+func (h *CapacityCalendarHandler) Calendar(c *gin.Context) {
+	operationID, ok := parseCapacityOperationID(c)
+	if !ok {
+		return
+	}
+	from, to, ok := parseCapacityRange(c)
+	if !ok {
+		return
+	}
+
+	calendar, err := h.capacityCalendarService.Calendar(operationID, from, to)
+	if err != nil {
+		c.JSON(capacityCalendarErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, calendar)
+}
+
+// SetDay handles replacing the capacity bands of an operation on a date
+func (h *CapacityCalendarHandler) SetDay(c *gin.Context) {
+	operationID, ok := parseCapacityOperationID(c)
+	if !ok {
+		return
+	}
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	date, err := service.ParseBlackoutDate(c.Param("date"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req CapacityDayRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+	overrides := make([]models.CapacityOverride, 0, len(req.Bands))
+	for _, band := range req.Bands {
+		overrides = append(overrides, models.CapacityOverride{
+			StartTime:     band.StartTime,
+			EndTime:       band.EndTime,
+			Type:          band.Type,
+			MaxConcurrent: *band.MaxConcurrent,
+			Reason:        band.Reason,
+		})
+	}
+
+	day, err := h.capacityCalendarService.SetDay(operationID, date, overrides, user.ID)
+	if err != nil {
+		c.JSON(capacityCalendarErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, day)
+}
+
+// ResetDay handles putting an operation's date back on the appointment type capacity rules
+func (h *CapacityCalendarHandler) ResetDay(c *gin.Context) {
+	operationID, ok := parseCapacityOperationID(c)
+	if !ok {
+		return
+	}
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	date, err := service.ParseBlackoutDate(c.Param("date"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	day, err := h.capacityCalendarService.SetDay(operationID, date, nil, user.ID)
+	if err != nil {
+		c.JSON(capacityCalendarErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, day)
+}
+
+// History handles listing the capacity changes of an operation's dates between two dates
+func (h *CapacityCalendarHandler) History(c *gin.Context) {
+	operationID, ok := parseCapacityOperationID(c)
+	if !ok {
+		return
+	}
+	from, to, ok := parseCapacityRange(c)
+	if !ok {
+		return
+	}
+
+	changes, err := h.capacityCalendarService.History(operationID, from, to)
+	if err != nil {
+		c.JSON(capacityCalendarErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"changes": changes})
+}
+
+// parseCapacityOperationID parses the operation ID from the path, writing a 400 if it's invalid
+func parseCapacityOperationID(c *gin.Context) (uint, bool) {
+	operationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+		return 0, false
+	}
+	return uint(operationID), true
+}
+
+// parseCapacityRange parses the from and to query dates, writing a 400 if either is invalid. Dates
+// not given are left zero for the service to default.
+func parseCapacityRange(c *gin.Context) (time.Time, time.Time, bool) {
+	var err error
+	var from, to time.Time
+	if value := c.Query("from"); value != "" {
+		if from, err = service.ParseBlackoutDate(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return time.Time{}, time.Time{}, false
+		}
+	}
+	if value := c.Query("to"); value != "" {
+		if to, err = service.ParseBlackoutDate(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return time.Time{}, time.Time{}, false
+		}
+	}
+	return from, to, true
+}
+
+// capacityCalendarErrorStatus maps capacity calendar errors to HTTP statuses
+func capacityCalendarErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrCapacityOverlap):
+		return http.StatusConflict
+	case errors.Is(err, repository.ErrOperationNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadRequest
+	}
+}
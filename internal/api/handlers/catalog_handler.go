@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+	"github.com/bernardofernandezz/scheduling-api/pkg/i18n"
+)
+
+// CatalogHandler handles the operations list, product catalog, notification templates and
+// enum labels
+type CatalogHandler struct {
+	catalogService service.CatalogService
+}
+
+// NewCatalogHandler creates a new catalog handler
+func NewCatalogHandler(catalogService service.CatalogService) *CatalogHandler {
+	return &CatalogHandler{
+		catalogService: catalogService,
+	}
+}
+
+// ListOperations handles listing operations. Inactive operations are only listed for admins
+// who ask for them with include_inactive=true.
+func (h *CatalogHandler) ListOperations(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	activeOnly := !(user.Role == "admin" && c.Query("include_inactive") == "true")
+	operations, err := h.catalogService.ListOperations(activeOnly)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"operations": operations})
+}
+
+// ListProducts handles listing the active product catalog, optionally by supplier or category
+func (h *CatalogHandler) ListProducts(c *gin.Context) {
+	filters := repository.ProductFilters{
+		Category:   c.Query("category"),
+		ActiveOnly: true,
+	}
+	if supplierIDStr := c.Query("supplier_id"); supplierIDStr != "" {
+		supplierID, err := strconv.ParseUint(supplierIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid supplier ID"})
+			return
+		}
+		id := uint(supplierID)
+		filters.SupplierID = &id
+	}
+
+	products, err := h.catalogService.ListProducts(filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"products": products})
+}
+
+// ListTemplates handles listing the notification templates
+func (h *CatalogHandler) ListTemplates(c *gin.Context) {
+	templates, err := h.catalogService.ListTemplates()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"templates": templates})
+}
+
+// ListLabels handles listing the labels of statuses, types, events and other enums in the
+// request's language, so clients can show them without their own translations
+func (h *CatalogHandler) ListLabels(c *gin.Context) {
+	locale := middleware.RequestLocale(c)
+	c.JSON(http.StatusOK, gin.H{
+		"locale":            locale,
+		"supported_locales": i18n.MessageLocales(),
+		"labels":            i18n.Labels(locale),
+	})
+}
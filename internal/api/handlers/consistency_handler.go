@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// ConsistencyHandler handles admin requests to check records left inconsistent with each other
+type ConsistencyHandler struct {
+	consistencyService service.ConsistencyService
+}
+
+// NewConsistencyHandler creates a new consistency handler
+func NewConsistencyHandler(consistencyService service.ConsistencyService) *ConsistencyHandler {
+	return &ConsistencyHandler{
+		consistencyService: consistencyService,
+	}
+}
+
+// Check handles reporting inconsistent records without changing anything
+func (h *ConsistencyHandler) Check(c *gin.Context) {
+	report := h.consistencyService.Check(c.Request.Context(), false)
+
+	c.JSON(http.StatusOK, gin.H{"report": report})
+}
+
+// Repair handles checking for inconsistent records and repairing those that can be repaired
+// safely; the report lists what was repaired and what is left for staff
+func (h *ConsistencyHandler) Repair(c *gin.Context) {
+	report := h.consistencyService.Check(c.Request.Context(), true)
+
+	c.JSON(http.StatusOK, gin.H{"report": report})
+}
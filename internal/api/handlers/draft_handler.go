@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// DraftHandler handles the booking forms users save as they fill them in
+type DraftHandler struct {
+	draftService service.DraftService
+}
+
+// NewDraftHandler creates a new appointment draft handler
+func NewDraftHandler(draftService service.DraftService) *DraftHandler {
+	return &DraftHandler{
+		draftService: draftService,
+	}
+}
+
+// DraftRequest represents the request body for saving a draft: the booking form as the client
+// holds it, a JSON object stored as it is
+type DraftRequest struct {
+	Payload json.RawMessage `json:"payload" binding:"required"`
+}
+
+// DraftResponse is a draft with its payload
+type DraftResponse struct {
+	Key       string          `json:"key"`
+	Payload   json.RawMessage `json:"payload"`
+	ExpiresAt time.Time       `json:"expires_at"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// newDraftResponse renders a draft with its payload
+func newDraftResponse(draft *models.AppointmentDraft) DraftResponse {
+	return DraftResponse{
+		Key:       draft.Key,
+		Payload:   json.RawMessage(draft.Payload),
+		ExpiresAt: draft.ExpiresAt,
+		CreatedAt: draft.CreatedAt,
+		UpdatedAt: draft.UpdatedAt,
+	}
+}
+
+// Create handles saving a new draft under a generated key, returned for the following saves
+func (h *DraftHandler) Create(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req DraftRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	draft, err := h.draftService.Create(user, req.Payload)
+	if err != nil {
+		respondDraftError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"draft": newDraftResponse(draft)})
+}
+
+// Save handles replacing the payload of a draft, creating it when the user has none under the
+// key. Clients autosaving a form call it on every change.
+func (h *DraftHandler) Save(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req DraftRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	draft, err := h.draftService.Save(user, c.Param("key"), req.Payload)
+	if err != nil {
+		respondDraftError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"draft": newDraftResponse(draft)})
+}
+
+// List handles listing the signed-in user's drafts without their payloads
+func (h *DraftHandler) List(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	drafts, err := h.draftService.List(user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"drafts": drafts})
+}
+
+// Get handles getting one of the signed-in user's drafts to restore the form
+func (h *DraftHandler) Get(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	draft, err := h.draftService.Get(user, c.Param("key"))
+	if err != nil {
+		respondDraftError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"draft": newDraftResponse(draft)})
+}
+
+// Delete handles discarding a draft, e.g. once the appointment was booked
+func (h *DraftHandler) Delete(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.draftService.Delete(user, c.Param("key")); err != nil {
+		respondDraftError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Draft deleted successfully"})
+}
+
+// respondDraftError answers a failed draft request with the status matching its error
+func respondDraftError(c *gin.Context, err error) {
+	status := http.StatusBadRequest
+	switch {
+	case errors.Is(err, service.ErrDraftNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrDraftLimit):
+		status = http.StatusConflict
+	case errors.Is(err, service.ErrDraftTooLarge):
+		status = http.StatusRequestEntityTooLarge
+	}
+	c.JSON(status, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/render"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+	"github.com/bernardofernandezz/scheduling-api/pkg/events"
+)
+
+// eventReplayColumns are the CSV columns of a replayed event
+var eventReplayColumns = []string{"id", "type", "source", "subject", "time", "dataschema", "data"}
+
+// EventReplayHandler handles replaying the CloudEvents of past appointment changes
+type EventReplayHandler struct {
+	replayService service.EventReplayService
+}
+
+// NewEventReplayHandler creates a new event replay handler
+func NewEventReplayHandler(replayService service.EventReplayService) *EventReplayHandler {
+	return &EventReplayHandler{
+		replayService: replayService,
+	}
+}
+
+// Replay handles streaming the CloudEvents of the bookings and status changes between since and
+// until (RFC 3339, until defaults to now), in JSON or CSV as the Accept header prefers
+func (h *EventReplayHandler) Replay(c *gin.Context) {
+	since, err := time.Parse(time.RFC3339, c.Query("since"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "since must be an RFC 3339 time"})
+		return
+	}
+	var until time.Time // now
+	if value := c.Query("until"); value != "" {
+		if until, err = time.Parse(time.RFC3339, value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "until must be an RFC 3339 time"})
+			return
+		}
+	}
+	if err := h.replayService.CheckPeriod(since, until); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	stream(c, &eventReplay{replayService: h.replayService, since: since, until: until})
+}
+
+// eventReplay streams the replayed events of a period
+type eventReplay struct {
+	replayService service.EventReplayService
+	since, until  time.Time
+}
+
+// Header returns the CSV columns of the replay
+func (r *eventReplay) Header() []string {
+	return eventReplayColumns
+}
+
+// Each calls fn with each batch of replayed events
+func (r *eventReplay) Each(fn func(rows []render.Row) error) error {
+	return r.replayService.Replay(r.since, r.until, func(batch []*events.Event) error {
+		rows := make([]render.Row, 0, len(batch))
+		for _, event := range batch {
+			rows = append(rows, replayedEvent{event})
+		}
+		return fn(rows)
+	})
+}
+
+// replayedEvent is a CloudEvent in a replay: JSON as the webhook sends it, and a CSV row with
+// its data as a JSON cell
+type replayedEvent struct {
+	*events.Event
+}
+
+// Record returns the CSV row of the event
+func (e replayedEvent) Record() []string {
+	return []string{e.ID, e.Type, e.Source, e.Subject, e.Time.Format(time.RFC3339), e.DataSchema, string(e.Data)}
+}
@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// FeeHandler handles the fees charged to suppliers, such as for no-shows
+type FeeHandler struct {
+	feeService service.FeeService
+}
+
+// NewFeeHandler creates a new fee handler
+func NewFeeHandler(feeService service.FeeService) *FeeHandler {
+	return &FeeHandler{
+		feeService: feeService,
+	}
+}
+
+// DisputeFeeRequest is the request body for a supplier disputing a fee
+type DisputeFeeRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// ResolveFeeRequest is the request body for an admin upholding or waiving a fee
+type ResolveFeeRequest struct {
+	Resolution string `json:"resolution"`
+}
+
+// List handles listing fees. Suppliers only see their own; staff may filter by supplier,
+// operation, appointment and status.
+func (h *FeeHandler) List(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	filters := repository.FeeFilters{
+		Status: models.FeeStatus(c.Query("status")),
+		Page:   page,
+		Limit:  limit,
+	}
+	for param, target := range map[string]**uint{
+		"supplier_id":    &filters.SupplierID,
+		"operation_id":   &filters.OperationID,
+		"appointment_id": &filters.AppointmentID,
+	} {
+		if value := c.Query(param); value != "" {
+			id, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + param})
+				return
+			}
+			parsed := uint(id)
+			*target = &parsed
+		}
+	}
+
+	if user.Role == "supplier" {
+		supplierID, err := h.feeService.SupplierFor(user)
+		if err != nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		filters.SupplierID = &supplierID
+	}
+
+	fees, total, err := h.feeService.List(filters)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"fees":  fees,
+		"total": total,
+		"page":  filters.Page,
+		"limit": filters.Limit,
+	})
+}
+
+// Dispute handles a supplier contesting one of their fees
+func (h *FeeHandler) Dispute(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	id, ok := parseFeeID(c)
+	if !ok {
+		return
+	}
+
+	var req DisputeFeeRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	supplierID, err := h.feeService.SupplierFor(user)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	// Other suppliers' fees are answered as if they did not exist
+	fee, err := h.feeService.GetByID(id)
+	if err != nil || fee.SupplierID != supplierID {
+		c.JSON(http.StatusNotFound, gin.H{"error": service.ErrFeeNotFound.Error()})
+		return
+	}
+
+	fee, err = h.feeService.Dispute(id, req.Reason)
+	if err != nil {
+		c.JSON(feeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"fee": fee})
+}
+
+// Uphold handles an admin rejecting the dispute of a fee
+func (h *FeeHandler) Uphold(c *gin.Context) {
+	h.resolve(c, h.feeService.Uphold)
+}
+
+// Waive handles an admin cancelling a fee
+func (h *FeeHandler) Waive(c *gin.Context) {
+	h.resolve(c, h.feeService.Waive)
+}
+
+// resolve applies an admin's decision on the fee in the path
+func (h *FeeHandler) resolve(c *gin.Context, decide func(id, actorID uint, resolution string) (*models.Fee, error)) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	id, ok := parseFeeID(c)
+	if !ok {
+		return
+	}
+
+	var req ResolveFeeRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	fee, err := decide(id, user.ID, req.Resolution)
+	if err != nil {
+		c.JSON(feeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"fee": fee})
+}
+
+// parseFeeID parses the fee ID from the path, writing a 400 if it's invalid
+func parseFeeID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid fee ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// feeErrorStatus maps fee errors to HTTP statuses
+func feeErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrFeeNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrFeeNotDisputable), errors.Is(err, service.ErrFeeNotDisputed), errors.Is(err, service.ErrFeeWaived):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+	"github.com/bernardofernandezz/scheduling-api/pkg/geo"
+)
+
+// GateHandler handles appointment visitors, gate manifests and check-in
+type GateHandler struct {
+	gateService        service.GateService
+	appointmentService service.AppointmentService
+	visibilityService  service.VisibilityService
+}
+
+// NewGateHandler creates a new gate handler
+func NewGateHandler(
+	gateService service.GateService,
+	appointmentService service.AppointmentService,
+	visibilityService service.VisibilityService,
+) *GateHandler {
+	return &GateHandler{
+		gateService:        gateService,
+		appointmentService: appointmentService,
+		visibilityService:  visibilityService,
+	}
+}
+
+// VisitorRequest represents the request body for registering a visitor on an appointment
+type VisitorRequest struct {
+	Name           string `json:"name" binding:"required"`
+	DocumentType   string `json:"document_type"`
+	DocumentNumber string `json:"document_number" binding:"required"`
+	Role           string `json:"role"`
+}
+
+// LocationRequest represents a GPS ping from the driver app
+type LocationRequest struct {
+	Latitude       *float64 `json:"latitude" binding:"required"`
+	Longitude      *float64 `json:"longitude" binding:"required"`
+	AccuracyMeters float64  `json:"accuracy_meters"`
+}
+
+// CheckInRequest represents the request body for checking in at the gate
+type CheckInRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// AddVisitor handles registering an extra person on an appointment
+func (h *GateHandler) AddVisitor(c *gin.Context) {
+	appointment, ok := h.authorizeAppointment(c)
+	if !ok {
+		return
+	}
+
+	var req VisitorRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	visitor := &models.AppointmentVisitor{
+		AppointmentID:  appointment.ID,
+		Name:           req.Name,
+		DocumentType:   req.DocumentType,
+		DocumentNumber: req.DocumentNumber,
+		Role:           req.Role,
+	}
+	if err := h.gateService.AddVisitor(visitor); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, service.ErrVisitorLimitReached) || errors.Is(err, service.ErrAppointmentClosed) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, visitor)
+}
+
+// ListVisitors handles listing the visitors of an appointment
+func (h *GateHandler) ListVisitors(c *gin.Context) {
+	appointment, ok := h.authorizeAppointment(c)
+	if !ok {
+		return
+	}
+
+	visitors, err := h.gateService.ListVisitors(appointment.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"visitors": visitors})
+}
+
+// RemoveVisitor handles removing a visitor from an appointment
+func (h *GateHandler) RemoveVisitor(c *gin.Context) {
+	appointment, ok := h.authorizeAppointment(c)
+	if !ok {
+		return
+	}
+
+	visitorID, err := strconv.ParseUint(c.Param("visitor_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid visitor ID"})
+		return
+	}
+
+	if err := h.gateService.RemoveVisitor(appointment.ID, uint(visitorID)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Visitor removed successfully"})
+}
+
+// RecordLocation handles a GPS ping from the driver app, which may trigger the arriving soon
+// notification and the automatic check-in
+func (h *GateHandler) RecordLocation(c *gin.Context) {
+	appointment, ok := h.authorizeAppointment(c)
+	if !ok {
+		return
+	}
+
+	var req LocationRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	position := geo.Point{Latitude: *req.Latitude, Longitude: *req.Longitude}
+	update, err := h.gateService.RecordLocation(appointment.ID, position, req.AccuracyMeters)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrInvalidPosition):
+			status = http.StatusBadRequest
+		case errors.Is(err, service.ErrAppointmentClosed):
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, update)
+}
+
+// CheckInCode handles issuing the signed check-in code of an appointment
+func (h *GateHandler) CheckInCode(c *gin.Context) {
+	appointment, ok := h.authorizeAppointment(c)
+	if !ok {
+		return
+	}
+
+	code, expiresAt, err := h.gateService.CheckInCode(appointment.ID)
+	if err != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"appointment_id": appointment.ID,
+		"booking_code":   appointment.BookingCode, // printed under the QR code for manual lookup
+		"code":           code,
+		"expires_at":     expiresAt,
+	})
+}
+
+// CheckIn handles a check-in code scanned at the gate
+func (h *GateHandler) CheckIn(c *gin.Context) {
+	var req CheckInRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	appointment, err := h.gateService.CheckIn(req.Code)
+	if err != nil {
+		status := http.StatusConflict
+		switch {
+		case errors.Is(err, service.ErrInvalidCheckInCode):
+			status = http.StatusBadRequest
+		case errors.Is(err, service.ErrCheckInCodeExpired):
+			status = http.StatusGone
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, _ := currentUser(c)
+	c.JSON(http.StatusOK, gin.H{"appointment": viewAppointment(h.visibilityService, appointment, user)})
+}
+
+// Manifest handles getting the gate manifest of an operation for a day
+func (h *GateHandler) Manifest(c *gin.Context) {
+	operationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+		return
+	}
+
+	// Without a date the manifest is for today at the operation, not on the server
+	var day *time.Time
+	if dateStr := c.Query("date"); dateStr != "" {
+		parsed, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid date format. Use YYYY-MM-DD"})
+			return
+		}
+		day = &parsed
+	}
+
+	manifest, err := h.gateService.Manifest(uint(operationID), day)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, manifest)
+}
+
+// authorizeAppointment loads the appointment from the path and checks the user may manage it.
+// Admins and employees may manage any appointment, suppliers only their own.
+func (h *GateHandler) authorizeAppointment(c *gin.Context) (*models.Appointment, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid appointment ID"})
+		return nil, false
+	}
+
+	user, ok := currentUser(c)
+	if !ok {
+		return nil, false
+	}
+
+	appointment, err := h.appointmentService.GetByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	if user.Role == "supplier" && appointment.Supplier.UserID != user.ID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to manage this appointment"})
+		return nil, false
+	}
+
+	return appointment, true
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// HandoverHandler handles shift handover notes and each employee's day
+type HandoverHandler struct {
+	handoverService service.HandoverService
+}
+
+// NewHandoverHandler creates a new handover handler
+func NewHandoverHandler(handoverService service.HandoverService) *HandoverHandler {
+	return &HandoverHandler{
+		handoverService: handoverService,
+	}
+}
+
+// HandoverRequest is the request body for leaving a handover note at an operation
+type HandoverRequest struct {
+	Date       string `json:"date"`        // "YYYY-MM-DD" at the operation, today when empty
+	ShiftStart string `json:"shift_start"` // "HH:MM" the incoming shift starts at, the next one when empty
+	Note       string `json:"note" binding:"required"`
+}
+
+// Create handles leaving a handover note for the incoming shift
+func (h *HandoverHandler) Create(c *gin.Context) {
+	operationID, ok := parseHandoverOperationID(c)
+	if !ok {
+		return
+	}
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req HandoverRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	handover := &models.ShiftHandover{
+		OperationID: operationID,
+		ShiftStart:  req.ShiftStart,
+		Note:        req.Note,
+	}
+	if req.Date != "" {
+		date, err := service.ParseBlackoutDate(req.Date)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		handover.Date = date
+	}
+
+	if err := h.handoverService.Create(handover, user); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"handover": handover})
+}
+
+// List handles getting the handover notes of an operation on a day
+func (h *HandoverHandler) List(c *gin.Context) {
+	operationID, ok := parseHandoverOperationID(c)
+	if !ok {
+		return
+	}
+	date, ok := parseHandoverDate(c)
+	if !ok {
+		return
+	}
+
+	handovers, err := h.handoverService.ListByDay(operationID, date)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"handovers": handovers, "count": len(handovers)})
+}
+
+// MyDay handles getting the signed-in employee's appointments and the handover notes at their
+// operations on a day
+func (h *HandoverHandler) MyDay(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	date, ok := parseHandoverDate(c)
+	if !ok {
+		return
+	}
+
+	day, err := h.handoverService.MyDay(user, date)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrNotAnEmployee) {
+			status = http.StatusForbidden
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	operations := make([]gin.H, 0, len(day.Operations))
+	for _, operation := range day.Operations {
+		operations = append(operations, gin.H{
+			"operation_id":   operation.OperationID,
+			"operation_name": operation.OperationName,
+			"date":           operation.Date,
+			"timezone":       operation.Timezone,
+			"appointments":   newAppointmentSummaries(operation.Appointments),
+			"handovers":      operation.Handovers,
+		})
+	}
+	c.JSON(http.StatusOK, gin.H{"employee_id": day.EmployeeID, "operations": operations})
+}
+
+// parseHandoverOperationID parses the operation ID from the path, writing a 400 if it's invalid
+func parseHandoverOperationID(c *gin.Context) (uint, bool) {
+	operationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+		return 0, false
+	}
+	return uint(operationID), true
+}
+
+// parseHandoverDate parses the optional date query parameter, writing a 400 if it's invalid.
+// Without a date, the day is today at each operation rather than on the server.
+func parseHandoverDate(c *gin.Context) (*time.Time, bool) {
+	dateStr := c.Query("date")
+	if dateStr == "" {
+		return nil, true
+	}
+	date, err := service.ParseBlackoutDate(dateStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return &date, true
+}
@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// HolidayHandler handles operation blackout calendars and their public holiday import
+type HolidayHandler struct {
+	holidayService service.HolidayService
+}
+
+// NewHolidayHandler creates a new holiday handler
+func NewHolidayHandler(holidayService service.HolidayService) *HolidayHandler {
+	return &HolidayHandler{
+		holidayService: holidayService,
+	}
+}
+
+// SetBlackoutRequest is the request body for closing or opening an operation on a date
+type SetBlackoutRequest struct {
+	Closed *bool  `json:"closed" binding:"required"`
+	Name   string `json:"name"`
+}
+
+// ListBlackouts handles listing an operation's blackout dates of a year, the current one by
+// default
+func (h *HolidayHandler) ListBlackouts(c *gin.Context) {
+	operationID, ok := parseBlackoutOperationID(c)
+	if !ok {
+		return
+	}
+	year, ok := parseBlackoutYear(c)
+	if !ok {
+		return
+	}
+
+	blackouts, err := h.holidayService.ListBlackouts(operationID, year)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"year": year, "blackouts": blackouts})
+}
+
+// SetBlackout handles closing an operation on a date, or keeping it open on a holiday
+func (h *HolidayHandler) SetBlackout(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	operationID, ok := parseBlackoutOperationID(c)
+	if !ok {
+		return
+	}
+	date, err := service.ParseBlackoutDate(c.Param("date"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req SetBlackoutRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	blackout, err := h.holidayService.SetBlackout(operationID, date, *req.Closed, req.Name, user.ID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"blackout": blackout})
+}
+
+// RemoveBlackout handles deleting a manual blackout date
+func (h *HolidayHandler) RemoveBlackout(c *gin.Context) {
+	operationID, ok := parseBlackoutOperationID(c)
+	if !ok {
+		return
+	}
+	date, err := service.ParseBlackoutDate(c.Param("date"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.holidayService.RemoveBlackout(c.Request.Context(), operationID, date); err != nil {
+		c.JSON(holidayErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Blackout date removed"})
+}
+
+// Sync handles importing a year of public holidays into an operation's calendar now, the
+// current year by default
+func (h *HolidayHandler) Sync(c *gin.Context) {
+	operationID, ok := parseBlackoutOperationID(c)
+	if !ok {
+		return
+	}
+	year, ok := parseBlackoutYear(c)
+	if !ok {
+		return
+	}
+
+	imported, err := h.holidayService.Sync(c.Request.Context(), operationID, year)
+	if err != nil {
+		c.JSON(holidayErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"year": year, "imported": imported})
+}
+
+// parseBlackoutOperationID parses the operation ID from the path, writing a 400 if it's invalid
+func parseBlackoutOperationID(c *gin.Context) (uint, bool) {
+	operationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+		return 0, false
+	}
+	return uint(operationID), true
+}
+
+// parseBlackoutYear parses the year query parameter, writing a 400 if it's invalid. Without one
+// it returns zero, which the service takes as the current year.
+func parseBlackoutYear(c *gin.Context) (int, bool) {
+	value := c.Query("year")
+	if value == "" {
+		return 0, true
+	}
+	year, err := strconv.Atoi(value)
+	if err != nil || year < 1900 || year > 2200 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid year"})
+		return 0, false
+	}
+	return year, true
+}
+
+// holidayErrorStatus maps holiday and blackout errors to HTTP statuses
+func holidayErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrBlackoutNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrBlackoutImported), errors.Is(err, service.ErrHolidaysNotImported), errors.Is(err, service.ErrHolidaysDisabled):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrHolidaySyncFailed):
+		return http.StatusBadGateway
+	default:
+		return http.StatusBadRequest
+	}
+}
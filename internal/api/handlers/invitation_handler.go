@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// InvitationHandler handles self-service booking invitations
+type InvitationHandler struct {
+	invitationService service.InvitationService
+}
+
+// NewInvitationHandler creates a new invitation handler
+func NewInvitationHandler(invitationService service.InvitationService) *InvitationHandler {
+	return &InvitationHandler{invitationService: invitationService}
+}
+
+// CreateInvitationRequest represents the request body for inviting a supplier to book
+type CreateInvitationRequest struct {
+	SupplierID        uint                   `json:"supplier_id" binding:"required"`
+	OperationID       uint                   `json:"operation_id" binding:"required"`
+	EmployeeID        uint                   `json:"employee_id"` // admins only; employees invite on their own behalf
+	Type              models.AppointmentType `json:"type"`
+	ProductID         *uint                  `json:"product_id"`
+	QuantityToDeliver int                    `json:"quantity_to_deliver"`
+	DurationMinutes   int                    `json:"duration_minutes" binding:"required"`
+	BookFrom          time.Time              `json:"book_from"`
+	BookUntil         time.Time              `json:"book_until"`
+	ValidForHours     int                    `json:"valid_for_hours"`
+	Notes             string                 `json:"notes"`
+	NotifySupplier    bool                   `json:"notify_supplier"`
+}
+
+// BookInvitationRequest represents the request body for booking through an invitation
+type BookInvitationRequest struct {
+	ScheduledStart    time.Time `json:"scheduled_start" binding:"required"`
+	QuantityToDeliver int       `json:"quantity_to_deliver"`
+	Notes             string    `json:"notes"`
+}
+
+// Create handles issuing a booking invitation. The token and link are only returned here.
+func (h *InvitationHandler) Create(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req CreateInvitationRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	issued, err := h.invitationService.CreateInvitation(user, &models.BookingInvitation{
+		InviterID:         req.EmployeeID,
+		SupplierID:        req.SupplierID,
+		OperationID:       req.OperationID,
+		Type:              req.Type,
+		ProductID:         req.ProductID,
+		QuantityToDeliver: req.QuantityToDeliver,
+		DurationMinutes:   req.DurationMinutes,
+		BookFrom:          req.BookFrom,
+		BookUntil:         req.BookUntil,
+		Notes:             req.Notes,
+	}, time.Duration(req.ValidForHours)*time.Hour, req.NotifySupplier)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, issued)
+}
+
+// List handles listing the invitations sent on behalf of an employee. Employees see their own;
+// admins pass employee_id.
+func (h *InvitationHandler) List(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var inviterID uint
+	if user.Role == "employee" {
+		id, err := h.invitationService.InviterFor(user)
+		if err != nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		inviterID = id
+	} else {
+		id, err := strconv.ParseUint(c.Query("employee_id"), 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "employee_id is required"})
+			return
+		}
+		inviterID = uint(id)
+	}
+
+	invitations, err := h.invitationService.ListInvitations(inviterID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"invitations": invitations})
+}
+
+// Revoke handles disabling an invitation link. Employees can only revoke their own.
+func (h *InvitationHandler) Revoke(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	invitationID, err := strconv.ParseUint(c.Param("invitation_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid invitation ID"})
+		return
+	}
+
+	invitation, err := h.invitationService.GetInvitation(uint(invitationID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if user.Role == "employee" {
+		inviterID, err := h.invitationService.InviterFor(user)
+		if err != nil || inviterID != invitation.InviterID {
+			c.JSON(http.StatusNotFound, gin.H{"error": service.ErrInvitationNotFound.Error()})
+			return
+		}
+	}
+
+	invitation, err = h.invitationService.RevokeInvitation(invitation.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, invitation)
+}
+
+// View handles showing what an invitation link offers. No authentication is required.
+func (h *InvitationHandler) View(c *gin.Context) {
+	view, err := h.invitationService.ViewInvitation(c.Param("token"))
+	if err != nil {
+		c.JSON(invitationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+// Slots handles listing the slots open to an invitation. start_date and end_date are optional
+// RFC 3339 timestamps and default to the invitation's booking window.
+func (h *InvitationHandler) Slots(c *gin.Context) {
+	var from, to time.Time
+	if s := c.Query("start_date"); s != "" {
+		parsed, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "start_date must be an RFC 3339 timestamp"})
+			return
+		}
+		from = parsed
+	}
+	if s := c.Query("end_date"); s != "" {
+		parsed, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "end_date must be an RFC 3339 timestamp"})
+			return
+		}
+		to = parsed
+	}
+
+	slots, err := h.invitationService.InvitationSlots(c.Param("token"), from, to)
+	if err != nil {
+		c.JSON(invitationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"slots": slots})
+}
+
+// Book handles creating a pending appointment through an invitation
+func (h *InvitationHandler) Book(c *gin.Context) {
+	var req BookInvitationRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	appointment, err := h.invitationService.BookInvitation(c.Param("token"), service.InvitationBooking{
+		ScheduledStart:    req.ScheduledStart,
+		QuantityToDeliver: req.QuantityToDeliver,
+		Notes:             req.Notes,
+	})
+	if err != nil {
+		c.JSON(invitationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"id":              appointment.ID,
+		"booking_code":    appointment.BookingCode,
+		"status":          appointment.Status,
+		"scheduled_start": appointment.ScheduledStart,
+		"scheduled_end":   appointment.ScheduledEnd,
+	})
+}
+
+// invitationErrorStatus maps invitation errors to HTTP statuses
+func invitationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvitationNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvitationExpired), errors.Is(err, service.ErrInvitationUsed),
+		errors.Is(err, service.ErrInvitationRevoked):
+		return http.StatusGone
+	case errors.Is(err, service.ErrSlotNotOpen):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
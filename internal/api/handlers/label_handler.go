@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// LabelHandler handles the pallet labels receiving docks print for appointments
+type LabelHandler struct {
+	labelService       service.LabelService
+	appointmentService service.AppointmentService
+}
+
+// NewLabelHandler creates a new label handler
+func NewLabelHandler(labelService service.LabelService, appointmentService service.AppointmentService) *LabelHandler {
+	return &LabelHandler{
+		labelService:       labelService,
+		appointmentService: appointmentService,
+	}
+}
+
+// LabelTemplateRequest represents the request body for replacing an operation's label template
+type LabelTemplateRequest struct {
+	Body string `json:"body" binding:"required"`
+}
+
+// Labels handles printing an appointment's labels in a printer format (format, default zpl).
+// count labels are printed, numbered, one per pallet (default 1).
+func (h *LabelHandler) Labels(c *gin.Context) {
+	appointment, ok := h.authorizeAppointment(c)
+	if !ok {
+		return
+	}
+
+	count := 1
+	if value := c.Query("count"); value != "" {
+		var err error
+		if count, err = strconv.Atoi(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": service.ErrInvalidLabelCount.Error()})
+			return
+		}
+	}
+	format := c.DefaultQuery("format", models.LabelFormatZPL)
+
+	labels, err := h.labelService.Render(appointment, format, count)
+	if err != nil {
+		respondLabelError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "labels-"+appointment.Reference()+"."+format))
+	c.Data(http.StatusOK, "application/zpl; charset=utf-8", labels)
+}
+
+// GetTemplate handles getting the label template an operation prints with (format, default zpl)
+func (h *LabelHandler) GetTemplate(c *gin.Context) {
+	operationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+		return
+	}
+
+	template, err := h.labelService.Template(uint(operationID), c.DefaultQuery("format", models.LabelFormatZPL))
+	if err != nil {
+		respondLabelError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"template": template})
+}
+
+// SetTemplate handles replacing an operation's label template (format, default zpl)
+func (h *LabelHandler) SetTemplate(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	operationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+		return
+	}
+
+	var req LabelTemplateRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	template, err := h.labelService.SetTemplate(uint(operationID), c.DefaultQuery("format", models.LabelFormatZPL), req.Body, user.ID)
+	if err != nil {
+		respondLabelError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"template": template})
+}
+
+// ResetTemplate handles bringing back the built-in label template of an operation (format,
+// default zpl)
+func (h *LabelHandler) ResetTemplate(c *gin.Context) {
+	operationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+		return
+	}
+
+	template, err := h.labelService.ResetTemplate(uint(operationID), c.DefaultQuery("format", models.LabelFormatZPL))
+	if err != nil {
+		respondLabelError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"template": template})
+}
+
+// authorizeAppointment parses the appointment ID from the path and checks the user may access it
+func (h *LabelHandler) authorizeAppointment(c *gin.Context) (*models.Appointment, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid appointment ID"})
+		return nil, false
+	}
+
+	user, ok := currentUser(c)
+	if !ok {
+		return nil, false
+	}
+
+	appointment, err := h.appointmentService.GetByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	if user.Role == "supplier" && appointment.Supplier.UserID != user.ID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to access this appointment"})
+		return nil, false
+	}
+
+	return appointment, true
+}
+
+// respondLabelError answers a failed label request with the status matching its error
+func respondLabelError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, service.ErrUnsupportedLabelFormat),
+		errors.Is(err, service.ErrInvalidLabelCount),
+		errors.Is(err, service.ErrInvalidLabelTemplate):
+		status = http.StatusBadRequest
+	case errors.Is(err, repository.ErrOperationNotFound):
+		status = http.StatusNotFound
+	}
+	c.JSON(status, gin.H{"error": err.Error()})
+}
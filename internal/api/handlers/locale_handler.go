@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// LocaleHandler handles the language and timezone users are served in
+type LocaleHandler struct {
+	localeService service.LocaleService
+}
+
+// NewLocaleHandler creates a new locale handler
+func NewLocaleHandler(localeService service.LocaleService) *LocaleHandler {
+	return &LocaleHandler{
+		localeService: localeService,
+	}
+}
+
+// LocaleRequest represents the request body for setting a user's language and timezone
+type LocaleRequest struct {
+	Locale   string `json:"locale" binding:"required"`
+	Timezone string `json:"timezone"` // IANA name; empty uses the employee's operation's, or UTC
+}
+
+// Get handles showing the language and timezone the user set, and those the request is served
+// in after its headers are taken into account
+func (h *LocaleHandler) Get(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	preferences, err := h.localeService.GetPreferences(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"preferences": preferences, "request": requestLocale(c)})
+}
+
+// Update handles setting the language and timezone the user is served in when their requests
+// don't ask for one, and their notifications are written in
+func (h *LocaleHandler) Update(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req LocaleRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	preferences, err := h.localeService.UpdatePreferences(user.ID, service.LocalePreferences{
+		Locale:   req.Locale,
+		Timezone: req.Timezone,
+	})
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrUnsupportedLocale) || errors.Is(err, service.ErrUnknownTimezone) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"preferences": preferences})
+}
+
+// requestLocale describes the locale and timezone a request is served in
+func requestLocale(c *gin.Context) gin.H {
+	preferences := middleware.RequestPreferences(c)
+	return gin.H{"locale": preferences.Locale, "timezone": preferences.Location.String()}
+}
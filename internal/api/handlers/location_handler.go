@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+	"github.com/bernardofernandezz/scheduling-api/pkg/geo"
+)
+
+// LocationHandler handles address geocoding and travel time checks
+type LocationHandler struct {
+	locationService service.LocationService
+}
+
+// NewLocationHandler creates a new location handler
+func NewLocationHandler(locationService service.LocationService) *LocationHandler {
+	return &LocationHandler{
+		locationService: locationService,
+	}
+}
+
+// CheckTravelRequest is the request body for checking whether a supplier can make a slot in time
+type CheckTravelRequest struct {
+	AppointmentID  uint      `json:"appointment_id"`
+	SupplierID     uint      `json:"supplier_id" binding:"required"`
+	OperationID    uint      `json:"operation_id" binding:"required"`
+	ScheduledStart time.Time `json:"scheduled_start" binding:"required"`
+	ScheduledEnd   time.Time `json:"scheduled_end" binding:"required"`
+}
+
+// GeocodeOperation handles geocoding the address of an operation
+func (h *LocationHandler) GeocodeOperation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+		return
+	}
+
+	operation, err := h.locationService.GeocodeOperation(uint(id))
+	if err != nil {
+		c.JSON(geocodeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"operation": operation})
+}
+
+// GeocodeSupplier handles geocoding the address of a supplier
+func (h *LocationHandler) GeocodeSupplier(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid supplier ID"})
+		return
+	}
+
+	supplier, err := h.locationService.GeocodeSupplier(uint(id))
+	if err != nil {
+		c.JSON(geocodeErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"supplier": supplier})
+}
+
+// CheckTravel handles checking a prospective slot against the supplier's other appointments
+func (h *LocationHandler) CheckTravel(c *gin.Context) {
+	var req CheckTravelRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	if !req.ScheduledStart.Before(req.ScheduledEnd) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Start time must be before end time"})
+		return
+	}
+
+	appointment := &models.Appointment{
+		SupplierID:     req.SupplierID,
+		OperationID:    req.OperationID,
+		ScheduledStart: req.ScheduledStart,
+		ScheduledEnd:   req.ScheduledEnd,
+	}
+	appointment.ID = req.AppointmentID
+
+	warnings, err := h.locationService.CheckTravel(appointment)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"feasible": len(warnings) == 0,
+		"warnings": warnings,
+	})
+}
+
+// geocodeErrorStatus maps a geocoding error to an HTTP status
+func geocodeErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, geo.ErrAddressNotFound), errors.Is(err, service.ErrMissingAddress):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, service.ErrGeocodingDisabled):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, service.ErrGeocodingFailed):
+		return http.StatusBadGateway
+	default:
+		return http.StatusNotFound
+	}
+}
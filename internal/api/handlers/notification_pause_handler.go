@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// NotificationPauseHandler handles notification maintenance window requests
+type NotificationPauseHandler struct {
+	pauseService service.NotificationPauseService
+}
+
+// NewNotificationPauseHandler creates a new notification pause handler
+func NewNotificationPauseHandler(pauseService service.NotificationPauseService) *NotificationPauseHandler {
+	return &NotificationPauseHandler{
+		pauseService: pauseService,
+	}
+}
+
+// PauseNotificationsRequest represents the request body for pausing notifications
+type PauseNotificationsRequest struct {
+	Reason         string                     `json:"reason" binding:"required"`
+	EndsAt         *time.Time                 `json:"ends_at"`
+	CriticalEvents []models.NotificationEvent `json:"critical_events"` // Omit to use the configured defaults
+}
+
+// Status handles getting the active maintenance window and recent history
+func (h *NotificationPauseHandler) Status(c *gin.Context) {
+	pause, err := h.pauseService.ActivePause()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	history, err := h.pauseService.History(10)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"paused":  pause != nil,
+		"pause":   pause,
+		"history": history,
+	})
+}
+
+// Pause handles starting a notification maintenance window
+func (h *NotificationPauseHandler) Pause(c *gin.Context) {
+	var req PauseNotificationsRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	pause, err := h.pauseService.Pause(service.PauseRequest{
+		Reason:         req.Reason,
+		EndsAt:         req.EndsAt,
+		CriticalEvents: req.CriticalEvents,
+	}, user.ID)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, service.ErrNotificationsPaused) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"pause": pause})
+}
+
+// Resume handles ending the active maintenance window and releasing held notifications
+func (h *NotificationPauseHandler) Resume(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	pause, err := h.pauseService.Resume(user.ID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrNotificationsNotPaused) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"pause": pause})
+}
+
+// currentUser returns the authenticated user, writing an error response if there is none
+func currentUser(c *gin.Context) (*models.User, bool) {
+	userObj, exists := c.Get("user")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return nil, false
+	}
+
+	user, ok := userObj.(*models.User)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user object"})
+		return nil, false
+	}
+
+	return user, true
+}
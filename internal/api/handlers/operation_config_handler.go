@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// OperationConfigHandler handles operation configuration export and import requests
+type OperationConfigHandler struct {
+	configService service.OperationConfigService
+}
+
+// NewOperationConfigHandler creates a new operation configuration handler
+func NewOperationConfigHandler(configService service.OperationConfigService) *OperationConfigHandler {
+	return &OperationConfigHandler{
+		configService: configService,
+	}
+}
+
+// Export handles exporting an operation's scheduling configuration as JSON or YAML. The
+// notification templates, shared by all operations, are included with ?templates=true.
+func (h *OperationConfigHandler) Export(c *gin.Context) {
+	// Parse operation ID from path
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+		return
+	}
+
+	doc, err := h.configService.Export(uint(id), c.Query("templates") == "true")
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("operation-%s", doc.Operation.Code)
+	switch c.DefaultQuery("format", "json") {
+	case "yaml", "yml":
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s.yaml", filename))
+		c.YAML(http.StatusOK, doc)
+	case "json":
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s.json", filename))
+		c.JSON(http.StatusOK, doc)
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid format. Use json or yaml"})
+	}
+}
+
+// Preview handles computing the diff an import would apply without changing anything
+func (h *OperationConfigHandler) Preview(c *gin.Context) {
+	doc, ok := bindOperationConfig(c)
+	if !ok {
+		return
+	}
+
+	changes, err := h.configService.Preview(doc)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"operation_code": doc.Operation.Code,
+		"changes":        changes,
+		"count":          len(changes),
+	})
+}
+
+// Import handles applying an operation configuration document
+func (h *OperationConfigHandler) Import(c *gin.Context) {
+	doc, ok := bindOperationConfig(c)
+	if !ok {
+		return
+	}
+
+	changes, err := h.configService.Import(doc)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"operation_code": doc.Operation.Code,
+		"changes":        changes,
+		"count":          len(changes),
+	})
+}
+
+// bindOperationConfig decodes a configuration document from a JSON or YAML request body
+func bindOperationConfig(c *gin.Context) (*service.OperationConfigDocument, bool) {
+	var doc service.OperationConfigDocument
+
+	var err error
+	contentType := c.ContentType()
+	if strings.Contains(contentType, "yaml") || strings.Contains(contentType, "yml") {
+		err = c.ShouldBindYAML(&doc)
+	} else {
+		err = middleware.BindJSON(c, &doc)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid configuration document: " + err.Error()})
+		return nil, false
+	}
+
+	return &doc, true
+}
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// PartnerHandler handles partner API credentials and the calls partner systems make with them
+type PartnerHandler struct {
+	partnerService     service.PartnerService
+	appointmentService service.AppointmentService
+}
+
+// NewPartnerHandler creates a new partner handler
+func NewPartnerHandler(partnerService service.PartnerService, appointmentService service.AppointmentService) *PartnerHandler {
+	return &PartnerHandler{
+		partnerService:     partnerService,
+		appointmentService: appointmentService,
+	}
+}
+
+// CreatePartnerCredentialRequest is the request body for issuing a partner credential
+type CreatePartnerCredentialRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// PartnerReferenceRequest is the request body partners push an appointment's reference in their
+// system and purchase order with. Omitted fields are left as they are.
+type PartnerReferenceRequest struct {
+	ExternalRef   *string `json:"external_ref"`
+	PurchaseOrder *string `json:"purchase_order"`
+}
+
+// ListCredentials handles listing the partner credentials, without their secrets
+func (h *PartnerHandler) ListCredentials(c *gin.Context) {
+	credentials, err := h.partnerService.ListCredentials()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"credentials": credentials})
+}
+
+// CreateCredential handles issuing a key ID and secret for a partner. The secret is only shown in
+// this response.
+func (h *PartnerHandler) CreateCredential(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req CreatePartnerCredentialRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	credential, secret, err := h.partnerService.CreateCredential(req.Name, user.ID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"credential": credential, "secret": secret})
+}
+
+// RotateSecret handles issuing a new secret for a partner, keeping the previous one valid for the
+// rotation grace period. The new secret is only shown in this response.
+func (h *PartnerHandler) RotateSecret(c *gin.Context) {
+	id, ok := parsePartnerCredentialID(c)
+	if !ok {
+		return
+	}
+
+	credential, secret, err := h.partnerService.RotateSecret(id)
+	if err != nil {
+		c.JSON(partnerErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"credential": credential, "secret": secret})
+}
+
+// RevokeCredential handles stopping a partner's secrets from being accepted
+func (h *PartnerHandler) RevokeCredential(c *gin.Context) {
+	id, ok := parsePartnerCredentialID(c)
+	if !ok {
+		return
+	}
+
+	credential, err := h.partnerService.RevokeCredential(id)
+	if err != nil {
+		c.JSON(partnerErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"credential": credential})
+}
+
+// SetReference handles a partner system pushing its reference and purchase order for the
+// appointment with a booking code
+func (h *PartnerHandler) SetReference(c *gin.Context) {
+	partner, ok := currentPartner(c)
+	if !ok {
+		return
+	}
+
+	var req PartnerReferenceRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	appointment, err := h.appointmentService.SetPartnerReference(c.Param("code"), service.PartnerReference{
+		ExternalRef:   req.ExternalRef,
+		PurchaseOrder: req.PurchaseOrder,
+	})
+	if err != nil {
+		c.JSON(partnerErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	log.Printf("Partner %s (%s) set the reference of appointment %s", partner.Name, partner.KeyID, appointment.BookingCode)
+
+	c.JSON(http.StatusOK, gin.H{
+		"booking_code":   appointment.BookingCode,
+		"status":         appointment.Status,
+		"external_ref":   appointment.ExternalRef,
+		"purchase_order": appointment.PurchaseOrder,
+		"updated_at":     appointment.UpdatedAt,
+	})
+}
+
+// currentPartner returns the partner credential that signed the request, writing a 401 if there
+// is none
+func currentPartner(c *gin.Context) (*models.PartnerCredential, bool) {
+	value, exists := c.Get("partner")
+	partner, ok := value.(*models.PartnerCredential)
+	if !exists || !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Partner signature required"})
+		return nil, false
+	}
+	return partner, true
+}
+
+// parsePartnerCredentialID parses the credential ID from the path, writing a 400 if it's invalid
+func parsePartnerCredentialID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid partner credential ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// partnerErrorStatus maps partner credential and reference errors to HTTP statuses
+func partnerErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrPartnerRevoked):
+		return http.StatusConflict
+	case errors.Is(err, repository.ErrPartnerCredentialNotFound), errors.Is(err, repository.ErrAppointmentNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadRequest
+	}
+}
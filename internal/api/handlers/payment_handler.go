@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// maxPaymentEventBytes bounds the webhook requests read from the payment provider
+const maxPaymentEventBytes = 1 << 20
+
+// PaymentHandler handles the payment provider's webhook reporting what became of booking deposits
+type PaymentHandler struct {
+	appointmentService service.AppointmentService
+}
+
+// NewPaymentHandler creates a new payment handler
+func NewPaymentHandler(appointmentService service.AppointmentService) *PaymentHandler {
+	return &PaymentHandler{
+		appointmentService: appointmentService,
+	}
+}
+
+// Webhook handles an event the payment provider sends about a deposit. The signature over the
+// raw body authenticates it, so the route is public. Anything but a 2xx answer makes the provider
+// send the event again later.
+func (h *PaymentHandler) Webhook(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxPaymentEventBytes))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	if err := h.appointmentService.HandlePaymentEvent(c.Request.Header, body); err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidPaymentEvent):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrDepositsUnavailable):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			log.Printf("Failed to handle payment event: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to handle payment event"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"received": true})
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// QuickAddHandler handles reading appointments from short free-text descriptions
+type QuickAddHandler struct {
+	quickAddService service.QuickAddService
+}
+
+// NewQuickAddHandler creates a new quick-add handler
+func NewQuickAddHandler(quickAddService service.QuickAddService) *QuickAddHandler {
+	return &QuickAddHandler{
+		quickAddService: quickAddService,
+	}
+}
+
+// QuickAddRequest represents the request body for reading an appointment from text
+type QuickAddRequest struct {
+	Text string `json:"text" binding:"required"`
+}
+
+// Parse handles reading a draft appointment from a text such as
+// "Supplier Acme, 40 boxes SKU-123, SP warehouse, Tuesday 9am". Nothing is booked: the draft
+// says how sure each field is and what couldn't be read, for the admin to complete and post to
+// POST /appointments.
+func (h *QuickAddHandler) Parse(c *gin.Context) {
+	var req QuickAddRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	draft, err := h.quickAddService.Parse(c.Request.Context(), req.Text)
+	if err != nil {
+		if errors.Is(err, service.ErrQuickAddText) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"draft": draft})
+}
@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// RegionHandler handles regions, the groups of operations regional managers follow together
+type RegionHandler struct {
+	regionService service.RegionService
+}
+
+// NewRegionHandler creates a new region handler
+func NewRegionHandler(regionService service.RegionService) *RegionHandler {
+	return &RegionHandler{
+		regionService: regionService,
+	}
+}
+
+// RegionRequest represents the request body for creating or replacing a region
+type RegionRequest struct {
+	Code         string `json:"code" binding:"required"`
+	Name         string `json:"name" binding:"required"`
+	OperationIDs []uint `json:"operation_ids"`
+	ManagerIDs   []uint `json:"manager_ids"` // users of employees who may follow the region
+}
+
+// Create handles creating a region
+func (h *RegionHandler) Create(c *gin.Context) {
+	var req RegionRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	region, err := h.regionService.Create(newRegion(&req))
+	if err != nil {
+		c.JSON(regionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"region": region})
+}
+
+// Update handles replacing a region's code, name, operations and managers
+func (h *RegionHandler) Update(c *gin.Context) {
+	id, ok := parseRegionID(c)
+	if !ok {
+		return
+	}
+
+	var req RegionRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	region, err := h.regionService.Update(id, newRegion(&req))
+	if err != nil {
+		c.JSON(regionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"region": region})
+}
+
+// Delete handles deleting a region
+func (h *RegionHandler) Delete(c *gin.Context) {
+	id, ok := parseRegionID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.regionService.Delete(id); err != nil {
+		c.JSON(regionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Region deleted successfully"})
+}
+
+// List handles listing the regions the signed-in user can follow
+func (h *RegionHandler) List(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	regions, err := h.regionService.List(user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"regions": regions})
+}
+
+// Get handles getting a region with its operations and managers
+func (h *RegionHandler) Get(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	id, ok := parseRegionID(c)
+	if !ok {
+		return
+	}
+
+	region, err := h.regionService.Get(user, id)
+	if err != nil {
+		c.JSON(regionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"region": region})
+}
+
+// ListAppointments handles listing the appointments of a region's operations, with the filters of
+// the appointment list
+func (h *RegionHandler) ListAppointments(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	id, ok := parseRegionID(c)
+	if !ok {
+		return
+	}
+
+	filters := GetAppointmentFilters(c)
+	appointments, total, err := h.regionService.Appointments(user, id, filters)
+	if err != nil {
+		c.JSON(regionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	respond(c, http.StatusOK, newAppointmentList(appointments, total, filters))
+}
+
+// GetStatistics handles counting the appointments of a region's operations by status, per
+// operation and in total, between start_date and end_date or over the last 30 days
+func (h *RegionHandler) GetStatistics(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	id, ok := parseRegionID(c)
+	if !ok {
+		return
+	}
+
+	from, ok := parseRegionDate(c, "start_date")
+	if !ok {
+		return
+	}
+	to, ok := parseRegionDate(c, "end_date")
+	if !ok {
+		return
+	}
+
+	statistics, err := h.regionService.Statistics(user, id, from, to)
+	if err != nil {
+		c.JSON(regionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"statistics": statistics})
+}
+
+// newRegion maps a region request
+func newRegion(req *RegionRequest) *models.Region {
+	return &models.Region{
+		Code:         req.Code,
+		Name:         req.Name,
+		OperationIDs: req.OperationIDs,
+		ManagerIDs:   req.ManagerIDs,
+	}
+}
+
+// parseRegionID parses the region ID from the path, writing a 400 if it's invalid
+func parseRegionID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid region ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// parseRegionDate parses an optional RFC 3339 date from the query, writing a 400 if it's invalid
+func parseRegionDate(c *gin.Context, name string) (*time.Time, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, true
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + name + ", expected RFC 3339"})
+		return nil, false
+	}
+	return &parsed, true
+}
+
+// regionErrorStatus maps region errors to HTTP statuses
+func regionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrRegionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrRegionForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrRegionCodeTaken):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/render"
+)
+
+// localized is a payload whose times are presented in the request's timezone
+type localized interface {
+	localize(location *time.Location)
+}
+
+// respond writes a payload in the media type the request's Accept header prefers among JSON,
+// XML and CSV. Clients accepting none of them, or asking for a media type the payload can't be
+// encoded in, get a 406 Not Acceptable listing the supported ones. Times of localized payloads
+// are converted to the request's timezone first.
+func respond(c *gin.Context, status int, payload interface{}) {
+	c.Writer.Header().Add("Vary", "Accept")
+	if payload, ok := payload.(localized); ok {
+		payload.localize(middleware.RequestTimezone(c))
+	}
+
+	encoder, ok := render.Default.Negotiate(c.GetHeader("Accept"))
+	if !ok {
+		notAcceptable(c)
+		return
+	}
+
+	// Encode up front so a payload the encoder can't represent doesn't leave half a response
+	var body bytes.Buffer
+	if err := encoder.Encode(&body, payload); err != nil {
+		if errors.Is(err, render.ErrUnsupportedPayload) {
+			notAcceptable(c)
+			return
+		}
+		log.Printf("Failed to encode %s response: %v", encoder.MediaType(), err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to encode response"})
+		return
+	}
+	c.Data(status, encoder.ContentType(), body.Bytes())
+}
+
+// stream writes a large payload as it is read from the database, in JSON or CSV as the Accept
+// header prefers, with chunked transfer encoding. Each batch is written to the client before the
+// next is read, so a slow client slows the reads down rather than rows piling up in memory, and
+// a client going away stops them. Errors before the first batch get a 500; later ones can only
+// cut the response short and are logged.
+func stream(c *gin.Context, payload render.Stream) {
+	c.Writer.Header().Add("Vary", "Accept")
+	negotiated, ok := render.Streaming.Negotiate(c.GetHeader("Accept"))
+	if !ok {
+		notAcceptableFrom(c, render.Streaming)
+		return
+	}
+	encoder := negotiated.(render.StreamEncoder)
+
+	c.Header("Content-Type", encoder.ContentType())
+	c.Status(http.StatusOK)
+	err := encoder.EncodeStream(c.Writer, cancellableStream{Stream: payload, ctx: c.Request.Context()})
+	if err == nil || errors.Is(err, context.Canceled) {
+		return
+	}
+	if !c.Writer.Written() {
+		c.Writer.Header().Del("Content-Type")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	log.Printf("Streaming %s %s stopped: %v", encoder.MediaType(), c.Request.URL.Path, err)
+}
+
+// cancellableStream stops reading a stream once the request's context is done
+type cancellableStream struct {
+	render.Stream
+	ctx context.Context
+}
+
+// Each calls fn with each batch while the request is still wanted
+func (s cancellableStream) Each(fn func(rows []render.Row) error) error {
+	return s.Stream.Each(func(rows []render.Row) error {
+		if err := s.ctx.Err(); err != nil {
+			return err
+		}
+		return fn(rows)
+	})
+}
+
+// notAcceptable writes a 406 listing the media types responses can be negotiated to
+func notAcceptable(c *gin.Context) {
+	notAcceptableFrom(c, render.Default)
+}
+
+// notAcceptableFrom writes a 406 listing the media types of a registry
+func notAcceptableFrom(c *gin.Context, registry *render.Registry) {
+	c.JSON(http.StatusNotAcceptable, gin.H{
+		"error":                 "Unsupported media type in Accept: " + c.GetHeader("Accept"),
+		"supported_media_types": registry.MediaTypes(),
+	})
+}
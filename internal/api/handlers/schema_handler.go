@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/pkg/events"
+)
+
+// SchemaHandler handles publishing the JSON Schemas of the CloudEvents the API emits and
+// checking sample events against them
+type SchemaHandler struct {
+	catalog *events.Catalog
+}
+
+// NewSchemaHandler creates a new schema handler
+func NewSchemaHandler(catalog *events.Catalog) *SchemaHandler {
+	return &SchemaHandler{
+		catalog: catalog,
+	}
+}
+
+// SchemaSummary describes a published schema version
+type SchemaSummary struct {
+	Type        string `json:"type"`
+	Version     int    `json:"version"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	URL         string `json:"url"` // the dataschema of events following this version
+}
+
+// List handles listing every published event schema version
+func (h *SchemaHandler) List(c *gin.Context) {
+	base := strings.TrimRight(c.Request.URL.Path, "/")
+	schemas := h.catalog.List()
+	summaries := make([]SchemaSummary, 0, len(schemas))
+	for _, schema := range schemas {
+		summaries = append(summaries, SchemaSummary{
+			Type:        schema.Type,
+			Version:     schema.Version,
+			Title:       schema.Title,
+			Description: schema.Description,
+			URL:         base + "/" + schema.Path(),
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"specversion": events.SpecVersion,
+		"schemas":     summaries,
+	})
+}
+
+// Get handles getting a version of an event type's schema, e.g.
+// /schemas/com.scheduling.appointment.created/v1
+func (h *SchemaHandler) Get(c *gin.Context) {
+	version, err := strconv.Atoi(strings.TrimPrefix(c.Param("version"), "v"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid schema version, use v1, v2, ..."})
+		return
+	}
+	schema, ok := h.catalog.Find(c.Param("type"), version)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "schema not found"})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/schema+json", schema.Document)
+}
+
+// Validate handles checking a structured mode CloudEvent against the published schema its
+// dataschema names, so consumers can test their fixtures against the contract
+func (h *SchemaHandler) Validate(c *gin.Context) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read request body"})
+		return
+	}
+	event, err := events.Decode(body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	problems := h.catalog.Validate(event)
+	if problems == nil {
+		problems = []string{}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"valid":  len(problems) == 0,
+		"errors": problems,
+	})
+}
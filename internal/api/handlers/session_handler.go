@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+	"github.com/bernardofernandezz/scheduling-api/pkg/auth"
+)
+
+// SessionHandler handles signing out, which revokes issued tokens before they expire
+type SessionHandler struct {
+	revocationService service.TokenRevocationService
+}
+
+// NewSessionHandler creates a new session handler
+func NewSessionHandler(revocationService service.TokenRevocationService) *SessionHandler {
+	return &SessionHandler{
+		revocationService: revocationService,
+	}
+}
+
+// LogoutRequest represents the optional request body for signing out
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"` // revoked with the access token when given
+}
+
+// Logout handles signing out: the access token the request was made with, and the refresh token
+// if given, are refused from now on
+func (h *SessionHandler) Logout(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req LogoutRequest
+	if c.Request.ContentLength > 0 {
+		if err := middleware.BindJSON(c, &req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+			return
+		}
+	}
+
+	tokens := []string{strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")}
+	if req.RefreshToken != "" {
+		tokens = append(tokens, req.RefreshToken)
+	}
+	for _, token := range tokens {
+		identity := auth.IdentifyToken(token)
+		// A refresh token of someone else is ignored rather than revoked on their behalf
+		if identity.UserID != 0 && identity.UserID != user.ID {
+			continue
+		}
+		if err := h.revocationService.RevokeToken(user.ID, identity.ID, identity.ExpiresAt, models.RevocationLogout); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Signed out successfully"})
+}
+
+// LogoutAll handles signing out everywhere: every token the signed-in user was issued until now
+// is refused, on every device
+func (h *SessionHandler) LogoutAll(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.revocationService.RevokeUser(user.ID, models.RevocationLogoutAll); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Signed out of every session successfully"})
+}
+
+// RevokeUser handles an admin revoking every token a user was issued until now, e.g. after
+// taking away their role or when their account is compromised
+func (h *SessionHandler) RevokeUser(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	if err := h.revocationService.RevokeUser(uint(id), models.RevocationAdmin); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User tokens revoked successfully"})
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// SettingsHandler handles the operational parameters admins change at runtime
+type SettingsHandler struct {
+	settingsService service.SettingsService
+}
+
+// NewSettingsHandler creates a new settings handler
+func NewSettingsHandler(settingsService service.SettingsService) *SettingsHandler {
+	return &SettingsHandler{
+		settingsService: settingsService,
+	}
+}
+
+// UpdateSettingRequest represents the request body for changing a setting. The value is a JSON
+// string or, for whole numbers, a JSON number.
+type UpdateSettingRequest struct {
+	Value json.RawMessage `json:"value"`
+}
+
+// List handles listing the runtime settings with their values in effect
+func (h *SettingsHandler) List(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"settings": h.settingsService.List()})
+}
+
+// Get handles getting a runtime setting
+func (h *SettingsHandler) Get(c *gin.Context) {
+	setting, err := h.settingsService.Get(c.Param("key"))
+	if err != nil {
+		respondSettingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"setting": setting})
+}
+
+// Update handles overriding the configured value of a setting
+func (h *SettingsHandler) Update(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateSettingRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+	value := strings.TrimSpace(string(req.Value))
+	if value == "" || value == "null" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Value is required"})
+		return
+	}
+	if strings.HasPrefix(value, `"`) {
+		if err := json.Unmarshal(req.Value, &value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+			return
+		}
+	}
+
+	setting, err := h.settingsService.Set(c.Param("key"), value, user.ID)
+	if err != nil {
+		respondSettingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"setting": setting})
+}
+
+// Reset handles bringing back the configured value of a setting
+func (h *SettingsHandler) Reset(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	setting, err := h.settingsService.Reset(c.Param("key"), user.ID)
+	if err != nil {
+		respondSettingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"setting": setting})
+}
+
+// respondSettingError answers a failed settings request with the status matching its error
+func respondSettingError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, service.ErrSettingNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidSettingValue):
+		status = http.StatusBadRequest
+	}
+	c.JSON(status, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// SkillHandler handles employee skills and the skills operations and product categories require
+type SkillHandler struct {
+	skillService service.SkillService
+}
+
+// NewSkillHandler creates a new skill handler
+func NewSkillHandler(skillService service.SkillService) *SkillHandler {
+	return &SkillHandler{
+		skillService: skillService,
+	}
+}
+
+// EmployeeSkillsRequest represents the request body for replacing the skills of an employee
+type EmployeeSkillsRequest struct {
+	Skills []string `json:"skills"`
+}
+
+// SkillRequirementsRequest represents the request body for replacing skill requirements
+type SkillRequirementsRequest struct {
+	Requirements []struct {
+		ProductCategory string `json:"product_category"`
+		Skill           string `json:"skill" binding:"required"`
+	} `json:"requirements"`
+}
+
+// GetEmployeeSkills handles getting the skills of an employee
+func (h *SkillHandler) GetEmployeeSkills(c *gin.Context) {
+	employeeID, ok := parseSkillPathID(c, "Invalid employee ID")
+	if !ok {
+		return
+	}
+
+	skills, err := h.skillService.GetEmployeeSkills(employeeID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"employee_id": employeeID, "skills": skills})
+}
+
+// SetEmployeeSkills handles replacing the skills of an employee
+func (h *SkillHandler) SetEmployeeSkills(c *gin.Context) {
+	employeeID, ok := parseSkillPathID(c, "Invalid employee ID")
+	if !ok {
+		return
+	}
+
+	var req EmployeeSkillsRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	skills, err := h.skillService.SetEmployeeSkills(employeeID, req.Skills)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"employee_id": employeeID, "skills": skills})
+}
+
+// GetGlobalRequirements handles getting the skill requirements that apply at every operation
+func (h *SkillHandler) GetGlobalRequirements(c *gin.Context) {
+	h.getRequirements(c, nil)
+}
+
+// SetGlobalRequirements handles replacing the skill requirements that apply at every operation
+func (h *SkillHandler) SetGlobalRequirements(c *gin.Context) {
+	h.setRequirements(c, nil)
+}
+
+// GetOperationRequirements handles getting the skill requirements of an operation
+func (h *SkillHandler) GetOperationRequirements(c *gin.Context) {
+	operationID, ok := parseSkillPathID(c, "Invalid operation ID")
+	if !ok {
+		return
+	}
+	h.getRequirements(c, &operationID)
+}
+
+// SetOperationRequirements handles replacing the skill requirements of an operation
+func (h *SkillHandler) SetOperationRequirements(c *gin.Context) {
+	operationID, ok := parseSkillPathID(c, "Invalid operation ID")
+	if !ok {
+		return
+	}
+	h.setRequirements(c, &operationID)
+}
+
+// getRequirements writes the requirements of a scope
+func (h *SkillHandler) getRequirements(c *gin.Context, operationID *uint) {
+	requirements, err := h.skillService.ListRequirements(operationID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"requirements": requirements})
+}
+
+// setRequirements replaces the requirements of a scope with the ones in the request body
+func (h *SkillHandler) setRequirements(c *gin.Context, operationID *uint) {
+	var req SkillRequirementsRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	requirements := make([]models.SkillRequirement, 0, len(req.Requirements))
+	for _, r := range req.Requirements {
+		requirements = append(requirements, models.SkillRequirement{
+			ProductCategory: r.ProductCategory,
+			Skill:           r.Skill,
+		})
+	}
+
+	saved, err := h.skillService.SetRequirements(operationID, requirements)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"requirements": saved})
+}
+
+// parseSkillPathID parses the employee or operation ID from the path, writing a 400 if it's
+// invalid
+func parseSkillPathID(c *gin.Context, message string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": message})
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,276 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// SlotWatchHandler handles suppliers watching operations for freed capacity and booking the
+// openings broadcast to them
+type SlotWatchHandler struct {
+	slotWatchService    service.SlotWatchService
+	appointmentService  service.AppointmentService
+	availabilityService service.AvailabilityService
+}
+
+// NewSlotWatchHandler creates a new slot watch handler
+func NewSlotWatchHandler(slotWatchService service.SlotWatchService, appointmentService service.AppointmentService, availabilityService service.AvailabilityService) *SlotWatchHandler {
+	return &SlotWatchHandler{
+		slotWatchService:    slotWatchService,
+		appointmentService:  appointmentService,
+		availabilityService: availabilityService,
+	}
+}
+
+// WatchSlotsRequest is the request body for watching an operation for freed capacity. Suppliers
+// watch for themselves; staff name the supplier. Without a date every date is watched, without a
+// type every type.
+type WatchSlotsRequest struct {
+	SupplierID  uint                   `json:"supplier_id"`
+	OperationID uint                   `json:"operation_id" binding:"required"`
+	Date        string                 `json:"date"` // "YYYY-MM-DD" at the operation
+	Type        models.AppointmentType `json:"type"`
+}
+
+// BookSlotOpeningRequest is the request body for booking through a slot opening. Without times
+// the whole opened time is booked, and without an employee the one whose appointment was
+// cancelled, or else a qualified free one.
+type BookSlotOpeningRequest struct {
+	EmployeeID        uint                   `json:"employee_id"`
+	Type              models.AppointmentType `json:"type"`
+	ProductID         *uint                  `json:"product_id"`
+	ScheduledStart    time.Time              `json:"scheduled_start"`
+	ScheduledEnd      time.Time              `json:"scheduled_end"`
+	QuantityToDeliver int                    `json:"quantity_to_deliver"`
+	Notes             string                 `json:"notes"`
+}
+
+// Watch handles starting to watch an operation for freed capacity
+func (h *SlotWatchHandler) Watch(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req WatchSlotsRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+	var date *time.Time
+	if req.Date != "" {
+		parsed, err := service.ParseBlackoutDate(req.Date)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		date = &parsed
+	}
+
+	supplierID := req.SupplierID
+	if user.Role == "supplier" {
+		var err error
+		if supplierID, err = h.slotWatchService.SupplierFor(user); err != nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	watch, err := h.slotWatchService.Watch(&models.SlotWatch{
+		SupplierID:  supplierID,
+		OperationID: req.OperationID,
+		Date:        date,
+		Type:        req.Type,
+		CreatedByID: user.ID,
+	})
+	if err != nil {
+		c.JSON(slotWatchErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"watch": watch})
+}
+
+// List handles listing slot watches. Suppliers see their own; staff list a supplier's with
+// supplier_id.
+func (h *SlotWatchHandler) List(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var supplierID uint
+	if user.Role == "supplier" {
+		id, err := h.slotWatchService.SupplierFor(user)
+		if err != nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		supplierID = id
+	} else {
+		id, err := strconv.ParseUint(c.Query("supplier_id"), 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid supplier_id"})
+			return
+		}
+		supplierID = uint(id)
+	}
+
+	watches, err := h.slotWatchService.ListBySupplier(supplierID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"watches": watches})
+}
+
+// Unwatch handles stopping a slot watch
+func (h *SlotWatchHandler) Unwatch(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid slot watch ID"})
+		return
+	}
+
+	watch, err := h.slotWatchService.GetWatch(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	// Other suppliers' watches are answered as if they did not exist
+	if user.Role == "supplier" {
+		supplierID, err := h.slotWatchService.SupplierFor(user)
+		if err != nil || watch.SupplierID != supplierID {
+			c.JSON(http.StatusNotFound, gin.H{"error": service.ErrSlotWatchNotFound.Error()})
+			return
+		}
+	}
+
+	if err := h.slotWatchService.Unwatch(watch.ID); err != nil {
+		c.JSON(slotWatchErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Slot watch removed successfully"})
+}
+
+// GetOpening handles showing a slot opening. Suppliers only see the openings a broadcast wave
+// reached them with.
+func (h *SlotWatchHandler) GetOpening(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid slot opening ID"})
+		return
+	}
+
+	var opening *models.SlotOpening
+	if user.Role == "supplier" {
+		var supplierID uint
+		if supplierID, err = h.slotWatchService.SupplierFor(user); err != nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		opening, err = h.slotWatchService.OpeningFor(uint(id), supplierID)
+	} else {
+		opening, err = h.slotWatchService.GetOpening(uint(id))
+	}
+	if err != nil {
+		c.JSON(slotWatchErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"opening": opening})
+}
+
+// BookOpening handles a watching supplier booking through a slot opening, first come first
+// served. The place taken is given back when the appointment can't be created.
+func (h *SlotWatchHandler) BookOpening(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid slot opening ID"})
+		return
+	}
+	var req BookSlotOpeningRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+	supplierID, err := h.slotWatchService.SupplierFor(user)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	appointment, err := h.slotWatchService.Claim(uint(id), supplierID, service.SlotBooking{
+		EmployeeID:        req.EmployeeID,
+		Type:              req.Type,
+		ProductID:         req.ProductID,
+		ScheduledStart:    req.ScheduledStart,
+		ScheduledEnd:      req.ScheduledEnd,
+		QuantityToDeliver: req.QuantityToDeliver,
+		Notes:             req.Notes,
+	})
+	if err != nil {
+		c.JSON(slotWatchErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	if appointment.EmployeeID == 0 {
+		employee, err := h.availabilityService.AssignEmployee(appointment.OperationID, appointment.ProductID, appointment.ScheduledStart, appointment.ScheduledEnd)
+		if err != nil {
+			h.slotWatchService.Release(uint(id))
+			status := http.StatusBadRequest
+			if errors.Is(err, service.ErrNoQualifiedEmployee) {
+				status = http.StatusConflict
+			}
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+		appointment.EmployeeID = employee.ID
+	}
+
+	if err := h.appointmentService.Create(appointment); err != nil {
+		h.slotWatchService.Release(uint(id))
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	log.Printf("Supplier %d booked appointment %d through slot opening %d", supplierID, appointment.ID, id)
+
+	c.JSON(http.StatusCreated, gin.H{"appointment": appointment})
+}
+
+// slotWatchErrorStatus maps slot watch errors to HTTP statuses
+func slotWatchErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrSlotWatchNotFound), errors.Is(err, service.ErrSlotOpeningNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrSlotWatchSupplier):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrSlotWatchDuplicate), errors.Is(err, service.ErrSlotOpeningTaken),
+		errors.Is(err, service.ErrSlotOpeningClosed):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
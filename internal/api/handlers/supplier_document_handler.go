@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// SupplierDocumentHandler handles supplier compliance document requests
+type SupplierDocumentHandler struct {
+	documentService service.SupplierDocumentService
+}
+
+// NewSupplierDocumentHandler creates a new supplier document handler
+func NewSupplierDocumentHandler(documentService service.SupplierDocumentService) *SupplierDocumentHandler {
+	return &SupplierDocumentHandler{
+		documentService: documentService,
+	}
+}
+
+// SupplierDocumentRequest represents the request body for creating or updating a supplier document
+type SupplierDocumentRequest struct {
+	Type      models.SupplierDocumentType `json:"type" binding:"required"`
+	Number    string                      `json:"number"`
+	Issuer    string                      `json:"issuer"`
+	IssuedAt  *time.Time                  `json:"issued_at"`
+	ExpiresAt time.Time                   `json:"expires_at" binding:"required"`
+	FileURL   string                      `json:"file_url"`
+}
+
+// DocumentRequirementsRequest represents the request body for setting operation document requirements
+type DocumentRequirementsRequest struct {
+	Requirements []struct {
+		DocumentType  models.SupplierDocumentType `json:"document_type" binding:"required"`
+		BlockBookings bool                        `json:"block_bookings"`
+	} `json:"requirements"`
+}
+
+// List handles listing the documents of a supplier
+func (h *SupplierDocumentHandler) List(c *gin.Context) {
+	supplierID, ok := h.authorizeSupplier(c, false)
+	if !ok {
+		return
+	}
+
+	documents, err := h.documentService.ListDocuments(supplierID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"documents": documents})
+}
+
+// Create handles registering a new supplier document
+func (h *SupplierDocumentHandler) Create(c *gin.Context) {
+	supplierID, ok := h.authorizeSupplier(c, true)
+	if !ok {
+		return
+	}
+
+	var req SupplierDocumentRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	document := &models.SupplierDocument{
+		SupplierID: supplierID,
+		Type:       req.Type,
+		Number:     req.Number,
+		Issuer:     req.Issuer,
+		IssuedAt:   req.IssuedAt,
+		ExpiresAt:  req.ExpiresAt,
+		FileURL:    req.FileURL,
+	}
+	if err := h.documentService.AddDocument(document); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, document)
+}
+
+// Update handles updating or renewing a supplier document
+func (h *SupplierDocumentHandler) Update(c *gin.Context) {
+	supplierID, ok := h.authorizeSupplier(c, true)
+	if !ok {
+		return
+	}
+
+	document, ok := h.findDocument(c, supplierID)
+	if !ok {
+		return
+	}
+
+	var req SupplierDocumentRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	document.Type = req.Type
+	document.Number = req.Number
+	document.Issuer = req.Issuer
+	document.IssuedAt = req.IssuedAt
+	document.ExpiresAt = req.ExpiresAt
+	document.FileURL = req.FileURL
+	if err := h.documentService.UpdateDocument(document); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, document)
+}
+
+// Delete handles deleting a supplier document
+func (h *SupplierDocumentHandler) Delete(c *gin.Context) {
+	supplierID, ok := h.authorizeSupplier(c, true)
+	if !ok {
+		return
+	}
+
+	document, ok := h.findDocument(c, supplierID)
+	if !ok {
+		return
+	}
+
+	if err := h.documentService.DeleteDocument(document.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Document deleted successfully"})
+}
+
+// Compliance handles checking a supplier's documents against an operation's requirements
+func (h *SupplierDocumentHandler) Compliance(c *gin.Context) {
+	supplierID, ok := h.authorizeSupplier(c, false)
+	if !ok {
+		return
+	}
+
+	operationID, err := strconv.ParseUint(c.Query("operation_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+		return
+	}
+
+	var now time.Time // the service's current time
+	compliance, err := h.documentService.CheckCompliance(supplierID, uint(operationID), now)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	canBook := true
+	for _, entry := range compliance {
+		if entry.BlockBookings && !entry.Valid {
+			canBook = false
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"supplier_id":  supplierID,
+		"operation_id": operationID,
+		"can_book":     canBook,
+		"documents":    compliance,
+	})
+}
+
+// GetRequirements handles getting the document requirements of an operation
+func (h *SupplierDocumentHandler) GetRequirements(c *gin.Context) {
+	operationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+		return
+	}
+
+	requirements, err := h.documentService.GetRequirements(uint(operationID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"requirements": requirements})
+}
+
+// SetRequirements handles replacing the document requirements of an operation
+func (h *SupplierDocumentHandler) SetRequirements(c *gin.Context) {
+	operationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+		return
+	}
+
+	var req DocumentRequirementsRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	requirements := make([]models.SupplierDocumentRequirement, 0, len(req.Requirements))
+	for _, r := range req.Requirements {
+		requirements = append(requirements, models.SupplierDocumentRequirement{
+			DocumentType:  r.DocumentType,
+			BlockBookings: r.BlockBookings,
+		})
+	}
+
+	if err := h.documentService.SetRequirements(uint(operationID), requirements); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"requirements": requirements})
+}
+
+// NotifyExpiring handles warning suppliers about expiring documents immediately
+func (h *SupplierDocumentHandler) NotifyExpiring(c *gin.Context) {
+	queued, err := h.documentService.NotifyExpiring()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"queued": queued})
+}
+
+// authorizeSupplier parses the supplier ID from the path and checks the user may access it.
+// Admins may access any supplier, employees may read, and suppliers may only access themselves.
+func (h *SupplierDocumentHandler) authorizeSupplier(c *gin.Context, write bool) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid supplier ID"})
+		return 0, false
+	}
+	supplierID := uint(id)
+
+	user, ok := currentUser(c)
+	if !ok {
+		return 0, false
+	}
+
+	switch user.Role {
+	case "admin":
+		return supplierID, true
+	case "employee":
+		if !write {
+			return supplierID, true
+		}
+	case "supplier":
+		if h.documentService.IsSupplierUser(supplierID, user.ID) {
+			return supplierID, true
+		}
+	}
+
+	c.JSON(http.StatusForbidden, gin.H{"error": "You do not have permission to access this supplier's documents"})
+	return 0, false
+}
+
+// findDocument loads the document from the path and checks it belongs to the supplier
+func (h *SupplierDocumentHandler) findDocument(c *gin.Context, supplierID uint) (*models.SupplierDocument, bool) {
+	id, err := strconv.ParseUint(c.Param("document_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid document ID"})
+		return nil, false
+	}
+
+	document, err := h.documentService.GetDocument(uint(id))
+	if err != nil || document.SupplierID != supplierID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Document not found"})
+		return nil, false
+	}
+
+	return document, true
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// SyncHandler handles the delta sync the mobile apps keep their offline copy up to date with
+type SyncHandler struct {
+	syncService       service.SyncService
+	visibilityService service.VisibilityService
+}
+
+// NewSyncHandler creates a new sync handler
+func NewSyncHandler(syncService service.SyncService, visibilityService service.VisibilityService) *SyncHandler {
+	return &SyncHandler{
+		syncService:       syncService,
+		visibilityService: visibilityService,
+	}
+}
+
+// Changes handles getting the appointments and notifications changed since a timestamp or the
+// cursor of the previous sync, optionally waiting up to wait seconds for changes when none are
+// pending
+func (h *SyncHandler) Changes(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var wait time.Duration
+	if value := c.Query("wait"); value != "" {
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "wait must be a number of seconds"})
+			return
+		}
+		wait = time.Duration(seconds) * time.Second
+	}
+
+	result, err := h.syncService.Changes(c.Request.Context(), user, c.Query("since"), wait)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrInvalidSyncCursor):
+			status = http.StatusBadRequest
+		case errors.Is(err, service.ErrSyncNoProfile):
+			status = http.StatusForbidden
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	appointments := make([]*AppointmentResponse, 0, len(result.Appointments))
+	for i := range result.Appointments {
+		appointments = append(appointments, viewAppointment(h.visibilityService, &result.Appointments[i], user))
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"appointments":  appointments,
+		"notifications": result.Notifications,
+		"deleted":       result.Deleted,
+		"cursor":        result.Cursor,
+		"has_more":      result.HasMore,
+		"server_time":   result.ServerTime,
+	})
+}
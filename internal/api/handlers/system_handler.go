@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+	"github.com/bernardofernandezz/scheduling-api/pkg/buildinfo"
+	"github.com/bernardofernandezz/scheduling-api/pkg/circuitbreaker"
+	"github.com/bernardofernandezz/scheduling-api/pkg/jobs"
+)
+
+// SystemHandler handles admin system introspection requests
+type SystemHandler struct {
+	breakers      *circuitbreaker.Registry
+	systemService service.SystemService
+}
+
+// NewSystemHandler creates a new system handler
+func NewSystemHandler(breakers *circuitbreaker.Registry, systemService service.SystemService) *SystemHandler {
+	return &SystemHandler{
+		breakers:      breakers,
+		systemService: systemService,
+	}
+}
+
+// GetVersion handles reporting the release, commit and build date of the running binary
+func (h *SystemHandler) GetVersion(c *gin.Context) {
+	c.JSON(http.StatusOK, buildinfo.Get())
+}
+
+// GetCircuitBreakers handles listing the state of all external provider circuit breakers
+func (h *SystemHandler) GetCircuitBreakers(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"circuit_breakers": h.breakers.Snapshots()})
+}
+
+// ResetCircuitBreaker handles forcing a provider circuit breaker back to closed
+func (h *SystemHandler) ResetCircuitBreaker(c *gin.Context) {
+	breaker, exists := h.breakers.Lookup(c.Param("name"))
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Circuit breaker not found"})
+		return
+	}
+
+	breaker.Reset()
+
+	c.JSON(http.StatusOK, gin.H{"circuit_breaker": breaker.Snapshot()})
+}
+
+// GetQueues handles listing pending and in-flight notification queue items per queue and priority
+func (h *SystemHandler) GetQueues(c *gin.Context) {
+	depths, err := h.systemService.QueueDepths()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"queues": depths})
+}
+
+// GetWorkers handles showing the notification worker pools of the instance serving the request
+func (h *SystemHandler) GetWorkers(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"workers": h.systemService.Workers()})
+}
+
+// GetLeader handles showing whether the instance serving the request leads the replicas
+func (h *SystemHandler) GetLeader(c *gin.Context) {
+	status := h.systemService.Leader()
+	if status == nil {
+		c.JSON(http.StatusOK, gin.H{"leader": gin.H{"election_enabled": false}})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"leader": status})
+}
+
+// GetLocks handles listing notification queue items locked by a worker
+func (h *SystemHandler) GetLocks(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	items, err := h.systemService.LockedItems(limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"locks": items})
+}
+
+// ReleaseLock handles putting a locked notification queue item back in the queue
+func (h *SystemHandler) ReleaseLock(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid queue item ID"})
+		return
+	}
+
+	item, err := h.systemService.ReleaseLock(uint(id))
+	if err != nil {
+		status := http.StatusNotFound
+		if errors.Is(err, service.ErrQueueItemNotLocked) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"queue_item": item})
+}
+
+// ReleaseStuckLocks handles putting every queue item whose lock expired back in the queue
+func (h *SystemHandler) ReleaseStuckLocks(c *gin.Context) {
+	released, err := h.systemService.ReleaseStuckLocks()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"released": released})
+}
+
+// UpdateJobRequest represents the request body for changing how a background job is scheduled
+type UpdateJobRequest struct {
+	Schedule *string `json:"schedule"`
+	Enabled  *bool   `json:"enabled"`
+}
+
+// GetJobs handles listing background jobs with their last run
+func (h *SystemHandler) GetJobs(c *gin.Context) {
+	jobStatuses, err := h.systemService.Jobs()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"jobs": jobStatuses})
+}
+
+// UpdateJob handles changing the schedule of a background job or enabling and disabling it
+func (h *SystemHandler) UpdateJob(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateJobRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	job, err := h.systemService.UpdateJob(c.Param("name"), service.JobUpdate{
+		Schedule: req.Schedule,
+		Enabled:  req.Enabled,
+	}, user.ID)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, jobs.ErrJobNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"job": job})
+}
+
+// GetMissingTemplates handles reporting notification combinations without a template
+func (h *SystemHandler) GetMissingTemplates(c *gin.Context) {
+	missing, err := h.systemService.MissingTemplates()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"missing_templates": missing})
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// transparentGIF is the 1x1 image answered to email open tracking
+var transparentGIF = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0xff, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+// TemplateExperimentHandler handles A/B tests of notification templates
+type TemplateExperimentHandler struct {
+	experimentService service.TemplateExperimentService
+}
+
+// NewTemplateExperimentHandler creates a new template experiment handler
+func NewTemplateExperimentHandler(experimentService service.TemplateExperimentService) *TemplateExperimentHandler {
+	return &TemplateExperimentHandler{
+		experimentService: experimentService,
+	}
+}
+
+// TemplateExperimentRequest represents the request body for starting a template experiment
+type TemplateExperimentRequest struct {
+	Name               string                           `json:"name" binding:"required"`
+	Event              models.NotificationEvent         `json:"event" binding:"required"`
+	RecipientType      models.NotificationRecipientType `json:"recipient_type" binding:"required"`
+	Type               models.NotificationType          `json:"type" binding:"required"`
+	VariantATemplateID uint                             `json:"variant_a_template_id" binding:"required"`
+	VariantBTemplateID uint                             `json:"variant_b_template_id" binding:"required"`
+	SplitB             *int                             `json:"split_b"` // percent sent with variant B, 50 when left out
+}
+
+// Create handles starting a template experiment
+func (h *TemplateExperimentHandler) Create(c *gin.Context) {
+	var req TemplateExperimentRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	splitB := 50
+	if req.SplitB != nil {
+		splitB = *req.SplitB
+	}
+	experiment, err := h.experimentService.Create(&models.TemplateExperiment{
+		Name:               req.Name,
+		Event:              req.Event,
+		RecipientType:      req.RecipientType,
+		Type:               req.Type,
+		VariantATemplateID: req.VariantATemplateID,
+		VariantBTemplateID: req.VariantBTemplateID,
+		SplitB:             splitB,
+	})
+	if err != nil {
+		c.JSON(templateExperimentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"experiment": experiment})
+}
+
+// List handles listing template experiments
+func (h *TemplateExperimentHandler) List(c *gin.Context) {
+	experiments, err := h.experimentService.List()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"experiments": experiments})
+}
+
+// Get handles getting a template experiment with the results of its variants compared
+func (h *TemplateExperimentHandler) Get(c *gin.Context) {
+	id, ok := parseTemplateExperimentID(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.experimentService.Get(id)
+	if err != nil {
+		c.JSON(templateExperimentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// Stop handles stopping a template experiment, answering its final results
+func (h *TemplateExperimentHandler) Stop(c *gin.Context) {
+	id, ok := parseTemplateExperimentID(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.experimentService.Stop(id)
+	if err != nil {
+		c.JSON(templateExperimentErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// TrackOpen handles the tracking image of emails sent in template experiments. The image is
+// answered whatever the token, so mail clients never show a broken image.
+func (h *TemplateExperimentHandler) TrackOpen(c *gin.Context) {
+	if err := h.experimentService.RecordOpen(c.Param("token")); err != nil && !errors.Is(err, service.ErrInvalidTrackingToken) {
+		log.Printf("Failed to record an email open: %v", err)
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "image/gif", transparentGIF)
+}
+
+// parseTemplateExperimentID parses the experiment ID from the path, writing a 400 if it's invalid
+func parseTemplateExperimentID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid experiment ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// templateExperimentErrorStatus maps template experiment errors to HTTP statuses
+func templateExperimentErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrExperimentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrExperimentRunning), errors.Is(err, service.ErrExperimentStopped):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// UsageHandler handles the API usage analytics admins troubleshoot integrations with
+type UsageHandler struct {
+	usageService service.UsageService
+}
+
+// NewUsageHandler creates a new usage handler
+func NewUsageHandler(usageService service.UsageService) *UsageHandler {
+	return &UsageHandler{
+		usageService: usageService,
+	}
+}
+
+// Summary handles summarizing API usage per consumer, route or both (group_by). The period is
+// given as RFC3339 start_date and end_date and defaults to the last day; consumer_type,
+// consumer_id and route narrow it down.
+func (h *UsageHandler) Summary(c *gin.Context) {
+	filters := repository.UsageFilters{
+		ConsumerType: c.Query("consumer_type"),
+		Route:        c.Query("route"),
+		GroupBy:      c.Query("group_by"),
+	}
+	filters.Limit, _ = strconv.Atoi(c.Query("limit"))
+
+	if value := c.Query("consumer_id"); value != "" {
+		consumerID, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid consumer ID"})
+			return
+		}
+		id := uint(consumerID)
+		filters.ConsumerID = &id
+	}
+	if value := c.Query("start_date"); value != "" {
+		startDate, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start_date format, expected RFC3339"})
+			return
+		}
+		filters.StartDate = &startDate
+	}
+	if value := c.Query("end_date"); value != "" {
+		endDate, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid end_date format, expected RFC3339"})
+			return
+		}
+		filters.EndDate = &endDate
+	}
+
+	rows, err := h.usageService.Summary(filters)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrInvalidUsageGroup) || errors.Is(err, service.ErrInvalidUsageConsumer) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"usage": rows})
+}
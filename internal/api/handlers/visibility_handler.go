@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// VisibilityHandler handles the rules deciding which appointment fields each role sees
+type VisibilityHandler struct {
+	visibilityService service.VisibilityService
+}
+
+// NewVisibilityHandler creates a new visibility handler
+func NewVisibilityHandler(visibilityService service.VisibilityService) *VisibilityHandler {
+	return &VisibilityHandler{
+		visibilityService: visibilityService,
+	}
+}
+
+// VisibilityRulesRequest represents the request body for replacing visibility rules
+type VisibilityRulesRequest struct {
+	Rules []struct {
+		Role   string `json:"role" binding:"required"`
+		Field  string `json:"field" binding:"required"`
+		Hidden bool   `json:"hidden"`
+	} `json:"rules"`
+}
+
+// GetGlobalRules handles getting the visibility rules that apply at every operation
+func (h *VisibilityHandler) GetGlobalRules(c *gin.Context) {
+	h.getRules(c, nil)
+}
+
+// SetGlobalRules handles replacing the visibility rules that apply at every operation
+func (h *VisibilityHandler) SetGlobalRules(c *gin.Context) {
+	h.setRules(c, nil)
+}
+
+// GetOperationRules handles getting the visibility rules of an operation
+func (h *VisibilityHandler) GetOperationRules(c *gin.Context) {
+	operationID, ok := parseVisibilityOperationID(c)
+	if !ok {
+		return
+	}
+	h.getRules(c, &operationID)
+}
+
+// SetOperationRules handles replacing the visibility rules of an operation
+func (h *VisibilityHandler) SetOperationRules(c *gin.Context) {
+	operationID, ok := parseVisibilityOperationID(c)
+	if !ok {
+		return
+	}
+	h.setRules(c, &operationID)
+}
+
+// getRules writes the rules of a scope along with the fields rules may hide
+func (h *VisibilityHandler) getRules(c *gin.Context, operationID *uint) {
+	rules, err := h.visibilityService.ListRules(operationID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rules": rules, "fields": models.MaskableFields})
+}
+
+// setRules replaces the rules of a scope with the ones in the request body
+func (h *VisibilityHandler) setRules(c *gin.Context, operationID *uint) {
+	var req VisibilityRulesRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	rules := make([]models.FieldVisibilityRule, 0, len(req.Rules))
+	for _, r := range req.Rules {
+		rules = append(rules, models.FieldVisibilityRule{
+			Role:   r.Role,
+			Field:  r.Field,
+			Hidden: r.Hidden,
+		})
+	}
+
+	saved, err := h.visibilityService.SetRules(operationID, rules)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"rules": saved, "fields": models.MaskableFields})
+}
+
+// parseVisibilityOperationID parses the operation ID from the path, writing a 400 if it's invalid
+func parseVisibilityOperationID(c *gin.Context) (uint, bool) {
+	operationID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation ID"})
+		return 0, false
+	}
+	return uint(operationID), true
+}
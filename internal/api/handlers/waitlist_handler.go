@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// WaitlistHandler handles suppliers waiting for a slot on a booked-up date
+type WaitlistHandler struct {
+	waitlistService service.WaitlistService
+}
+
+// NewWaitlistHandler creates a new waitlist handler
+func NewWaitlistHandler(waitlistService service.WaitlistService) *WaitlistHandler {
+	return &WaitlistHandler{
+		waitlistService: waitlistService,
+	}
+}
+
+// JoinWaitlistRequest is the request body for joining an operation's waitlist. Suppliers join
+// for themselves; staff name the supplier.
+type JoinWaitlistRequest struct {
+	SupplierID  uint                   `json:"supplier_id"`
+	OperationID uint                   `json:"operation_id" binding:"required"`
+	Date        string                 `json:"date" binding:"required"` // "YYYY-MM-DD" at the operation
+	Type        models.AppointmentType `json:"type"`
+	Notes       string                 `json:"notes"`
+}
+
+// Join handles putting a supplier on an operation's waitlist for a date
+func (h *WaitlistHandler) Join(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	var req JoinWaitlistRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+	date, err := service.ParseBlackoutDate(req.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	supplierID := req.SupplierID
+	if user.Role == "supplier" {
+		if supplierID, err = h.waitlistService.SupplierFor(user); err != nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	entry, err := h.waitlistService.Join(&models.WaitlistEntry{
+		SupplierID:  supplierID,
+		OperationID: req.OperationID,
+		Date:        date,
+		Type:        req.Type,
+		Notes:       req.Notes,
+		CreatedByID: user.ID,
+	})
+	if err != nil {
+		c.JSON(waitlistErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"entry": entry})
+}
+
+// List handles listing waitlist entries. Suppliers see their own; staff list an operation's
+// waitlist between two dates, the next 30 days by default.
+func (h *WaitlistHandler) List(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+
+	if user.Role == "supplier" {
+		supplierID, err := h.waitlistService.SupplierFor(user)
+		if err != nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+		entries, err := h.waitlistService.ListBySupplier(supplierID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"entries": entries})
+		return
+	}
+
+	operationID, err := strconv.ParseUint(c.Query("operation_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid operation_id"})
+		return
+	}
+	var from, to time.Time // defaulted by the service
+	if value := c.Query("from"); value != "" {
+		if from, err = service.ParseBlackoutDate(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	if value := c.Query("to"); value != "" {
+		if to, err = service.ParseBlackoutDate(value); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	entries, err := h.waitlistService.ListByOperation(uint(operationID), from, to)
+	if err != nil {
+		c.JSON(waitlistErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"entries": entries})
+}
+
+// Withdraw handles taking a supplier off a waitlist
+func (h *WaitlistHandler) Withdraw(c *gin.Context) {
+	user, ok := currentUser(c)
+	if !ok {
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid waitlist entry ID"})
+		return
+	}
+
+	entry, err := h.waitlistService.GetByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	// Other suppliers' entries are answered as if they did not exist
+	if user.Role == "supplier" {
+		supplierID, err := h.waitlistService.SupplierFor(user)
+		if err != nil || entry.SupplierID != supplierID {
+			c.JSON(http.StatusNotFound, gin.H{"error": service.ErrWaitlistEntryNotFound.Error()})
+			return
+		}
+	}
+
+	entry, err = h.waitlistService.Withdraw(entry.ID)
+	if err != nil {
+		c.JSON(waitlistErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"entry": entry})
+}
+
+// waitlistErrorStatus maps waitlist errors to HTTP statuses
+func waitlistErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrWaitlistEntryNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrWaitlistDuplicate), errors.Is(err, service.ErrWaitlistNotWaiting):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
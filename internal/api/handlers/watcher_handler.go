@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// WatcherHandler handles who receives an appointment's notifications: watchers copied on them
+// and participants muting them
+type WatcherHandler struct {
+	watcherService      service.WatcherService
+	appointmentService  service.AppointmentService
+	notificationService service.NotificationService
+}
+
+// NewWatcherHandler creates a new watcher handler
+func NewWatcherHandler(
+	watcherService service.WatcherService,
+	appointmentService service.AppointmentService,
+	notificationService service.NotificationService,
+) *WatcherHandler {
+	return &WatcherHandler{
+		watcherService:      watcherService,
+		appointmentService:  appointmentService,
+		notificationService: notificationService,
+	}
+}
+
+// WatcherRequest represents the request body for subscribing a watcher. Without channels the
+// watcher is copied by email.
+type WatcherRequest struct {
+	UserID uint  `json:"user_id"` // defaults to the signed-in user
+	Email  *bool `json:"email"`
+	SMS    *bool `json:"sms"`
+	Push   *bool `json:"push"`
+}
+
+// WatcherChannelsRequest represents the request body for changing a watcher's channels
+type WatcherChannelsRequest struct {
+	Email *bool `json:"email"`
+	SMS   *bool `json:"sms"`
+	Push  *bool `json:"push"`
+}
+
+// AddWatcher handles subscribing a user to an appointment's notifications
+func (h *WatcherHandler) AddWatcher(c *gin.Context) {
+	appointment, ok := h.authorizeAppointment(c)
+	if !ok {
+		return
+	}
+
+	var req WatcherRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	user, _ := currentUser(c)
+	watcher := &models.AppointmentWatcher{
+		AppointmentID: appointment.ID,
+		UserID:        req.UserID,
+		AddedByID:     user.ID,
+		EmailEnabled:  req.Email == nil && req.SMS == nil && req.Push == nil,
+	}
+	if watcher.UserID == 0 {
+		watcher.UserID = user.ID
+	}
+	applyWatcherChannels(watcher, req.Email, req.SMS, req.Push)
+
+	if err := h.watcherService.AddWatcher(watcher); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, service.ErrAlreadyWatching) {
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, watcher)
+}
+
+// ListWatchers handles listing the watchers of an appointment
+func (h *WatcherHandler) ListWatchers(c *gin.Context) {
+	appointment, ok := h.authorizeAppointment(c)
+	if !ok {
+		return
+	}
+
+	watchers, err := h.watcherService.ListWatchers(appointment.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"watchers": watchers})
+}
+
+// UpdateWatcher handles changing the channels a watcher is copied on
+func (h *WatcherHandler) UpdateWatcher(c *gin.Context) {
+	watcher, ok := h.authorizeWatcher(c)
+	if !ok {
+		return
+	}
+
+	var req WatcherChannelsRequest
+	if err := middleware.BindJSON(c, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+	applyWatcherChannels(watcher, req.Email, req.SMS, req.Push)
+
+	if err := h.watcherService.UpdateChannels(watcher); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, watcher)
+}
+
+// RemoveWatcher handles unsubscribing a watcher from an appointment
+func (h *WatcherHandler) RemoveWatcher(c *gin.Context) {
+	watcher, ok := h.authorizeWatcher(c)
+	if !ok {
+		return
+	}
+
+	if err := h.watcherService.RemoveWatcher(watcher.AppointmentID, watcher.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Watcher removed successfully"})
+}
+
+// Mute handles silencing further notifications of an appointment for the signed-in user. Their
+// notification preferences for other appointments are unchanged.
+func (h *WatcherHandler) Mute(c *gin.Context) {
+	appointmentID, user, ok := h.authorizeParticipant(c)
+	if !ok {
+		return
+	}
+
+	mute, err := h.notificationService.MuteAppointment(appointmentID, user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"appointment_id": appointmentID, "muted": true, "muted_at": mute.CreatedAt})
+}
+
+// Unmute handles letting an appointment's notifications reach the signed-in user again
+func (h *WatcherHandler) Unmute(c *gin.Context) {
+	appointmentID, user, ok := h.authorizeParticipant(c)
+	if !ok {
+		return
+	}
+
+	if err := h.notificationService.UnmuteAppointment(appointmentID, user.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"appointment_id": appointmentID, "muted": false})
+}
+
+// authorizeParticipant checks the signed-in user receives the notifications of the appointment
+// in the URL, either as someone with access to it or as a watcher
+func (h *WatcherHandler) authorizeParticipant(c *gin.Context) (uint, *models.User, bool) {
+	appointmentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid appointment ID"})
+		return 0, nil, false
+	}
+
+	user, ok := currentUser(c)
+	if !ok {
+		return 0, nil, false
+	}
+
+	if watching, err := h.watcherService.IsWatching(uint(appointmentID), user.ID); err == nil && watching {
+		return uint(appointmentID), user, true
+	}
+	if _, ok := h.authorizeAppointment(c); !ok {
+		return 0, nil, false
+	}
+	return uint(appointmentID), user, true
+}
+
+// authorizeWatcher loads the watcher in the URL. Watchers manage their own subscription; anyone
+// else needs access to the appointment.
+func (h *WatcherHandler) authorizeWatcher(c *gin.Context) (*models.AppointmentWatcher, bool) {
+	appointmentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid appointment ID"})
+		return nil, false
+	}
+	watcherID, err := strconv.ParseUint(c.Param("watcher_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid watcher ID"})
+		return nil, false
+	}
+
+	user, ok := currentUser(c)
+	if !ok {
+		return nil, false
+	}
+
+	watcher, err := h.watcherService.GetWatcher(uint(appointmentID), uint(watcherID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	if watcher.UserID == user.ID {
+		return watcher, true
+	}
+	if _, ok := h.authorizeAppointment(c); !ok {
+		return nil, false
+	}
+	return watcher, true
+}
+
+// authorizeAppointment loads the appointment in the URL and checks the user may manage it
+func (h *WatcherHandler) authorizeAppointment(c *gin.Context) (*models.Appointment, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid appointment ID"})
+		return nil, false
+	}
+
+	user, ok := currentUser(c)
+	if !ok {
+		return nil, false
+	}
+
+	appointment, err := h.appointmentService.GetByID(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	if user.Role == "supplier" && appointment.Supplier.UserID != user.ID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to manage this appointment"})
+		return nil, false
+	}
+
+	return appointment, true
+}
+
+// applyWatcherChannels sets the channels given in a request, leaving the others as they are
+func applyWatcherChannels(watcher *models.AppointmentWatcher, email, sms, push *bool) {
+	if email != nil {
+		watcher.EmailEnabled = *email
+	}
+	if sms != nil {
+		watcher.SMSEnabled = *sms
+	}
+	if push != nil {
+		watcher.PushEnabled = *push
+	}
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+)
+
+// bookingRequest is a request body with a required field
+type bookingRequest struct {
+	ScheduledStart string `json:"scheduled_start" binding:"required"`
+}
+
+// bindRouter answers 204 when the body of POST /bind binds into a bookingRequest, and 400 with
+// the error otherwise
+func bindRouter(middleware ...gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middleware...)
+	router.POST("/bind", func(c *gin.Context) {
+		var req bookingRequest
+		if err := BindJSON(c, &req); err != nil {
+			c.String(http.StatusBadRequest, err.Error())
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+	return router
+}
+
+func TestBindJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		strict bool
+		body   string
+		want   int
+	}{
+		{"known fields", true, `{"scheduled_start": "2024-03-04T10:00:00Z"}`, http.StatusNoContent},
+		{"unknown field when strict", true, `{"scheduled_start": "2024-03-04T10:00:00Z", "schedule_end": "x"}`, http.StatusBadRequest},
+		{"unknown field when lenient", false, `{"scheduled_start": "2024-03-04T10:00:00Z", "schedule_end": "x"}`, http.StatusNoContent},
+		{"missing required field when strict", true, `{}`, http.StatusBadRequest},
+		{"malformed body when strict", true, `{"scheduled_start":`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var router *gin.Engine
+			if tt.strict {
+				router = bindRouter(StrictJSON())
+			} else {
+				router = bindRouter()
+			}
+			req := httptest.NewRequest(http.MethodPost, "/bind", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			assert.Equal(t, tt.want, recorder.Code, recorder.Body.String())
+		})
+	}
+}
+
+func TestStrictJSONLeavesGinBindingAlone(t *testing.T) {
+	StrictJSON()
+	assert.False(t, binding.EnableDecoderDisallowUnknownFields)
+}
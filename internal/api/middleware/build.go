@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/bernardofernandezz/scheduling-api/pkg/buildinfo"
+	"github.com/gin-gonic/gin"
+)
+
+// BuildHeader names the response header carrying the release and commit that served a request
+const BuildHeader = "X-App-Version"
+
+// BuildVersion sets the release and commit of the running binary on every response, so error
+// reports and captured requests say which deploy served them
+func BuildVersion() gin.HandlerFunc {
+	build := buildinfo.Get()
+	header := fmt.Sprintf("%s+%s", build.Version, build.ShortCommit())
+
+	return func(c *gin.Context) {
+		c.Header(BuildHeader, header)
+		c.Next()
+	}
+}
+
+// Recovery answers requests that panicked with 500, logging the request and the build alongside
+// the stack trace gin writes
+func Recovery() gin.HandlerFunc {
+	build := buildinfo.Get().String()
+
+	return gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
+		log.Printf("Panic serving %s %s on %s: %v", c.Request.Method, c.FullPath(), build, recovered)
+		c.AbortWithStatus(http.StatusInternalServerError)
+	})
+}
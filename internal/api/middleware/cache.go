@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CacheControl marks successful GET and HEAD responses as cacheable by the client for maxAge.
+// Responses are private because they depend on the authenticated user; error responses and
+// other methods are marked no-store. A zero maxAge disables caching for the route group.
+func CacheControl(maxAge time.Duration) gin.HandlerFunc {
+	cacheable := fmt.Sprintf("private, max-age=%d", int(maxAge.Seconds()))
+
+	return func(c *gin.Context) {
+		if maxAge <= 0 || (c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead) {
+			c.Header("Cache-Control", "no-store")
+			c.Next()
+			return
+		}
+
+		c.Writer = &cacheWriter{ResponseWriter: c.Writer, cacheable: cacheable}
+		c.Next()
+	}
+}
+
+// cacheWriter sets the Cache-Control header once the response status is known
+type cacheWriter struct {
+	gin.ResponseWriter
+	cacheable string
+	applied   bool
+}
+
+// WriteHeader implements http.ResponseWriter
+func (w *cacheWriter) WriteHeader(code int) {
+	w.apply(code)
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write implements http.ResponseWriter
+func (w *cacheWriter) Write(data []byte) (int, error) {
+	w.apply(w.Status())
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString implements io.StringWriter
+func (w *cacheWriter) WriteString(s string) (int, error) {
+	w.apply(w.Status())
+	return w.ResponseWriter.WriteString(s)
+}
+
+// apply sets the Cache-Control header for the status, unless the handler set its own
+func (w *cacheWriter) apply(code int) {
+	if w.applied {
+		return
+	}
+	w.applied = true
+
+	header := w.ResponseWriter.Header()
+	if header.Get("Cache-Control") != "" {
+		return
+	}
+	if code >= http.StatusOK && code < http.StatusMultipleChoices {
+		header.Set("Cache-Control", w.cacheable)
+		return
+	}
+	header.Set("Cache-Control", "no-store")
+}
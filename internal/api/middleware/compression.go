@@ -0,0 +1,201 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// compressor is implemented by both gzip and flate writers
+type compressor interface {
+	io.WriteCloser
+	Flush() error
+}
+
+// Compress compresses responses with gzip or deflate, whichever the client prefers. Responses
+// smaller than minSize bytes, already encoded or with an incompressible content type are sent
+// as they are. The level is a compress/flate level; 0 uses the default.
+func Compress(level, minSize int) gin.HandlerFunc {
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+
+	return func(c *gin.Context) {
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if encoding == "" || c.Request.Method == http.MethodHead || c.GetHeader("Upgrade") != "" {
+			c.Next()
+			return
+		}
+
+		writer := &compressWriter{
+			ResponseWriter: c.Writer,
+			encoding:       encoding,
+			level:          level,
+			minSize:        minSize,
+		}
+		c.Writer = writer
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+
+		defer writer.finish()
+		c.Next()
+	}
+}
+
+// negotiateEncoding picks gzip or deflate from an Accept-Encoding header, preferring gzip
+func negotiateEncoding(header string) string {
+	accepted := make(map[string]bool)
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		name := strings.ToLower(strings.TrimSpace(fields[0]))
+		accepted[name] = true
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if !strings.HasPrefix(param, "q=") {
+				continue
+			}
+			if q, err := strconv.ParseFloat(param[2:], 64); err == nil && q == 0 {
+				accepted[name] = false
+			}
+		}
+	}
+
+	switch {
+	case accepted["gzip"]:
+		return "gzip"
+	case accepted["deflate"]:
+		return "deflate"
+	case accepted["*"]:
+		return "gzip"
+	default:
+		return ""
+	}
+}
+
+// compressWriter buffers the start of a response until it knows whether compressing is worth it
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding string
+	level    int
+	minSize  int
+
+	buffer   bytes.Buffer
+	decided  bool
+	encoder  compressor
+	finished bool
+}
+
+// Write implements http.ResponseWriter
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.buffer.Write(data)
+		if w.buffer.Len() < w.minSize {
+			return len(data), nil
+		}
+		if err := w.decide(); err != nil {
+			return 0, err
+		}
+		return len(data), nil
+	}
+
+	if w.encoder != nil {
+		return w.encoder.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString implements io.StringWriter
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush sends what has been written so far, deciding on compression if still undecided
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		if err := w.decide(); err != nil {
+			return
+		}
+	}
+	if w.encoder != nil {
+		w.encoder.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// decide chooses whether to compress the response and writes out the buffered data
+func (w *compressWriter) decide() error {
+	w.decided = true
+	header := w.ResponseWriter.Header()
+
+	if header.Get("Content-Encoding") == "" && compressible(header.Get("Content-Type")) &&
+		w.Status() != http.StatusNoContent && w.Status() != http.StatusNotModified {
+		encoder, err := w.newEncoder()
+		if err != nil {
+			return err
+		}
+		w.encoder = encoder
+		header.Set("Content-Encoding", w.encoding)
+		header.Del("Content-Length")
+	}
+
+	buffered := w.buffer.Bytes()
+	w.buffer = bytes.Buffer{}
+	if len(buffered) == 0 {
+		return nil
+	}
+	if w.encoder != nil {
+		_, err := w.encoder.Write(buffered)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(buffered)
+	return err
+}
+
+// newEncoder creates the encoder for the negotiated encoding
+func (w *compressWriter) newEncoder() (compressor, error) {
+	if w.encoding == "deflate" {
+		return flate.NewWriter(w.ResponseWriter, w.level)
+	}
+	return gzip.NewWriterLevel(w.ResponseWriter, w.level)
+}
+
+// finish sends a response that stayed below the minimum size uncompressed, or closes the encoder
+func (w *compressWriter) finish() {
+	if w.finished {
+		return
+	}
+	w.finished = true
+
+	if !w.decided {
+		w.decided = true
+		if w.buffer.Len() > 0 {
+			w.ResponseWriter.Write(w.buffer.Bytes())
+		}
+		return
+	}
+	if w.encoder != nil {
+		w.encoder.Close()
+	}
+}
+
+// compressible reports whether a content type benefits from compression
+func compressible(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	if contentType == "" {
+		return true
+	}
+	if strings.HasPrefix(contentType, "text/") {
+		return true
+	}
+	for _, kind := range []string{"json", "xml", "yaml", "javascript", "csv", "calendar", "svg"} {
+		if strings.Contains(contentType, kind) {
+			return true
+		}
+	}
+	return false
+}
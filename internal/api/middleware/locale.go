@@ -0,0 +1,168 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+	"github.com/bernardofernandezz/scheduling-api/pkg/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// preferencesKey is the context key holding the locale and timezone of a request
+const preferencesKey = "locale_preferences"
+
+// TimezoneHeader is the request header clients name the IANA timezone they want times in with,
+// e.g. America/Sao_Paulo
+const TimezoneHeader = "Time-Zone"
+
+// requestPreferences are the locale and timezone of a request and whether the client asked for
+// them in its headers, which take precedence over the user's profile
+type requestPreferences struct {
+	i18n.Preferences
+	localeRequested   bool
+	timezoneRequested bool
+}
+
+// Locale negotiates the language of a request from its Accept-Language header and its timezone
+// from the Time-Zone header, and localizes error responses: JSON errors keep their English
+// "error" for existing clients and gain a machine "code" and a translated "message". Both are
+// put in the request's context for the layers below; UserLocale fills in what the headers leave
+// out once the caller is known.
+func Locale() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		preferences := &requestPreferences{Preferences: i18n.DefaultPreferences()}
+		preferences.Locale, preferences.localeRequested = i18n.MatchLocale(c.GetHeader("Accept-Language"))
+		if name := strings.TrimSpace(c.GetHeader(TimezoneHeader)); name != "" {
+			if location, err := time.LoadLocation(name); err == nil {
+				preferences.Location, preferences.timezoneRequested = location, true
+			}
+		}
+		setPreferences(c, preferences)
+		c.Writer.Header().Add("Vary", "Accept-Language")
+		c.Writer.Header().Add("Vary", TimezoneHeader)
+
+		writer := &localeWriter{ResponseWriter: c.Writer, context: c}
+		c.Writer = writer
+
+		defer writer.finish()
+		c.Next()
+	}
+}
+
+// UserLocale fills in the locale and timezone the headers of a signed-in user's request didn't
+// ask for from the user's profile and, for the timezone of employees who set none, their
+// operation. It runs after the authentication middleware.
+func UserLocale(localeService service.LocaleService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		preferences := requestPreferencesOf(c)
+		value, ok := c.Get("user")
+		user, isUser := value.(*models.User)
+		if !ok || !isUser || (preferences.localeRequested && preferences.timezoneRequested) {
+			c.Next()
+			return
+		}
+
+		profile := localeService.ForUser(user)
+		if !preferences.localeRequested {
+			preferences.Locale = profile.Locale
+		}
+		if !preferences.timezoneRequested {
+			preferences.Location = profile.Location
+		}
+		setPreferences(c, preferences)
+		c.Next()
+	}
+}
+
+// RequestLocale returns the locale a request is answered in
+func RequestLocale(c *gin.Context) string {
+	return requestPreferencesOf(c).Locale
+}
+
+// RequestTimezone returns the timezone times are presented in for a request
+func RequestTimezone(c *gin.Context) *time.Location {
+	return requestPreferencesOf(c).Location
+}
+
+// RequestPreferences returns the locale and timezone of a request
+func RequestPreferences(c *gin.Context) i18n.Preferences {
+	return requestPreferencesOf(c).Preferences
+}
+
+// requestPreferencesOf returns a copy of the preferences of a request, the defaults when the
+// Locale middleware didn't run
+func requestPreferencesOf(c *gin.Context) *requestPreferences {
+	if value, ok := c.Get(preferencesKey); ok {
+		if preferences, ok := value.(*requestPreferences); ok {
+			copied := *preferences
+			return &copied
+		}
+	}
+	return &requestPreferences{Preferences: i18n.DefaultPreferences()}
+}
+
+// setPreferences stores the preferences of a request in the gin context and in the request's
+// context, and announces the locale in Content-Language
+func setPreferences(c *gin.Context, preferences *requestPreferences) {
+	c.Set(preferencesKey, preferences)
+	c.Request = c.Request.WithContext(i18n.WithPreferences(c.Request.Context(), preferences.Preferences))
+	c.Header("Content-Language", preferences.Locale)
+}
+
+// localeWriter holds back JSON error bodies until they can be localized
+type localeWriter struct {
+	gin.ResponseWriter
+	context *gin.Context // read when the body is localized, by then the caller is known
+	buffer  bytes.Buffer
+}
+
+// Write implements http.ResponseWriter
+func (w *localeWriter) Write(data []byte) (int, error) {
+	if !w.localizes() {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.buffer.Write(data)
+}
+
+// WriteString implements io.StringWriter
+func (w *localeWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// localizes reports whether the response is a JSON error
+func (w *localeWriter) localizes() bool {
+	return w.Status() >= http.StatusBadRequest &&
+		strings.HasPrefix(w.Header().Get("Content-Type"), "application/json")
+}
+
+// finish adds the code and translated message to a held back error body and sends it. Bodies
+// that are not a JSON object with an "error" message, or already carry a code, are sent as
+// they are.
+func (w *localeWriter) finish() {
+	if w.buffer.Len() == 0 {
+		return
+	}
+	body := w.buffer.Bytes()
+
+	var payload map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&payload); err == nil {
+		message, isString := payload["error"].(string)
+		if _, hasCode := payload["code"]; isString && !hasCode {
+			localized := i18n.LocalizeError(RequestLocale(w.context), message)
+			payload["code"] = localized.Code
+			payload["message"] = localized.Message
+			if encoded, err := json.Marshal(payload); err == nil {
+				body = encoded
+			}
+		}
+	}
+
+	w.ResponseWriter.Write(body)
+}
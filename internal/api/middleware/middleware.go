@@ -3,32 +3,13 @@ package middleware
 import (
 	"net/http"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"golang.org/x/time/rate"
 )
 
-// SecurityHeaders adds security headers to all responses
-func SecurityHeaders() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// Add security headers to prevent common web vulnerabilities
-		c.Writer.Header().Set("X-Content-Type-Options", "nosniff")
-		c.Writer.Header().Set("X-Frame-Options", "DENY")
-		c.Writer.Header().Set("X-XSS-Protection", "1; mode=block")
-		c.Writer.Header().Set("Content-Security-Policy", "default-src 'self'")
-		c.Writer.Header().Set("Referrer-Policy", "strict-origin-when-cross-origin")
-		c.Writer.Header().Set("Permissions-Policy", "geolocation=(), microphone=(), camera=()")
-		
-		// Only add HSTS header in production
-		if gin.Mode() == gin.ReleaseMode {
-			c.Writer.Header().Set("Strict-Transport-Security", "max-age=31536000; includeSubDomains")
-		}
-		
-		c.Next()
-	}
-}
-
 // Client represents a client for rate limiting
 type Client struct {
 	limiter  *rate.Limiter
@@ -41,18 +22,6 @@ type ClientMap struct {
 	mu      sync.Mutex
 }
 
-// Global client map for rate limiting
-var (
-	clientMap = &ClientMap{
-		clients: make(map[string]*Client),
-	}
-)
-
-// init starts the cleanup goroutine for the client map
-func init() {
-	go clientMap.cleanup()
-}
-
 // cleanup periodically removes old clients from the map
 func (cm *ClientMap) cleanup() {
 	for {
@@ -83,24 +52,54 @@ func (cm *ClientMap) getClient(ip string, rps rate.Limit, burst int) *rate.Limit
 		}
 		cm.clients[ip] = client
 	} else {
-		// Update the last seen time
+		// Update the last seen time, and the rate if it was changed since
 		client.lastSeen = time.Now()
+		if client.limiter.Limit() != rps {
+			client.limiter.SetLimit(rps)
+			client.limiter.SetBurst(burst)
+		}
 	}
 	
 	return client.limiter
 }
 
-// RateLimiter creates a middleware that limits request rate per client IP
-func RateLimiter(requestsPerMinute int, per time.Duration) gin.HandlerFunc {
-	// Calculate requests per second
-	rps := rate.Limit(float64(requestsPerMinute) / per.Seconds())
-	
+// RateLimit limits the request rate per client IP. The allowed requests can be changed while
+// serving; clients seen before get the new rate on their next request.
+type RateLimit struct {
+	requests atomic.Int64
+	per      time.Duration
+	clients  *ClientMap
+}
+
+// NewRateLimit creates a rate limit allowing requests per period to each client IP
+func NewRateLimit(requests int, per time.Duration) *RateLimit {
+	clients := &ClientMap{
+		clients: make(map[string]*Client),
+	}
+	go clients.cleanup()
+
+	limit := &RateLimit{per: per, clients: clients}
+	limit.requests.Store(int64(requests))
+	return limit
+}
+
+// SetRequests changes the requests allowed per period
+func (l *RateLimit) SetRequests(requests int) {
+	l.requests.Store(int64(requests))
+}
+
+// Handler returns the middleware enforcing the rate limit
+func (l *RateLimit) Handler() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// Calculate requests per second
+		requests := int(l.requests.Load())
+		rps := rate.Limit(float64(requests) / l.per.Seconds())
+
 		// Get client IP
 		ip := c.ClientIP()
 		
 		// Get client limiter
-		limiter := clientMap.getClient(ip, rps, requestsPerMinute)
+		limiter := l.clients.getClient(ip, rps, requests)
 		
 		// Check if the request can be processed
 		if !limiter.Allow() {
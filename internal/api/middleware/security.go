@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"sync"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+// SecurityHeaders adds security headers to all responses, as the security settings in effect
+// configure them
+func SecurityHeaders(settings *config.SecuritySettings) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		security := settings.Current()
+		header := c.Writer.Header()
+
+		// Add security headers to prevent common web vulnerabilities
+		header.Set("X-Content-Type-Options", "nosniff")
+		header.Set("X-XSS-Protection", "1; mode=block")
+		setHeader(header.Set, "X-Frame-Options", security.Headers.FrameOptions)
+		setHeader(header.Set, "Content-Security-Policy", security.Headers.ContentSecurityPolicy)
+		setHeader(header.Set, "Referrer-Policy", security.Headers.ReferrerPolicy)
+		setHeader(header.Set, "Permissions-Policy", security.Headers.PermissionsPolicy)
+		setHeader(header.Set, "Strict-Transport-Security", security.HSTSValue())
+
+		c.Next()
+	}
+}
+
+// setHeader sets a header unless its configured value is empty
+func setHeader(set func(key, value string), key, value string) {
+	if value != "" {
+		set(key, value)
+	}
+}
+
+// CORS applies the CORS policy of the security settings in effect. The policy is built again the
+// first request after the settings are reloaded.
+func CORS(settings *config.SecuritySettings) gin.HandlerFunc {
+	var (
+		mu      sync.Mutex
+		builtOf *config.SecurityConfig
+		handler gin.HandlerFunc
+	)
+	current := func() gin.HandlerFunc {
+		security := settings.Current()
+
+		mu.Lock()
+		defer mu.Unlock()
+		if security != builtOf {
+			handler = cors.New(cors.Config{
+				AllowOrigins:     security.CORS.AllowedOrigins,
+				AllowMethods:     security.CORS.AllowedMethods,
+				AllowHeaders:     security.CORS.AllowedHeaders,
+				ExposeHeaders:    security.CORS.ExposedHeaders,
+				AllowCredentials: security.CORS.AllowCredentials,
+				AllowWildcard:    true,
+				MaxAge:           security.CORS.MaxAge,
+			})
+			builtOf = security
+		}
+		return handler
+	}
+
+	return func(c *gin.Context) {
+		current()(c)
+	}
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"strings"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// Usage counts every API request for usage analytics: its route template, status and latency,
+// attributed to the user or partner the authentication middleware set in the context. Requests
+// that matched no route and those outside the API, such as health checks, aren't counted.
+func Usage(usageService service.UsageService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if !strings.HasPrefix(route, "/api") {
+			return
+		}
+
+		sample := service.UsageSample{
+			ConsumerType: models.UsageConsumerAnonymous,
+			Method:       c.Request.Method,
+			Route:        route,
+			Status:       c.Writer.Status(),
+			Latency:      time.Since(start),
+			At:           start,
+		}
+		if value, ok := c.Get("user"); ok {
+			if user, ok := value.(*models.User); ok {
+				sample.ConsumerType, sample.ConsumerID = models.UsageConsumerUser, user.ID
+			}
+		} else if value, ok := c.Get("partner"); ok {
+			if credential, ok := value.(*models.PartnerCredential); ok {
+				sample.ConsumerType, sample.ConsumerID = models.UsageConsumerPartner, credential.ID
+			}
+		}
+		usageService.Record(sample)
+	}
+}
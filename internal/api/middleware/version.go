@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// API versions
+const (
+	APIVersion1 = 1
+	APIVersion2 = 2
+
+	// LatestAPIVersion is the newest version clients can ask for
+	LatestAPIVersion = APIVersion2
+)
+
+// apiVersionKey is the context key holding the API version of a request
+const apiVersionKey = "api_version"
+
+// vendorMediaType matches version-carrying Accept media types like application/vnd.scheduling.v2+json
+var vendorMediaType = regexp.MustCompile(`application/vnd\.scheduling\.v(\d+)\+json`)
+
+// PinAPIVersion serves a route group, such as /api/v2, under a fixed API version
+func PinAPIVersion(version int) gin.HandlerFunc {
+	header := strconv.Itoa(version)
+	return func(c *gin.Context) {
+		c.Set(apiVersionKey, version)
+		c.Header("API-Version", header)
+		c.Next()
+	}
+}
+
+// NegotiateAPIVersion serves an unversioned route group under the version the client asks for,
+// either with the API-Version header or an Accept media type like
+// application/vnd.scheduling.v2+json, and under defaultVersion otherwise. Unknown versions are
+// rejected with 406 Not Acceptable.
+func NegotiateAPIVersion(defaultVersion int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		version := defaultVersion
+
+		requested := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(c.GetHeader("API-Version"))), "v")
+		if requested == "" {
+			if match := vendorMediaType.FindStringSubmatch(c.GetHeader("Accept")); match != nil {
+				requested = match[1]
+			}
+		}
+		if requested != "" {
+			parsed, err := strconv.Atoi(requested)
+			if err != nil || parsed < APIVersion1 || parsed > LatestAPIVersion {
+				c.AbortWithStatusJSON(http.StatusNotAcceptable, gin.H{
+					"error":              "Unsupported API version " + requested,
+					"supported_versions": supportedAPIVersions(),
+				})
+				return
+			}
+			version = parsed
+		}
+
+		c.Set(apiVersionKey, version)
+		c.Header("API-Version", strconv.Itoa(version))
+		c.Writer.Header().Add("Vary", "API-Version, Accept")
+		c.Next()
+	}
+}
+
+// RequestAPIVersion returns the API version a request is served under, for handlers whose
+// behaviour differs between versions
+func RequestAPIVersion(c *gin.Context) int {
+	if version, ok := c.Get(apiVersionKey); ok {
+		if v, ok := version.(int); ok {
+			return v
+		}
+	}
+	return APIVersion1
+}
+
+// supportedAPIVersions lists the versions clients can ask for
+func supportedAPIVersions() []string {
+	versions := make([]string, 0, LatestAPIVersion)
+	for v := APIVersion1; v <= LatestAPIVersion; v++ {
+		versions = append(versions, "v"+strconv.Itoa(v))
+	}
+	return versions
+}
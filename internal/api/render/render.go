@@ -0,0 +1,335 @@
+// Package render encodes API responses in the media type a client asks for with the Accept
+// header. Encoders are pluggable: a Registry holds the ones an endpoint can answer with, the
+// first being the default for clients that accept anything. Encoders implementing StreamEncoder
+// can also write a Stream as it is read, for responses too large to hold in memory.
+package render
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrUnsupportedPayload is returned by encoders that can't represent a payload, like CSV for a
+// payload that isn't tabular
+var ErrUnsupportedPayload = errors.New("payload can't be encoded in this media type")
+
+// Encoder writes payloads in one media type
+type Encoder interface {
+	// MediaType is the media type the encoder writes, like application/json
+	MediaType() string
+	// ContentType is the Content-Type header of the responses it writes
+	ContentType() string
+	// Encode writes the payload, or returns ErrUnsupportedPayload if it can't represent it
+	Encode(w io.Writer, payload interface{}) error
+}
+
+// aliased is implemented by encoders that also answer to other media types, like text/xml for XML
+type aliased interface {
+	Aliases() []string
+}
+
+// Tabular is implemented by payloads that can be rendered as a table, such as CSV
+type Tabular interface {
+	// Header returns the column names
+	Header() []string
+	// Records returns the rows, each with a value per column
+	Records() [][]string
+}
+
+// Stream is a payload read in batches and written out as it is read, so a response never holds
+// more than a batch of it
+type Stream interface {
+	// Header returns the column names
+	Header() []string
+	// Each calls fn with each batch of rows in order. The next batch is only read once fn returns,
+	// and an error from fn stops it and is returned.
+	Each(fn func(rows []Row) error) error
+}
+
+// Row is a row of a Stream: JSON encoders write it as it marshals, CSV encoders write its Record
+type Row interface {
+	// Record returns the row's value for each column
+	Record() []string
+}
+
+// StreamEncoder is implemented by encoders that can write a Stream
+type StreamEncoder interface {
+	Encoder
+	// EncodeStream writes the stream a batch at a time, flushing each batch to the client when w
+	// is an http.Flusher. Nothing is written before the first batch is read, so a stream failing
+	// to start can still get an error response; a response cut short by an error is left
+	// incomplete.
+	EncodeStream(w io.Writer, stream Stream) error
+}
+
+// JSONEncoder writes payloads as JSON
+type JSONEncoder struct{}
+
+// MediaType returns application/json
+func (JSONEncoder) MediaType() string { return "application/json" }
+
+// ContentType returns the JSON content type
+func (JSONEncoder) ContentType() string { return "application/json; charset=utf-8" }
+
+// Encode writes the payload as JSON
+func (JSONEncoder) Encode(w io.Writer, payload interface{}) error {
+	return json.NewEncoder(w).Encode(payload)
+}
+
+// EncodeStream writes the stream as a JSON array, one row per line. The array is only closed once
+// the stream ends, so clients can tell a response cut short by an error.
+func (JSONEncoder) EncodeStream(w io.Writer, stream Stream) error {
+	separator := "[\n"
+	write := func(rows []Row) error {
+		for _, row := range rows {
+			encoded, err := json.Marshal(row)
+			if err != nil {
+				return err
+			}
+			if _, err := io.WriteString(w, separator); err != nil {
+				return err
+			}
+			if _, err := w.Write(encoded); err != nil {
+				return err
+			}
+			separator = ",\n"
+		}
+		flush(w)
+		return nil
+	}
+	if err := stream.Each(write); err != nil {
+		return err
+	}
+
+	closing := "\n]\n"
+	if separator == "[\n" {
+		closing = "[]\n"
+	}
+	_, err := io.WriteString(w, closing)
+	return err
+}
+
+// XMLEncoder writes payloads as XML. Payloads need a named root element, so maps like gin.H
+// can't be encoded.
+type XMLEncoder struct{}
+
+// MediaType returns application/xml
+func (XMLEncoder) MediaType() string { return "application/xml" }
+
+// Aliases returns text/xml, which older clients still ask for
+func (XMLEncoder) Aliases() []string { return []string{"text/xml"} }
+
+// ContentType returns the XML content type
+func (XMLEncoder) ContentType() string { return "application/xml; charset=utf-8" }
+
+// Encode writes the payload as an XML document
+func (XMLEncoder) Encode(w io.Writer, payload interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	if err := encoder.Encode(payload); err != nil {
+		var unsupported *xml.UnsupportedTypeError
+		if errors.As(err, &unsupported) {
+			return ErrUnsupportedPayload
+		}
+		return err
+	}
+	return encoder.Flush()
+}
+
+// CSVEncoder writes Tabular payloads as CSV with a header row
+type CSVEncoder struct{}
+
+// MediaType returns text/csv
+func (CSVEncoder) MediaType() string { return "text/csv" }
+
+// ContentType returns the CSV content type
+func (CSVEncoder) ContentType() string { return "text/csv; charset=utf-8" }
+
+// Encode writes the payload as CSV
+func (CSVEncoder) Encode(w io.Writer, payload interface{}) error {
+	table, ok := payload.(Tabular)
+	if !ok {
+		return ErrUnsupportedPayload
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(table.Header()); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(table.Records()); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+// EncodeStream writes the stream as CSV, the header row with the first batch
+func (CSVEncoder) EncodeStream(w io.Writer, stream Stream) error {
+	writer := csv.NewWriter(w)
+	started := false
+	start := func() error {
+		started = true
+		return writer.Write(stream.Header())
+	}
+
+	err := stream.Each(func(rows []Row) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		for _, row := range rows {
+			if err := writer.Write(row.Record()); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return err
+		}
+		flush(w)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !started {
+		if err := start(); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// flush sends what has been written to w so far to the client, if w buffers it
+func flush(w io.Writer) {
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Registry holds the encoders responses can be negotiated to
+type Registry struct {
+	encoders []Encoder
+}
+
+// NewRegistry creates a registry of encoders; the first one is the default
+func NewRegistry(encoders ...Encoder) *Registry {
+	return &Registry{encoders: encoders}
+}
+
+// Default answers in JSON unless the client asks for XML or CSV
+var Default = NewRegistry(JSONEncoder{}, XMLEncoder{}, CSVEncoder{})
+
+// Streaming answers streamed responses in JSON unless the client asks for CSV. XML documents
+// can't be written a batch at a time and aren't offered.
+var Streaming = NewRegistry(JSONEncoder{}, CSVEncoder{})
+
+// MediaTypes returns the media types the registry can answer with, the default first
+func (r *Registry) MediaTypes() []string {
+	types := make([]string, 0, len(r.encoders))
+	for _, encoder := range r.encoders {
+		types = append(types, encoder.MediaType())
+	}
+	return types
+}
+
+// Negotiate returns the encoder for the media type the Accept header prefers among those the
+// registry has. Without an Accept header the default encoder is used. Structured syntax suffixes
+// are honoured, so application/vnd.scheduling.v2+json picks JSON. It returns false when the client
+// accepts none of them.
+func (r *Registry) Negotiate(accept string) (Encoder, bool) {
+	if len(r.encoders) == 0 {
+		return nil, false
+	}
+	if strings.TrimSpace(accept) == "" {
+		return r.encoders[0], true
+	}
+
+	for _, mediaRange := range parseAccept(accept) {
+		if mediaRange.q == 0 {
+			break
+		}
+		for _, encoder := range r.encoders {
+			if mediaRange.matches(encoder.MediaType()) {
+				return encoder, true
+			}
+			if a, ok := encoder.(aliased); ok {
+				for _, alias := range a.Aliases() {
+					if mediaRange.mediaType == alias {
+						return encoder, true
+					}
+				}
+			}
+		}
+	}
+	return nil, false
+}
+
+// acceptRange is a media range of an Accept header with its quality
+type acceptRange struct {
+	mediaType string
+	q         float64
+}
+
+// parseAccept returns the media ranges of an Accept header, most preferred first. Ranges of the
+// same quality keep the client's order, except that more specific ones go before wildcards.
+func parseAccept(header string) []acceptRange {
+	var ranges []acceptRange
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(fields[0]))
+		if mediaType == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if !strings.HasPrefix(param, "q=") {
+				continue
+			}
+			if parsed, err := strconv.ParseFloat(param[2:], 64); err == nil {
+				q = parsed
+			}
+		}
+		ranges = append(ranges, acceptRange{mediaType: mediaType, q: q})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		if ranges[i].q != ranges[j].q {
+			return ranges[i].q > ranges[j].q
+		}
+		return strings.Count(ranges[i].mediaType, "*") < strings.Count(ranges[j].mediaType, "*")
+	})
+	return ranges
+}
+
+// matches reports whether the media range covers a media type
+func (a acceptRange) matches(mediaType string) bool {
+	if a.mediaType == "*/*" || a.mediaType == mediaType {
+		return true
+	}
+	kind, subtype, _ := strings.Cut(mediaType, "/")
+	rangeKind, rangeSubtype, _ := strings.Cut(a.mediaType, "/")
+	if rangeKind != kind {
+		return false
+	}
+	if rangeSubtype == "*" {
+		return true
+	}
+	// A structured syntax suffix names the format, e.g. application/vnd.scheduling.v2+json
+	if i := strings.LastIndex(rangeSubtype, "+"); i >= 0 {
+		return rangeSubtype[i+1:] == subtype
+	}
+	return false
+}
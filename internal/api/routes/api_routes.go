@@ -0,0 +1,476 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/api/handlers"
+	"github.com/bernardofernandezz/scheduling-api/pkg/auth"
+)
+
+// apiHandlers holds the handlers mounted under every API version
+type apiHandlers struct {
+	auth              *handlers.AuthHandler
+	appointment       *handlers.AppointmentHandler
+	operationConfig   *handlers.OperationConfigHandler
+	system            *handlers.SystemHandler
+	notificationPause *handlers.NotificationPauseHandler
+	supplierDocument  *handlers.SupplierDocumentHandler
+	gate              *handlers.GateHandler
+	location          *handlers.LocationHandler
+	catalog           *handlers.CatalogHandler
+	availability      *handlers.AvailabilityHandler
+	invitation        *handlers.InvitationHandler
+	watcher           *handlers.WatcherHandler
+	visibility        *handlers.VisibilityHandler
+	billing           *handlers.BillingHandler
+	fee               *handlers.FeeHandler
+	holiday           *handlers.HolidayHandler
+	waitlist          *handlers.WaitlistHandler
+	slotWatch         *handlers.SlotWatchHandler
+	skill             *handlers.SkillHandler
+	handover          *handlers.HandoverHandler
+	capacityCalendar  *handlers.CapacityCalendarHandler
+	sync              *handlers.SyncHandler
+	partner           *handlers.PartnerHandler
+	schema            *handlers.SchemaHandler
+	biExport          *handlers.BIExportHandler
+	eventReplay       *handlers.EventReplayHandler
+	usage             *handlers.UsageHandler
+	settings          *handlers.SettingsHandler
+	label             *handlers.LabelHandler
+	calendarFeed      *handlers.CalendarFeedHandler
+	broadcast         *handlers.BroadcastHandler
+	payment           *handlers.PaymentHandler
+	consistency       *handlers.ConsistencyHandler
+	locale            *handlers.LocaleHandler
+	quickAdd          *handlers.QuickAddHandler
+	draft             *handlers.DraftHandler
+	calendarOverlay   *handlers.CalendarOverlayHandler
+	region            *handlers.RegionHandler
+	experiment        *handlers.TemplateExperimentHandler
+	session           *handlers.SessionHandler
+}
+
+// apiMiddleware holds the middleware shared by the API route groups
+type apiMiddleware struct {
+	auth             gin.HandlerFunc
+	userLocale       gin.HandlerFunc
+	permissions      *auth.RoutePermissions
+	partner          gin.HandlerFunc
+	publicLimiter    gin.HandlerFunc
+	protectedLimiter gin.HandlerFunc
+	catalogCache     gin.HandlerFunc
+	templateCache    gin.HandlerFunc
+	refreshRevoked   gin.HandlerFunc // refuses revoked refresh tokens
+	passwordChanged  gin.HandlerFunc // revokes the user's tokens after a password change
+}
+
+// registerAPIRoutes registers the API routes on a version group. All versions share these
+// routes; handlers branch on middleware.RequestAPIVersion where a later version changes a
+// response, and endpoints that only exist in one version are registered on its group after
+// calling this.
+func registerAPIRoutes(api *gin.RouterGroup, h *apiHandlers, mw *apiMiddleware) {
+	// Public authentication routes
+	authRoutes := api.Group("/auth")
+	authRoutes.Use(mw.publicLimiter)
+	{
+		authRoutes.POST("/register", h.auth.Register)
+		authRoutes.POST("/login", h.auth.Login)
+		authRoutes.POST("/refresh", mw.refreshRevoked, h.auth.RefreshToken)
+		authRoutes.POST("/password-reset", h.auth.RequestPasswordReset)
+	}
+
+	// Public booking through invitation links
+	invitationRoutes := api.Group("/invitations")
+	invitationRoutes.Use(mw.publicLimiter)
+	{
+		invitationRoutes.GET("/:token", h.invitation.View)
+		invitationRoutes.GET("/:token/slots", h.invitation.Slots)
+		invitationRoutes.POST("/:token/appointments", h.invitation.Book)
+	}
+
+	// Calendar feeds fetched by calendar apps, authenticated by the token in the link
+	calendarRoutes := api.Group("/calendar/ics")
+	calendarRoutes.Use(mw.publicLimiter)
+	{
+		calendarRoutes.GET("/:token", h.calendarFeed.Subscribe)
+	}
+
+	// Images tracking email opens in template experiments, authenticated by the token in the link
+	api.GET("/notifications/opened/:token", mw.publicLimiter, h.experiment.TrackOpen)
+
+	// Payment provider webhook, authenticated by the signature of each event
+	paymentRoutes := api.Group("/payments")
+	paymentRoutes.Use(mw.publicLimiter)
+	{
+		paymentRoutes.POST("/webhook", h.payment.Webhook)
+	}
+
+	// Published CloudEvents schemas of the events the API emits
+	schemaRoutes := api.Group("/schemas")
+	schemaRoutes.Use(mw.publicLimiter)
+	{
+		schemaRoutes.GET("", h.schema.List)
+		schemaRoutes.GET("/:type/:version", h.schema.Get)
+		schemaRoutes.POST("/validate", h.schema.Validate)
+	}
+
+	// Release and commit of the running binary, for support to match reports to deploys
+	api.GET("/version", mw.publicLimiter, h.system.GetVersion)
+
+	// Calls from partner systems, authenticated by the HMAC signature of each request
+	partnerRoutes := api.Group("/partner")
+	partnerRoutes.Use(mw.publicLimiter, mw.partner)
+	{
+		partnerRoutes.PUT("/appointments/:code/reference", h.partner.SetReference)
+	}
+
+	// Protected routes requiring authentication, each open to the roles routePermissions grants
+	protected := api.Group("/")
+	protected.Use(mw.auth, mw.userLocale, mw.protectedLimiter, auth.Authorize(mw.permissions, api.BasePath()))
+	{
+		// User routes
+		userRoutes := protected.Group("/users")
+		{
+			userRoutes.GET("/profile", h.auth.Profile)
+			userRoutes.POST("/change-password", mw.passwordChanged, h.auth.ChangePassword)
+			userRoutes.POST("/logout", h.session.Logout)
+			userRoutes.POST("/logout-all", h.session.LogoutAll)
+			userRoutes.GET("/locale", h.locale.Get)
+			userRoutes.PUT("/locale", h.locale.Update)
+			userRoutes.GET("/calendar", h.calendarOverlay.Get)
+			userRoutes.PUT("/calendar", h.calendarOverlay.Connect)
+			userRoutes.DELETE("/calendar", h.calendarOverlay.Disconnect)
+		}
+
+		// Appointment routes
+		appointmentRoutes := protected.Group("/appointments")
+		{
+			// Basic CRUD operations
+			appointmentRoutes.POST("", h.appointment.Create)
+			appointmentRoutes.GET("", h.appointment.List)
+			appointmentRoutes.GET("/:id", h.appointment.Get)
+			appointmentRoutes.PUT("/:id", h.appointment.Update)
+			appointmentRoutes.DELETE("/:id", h.appointment.Delete)
+
+			// Status management
+			appointmentRoutes.POST("/:id/status", h.appointment.UpdateStatus)
+			appointmentRoutes.POST("/:id/complete", h.appointment.Complete)
+			appointmentRoutes.POST("/:id/undo-auto-complete", h.appointment.UndoAutoComplete)
+
+			// Cross-docking links between pickups and inbound deliveries
+			appointmentRoutes.POST("/:id/link-inbound", h.appointment.LinkInbound)
+			appointmentRoutes.DELETE("/:id/link-inbound", h.appointment.UnlinkInbound)
+			appointmentRoutes.GET("/:id/linked-pickups", h.appointment.GetLinkedPickups)
+
+			// Supplier delay declarations
+			appointmentRoutes.POST("/:id/eta", h.appointment.DeclareDelay)
+			appointmentRoutes.GET("/:id/delays", h.appointment.GetDelays)
+
+			// Status history
+			appointmentRoutes.GET("/:id/history", h.appointment.GetStatusHistory)
+
+			// Feedback on completed appointments
+			appointmentRoutes.POST("/:id/feedback", h.appointment.SubmitFeedback)
+			appointmentRoutes.GET("/:id/feedback", h.appointment.GetFeedback)
+
+			// Incidents logged by dock staff
+			appointmentRoutes.POST("/:id/incidents", h.appointment.ReportIncident)
+			appointmentRoutes.GET("/:id/incidents", h.appointment.GetIncidents)
+			appointmentRoutes.POST("/:id/incidents/:incident_id/resolve", h.appointment.ResolveIncident)
+			appointmentRoutes.POST("/:id/incidents/:incident_id/attachments", h.appointment.AddIncidentAttachments)
+
+			// Proof of delivery captured at the dock
+			appointmentRoutes.POST("/:id/proof-of-delivery", h.appointment.RecordProofOfDelivery)
+			appointmentRoutes.GET("/:id/proof-of-delivery", h.appointment.GetProofOfDelivery)
+			appointmentRoutes.POST("/:id/proof-of-delivery/attachments", h.appointment.AddProofOfDeliveryAttachments)
+
+			// Pallet labels for the dock label printers
+			appointmentRoutes.GET("/:id/labels", h.label.Labels)
+
+			// Deposit reserving the appointment at operations charging one
+			appointmentRoutes.GET("/:id/deposit", h.appointment.GetDeposit)
+
+			// Availability checking
+			appointmentRoutes.POST("/check-availability", h.appointment.CheckAvailability)
+			appointmentRoutes.POST("/check-travel", h.location.CheckTravel)
+
+			// Drafts read from free text, for bookings taken over the phone
+			appointmentRoutes.POST("/quick-add", h.quickAdd.Parse)
+
+			// Booking forms saved as they are filled in, to finish later or on another device
+			appointmentRoutes.POST("/drafts", h.draft.Create)
+			appointmentRoutes.GET("/drafts", h.draft.List)
+			appointmentRoutes.GET("/drafts/:key", h.draft.Get)
+			appointmentRoutes.PUT("/drafts/:key", h.draft.Save)
+			appointmentRoutes.DELETE("/drafts/:key", h.draft.Delete)
+
+			// Self-service booking invitations
+			appointmentRoutes.POST("/invitations", h.invitation.Create)
+			appointmentRoutes.GET("/invitations", h.invitation.List)
+			appointmentRoutes.DELETE("/invitations/:invitation_id", h.invitation.Revoke)
+
+			// Specialized queries
+			appointmentRoutes.GET("/upcoming", h.appointment.GetUpcoming)
+			appointmentRoutes.GET("/by-date-range", h.appointment.GetByDateRange)
+			appointmentRoutes.GET("/by-code/:code", h.appointment.GetByBookingCode)
+			appointmentRoutes.GET("/by-supplier/:supplier_id", h.appointment.GetBySupplier)
+			appointmentRoutes.GET("/by-employee/:employee_id", h.appointment.GetByEmployee)
+			appointmentRoutes.GET("/by-operation/:operation_id", h.appointment.GetByOperation)
+
+			// Visitors and gate pass
+			appointmentRoutes.GET("/:id/visitors", h.gate.ListVisitors)
+			appointmentRoutes.POST("/:id/visitors", h.gate.AddVisitor)
+			appointmentRoutes.DELETE("/:id/visitors/:visitor_id", h.gate.RemoveVisitor)
+			appointmentRoutes.GET("/:id/check-in-code", h.gate.CheckInCode)
+			appointmentRoutes.POST("/:id/location", h.gate.RecordLocation)
+
+			// Watchers copied on every notification of an appointment
+			appointmentRoutes.GET("/:id/watchers", h.watcher.ListWatchers)
+			appointmentRoutes.POST("/:id/watchers", h.watcher.AddWatcher)
+			appointmentRoutes.PUT("/:id/watchers/:watcher_id", h.watcher.UpdateWatcher)
+			appointmentRoutes.DELETE("/:id/watchers/:watcher_id", h.watcher.RemoveWatcher)
+			appointmentRoutes.POST("/:id/mute", h.watcher.Mute)
+			appointmentRoutes.DELETE("/:id/mute", h.watcher.Unmute)
+		}
+
+		// Gate check-in, manifest and shift handovers (staff only)
+		gateRoutes := protected.Group("/")
+		{
+			gateRoutes.POST("/gate/check-in", h.gate.CheckIn)
+			gateRoutes.GET("/operations/:id/manifest", h.gate.Manifest)
+
+			// Shift handover notes and each employee's day
+			gateRoutes.POST("/operations/:id/handovers", h.handover.Create)
+			gateRoutes.GET("/operations/:id/handovers", h.handover.List)
+			gateRoutes.GET("/my-day", h.handover.MyDay)
+		}
+
+		// Employee availability
+		employeeRoutes := protected.Group("/employees")
+		{
+			employeeRoutes.GET("/:id/open-slots", h.availability.OpenSlots)
+			employeeRoutes.GET("/:id/availability-exceptions", h.availability.ListExceptions)
+			employeeRoutes.POST("/:id/availability-exceptions", h.availability.CreateException)
+			employeeRoutes.GET("/:id/availability-exceptions/:exception_id/conflicts", h.availability.GetExceptionConflicts)
+			employeeRoutes.DELETE("/:id/availability-exceptions/:exception_id", h.availability.DeleteException)
+		}
+
+		// Reference data clients may cache
+		catalogRoutes := protected.Group("/")
+		catalogRoutes.Use(mw.catalogCache)
+		{
+			catalogRoutes.GET("/operations", h.catalog.ListOperations)
+			catalogRoutes.GET("/products", h.catalog.ListProducts)
+			catalogRoutes.GET("/labels", h.catalog.ListLabels)
+		}
+
+		// Supplier compliance documents
+		supplierRoutes := protected.Group("/suppliers")
+		{
+			supplierRoutes.GET("/:id/documents", h.supplierDocument.List)
+			supplierRoutes.POST("/:id/documents", h.supplierDocument.Create)
+			supplierRoutes.PUT("/:id/documents/:document_id", h.supplierDocument.Update)
+			supplierRoutes.DELETE("/:id/documents/:document_id", h.supplierDocument.Delete)
+			supplierRoutes.GET("/:id/compliance", h.supplierDocument.Compliance)
+		}
+
+		// Fees charged to suppliers
+		feeRoutes := protected.Group("/fees")
+		{
+			feeRoutes.GET("", h.fee.List)
+			feeRoutes.POST("/:id/dispute", h.fee.Dispute)
+		}
+
+		// Delta sync for the mobile apps' offline copy
+		protected.GET("/sync", h.sync.Changes)
+
+		// Waitlists for slots freed by cancellations
+		waitlistRoutes := protected.Group("/waitlist")
+		{
+			waitlistRoutes.POST("", h.waitlist.Join)
+			waitlistRoutes.GET("", h.waitlist.List)
+			waitlistRoutes.DELETE("/:id", h.waitlist.Withdraw)
+		}
+
+		// Watching operations for freed capacity, and booking the openings broadcast to watchers
+		slotWatchRoutes := protected.Group("/slot-watches")
+		{
+			slotWatchRoutes.POST("", h.slotWatch.Watch)
+			slotWatchRoutes.GET("", h.slotWatch.List)
+			slotWatchRoutes.DELETE("/:id", h.slotWatch.Unwatch)
+		}
+		slotOpeningRoutes := protected.Group("/slot-openings")
+		{
+			slotOpeningRoutes.GET("/:id", h.slotWatch.GetOpening)
+			slotOpeningRoutes.POST("/:id/appointments", h.slotWatch.BookOpening)
+		}
+
+		// Filtered calendar feeds of the signed-in user
+		calendarFeedRoutes := protected.Group("/calendar/feeds")
+		{
+			calendarFeedRoutes.POST("", h.calendarFeed.Create)
+			calendarFeedRoutes.GET("", h.calendarFeed.List)
+			calendarFeedRoutes.GET("/:id", h.calendarFeed.Get)
+			calendarFeedRoutes.PUT("/:id", h.calendarFeed.Update)
+			calendarFeedRoutes.DELETE("/:id", h.calendarFeed.Delete)
+			calendarFeedRoutes.POST("/:id/rotate-token", h.calendarFeed.RotateToken)
+		}
+
+		// Regions: roll-ups of several operations for the employees managing them
+		regionRoutes := protected.Group("/regions")
+		{
+			regionRoutes.GET("", h.region.List)
+			regionRoutes.GET("/:id", h.region.Get)
+			regionRoutes.GET("/:id/appointments", h.region.ListAppointments)
+			regionRoutes.GET("/:id/statistics", h.region.GetStatistics)
+		}
+
+		// Admin routes (requires admin role)
+		adminRoutes := protected.Group("/admin")
+		{
+			adminRoutes.GET("/statistics/appointments", h.appointment.GetStatistics)
+			adminRoutes.GET("/statistics/deliveries", h.appointment.GetDeliveryReport)
+			adminRoutes.GET("/statistics/feedback", h.appointment.GetFeedbackScores)
+			adminRoutes.GET("/statistics/suppliers", h.appointment.GetSupplierPerformance)
+			adminRoutes.GET("/statistics/status-transitions", h.appointment.GetStatusTransitions)
+			adminRoutes.GET("/statistics/denials", h.appointment.GetDenials)
+
+			// Operation configuration-as-code
+			adminRoutes.GET("/operations/:id/config", h.operationConfig.Export)
+			adminRoutes.POST("/operations/config/preview", h.operationConfig.Preview)
+			adminRoutes.POST("/operations/config/import", h.operationConfig.Import)
+
+			// Sessions
+			adminRoutes.POST("/users/:id/revoke-tokens", h.session.RevokeUser)
+
+			// System introspection
+			adminRoutes.GET("/system/circuit-breakers", h.system.GetCircuitBreakers)
+			adminRoutes.POST("/system/circuit-breakers/:name/reset", h.system.ResetCircuitBreaker)
+			adminRoutes.GET("/system/queues", h.system.GetQueues)
+			adminRoutes.GET("/system/workers", h.system.GetWorkers)
+			adminRoutes.GET("/system/leader", h.system.GetLeader)
+			adminRoutes.GET("/system/locks", h.system.GetLocks)
+			adminRoutes.POST("/system/locks/release-stuck", h.system.ReleaseStuckLocks)
+			adminRoutes.POST("/system/locks/:id/release", h.system.ReleaseLock)
+			adminRoutes.GET("/system/jobs", h.system.GetJobs)
+			adminRoutes.PUT("/system/jobs/:name", h.system.UpdateJob)
+			adminRoutes.GET("/system/missing-templates", h.system.GetMissingTemplates)
+			adminRoutes.GET("/system/consistency", h.consistency.Check)
+			adminRoutes.POST("/system/consistency/repair", h.consistency.Repair)
+
+			// Notification maintenance windows
+			adminRoutes.GET("/notifications/pause", h.notificationPause.Status)
+			adminRoutes.POST("/notifications/pause", h.notificationPause.Pause)
+			adminRoutes.POST("/notifications/resume", h.notificationPause.Resume)
+
+			// Supplier compliance document requirements
+			adminRoutes.GET("/operations/:id/document-requirements", h.supplierDocument.GetRequirements)
+			adminRoutes.PUT("/operations/:id/document-requirements", h.supplierDocument.SetRequirements)
+			adminRoutes.POST("/supplier-documents/notify-expiring", h.supplierDocument.NotifyExpiring)
+
+			// Notification templates
+			templateRoutes := adminRoutes.Group("/notification-templates")
+			templateRoutes.Use(mw.templateCache)
+			{
+				templateRoutes.GET("", h.catalog.ListTemplates)
+			}
+
+			// Notification template A/B tests
+			adminRoutes.GET("/notification-experiments", h.experiment.List)
+			adminRoutes.POST("/notification-experiments", h.experiment.Create)
+			adminRoutes.GET("/notification-experiments/:id", h.experiment.Get)
+			adminRoutes.POST("/notification-experiments/:id/stop", h.experiment.Stop)
+
+			// Appointment label templates
+			adminRoutes.GET("/operations/:id/label-template", h.label.GetTemplate)
+			adminRoutes.PUT("/operations/:id/label-template", h.label.SetTemplate)
+			adminRoutes.DELETE("/operations/:id/label-template", h.label.ResetTemplate)
+
+			// Appointment type capacity rules
+			adminRoutes.GET("/operations/:id/appointment-capacities", h.appointment.GetTypeCapacities)
+			adminRoutes.PUT("/operations/:id/appointment-capacities", h.appointment.SetTypeCapacities)
+
+			// Capacity per date and time band, overriding the appointment type rules
+			adminRoutes.GET("/operations/:id/capacity-calendar", h.capacityCalendar.Calendar)
+			adminRoutes.GET("/operations/:id/capacity-calendar/history", h.capacityCalendar.History)
+			adminRoutes.PUT("/operations/:id/capacity-calendar/:date", h.capacityCalendar.SetDay)
+			adminRoutes.DELETE("/operations/:id/capacity-calendar/:date", h.capacityCalendar.ResetDay)
+
+			// Availability tools
+			adminRoutes.POST("/employees/:id/availability/copy", h.availability.CopyAvailability)
+			adminRoutes.GET("/operations/:id/default-schedule", h.availability.GetDefaultSchedule)
+			adminRoutes.PUT("/operations/:id/default-schedule", h.availability.SetDefaultSchedule)
+			adminRoutes.POST("/operations/:id/default-schedule/apply", h.availability.ApplyDefaultSchedule)
+			adminRoutes.POST("/operations/:id/availability/shift", h.availability.ShiftSlots)
+
+			// Address geocoding
+			adminRoutes.POST("/operations/:id/geocode", h.location.GeocodeOperation)
+			adminRoutes.POST("/suppliers/:id/geocode", h.location.GeocodeSupplier)
+
+			// Operation blackout calendars and public holiday import
+			adminRoutes.GET("/operations/:id/blackouts", h.holiday.ListBlackouts)
+			adminRoutes.POST("/operations/:id/blackouts/sync", h.holiday.Sync)
+			adminRoutes.PUT("/operations/:id/blackouts/:date", h.holiday.SetBlackout)
+			adminRoutes.DELETE("/operations/:id/blackouts/:date", h.holiday.RemoveBlackout)
+
+			// Field visibility rules
+			adminRoutes.GET("/visibility-rules", h.visibility.GetGlobalRules)
+			adminRoutes.PUT("/visibility-rules", h.visibility.SetGlobalRules)
+			adminRoutes.GET("/operations/:id/visibility-rules", h.visibility.GetOperationRules)
+			adminRoutes.PUT("/operations/:id/visibility-rules", h.visibility.SetOperationRules)
+
+			// Employee skills and the skills appointments require
+			adminRoutes.GET("/employees/:id/skills", h.skill.GetEmployeeSkills)
+			adminRoutes.PUT("/employees/:id/skills", h.skill.SetEmployeeSkills)
+			adminRoutes.GET("/skill-requirements", h.skill.GetGlobalRequirements)
+			adminRoutes.PUT("/skill-requirements", h.skill.SetGlobalRequirements)
+			adminRoutes.GET("/operations/:id/skill-requirements", h.skill.GetOperationRequirements)
+			adminRoutes.PUT("/operations/:id/skill-requirements", h.skill.SetOperationRequirements)
+
+			// Regions and their managers
+			adminRoutes.GET("/regions", h.region.List)
+			adminRoutes.POST("/regions", h.region.Create)
+			adminRoutes.PUT("/regions/:id", h.region.Update)
+			adminRoutes.DELETE("/regions/:id", h.region.Delete)
+
+			// Cost centers, billing codes and the billing export
+			adminRoutes.GET("/billing-codes", h.billing.ListCodes)
+			adminRoutes.POST("/billing-codes", h.billing.CreateCode)
+			adminRoutes.PUT("/billing-codes/:id", h.billing.UpdateCode)
+			adminRoutes.GET("/billing/export", h.billing.Export)
+			adminRoutes.PUT("/appointments/:id/billing", h.appointment.SetBilling)
+			adminRoutes.POST("/fees/:id/uphold", h.fee.Uphold)
+			adminRoutes.POST("/fees/:id/waive", h.fee.Waive)
+
+			// Partner API credentials
+			adminRoutes.GET("/partners", h.partner.ListCredentials)
+			adminRoutes.POST("/partners", h.partner.CreateCredential)
+			adminRoutes.POST("/partners/:id/rotate", h.partner.RotateSecret)
+			adminRoutes.DELETE("/partners/:id", h.partner.RevokeCredential)
+
+			// Anonymized appointment export for the data warehouse
+			adminRoutes.GET("/bi-export/schema", h.biExport.Schema)
+			adminRoutes.GET("/bi-export/runs", h.biExport.ListRuns)
+			adminRoutes.GET("/bi-export/runs/:id", h.biExport.GetRun)
+			adminRoutes.POST("/bi-export/runs", h.biExport.Run)
+
+			// Streamed exports of every appointment and of past events
+			adminRoutes.GET("/appointments/export", h.appointment.Export)
+			adminRoutes.GET("/events/replay", h.eventReplay.Replay)
+
+			// API usage per consumer and route
+			adminRoutes.GET("/usage", h.usage.Summary)
+
+			// Operational parameters changed at runtime
+			adminRoutes.GET("/settings", h.settings.List)
+			adminRoutes.GET("/settings/:key", h.settings.Get)
+			adminRoutes.PUT("/settings/:key", h.settings.Update)
+			adminRoutes.DELETE("/settings/:key", h.settings.Reset)
+
+			// Ad-hoc messages to an audience
+			adminRoutes.POST("/broadcast", h.broadcast.Send)
+			adminRoutes.POST("/broadcast/preview", h.broadcast.Preview)
+			adminRoutes.GET("/broadcasts", h.broadcast.List)
+		}
+	}
+}
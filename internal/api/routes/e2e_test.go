@@ -0,0 +1,230 @@
+package routes_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// e2ePassword is the password of every user the end-to-end flow registers
+const e2ePassword = "e2e-password-123"
+
+// flow holds what earlier steps of the end-to-end flow created for later steps
+type flow struct {
+	server *testServer
+	run    string
+
+	adminToken    string
+	supplierToken string
+	supplierUser  uint
+	employeeUser  uint
+
+	supplierID    uint
+	employeeID    uint
+	operationID   uint
+	productID     uint
+	appointmentID uint
+}
+
+// TestEndToEndBookingFlow runs the main booking flow through the full router over HTTP:
+// register, login, create an appointment, change its status and check the notifications it
+// enqueued. It stops at the first failing step, as later steps build on earlier ones.
+func TestEndToEndBookingFlow(t *testing.T) {
+	f := &flow{server: newTestServer(t), run: testRun()}
+
+	steps := []struct {
+		name string
+		run  func(t *testing.T)
+	}{
+		{"health check", f.health},
+		{"register users", f.register},
+		{"login", f.login},
+		{"seed reference data", f.seed},
+		{"reject unknown fields", f.rejectUnknownFields},
+		{"create appointment", f.createAppointment},
+		{"confirm appointment", f.confirmAppointment},
+		{"check notifications", f.checkNotifications},
+	}
+	for _, step := range steps {
+		if !t.Run(step.name, step.run) {
+			return
+		}
+	}
+}
+
+// health checks the router is up
+func (f *flow) health(t *testing.T) {
+	f.do(t, http.MethodGet, "/health", "", nil, http.StatusOK, nil)
+}
+
+// register creates an admin, an employee and a supplier user through the API
+func (f *flow) register(t *testing.T) {
+	for _, role := range []string{"admin", "employee", "supplier"} {
+		var response struct {
+			User  models.User `json:"user"`
+			Token string      `json:"token"`
+		}
+		body := map[string]string{
+			"name":     "E2E " + role,
+			"email":    f.email(role),
+			"password": e2ePassword,
+			"role":     role,
+			"phone":    "+5511999990000",
+		}
+		f.do(t, http.MethodPost, "/api/v1/auth/register", "", body, http.StatusCreated, &response)
+		switch role {
+		case "employee":
+			f.employeeUser = response.User.ID
+		case "supplier":
+			f.supplierUser = response.User.ID
+		}
+	}
+}
+
+// login signs in the admin and supplier users
+func (f *flow) login(t *testing.T) {
+	for _, role := range []string{"admin", "supplier"} {
+		var response struct {
+			Token string `json:"token"`
+		}
+		body := map[string]string{"email": f.email(role), "password": e2ePassword}
+		f.do(t, http.MethodPost, "/api/v1/auth/login", "", body, http.StatusOK, &response)
+		require.NotEmpty(t, response.Token, "login %s: no token in response", role)
+		if role == "admin" {
+			f.adminToken = response.Token
+		} else {
+			f.supplierToken = response.Token
+		}
+	}
+}
+
+// seed creates the employee, supplier, operation and product records the booking needs, which
+// have no public endpoints
+func (f *flow) seed(t *testing.T) {
+	db := f.server.repos.GetDB()
+
+	employee := &models.Employee{UserID: f.employeeUser, Department: "Receiving", EmployeeNumber: "E2E-" + f.run}
+	require.NoError(t, db.Create(employee).Error, "employee")
+	supplier := &models.Supplier{UserID: f.supplierUser, CompanyName: "E2E Supplier " + f.run, CNPJ: f.run}
+	require.NoError(t, db.Create(supplier).Error, "supplier")
+	operation := &models.Operation{
+		Name:      "E2E Operation " + f.run,
+		Code:      "E2E-" + f.run,
+		Address:   "Rua E2E, 1",
+		City:      "São Paulo",
+		State:     "SP",
+		ZipCode:   "01000-000",
+		ManagerID: employee.ID,
+		Active:    true,
+	}
+	require.NoError(t, db.Create(operation).Error, "operation")
+	product := &models.Product{Name: "E2E Product", SKU: "E2E-" + f.run, SupplierID: supplier.ID, Active: true}
+	require.NoError(t, db.Create(product).Error, "product")
+
+	f.employeeID = employee.ID
+	f.supplierID = supplier.ID
+	f.operationID = operation.ID
+	f.productID = product.ID
+}
+
+// rejectUnknownFields checks a mistyped field is answered with 400 rather than ignored
+func (f *flow) rejectUnknownFields(t *testing.T) {
+	body := f.appointmentBody()
+	body["schedule_start"] = body["scheduled_start"]
+	f.do(t, http.MethodPost, "/api/v1/appointments", f.supplierToken, body, http.StatusBadRequest, nil)
+}
+
+// createAppointment books a delivery as the supplier
+func (f *flow) createAppointment(t *testing.T) {
+	var response struct {
+		Appointment models.Appointment `json:"appointment"`
+	}
+	f.do(t, http.MethodPost, "/api/v1/appointments", f.supplierToken, f.appointmentBody(), http.StatusCreated, &response)
+	require.NotZero(t, response.Appointment.ID, "no appointment in response")
+	assert.Equal(t, models.StatusPending, response.Appointment.Status)
+	f.appointmentID = response.Appointment.ID
+}
+
+// confirmAppointment confirms the booking as an admin and reads it back
+func (f *flow) confirmAppointment(t *testing.T) {
+	path := fmt.Sprintf("/api/v1/appointments/%d", f.appointmentID)
+	body := map[string]string{"status": string(models.StatusConfirmed)}
+	f.do(t, http.MethodPost, path+"/status", f.adminToken, body, http.StatusOK, nil)
+
+	var response struct {
+		Appointment models.Appointment `json:"appointment"`
+	}
+	f.do(t, http.MethodGet, path, f.adminToken, nil, http.StatusOK, &response)
+	assert.Equal(t, models.StatusConfirmed, response.Appointment.Status)
+}
+
+// checkNotifications checks creating and confirming the appointment enqueued notifications
+func (f *flow) checkNotifications(t *testing.T) {
+	var count int64
+	err := f.server.repos.GetDB().Model(&models.Notification{}).
+		Where("appointment_id = ?", f.appointmentID).
+		Count(&count).Error
+	require.NoError(t, err)
+	assert.NotZero(t, count, "no notifications enqueued for appointment %d", f.appointmentID)
+}
+
+// appointmentBody returns a booking request for tomorrow morning
+func (f *flow) appointmentBody() map[string]interface{} {
+	tomorrow := time.Now().AddDate(0, 0, 1)
+	start := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 10, 0, 0, 0, time.Local)
+	return map[string]interface{}{
+		"supplier_id":         f.supplierID,
+		"employee_id":         f.employeeID,
+		"operation_id":        f.operationID,
+		"product_id":          f.productID,
+		"scheduled_start":     start,
+		"scheduled_end":       start.Add(time.Hour),
+		"quantity_to_deliver": 10,
+		"notes":               "e2e run " + f.run,
+	}
+}
+
+// email returns the address of the run's user with role
+func (f *flow) email(role string) string {
+	return fmt.Sprintf("e2e-%s-%s@example.com", role, f.run)
+}
+
+// do sends a JSON request, requires the response status and decodes the response into out
+func (f *flow) do(t *testing.T, method, path, token string, body interface{}, wantStatus int, out interface{}) {
+	t.Helper()
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, f.server.URL+path, reader)
+	require.NoError(t, err)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := f.server.client.Do(req)
+	require.NoError(t, err, "%s %s", method, path)
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, wantStatus, resp.StatusCode, "%s %s: %s", method, path, strings.TrimSpace(string(data)))
+	if out != nil {
+		require.NoError(t, json.Unmarshal(data, out), "%s %s: decoding response", method, path)
+	}
+}
@@ -0,0 +1,278 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/bernardofernandezz/scheduling-api/pkg/auth"
+)
+
+// routePermissions declares who may call each API route, relative to the version group. The
+// Authorize middleware enforces it on authenticated routes, the router refuses to start while a
+// registered route is missing from it, and TestRoutePermissions checks every route answers 401 or 403 to
+// each role it doesn't grant. Handlers still scope what an allowed caller sees, e.g. suppliers
+// only get their own appointments.
+var routePermissions = []auth.RouteRule{
+	// Sign-up and sign-in
+	route(http.MethodPost, "/auth/register", auth.PermissionPublic),
+	route(http.MethodPost, "/auth/login", auth.PermissionPublic),
+	route(http.MethodPost, "/auth/refresh", auth.PermissionPublic),
+	route(http.MethodPost, "/auth/password-reset", auth.PermissionPublic),
+
+	// Booking through invitation links
+	route(http.MethodGet, "/invitations/:token", auth.PermissionPublic),
+	route(http.MethodGet, "/invitations/:token/slots", auth.PermissionPublic),
+	route(http.MethodPost, "/invitations/:token/appointments", auth.PermissionPublic),
+
+	// Calendar apps fetching feeds through their links
+	route(http.MethodGet, "/calendar/ics/:token", auth.PermissionPublic),
+
+	// Email open tracking of template experiments
+	route(http.MethodGet, "/notifications/opened/:token", auth.PermissionPublic),
+
+	// Payment provider webhook
+	route(http.MethodPost, "/payments/webhook", auth.PermissionPublic),
+
+	// Published event schemas
+	route(http.MethodGet, "/schemas", auth.PermissionPublic),
+	route(http.MethodGet, "/schemas/:type/:version", auth.PermissionPublic),
+	route(http.MethodPost, "/schemas/validate", auth.PermissionPublic),
+
+	// Build of the running binary
+	route(http.MethodGet, "/version", auth.PermissionPublic),
+
+	// Signed calls from partner systems
+	route(http.MethodPut, "/partner/appointments/:code/reference", auth.PermissionPartner),
+
+	// Signed-in user
+	route(http.MethodGet, "/users/profile", auth.PermissionAuthenticated),
+	route(http.MethodPost, "/users/change-password", auth.PermissionAuthenticated),
+	route(http.MethodPost, "/users/logout", auth.PermissionAuthenticated),
+	route(http.MethodPost, "/users/logout-all", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/users/locale", auth.PermissionAuthenticated),
+	route(http.MethodPut, "/users/locale", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/users/calendar", auth.PermissionAuthenticated),
+	route(http.MethodPut, "/users/calendar", auth.PermissionAuthenticated),
+	route(http.MethodDelete, "/users/calendar", auth.PermissionAuthenticated),
+
+	// Appointments; handlers scope suppliers and employees to their own
+	route(http.MethodPost, "/appointments", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/appointments", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/appointments/:id", auth.PermissionAuthenticated),
+	route(http.MethodPut, "/appointments/:id", auth.PermissionAuthenticated),
+	route(http.MethodDelete, "/appointments/:id", auth.PermissionAuthenticated),
+	route(http.MethodPost, "/appointments/:id/status", auth.PermissionAuthenticated),
+	route(http.MethodPost, "/appointments/:id/complete", auth.PermissionAuthenticated),
+	route(http.MethodPost, "/appointments/:id/undo-auto-complete", auth.PermissionStaff),
+	route(http.MethodPost, "/appointments/:id/link-inbound", auth.PermissionAuthenticated),
+	route(http.MethodDelete, "/appointments/:id/link-inbound", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/appointments/:id/linked-pickups", auth.PermissionAuthenticated),
+	route(http.MethodPost, "/appointments/:id/eta", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/appointments/:id/delays", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/appointments/:id/history", auth.PermissionAuthenticated),
+	route(http.MethodPost, "/appointments/:id/feedback", auth.PermissionFeedback),
+	route(http.MethodGet, "/appointments/:id/feedback", auth.PermissionAuthenticated),
+	route(http.MethodPost, "/appointments/:id/incidents", auth.PermissionStaff),
+	route(http.MethodGet, "/appointments/:id/incidents", auth.PermissionAuthenticated),
+	route(http.MethodPost, "/appointments/:id/incidents/:incident_id/resolve", auth.PermissionStaff),
+	route(http.MethodPost, "/appointments/:id/incidents/:incident_id/attachments", auth.PermissionStaff),
+	route(http.MethodPost, "/appointments/:id/proof-of-delivery", auth.PermissionStaff),
+	route(http.MethodGet, "/appointments/:id/proof-of-delivery", auth.PermissionAuthenticated),
+	route(http.MethodPost, "/appointments/:id/proof-of-delivery/attachments", auth.PermissionStaff),
+	route(http.MethodGet, "/appointments/:id/labels", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/appointments/:id/deposit", auth.PermissionAuthenticated),
+	route(http.MethodPost, "/appointments/check-availability", auth.PermissionAuthenticated),
+	route(http.MethodPost, "/appointments/check-travel", auth.PermissionAuthenticated),
+	route(http.MethodPost, "/appointments/quick-add", auth.PermissionAdmin),
+	route(http.MethodPost, "/appointments/drafts", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/appointments/drafts", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/appointments/drafts/:key", auth.PermissionAuthenticated),
+	route(http.MethodPut, "/appointments/drafts/:key", auth.PermissionAuthenticated),
+	route(http.MethodDelete, "/appointments/drafts/:key", auth.PermissionAuthenticated),
+	route(http.MethodPost, "/appointments/invitations", auth.PermissionStaff),
+	route(http.MethodGet, "/appointments/invitations", auth.PermissionStaff),
+	route(http.MethodDelete, "/appointments/invitations/:invitation_id", auth.PermissionStaff),
+	route(http.MethodGet, "/appointments/upcoming", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/appointments/by-date-range", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/appointments/by-code/:code", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/appointments/by-supplier/:supplier_id", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/appointments/by-employee/:employee_id", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/appointments/by-operation/:operation_id", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/appointments/:id/visitors", auth.PermissionAuthenticated),
+	route(http.MethodPost, "/appointments/:id/visitors", auth.PermissionAuthenticated),
+	route(http.MethodDelete, "/appointments/:id/visitors/:visitor_id", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/appointments/:id/check-in-code", auth.PermissionAuthenticated),
+	route(http.MethodPost, "/appointments/:id/location", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/appointments/:id/watchers", auth.PermissionAuthenticated),
+	route(http.MethodPost, "/appointments/:id/watchers", auth.PermissionAuthenticated),
+	route(http.MethodPut, "/appointments/:id/watchers/:watcher_id", auth.PermissionAuthenticated),
+	route(http.MethodDelete, "/appointments/:id/watchers/:watcher_id", auth.PermissionAuthenticated),
+	route(http.MethodPost, "/appointments/:id/mute", auth.PermissionAuthenticated),
+	route(http.MethodDelete, "/appointments/:id/mute", auth.PermissionAuthenticated),
+
+	// Gate, manifest and shift handovers
+	route(http.MethodPost, "/gate/check-in", auth.PermissionStaff),
+	route(http.MethodGet, "/operations/:id/manifest", auth.PermissionStaff),
+	route(http.MethodPost, "/operations/:id/handovers", auth.PermissionStaff),
+	route(http.MethodGet, "/operations/:id/handovers", auth.PermissionStaff),
+	route(http.MethodGet, "/my-day", auth.PermissionStaff),
+
+	// Employee availability
+	route(http.MethodGet, "/employees/:id/open-slots", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/employees/:id/availability-exceptions", auth.PermissionAuthenticated),
+	route(http.MethodPost, "/employees/:id/availability-exceptions", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/employees/:id/availability-exceptions/:exception_id/conflicts", auth.PermissionAuthenticated),
+	route(http.MethodDelete, "/employees/:id/availability-exceptions/:exception_id", auth.PermissionAuthenticated),
+
+	// Reference data
+	route(http.MethodGet, "/operations", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/products", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/labels", auth.PermissionAuthenticated),
+
+	// Supplier compliance documents
+	route(http.MethodGet, "/suppliers/:id/documents", auth.PermissionAuthenticated),
+	route(http.MethodPost, "/suppliers/:id/documents", auth.PermissionAuthenticated),
+	route(http.MethodPut, "/suppliers/:id/documents/:document_id", auth.PermissionAuthenticated),
+	route(http.MethodDelete, "/suppliers/:id/documents/:document_id", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/suppliers/:id/compliance", auth.PermissionAuthenticated),
+
+	// Fees
+	route(http.MethodGet, "/fees", auth.PermissionAuthenticated),
+	route(http.MethodPost, "/fees/:id/dispute", auth.PermissionAuthenticated),
+
+	// Delta sync
+	route(http.MethodGet, "/sync", auth.PermissionAuthenticated),
+
+	// Waitlists
+	route(http.MethodPost, "/waitlist", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/waitlist", auth.PermissionAuthenticated),
+	route(http.MethodDelete, "/waitlist/:id", auth.PermissionAuthenticated),
+
+	// Slot watches and the openings broadcast to them; handlers limit booking to suppliers
+	route(http.MethodPost, "/slot-watches", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/slot-watches", auth.PermissionAuthenticated),
+	route(http.MethodDelete, "/slot-watches/:id", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/slot-openings/:id", auth.PermissionAuthenticated),
+	route(http.MethodPost, "/slot-openings/:id/appointments", auth.PermissionAuthenticated),
+
+	// Calendar feeds; handlers scope each user to their own
+	route(http.MethodPost, "/calendar/feeds", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/calendar/feeds", auth.PermissionAuthenticated),
+	route(http.MethodGet, "/calendar/feeds/:id", auth.PermissionAuthenticated),
+	route(http.MethodPut, "/calendar/feeds/:id", auth.PermissionAuthenticated),
+	route(http.MethodDelete, "/calendar/feeds/:id", auth.PermissionAuthenticated),
+	route(http.MethodPost, "/calendar/feeds/:id/rotate-token", auth.PermissionAuthenticated),
+
+	// Regions; employees only see the regions they manage
+	route(http.MethodGet, "/regions", auth.PermissionStaff),
+	route(http.MethodGet, "/regions/:id", auth.PermissionStaff),
+	route(http.MethodGet, "/regions/:id/appointments", auth.PermissionStaff),
+	route(http.MethodGet, "/regions/:id/statistics", auth.PermissionStaff),
+
+	// Administration
+	route(http.MethodGet, "/admin/statistics/appointments", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/statistics/deliveries", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/statistics/feedback", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/statistics/suppliers", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/statistics/status-transitions", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/statistics/denials", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/operations/:id/config", auth.PermissionAdmin),
+	route(http.MethodPost, "/admin/operations/config/preview", auth.PermissionAdmin),
+	route(http.MethodPost, "/admin/operations/config/import", auth.PermissionAdmin),
+	route(http.MethodPost, "/admin/users/:id/revoke-tokens", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/system/circuit-breakers", auth.PermissionAdmin),
+	route(http.MethodPost, "/admin/system/circuit-breakers/:name/reset", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/system/queues", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/system/workers", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/system/leader", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/system/locks", auth.PermissionAdmin),
+	route(http.MethodPost, "/admin/system/locks/release-stuck", auth.PermissionAdmin),
+	route(http.MethodPost, "/admin/system/locks/:id/release", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/system/jobs", auth.PermissionAdmin),
+	route(http.MethodPut, "/admin/system/jobs/:name", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/system/missing-templates", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/system/consistency", auth.PermissionAdmin),
+	route(http.MethodPost, "/admin/system/consistency/repair", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/notifications/pause", auth.PermissionAdmin),
+	route(http.MethodPost, "/admin/notifications/pause", auth.PermissionAdmin),
+	route(http.MethodPost, "/admin/notifications/resume", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/operations/:id/document-requirements", auth.PermissionAdmin),
+	route(http.MethodPut, "/admin/operations/:id/document-requirements", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/operations/:id/label-template", auth.PermissionAdmin),
+	route(http.MethodPut, "/admin/operations/:id/label-template", auth.PermissionAdmin),
+	route(http.MethodDelete, "/admin/operations/:id/label-template", auth.PermissionAdmin),
+	route(http.MethodPost, "/admin/supplier-documents/notify-expiring", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/notification-templates", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/notification-experiments", auth.PermissionAdmin),
+	route(http.MethodPost, "/admin/notification-experiments", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/notification-experiments/:id", auth.PermissionAdmin),
+	route(http.MethodPost, "/admin/notification-experiments/:id/stop", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/operations/:id/appointment-capacities", auth.PermissionAdmin),
+	route(http.MethodPut, "/admin/operations/:id/appointment-capacities", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/operations/:id/capacity-calendar", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/operations/:id/capacity-calendar/history", auth.PermissionAdmin),
+	route(http.MethodPut, "/admin/operations/:id/capacity-calendar/:date", auth.PermissionAdmin),
+	route(http.MethodDelete, "/admin/operations/:id/capacity-calendar/:date", auth.PermissionAdmin),
+	route(http.MethodPost, "/admin/employees/:id/availability/copy", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/operations/:id/default-schedule", auth.PermissionAdmin),
+	route(http.MethodPut, "/admin/operations/:id/default-schedule", auth.PermissionAdmin),
+	route(http.MethodPost, "/admin/operations/:id/default-schedule/apply", auth.PermissionAdmin),
+	route(http.MethodPost, "/admin/operations/:id/availability/shift", auth.PermissionAdmin),
+	route(http.MethodPost, "/admin/operations/:id/geocode", auth.PermissionAdmin),
+	route(http.MethodPost, "/admin/suppliers/:id/geocode", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/operations/:id/blackouts", auth.PermissionAdmin),
+	route(http.MethodPost, "/admin/operations/:id/blackouts/sync", auth.PermissionAdmin),
+	route(http.MethodPut, "/admin/operations/:id/blackouts/:date", auth.PermissionAdmin),
+	route(http.MethodDelete, "/admin/operations/:id/blackouts/:date", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/visibility-rules", auth.PermissionAdmin),
+	route(http.MethodPut, "/admin/visibility-rules", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/operations/:id/visibility-rules", auth.PermissionAdmin),
+	route(http.MethodPut, "/admin/operations/:id/visibility-rules", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/employees/:id/skills", auth.PermissionAdmin),
+	route(http.MethodPut, "/admin/employees/:id/skills", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/skill-requirements", auth.PermissionAdmin),
+	route(http.MethodPut, "/admin/skill-requirements", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/operations/:id/skill-requirements", auth.PermissionAdmin),
+	route(http.MethodPut, "/admin/operations/:id/skill-requirements", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/regions", auth.PermissionAdmin),
+	route(http.MethodPost, "/admin/regions", auth.PermissionAdmin),
+	route(http.MethodPut, "/admin/regions/:id", auth.PermissionAdmin),
+	route(http.MethodDelete, "/admin/regions/:id", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/billing-codes", auth.PermissionAdmin),
+	route(http.MethodPost, "/admin/billing-codes", auth.PermissionAdmin),
+	route(http.MethodPut, "/admin/billing-codes/:id", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/billing/export", auth.PermissionAdmin),
+	route(http.MethodPut, "/admin/appointments/:id/billing", auth.PermissionAdmin),
+	route(http.MethodPost, "/admin/fees/:id/uphold", auth.PermissionAdmin),
+	route(http.MethodPost, "/admin/fees/:id/waive", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/partners", auth.PermissionAdmin),
+	route(http.MethodPost, "/admin/partners", auth.PermissionAdmin),
+	route(http.MethodPost, "/admin/partners/:id/rotate", auth.PermissionAdmin),
+	route(http.MethodDelete, "/admin/partners/:id", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/bi-export/schema", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/bi-export/runs", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/bi-export/runs/:id", auth.PermissionAdmin),
+	route(http.MethodPost, "/admin/bi-export/runs", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/appointments/export", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/events/replay", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/usage", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/settings", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/settings/:key", auth.PermissionAdmin),
+	route(http.MethodPut, "/admin/settings/:key", auth.PermissionAdmin),
+	route(http.MethodDelete, "/admin/settings/:key", auth.PermissionAdmin),
+	route(http.MethodPost, "/admin/broadcast", auth.PermissionAdmin),
+	route(http.MethodPost, "/admin/broadcast/preview", auth.PermissionAdmin),
+	route(http.MethodGet, "/admin/broadcasts", auth.PermissionAdmin),
+}
+
+// APIBasePaths are the paths of the API version groups every API route is registered under
+var APIBasePaths = []string{"/api", "/api/v1", "/api/v2"}
+
+// RoutePermissions returns the declared permission of every API route
+func RoutePermissions() (*auth.RoutePermissions, error) {
+	return auth.NewRoutePermissions(routePermissions)
+}
+
+// route declares the permission a route requires
+func route(method, path string, permission auth.Permission) auth.RouteRule {
+	return auth.RouteRule{Method: method, Path: path, Permission: permission}
+}
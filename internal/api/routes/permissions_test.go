@@ -0,0 +1,214 @@
+package routes_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/api/routes"
+	"github.com/bernardofernandezz/scheduling-api/pkg/auth"
+	"github.com/stretchr/testify/require"
+)
+
+// authzPassword is the password of every user the authorization tests register
+const authzPassword = "authz-password-123"
+
+// anonymous is the caller without credentials
+const anonymous = "anonymous"
+
+// caller is who a route is called as
+type caller struct {
+	name  string // a role, or anonymous
+	token string
+}
+
+// result is the outcome of calling a route as a caller
+type result struct {
+	status  int
+	refused bool // refused by the Authorize middleware rather than by the handler
+}
+
+// TestRoutePermissions calls every registered route of every API version anonymously and as a
+// user of each role: routes must answer 401 to anonymous callers and 403 to roles their
+// permission doesn't grant, and must let the roles it grants through. Granted roles are only
+// exercised on GET routes, so the test never changes data.
+func TestRoutePermissions(t *testing.T) {
+	server := newTestServer(t)
+
+	permissions, err := routes.RoutePermissions()
+	require.NoError(t, err, "loading route permissions")
+
+	callers := []caller{{name: anonymous}}
+	run := testRun()
+	for _, role := range auth.Roles {
+		token, err := server.registerRole(role, run)
+		require.NoError(t, err, "registering a %s", role)
+		callers = append(callers, caller{name: role, token: token})
+	}
+
+	checked := 0
+	for _, route := range server.router.Routes() {
+		for _, basePath := range routes.APIBasePaths {
+			if !strings.HasPrefix(route.Path, basePath+"/") {
+				continue
+			}
+			path := strings.TrimPrefix(route.Path, basePath)
+			permission, ok := permissions.Lookup(route.Method, path)
+			if !ok {
+				// Another version group's route, e.g. /api/v1/... while checking /api
+				continue
+			}
+
+			for _, who := range callers {
+				want, call := expectation(permission, who, route.Method)
+				if !call {
+					continue
+				}
+				got, err := server.send(route.Method, fillParams(route.Path), who.token)
+				checked++
+				if err != nil {
+					t.Errorf("%s %s as %s: %v", route.Method, route.Path, who.name, err)
+					continue
+				}
+				if problem := want(got); problem != "" {
+					t.Errorf("%s %s as %s (%s): %s", route.Method, route.Path, who.name, permission, problem)
+				}
+			}
+		}
+	}
+	require.NotZero(t, checked, "no routes checked")
+	t.Logf("%d authorization checks", checked)
+}
+
+// expectation returns what a route requiring permission must answer a caller, and whether the
+// call is made at all
+func expectation(permission auth.Permission, who caller, method string) (func(result) string, bool) {
+	switch {
+	case permission == auth.PermissionPartner:
+		// A user token is no partner signature
+		return wantStatus(http.StatusUnauthorized), true
+	case permission == auth.PermissionPublic:
+		if who.name != anonymous || method != http.MethodGet {
+			return nil, false
+		}
+		return wantThrough, true
+	case who.name == anonymous:
+		return wantStatus(http.StatusUnauthorized), true
+	case !permission.Allows(who.name):
+		return wantRefused, true
+	case method == http.MethodGet:
+		return wantThrough, true
+	default:
+		return nil, false
+	}
+}
+
+// wantStatus expects a status
+func wantStatus(status int) func(result) string {
+	return func(got result) string {
+		if got.status != status {
+			return fmt.Sprintf("got status %d, want %d", got.status, status)
+		}
+		return ""
+	}
+}
+
+// wantRefused expects the Authorize middleware to refuse the call
+func wantRefused(got result) string {
+	if got.status != http.StatusForbidden || !got.refused {
+		return fmt.Sprintf("got status %d, want 403 from the route permission", got.status)
+	}
+	return ""
+}
+
+// wantThrough expects the call to reach the handler; what the handler answers to made-up IDs
+// doesn't matter
+func wantThrough(got result) string {
+	if got.status == http.StatusUnauthorized || got.refused {
+		return fmt.Sprintf("got status %d, want the call let through", got.status)
+	}
+	return ""
+}
+
+// registerRole signs up a user with a role and returns their token
+func (s *testServer) registerRole(role, run string) (string, error) {
+	body, err := json.Marshal(map[string]string{
+		"name":     "Authz " + role,
+		"email":    fmt.Sprintf("authz-%s-%s@example.com", role, run),
+		"password": authzPassword,
+		"role":     role,
+		"phone":    "+5511999990000",
+	})
+	if err != nil {
+		return "", err
+	}
+	resp, err := s.client.Post(s.URL+"/api/v1/auth/register", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		Token string `json:"token"`
+	}
+	if resp.StatusCode != http.StatusCreated {
+		data, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return "", err
+	}
+	if response.Token == "" {
+		return "", fmt.Errorf("no token in response")
+	}
+	return response.Token, nil
+}
+
+// send calls a route with an empty JSON body
+func (s *testServer) send(method, path, token string) (result, error) {
+	var body io.Reader
+	if method != http.MethodGet && method != http.MethodDelete {
+		body = strings.NewReader("{}")
+	}
+	req, err := http.NewRequest(method, s.URL+path, body)
+	if err != nil {
+		return result{}, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return result{}, err
+	}
+	defer resp.Body.Close()
+
+	var response struct {
+		RequiredPermission string `json:"required_permission"`
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return result{}, err
+	}
+	refused := json.Unmarshal(data, &response) == nil && response.RequiredPermission != ""
+	return result{status: resp.StatusCode, refused: refused}, nil
+}
+
+// fillParams replaces the parameters of a route path with made-up values
+func fillParams(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "1"
+		}
+	}
+	return strings.Join(segments, "/")
+}
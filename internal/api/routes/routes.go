@@ -1,160 +1,616 @@
 package routes
 
 import (
+	"context"
+	"log"
 	"net/http"
-	"os"
-	"strconv"
 	"strings"
 	"time"
 
-	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/bernardofernandezz/scheduling-api/internal/api/handlers"
 	"github.com/bernardofernandezz/scheduling-api/internal/api/middleware"
 	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/metrics"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
 	"github.com/bernardofernandezz/scheduling-api/internal/repository"
 	"github.com/bernardofernandezz/scheduling-api/internal/service"
 	"github.com/bernardofernandezz/scheduling-api/pkg/auth"
+	"github.com/bernardofernandezz/scheduling-api/pkg/buildinfo"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+	"github.com/bernardofernandezz/scheduling-api/pkg/events"
+	"github.com/bernardofernandezz/scheduling-api/pkg/jobs"
+	"github.com/bernardofernandezz/scheduling-api/pkg/leader"
 )
 
-// SetupRouter configures and returns the API router
-func SetupRouter(repos *repository.Repositories, cfg *config.Config) *gin.Engine {
+// SetupRouter configures and returns the API router. Background jobs needed by the
+// services are registered on the scheduler, which the caller starts. Scheduled runs only happen
+// while the elector leads; a nil elector lets every replica run them.
+func SetupRouter(repos *repository.Repositories, cfg *config.Config, scheduler *jobs.Scheduler, elector *leader.Elector) *gin.Engine {
 	// Set Gin mode based on configuration
 	gin.SetMode(cfg.Server.Mode)
 
 	// Initialize router with recovery and logging
 	router := gin.New()
-	router.Use(gin.Recovery())
+	router.Use(middleware.Recovery())
 	router.Use(gin.Logger())
-	router.Use(middleware.SecurityHeaders())
-
-	// Configure CORS with environment settings
-	corsOrigins := strings.Split(os.Getenv("CORS_ALLOWED_ORIGINS"), ",")
-	if len(corsOrigins) == 0 || (len(corsOrigins) == 1 && corsOrigins[0] == "") {
-		corsOrigins = []string{"*"} // Default to all origins if not specified
-	}
-
-	router.Use(cors.New(cors.Config{
-		AllowOrigins:     corsOrigins,
-		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Authorization", "Content-Type", "Accept"},
-		ExposeHeaders:    []string{"Content-Length"},
-		AllowCredentials: true,
-		MaxAge:           12 * time.Hour,
-	}))
-
-	// Configure rate limits from environment
-	reqLimit, _ := strconv.Atoi(os.Getenv("RATE_LIMIT_REQUESTS"))
-	if reqLimit <= 0 {
-		reqLimit = 60 // Default to 60 requests
-	}
-	
-	rateDuration := os.Getenv("RATE_LIMIT_DURATION")
-	var duration time.Duration
-	if rateDuration == "" {
-		duration = time.Minute // Default to 1 minute
-	} else {
-		var err error
-		duration, err = time.ParseDuration(rateDuration)
-		if err != nil {
-			duration = time.Minute // Default to 1 minute on parse error
-		}
+	router.Use(middleware.BuildVersion())
+	router.Use(middleware.SecurityHeaders(cfg.Security))
+
+	// Reject oversized request bodies and, unless disabled, JSON fields endpoints do not know
+	router.Use(middleware.BodyLimit(int64(cfg.HTTP.MaxBodyBytes)))
+	if cfg.HTTP.StrictJSON {
+		router.Use(middleware.StrictJSON())
+	}
+
+	// Compress responses for clients on slow connections
+	if cfg.HTTP.CompressionEnabled {
+		router.Use(middleware.Compress(cfg.HTTP.CompressionLevel, cfg.HTTP.CompressionMinBytes))
+	}
+
+	// Answer in the client's language and give error responses a machine code
+	router.Use(middleware.Locale())
+
+	// Apply the CORS policy of the security settings, which SIGHUP reloads
+	router.Use(middleware.CORS(cfg.Security))
+
+	// Circuit breakers and the HTTP client shared by all external provider integrations
+	providerBreakers := service.NewProviderBreakers(cfg.Breaker)
+	outboundClient := service.NewOutboundClient(cfg.OutboundHTTP)
+
+	// Services read the time from a shared clock so time-based rules can be run at a fixed instant
+	systemClock := clock.System{}
+
+	// Operational parameters admins change at runtime, read here before the services using them
+	settingsService := service.NewSettingsService(repos.SettingRepo, cfg)
+	if err := settingsService.Reload(); err != nil {
+		log.Printf("Failed to load runtime settings, using the configured values: %v", err)
 	}
 
 	// Create services
 	userService := service.NewUserService(repos.UserRepo, cfg)
+	notificationPauseService := service.NewNotificationPauseService(
+		repos.PauseRepo,
+		repos.NotificationRepo,
+		repos.QueueRepo,
+		cfg,
+		systemClock,
+	)
+	recipientResolver := service.NewRecipientResolver(
+		repos.UserRepo,
+		repos.EmployeeRepo,
+		repos.SupplierRepo,
+		repos.PreferenceRepo,
+		repos.OperationRepo,
+	)
+	localeService := service.NewLocaleService(repos.PreferenceRepo, recipientResolver)
+	notificationService := service.NewNotificationService(
+		repos.NotificationRepo,
+		repos.TemplateRepo,
+		repos.QueueRepo,
+		recipientResolver,
+		repos.OperationRepo,
+		repos.WatcherRepo,
+		repos.MuteRepo,
+		repos.ExperimentRepo,
+		cfg,
+		providerBreakers,
+		outboundClient,
+		notificationPauseService,
+		systemClock,
+	)
+	supplierDocumentService := service.NewSupplierDocumentService(
+		repos.DocumentRepo,
+		repos.RequirementRepo,
+		repos.SupplierRepo,
+		notificationService,
+		cfg,
+		systemClock,
+	)
+	waitlistService := service.NewWaitlistService(
+		repos.WaitlistRepo,
+		repos.SupplierRepo,
+		repos.OperationRepo,
+		notificationService,
+		cfg,
+		systemClock,
+	)
+	slotWatchService := service.NewSlotWatchService(
+		repos.SlotWatchRepo,
+		repos.SupplierRepo,
+		repos.OperationRepo,
+		notificationService,
+		settingsService,
+		cfg,
+		systemClock,
+	)
+	// External calendar sync isn't set up yet, so cancellations have no synced events to remove
+	// and appointments aren't synced to employees' calendars
+	var calendarService service.CalendarService
+	cancellationService := service.NewCancellationService(
+		repos.NotificationRepo,
+		repos.QueueRepo,
+		calendarService,
+		waitlistService,
+		slotWatchService,
+	)
+	// The schemas are built into the binary, so failing to load them is a build defect
+	eventCatalog, err := events.NewCatalog()
+	if err != nil {
+		log.Fatalf("Failed to load event schemas: %v", err)
+	}
+
+	// Side effects of appointment changes, run in this order whenever the appointment service
+	// publishes one
+	appointmentEvents := service.NewAppointmentEvents()
+	appointmentEvents.Subscribe("status_history", service.RecordStatusHistory(repos.StatusEventRepo),
+		service.AppointmentEventCreated, service.AppointmentEventStatusChanged)
+	appointmentEvents.Subscribe("notifications", service.NotifyParticipants(notificationService))
+	if calendarService != nil {
+		appointmentEvents.Subscribe("calendar_sync", service.SyncEmployeeCalendar(calendarService, repos.EmployeeRepo))
+	}
+	schemaBaseURL := strings.TrimRight(cfg.Server.BaseURL, "/") + "/api/schemas"
+	if cfg.Webhooks.URL != "" {
+		appointmentEvents.Subscribe("webhooks", service.PublishWebhooks(eventCatalog, cfg.Webhooks, schemaBaseURL, outboundClient, systemClock))
+	}
+
 	appointmentService := service.NewAppointmentService(
 		repos.AppointmentRepo,
 		repos.EmployeeRepo,
 		repos.SupplierRepo,
+		repos.OperationRepo,
+		service.AppointmentDeps{
+			ReportRepo:          repos.ReportRepo,
+			LinkRepo:            repos.LinkRepo,
+			DelayRepo:           repos.DelayRepo,
+			FeedbackRepo:        repos.FeedbackRepo,
+			IncidentRepo:        repos.IncidentRepo,
+			ProofRepo:           repos.ProofRepo,
+			BillingRepo:         repos.BillingRepo,
+			OverdueRepo:         repos.OverdueRepo,
+			BookingCodeRepo:     repos.BookingCodeRepo,
+			StatusEventRepo:     repos.StatusEventRepo,
+			ProductRepo:         repos.ProductRepo,
+			DocumentService:     supplierDocumentService,
+			CapacityRepo:        repos.CapacityRepo,
+			FeeRepo:             repos.FeeRepo,
+			BlackoutRepo:        repos.BlackoutRepo,
+			DenialRepo:          repos.DenialRepo,
+			SkillRepo:           repos.SkillRepo,
+			DepositRepo:         repos.DepositRepo,
+			NotificationService: notificationService,
+			CancellationService: cancellationService,
+			SettingsService:     settingsService,
+			PaymentProvider:     service.NewPaymentProvider(cfg.Payments, outboundClient),
+			Breakers:            providerBreakers,
+			Events:              appointmentEvents,
+			Config:              cfg,
+		},
+		systemClock,
+	)
+	// Needs the appointment service to count bookings, so subscribes once it exists
+	appointmentEvents.Subscribe("capacity_warnings",
+		service.WarnOperationManager(appointmentService, repos.OperationRepo, notificationService),
+		service.AppointmentEventCreated)
+	gateService := service.NewGateService(
+		repos.AppointmentRepo,
+		repos.VisitorRepo,
+		repos.OperationRepo,
+		repos.PingRepo,
+		repos.HandoverRepo,
+		notificationService,
+		cfg,
+		systemClock,
+	)
+	handoverService := service.NewHandoverService(
+		repos.HandoverRepo,
+		repos.OperationRepo,
+		repos.EmployeeRepo,
+		repos.AvailabilityRepo,
+		repos.ExceptionRepo,
+		repos.AppointmentRepo,
+		notificationService,
+		systemClock,
+	)
+	locationService := service.NewLocationService(
+		repos.AppointmentRepo,
+		repos.OperationRepo,
+		repos.SupplierRepo,
+		service.NewGeocoder(cfg.Geocoding, outboundClient),
+		providerBreakers,
+		cfg,
+	)
+	holidayService := service.NewHolidayService(
+		repos.BlackoutRepo,
+		repos.OperationRepo,
+		service.NewHolidayProvider(cfg.Holidays, outboundClient),
+		providerBreakers,
+		systemClock,
+	)
+	catalogService := service.NewCatalogService(
 		repos.OperationRepo,
 		repos.ProductRepo,
+		repos.TemplateRepo,
+	)
+	operationConfigService := service.NewOperationConfigService(
+		repos.OperationRepo,
+		repos.AvailabilityRepo,
+		repos.EmployeeRepo,
+		repos.TemplateRepo,
+		systemClock,
+	)
+	availabilityService := service.NewAvailabilityService(
+		repos.AvailabilityRepo,
+		repos.ExceptionRepo,
+		repos.ScheduleRepo,
+		repos.EmployeeRepo,
+		repos.OperationRepo,
+		repos.AppointmentRepo,
+		repos.BlackoutRepo,
+		repos.SkillRepo,
+		repos.ProductRepo,
+		repos.EmployeeCalRepo,
+		systemClock,
+	)
+	invitationService := service.NewInvitationService(
+		repos.InvitationRepo,
+		repos.EmployeeRepo,
+		repos.SupplierRepo,
+		repos.OperationRepo,
+		repos.ProductRepo,
+		repos.SkillRepo,
+		appointmentService,
+		availabilityService,
+		notificationService,
+		cfg,
+		systemClock,
+	)
+	visibilityService := service.NewVisibilityService(
+		repos.VisibilityRepo,
+		repos.OperationRepo,
+	)
+	skillService := service.NewSkillService(
+		repos.SkillRepo,
+		repos.EmployeeRepo,
+		repos.OperationRepo,
+	)
+	capacityCalendarService := service.NewCapacityCalendarService(
+		repos.CapacityRepo,
+		repos.OperationRepo,
+		repos.BlackoutRepo,
+		slotWatchService,
+		systemClock,
+	)
+	syncService := service.NewSyncService(
+		repos.SyncRepo,
+		repos.SupplierRepo,
+		repos.EmployeeRepo,
+		cfg.Sync,
+		systemClock,
+	)
+	partnerService := service.NewPartnerService(
+		repos.PartnerRepo,
+		cfg.Partners,
+		systemClock,
+	)
+	biExportService := service.NewBIExportService(
+		repos.BIExportRepo,
+		service.NewObjectStore(cfg.BIExport, outboundClient),
+		repos.NewLeaderLock("bi-export"),
+		cfg.BIExport,
+		systemClock,
+	)
+	eventReplayService := service.NewEventReplayService(repos.StatusEventRepo, repos.AppointmentRepo, eventCatalog, schemaBaseURL, cfg, systemClock)
+	usageService := service.NewUsageService(repos.UsageRepo, cfg.Usage, systemClock)
+	labelService := service.NewLabelService(repos.LabelRepo, repos.OperationRepo)
+	calendarFeedService := service.NewCalendarFeedService(
+		repos.CalendarFeedRepo,
+		repos.UserRepo,
+		repos.EmployeeRepo,
+		repos.SupplierRepo,
+		repos.OperationRepo,
+		cfg,
+		systemClock,
+	)
+	broadcastService := service.NewBroadcastService(
+		repos.BroadcastRepo,
+		repos.OperationRepo,
+		recipientResolver,
+		notificationService,
+		cfg.Broadcasts,
+		systemClock,
+	)
+	consistencyService := service.NewConsistencyService(repos.ConsistencyRepo, cfg.Consistency, systemClock)
+	quickAddService := service.NewQuickAddService(repos.SupplierRepo, repos.ProductRepo, repos.OperationRepo, systemClock)
+	draftService := service.NewDraftService(repos.DraftRepo, cfg.Drafts, systemClock)
+	regionService := service.NewRegionService(repos.RegionRepo, repos.OperationRepo, repos.EmployeeRepo, systemClock)
+	templateExperimentService := service.NewTemplateExperimentService(repos.ExperimentRepo, repos.TemplateRepo, cfg, systemClock)
+	tokenRevocationService := service.NewTokenRevocationService(repos.RevocationRepo, cfg.Auth, systemClock)
+	calendarOverlayService := service.NewCalendarOverlayService(
+		repos.EmployeeCalRepo,
+		repos.EmployeeRepo,
+		service.NewFreeBusyProviders(cfg.CalendarOverlay, outboundClient),
+		providerBreakers,
+		cfg.CalendarOverlay,
+		systemClock,
+	)
+	billingService := service.NewBillingService(repos.BillingRepo)
+	feeService := service.NewFeeService(
+		repos.FeeRepo,
+		repos.AppointmentRepo,
+		repos.SupplierRepo,
+		repos.OperationRepo,
+		notificationService,
+		cfg,
+		systemClock,
+	)
+	watcherService := service.NewWatcherService(
+		repos.WatcherRepo,
+		repos.UserRepo,
+	)
+	systemService := service.NewSystemService(
+		repos.QueueRepo,
+		notificationService,
+		repos.JobRepo,
+		scheduler,
+		elector,
+		cfg.Jobs.RunRetention,
+		systemClock,
 	)
 
+	// Background jobs. Scheduled runs happen on the elected leader, which records each of them in
+	// job_runs so no run happens twice while leadership changes hands.
+	scheduler.SetCoordinator(service.NewJobCoordinator(repos.JobRepo, elector, cfg.Jobs.Instance, systemClock))
+	registerJob(scheduler, cfg.Jobs, "supplier_document_expiry", cfg.SupplierDocuments.CheckInterval, func(ctx context.Context) error {
+		_, err := supplierDocumentService.NotifyExpiring()
+		return err
+	})
+	registerJob(scheduler, cfg.Jobs, "auto_complete_appointments", cfg.AutoComplete.CheckInterval, func(ctx context.Context) error {
+		_, err := appointmentService.AutoCompleteOverdue()
+		return err
+	})
+	registerJob(scheduler, cfg.Jobs, "expire_pending_appointments", cfg.PendingExpiry.CheckInterval, func(ctx context.Context) error {
+		_, err := appointmentService.ExpirePending()
+		return err
+	})
+	registerJob(scheduler, cfg.Jobs, "expire_unpaid_deposits", cfg.Payments.CheckInterval, func(ctx context.Context) error {
+		_, err := appointmentService.ExpireUnpaidDeposits()
+		return err
+	})
+	registerJob(scheduler, cfg.Jobs, "geocode_addresses", cfg.Geocoding.BatchInterval, func(ctx context.Context) error {
+		_, err := locationService.GeocodeMissing(ctx)
+		return err
+	})
+	registerJob(scheduler, cfg.Jobs, "sync_holidays", cfg.Holidays.SyncInterval, func(ctx context.Context) error {
+		_, err := holidayService.SyncAll(ctx)
+		return err
+	})
+	registerJob(scheduler, cfg.Jobs, "prune_job_runs", 24*time.Hour, func(ctx context.Context) error {
+		_, err := systemService.PruneJobRuns()
+		return err
+	})
+	registerJob(scheduler, cfg.Jobs, "prune_partner_nonces", time.Hour, func(ctx context.Context) error {
+		_, err := partnerService.PruneNonces()
+		return err
+	})
+	registerJob(scheduler, cfg.Jobs, "prune_token_revocations", time.Hour, func(ctx context.Context) error {
+		_, err := tokenRevocationService.PruneExpired()
+		return err
+	})
+	registerJob(scheduler, cfg.Jobs, "bi_export", cfg.BIExport.Interval, func(ctx context.Context) error {
+		_, err := biExportService.Export(ctx)
+		return err
+	})
+	registerJob(scheduler, cfg.Jobs, "slot_watch_broadcast", cfg.SlotWatches.BroadcastInterval, func(ctx context.Context) error {
+		_, err := slotWatchService.Broadcast(ctx)
+		return err
+	})
+	registerJob(scheduler, cfg.Jobs, "prune_api_usage", 24*time.Hour, func(ctx context.Context) error {
+		_, err := usageService.Prune()
+		return err
+	})
+	registerJob(scheduler, cfg.Jobs, "prune_booking_denials", 24*time.Hour, func(ctx context.Context) error {
+		_, err := appointmentService.PruneDenials()
+		return err
+	})
+	registerJob(scheduler, cfg.Jobs, "prune_appointment_drafts", time.Hour, func(ctx context.Context) error {
+		_, err := draftService.PruneExpired()
+		return err
+	})
+	registerJob(scheduler, cfg.Jobs, "refresh_calendar_busy_times", cfg.CalendarOverlay.RefreshInterval, func(ctx context.Context) error {
+		_, err := calendarOverlayService.RefreshAll(ctx)
+		return err
+	})
+	// Each notification queue is sent by its own worker job, so a backlog on one never holds up the others
+	for _, queueName := range service.NotificationQueues {
+		queueName := queueName
+		registerJob(scheduler, cfg.Jobs, "send_"+queueName, cfg.Notification.QueueInterval, func(ctx context.Context) error {
+			return notificationService.ProcessQueue(queueName, cfg.Notification.QueueBatchSize)
+		})
+	}
+	registerJob(scheduler, cfg.Jobs, "check_consistency", cfg.Consistency.CheckInterval, func(ctx context.Context) error {
+		return consistencyService.CheckScheduled(ctx)
+	})
+
+	// Schedules changed through the admin API, applied now and reloaded on every replica
+	if err := systemService.ApplyJobSchedules(); err != nil {
+		log.Printf("Failed to apply job schedules: %v", err)
+	}
+	scheduler.RegisterLocal("reload_job_schedules", time.Minute, func(ctx context.Context) error {
+		return systemService.ApplyJobSchedules()
+	})
+	// Settings changed through the admin API on another replica
+	if cfg.Settings.ReloadInterval > 0 {
+		scheduler.RegisterLocal("reload_settings", cfg.Settings.ReloadInterval, func(ctx context.Context) error {
+			return settingsService.Reload()
+		})
+	}
+	// Each replica counts the requests it serves, so each writes its own counts
+	if cfg.Usage.Enabled {
+		scheduler.RegisterLocal("flush_api_usage", cfg.Usage.FlushInterval, func(ctx context.Context) error {
+			_, err := usageService.Flush()
+			return err
+		})
+	}
+
 	// Create JWT manager
 	jwtManager := auth.NewJWTManager(
 		cfg.Auth.JWTSecret,
 		time.Duration(cfg.Auth.ExpireTime)*time.Hour,
+		cfg.Auth.RefreshTokenDuration,
 	)
 
 	// Create handlers
-	authHandler := handlers.NewAuthHandler(userService, jwtManager)
-	appointmentHandler := handlers.NewAppointmentHandler(appointmentService)
-
-	// Create authentication middleware
-	authMiddleware := auth.AuthMiddleware(userService)
-
-	// Rate limiters with different configurations for public and protected routes
-	publicLimiter := middleware.RateLimiter(reqLimit, duration)
-	protectedLimiter := middleware.RateLimiter(reqLimit*5, duration) // 5x more for authenticated users
-
-	// API group
-	api := router.Group("/api")
-	{
-		// Public authentication routes
-		authRoutes := api.Group("/auth")
-		authRoutes.Use(publicLimiter)
-		{
-			authRoutes.POST("/register", authHandler.Register)
-			authRoutes.POST("/login", authHandler.Login)
-			authRoutes.POST("/refresh", authHandler.RefreshToken)
-			authRoutes.POST("/password-reset", authHandler.RequestPasswordReset)
-		}
+	authHandler := handlers.NewAuthHandler(userService, jwtManager, cfg.Phone.DefaultRegion, cfg.Auth.StripPlusAddress)
+	appointmentHandler := handlers.NewAppointmentHandler(appointmentService, availabilityService, locationService, visibilityService)
+	operationConfigHandler := handlers.NewOperationConfigHandler(operationConfigService)
+	systemHandler := handlers.NewSystemHandler(providerBreakers, systemService)
+	notificationPauseHandler := handlers.NewNotificationPauseHandler(notificationPauseService)
+	supplierDocumentHandler := handlers.NewSupplierDocumentHandler(supplierDocumentService)
+	gateHandler := handlers.NewGateHandler(gateService, appointmentService, visibilityService)
+	locationHandler := handlers.NewLocationHandler(locationService)
+	catalogHandler := handlers.NewCatalogHandler(catalogService)
+	availabilityHandler := handlers.NewAvailabilityHandler(availabilityService)
+	invitationHandler := handlers.NewInvitationHandler(invitationService)
+	watcherHandler := handlers.NewWatcherHandler(watcherService, appointmentService, notificationService)
+	visibilityHandler := handlers.NewVisibilityHandler(visibilityService)
+	billingHandler := handlers.NewBillingHandler(billingService)
+	feeHandler := handlers.NewFeeHandler(feeService)
+	holidayHandler := handlers.NewHolidayHandler(holidayService)
+	waitlistHandler := handlers.NewWaitlistHandler(waitlistService)
+	slotWatchHandler := handlers.NewSlotWatchHandler(slotWatchService, appointmentService, availabilityService)
+	skillHandler := handlers.NewSkillHandler(skillService)
+	handoverHandler := handlers.NewHandoverHandler(handoverService)
+	capacityCalendarHandler := handlers.NewCapacityCalendarHandler(capacityCalendarService)
+	syncHandler := handlers.NewSyncHandler(syncService, visibilityService)
+	partnerHandler := handlers.NewPartnerHandler(partnerService, appointmentService)
 
-		// Protected routes requiring authentication
-		protected := api.Group("/")
-		protected.Use(authMiddleware, protectedLimiter)
-		{
-			// User routes
-			userRoutes := protected.Group("/users")
-			{
-				userRoutes.GET("/profile", authHandler.Profile)
-				userRoutes.POST("/change-password", authHandler.ChangePassword)
-			}
-
-			// Appointment routes
-			appointmentRoutes := protected.Group("/appointments")
-			{
-				// Basic CRUD operations
-				appointmentRoutes.POST("", appointmentHandler.Create)
-				appointmentRoutes.GET("", appointmentHandler.List)
-				appointmentRoutes.GET("/:id", appointmentHandler.Get)
-				appointmentRoutes.PUT("/:id", appointmentHandler.Update)
-				appointmentRoutes.DELETE("/:id", appointmentHandler.Delete)
-
-				// Status management
-				appointmentRoutes.POST("/:id/status", appointmentHandler.UpdateStatus)
-
-				// Availability checking
-				appointmentRoutes.POST("/check-availability", appointmentHandler.CheckAvailability)
-
-				// Specialized queries
-				appointmentRoutes.GET("/upcoming", appointmentHandler.GetUpcoming)
-				appointmentRoutes.GET("/by-date-range", appointmentHandler.GetByDateRange)
-				appointmentRoutes.GET("/by-supplier/:supplier_id", appointmentHandler.GetBySupplier)
-				appointmentRoutes.GET("/by-employee/:employee_id", appointmentHandler.GetByEmployee)
-				appointmentRoutes.GET("/by-operation/:operation_id", appointmentHandler.GetByOperation)
-			}
-
-			// Admin routes (requires admin role)
-			adminRoutes := protected.Group("/admin")
-			adminRoutes.Use(auth.RoleMiddleware("admin"))
-			{
-				adminRoutes.GET("/statistics/appointments", appointmentHandler.GetStatistics)
-			}
-		}
+	schemaHandler := handlers.NewSchemaHandler(eventCatalog)
+	biExportHandler := handlers.NewBIExportHandler(biExportService)
+	eventReplayHandler := handlers.NewEventReplayHandler(eventReplayService)
+	usageHandler := handlers.NewUsageHandler(usageService)
+	settingsHandler := handlers.NewSettingsHandler(settingsService)
+	labelHandler := handlers.NewLabelHandler(labelService, appointmentService)
+	calendarFeedHandler := handlers.NewCalendarFeedHandler(calendarFeedService)
+	broadcastHandler := handlers.NewBroadcastHandler(broadcastService)
+	paymentHandler := handlers.NewPaymentHandler(appointmentService)
+	consistencyHandler := handlers.NewConsistencyHandler(consistencyService)
+	localeHandler := handlers.NewLocaleHandler(localeService)
+	quickAddHandler := handlers.NewQuickAddHandler(quickAddService)
+	draftHandler := handlers.NewDraftHandler(draftService)
+	calendarOverlayHandler := handlers.NewCalendarOverlayHandler(calendarOverlayService)
+	regionHandler := handlers.NewRegionHandler(regionService)
+	templateExperimentHandler := handlers.NewTemplateExperimentHandler(templateExperimentService)
+	sessionHandler := handlers.NewSessionHandler(tokenRevocationService)
+
+	// Create authentication middleware. The route permissions are declared in code, so failing to
+	// load them is a build defect.
+	authMiddleware := auth.AuthMiddleware(userService, tokenRevocationService)
+	permissions, err := RoutePermissions()
+	if err != nil {
+		log.Fatalf("Failed to load route permissions: %v", err)
+	}
+
+	// Rate limiters with different configurations for public and protected routes, following the
+	// requests setting as admins change it
+	requests := settingsService.Int(service.SettingRateLimitRequests)
+	publicLimit := middleware.NewRateLimit(requests, cfg.RateLimit.Period)
+	protectedLimit := middleware.NewRateLimit(requests*5, cfg.RateLimit.Period) // 5x more for authenticated users
+	settingsService.OnChange(service.SettingRateLimitRequests, func(value string) {
+		requests := settingsService.Int(service.SettingRateLimitRequests)
+		publicLimit.SetRequests(requests)
+		protectedLimit.SetRequests(requests * 5)
+	})
+	publicLimiter := publicLimit.Handler()
+	protectedLimiter := protectedLimit.Handler()
+
+	// Counts requests per consumer and route; registered before the API routes so it wraps them all
+	if cfg.Usage.Enabled {
+		router.Use(middleware.Usage(usageService))
+	}
+
+	// Versioned API groups. /api/v1 is the current API and /api/v2 is where breaking changes
+	// ship. Unversioned /api paths are kept for existing integrations and serve v1 unless the
+	// client asks for another version with the API-Version header.
+	h := &apiHandlers{
+		auth:              authHandler,
+		appointment:       appointmentHandler,
+		operationConfig:   operationConfigHandler,
+		system:            systemHandler,
+		notificationPause: notificationPauseHandler,
+		supplierDocument:  supplierDocumentHandler,
+		gate:              gateHandler,
+		location:          locationHandler,
+		catalog:           catalogHandler,
+		availability:      availabilityHandler,
+		invitation:        invitationHandler,
+		watcher:           watcherHandler,
+		visibility:        visibilityHandler,
+		billing:           billingHandler,
+		fee:               feeHandler,
+		holiday:           holidayHandler,
+		waitlist:          waitlistHandler,
+		slotWatch:         slotWatchHandler,
+		skill:             skillHandler,
+		handover:          handoverHandler,
+		capacityCalendar:  capacityCalendarHandler,
+		sync:              syncHandler,
+		partner:           partnerHandler,
+		schema:            schemaHandler,
+		biExport:          biExportHandler,
+		eventReplay:       eventReplayHandler,
+		usage:             usageHandler,
+		settings:          settingsHandler,
+		label:             labelHandler,
+		calendarFeed:      calendarFeedHandler,
+		broadcast:         broadcastHandler,
+		payment:           paymentHandler,
+		consistency:       consistencyHandler,
+		locale:            localeHandler,
+		quickAdd:          quickAddHandler,
+		draft:             draftHandler,
+		calendarOverlay:   calendarOverlayHandler,
+		region:            regionHandler,
+		experiment:        templateExperimentHandler,
+		session:           sessionHandler,
+	}
+	mw := &apiMiddleware{
+		auth:             authMiddleware,
+		userLocale:       middleware.UserLocale(localeService),
+		permissions:      permissions,
+		partner:          auth.PartnerSignatureMiddleware(partnerService),
+		publicLimiter:    publicLimiter,
+		protectedLimiter: protectedLimiter,
+		catalogCache:     middleware.CacheControl(cfg.HTTP.CatalogCacheMaxAge),
+		templateCache:    middleware.CacheControl(cfg.HTTP.TemplateCacheMaxAge),
+		refreshRevoked:   auth.RefreshRevocationMiddleware(tokenRevocationService),
+		passwordChanged:  auth.RevokeUserOnSuccess(tokenRevocationService, models.RevocationPasswordChanged),
+	}
+	registerAPIRoutes(router.Group("/api", middleware.NegotiateAPIVersion(middleware.APIVersion1)), h, mw)
+	registerAPIRoutes(router.Group("/api/v1", middleware.PinAPIVersion(middleware.APIVersion1)), h, mw)
+	registerAPIRoutes(router.Group("/api/v2", middleware.PinAPIVersion(middleware.APIVersion2)), h, mw)
+
+	// Every API route must declare who may call it; an undeclared one would be refused at runtime
+	if problems := permissions.Verify(router.Routes(), APIBasePaths); len(problems) > 0 {
+		log.Fatalf("Route permissions are out of date:\n%s", strings.Join(problems, "\n"))
 	}
 
 	// Health check endpoint for container orchestration
 	router.GET("/health", func(c *gin.Context) {
+		build := buildinfo.Get()
 		c.JSON(http.StatusOK, gin.H{
-			"status": "UP",
-			"time":   time.Now().UTC().Format(time.RFC3339),
-			"mode":   cfg.Server.Mode,
-			"version": "1.0.0",
+			"status":     "UP",
+			"time":       time.Now().UTC().Format(time.RFC3339),
+			"mode":       cfg.Server.Mode,
+			"version":    build.Version,
+			"commit":     build.Commit,
+			"build_date": build.BuildDate,
 		})
 	})
 
+	// Prometheus metrics endpoint
+	router.GET("/metrics", metrics.Handler())
+
 	// Readiness probe for Kubernetes
 	router.GET("/ready", func(c *gin.Context) {
 		// Check if database is accessible
@@ -201,3 +657,26 @@ func SetupRouter(repos *repository.Repositories, cfg *config.Config) *gin.Engine
 	return router
 }
 
+// registerJob registers a background job on the schedule configured for it or, without one, every
+// interval, and disables it if it is listed in JOBS_DISABLED
+func registerJob(scheduler *jobs.Scheduler, cfg config.JobsConfig, name string, interval time.Duration, run jobs.RunFunc) {
+	var schedule jobs.Schedule
+	if interval > 0 {
+		schedule = jobs.Every(interval)
+	}
+	if spec := cfg.Schedule(name); spec != "" {
+		parsed, err := jobs.ParseSchedule(spec)
+		if err != nil {
+			log.Printf("Ignoring the configured schedule of job %s: %v", name, err)
+		} else {
+			schedule = parsed
+		}
+	}
+
+	scheduler.RegisterSchedule(name, schedule, run)
+	if cfg.IsDisabled(name) {
+		if err := scheduler.Configure(name, nil, false); err != nil {
+			log.Printf("Failed to disable job %s: %v", name, err)
+		}
+	}
+}
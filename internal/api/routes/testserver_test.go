@@ -0,0 +1,68 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/api/routes"
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+	"github.com/bernardofernandezz/scheduling-api/pkg/jobs"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+)
+
+// testServer is the full API router served on a local port against the test database
+type testServer struct {
+	*httptest.Server
+	router *gin.Engine
+	repos  *repository.Repositories
+	client *http.Client
+}
+
+// newTestServer boots the full router against the database in DB_NAME. The tests register users
+// and write data, so they are skipped unless DB_NAME ends in _test.
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	if name := os.Getenv("DB_NAME"); !strings.HasSuffix(name, "_test") {
+		t.Skipf("DB_NAME %q does not end in _test, skipping tests against the database", name)
+	}
+
+	// Every route is called once per caller and version, well over the default rate limit
+	t.Setenv("RATE_LIMIT_REQUESTS", "1000000")
+	cfg, err := config.Load()
+	require.NoError(t, err, "loading configuration")
+	// The delta sync would hold each call open waiting for changes
+	cfg.Sync.MaxWait = 0
+
+	db, err := repository.NewDBConnection(cfg.Database)
+	require.NoError(t, err, "connecting to database")
+	repos := repository.NewRepositories(db)
+	require.NoError(t, repos.AutoMigrate(), "migrating database")
+	_, err = service.SeedNotificationTemplates(repos.TemplateRepo)
+	require.NoError(t, err, "seeding notification templates")
+
+	// Background jobs are not started; the tests only check what requests do and enqueue
+	router := routes.SetupRouter(repos, cfg, jobs.NewScheduler(), nil)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	return &testServer{
+		Server: server,
+		router: router,
+		repos:  repos,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// testRun returns a suffix unique to the test run, for emails and codes that must not collide
+// with earlier runs against the same database
+func testRun() string {
+	return strconv.FormatInt(time.Now().UnixNano(), 10)
+}
@@ -1,22 +1,60 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 // Config holds all configuration for the application
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Auth     AuthConfig
+	Server            ServerConfig
+	Database          DatabaseConfig
+	Auth              AuthConfig
+	Breaker           CircuitBreakerConfig
+	Notification      *NotificationConfig
+	SupplierDocuments SupplierDocumentConfig
+	Geofence          GeofenceConfig
+	Geocoding         GeocodingConfig
+	Holidays          HolidayConfig
+	Delays            DelayConfig
+	AutoComplete      AutoCompleteConfig
+	PendingExpiry     PendingExpiryConfig
+	CapacityWarnings  CapacityWarningConfig
+	HTTP              HTTPConfig
+	Phone             PhoneConfig
+	Jobs              JobsConfig
+	Leader            LeaderConfig
+	OutboundHTTP      OutboundHTTPConfig
+	Sync              SyncConfig
+	Partners          PartnerConfig
+	BIExport          BIExportConfig
+	Exports           ExportConfig
+	SlotWatches       SlotWatchConfig
+	Usage             UsageConfig
+	RateLimit         RateLimitConfig
+	Settings          SettingsConfig
+	CalendarFeeds     CalendarFeedConfig
+	Broadcasts        BroadcastConfig
+	Payments          PaymentConfig
+	Consistency       ConsistencyConfig
+	Denials           DenialConfig
+	Drafts            DraftConfig
+	CalendarOverlay   CalendarOverlayConfig
+	Experiments       TemplateExperimentConfig
+	Webhooks          WebhookConfig
+	Security          *SecuritySettings // CORS and security headers, reloadable at runtime
 }
 
 // ServerConfig holds server-specific configuration
 type ServerConfig struct {
 	Address string
 	Mode    string
+	BaseURL string // default portal URL for calendar links; operations may set their own portal
 }
 
 // DatabaseConfig holds database-specific configuration
@@ -29,10 +67,325 @@ type DatabaseConfig struct {
 	SSLMode  string
 }
 
+// refreshTokenDuration is how long refresh tokens last
+const refreshTokenDuration = 7 * 24 * time.Hour
+
 // AuthConfig holds authentication-specific configuration
 type AuthConfig struct {
-	JWTSecret  string
-	ExpireTime int // in hours
+	JWTSecret            string
+	ExpireTime           int           // in hours
+	RefreshTokenDuration time.Duration // how long refresh tokens last
+	StripPlusAddress     bool          // store user+tag@domain addresses as user@domain
+	RevocationTTL        time.Duration // how long revoking every token of a user lasts: at least the lifetime of the longest-lived token
+}
+
+// longestTokenDuration returns the lifetime of the longest-lived token the API issues
+func (c AuthConfig) longestTokenDuration() time.Duration {
+	access := time.Duration(c.ExpireTime) * time.Hour
+	if access > c.RefreshTokenDuration {
+		return access
+	}
+	return c.RefreshTokenDuration
+}
+
+// validate checks revoking a user's tokens outlasts every token they were issued, which would
+// otherwise be accepted again once the revocation is pruned
+func (c AuthConfig) validate() error {
+	if longest := c.longestTokenDuration(); c.RevocationTTL < longest {
+		return fmt.Errorf("JWT_REVOCATION_TTL %s is shorter than the %s lifetime of the longest-lived token", c.RevocationTTL, longest)
+	}
+	return nil
+}
+
+// CircuitBreakerConfig holds circuit breaker settings for external providers
+type CircuitBreakerConfig struct {
+	FailureThreshold int // consecutive failures before a breaker opens
+	OpenSeconds      int // seconds a breaker stays open before a trial call
+}
+
+// SupplierDocumentConfig holds supplier compliance document settings
+type SupplierDocumentConfig struct {
+	ExpiryNotice     time.Duration // how long before expiry suppliers are warned
+	ReminderInterval time.Duration // minimum time between warnings for the same document
+	CheckInterval    time.Duration // how often expiring documents are checked, 0 disables the job
+}
+
+// GeofenceConfig holds driver location tracking settings
+type GeofenceConfig struct {
+	ArrivalRadiusMeters  float64 // distance from the operation at which a delivery is checked in
+	ApproachRadiusMeters float64 // distance at which the dock team is told the delivery is arriving soon
+	MaxAccuracyMeters    float64 // pings less accurate than this are stored but ignored, 0 accepts all
+}
+
+// GeocodingConfig holds address geocoding and travel time estimation settings
+type GeocodingConfig struct {
+	Provider        string        // "nominatim" or "none"
+	BaseURL         string        // geocoding server URL
+	UserAgent       string        // identifies the application to the geocoding server
+	Timeout         time.Duration // maximum time for one geocoding request
+	BatchInterval   time.Duration // how often missing coordinates are geocoded, 0 disables the job
+	AverageSpeedKmh float64       // average truck speed used to estimate travel times
+	RoadFactor      float64       // road distance relative to the straight line distance
+}
+
+// HolidayConfig holds public holiday import settings. Which operations close on holidays is set
+// per operation.
+type HolidayConfig struct {
+	Provider     string        // "embedded", "brasilapi" or "none"
+	BaseURL      string        // BrasilAPI server URL
+	Timeout      time.Duration // maximum time for one holiday request
+	SyncInterval time.Duration // how often the current and next year's holidays are imported, 0 disables the job
+}
+
+// DelayConfig holds supplier delay declaration settings
+type DelayConfig struct {
+	RescheduleTolerance time.Duration // largest delay an appointment is moved automatically for, 0 disables auto-rescheduling
+}
+
+// AutoCompleteConfig holds settings for closing confirmed appointments nobody completed. Whether
+// an operation's overdue appointments are completed or only flagged, and after how long, is set
+// per operation.
+type AutoCompleteConfig struct {
+	CheckInterval time.Duration // how often overdue appointments are looked for, 0 disables the job
+	UndoWindow    time.Duration // how long staff can undo an automatic completion
+	BatchSize     int           // maximum appointments handled per run
+}
+
+// PendingExpiryConfig holds settings for cancelling pending appointments nobody confirmed, so they
+// stop holding capacity. How long before its start an appointment must be confirmed is set per
+// operation.
+type PendingExpiryConfig struct {
+	CheckInterval time.Duration // how often expiring appointments are looked for, 0 disables the job
+	WarningLead   time.Duration // how long before the expiry the participants are warned; appointments booked later get at least this long
+	BatchSize     int           // maximum appointments warned and cancelled per run
+}
+
+// CapacityWarningConfig holds the warnings about bookings that leave a capacity limit almost full
+type CapacityWarningConfig struct {
+	Percent int // bookings taking a limit above this percent of its capacity are flagged, 0 disables
+}
+
+// HTTPConfig holds request body, response compression and client caching settings
+type HTTPConfig struct {
+	MaxBodyBytes        int  // larger request bodies are rejected with 413, 0 disables the limit
+	StrictJSON          bool // reject JSON bodies with fields the endpoint does not know
+	CompressionEnabled  bool
+	CompressionLevel    int           // 1 (fastest) to 9 (smallest), 0 uses the default level
+	CompressionMinBytes int           // responses smaller than this are sent uncompressed
+	CatalogCacheMaxAge  time.Duration // client cache lifetime of the operations list and product catalog, 0 disables
+	TemplateCacheMaxAge time.Duration // client cache lifetime of notification templates, 0 disables
+}
+
+// SyncConfig holds the delta sync the mobile apps keep their offline copy up to date with
+type SyncConfig struct {
+	PageSize     int           // changed records of each kind returned per request
+	MaxWait      time.Duration // longest a request with nothing new is held open waiting for changes
+	PollInterval time.Duration // how often a held request looks for changes
+}
+
+// PartnerConfig holds the verification of requests partner systems sign with their API secret
+type PartnerConfig struct {
+	SignatureTolerance time.Duration // how far a request's timestamp may be from the server time
+	RotationGrace      time.Duration // how long the previous secret is still accepted after a rotation
+}
+
+// BIExportConfig holds the anonymized appointment export the data warehouse loads
+type BIExportConfig struct {
+	Store        string // "s3", "gcs", "filesystem" or "none"
+	Endpoint     string // S3-compatible API URL; GCS uses https://storage.googleapis.com
+	Region       string // bucket region, "auto" for GCS
+	Bucket       string
+	AccessKey    string // access key ID, or GCS HMAC key
+	SecretKey    string
+	Prefix       string        // key prefix every exported object is written under
+	Directory    string        // where the filesystem store writes
+	PseudonymKey string        // keys the pseudonyms replacing supplier and employee IDs
+	Interval     time.Duration // how often new facts are exported, 0 disables the job
+	BatchSize    int           // appointments read from the database at a time
+	Timeout      time.Duration // maximum time for one upload
+}
+
+// ExportConfig holds the appointment export and event replay endpoints, which stream their rows
+type ExportConfig struct {
+	BatchSize int           // rows read from the database and written out at a time
+	MaxPeriod time.Duration // longest period of events a replay may cover
+}
+
+// SlotWatchConfig holds the broadcast of freed capacity to the suppliers watching an operation.
+// Watchers are notified in waves so a popular opening isn't rushed by every one of them at once.
+type SlotWatchConfig struct {
+	BroadcastInterval time.Duration // how often due waves are sent and past openings closed, 0 disables the job
+	WaveSize          int           // watchers notified per wave
+	WaveInterval      time.Duration // time between waves, and the head start of a waitlisted supplier offered the slot
+	Cooldown          time.Duration // shortest time between two notifications of the same watch
+}
+
+// UsageConfig holds the API usage analytics admins troubleshoot integrations with. Requests are
+// counted in memory per consumer and route, and each replica adds its counts to hourly rows.
+type UsageConfig struct {
+	Enabled       bool
+	FlushInterval time.Duration // how often a replica writes its counts; up to this much usage is lost when it stops
+	Retention     time.Duration // how long hourly usage is kept, 0 keeps it forever
+}
+
+// RateLimitConfig holds the request rate each client IP is allowed. Requests can be changed at
+// runtime through the admin settings.
+type RateLimitConfig struct {
+	Requests int           // requests per period on public routes; signed-in routes allow 5 times as many
+	Period   time.Duration // period the requests are counted over
+}
+
+// SettingsConfig holds the runtime settings admins change through the API instead of the environment
+type SettingsConfig struct {
+	ReloadInterval time.Duration // how often a replica picks up settings changed on another one
+}
+
+// CalendarFeedConfig holds the filtered iCalendar feeds users subscribe to from calendar apps
+type CalendarFeedConfig struct {
+	URL        string        // public URL feeds are served under, each feed's token appended to it
+	Past       time.Duration // how far back feeds list appointments
+	Ahead      time.Duration // how far ahead feeds list appointments
+	MaxPerUser int           // feeds a user may keep
+	MaxEvents  int           // most appointments a feed lists
+}
+
+// BroadcastConfig holds the ad-hoc messages admins send to an audience through the notification queue
+type BroadcastConfig struct {
+	MaxRecipients    int           // largest audience one broadcast may reach
+	MaxScheduleAhead time.Duration // how far ahead a broadcast may be scheduled
+}
+
+// PaymentConfig holds the payment provider collecting the deposits some operations charge for a
+// booking, and how long a reserved appointment waits for its deposit
+type PaymentConfig struct {
+	Provider         string        // "stripe" or "none"
+	BaseURL          string        // Stripe API URL
+	SecretKey        string        // API key payments are created with
+	WebhookSecret    string        // signs the webhook requests the provider sends
+	Methods          []string      // payment methods offered, e.g. card and pix
+	Timeout          time.Duration // maximum time for one provider request
+	HoldDuration     time.Duration // how long a reserved appointment waits for its deposit
+	ConfirmOnPayment bool          // paid appointments are confirmed rather than left pending for staff
+	CheckInterval    time.Duration // how often unpaid reservations are cancelled, 0 disables the job
+}
+
+// ConsistencyConfig holds the scheduled check for records left inconsistent with each other
+type ConsistencyConfig struct {
+	CheckInterval time.Duration // how often the check runs, 0 disables the job
+	AutoRepair    bool          // the scheduled check repairs what it safely can instead of only reporting
+	MaxFindings   int           // most findings of each kind a check reports
+}
+
+// DenialConfig holds the log of bookings refused for lack of room, which managers read unmet
+// demand from
+type DenialConfig struct {
+	Retention time.Duration // how long refused bookings are kept, 0 keeps them forever
+}
+
+// DraftConfig holds the booking forms users save as they fill them in
+type DraftConfig struct {
+	TTL        time.Duration // how long a draft is kept after it was last saved
+	MaxPerUser int           // drafts a user may keep
+	MaxBytes   int           // largest payload a draft may hold
+}
+
+// CalendarOverlayConfig holds the work calendars employees connect so their meetings count as
+// unavailable. A provider without a client ID can't be connected.
+type CalendarOverlayConfig struct {
+	RefreshInterval       time.Duration // how often busy times are read again; 0 disables the refresh job
+	Horizon               time.Duration // how far ahead busy times are read
+	Timeout               time.Duration
+	GoogleClientID        string
+	GoogleClientSecret    string
+	GoogleBaseURL         string
+	MicrosoftClientID     string
+	MicrosoftClientSecret string
+	MicrosoftTenant       string // directory tenant of the application, "common" for any organization
+	MicrosoftBaseURL      string
+}
+
+// TemplateExperimentConfig holds the A/B tests of notification templates
+type TemplateExperimentConfig struct {
+	TrackingURL string // public URL of the image tracking email opens, each notification's token appended to it
+}
+
+// WebhookConfig holds the delivery of appointment events to an external endpoint
+type WebhookConfig struct {
+	URL     string        // endpoint the CloudEvents are posted to, empty disables the webhook
+	Secret  string        // signs each delivery in the X-Webhook-Signature header
+	Source  string        // CloudEvents source of the events, identifying this deployment
+	Timeout time.Duration // bound of one delivery
+}
+
+// PhoneConfig holds phone number normalization settings
+type PhoneConfig struct {
+	DefaultRegion string // country numbers written without a calling code are read in, e.g. "BR"
+}
+
+// JobsConfig holds background job scheduling settings. Jobs without a configured schedule keep
+// the interval of their feature's own setting, e.g. AUTO_COMPLETE_CHECK_INTERVAL.
+type JobsConfig struct {
+	Schedules    map[string]string // JOB_<NAME>_SCHEDULE by job name: cron expression, descriptor or interval
+	Disabled     []string          // jobs that don't run until enabled through the admin API
+	Instance     string            // name this replica records on the runs it claims
+	RunRetention time.Duration     // how long scheduled runs are kept, 0 keeps them forever
+}
+
+// Schedule returns the configured schedule of a job, or "" to keep its default
+func (c JobsConfig) Schedule(name string) string {
+	return c.Schedules[name]
+}
+
+// IsDisabled reports whether a job is disabled by configuration
+func (c JobsConfig) IsDisabled(name string) bool {
+	for _, disabled := range c.Disabled {
+		if disabled == name {
+			return true
+		}
+	}
+	return false
+}
+
+// LeaderConfig holds the election of the replica running background jobs
+type LeaderConfig struct {
+	Enabled       bool          // without election every replica may run scheduled jobs
+	LockName      string        // replicas using the same name compete for the same lock
+	CheckInterval time.Duration // how often followers try to take over and the leader checks its lock
+}
+
+// OutboundHTTPConfig holds the settings of the HTTP client shared by third-party API calls
+type OutboundHTTPConfig struct {
+	Timeout          time.Duration      // bound of each attempt
+	MaxRetries       int                // retries of throttled and, when idempotent, failed requests
+	RetryBaseDelay   time.Duration      // delay before the first retry, doubled on each further one
+	RetryMaxDelay    time.Duration      // longest delay between retries, including Retry-After
+	RetryJitter      float64            // fraction of the delay randomly added or removed (0-1)
+	RateLimits       map[string]float64 // requests per second by host
+	DefaultRateLimit float64            // requests per second to other hosts, 0 is unlimited
+}
+
+// NotificationConfig holds notification dispatcher configuration
+type NotificationConfig struct {
+	WorkerPoolSize        int
+	ReservedWorkers       int                    // extra workers only high priority queue items may use
+	QueueInterval         time.Duration          // how often each queue's worker job sends a batch
+	QueueBatchSize        int                    // queue items sent per batch
+	HighPriorityThreshold int                    // queue priority at or above which "_high" retry policies apply
+	RetryPolicies         map[string]RetryPolicy // keyed by "default", "<channel>" or "<channel>_high"
+	PauseCriticalEvents   []string               // events still delivered while notifications are paused
+	CollapseWindow        time.Duration          // pending duplicates newer than this are merged, 0 disables
+	TemplateTimeout       time.Duration          // maximum time to render one template
+	TemplateMaxBytes      int                    // maximum rendered size of one template
+	LinkBaseURL           string                 // base URL of the {{link}} template helper
+}
+
+// RetryPolicy holds the retry schedule for failed notifications
+type RetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	Multiplier float64
+	Jitter     float64 // fraction of the delay randomly added or removed (0-1)
+	MaxDelay   time.Duration
 }
 
 // Load loads configuration from environment variables
@@ -40,10 +393,33 @@ func Load() (*Config, error) {
 	// Load .env file if it exists
 	_ = godotenv.Load()
 
+	security := &SecuritySettings{}
+	if err := security.Reload(); err != nil {
+		return nil, fmt.Errorf("failed to load security settings: %w", err)
+	}
+
+	// Without a positive limit every request would be refused
+	rateLimitRequests := getEnvAsInt("RATE_LIMIT_REQUESTS", 60)
+	if rateLimitRequests <= 0 {
+		rateLimitRequests = 60
+	}
+
+	auth := AuthConfig{
+		JWTSecret:            getEnv("JWT_SECRET", "your-secret-key"),
+		ExpireTime:           getEnvAsInt("JWT_EXPIRE_HOURS", 24),
+		RefreshTokenDuration: refreshTokenDuration,
+		StripPlusAddress:     getEnvAsBool("AUTH_EMAIL_STRIP_PLUS_ADDRESS", false),
+	}
+	auth.RevocationTTL = getEnvAsDuration("JWT_REVOCATION_TTL", auth.longestTokenDuration())
+	if err := auth.validate(); err != nil {
+		return nil, fmt.Errorf("invalid auth settings: %w", err)
+	}
+
 	return &Config{
 		Server: ServerConfig{
 			Address: getEnv("SERVER_ADDRESS", ":8080"),
 			Mode:    getEnv("GIN_MODE", "debug"),
+			BaseURL: getEnv("SERVER_BASE_URL", "https://scheduling-api.example.com"),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -53,10 +429,204 @@ func Load() (*Config, error) {
 			Name:     getEnv("DB_NAME", "scheduling_db"),
 			SSLMode:  getEnv("DB_SSLMODE", "disable"),
 		},
-		Auth: AuthConfig{
-			JWTSecret:  getEnv("JWT_SECRET", "your-secret-key"),
-			ExpireTime: getEnvAsInt("JWT_EXPIRE_HOURS", 24),
+		Auth: auth,
+		Breaker: CircuitBreakerConfig{
+			FailureThreshold: getEnvAsInt("BREAKER_FAILURE_THRESHOLD", 5),
+			OpenSeconds:      getEnvAsInt("BREAKER_OPEN_SECONDS", 60),
+		},
+		Notification: &NotificationConfig{
+			WorkerPoolSize:        getEnvAsInt("NOTIFICATION_WORKER_POOL_SIZE", 5),
+			ReservedWorkers:       getEnvAsInt("NOTIFICATION_RESERVED_WORKERS", 1),
+			QueueInterval:         getEnvAsDuration("NOTIFICATION_QUEUE_INTERVAL", 10*time.Second),
+			QueueBatchSize:        getEnvAsInt("NOTIFICATION_QUEUE_BATCH_SIZE", 50),
+			HighPriorityThreshold: getEnvAsInt("NOTIFICATION_HIGH_PRIORITY_THRESHOLD", 3),
+			RetryPolicies:         loadRetryPolicies(),
+			PauseCriticalEvents:   getEnvAsList("NOTIFICATION_PAUSE_CRITICAL_EVENTS", []string{"appointment_cancelled"}),
+			CollapseWindow:        getEnvAsDuration("NOTIFICATION_COLLAPSE_WINDOW", 2*time.Minute),
+			TemplateTimeout:       getEnvAsDuration("NOTIFICATION_TEMPLATE_TIMEOUT", 2*time.Second),
+			TemplateMaxBytes:      getEnvAsInt("NOTIFICATION_TEMPLATE_MAX_BYTES", 64*1024),
+			LinkBaseURL:           getEnv("NOTIFICATION_LINK_BASE_URL", "http://localhost:3000"),
+		},
+		SupplierDocuments: SupplierDocumentConfig{
+			ExpiryNotice:     getEnvAsDuration("SUPPLIER_DOCUMENT_EXPIRY_NOTICE", 30*24*time.Hour),
+			ReminderInterval: getEnvAsDuration("SUPPLIER_DOCUMENT_REMINDER_INTERVAL", 7*24*time.Hour),
+			CheckInterval:    getEnvAsDuration("SUPPLIER_DOCUMENT_CHECK_INTERVAL", 12*time.Hour),
+		},
+		Geofence: GeofenceConfig{
+			ArrivalRadiusMeters:  getEnvAsFloat("GEOFENCE_ARRIVAL_RADIUS_METERS", 150),
+			ApproachRadiusMeters: getEnvAsFloat("GEOFENCE_APPROACH_RADIUS_METERS", 5000),
+			MaxAccuracyMeters:    getEnvAsFloat("GEOFENCE_MAX_ACCURACY_METERS", 100),
+		},
+		Geocoding: GeocodingConfig{
+			Provider:        getEnv("GEOCODING_PROVIDER", "nominatim"),
+			BaseURL:         getEnv("GEOCODING_BASE_URL", "https://nominatim.openstreetmap.org"),
+			UserAgent:       getEnv("GEOCODING_USER_AGENT", "scheduling-api"),
+			Timeout:         getEnvAsDuration("GEOCODING_TIMEOUT", 5*time.Second),
+			BatchInterval:   getEnvAsDuration("GEOCODING_BATCH_INTERVAL", 6*time.Hour),
+			AverageSpeedKmh: getEnvAsFloat("GEOCODING_AVERAGE_SPEED_KMH", 50),
+			RoadFactor:      getEnvAsFloat("GEOCODING_ROAD_FACTOR", 1.3),
+		},
+		Holidays: HolidayConfig{
+			Provider:     getEnv("HOLIDAY_PROVIDER", "embedded"),
+			BaseURL:      getEnv("HOLIDAY_BASE_URL", "https://brasilapi.com.br"),
+			Timeout:      getEnvAsDuration("HOLIDAY_TIMEOUT", 10*time.Second),
+			SyncInterval: getEnvAsDuration("HOLIDAY_SYNC_INTERVAL", 24*time.Hour),
+		},
+		Delays: DelayConfig{
+			RescheduleTolerance: getEnvAsDuration("DELAY_RESCHEDULE_TOLERANCE", 2*time.Hour),
+		},
+		AutoComplete: AutoCompleteConfig{
+			CheckInterval: getEnvAsDuration("AUTO_COMPLETE_CHECK_INTERVAL", 15*time.Minute),
+			UndoWindow:    getEnvAsDuration("AUTO_COMPLETE_UNDO_WINDOW", 72*time.Hour),
+			BatchSize:     getEnvAsInt("AUTO_COMPLETE_BATCH_SIZE", 200),
+		},
+		PendingExpiry: PendingExpiryConfig{
+			CheckInterval: getEnvAsDuration("PENDING_EXPIRY_CHECK_INTERVAL", 15*time.Minute),
+			WarningLead:   getEnvAsDuration("PENDING_EXPIRY_WARNING_LEAD", 12*time.Hour),
+			BatchSize:     getEnvAsInt("PENDING_EXPIRY_BATCH_SIZE", 200),
+		},
+		CapacityWarnings: CapacityWarningConfig{
+			Percent: getEnvAsInt("CAPACITY_WARNING_PERCENT", 90),
+		},
+		HTTP: HTTPConfig{
+			MaxBodyBytes:        getEnvAsInt("HTTP_MAX_BODY_BYTES", 1<<20),
+			StrictJSON:          getEnvAsBool("HTTP_STRICT_JSON", true),
+			CompressionEnabled:  getEnvAsBool("HTTP_COMPRESSION_ENABLED", true),
+			CompressionLevel:    getEnvAsInt("HTTP_COMPRESSION_LEVEL", 0),
+			CompressionMinBytes: getEnvAsInt("HTTP_COMPRESSION_MIN_BYTES", 1024),
+			CatalogCacheMaxAge:  getEnvAsDuration("HTTP_CATALOG_CACHE_MAX_AGE", 5*time.Minute),
+			TemplateCacheMaxAge: getEnvAsDuration("HTTP_TEMPLATE_CACHE_MAX_AGE", time.Minute),
+		},
+		Phone: PhoneConfig{
+			DefaultRegion: strings.ToUpper(getEnv("PHONE_DEFAULT_REGION", "BR")),
+		},
+		Jobs: JobsConfig{
+			Schedules:    loadJobSchedules(),
+			Disabled:     getEnvAsList("JOBS_DISABLED", nil),
+			Instance:     getEnv("JOBS_INSTANCE_NAME", defaultInstanceName()),
+			RunRetention: getEnvAsDuration("JOBS_RUN_RETENTION", 30*24*time.Hour),
+		},
+		Leader: LeaderConfig{
+			Enabled:       getEnvAsBool("LEADER_ELECTION_ENABLED", true),
+			LockName:      getEnv("LEADER_LOCK_NAME", "scheduling-api"),
+			CheckInterval: getEnvAsDuration("LEADER_CHECK_INTERVAL", 10*time.Second),
+		},
+		OutboundHTTP: OutboundHTTPConfig{
+			Timeout:        getEnvAsDuration("OUTBOUND_HTTP_TIMEOUT", 10*time.Second),
+			MaxRetries:     getEnvAsInt("OUTBOUND_HTTP_MAX_RETRIES", 3),
+			RetryBaseDelay: getEnvAsDuration("OUTBOUND_HTTP_RETRY_BASE_DELAY", 500*time.Millisecond),
+			RetryMaxDelay:  getEnvAsDuration("OUTBOUND_HTTP_RETRY_MAX_DELAY", 30*time.Second),
+			RetryJitter:    getEnvAsFloat("OUTBOUND_HTTP_RETRY_JITTER", 0.2),
+			// Nominatim's usage policy allows one request per second
+			RateLimits: getEnvAsRateLimits("OUTBOUND_HTTP_RATE_LIMITS", map[string]float64{
+				"nominatim.openstreetmap.org": 1,
+				"www.googleapis.com":          10,
+			}),
+			DefaultRateLimit: getEnvAsFloat("OUTBOUND_HTTP_DEFAULT_RATE_LIMIT", 0),
+		},
+		Sync: SyncConfig{
+			PageSize:     getEnvAsInt("SYNC_PAGE_SIZE", 200),
+			MaxWait:      getEnvAsDuration("SYNC_MAX_WAIT", 30*time.Second),
+			PollInterval: getEnvAsDuration("SYNC_POLL_INTERVAL", 2*time.Second),
+		},
+		Partners: PartnerConfig{
+			SignatureTolerance: getEnvAsDuration("PARTNER_SIGNATURE_TOLERANCE", 5*time.Minute),
+			RotationGrace:      getEnvAsDuration("PARTNER_ROTATION_GRACE", 24*time.Hour),
+		},
+		BIExport: BIExportConfig{
+			Store:     getEnv("BI_EXPORT_STORE", "none"),
+			Endpoint:  getEnv("BI_EXPORT_ENDPOINT", "https://s3.amazonaws.com"),
+			Region:    getEnv("BI_EXPORT_REGION", "us-east-1"),
+			Bucket:    getEnv("BI_EXPORT_BUCKET", ""),
+			AccessKey: getEnv("BI_EXPORT_ACCESS_KEY", ""),
+			SecretKey: getEnv("BI_EXPORT_SECRET_KEY", ""),
+			Prefix:    strings.Trim(getEnv("BI_EXPORT_PREFIX", "scheduling"), "/"),
+			Directory: getEnv("BI_EXPORT_DIRECTORY", "./exports"),
+			// Pseudonyms stay stable across runs as long as the key does not change
+			PseudonymKey: getEnv("BI_EXPORT_PSEUDONYM_KEY", getEnv("JWT_SECRET", "")),
+			Interval:     getEnvAsDuration("BI_EXPORT_INTERVAL", 24*time.Hour),
+			BatchSize:    getEnvAsInt("BI_EXPORT_BATCH_SIZE", 5000),
+			Timeout:      getEnvAsDuration("BI_EXPORT_TIMEOUT", 2*time.Minute),
+		},
+		Exports: ExportConfig{
+			BatchSize: getEnvAsInt("EXPORT_BATCH_SIZE", 1000),
+			MaxPeriod: getEnvAsDuration("EXPORT_REPLAY_MAX_PERIOD", 31*24*time.Hour),
+		},
+		SlotWatches: SlotWatchConfig{
+			BroadcastInterval: getEnvAsDuration("SLOT_WATCH_BROADCAST_INTERVAL", time.Minute),
+			WaveSize:          getEnvAsInt("SLOT_WATCH_WAVE_SIZE", 10),
+			WaveInterval:      getEnvAsDuration("SLOT_WATCH_WAVE_INTERVAL", 5*time.Minute),
+			Cooldown:          getEnvAsDuration("SLOT_WATCH_COOLDOWN", 30*time.Minute),
+		},
+		Usage: UsageConfig{
+			Enabled:       getEnvAsBool("API_USAGE_ENABLED", true),
+			FlushInterval: getEnvAsDuration("API_USAGE_FLUSH_INTERVAL", time.Minute),
+			Retention:     getEnvAsDuration("API_USAGE_RETENTION", 90*24*time.Hour),
 		},
+		RateLimit: RateLimitConfig{
+			Requests: rateLimitRequests,
+			Period:   getEnvAsDuration("RATE_LIMIT_DURATION", time.Minute),
+		},
+		Settings: SettingsConfig{
+			ReloadInterval: getEnvAsDuration("SETTINGS_RELOAD_INTERVAL", 30*time.Second),
+		},
+		CalendarFeeds: CalendarFeedConfig{
+			URL:        strings.TrimRight(getEnv("CALENDAR_FEED_URL", "http://localhost:8080/api/v1/calendar/ics"), "/"),
+			Past:       getEnvAsDuration("CALENDAR_FEED_PAST", 30*24*time.Hour),
+			Ahead:      getEnvAsDuration("CALENDAR_FEED_AHEAD", 90*24*time.Hour),
+			MaxPerUser: getEnvAsInt("CALENDAR_FEED_MAX_PER_USER", 20),
+			MaxEvents:  getEnvAsInt("CALENDAR_FEED_MAX_EVENTS", 2000),
+		},
+		Broadcasts: BroadcastConfig{
+			MaxRecipients:    getEnvAsInt("BROADCAST_MAX_RECIPIENTS", 5000),
+			MaxScheduleAhead: getEnvAsDuration("BROADCAST_MAX_SCHEDULE_AHEAD", 30*24*time.Hour),
+		},
+		Payments: PaymentConfig{
+			Provider:         getEnv("PAYMENT_PROVIDER", "none"),
+			BaseURL:          getEnv("PAYMENT_BASE_URL", "https://api.stripe.com"),
+			SecretKey:        getEnv("PAYMENT_SECRET_KEY", ""),
+			WebhookSecret:    getEnv("PAYMENT_WEBHOOK_SECRET", ""),
+			Methods:          getEnvAsList("PAYMENT_METHODS", []string{"card", "pix"}),
+			Timeout:          getEnvAsDuration("PAYMENT_TIMEOUT", 10*time.Second),
+			HoldDuration:     getEnvAsDuration("DEPOSIT_HOLD_DURATION", 30*time.Minute),
+			ConfirmOnPayment: getEnvAsBool("DEPOSIT_CONFIRM_ON_PAYMENT", false),
+			CheckInterval:    getEnvAsDuration("DEPOSIT_CHECK_INTERVAL", time.Minute),
+		},
+		Consistency: ConsistencyConfig{
+			CheckInterval: getEnvAsDuration("CONSISTENCY_CHECK_INTERVAL", 24*time.Hour),
+			AutoRepair:    getEnvAsBool("CONSISTENCY_AUTO_REPAIR", false),
+			MaxFindings:   getEnvAsInt("CONSISTENCY_MAX_FINDINGS", 500),
+		},
+		Denials: DenialConfig{
+			Retention: getEnvAsDuration("BOOKING_DENIAL_RETENTION", 2*365*24*time.Hour),
+		},
+		Drafts: DraftConfig{
+			TTL:        getEnvAsDuration("APPOINTMENT_DRAFT_TTL", 7*24*time.Hour),
+			MaxPerUser: getEnvAsInt("APPOINTMENT_DRAFT_MAX_PER_USER", 10),
+			MaxBytes:   getEnvAsInt("APPOINTMENT_DRAFT_MAX_BYTES", 64*1024),
+		},
+		CalendarOverlay: CalendarOverlayConfig{
+			RefreshInterval:       getEnvAsDuration("CALENDAR_BUSY_REFRESH_INTERVAL", 15*time.Minute),
+			Horizon:               getEnvAsDuration("CALENDAR_BUSY_HORIZON", 31*24*time.Hour),
+			Timeout:               getEnvAsDuration("CALENDAR_BUSY_TIMEOUT", 10*time.Second),
+			GoogleClientID:        getEnv("GOOGLE_CLIENT_ID", ""),
+			GoogleClientSecret:    getEnv("GOOGLE_CLIENT_SECRET", ""),
+			GoogleBaseURL:         getEnv("GOOGLE_CALENDAR_BASE_URL", "https://www.googleapis.com"),
+			MicrosoftClientID:     getEnv("MICROSOFT_CLIENT_ID", ""),
+			MicrosoftClientSecret: getEnv("MICROSOFT_CLIENT_SECRET", ""),
+			MicrosoftTenant:       getEnv("MICROSOFT_TENANT", "common"),
+			MicrosoftBaseURL:      getEnv("MICROSOFT_GRAPH_BASE_URL", "https://graph.microsoft.com"),
+		},
+		Experiments: TemplateExperimentConfig{
+			TrackingURL: strings.TrimRight(getEnv("TEMPLATE_EXPERIMENT_TRACKING_URL", "http://localhost:8080/api/v1/notifications/opened"), "/"),
+		},
+		Webhooks: WebhookConfig{
+			URL:     getEnv("EVENT_WEBHOOK_URL", ""),
+			Secret:  getEnv("EVENT_WEBHOOK_SECRET", ""),
+			Source:  getEnv("EVENT_SOURCE", "/scheduling-api"),
+			Timeout: getEnvAsDuration("EVENT_WEBHOOK_TIMEOUT", 10*time.Second),
+		},
+		Security: security,
 	}, nil
 }
 
@@ -75,11 +645,142 @@ func getEnvAsInt(key string, defaultValue int) int {
 	if value == "" {
 		return defaultValue
 	}
-	intValue := defaultValue
-	_, err := os.Scanf(value, "%d", &intValue)
+	intValue, err := strconv.Atoi(value)
 	if err != nil {
 		return defaultValue
 	}
 	return intValue
 }
 
+// getEnvAsBool gets an environment variable as a boolean or returns a default value
+func getEnvAsBool(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	boolValue, err := strconv.ParseBool(value)
+	if err != nil {
+		return defaultValue
+	}
+	return boolValue
+}
+
+// getEnvAsFloat gets an environment variable as a float or returns a default value
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	floatValue, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return defaultValue
+	}
+	return floatValue
+}
+
+// getEnvAsDuration gets an environment variable as a time.Duration or returns a default value
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return defaultValue
+	}
+	return duration
+}
+
+// getEnvAsList gets a comma separated environment variable as a list or returns a default value
+func getEnvAsList(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// getEnvAsRateLimits gets a comma separated list of host=requests per second pairs or returns a
+// default value, e.g. "www.googleapis.com=10,api.twilio.com=5". Malformed pairs are ignored.
+func getEnvAsRateLimits(key string, defaultValue map[string]float64) map[string]float64 {
+	items := getEnvAsList(key, nil)
+	if items == nil {
+		return defaultValue
+	}
+	limits := make(map[string]float64, len(items))
+	for _, item := range items {
+		host, value, ok := strings.Cut(item, "=")
+		if !ok {
+			continue
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			continue
+		}
+		limits[strings.ToLower(strings.TrimSpace(host))] = rate
+	}
+	return limits
+}
+
+// getEnvAsRetryPolicy reads a retry policy from <prefix>_MAX, _BASE, _MULTIPLIER, _JITTER and _MAX_DELAY,
+// falling back to the given policy for unset values
+func getEnvAsRetryPolicy(prefix string, fallback RetryPolicy) RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: getEnvAsInt(prefix+"_MAX", fallback.MaxRetries),
+		BaseDelay:  getEnvAsDuration(prefix+"_BASE", fallback.BaseDelay),
+		Multiplier: getEnvAsFloat(prefix+"_MULTIPLIER", fallback.Multiplier),
+		Jitter:     getEnvAsFloat(prefix+"_JITTER", fallback.Jitter),
+		MaxDelay:   getEnvAsDuration(prefix+"_MAX_DELAY", fallback.MaxDelay),
+	}
+}
+
+// loadRetryPolicies loads the notification retry policies. Channel policies inherit from the
+// default policy and high priority policies inherit from their channel policy.
+func loadRetryPolicies() map[string]RetryPolicy {
+	// Defaults reproduce the original 5/15/45 minute schedule with 3 attempts
+	defaultPolicy := getEnvAsRetryPolicy("NOTIFICATION_RETRY", RetryPolicy{
+		MaxRetries: 3,
+		BaseDelay:  5 * time.Minute,
+		Multiplier: 3,
+		Jitter:     0,
+		MaxDelay:   24 * time.Hour,
+	})
+
+	policies := map[string]RetryPolicy{"default": defaultPolicy}
+	for _, channel := range []string{"email", "sms", "push"} {
+		prefix := "NOTIFICATION_RETRY_" + strings.ToUpper(channel)
+		policies[channel] = getEnvAsRetryPolicy(prefix, defaultPolicy)
+		policies[channel+"_high"] = getEnvAsRetryPolicy(prefix+"_HIGH", policies[channel])
+	}
+	return policies
+}
+
+// loadJobSchedules reads the JOB_<NAME>_SCHEDULE variables, keyed by the lowercased job name,
+// e.g. JOB_AUTO_COMPLETE_APPOINTMENTS_SCHEDULE="*/10 * * * *"
+func loadJobSchedules() map[string]string {
+	schedules := make(map[string]string)
+	for _, entry := range os.Environ() {
+		key, value, _ := strings.Cut(entry, "=")
+		if !strings.HasPrefix(key, "JOB_") || !strings.HasSuffix(key, "_SCHEDULE") || value == "" {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(key, "JOB_"), "_SCHEDULE")
+		schedules[strings.ToLower(name)] = strings.TrimSpace(value)
+	}
+	return schedules
+}
+
+// defaultInstanceName names the replica after its host and process
+func defaultInstanceName() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "unknown"
+	}
+	return host + "-" + strconv.Itoa(os.Getpid())
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevocationTTLDefaultsToTheLongestTokenLifetime(t *testing.T) {
+	tests := []struct {
+		name        string
+		expireHours string
+		want        time.Duration
+	}{
+		{"refresh tokens outlive access tokens", "24", refreshTokenDuration},
+		{"access tokens outlive refresh tokens", "336", 336 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("JWT_EXPIRE_HOURS", tt.expireHours)
+			t.Setenv("JWT_REVOCATION_TTL", "")
+
+			cfg, err := Load()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cfg.Auth.RevocationTTL)
+		})
+	}
+}
+
+func TestLoadRefusesRevocationTTLShorterThanTokens(t *testing.T) {
+	t.Setenv("JWT_EXPIRE_HOURS", "24")
+	t.Setenv("JWT_REVOCATION_TTL", "72h")
+
+	_, err := Load()
+	assert.EqualError(t, err, "invalid auth settings: JWT_REVOCATION_TTL 72h0m0s is shorter than the 168h0m0s lifetime of the longest-lived token")
+}
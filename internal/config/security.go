@@ -0,0 +1,188 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SecurityConfig holds the CORS and security header settings responses are given
+type SecurityConfig struct {
+	Profile string        `yaml:"-"` // environment profile the settings were read for
+	CORS    CORSConfig    `yaml:"cors"`
+	Headers HeadersConfig `yaml:"headers"`
+}
+
+// CORSConfig holds which browser origins may call the API and how
+type CORSConfig struct {
+	AllowedOrigins   []string      `yaml:"allowed_origins"` // "*" allows any origin; an origin may hold one wildcard, e.g. https://*.example.com
+	AllowedMethods   []string      `yaml:"allowed_methods"`
+	AllowedHeaders   []string      `yaml:"allowed_headers"`
+	ExposedHeaders   []string      `yaml:"exposed_headers"`
+	AllowCredentials bool          `yaml:"allow_credentials"`
+	MaxAge           time.Duration `yaml:"max_age"` // how long browsers may cache a preflight response
+}
+
+// HeadersConfig holds the security headers added to every response; empty values leave a header out
+type HeadersConfig struct {
+	ContentSecurityPolicy string     `yaml:"content_security_policy"`
+	FrameOptions          string     `yaml:"frame_options"`
+	ReferrerPolicy        string     `yaml:"referrer_policy"`
+	PermissionsPolicy     string     `yaml:"permissions_policy"`
+	HSTS                  HSTSConfig `yaml:"hsts"`
+}
+
+// HSTSConfig holds the Strict-Transport-Security header, which makes browsers only use HTTPS
+type HSTSConfig struct {
+	Enabled           bool          `yaml:"enabled"`
+	MaxAge            time.Duration `yaml:"max_age"`
+	IncludeSubdomains bool          `yaml:"include_subdomains"`
+	Preload           bool          `yaml:"preload"`
+}
+
+// securityFile is the layout of the security settings file: settings shared by every environment
+// and, per environment profile, the settings it changes
+type securityFile struct {
+	Default  yaml.Node            `yaml:"default"`
+	Profiles map[string]yaml.Node `yaml:"profiles"`
+}
+
+// SecuritySettings holds the security settings in effect. Reload replaces them at runtime, e.g.
+// on SIGHUP; readers see either the old or the new settings, never a mix.
+type SecuritySettings struct {
+	current atomic.Pointer[SecurityConfig]
+}
+
+// Current returns the security settings in effect
+func (s *SecuritySettings) Current() *SecurityConfig {
+	return s.current.Load()
+}
+
+// Reload reads the security settings again and puts them in effect. Invalid settings are
+// rejected and the current ones kept.
+func (s *SecuritySettings) Reload() error {
+	security, err := LoadSecurity()
+	if err != nil {
+		return err
+	}
+	s.current.Store(security)
+	return nil
+}
+
+// LoadSecurity reads the security settings of the APP_ENV profile. The environment gives the
+// defaults; SECURITY_CONFIG_FILE, when set, overrides them with its default section and then with
+// the section of the profile.
+func LoadSecurity() (*SecurityConfig, error) {
+	release := getEnv("GIN_MODE", "debug") == "release"
+	profile := "development"
+	if release {
+		profile = "production"
+	}
+
+	security := &SecurityConfig{
+		Profile: getEnv("APP_ENV", profile),
+		CORS: CORSConfig{
+			AllowedOrigins:   getEnvAsList("CORS_ALLOWED_ORIGINS", []string{"*"}),
+			AllowedMethods:   getEnvAsList("CORS_ALLOWED_METHODS", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}),
+			AllowedHeaders:   []string{"Origin", "Authorization", "Content-Type", "Accept", "Accept-Language", "Time-Zone", "API-Version"},
+			ExposedHeaders:   []string{"Content-Length", "Content-Language", "API-Version"},
+			AllowCredentials: getEnvAsBool("CORS_ALLOW_CREDENTIALS", true),
+			MaxAge:           getEnvAsDuration("CORS_MAX_AGE", 12*time.Hour),
+		},
+		Headers: HeadersConfig{
+			ContentSecurityPolicy: getEnv("SECURITY_CONTENT_SECURITY_POLICY", "default-src 'self'"),
+			FrameOptions:          "DENY",
+			ReferrerPolicy:        "strict-origin-when-cross-origin",
+			PermissionsPolicy:     "geolocation=(), microphone=(), camera=()",
+			HSTS: HSTSConfig{
+				Enabled:           getEnvAsBool("SECURITY_HSTS_ENABLED", release),
+				MaxAge:            getEnvAsDuration("SECURITY_HSTS_MAX_AGE", 365*24*time.Hour),
+				IncludeSubdomains: true,
+			},
+		},
+	}
+
+	if path := os.Getenv("SECURITY_CONFIG_FILE"); path != "" {
+		if err := security.applyFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if err := security.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid security settings of profile %s: %w", security.Profile, err)
+	}
+	return security, nil
+}
+
+// applyFile overrides the settings with those of a security settings file
+func (c *SecurityConfig) applyFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read security settings: %w", err)
+	}
+
+	var file securityFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse security settings %s: %w", path, err)
+	}
+	if !file.Default.IsZero() {
+		if err := file.Default.Decode(c); err != nil {
+			return fmt.Errorf("invalid default security settings in %s: %w", path, err)
+		}
+	}
+	// A profile the file doesn't mention uses its default section
+	if node, ok := file.Profiles[c.Profile]; ok {
+		if err := node.Decode(c); err != nil {
+			return fmt.Errorf("invalid security settings of profile %s in %s: %w", c.Profile, path, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks the settings can be applied, so a reload never installs a CORS policy that
+// fails every request
+func (c *SecurityConfig) Validate() error {
+	if len(c.CORS.AllowedOrigins) == 0 {
+		return errors.New("at least one CORS origin must be allowed")
+	}
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" {
+			continue
+		}
+		if strings.Count(origin, "*") > 1 {
+			return fmt.Errorf("CORS origin %q has more than one wildcard", origin)
+		}
+		if !strings.HasPrefix(origin, "http://") && !strings.HasPrefix(origin, "https://") {
+			return fmt.Errorf("CORS origin %q must start with http:// or https://", origin)
+		}
+	}
+	if len(c.CORS.AllowedMethods) == 0 {
+		return errors.New("at least one CORS method must be allowed")
+	}
+	if c.CORS.MaxAge < 0 {
+		return errors.New("CORS max age cannot be negative")
+	}
+	if c.Headers.HSTS.Enabled && c.Headers.HSTS.MaxAge <= 0 {
+		return errors.New("HSTS max age must be positive")
+	}
+	return nil
+}
+
+// HSTSValue returns the Strict-Transport-Security header value, empty when HSTS is disabled
+func (c *SecurityConfig) HSTSValue() string {
+	if !c.Headers.HSTS.Enabled {
+		return ""
+	}
+	value := fmt.Sprintf("max-age=%d", int64(c.Headers.HSTS.MaxAge/time.Second))
+	if c.Headers.HSTS.IncludeSubdomains {
+		value += "; includeSubDomains"
+	}
+	if c.Headers.HSTS.Preload {
+		value += "; preload"
+	}
+	return value
+}
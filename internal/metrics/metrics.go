@@ -0,0 +1,122 @@
+package metrics
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixes all application metrics
+const namespace = "scheduling"
+
+var (
+	// CircuitBreakerState reports the state of each provider circuit breaker
+	// (0 = closed, 1 = half-open, 2 = open)
+	CircuitBreakerState = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "circuit_breaker_state",
+		Help:      "State of external provider circuit breakers (0=closed, 1=half-open, 2=open).",
+	}, []string{"provider"})
+
+	// CircuitBreakerTransitions counts circuit breaker state changes
+	CircuitBreakerTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "circuit_breaker_transitions_total",
+		Help:      "Number of circuit breaker state transitions.",
+	}, []string{"provider", "to"})
+
+	// CircuitBreakerFallbacks counts calls that took the fallback path because a breaker was open
+	CircuitBreakerFallbacks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "circuit_breaker_fallbacks_total",
+		Help:      "Number of calls handled by a fallback because the provider circuit breaker was open.",
+	}, []string{"provider", "fallback"})
+
+	// NotificationQueueDepth reports the number of pending queue items per queue and priority
+	NotificationQueueDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "notification_queue_depth",
+		Help:      "Number of pending notification queue items by queue and priority.",
+	}, []string{"queue", "priority"})
+
+	// NotificationTemplateMissing counts notifications rendered with the built-in fallback
+	// because no active template exists for their event, recipient and channel
+	NotificationTemplateMissing = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "notification_template_missing_total",
+		Help:      "Number of notifications rendered with the fallback template because no template was found.",
+	}, []string{"event", "recipient", "channel"})
+
+	// PendingExpiryWarnings counts pending appointments whose participants were warned they expire
+	// unless confirmed
+	PendingExpiryWarnings = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pending_expiry_warnings_total",
+		Help:      "Number of pending appointments warned they are cancelled unless confirmed, by operation.",
+	}, []string{"operation"})
+
+	// PendingExpired counts pending appointments cancelled because nobody confirmed them in time
+	PendingExpired = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pending_expired_total",
+		Help:      "Number of pending appointments cancelled because they were not confirmed in time, by operation.",
+	}, []string{"operation"})
+
+	// Leader reports whether an instance leads the replicas (1) or follows (0); the series set to
+	// 1 names the current leader
+	Leader = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "leader",
+		Help:      "Whether the instance is the leader running singleton background jobs (1=leader, 0=follower).",
+	}, []string{"instance"})
+
+	// LeaderTransitions counts the times an instance gained or lost leadership
+	LeaderTransitions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "leader_transitions_total",
+		Help:      "Number of times the instance became leader or follower.",
+	}, []string{"instance", "to"})
+
+	// OutboundRequests counts attempts of third-party API calls by host and status code
+	// ("error" when no response was received)
+	OutboundRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "outbound_requests_total",
+		Help:      "Number of outbound HTTP request attempts by host and status code.",
+	}, []string{"host", "status"})
+
+	// OutboundRequestDuration observes how long each outbound request attempt took
+	OutboundRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "outbound_request_duration_seconds",
+		Help:      "Duration of outbound HTTP request attempts.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"host"})
+
+	// OutboundRetries counts outbound requests sent again after a failed attempt
+	OutboundRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "outbound_retries_total",
+		Help:      "Number of outbound HTTP request retries by host.",
+	}, []string{"host"})
+
+	// OutboundThrottleWait sums the time outbound requests waited for the rate limit of their host
+	OutboundThrottleWait = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "outbound_rate_limit_wait_seconds_total",
+		Help:      "Time outbound HTTP requests spent waiting for the rate limit of their host.",
+	}, []string{"host"})
+
+	// BookingDenials counts bookings refused for lack of room, by operation and reason
+	BookingDenials = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "booking_denials_total",
+		Help:      "Number of bookings refused for a conflict, capacity, lead time, supplier quota or closure, by operation and reason.",
+	}, []string{"operation", "reason"})
+)
+
+// Handler returns the Prometheus scrape handler
+func Handler() gin.HandlerFunc {
+	return gin.WrapH(promhttp.Handler())
+}
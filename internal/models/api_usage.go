@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// Who made an API request
+const (
+	UsageConsumerUser      = "user"      // a user authenticated with a token
+	UsageConsumerPartner   = "partner"   // a partner system authenticated by its request signature
+	UsageConsumerAnonymous = "anonymous" // nobody authenticated, such as logins and invitation links
+)
+
+// APIUsage aggregates the requests one consumer made to one route during an hour. Each replica
+// counts requests in memory and adds them to their hour's row when it flushes, so the table grows
+// with the consumers and routes in use rather than with the traffic.
+type APIUsage struct {
+	ID               uint      `gorm:"primaryKey" json:"-"`
+	Hour             time.Time `gorm:"not null;uniqueIndex:idx_api_usage_key,priority:1" json:"hour"`
+	ConsumerType     string    `gorm:"not null;uniqueIndex:idx_api_usage_key,priority:2" json:"consumer_type"`
+	ConsumerID       uint      `gorm:"not null;uniqueIndex:idx_api_usage_key,priority:3" json:"consumer_id"` // user or partner credential, 0 for anonymous requests
+	Method           string    `gorm:"not null;uniqueIndex:idx_api_usage_key,priority:4" json:"method"`
+	Route            string    `gorm:"not null;uniqueIndex:idx_api_usage_key,priority:5" json:"route"` // route template, e.g. /api/v1/appointments/:id
+	Requests         int64     `gorm:"not null;default:0" json:"requests"`
+	ClientErrors     int64     `gorm:"not null;default:0" json:"client_errors"` // 4xx responses
+	ServerErrors     int64     `gorm:"not null;default:0" json:"server_errors"` // 5xx responses
+	LatencyMillis    int64     `gorm:"not null;default:0" json:"latency_ms"`    // total of the requests' latencies
+	MaxLatencyMillis int64     `gorm:"not null;default:0" json:"max_latency_ms"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// AppointmentDelay records a delay declared by a supplier, with the arrival time they now expect
+type AppointmentDelay struct {
+	BaseModel
+	AppointmentID uint      `gorm:"not null;index" json:"appointment_id"`
+	DeclaredByID  uint      `json:"declared_by_id"` // User who declared the delay
+	PreviousStart time.Time `gorm:"not null" json:"previous_start"`
+	ETA           time.Time `gorm:"not null" json:"eta"`
+	DelayMinutes  int       `json:"delay_minutes"`
+	Reason        string    `json:"reason"`
+	SlotAvailable bool      `json:"slot_available"` // Whether the slot shifted to the ETA was free
+	Rescheduled   bool      `json:"rescheduled"`    // Whether the appointment was moved to the ETA
+}
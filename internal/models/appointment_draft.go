@@ -0,0 +1,32 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+// draftKeyPattern matches the keys clients name their drafts with, e.g. "new-booking" or a UUID
+var draftKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// AppointmentDraft is a booking form a user is still filling in, saved as they go so they can
+// pick it up on another device. The payload is the form as the client sends it, a JSON object
+// that isn't validated until it is booked. Drafts expire when they haven't been saved for a
+// while.
+type AppointmentDraft struct {
+	ID        uint      `gorm:"primaryKey" json:"-"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_appointment_drafts_user_key,priority:1" json:"user_id"`
+	Key       string    `gorm:"size:64;not null;uniqueIndex:idx_appointment_drafts_user_key,priority:2" json:"key"`
+	Payload   string    `gorm:"type:text;not null" json:"-"` // JSON object
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ValidateDraftKey checks a draft key is 1 to 64 letters, digits, dashes or underscores
+func ValidateDraftKey(key string) error {
+	if !draftKeyPattern.MatchString(key) {
+		return errors.New("draft key must be 1 to 64 letters, digits, dashes or underscores")
+	}
+	return nil
+}
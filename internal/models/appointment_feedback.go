@@ -0,0 +1,31 @@
+package models
+
+import "errors"
+
+// Feedback rating bounds
+const (
+	MinFeedbackRating = 1
+	MaxFeedbackRating = 5
+)
+
+// AppointmentFeedback is a supplier's or employee's rating of a completed appointment
+type AppointmentFeedback struct {
+	BaseModel
+	AppointmentID uint   `gorm:"not null;uniqueIndex:idx_feedback_appointment_author" json:"appointment_id"`
+	OperationID   uint   `gorm:"not null;index" json:"operation_id"`                                    // copied from the appointment for per-operation scores
+	AuthorID      uint   `gorm:"not null;uniqueIndex:idx_feedback_appointment_author" json:"author_id"` // User who left the feedback
+	AuthorRole    string `gorm:"not null" json:"author_role"`                                           // supplier or employee
+	Rating        int    `gorm:"not null" json:"rating"`
+	Comment       string `gorm:"type:text" json:"comment"`
+}
+
+// Validate checks the rating is within bounds
+func (f *AppointmentFeedback) Validate() error {
+	if f.Rating < MinFeedbackRating || f.Rating > MaxFeedbackRating {
+		return errors.New("rating must be between 1 and 5")
+	}
+	if f.AuthorRole != "supplier" && f.AuthorRole != "employee" {
+		return errors.New("only suppliers and employees can leave feedback")
+	}
+	return nil
+}
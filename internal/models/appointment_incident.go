@@ -0,0 +1,119 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// MaxIncidentAttachments is how many photos or files an incident may hold
+const MaxIncidentAttachments = 10
+
+// IncidentCategory defines the kind of problem logged on an appointment
+type IncidentCategory string
+
+const (
+	// IncidentDamagedGoods is goods received broken, spoiled or badly packed
+	IncidentDamagedGoods IncidentCategory = "damaged_goods"
+
+	// IncidentWrongQuantity is a delivery that doesn't match the scheduled quantity
+	IncidentWrongQuantity IncidentCategory = "wrong_quantity"
+
+	// IncidentWrongProduct is a delivery of a different product than scheduled
+	IncidentWrongProduct IncidentCategory = "wrong_product"
+
+	// IncidentLateArrival is a supplier arriving after the scheduled slot
+	IncidentLateArrival IncidentCategory = "late_arrival"
+
+	// IncidentDocumentation is missing or incorrect invoices, labels or permits
+	IncidentDocumentation IncidentCategory = "documentation"
+
+	// IncidentOther is any other problem
+	IncidentOther IncidentCategory = "other"
+)
+
+// IsValid reports whether the incident category is known
+func (c IncidentCategory) IsValid() bool {
+	switch c {
+	case IncidentDamagedGoods, IncidentWrongQuantity, IncidentWrongProduct, IncidentLateArrival, IncidentDocumentation, IncidentOther:
+		return true
+	}
+	return false
+}
+
+// IncidentSeverity defines how serious an incident is
+type IncidentSeverity string
+
+const (
+	// SeverityLow is a minor problem that needs no follow-up
+	SeverityLow IncidentSeverity = "low"
+
+	// SeverityMedium is a problem the supplier should correct
+	SeverityMedium IncidentSeverity = "medium"
+
+	// SeverityHigh is a problem that affected the operation
+	SeverityHigh IncidentSeverity = "high"
+
+	// SeverityCritical is a safety or compliance problem
+	SeverityCritical IncidentSeverity = "critical"
+)
+
+// IsValid reports whether the incident severity is known
+func (s IncidentSeverity) IsValid() bool {
+	switch s {
+	case SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical:
+		return true
+	}
+	return false
+}
+
+// AppointmentIncident is a problem dock staff logged on an appointment
+type AppointmentIncident struct {
+	BaseModel
+	AppointmentID uint                 `gorm:"not null;index" json:"appointment_id"`
+	SupplierID    uint                 `gorm:"not null;index" json:"supplier_id"`  // copied from the appointment for supplier analytics
+	OperationID   uint                 `gorm:"not null;index" json:"operation_id"` // copied from the appointment
+	Category      IncidentCategory     `gorm:"not null;index" json:"category"`
+	Severity      IncidentSeverity     `gorm:"not null" json:"severity"`
+	Description   string               `gorm:"type:text" json:"description"`
+	ReportedByID  uint                 `json:"reported_by_id"` // User who logged the incident
+	ResolvedAt    *time.Time           `json:"resolved_at"`
+	ResolvedByID  *uint                `json:"resolved_by_id"`
+	Resolution    string               `gorm:"type:text" json:"resolution"`
+	Attachments   []IncidentAttachment `gorm:"foreignKey:IncidentID" json:"attachments"`
+}
+
+// IncidentAttachment is a photo or file documenting an incident
+type IncidentAttachment struct {
+	BaseModel
+	IncidentID  uint   `gorm:"not null;index" json:"incident_id"`
+	FileURL     string `gorm:"not null" json:"file_url"`
+	ContentType string `json:"content_type"`
+	Caption     string `json:"caption"`
+}
+
+// IsResolved reports whether the incident was resolved
+func (i *AppointmentIncident) IsResolved() bool {
+	return i.ResolvedAt != nil
+}
+
+// Validate validates an incident
+func (i *AppointmentIncident) Validate() error {
+	if i.AppointmentID == 0 {
+		return errors.New("appointment is required")
+	}
+	if !i.Category.IsValid() {
+		return errors.New("invalid incident category")
+	}
+	if !i.Severity.IsValid() {
+		return errors.New("invalid incident severity")
+	}
+	if len(i.Attachments) > MaxIncidentAttachments {
+		return errors.New("an incident can have at most 10 attachments")
+	}
+	for _, attachment := range i.Attachments {
+		if attachment.FileURL == "" {
+			return errors.New("attachment file URL is required")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// What made an appointment change status
+const (
+	StatusSourceBooking      = "booking"       // the appointment was created
+	StatusSourceUser         = "user"          // a user changed the status or updated the appointment
+	StatusSourceCompletion   = "completion"    // dock staff recorded the quantity received
+	StatusSourceAutoComplete = "auto_complete" // the overdue job closed the appointment
+	StatusSourceUndo         = "undo"          // staff undid an automatic completion
+	StatusSourceCrossDock    = "cross_dock"    // the inbound a pickup depends on was cancelled
+	StatusSourceExpiry       = "expiry"        // the pending expiry job cancelled an appointment nobody confirmed
+	StatusSourceDeposit      = "deposit"       // the deposit was paid, or its hold expired unpaid
+)
+
+// AppointmentStatusEvent records one status transition of an appointment. The events are the
+// appointment's full status history; ConfirmedAt, CancelledAt and CompletedAt only keep the
+// latest time of each.
+type AppointmentStatusEvent struct {
+	ID            uint              `gorm:"primaryKey" json:"id"`
+	AppointmentID uint              `gorm:"not null;index:idx_status_events_appointment,priority:1" json:"appointment_id"`
+	FromStatus    AppointmentStatus `json:"from_status"` // empty for the creation of the appointment
+	ToStatus      AppointmentStatus `gorm:"not null;index" json:"to_status"`
+	ActorID       *uint             `json:"actor_id"` // user who made the change, nil for automatic changes
+	Source        string            `gorm:"not null" json:"source"`
+	Reason        string            `json:"reason"`
+	OccurredAt    time.Time         `gorm:"not null;index:idx_status_events_appointment,priority:2" json:"occurred_at"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import "errors"
+
+// AppointmentType represents what a slot is booked for
+type AppointmentType string
+
+const (
+	// AppointmentTypeDelivery is an inbound delivery of goods
+	AppointmentTypeDelivery AppointmentType = "delivery"
+
+	// AppointmentTypePickup is an outbound pickup, e.g. a return collected by the supplier
+	AppointmentTypePickup AppointmentType = "pickup"
+
+	// AppointmentTypeServiceVisit is a visit without goods, e.g. maintenance or an audit
+	AppointmentTypeServiceVisit AppointmentType = "service_visit"
+)
+
+// IsValid reports whether the appointment type is known
+func (t AppointmentType) IsValid() bool {
+	switch t {
+	case AppointmentTypeDelivery, AppointmentTypePickup, AppointmentTypeServiceVisit:
+		return true
+	}
+	return false
+}
+
+// MovesGoods reports whether appointments of this type carry a product and a quantity
+func (t AppointmentType) MovesGoods() bool {
+	return t != AppointmentTypeServiceVisit
+}
+
+// Label returns a human readable name for calendar and manifest outputs
+func (t AppointmentType) Label() string {
+	switch t {
+	case AppointmentTypePickup:
+		return "Pickup"
+	case AppointmentTypeServiceVisit:
+		return "Service visit"
+	default:
+		return "Delivery"
+	}
+}
+
+// AppointmentTypeCapacity limits how many appointments of a type an operation accepts
+type AppointmentTypeCapacity struct {
+	BaseModel
+	OperationID   uint            `gorm:"not null;uniqueIndex:idx_operation_appointment_type" json:"operation_id"`
+	Type          AppointmentType `gorm:"not null;uniqueIndex:idx_operation_appointment_type" json:"type"`
+	MaxConcurrent int             `json:"max_concurrent"` // Overlapping appointments of the type; 0 means unlimited
+	MaxPerDay     int             `json:"max_per_day"`    // Appointments of the type per day; 0 means unlimited
+}
+
+// Validate validates a capacity rule
+func (c *AppointmentTypeCapacity) Validate() error {
+	if !c.Type.IsValid() {
+		return errors.New("invalid appointment type")
+	}
+	if c.MaxConcurrent < 0 || c.MaxPerDay < 0 {
+		return errors.New("capacity limits cannot be negative")
+	}
+	return nil
+}
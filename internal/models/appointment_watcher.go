@@ -0,0 +1,53 @@
+package models
+
+import "errors"
+
+// AppointmentWatcher subscribes a user, e.g. a quality inspector, to every notification of an
+// appointment. Each watcher picks the channels they are copied on.
+type AppointmentWatcher struct {
+	BaseModel
+	AppointmentID uint  `gorm:"not null;uniqueIndex:idx_appointment_watchers_user" json:"appointment_id"`
+	UserID        uint  `gorm:"not null;uniqueIndex:idx_appointment_watchers_user;index" json:"user_id"`
+	User          *User `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	AddedByID     uint  `json:"added_by_id"`
+	EmailEnabled  bool  `gorm:"not null" json:"email_enabled"`
+	SMSEnabled    bool  `gorm:"not null" json:"sms_enabled"`
+	PushEnabled   bool  `gorm:"not null" json:"push_enabled"`
+}
+
+// Validate validates a watcher
+func (w *AppointmentWatcher) Validate() error {
+	if w.AppointmentID == 0 {
+		return errors.New("appointment is required")
+	}
+	if w.UserID == 0 {
+		return errors.New("watcher user is required")
+	}
+	if len(w.Channels()) == 0 {
+		return errors.New("watchers need at least one notification channel")
+	}
+	return nil
+}
+
+// Channels returns the notification types the watcher receives
+func (w *AppointmentWatcher) Channels() []NotificationType {
+	var channels []NotificationType
+	if w.EmailEnabled {
+		channels = append(channels, NotificationTypeEmail)
+	}
+	if w.SMSEnabled {
+		channels = append(channels, NotificationTypeSMS)
+	}
+	if w.PushEnabled {
+		channels = append(channels, NotificationTypePush)
+	}
+	return channels
+}
+
+// AppointmentMute silences the notifications of one appointment for a user, leaving their
+// notification preferences for everything else as they are
+type AppointmentMute struct {
+	BaseModel
+	AppointmentID uint `gorm:"not null;uniqueIndex:idx_appointment_mutes_user" json:"appointment_id"`
+	UserID        uint `gorm:"not null;uniqueIndex:idx_appointment_mutes_user" json:"user_id"`
+}
@@ -5,18 +5,19 @@ import (
     "errors"
 )
 
-// AvailabilitySlot represents a time slot when an employee is available for appointments
+// AvailabilitySlot represents a time slot when an employee is available for appointments.
+// Services call Validate before saving; the check constraints back the day and time rules.
 type AvailabilitySlot struct {
     ID           uint      `json:"id" gorm:"primaryKey"`
     EmployeeID   uint      `json:"employee_id" gorm:"not null;index"`
     Employee     Employee  `json:"employee" gorm:"foreignKey:EmployeeID"`
     OperationID  uint      `json:"operation_id" gorm:"not null;index"`
     Operation    Operation `json:"operation" gorm:"foreignKey:OperationID"`
-    DayOfWeek    int       `json:"day_of_week" gorm:"not null"` // 0=Sunday, 1=Monday, etc.
+    DayOfWeek    int       `json:"day_of_week" gorm:"not null;check:chk_availability_slots_day,day_of_week BETWEEN 0 AND 6"` // 0=Sunday, 1=Monday, etc.
     StartTime    string    `json:"start_time" gorm:"not null"`  // Format: "HH:MM"
-    EndTime      string    `json:"end_time" gorm:"not null"`    // Format: "HH:MM"
+    EndTime      string    `json:"end_time" gorm:"not null;check:chk_availability_slots_times,end_time > start_time"`    // Format: "HH:MM", "24:00" ends at midnight
     IsRecurring  bool      `json:"is_recurring" gorm:"default:true"`
-    SpecificDate *time.Time `json:"specific_date"`  // Used for non-recurring slots
+    SpecificDate *time.Time `json:"specific_date" gorm:"check:chk_availability_slots_date,is_recurring OR specific_date IS NOT NULL"`  // Used for non-recurring slots
     Active       bool      `json:"active" gorm:"default:true"`
     CreatedAt    time.Time `json:"created_at"`
     UpdatedAt    time.Time `json:"updated_at"`
@@ -30,6 +31,12 @@ func (a *AvailabilitySlot) Validate() error {
     if a.OperationID == 0 {
         return errors.New("operation is required")
     }
+    return a.ValidateSchedule()
+}
+
+// ValidateSchedule checks when the slot is, without the employee and operation it belongs to,
+// for slots whose operation does not exist yet
+func (a *AvailabilitySlot) ValidateSchedule() error {
     if a.DayOfWeek < 0 || a.DayOfWeek > 6 {
         return errors.New("day of week must be between 0 and 6")
     }
@@ -39,6 +46,15 @@ func (a *AvailabilitySlot) Validate() error {
     if a.EndTime == "" {
         return errors.New("end time is required")
     }
+    if !isClockTime(a.StartTime) {
+        return errors.New("start time must be in HH:MM format")
+    }
+    if a.EndTime != "24:00" && !isClockTime(a.EndTime) {
+        return errors.New("end time must be in HH:MM format")
+    }
+    if a.EndTime <= a.StartTime {
+        return errors.New("end time must be after start time")
+    }
 
     // For non-recurring slots, a specific date is required
     if !a.IsRecurring && a.SpecificDate == nil {
@@ -48,14 +64,14 @@ func (a *AvailabilitySlot) Validate() error {
     return nil
 }
 
-// BeforeCreate is called by GORM before creating a new record
-func (a *AvailabilitySlot) BeforeCreate() error {
-    return a.Validate()
-}
-
-// BeforeUpdate is called by GORM before updating a record
-func (a *AvailabilitySlot) BeforeUpdate() error {
-    return a.Validate()
+// isClockTime reports whether a value is a zero-padded "HH:MM" time of day, so that times
+// compare correctly as strings
+func isClockTime(value string) bool {
+    if len(value) != 5 {
+        return false
+    }
+    _, err := time.Parse("15:04", value)
+    return err == nil
 }
 
 // OverlapsWith checks if this availability slot overlaps with another
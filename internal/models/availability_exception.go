@@ -0,0 +1,67 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// AvailabilityExceptionReason defines why an employee is unavailable
+type AvailabilityExceptionReason string
+
+const (
+	// ExceptionVacation is planned time off
+	ExceptionVacation AvailabilityExceptionReason = "vacation"
+
+	// ExceptionSickLeave is time off for illness
+	ExceptionSickLeave AvailabilityExceptionReason = "sick_leave"
+
+	// ExceptionTraining is time spent in training away from the dock
+	ExceptionTraining AvailabilityExceptionReason = "training"
+
+	// ExceptionOther is any other absence
+	ExceptionOther AvailabilityExceptionReason = "other"
+)
+
+// IsValid reports whether the exception reason is known
+func (r AvailabilityExceptionReason) IsValid() bool {
+	switch r {
+	case ExceptionVacation, ExceptionSickLeave, ExceptionTraining, ExceptionOther:
+		return true
+	}
+	return false
+}
+
+// AvailabilityException is a period in which an employee is unavailable despite their recurring
+// availability slots
+type AvailabilityException struct {
+	BaseModel
+	EmployeeID  uint                        `gorm:"not null;index" json:"employee_id"`
+	OperationID *uint                       `gorm:"index" json:"operation_id"` // nil applies to every operation
+	StartsAt    time.Time                   `gorm:"not null;index" json:"starts_at"`
+	EndsAt      time.Time                   `gorm:"not null;index" json:"ends_at"`
+	Reason      AvailabilityExceptionReason `gorm:"not null" json:"reason"`
+	Notes       string                      `json:"notes"`
+	CreatedByID uint                        `json:"created_by_id"` // User who recorded the exception
+}
+
+// Validate validates an availability exception
+func (e *AvailabilityException) Validate() error {
+	if e.EmployeeID == 0 {
+		return errors.New("employee is required")
+	}
+	if e.StartsAt.IsZero() || e.EndsAt.IsZero() {
+		return errors.New("start and end are required")
+	}
+	if !e.EndsAt.After(e.StartsAt) {
+		return errors.New("end must be after start")
+	}
+	if !e.Reason.IsValid() {
+		return errors.New("invalid exception reason")
+	}
+	return nil
+}
+
+// AppliesTo reports whether the exception covers an operation
+func (e *AvailabilityException) AppliesTo(operationID uint) bool {
+	return e.OperationID == nil || *e.OperationID == operationID
+}
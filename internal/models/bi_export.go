@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// BIExportTrigger is what started a BI export run
+type BIExportTrigger string
+
+const (
+	BIExportTriggerScheduled BIExportTrigger = "scheduled"
+	BIExportTriggerManual    BIExportTrigger = "manual"
+)
+
+// BIExportStatus is the state of a BI export run
+type BIExportStatus string
+
+const (
+	BIExportRunning   BIExportStatus = "running"
+	BIExportSucceeded BIExportStatus = "succeeded"
+	BIExportFailed    BIExportStatus = "failed"
+)
+
+// BIExportRun is one export of anonymized appointment facts to the data warehouse bucket. A run
+// exports the appointments changed after the ChangedUntil of the last run that succeeded, up to
+// its own ChangedUntil, so a failed run is covered again by the next one.
+type BIExportRun struct {
+	ID            uint            `gorm:"primaryKey" json:"id"`
+	Trigger       BIExportTrigger `gorm:"not null" json:"trigger"`
+	TriggeredByID *uint           `json:"triggered_by_id"` // Set for manual runs
+	SchemaVersion int             `gorm:"not null" json:"schema_version"`
+	ChangedAfter  time.Time       `json:"changed_after"`                       // Exclusive; zero for the first, full export
+	ChangedUntil  time.Time       `gorm:"not null;index" json:"changed_until"` // Inclusive
+	Rows          int             `json:"rows"`
+	ObjectKey     string          `json:"object_key,omitempty"` // Empty when nothing changed
+	Status        BIExportStatus  `gorm:"not null;index" json:"status"`
+	Error         string          `gorm:"type:text" json:"error,omitempty"`
+	StartedAt     time.Time       `gorm:"not null" json:"started_at"`
+	FinishedAt    *time.Time      `json:"finished_at"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"errors"
+	"strings"
+)
+
+// BillingCodeType defines which appointment field a billing code may fill
+type BillingCodeType string
+
+const (
+	// BillingCodeCostCenter is a cost center dock time is allocated to
+	BillingCodeCostCenter BillingCodeType = "cost_center"
+
+	// BillingCodeProject is a billing code invoices reference, such as a project or contract
+	BillingCodeProject BillingCodeType = "billing_code"
+)
+
+// IsValid reports whether the billing code type is known
+func (t BillingCodeType) IsValid() bool {
+	switch t {
+	case BillingCodeCostCenter, BillingCodeProject:
+		return true
+	}
+	return false
+}
+
+// BillingCode is an entry of the admin-managed list of cost centers and billing codes appointments
+// may be charged to. Inactive codes stay on past appointments but can't be used for new ones.
+type BillingCode struct {
+	BaseModel
+	Type   BillingCodeType `gorm:"not null;uniqueIndex:idx_billing_codes_type_code" json:"type"`
+	Code   string          `gorm:"not null;uniqueIndex:idx_billing_codes_type_code" json:"code"`
+	Name   string          `json:"name"`
+	Active bool            `gorm:"not null" json:"active"`
+}
+
+// Validate validates a billing code
+func (b *BillingCode) Validate() error {
+	if !b.Type.IsValid() {
+		return errors.New("billing code type must be cost_center or billing_code")
+	}
+	b.Code = strings.TrimSpace(b.Code)
+	if b.Code == "" {
+		return errors.New("code is required")
+	}
+	if len(b.Code) > 50 {
+		return errors.New("code must be at most 50 characters")
+	}
+	return nil
+}
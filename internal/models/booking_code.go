@@ -0,0 +1,30 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// BookingSequence holds the last booking number handed out by an operation in a year
+type BookingSequence struct {
+	OperationID uint      `gorm:"primaryKey;autoIncrement:false" json:"operation_id"`
+	Year        int       `gorm:"primaryKey;autoIncrement:false" json:"year"`
+	LastNumber  int       `gorm:"not null;default:0" json:"last_number"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// FormatBookingCode returns the booking code of the number-th appointment an operation booked in
+// a year, e.g. SP01-2025-00423. Codes are upper case so lookups can ignore case.
+func FormatBookingCode(operationCode string, year, number int) string {
+	return fmt.Sprintf("%s-%d-%05d", strings.ToUpper(operationCode), year, number)
+}
+
+// Reference returns the booking code of the appointment, or its ID for appointments booked
+// before codes were introduced, for use in messages
+func (a *Appointment) Reference() string {
+	if a.BookingCode != "" {
+		return a.BookingCode
+	}
+	return fmt.Sprintf("#%d", a.ID)
+}
@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// DenialReason is why a booking was refused
+type DenialReason string
+
+const (
+	// DenialConflict refuses a booking overlapping another appointment of the employee
+	DenialConflict DenialReason = "conflict"
+	// DenialCapacity refuses a booking over the capacity of its type or time band
+	DenialCapacity DenialReason = "capacity"
+	// DenialLeadTime refuses a booking for a time that has already passed
+	DenialLeadTime DenialReason = "lead_time"
+	// DenialQuota refuses a booking over the supplier's limit of simultaneous appointments
+	DenialQuota DenialReason = "quota"
+	// DenialClosed refuses a booking outside the operation's hours or on a day it is closed
+	DenialClosed DenialReason = "closed"
+)
+
+// DenialReasons lists every denial reason
+var DenialReasons = []DenialReason{DenialConflict, DenialCapacity, DenialLeadTime, DenialQuota, DenialClosed}
+
+// IsValid reports whether the denial reason is known
+func (r DenialReason) IsValid() bool {
+	for _, reason := range DenialReasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// BookingDenial records a booking refused for lack of room at the time asked for: demand the
+// operation didn't meet. Bookings refused for invalid data, e.g. an unknown supplier, aren't
+// recorded. The weekday, hour and date are those of the requested start at the operation, so
+// demand can be counted per time band without converting timezones.
+type BookingDenial struct {
+	ID             uint            `gorm:"primaryKey" json:"id"`
+	OperationID    uint            `gorm:"not null;index:idx_booking_denials_operation,priority:1" json:"operation_id"`
+	SupplierID     uint            `gorm:"not null;index" json:"supplier_id"`
+	EmployeeID     uint            `json:"employee_id"`
+	ProductID      *uint           `json:"product_id"`
+	Type           AppointmentType `json:"type"`
+	Reason         DenialReason    `gorm:"not null;index" json:"reason"`
+	Detail         string          `json:"detail"` // the error the booking was refused with
+	RequestedStart time.Time       `gorm:"not null;index:idx_booking_denials_operation,priority:2" json:"requested_start"`
+	RequestedEnd   time.Time       `gorm:"not null" json:"requested_end"`
+	LocalDate      string          `gorm:"type:char(10);not null" json:"local_date"` // YYYY-MM-DD
+	Weekday        int             `gorm:"not null" json:"weekday"`                  // 0 for Sunday
+	Hour           int             `gorm:"not null" json:"hour"`
+	CreatedAt      time.Time       `gorm:"index" json:"created_at"`
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// Limits of the appointment length an invitation offers
+const (
+	MinInvitationDurationMinutes = 15
+	MaxInvitationDurationMinutes = 8 * 60
+)
+
+// BookingInvitation lets a supplier book one appointment through a link, without signing in,
+// within the constraints set by the employee who sent it. Only a hash of the link's token is
+// stored.
+type BookingInvitation struct {
+	BaseModel
+	TokenHash         string          `gorm:"uniqueIndex;not null" json:"-"`
+	InviterID         uint            `gorm:"not null;index" json:"inviter_id"` // Employee the appointment is booked with
+	CreatedByID       uint            `json:"created_by_id"`                    // User who sent the invitation
+	SupplierID        uint            `gorm:"not null;index" json:"supplier_id"`
+	OperationID       uint            `gorm:"not null" json:"operation_id"`
+	Type              AppointmentType `gorm:"not null;default:'delivery'" json:"type"`
+	ProductID         *uint           `json:"product_id"`
+	QuantityToDeliver int             `json:"quantity_to_deliver"` // 0 lets the supplier enter the quantity
+	DurationMinutes   int             `gorm:"not null" json:"duration_minutes"`
+	BookFrom          time.Time       `json:"book_from"` // The appointment must start in [BookFrom, BookUntil)
+	BookUntil         time.Time       `json:"book_until"`
+	Notes             string          `json:"notes"`
+	ExpiresAt         time.Time       `gorm:"index" json:"expires_at"`
+	UsedAt            *time.Time      `json:"used_at"`
+	AppointmentID     *uint           `json:"appointment_id"`
+	RevokedAt         *time.Time      `json:"revoked_at"`
+}
+
+// Validate validates a booking invitation
+func (i *BookingInvitation) Validate() error {
+	if i.InviterID == 0 {
+		return errors.New("inviting employee is required")
+	}
+	if i.SupplierID == 0 {
+		return errors.New("supplier is required")
+	}
+	if i.OperationID == 0 {
+		return errors.New("operation is required")
+	}
+	if !i.Type.IsValid() {
+		return errors.New("invalid appointment type")
+	}
+	if i.Type.MovesGoods() && i.ProductID == nil {
+		return errors.New("product is required")
+	}
+	if !i.Type.MovesGoods() && (i.ProductID != nil || i.QuantityToDeliver != 0) {
+		return errors.New("service visits cannot carry a product or quantity")
+	}
+	if i.QuantityToDeliver < 0 {
+		return errors.New("quantity to deliver cannot be negative")
+	}
+	if i.DurationMinutes < MinInvitationDurationMinutes || i.DurationMinutes > MaxInvitationDurationMinutes {
+		return errors.New("duration must be between 15 minutes and 8 hours")
+	}
+	if !i.BookUntil.After(i.BookFrom) {
+		return errors.New("booking window must end after it starts")
+	}
+	return nil
+}
+
+// Duration returns the length of the appointment the invitation offers
+func (i *BookingInvitation) Duration() time.Duration {
+	return time.Duration(i.DurationMinutes) * time.Minute
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// BroadcastAudience selects who an admin broadcast is sent to
+type BroadcastAudience string
+
+const (
+	// BroadcastOperationSuppliers is every supplier that booked at an operation
+	BroadcastOperationSuppliers BroadcastAudience = "operation_suppliers"
+
+	// BroadcastEmployees is every employee
+	BroadcastEmployees BroadcastAudience = "employees"
+
+	// BroadcastSuppliersInRange is the suppliers with appointments in a time range, optionally at
+	// one operation; cancelled appointments don't count
+	BroadcastSuppliersInRange BroadcastAudience = "suppliers_in_range"
+)
+
+// MaxBroadcastRange is the longest time range a suppliers_in_range broadcast may cover
+const MaxBroadcastRange = 92 * 24 * time.Hour
+
+// Broadcast is an ad-hoc message an admin sent to an audience through the notification queue.
+// Subject and body are templates rendered for each recipient.
+type Broadcast struct {
+	BaseModel
+	Audience       BroadcastAudience `gorm:"not null" json:"audience"`
+	OperationID    *uint             `json:"operation_id"`
+	RangeFrom      *time.Time        `json:"from"` // Appointments of suppliers_in_range start in [RangeFrom, RangeTo)
+	RangeTo        *time.Time        `json:"to"`
+	Channel        NotificationType  `gorm:"not null" json:"channel"`
+	Subject        string            `gorm:"not null" json:"subject"`
+	Body           string            `gorm:"type:text;not null" json:"body"`
+	SendAt         time.Time         `gorm:"index" json:"send_at"` // Queued notifications are held until then
+	CreatedByID    uint              `gorm:"not null" json:"created_by_id"`
+	RecipientCount int               `json:"recipient_count"` // Notifications queued
+	FailedCount    int               `json:"failed_count"`    // Recipients whose notification couldn't be queued
+}
+
+// Validate validates a broadcast
+func (b *Broadcast) Validate() error {
+	switch b.Audience {
+	case BroadcastOperationSuppliers:
+		if b.OperationID == nil {
+			return errors.New("operation is required for the operation_suppliers audience")
+		}
+	case BroadcastEmployees:
+	case BroadcastSuppliersInRange:
+		if b.RangeFrom == nil || b.RangeTo == nil {
+			return errors.New("from and to are required for the suppliers_in_range audience")
+		}
+		if !b.RangeTo.After(*b.RangeFrom) {
+			return errors.New("broadcast range must end after it starts")
+		}
+		if b.RangeTo.Sub(*b.RangeFrom) > MaxBroadcastRange {
+			return errors.New("broadcast range can span at most 92 days")
+		}
+	default:
+		return errors.New("invalid broadcast audience: use operation_suppliers, employees or suppliers_in_range")
+	}
+	if b.Channel != NotificationTypeEmail && b.Channel != NotificationTypeSMS {
+		return errors.New("invalid broadcast channel: use email or sms")
+	}
+	if strings.TrimSpace(b.Subject) == "" || strings.TrimSpace(b.Body) == "" {
+		return errors.New("broadcast subject and body are required")
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// MaxCalendarFeedNameLength is the longest name a calendar feed may have
+const MaxCalendarFeedNameLength = 100
+
+// CalendarFeed is a filtered iCalendar feed of appointments a user subscribes to from a calendar
+// app. Calendar apps can't sign in, so every feed has its own secret link; only a hash of the
+// link's token is stored.
+type CalendarFeed struct {
+	BaseModel
+	UserID        uint               `gorm:"not null;index" json:"user_id"`
+	Name          string             `gorm:"not null" json:"name"`
+	TokenHash     string             `gorm:"uniqueIndex;not null" json:"-"`
+	OperationID   *uint              `json:"operation_id"`  // Only this operation's appointments
+	Status        *AppointmentStatus `json:"status"`        // Only appointments in this status
+	Type          *AppointmentType   `json:"type"`          // Only appointments of this type
+	AssignedOnly  bool               `json:"assigned_only"` // Only appointments booked with the user's employee record
+	LastFetchedAt *time.Time         `json:"last_fetched_at"`
+}
+
+// Validate validates a calendar feed
+func (f *CalendarFeed) Validate() error {
+	name := strings.TrimSpace(f.Name)
+	if name == "" {
+		return errors.New("feed name is required")
+	}
+	if len([]rune(name)) > MaxCalendarFeedNameLength {
+		return errors.New("feed name must be at most 100 characters")
+	}
+	if f.Status != nil && !f.Status.IsValid() {
+		return errors.New("invalid appointment status")
+	}
+	if f.Type != nil && !f.Type.IsValid() {
+		return errors.New("invalid appointment type")
+	}
+	return nil
+}
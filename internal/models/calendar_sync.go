@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// CalendarSync records an appointment synced to a user's external calendar and the event created
+// for it there, so later changes update that event rather than adding another one
+type CalendarSync struct {
+	BaseModel
+	UserID          uint      `gorm:"not null;index" json:"user_id"`
+	AppointmentID   uint      `gorm:"not null;uniqueIndex:idx_calendar_syncs_appointment" json:"appointment_id"`
+	Provider        string    `gorm:"not null;uniqueIndex:idx_calendar_syncs_appointment" json:"provider"` // google, outlook or ical
+	ExternalEventID string    `json:"external_event_id"`                                                   // the provider's ID of the event
+	LastSynced      time.Time `json:"last_synced"`
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// CapacityOverride changes how many appointments an operation takes at once in a time band on one
+// date, e.g. fewer on an inventory-count day. It replaces the concurrent limit of the appointment
+// type capacity rules in the band; without a type it caps appointments of every type together.
+type CapacityOverride struct {
+	BaseModel
+	OperationID   uint            `gorm:"not null;index:idx_capacity_overrides_day" json:"operation_id"`
+	Date          time.Time       `gorm:"type:date;not null;index:idx_capacity_overrides_day" json:"date"` // civil date at midnight UTC
+	StartTime     string          `gorm:"not null" json:"start_time"`                                      // Format: "HH:MM"
+	EndTime       string          `gorm:"not null" json:"end_time"`                                        // Format: "HH:MM", "24:00" ends at midnight
+	Type          AppointmentType `json:"type"`                                                            // empty applies to every type
+	MaxConcurrent int             `gorm:"not null" json:"max_concurrent"`                                  // 0 takes no appointments in the band
+	Reason        string          `json:"reason"`
+}
+
+// Validate validates a capacity override
+func (o *CapacityOverride) Validate() error {
+	if !isClockTime(o.StartTime) {
+		return errors.New("start time must be in HH:MM format")
+	}
+	if o.EndTime != "24:00" && !isClockTime(o.EndTime) {
+		return errors.New("end time must be in HH:MM format")
+	}
+	if o.EndTime <= o.StartTime {
+		return errors.New("end time must be after start time")
+	}
+	if o.Type != "" && !o.Type.IsValid() {
+		return errors.New("invalid appointment type")
+	}
+	if o.MaxConcurrent < 0 {
+		return errors.New("capacity limits cannot be negative")
+	}
+	return nil
+}
+
+// OverlapsWith reports whether two overrides limit the same appointments at the same time
+func (o *CapacityOverride) OverlapsWith(other *CapacityOverride) bool {
+	if o.Type != other.Type {
+		return false
+	}
+	return o.StartTime < other.EndTime && other.StartTime < o.EndTime
+}
+
+// AppliesToType reports whether the override limits appointments of a type
+func (o *CapacityOverride) AppliesToType(appointmentType AppointmentType) bool {
+	return o.Type == "" || o.Type == appointmentType
+}
+
+// DateKey returns the override's date as "YYYY-MM-DD"
+func (o *CapacityOverride) DateKey() string {
+	return o.Date.UTC().Format(BlackoutDateLayout)
+}
+
+// CapacityChange records an edit of an operation's capacity on a date: the bands before and after
+// and how many booked bands ended up over capacity
+type CapacityChange struct {
+	BaseModel
+	OperationID uint      `gorm:"not null;index:idx_capacity_changes_day" json:"operation_id"`
+	Date        time.Time `gorm:"type:date;not null;index:idx_capacity_changes_day" json:"date"` // civil date at midnight UTC
+	ChangedByID uint      `json:"changed_by_id"`
+	Previous    string    `gorm:"type:text" json:"previous"` // JSON array of the bands before the change
+	Current     string    `gorm:"type:text" json:"current"`  // JSON array of the bands after it
+	Conflicts   int       `json:"conflicts"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// OperationDefaultSlot is one weekly slot of the schedule an operation gives new employees
+type OperationDefaultSlot struct {
+	BaseModel
+	OperationID uint   `gorm:"not null;index" json:"operation_id"`
+	DayOfWeek   int    `gorm:"not null" json:"day_of_week"` // 0=Sunday, 1=Monday, etc.
+	StartTime   string `gorm:"not null" json:"start_time"`  // Format: "HH:MM"
+	EndTime     string `gorm:"not null" json:"end_time"`    // Format: "HH:MM"
+}
+
+// Validate validates a default schedule slot
+func (s *OperationDefaultSlot) Validate() error {
+	if s.DayOfWeek < 0 || s.DayOfWeek > 6 {
+		return errors.New("day of week must be between 0 and 6")
+	}
+	start, err := time.Parse("15:04", s.StartTime)
+	if err != nil {
+		return errors.New("start time must be in HH:MM format")
+	}
+	end, err := time.Parse("15:04", s.EndTime)
+	if err != nil {
+		return errors.New("end time must be in HH:MM format")
+	}
+	if !end.After(start) {
+		return errors.New("end time must be after start time")
+	}
+	return nil
+}
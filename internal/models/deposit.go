@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// DepositStatus defines where a booking deposit is in its payment
+type DepositStatus string
+
+const (
+	// DepositPending is a deposit the supplier hasn't paid yet; its appointment stays reserved
+	DepositPending DepositStatus = "pending"
+
+	// DepositPaid is a deposit the payment provider confirmed
+	DepositPaid DepositStatus = "paid"
+
+	// DepositCancelled is a deposit nobody paid before its hold expired, or whose payment was
+	// cancelled at the provider
+	DepositCancelled DepositStatus = "cancelled"
+)
+
+// Deposit is the amount a supplier pays to keep an appointment at an operation that charges
+// one. The amount and currency are copied from the operation's settings when the appointment is
+// booked, so later changes don't affect it.
+type Deposit struct {
+	BaseModel
+	AppointmentID  uint            `gorm:"not null;uniqueIndex" json:"appointment_id"`
+	SupplierID     uint            `gorm:"not null;index" json:"supplier_id"`  // copied from the appointment
+	OperationID    uint            `gorm:"not null;index" json:"operation_id"` // copied from the appointment
+	Amount         decimal.Decimal `gorm:"type:decimal(10,2);not null" json:"amount"`
+	Currency       string          `gorm:"type:char(3);not null" json:"currency"` // ISO 4217 code
+	Provider       string          `gorm:"not null;uniqueIndex:idx_deposits_payment" json:"provider"`
+	PaymentID      string          `gorm:"not null;uniqueIndex:idx_deposits_payment" json:"payment_id"` // the provider's ID of the payment
+	ClientSecret   string          `json:"client_secret,omitempty"`                                     // lets the supplier's client complete the payment
+	Status         DepositStatus   `gorm:"not null;index" json:"status"`
+	ExpiresAt      time.Time       `gorm:"index" json:"expires_at"` // the appointment is cancelled unless paid by then
+	PaidAt         *time.Time      `json:"paid_at"`
+	CancelledAt    *time.Time      `json:"cancelled_at"`
+	FailureMessage string          `json:"failure_message"` // why the last attempt to pay failed
+}
@@ -0,0 +1,23 @@
+package models
+
+import "strings"
+
+// NormalizeEmail returns the canonical form of an email address, in which addresses are stored
+// and looked up: trimmed and lowercased. With stripPlusAddress, a +tag in the local part is
+// removed too, so "Ana+Invoices@Example.com" and "ana@example.com" are the same account.
+func NormalizeEmail(email string, stripPlusAddress bool) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if !stripPlusAddress {
+		return email
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return email
+	}
+	local, domain := email[:at], email[at:]
+	if plus := strings.Index(local, "+"); plus > 0 {
+		local = local[:plus]
+	}
+	return local + domain
+}
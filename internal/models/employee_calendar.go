@@ -0,0 +1,55 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Work calendar providers employees can connect
+const (
+	CalendarProviderGoogle    = "google"
+	CalendarProviderMicrosoft = "microsoft"
+)
+
+// EmployeeCalendar is the work calendar an employee connected so their meetings count as
+// unavailable. Only busy periods are read from it; the tokens are kept to read it again and are
+// never returned.
+type EmployeeCalendar struct {
+	ID              uint       `gorm:"primaryKey" json:"-"`
+	EmployeeID      uint       `gorm:"not null;uniqueIndex" json:"employee_id"`
+	Provider        string     `gorm:"not null" json:"provider"` // google or microsoft
+	CalendarID      string     `json:"calendar_id"`              // Google calendar ID, "primary" when empty, or the Microsoft 365 mailbox
+	AccessToken     string     `gorm:"type:text" json:"-"`
+	RefreshToken    string     `gorm:"type:text" json:"-"`
+	TokenExpiry     time.Time  `json:"-"`
+	LastRefreshedAt *time.Time `json:"last_refreshed_at"`
+	LastError       string     `gorm:"type:text" json:"last_error,omitempty"` // why the last refresh failed; busy times read before are kept
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// Validate validates a calendar connection
+func (c *EmployeeCalendar) Validate() error {
+	c.Provider = strings.ToLower(strings.TrimSpace(c.Provider))
+	c.CalendarID = strings.TrimSpace(c.CalendarID)
+	if c.Provider != CalendarProviderGoogle && c.Provider != CalendarProviderMicrosoft {
+		return errors.New("calendar provider must be google or microsoft")
+	}
+	if c.Provider == CalendarProviderMicrosoft && c.CalendarID == "" {
+		return errors.New("microsoft calendars need the mailbox address as calendar_id")
+	}
+	if c.AccessToken == "" && c.RefreshToken == "" {
+		return errors.New("calendar access token or refresh token is required")
+	}
+	return nil
+}
+
+// EmployeeBusyTime is a period an employee's connected calendar shows them busy, read again each
+// time the calendar is refreshed
+type EmployeeBusyTime struct {
+	ID         uint      `gorm:"primaryKey" json:"-"`
+	EmployeeID uint      `gorm:"not null;index:idx_employee_busy_times_range,priority:1" json:"employee_id"`
+	StartsAt   time.Time `gorm:"not null;index:idx_employee_busy_times_range,priority:2" json:"starts_at"`
+	EndsAt     time.Time `gorm:"not null" json:"ends_at"`
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// MaxFeeDisputeLength is how long a supplier's reason for disputing a fee may be
+const MaxFeeDisputeLength = 2000
+
+// FeeKind defines what a supplier is charged for
+type FeeKind string
+
+const (
+	// FeeNoShow is charged when a supplier misses a confirmed appointment
+	FeeNoShow FeeKind = "no_show"
+)
+
+// Label returns the human-readable name of the fee kind
+func (k FeeKind) Label() string {
+	switch k {
+	case FeeNoShow:
+		return "No-show"
+	}
+	return string(k)
+}
+
+// FeeStatus defines where a fee is in the dispute workflow
+type FeeStatus string
+
+const (
+	// FeeCharged is a fee the supplier owes; it is included in the billing export
+	FeeCharged FeeStatus = "charged"
+
+	// FeeDisputed is a fee the supplier contested; it is left out of the billing export until an
+	// admin upholds or waives it
+	FeeDisputed FeeStatus = "disputed"
+
+	// FeeWaived is a fee an admin cancelled
+	FeeWaived FeeStatus = "waived"
+)
+
+// Fee is an amount a supplier is charged for an appointment. The amount and currency are copied
+// from the operation's settings when the fee is created, so later changes don't affect it.
+type Fee struct {
+	BaseModel
+	AppointmentID uint            `gorm:"not null;uniqueIndex:idx_fees_appointment_kind" json:"appointment_id"`
+	Kind          FeeKind         `gorm:"not null;uniqueIndex:idx_fees_appointment_kind" json:"kind"`
+	SupplierID    uint            `gorm:"not null;index" json:"supplier_id"`  // copied from the appointment
+	OperationID   uint            `gorm:"not null;index" json:"operation_id"` // copied from the appointment
+	Amount        decimal.Decimal `gorm:"type:decimal(10,2);not null" json:"amount"`
+	Currency      string          `gorm:"type:char(3);not null" json:"currency"` // ISO 4217 code
+	Status        FeeStatus       `gorm:"not null;index" json:"status"`
+	DisputeReason string          `gorm:"type:text" json:"dispute_reason"`
+	DisputedAt    *time.Time      `json:"disputed_at"`
+	ResolvedAt    *time.Time      `json:"resolved_at"`    // when an admin upheld or waived the fee
+	ResolvedByID  *uint           `json:"resolved_by_id"` // admin who upheld or waived it
+	Resolution    string          `gorm:"type:text" json:"resolution"`
+}
+
+// Validate validates a fee
+func (f *Fee) Validate() error {
+	if f.AppointmentID == 0 {
+		return errors.New("appointment is required")
+	}
+	if f.Kind != FeeNoShow {
+		return errors.New("invalid fee kind")
+	}
+	if !f.Amount.IsPositive() {
+		return errors.New("fee amount must be positive")
+	}
+	if _, err := CurrencyMinorUnits(f.Currency); err != nil {
+		return err
+	}
+	if len(f.DisputeReason) > MaxFeeDisputeLength {
+		return errors.New("dispute reason must be at most 2000 characters")
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package models
+
+import "errors"
+
+// Response fields whose visibility can be configured per role and operation
+const (
+	FieldSupplierCNPJ  = "supplier.cnpj"
+	FieldSupplierEmail = "supplier.contact.email"
+	FieldSupplierPhone = "supplier.contact.phone"
+	FieldEmployeeEmail = "employee.contact.email"
+	FieldEmployeePhone = "employee.contact.phone"
+	FieldProductPrice  = "product.price"
+	FieldBilling       = "billing"
+)
+
+// MaskableFields lists the fields visibility rules may hide
+var MaskableFields = []string{
+	FieldSupplierCNPJ,
+	FieldSupplierEmail,
+	FieldSupplierPhone,
+	FieldEmployeeEmail,
+	FieldEmployeePhone,
+	FieldProductPrice,
+	FieldBilling,
+}
+
+// FieldVisibilityRule hides a response field from a role, or shows it again, at one operation or,
+// without an operation, at every operation. Operation rules override global ones, which override
+// the built-in defaults.
+type FieldVisibilityRule struct {
+	BaseModel
+	OperationID *uint  `gorm:"index" json:"operation_id"`
+	Role        string `gorm:"not null" json:"role"` // "admin", "employee" or "supplier"
+	Field       string `gorm:"not null" json:"field"`
+	Hidden      bool   `gorm:"not null" json:"hidden"`
+}
+
+// Validate validates a visibility rule
+func (r *FieldVisibilityRule) Validate() error {
+	if r.Role != "admin" && r.Role != "employee" && r.Role != "supplier" {
+		return errors.New("role must be admin, employee or supplier")
+	}
+	for _, field := range MaskableFields {
+		if r.Field == field {
+			return nil
+		}
+	}
+	return errors.New("unknown visibility field")
+}
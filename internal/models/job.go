@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// JobSchedule overrides the configured schedule of a background job. Rows are written through
+// the admin API; every replica applies them at startup and then checks for changes each minute.
+type JobSchedule struct {
+	Name        string    `gorm:"primaryKey" json:"name"`
+	Schedule    string    `json:"schedule"` // cron expression, descriptor or interval; empty keeps the configured one
+	Enabled     bool      `gorm:"not null;default:true" json:"enabled"`
+	UpdatedByID *uint     `json:"updated_by_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// JobRun is one scheduled run of a background job. The unique job and time pair is how replicas
+// agree on who runs it: the replica that inserts the row does.
+type JobRun struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	Job          string     `gorm:"not null;uniqueIndex:idx_job_runs_job_scheduled" json:"job"`
+	ScheduledFor time.Time  `gorm:"not null;uniqueIndex:idx_job_runs_job_scheduled" json:"scheduled_for"`
+	Instance     string     `gorm:"not null" json:"instance"`
+	StartedAt    time.Time  `gorm:"not null" json:"started_at"`
+	FinishedAt   *time.Time `json:"finished_at"`
+	DurationMs   int64      `json:"duration_ms"`
+	Error        string     `gorm:"type:text" json:"error,omitempty"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Formats appointment labels can be printed in
+const (
+	LabelFormatZPL = "zpl" // Zebra Programming Language, understood by the dock label printers
+)
+
+// LabelTemplate is how an operation lays out the labels printed for an appointment's pallets.
+// Operations without one use the built-in layout of the format.
+type LabelTemplate struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	OperationID uint      `gorm:"not null;uniqueIndex:idx_label_templates_operation_format" json:"operation_id"`
+	Format      string    `gorm:"not null;uniqueIndex:idx_label_templates_operation_format" json:"format"`
+	Body        string    `gorm:"type:text;not null" json:"body"` // Go template producing the label, run once per label
+	UpdatedByID *uint     `json:"updated_by_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -29,12 +29,14 @@ type User struct {
 // Supplier represents a supplier entity
 type Supplier struct {
 	BaseModel
-	UserID      uint   `json:"user_id"`
-	User        User   `json:"user"`
-	CompanyName string `json:"company_name"`
-	CNPJ        string `gorm:"uniqueIndex" json:"cnpj"`
-	Address     string `json:"address"`
-	Category    string `json:"category"`
+	UserID      uint     `json:"user_id"`
+	User        User     `json:"user"`
+	CompanyName string   `json:"company_name"`
+	CNPJ        string   `gorm:"uniqueIndex" json:"cnpj"`
+	Address     string   `json:"address"`
+	Latitude    *float64 `json:"latitude"` // Geocoded position of the address
+	Longitude   *float64 `json:"longitude"`
+	Category    string   `json:"category"`
 }
 
 // Employee represents an employee of the company
@@ -48,34 +50,6 @@ type Employee struct {
 	Operation      string `json:"operation"` // Which operation (branch/location) the employee belongs to
 }
 
-// Product represents a product that can be delivered
-type Product struct {
-	BaseModel
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	SKU         string  `gorm:"uniqueIndex" json:"sku"`
-	Category    string  `json:"category"`
-	Price       float64 `json:"price"`
-	SupplierID  uint    `json:"supplier_id"`
-	Supplier    Supplier `json:"supplier"`
-	Active      bool    `gorm:"default:true" json:"active"`
-}
-
-// Operation represents a company location/branch
-type Operation struct {
-	BaseModel
-	Name         string     `json:"name"`
-	Address      string     `json:"address"`
-	City         string     `json:"city"`
-	State        string     `json:"state"`
-	ZipCode      string     `json:"zip_code"`
-	ContactPhone string     `json:"contact_phone"`
-	Active       bool       `gorm:"default:true" json:"active"`
-	OpeningHour  time.Time  `json:"opening_hour"`
-	ClosingHour  time.Time  `json:"closing_hour"`
-	Employees    []Employee `gorm:"many2many:operation_employees" json:"employees"`
-}
-
 // AppointmentStatus represents the status of an appointment
 type AppointmentStatus string
 
@@ -85,8 +59,26 @@ const (
 	StatusCancelled AppointmentStatus = "cancelled"
 	StatusCompleted AppointmentStatus = "completed"
 	StatusRescheduled AppointmentStatus = "rescheduled"
+	StatusPartiallyCompleted AppointmentStatus = "partially_completed"
+	StatusNoShow AppointmentStatus = "no_show"
+	StatusReserved AppointmentStatus = "reserved" // Held for the supplier until the operation's deposit is paid
 )
 
+// IsValid reports whether the appointment status is known
+func (s AppointmentStatus) IsValid() bool {
+	switch s {
+	case StatusPending, StatusConfirmed, StatusCancelled, StatusCompleted, StatusRescheduled,
+		StatusPartiallyCompleted, StatusNoShow, StatusReserved:
+		return true
+	}
+	return false
+}
+
+// IsFinal reports whether an appointment in this status can no longer change
+func (s AppointmentStatus) IsFinal() bool {
+	return s == StatusCancelled || s == StatusCompleted || s == StatusPartiallyCompleted || s == StatusNoShow
+}
+
 // Appointment represents a scheduled appointment between a supplier and an employee
 type Appointment struct {
 	BaseModel
@@ -96,7 +88,9 @@ type Appointment struct {
 	Employee        Employee         `json:"employee"`
 	OperationID     uint             `json:"operation_id"`
 	Operation       Operation        `json:"operation"`
-	ProductID       uint             `json:"product_id"`
+	BookingCode     string           `gorm:"uniqueIndex:idx_appointments_booking_code,where:booking_code <> ''" json:"booking_code"` // Per-operation sequential code, e.g. SP01-2025-00423
+	Type            AppointmentType  `gorm:"not null;default:'delivery';index" json:"type"`
+	ProductID       *uint            `json:"product_id"` // Not set for service visits
 	Product         Product          `json:"product"`
 	ScheduledStart  time.Time        `json:"scheduled_start"`
 	ScheduledEnd    time.Time        `json:"scheduled_end"`
@@ -107,6 +101,22 @@ type Appointment struct {
 	CancelledAt     *time.Time       `json:"cancelled_at"`
 	CompletedAt     *time.Time       `json:"completed_at"`
 	CancellationReason string        `json:"cancellation_reason"`
+	CheckedInAt     *time.Time       `json:"checked_in_at"`
+	ReceivedQuantity *int            `json:"received_quantity"` // Set at completion; may be less than scheduled
+	FollowUpOfID    *uint            `gorm:"index" json:"follow_up_of_id,omitempty"` // Appointment whose remainder this one delivers
+	LinkedInboundID *uint            `gorm:"index" json:"linked_inbound_id,omitempty"` // Inbound delivery a cross-dock pickup depends on
+	RecurringAppointmentID *uint     `gorm:"index" json:"recurring_appointment_id,omitempty"` // Series the appointment was generated from
+	ArrivingNotifiedAt *time.Time    `json:"arriving_notified_at"` // When the dock team was told the driver is close
+	EstimatedArrival *time.Time      `json:"estimated_arrival"` // Latest ETA declared by the supplier
+	AutoCompletedAt *time.Time       `json:"auto_completed_at"` // Set when completed by the overdue job rather than by staff
+	OverdueFlaggedAt *time.Time      `gorm:"index" json:"overdue_flagged_at"` // When it was flagged as left open after its end
+	ExpiryWarnedAt  *time.Time       `json:"expiry_warned_at"` // When the participants were warned it expires unless confirmed
+	ExpiredAt       *time.Time       `json:"expired_at"` // Set when cancelled by the pending expiry job because nobody confirmed it
+	CostCenter      string           `gorm:"index" json:"cost_center"`  // Cost center dock time is charged to, from the billing code list
+	BillingCode     string           `json:"billing_code"` // Billing code invoices reference, from the billing code list
+	ExternalRef     string           `gorm:"index" json:"external_ref"` // Reference of the appointment in a partner's system, e.g. the ERP's receipt number
+	PurchaseOrder   string           `gorm:"index" json:"purchase_order"` // Purchase order the delivery fulfils, pushed by the partner
+	Visitors        []AppointmentVisitor `json:"visitors,omitempty"`
 }
 
 // Validate validates an appointment
@@ -120,7 +130,16 @@ func (a *Appointment) Validate() error {
 	if a.OperationID == 0 {
 		return errors.New("operation is required")
 	}
-	if a.ProductID == 0 {
+	if a.Type == "" {
+		a.Type = AppointmentTypeDelivery
+	}
+	if !a.Type.IsValid() {
+		return errors.New("invalid appointment type")
+	}
+	if a.LinkedInboundID != nil && a.Type != AppointmentTypePickup {
+		return errors.New("only pickups can be linked to an inbound delivery")
+	}
+	if a.Type.MovesGoods() && (a.ProductID == nil || *a.ProductID == 0) {
 		return errors.New("product is required")
 	}
 	if a.ScheduledStart.IsZero() {
@@ -132,9 +151,12 @@ func (a *Appointment) Validate() error {
 	if a.ScheduledStart.After(a.ScheduledEnd) {
 		return errors.New("scheduled start time must be before scheduled end time")
 	}
-	if a.QuantityToDeliver <= 0 {
+	if a.Type.MovesGoods() && a.QuantityToDeliver <= 0 {
 		return errors.New("quantity to deliver must be greater than zero")
 	}
+	if a.QuantityToDeliver < 0 {
+		return errors.New("quantity to deliver cannot be negative")
+	}
 	
 	// Check if the appointment is at least 1 hour
 	if a.ScheduledEnd.Sub(a.ScheduledStart) < time.Hour {
@@ -144,16 +166,84 @@ func (a *Appointment) Validate() error {
 	return nil
 }
 
-// AvailabilitySlot represents a time slot when an employee is available for appointments
-type AvailabilitySlot struct {
-	BaseModel
-	EmployeeID  uint      `json:"employee_id"`
-	Employee    Employee  `json:"employee"`
-	OperationID uint      `json:"operation_id"`
-	Operation   Operation `json:"operation"`
-	StartTime   time.Time `json:"start_time"`
-	EndTime     time.Time `json:"end_time"`
-	DayOfWeek   int       `json:"day_of_week"` // 0=Sunday, 1=Monday, etc.
-	IsRecurring bool      `gorm:"default:true" json:"is_recurring"`
+// ValidateAt validates an appointment being booked at now, additionally requiring it to start
+// in the future. Passing the reference time keeps the lead-time check deterministic.
+func (a *Appointment) ValidateAt(now time.Time) error {
+	if err := a.Validate(); err != nil {
+		return err
+	}
+	if a.ScheduledStart.Before(now) {
+		return errors.New("appointment must be scheduled for a future date")
+	}
+	return nil
 }
 
+// All returns every model the database is migrated with, in migration order
+func All() []interface{} {
+	return []interface{}{
+		&User{},
+		&Supplier{},
+		&Employee{},
+		&EmployeeSkill{},
+		&SkillRequirement{},
+		&Product{},
+		&Operation{},
+		&Appointment{},
+		&AvailabilitySlot{},
+		&NotificationTemplate{},
+		&Notification{},
+		&NotificationQueue{},
+		&NotificationPause{},
+		&NotificationPreference{},
+		&SupplierDocument{},
+		&SupplierDocumentRequirement{},
+		&AppointmentVisitor{},
+		&AppointmentTypeCapacity{},
+		&CapacityOverride{},
+		&CapacityChange{},
+		&AppointmentLocationPing{},
+		&AppointmentDelay{},
+		&AppointmentFeedback{},
+		&AppointmentIncident{},
+		&IncidentAttachment{},
+		&ProofOfDelivery{},
+		&ProofOfDeliveryAttachment{},
+		&BillingCode{},
+		&Fee{},
+		&OperationBlackout{},
+		&WaitlistEntry{},
+		&SlotWatch{},
+		&SlotOpening{},
+		&ShiftHandover{},
+		&ShiftHandoverMention{},
+		&AvailabilityException{},
+		&OperationDefaultSlot{},
+		&BookingSequence{},
+		&BookingInvitation{},
+		&AppointmentWatcher{},
+		&AppointmentMute{},
+		&AppointmentStatusEvent{},
+		&FieldVisibilityRule{},
+		&PartnerCredential{},
+		&PartnerNonce{},
+		&BIExportRun{},
+		&JobSchedule{},
+		&JobRun{},
+		&APIUsage{},
+		&Setting{},
+		&LabelTemplate{},
+		&CalendarFeed{},
+		&Broadcast{},
+		&Deposit{},
+		&CalendarSync{},
+		&BookingDenial{},
+		&AppointmentDraft{},
+		&EmployeeCalendar{},
+		&EmployeeBusyTime{},
+		&Region{},
+		&RegionOperation{},
+		&RegionManager{},
+		&TemplateExperiment{},
+		&TokenRevocation{},
+	}
+}
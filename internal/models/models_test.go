@@ -0,0 +1,61 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppointmentValidateAtLeadTime(t *testing.T) {
+	frozen := clock.NewFrozen(time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name    string
+		start   time.Duration // after the frozen time
+		wantErr string
+	}{
+		{"starts later", time.Hour, ""},
+		{"starts now", 0, ""},
+		{"started a second ago", -time.Second, "appointment must be scheduled for a future date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := frozen.Now().Add(tt.start)
+			appointment := &Appointment{
+				SupplierID:     1,
+				EmployeeID:     2,
+				OperationID:    3,
+				Type:           AppointmentTypeServiceVisit,
+				ScheduledStart: start,
+				ScheduledEnd:   start.Add(time.Hour),
+			}
+
+			err := appointment.ValidateAt(frozen.Now())
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAppointmentValidateAtFollowsClock(t *testing.T) {
+	frozen := clock.NewFrozen(time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC))
+	start := frozen.Now().Add(30 * time.Minute)
+	appointment := &Appointment{
+		SupplierID:     1,
+		EmployeeID:     2,
+		OperationID:    3,
+		Type:           AppointmentTypeServiceVisit,
+		ScheduledStart: start,
+		ScheduledEnd:   start.Add(time.Hour),
+	}
+
+	require.NoError(t, appointment.ValidateAt(frozen.Now()))
+	frozen.Advance(time.Hour)
+	require.Error(t, appointment.ValidateAt(frozen.Now()))
+}
@@ -61,6 +61,45 @@ const (
 	
 	// EventAppointmentReminder is triggered to remind about upcoming appointments
 	EventAppointmentReminder NotificationEvent = "appointment_reminder"
+	
+	// EventSupplierDocumentExpiring is triggered when a supplier compliance document is about to expire
+	EventSupplierDocumentExpiring NotificationEvent = "supplier_document_expiring"
+
+	// EventAppointmentArriving is triggered when a driver enters the approach radius of the operation
+	EventAppointmentArriving NotificationEvent = "appointment_arriving"
+
+	// EventAppointmentDelayed is triggered when a supplier declares a delay
+	EventAppointmentDelayed NotificationEvent = "appointment_delayed"
+
+	// EventFeedbackRequested is triggered when a completed appointment is opened for feedback
+	EventFeedbackRequested NotificationEvent = "appointment_feedback_requested"
+
+	// EventAppointmentOverdue is triggered when a confirmed appointment is left open after it ends
+	EventAppointmentOverdue NotificationEvent = "appointment_overdue"
+
+	// EventAppointmentExpiring is triggered when a pending appointment is about to be cancelled for lack of confirmation
+	EventAppointmentExpiring NotificationEvent = "appointment_expiring"
+
+	// EventBookingInvitation is triggered when an employee invites a supplier to book through a link
+	EventBookingInvitation NotificationEvent = "booking_invitation"
+
+	// EventFeeCharged is triggered when a supplier is charged a fee, such as for a no-show
+	EventFeeCharged NotificationEvent = "fee_charged"
+
+	// EventWaitlistSlotOpened is triggered when a cancellation frees a slot for a waitlisted supplier
+	EventWaitlistSlotOpened NotificationEvent = "waitlist_slot_opened"
+
+	// EventSlotOpened is triggered when capacity frees up at an operation and date a supplier watches
+	EventSlotOpened NotificationEvent = "slot_opened"
+
+	// EventShiftHandover is triggered when a supervisor leaves a handover note for the incoming shift
+	EventShiftHandover NotificationEvent = "shift_handover"
+
+	// EventBroadcast is triggered when an admin broadcasts a message to an audience
+	EventBroadcast NotificationEvent = "broadcast"
+
+	// EventCapacityWarning is triggered when a booking takes a capacity limit of an operation above the warning threshold
+	EventCapacityWarning NotificationEvent = "capacity_warning"
 )
 
 // NotificationRecipientType defines the type of recipient
@@ -75,6 +114,9 @@ const (
 	
 	// RecipientAdmin indicates the notification is for an admin
 	RecipientAdmin NotificationRecipientType = "admin"
+	
+	// RecipientWatcher indicates a copy for a user watching the appointment; the recipient ID is the user ID
+	RecipientWatcher NotificationRecipientType = "watcher"
 )
 
 // Notification represents a notification to be sent
@@ -91,7 +133,7 @@ type Notification struct {
 	// Content information
 	Subject         string                 `json:"subject" gorm:"not null"`
 	Body            string                 `json:"body" gorm:"not null;type:text"`
-	TemplateID      *string                `json:"template_id"`
+	TemplateID      *uint                  `json:"template_id"`
 	TemplateData    string                 `json:"template_data" gorm:"type:text"` // JSON string of template variables
 	
 	// Related resources
@@ -105,6 +147,13 @@ type Notification struct {
 	ErrorMessage    *string                `json:"error_message"`
 	RetryCount      int                    `json:"retry_count" gorm:"default:0"`
 	MaxRetries      int                    `json:"max_retries" gorm:"default:3"`
+	Priority        int                    `json:"priority" gorm:"default:1"` // Queue priority, selects the retry policy
+	MergedCount     int                    `json:"merged_count" gorm:"default:0"` // Duplicates collapsed into this notification
+	
+	// Template experiment the notification was sent in, and when its email was first opened
+	ExperimentID    *uint                  `json:"experiment_id" gorm:"index"`
+	Variant         string                 `json:"variant"`
+	OpenedAt        *time.Time             `json:"opened_at"`
 	
 	// Metadata
 	Metadata        string                 `json:"metadata" gorm:"type:text"` // JSON string for additional data
@@ -131,6 +180,11 @@ type NotificationTemplate struct {
 	// Status
 	IsActive        bool                   `json:"is_active" gorm:"default:true"`
 	
+	// Built-in templates installed at startup. Upgrades update system templates to newer
+	// defaults; saving a template through the config import makes it a custom one.
+	IsSystem        bool                   `json:"is_system" gorm:"default:false"`
+	SystemVersion   int                    `json:"system_version"`
+	
 	// Variables used in the template, stored as JSON array string
 	Variables       string                 `json:"variables" gorm:"type:text"`
 }
@@ -155,6 +209,10 @@ type NotificationPreference struct {
 	Email           string                 `json:"email"`
 	PhoneNumber     string                 `json:"phone_number"`
 	
+	// Localization
+	Locale          string                 `json:"locale" gorm:"default:'en-US'"` // e.g. en-US, pt-BR, es-ES
+	Timezone        string                 `json:"timezone"` // IANA name, e.g. America/Sao_Paulo; empty uses the employee's operation's, or UTC
+	
 	// Reminder settings
 	ReminderHours   int                    `json:"reminder_hours" gorm:"default:24"` // Hours before appointment to send reminder
 }
@@ -164,8 +222,8 @@ type NotificationQueue struct {
 	gorm.Model
 	
 	// Queue information
-	QueueName       string                 `json:"queue_name" gorm:"not null"`
-	Priority        int                    `json:"priority" gorm:"default:1"` // Higher number = higher priority
+	QueueName       string                 `json:"queue_name" gorm:"not null;index:idx_queue_pending,priority:1"`
+	Priority        int                    `json:"priority" gorm:"default:1;index:idx_queue_pending,priority:3"` // Higher number = higher priority
 	
 	// Notification relationship
 	NotificationID  uint                   `json:"notification_id" gorm:"not null"`
@@ -173,8 +231,28 @@ type NotificationQueue struct {
 	
 	// Processing status
 	ProcessedAt     *time.Time             `json:"processed_at"`
-	Status          NotificationStatus     `json:"status" gorm:"not null"`
+	Status          NotificationStatus     `json:"status" gorm:"not null;index:idx_queue_pending,priority:2"`
 	LockedUntil     *time.Time             `json:"locked_until"` // For distributed processing
 	ProcessorID     *string                `json:"processor_id"` // ID of the worker processing this notification
 }
 
+
+// NotificationPause represents an admin-controlled maintenance window during which
+// non-critical notifications are held in the queue
+type NotificationPause struct {
+	gorm.Model
+	
+	// Window information
+	Reason          string                 `json:"reason" gorm:"not null"`
+	StartedAt       time.Time              `json:"started_at" gorm:"not null"`
+	EndsAt          *time.Time             `json:"ends_at"` // Optional automatic release time
+	CreatedByID     uint                   `json:"created_by_id"`
+	
+	// Events delivered despite the pause, stored as comma separated list
+	CriticalEvents  string                 `json:"critical_events"`
+	
+	// Release information
+	ReleasedAt      *time.Time             `json:"released_at"`
+	ReleasedByID    *uint                  `json:"released_by_id"` // Nil when released automatically
+	CollapsedCount  int                    `json:"collapsed_count" gorm:"default:0"`
+}
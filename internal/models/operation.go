@@ -3,9 +3,12 @@ package models
 import (
     "time"
     "errors"
+
+    "github.com/shopspring/decimal"
 )
 
-// Operation represents a company location or branch
+// Operation represents a company location or branch. Services call Validate before saving;
+// the check constraints keep the numeric limits and modes sane for writes that bypass them.
 type Operation struct {
     ID              uint      `json:"id" gorm:"primaryKey"`
     Name            string    `json:"name" gorm:"not null"`
@@ -21,11 +24,83 @@ type Operation struct {
     Manager         Employee  `json:"manager" gorm:"foreignKey:ManagerID"`
     OpeningTime     string    `json:"opening_time" gorm:"not null;default:'08:00'"`
     ClosingTime     string    `json:"closing_time" gorm:"not null;default:'18:00'"`
+    Timezone        string    `json:"timezone" gorm:"not null;default:'America/Sao_Paulo'"` // IANA name used to format local times
+    MaxVisitorsPerAppointment int `json:"max_visitors_per_appointment" gorm:"not null;default:2;check:chk_operations_max_visitors,max_visitors_per_appointment >= 0"` // Extra people allowed per delivery
+    Latitude        *float64  `json:"latitude"`  // Geocoded position of the address, used for geofencing
+    Longitude       *float64  `json:"longitude"`
+    PortalURL       string    `json:"portal_url"` // Portal domain links for this operation point at; empty uses the default portal
+    AutoCompleteMode AutoCompleteMode `json:"auto_complete_mode" gorm:"not null;default:'flag';check:chk_operations_auto_complete_mode,auto_complete_mode IN ('off','flag','complete')"` // What happens to confirmed appointments left open after they end
+    AutoCompleteAfterHours int `json:"auto_complete_after_hours" gorm:"not null;default:24;check:chk_operations_auto_complete_after,auto_complete_after_hours >= 0"` // Hours after the scheduled end before they are handled
+    PendingExpiryHours int `json:"pending_expiry_hours" gorm:"not null;default:0;check:chk_operations_pending_expiry,pending_expiry_hours >= 0"` // Pending appointments not confirmed this many hours before they start are cancelled; 0 keeps them pending
+    MaxConcurrentPerSupplier int `json:"max_concurrent_per_supplier" gorm:"not null;default:0;check:chk_operations_max_per_supplier,max_concurrent_per_supplier >= 0"` // Overlapping appointments one supplier may hold; 0 means unlimited
+    SupplierLimitMode SupplierLimitMode `json:"supplier_limit_mode" gorm:"not null;default:'block';check:chk_operations_supplier_limit_mode,supplier_limit_mode IN ('block','warn')"` // Whether bookings over the supplier limit are refused or only warned about
+    NoShowFee       decimal.Decimal `json:"no_show_fee" gorm:"type:decimal(10,2);not null;default:0"` // Charged to suppliers whose confirmed appointment is marked no-show; 0 charges nothing
+    NoShowFeeCurrency string  `json:"no_show_fee_currency" gorm:"type:char(3);not null;default:'BRL'"` // ISO 4217 code of the no-show fee
+    DepositAmount   decimal.Decimal `json:"deposit_amount" gorm:"type:decimal(10,2);not null;default:0"` // Suppliers pay it for their booking to be reserved until paid; 0 charges no deposit
+    DepositCurrency string    `json:"deposit_currency" gorm:"type:char(3);not null;default:'BRL'"` // ISO 4217 code of the deposit
+    ImportHolidays  bool      `json:"import_holidays" gorm:"not null;default:false"` // Close on the public holidays of the operation's state, imported from the holiday provider
     Active          bool      `json:"active" gorm:"default:true"`
     CreatedAt       time.Time `json:"created_at"`
     UpdatedAt       time.Time `json:"updated_at"`
 }
 
+// AutoCompleteMode defines what happens to confirmed appointments nobody closed
+type AutoCompleteMode string
+
+const (
+    // AutoCompleteOff leaves overdue appointments alone
+    AutoCompleteOff AutoCompleteMode = "off"
+
+    // AutoCompleteFlag marks overdue appointments for review and notifies the employee
+    AutoCompleteFlag AutoCompleteMode = "flag"
+
+    // AutoCompleteComplete completes overdue appointments with the scheduled quantity
+    AutoCompleteComplete AutoCompleteMode = "complete"
+)
+
+// IsValid reports whether the auto-complete mode is known
+func (m AutoCompleteMode) IsValid() bool {
+    switch m {
+    case AutoCompleteOff, AutoCompleteFlag, AutoCompleteComplete:
+        return true
+    }
+    return false
+}
+
+// SupplierLimitMode defines what happens to bookings over an operation's per-supplier limit
+type SupplierLimitMode string
+
+const (
+    // SupplierLimitBlock refuses bookings over the limit
+    SupplierLimitBlock SupplierLimitMode = "block"
+
+    // SupplierLimitWarn accepts bookings over the limit and returns a warning
+    SupplierLimitWarn SupplierLimitMode = "warn"
+)
+
+// IsValid reports whether the supplier limit mode is known
+func (m SupplierLimitMode) IsValid() bool {
+    return m == SupplierLimitBlock || m == SupplierLimitWarn
+}
+
+// Location returns the operation's timezone, UTC when it is unset or unknown. Days at the
+// operation, such as "today" on the gate manifest, are counted in it.
+func (o *Operation) Location() *time.Location {
+    if o.Timezone == "" {
+        return time.UTC
+    }
+    location, err := time.LoadLocation(o.Timezone)
+    if err != nil {
+        return time.UTC
+    }
+    return location
+}
+
+// RequiresDeposit reports whether suppliers pay a deposit for their bookings at the operation
+func (o *Operation) RequiresDeposit() bool {
+    return o.DepositAmount.IsPositive()
+}
+
 // Validate performs validation on the operation
 func (o *Operation) Validate() error {
     if o.Name == "" {
@@ -49,16 +124,47 @@ func (o *Operation) Validate() error {
     if o.ManagerID == 0 {
         return errors.New("manager is required")
     }
+    if o.AutoCompleteMode != "" && !o.AutoCompleteMode.IsValid() {
+        return errors.New("invalid auto-complete mode")
+    }
+    if o.AutoCompleteAfterHours < 0 {
+        return errors.New("auto-complete delay cannot be negative")
+    }
+    if o.PendingExpiryHours < 0 {
+        return errors.New("pending expiry cannot be negative")
+    }
+    if o.MaxConcurrentPerSupplier < 0 {
+        return errors.New("per-supplier limit cannot be negative")
+    }
+    if o.SupplierLimitMode != "" && !o.SupplierLimitMode.IsValid() {
+        return errors.New("invalid supplier limit mode")
+    }
+    if o.NoShowFee.IsNegative() {
+        return errors.New("no-show fee cannot be negative")
+    }
+    if o.NoShowFeeCurrency == "" {
+        o.NoShowFeeCurrency = DefaultCurrency
+    }
+    places, err := CurrencyMinorUnits(o.NoShowFeeCurrency)
+    if err != nil {
+        return err
+    }
+    if !o.NoShowFee.Equal(o.NoShowFee.Truncate(places)) {
+        return errors.New("no-show fee has more decimal places than its currency allows")
+    }
+    if o.DepositAmount.IsNegative() {
+        return errors.New("deposit cannot be negative")
+    }
+    if o.DepositCurrency == "" {
+        o.DepositCurrency = DefaultCurrency
+    }
+    places, err = CurrencyMinorUnits(o.DepositCurrency)
+    if err != nil {
+        return err
+    }
+    if !o.DepositAmount.Equal(o.DepositAmount.Truncate(places)) {
+        return errors.New("deposit has more decimal places than its currency allows")
+    }
     return nil
 }
 
-// BeforeCreate is called by GORM before creating a new record
-func (o *Operation) BeforeCreate() error {
-    return o.Validate()
-}
-
-// BeforeUpdate is called by GORM before updating a record
-func (o *Operation) BeforeUpdate() error {
-    return o.Validate()
-}
-
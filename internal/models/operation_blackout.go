@@ -0,0 +1,61 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// BlackoutDateLayout is the format of blackout dates in paths and responses
+const BlackoutDateLayout = "2006-01-02"
+
+// BlackoutSource defines where a blackout date came from
+type BlackoutSource string
+
+const (
+	// BlackoutHoliday is a public holiday imported from the holiday provider. Holiday dates are
+	// replaced on every sync.
+	BlackoutHoliday BlackoutSource = "holiday"
+
+	// BlackoutManual is a date set by an admin. It overrides the holiday on the same date, if any,
+	// and is kept across syncs.
+	BlackoutManual BlackoutSource = "manual"
+)
+
+// IsValid reports whether the blackout source is known
+func (s BlackoutSource) IsValid() bool {
+	return s == BlackoutHoliday || s == BlackoutManual
+}
+
+// OperationBlackout is a date on an operation's blackout calendar. Closed dates take no
+// appointments; a manual date that isn't closed keeps the operation open on a holiday.
+type OperationBlackout struct {
+	BaseModel
+	OperationID uint           `gorm:"not null;uniqueIndex:idx_operation_blackouts_date" json:"operation_id"`
+	Date        time.Time      `gorm:"type:date;not null;uniqueIndex:idx_operation_blackouts_date" json:"date"` // civil date at midnight UTC
+	Name        string         `gorm:"not null" json:"name"`
+	Source      BlackoutSource `gorm:"not null" json:"source"`
+	Closed      bool           `gorm:"not null;default:true" json:"closed"`
+	UpdatedByID *uint          `json:"updated_by_id"` // admin who set a manual date
+}
+
+// Validate validates a blackout date
+func (b *OperationBlackout) Validate() error {
+	if b.OperationID == 0 {
+		return errors.New("operation is required")
+	}
+	if b.Date.IsZero() {
+		return errors.New("date is required")
+	}
+	if b.Name == "" {
+		return errors.New("name is required")
+	}
+	if !b.Source.IsValid() {
+		return errors.New("invalid blackout source")
+	}
+	return nil
+}
+
+// DateKey returns the blackout date as "YYYY-MM-DD"
+func (b *OperationBlackout) DateKey() string {
+	return b.Date.UTC().Format(BlackoutDateLayout)
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// PartnerCredential lets a partner system, such as a customer's ERP, call the partner API with
+// requests signed by an HMAC of a shared secret. The secret has to be stored as it is to verify
+// signatures, so it is never returned after it is issued. After a rotation the previous secret
+// is still accepted until PreviousSecretUntil, giving the partner time to switch.
+type PartnerCredential struct {
+	BaseModel
+	Name                string     `gorm:"not null" json:"name"`
+	KeyID               string     `gorm:"uniqueIndex;not null" json:"key_id"` // Sent by the partner to say which secret signed a request
+	Secret              string     `gorm:"not null" json:"-"`
+	PreviousSecret      string     `json:"-"`
+	PreviousSecretUntil *time.Time `json:"previous_secret_until,omitempty"`
+	CreatedByID         uint       `json:"created_by_id"`
+	LastUsedAt          *time.Time `json:"last_used_at"`
+	RevokedAt           *time.Time `json:"revoked_at"`
+}
+
+// Validate validates a partner credential
+func (p *PartnerCredential) Validate() error {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return errors.New("partner name is required")
+	}
+	if len(p.Name) > 100 {
+		return errors.New("partner name cannot exceed 100 characters")
+	}
+	return nil
+}
+
+// Secrets returns the secrets a request signed at now may be signed with, the current one first
+func (p *PartnerCredential) Secrets(now time.Time) []string {
+	secrets := []string{p.Secret}
+	if p.PreviousSecret != "" && p.PreviousSecretUntil != nil && now.Before(*p.PreviousSecretUntil) {
+		secrets = append(secrets, p.PreviousSecret)
+	}
+	return secrets
+}
+
+// PartnerNonce records a nonce a partner signed a request with, so the request can't be replayed
+// while its timestamp is still accepted
+type PartnerNonce struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	KeyID     string    `gorm:"not null;uniqueIndex:idx_partner_nonces_key_nonce" json:"key_id"`
+	Nonce     string    `gorm:"not null;uniqueIndex:idx_partner_nonces_key_nonce" json:"nonce"`
+	ExpiresAt time.Time `gorm:"index" json:"expires_at"`
+}
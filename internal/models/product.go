@@ -1,21 +1,82 @@
 package models
 
 import (
+	"errors"
+	"regexp"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
-// Product represents a product that can be delivered
+// DefaultCurrency is the currency of prices that don't name one
+const DefaultCurrency = "BRL"
+
+// currencyMinorUnits maps the ISO 4217 currencies prices may be in to their number of decimal
+// places
+var currencyMinorUnits = map[string]int32{
+	"BRL": 2,
+	"USD": 2,
+	"EUR": 2,
+	"ARS": 2,
+	"UYU": 2,
+	"PYG": 0,
+	"CLP": 0,
+	"COP": 2,
+	"MXN": 2,
+}
+
+var currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Product represents a product that can be delivered. Prices are exact decimals in the product's
+// currency and are written to JSON as strings, e.g. "12.50", so clients don't round them
+// through floats.
 type Product struct {
-	ID          uint      `json:"id" gorm:"primaryKey"`
-	Name        string    `json:"name" gorm:"not null"`
-	Description string    `json:"description" gorm:"type:text"`
-	SKU         string    `json:"sku" gorm:"uniqueIndex"`
-	Category    string    `json:"category"`
-	Price       float64   `json:"price" gorm:"type:decimal(10,2)"`
-	SupplierID  uint      `json:"supplier_id" gorm:"not null"`
-	Supplier    Supplier  `json:"supplier" gorm:"foreignKey:SupplierID"`
-	Active      bool      `json:"active" gorm:"default:true"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          uint            `json:"id" gorm:"primaryKey"`
+	Name        string          `json:"name" gorm:"not null"`
+	Description string          `json:"description" gorm:"type:text"`
+	SKU         string          `json:"sku" gorm:"uniqueIndex"`
+	Category    string          `json:"category"`
+	Price       decimal.Decimal `json:"price" gorm:"type:decimal(10,2);not null;default:0"`
+	Currency    string          `json:"currency" gorm:"type:char(3);not null;default:'BRL'"` // ISO 4217 code
+	SupplierID  uint            `json:"supplier_id" gorm:"not null"`
+	Supplier    Supplier        `json:"supplier" gorm:"foreignKey:SupplierID"`
+	Active      bool            `json:"active" gorm:"default:true"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+// Validate validates a product, defaulting its currency
+func (p *Product) Validate() error {
+	if p.Name == "" {
+		return errors.New("name is required")
+	}
+	if p.SupplierID == 0 {
+		return errors.New("supplier is required")
+	}
+	if p.Currency == "" {
+		p.Currency = DefaultCurrency
+	}
+	places, err := CurrencyMinorUnits(p.Currency)
+	if err != nil {
+		return err
+	}
+	if p.Price.IsNegative() {
+		return errors.New("price cannot be negative")
+	}
+	if !p.Price.Equal(p.Price.Truncate(places)) {
+		return errors.New("price has more decimal places than its currency allows")
+	}
+	return nil
 }
 
+// CurrencyMinorUnits returns the number of decimal places of a supported currency
+func CurrencyMinorUnits(code string) (int32, error) {
+	if !currencyCodePattern.MatchString(code) {
+		return 0, errors.New("currency must be a three-letter ISO 4217 code")
+	}
+	places, ok := currencyMinorUnits[code]
+	if !ok {
+		return 0, errors.New("unsupported currency")
+	}
+	return places, nil
+}
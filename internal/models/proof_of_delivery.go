@@ -0,0 +1,83 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// MaxProofOfDeliveryAttachments is how many documents or photos a proof of delivery may hold
+const MaxProofOfDeliveryAttachments = 20
+
+// ProofAttachmentKind defines what a proof of delivery attachment shows
+type ProofAttachmentKind string
+
+const (
+	// ProofAttachmentDocument is a signed delivery note, invoice or receipt
+	ProofAttachmentDocument ProofAttachmentKind = "document"
+
+	// ProofAttachmentPhoto is a photo of the goods as received
+	ProofAttachmentPhoto ProofAttachmentKind = "photo"
+)
+
+// IsValid reports whether the attachment kind is known
+func (k ProofAttachmentKind) IsValid() bool {
+	switch k {
+	case ProofAttachmentDocument, ProofAttachmentPhoto:
+		return true
+	}
+	return false
+}
+
+// ProofOfDelivery is the receipt of a completed appointment: who received the goods, their
+// signature and the signed documents and photos taken at the dock
+type ProofOfDelivery struct {
+	BaseModel
+	AppointmentID uint                        `gorm:"not null;uniqueIndex" json:"appointment_id"`
+	ReceiverName  string                      `gorm:"not null" json:"receiver_name"`
+	SignatureURL  string                      `json:"signature_url"` // image of the receiver's signature
+	SignedAt      *time.Time                  `json:"signed_at"`
+	Notes         string                      `gorm:"type:text" json:"notes"`
+	CapturedByID  uint                        `json:"captured_by_id"` // User who recorded the proof
+	Attachments   []ProofOfDeliveryAttachment `gorm:"foreignKey:ProofOfDeliveryID" json:"attachments"`
+}
+
+// ProofOfDeliveryAttachment is a signed document or photo proving a delivery
+type ProofOfDeliveryAttachment struct {
+	BaseModel
+	ProofOfDeliveryID uint                `gorm:"not null;index" json:"proof_of_delivery_id"`
+	Kind              ProofAttachmentKind `gorm:"not null" json:"kind"`
+	FileURL           string              `gorm:"not null" json:"file_url"`
+	ContentType       string              `json:"content_type"`
+	Caption           string              `json:"caption"`
+}
+
+// Validate validates a proof of delivery
+func (p *ProofOfDelivery) Validate() error {
+	if p.AppointmentID == 0 {
+		return errors.New("appointment is required")
+	}
+	if strings.TrimSpace(p.ReceiverName) == "" {
+		return errors.New("receiver name is required")
+	}
+	if len(p.Attachments) > MaxProofOfDeliveryAttachments {
+		return errors.New("a proof of delivery can have at most 20 attachments")
+	}
+	for _, attachment := range p.Attachments {
+		if err := attachment.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate validates a proof of delivery attachment
+func (a *ProofOfDeliveryAttachment) Validate() error {
+	if !a.Kind.IsValid() {
+		return errors.New("invalid attachment kind")
+	}
+	if a.FileURL == "" {
+		return errors.New("attachment file URL is required")
+	}
+	return nil
+}
@@ -83,6 +83,12 @@ type RecurringAppointment struct {
 
 // Validate ensures the recurring appointment data is valid
 func (ra *RecurringAppointment) Validate() error {
+	return ra.ValidateAt(time.Now())
+}
+
+// ValidateAt ensures the recurring appointment data is valid, judging whether the start date
+// is in the past against now
+func (ra *RecurringAppointment) ValidateAt(now time.Time) error {
 	// Check required fields
 	if ra.SupplierID == 0 {
 		return errors.New("supplier is required")
@@ -109,7 +115,7 @@ func (ra *RecurringAppointment) Validate() error {
 	}
 	
 	// Validate start date is not in the past
-	if ra.StartDate.Before(time.Now().Truncate(24 * time.Hour)) {
+	if ra.StartDate.Before(now.Truncate(24 * time.Hour)) {
 		return errors.New("start date cannot be in the past")
 	}
 	
@@ -330,6 +336,7 @@ func (ra *RecurringAppointment) GenerateOccurrences() []time.Time {
 func (ra *RecurringAppointment) GenerateAppointments() []Appointment {
 	occurrences := ra.GenerateOccurrences()
 	appointments := make([]Appointment, 0, len(occurrences))
+	productID := ra.ProductID
 	
 	for _, occurrence := range occurrences {
 		// Calculate start and end times
@@ -340,7 +347,7 @@ func (ra *RecurringAppointment) GenerateAppointments() []Appointment {
 			SupplierID:            ra.SupplierID,
 			EmployeeID:            ra.EmployeeID,
 			OperationID:           ra.OperationID,
-			ProductID:             ra.ProductID,
+			ProductID:             &productID,
 			ScheduledStart:        startTime,
 			ScheduledEnd:          endTime,
 			Notes:                 ra.Notes,
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// regionCodePattern is the form region codes are stored in, e.g. "SUDESTE" or "SP-INTERIOR"
+var regionCodePattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9_-]{0,29}$`)
+
+// Region groups operations so the employees managing it can follow them together without being
+// admins. An operation may belong to several regions.
+type Region struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	Code         string    `gorm:"uniqueIndex;not null" json:"code"`
+	Name         string    `gorm:"not null" json:"name"`
+	OperationIDs []uint    `gorm:"-" json:"operation_ids"`
+	ManagerIDs   []uint    `gorm:"-" json:"manager_ids"` // users who see the region's appointments and statistics
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// Validate validates a region, normalizing its code to upper case
+func (r *Region) Validate() error {
+	r.Code = strings.ToUpper(strings.TrimSpace(r.Code))
+	r.Name = strings.TrimSpace(r.Name)
+	if !regionCodePattern.MatchString(r.Code) {
+		return errors.New("region codes are up to 30 letters, digits, hyphens and underscores")
+	}
+	if r.Name == "" {
+		return errors.New("region name is required")
+	}
+	return nil
+}
+
+// RegionOperation puts an operation in a region
+type RegionOperation struct {
+	RegionID    uint `gorm:"primaryKey"`
+	OperationID uint `gorm:"primaryKey;index"`
+}
+
+// RegionManager makes a user a manager of a region
+type RegionManager struct {
+	RegionID uint `gorm:"primaryKey"`
+	UserID   uint `gorm:"primaryKey;index"`
+}
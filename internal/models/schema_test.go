@@ -0,0 +1,57 @@
+package models
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm/schema"
+)
+
+// parseAll parses the schema of every migrated model
+func parseAll(t *testing.T) []*schema.Schema {
+	t.Helper()
+	cache := &sync.Map{}
+	schemas := make([]*schema.Schema, 0, len(All()))
+	for _, model := range All() {
+		parsed, err := schema.Parse(model, cache, schema.NamingStrategy{})
+		require.NoError(t, err, "parsing %T", model)
+		schemas = append(schemas, parsed)
+	}
+	return schemas
+}
+
+func TestModelsMigrateDistinctTables(t *testing.T) {
+	tables := make(map[string]string)
+	for _, parsed := range parseAll(t) {
+		if other, ok := tables[parsed.Table]; ok {
+			t.Errorf("%s and %s both migrate table %s", other, parsed.Name, parsed.Table)
+			continue
+		}
+		tables[parsed.Table] = parsed.Name
+	}
+}
+
+func TestModelsHaveDistinctColumns(t *testing.T) {
+	for _, parsed := range parseAll(t) {
+		columns := make(map[string]string)
+		for _, field := range parsed.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			if other, ok := columns[field.DBName]; ok {
+				t.Errorf("%s fields %s and %s both map to column %s", parsed.Name, other, field.Name, field.DBName)
+				continue
+			}
+			columns[field.DBName] = field.Name
+		}
+	}
+}
+
+func TestModelsAreMigratedOnce(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, parsed := range parseAll(t) {
+		require.False(t, seen[parsed.Name], "%s is migrated twice", parsed.Name)
+		seen[parsed.Name] = true
+	}
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Kinds of value a runtime setting holds
+const (
+	SettingTypeInt      = "int"
+	SettingTypeDuration = "duration" // Go duration, e.g. 90s, 30m or 12h
+)
+
+// Setting overrides the configured value of an operational parameter at runtime. Rows are written
+// through the admin API; every replica caches them and checks for changes periodically, so a
+// change applies without a restart.
+type Setting struct {
+	Key         string    `gorm:"primaryKey" json:"key"`
+	Value       string    `gorm:"not null" json:"value"`
+	UpdatedByID *uint     `json:"updated_by_id"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
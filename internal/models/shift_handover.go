@@ -0,0 +1,67 @@
+package models
+
+import (
+	"errors"
+	"time"
+	"unicode/utf8"
+)
+
+// maxHandoverNoteLength is the longest handover note, in characters
+const maxHandoverNoteLength = 4000
+
+// HandoverMentionReason defines why an employee was notified of a handover note
+type HandoverMentionReason string
+
+const (
+	// MentionIncomingShift is an employee on the shift that takes over after the note
+	MentionIncomingShift HandoverMentionReason = "incoming_shift"
+
+	// MentionNamed is an employee the note mentions by @employee_number
+	MentionNamed HandoverMentionReason = "mentioned"
+)
+
+// ShiftHandover is a note a dock supervisor leaves at an operation on a day for the shift taking
+// over, such as a blocked dock door or a delivery still being unloaded
+type ShiftHandover struct {
+	BaseModel
+	OperationID uint                   `gorm:"not null;index:idx_shift_handovers_day" json:"operation_id"`
+	Date        time.Time              `gorm:"type:date;not null;index:idx_shift_handovers_day" json:"date"` // civil date at midnight UTC
+	ShiftStart  string                 `json:"shift_start"`                                                  // "HH:MM" the incoming shift starts at; empty for the next one
+	Note        string                 `gorm:"type:text;not null" json:"note"`
+	AuthorID    uint                   `gorm:"not null" json:"author_id"` // user who wrote the note
+	AuthorName  string                 `json:"author_name"`
+	Mentions    []ShiftHandoverMention `gorm:"foreignKey:HandoverID" json:"mentions"`
+}
+
+// ShiftHandoverMention is an employee notified of a handover note
+type ShiftHandoverMention struct {
+	BaseModel
+	HandoverID uint                  `gorm:"not null;index" json:"handover_id"`
+	EmployeeID uint                  `gorm:"not null" json:"employee_id"`
+	Reason     HandoverMentionReason `gorm:"not null" json:"reason"`
+}
+
+// Validate validates a shift handover
+func (h *ShiftHandover) Validate() error {
+	if h.OperationID == 0 {
+		return errors.New("operation is required")
+	}
+	if h.Date.IsZero() {
+		return errors.New("date is required")
+	}
+	if h.Note == "" {
+		return errors.New("handover note is required")
+	}
+	if utf8.RuneCountInString(h.Note) > maxHandoverNoteLength {
+		return errors.New("handover notes are at most 4000 characters")
+	}
+	if h.ShiftStart != "" && !isClockTime(h.ShiftStart) {
+		return errors.New("shift start must be in HH:MM format")
+	}
+	return nil
+}
+
+// DateKey returns the handover's date as "YYYY-MM-DD"
+func (h *ShiftHandover) DateKey() string {
+	return h.Date.UTC().Format(BlackoutDateLayout)
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// skillPattern is the form skills are stored in, e.g. "forklift" or "hazmat"
+var skillPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,49}$`)
+
+// NormalizeSkill returns a skill in its stored form: lower case, with spaces as underscores
+func NormalizeSkill(skill string) string {
+	return strings.Join(strings.Fields(strings.ToLower(skill)), "_")
+}
+
+// ValidateSkill checks a normalized skill name
+func ValidateSkill(skill string) error {
+	if skill == "" {
+		return errors.New("skill is required")
+	}
+	if !skillPattern.MatchString(skill) {
+		return errors.New("skills are up to 50 letters, digits, hyphens and underscores")
+	}
+	return nil
+}
+
+// EmployeeSkill is a qualification an employee holds, such as a forklift certification or
+// hazmat training
+type EmployeeSkill struct {
+	BaseModel
+	EmployeeID uint   `gorm:"not null;uniqueIndex:idx_employee_skills_skill" json:"employee_id"`
+	Skill      string `gorm:"not null;uniqueIndex:idx_employee_skills_skill" json:"skill"`
+}
+
+// SkillRequirement is a skill the employee an appointment is booked with must hold. It applies at
+// one operation or, without an operation, at every operation, and to appointments for products
+// of one category or, without a category, to every appointment.
+type SkillRequirement struct {
+	BaseModel
+	OperationID     *uint  `gorm:"index" json:"operation_id"`
+	ProductCategory string `gorm:"index" json:"product_category"`
+	Skill           string `gorm:"not null" json:"skill"`
+}
+
+// Validate validates a skill requirement
+func (r *SkillRequirement) Validate() error {
+	if r.OperationID == nil && r.ProductCategory == "" {
+		return errors.New("requirements that apply at every operation need a product category")
+	}
+	return ValidateSkill(r.Skill)
+}
+
+// AppliesTo reports whether the requirement covers an appointment at an operation for a product
+// of a category, which is empty for appointments without a product
+func (r *SkillRequirement) AppliesTo(operationID uint, productCategory string) bool {
+	if r.OperationID != nil && *r.OperationID != operationID {
+		return false
+	}
+	return r.ProductCategory == "" || strings.EqualFold(r.ProductCategory, productCategory)
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// SlotWatch is a supplier asking to hear when capacity frees up at an operation, on one date or
+// on any date. Unlike a waitlist entry it isn't offered a slot of its own: every watcher of an
+// opening gets the same first-come booking link.
+type SlotWatch struct {
+	BaseModel
+	SupplierID     uint            `gorm:"not null;index" json:"supplier_id"`
+	OperationID    uint            `gorm:"not null;index:idx_slot_watches_operation_date" json:"operation_id"`
+	Date           *time.Time      `gorm:"type:date;index:idx_slot_watches_operation_date" json:"date"` // civil date at midnight UTC, nil watches every date
+	Type           AppointmentType `json:"type"`                                                        // empty watches every type
+	CreatedByID    uint            `json:"created_by_id"`
+	LastNotifiedAt *time.Time      `json:"last_notified_at"`
+}
+
+// Validate validates a slot watch
+func (w *SlotWatch) Validate() error {
+	if w.SupplierID == 0 {
+		return errors.New("supplier is required")
+	}
+	if w.OperationID == 0 {
+		return errors.New("operation is required")
+	}
+	if w.Type != "" && !w.Type.IsValid() {
+		return errors.New("invalid appointment type")
+	}
+	return nil
+}
+
+// DateKey returns the watched date as "YYYY-MM-DD", empty when every date is watched
+func (w *SlotWatch) DateKey() string {
+	if w.Date == nil {
+		return ""
+	}
+	return w.Date.UTC().Format(BlackoutDateLayout)
+}
+
+// SlotOpeningCause defines what freed the capacity of a slot opening
+type SlotOpeningCause string
+
+const (
+	// SlotOpeningCancellation is the slot of a cancelled appointment
+	SlotOpeningCancellation SlotOpeningCause = "cancellation"
+
+	// SlotOpeningCapacityIncrease is a time band whose capacity was raised or lifted
+	SlotOpeningCapacityIncrease SlotOpeningCause = "capacity_increase"
+)
+
+// SlotOpeningStatus defines how far the broadcast of a slot opening got
+type SlotOpeningStatus string
+
+const (
+	// SlotOpeningBroadcasting is an opening whose watchers are still being notified, a wave at a time
+	SlotOpeningBroadcasting SlotOpeningStatus = "broadcasting"
+
+	// SlotOpeningNotified is an opening every watcher was notified of
+	SlotOpeningNotified SlotOpeningStatus = "notified"
+
+	// SlotOpeningExpired is an opening whose time passed
+	SlotOpeningExpired SlotOpeningStatus = "expired"
+)
+
+// SlotOpening is capacity freed at an operation on a date, broadcast to the suppliers watching it.
+// Watchers are notified in waves so they don't all rush for it at once, and book through it first
+// come, first served until its places are taken.
+type SlotOpening struct {
+	BaseModel
+	OperationID        uint              `gorm:"not null;index:idx_slot_openings_operation_date" json:"operation_id"`
+	Date               time.Time         `gorm:"type:date;not null;index:idx_slot_openings_operation_date" json:"date"` // civil date at midnight UTC
+	Type               AppointmentType   `json:"type"`                                                                  // empty opens every type
+	Cause              SlotOpeningCause  `gorm:"not null" json:"cause"`
+	AppointmentID      *uint             `json:"appointment_id"`               // cancelled appointment whose slot opened
+	EmployeeID         *uint             `json:"employee_id"`                  // employee of the cancelled appointment
+	WindowStart        time.Time         `gorm:"not null" json:"window_start"` // appointments booked through the opening fit in the window
+	WindowEnd          time.Time         `gorm:"not null;index" json:"window_end"`
+	Places             int               `gorm:"not null" json:"places"` // 0 doesn't cap the bookings; the operation's capacity still applies
+	Booked             int               `gorm:"not null;default:0" json:"booked"`
+	ExcludedSupplierID uint              `json:"-"`                   // supplier of the cancelled appointment
+	OfferedSupplierID  *uint             `json:"offered_supplier_id"` // waitlisted supplier offered the slot ahead of the watchers
+	Status             SlotOpeningStatus `gorm:"not null;default:'broadcasting';index" json:"status"`
+	Waves              int               `gorm:"not null;default:0" json:"waves"`
+	Notified           int               `gorm:"not null;default:0" json:"notified"`
+	NotifiedThroughID  uint              `json:"-"` // watches up to this ID were reached by a wave
+	NextWaveAt         *time.Time        `gorm:"index" json:"next_wave_at"`
+}
+
+// Full reports whether every place of the opening was booked
+func (o *SlotOpening) Full() bool {
+	return o.Places > 0 && o.Booked >= o.Places
+}
+
+// Bookable reports whether the opening can still be booked through at a time
+func (o *SlotOpening) Bookable(now time.Time) bool {
+	return o.Status != SlotOpeningExpired && o.WindowEnd.After(now) && !o.Full()
+}
+
+// DateKey returns the opening's date as "YYYY-MM-DD"
+func (o *SlotOpening) DateKey() string {
+	return o.Date.UTC().Format(BlackoutDateLayout)
+}
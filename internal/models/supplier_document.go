@@ -0,0 +1,76 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// SupplierDocumentType defines the kind of compliance document
+type SupplierDocumentType string
+
+const (
+	// DocumentInsurance is a cargo or liability insurance policy
+	DocumentInsurance SupplierDocumentType = "insurance"
+
+	// DocumentLicense is an operating or transport license
+	DocumentLicense SupplierDocumentType = "license"
+
+	// DocumentCertificate is a sanitary, safety or quality certificate
+	DocumentCertificate SupplierDocumentType = "certificate"
+
+	// DocumentOther is any other compliance document
+	DocumentOther SupplierDocumentType = "other"
+)
+
+// IsValid reports whether the document type is known
+func (t SupplierDocumentType) IsValid() bool {
+	switch t {
+	case DocumentInsurance, DocumentLicense, DocumentCertificate, DocumentOther:
+		return true
+	}
+	return false
+}
+
+// SupplierDocument represents a compliance document held by a supplier
+type SupplierDocument struct {
+	BaseModel
+	SupplierID       uint                 `gorm:"not null;index" json:"supplier_id"`
+	Supplier         Supplier             `json:"-"`
+	Type             SupplierDocumentType `gorm:"not null;index" json:"type"`
+	Number           string               `json:"number"`
+	Issuer           string               `json:"issuer"`
+	IssuedAt         *time.Time           `json:"issued_at"`
+	ExpiresAt        time.Time            `gorm:"not null;index" json:"expires_at"`
+	FileURL          string               `json:"file_url"`
+	ExpiryNotifiedAt *time.Time           `json:"expiry_notified_at"` // When the supplier was last warned about expiry
+}
+
+// Validate validates a supplier document
+func (d *SupplierDocument) Validate() error {
+	if d.SupplierID == 0 {
+		return errors.New("supplier is required")
+	}
+	if !d.Type.IsValid() {
+		return errors.New("invalid document type")
+	}
+	if d.ExpiresAt.IsZero() {
+		return errors.New("expiry date is required")
+	}
+	if d.IssuedAt != nil && d.IssuedAt.After(d.ExpiresAt) {
+		return errors.New("issue date must be before expiry date")
+	}
+	return nil
+}
+
+// IsValidAt reports whether the document has not expired at the given time
+func (d *SupplierDocument) IsValidAt(at time.Time) bool {
+	return d.ExpiresAt.After(at)
+}
+
+// SupplierDocumentRequirement defines a document suppliers must hold to book at an operation
+type SupplierDocumentRequirement struct {
+	BaseModel
+	OperationID   uint                 `gorm:"not null;uniqueIndex:idx_operation_document_type" json:"operation_id"`
+	DocumentType  SupplierDocumentType `gorm:"not null;uniqueIndex:idx_operation_document_type" json:"document_type"`
+	BlockBookings bool                 `gorm:"default:false" json:"block_bookings"` // Reject new bookings when the document has lapsed
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Template experiment statuses
+const (
+	ExperimentRunning = "running"
+	ExperimentStopped = "stopped"
+)
+
+// Template experiment variants
+const (
+	VariantA = "A"
+	VariantB = "B"
+)
+
+// TemplateExperiment splits the notifications of an event between two templates, so their open,
+// confirmation and no-show rates can be compared. Only one experiment runs per event, recipient
+// type and notification type at a time.
+type TemplateExperiment struct {
+	ID                 uint                      `gorm:"primaryKey" json:"id"`
+	Name               string                    `gorm:"not null" json:"name"`
+	Event              NotificationEvent         `gorm:"not null;index:idx_template_experiment" json:"event"`
+	RecipientType      NotificationRecipientType `gorm:"not null;index:idx_template_experiment" json:"recipient_type"`
+	Type               NotificationType          `gorm:"not null;index:idx_template_experiment" json:"type"`
+	VariantATemplateID uint                      `gorm:"not null" json:"variant_a_template_id"`
+	VariantBTemplateID uint                      `gorm:"not null" json:"variant_b_template_id"`
+	SplitB             int                       `gorm:"not null" json:"split_b"` // percent of the notifications sent with variant B
+	Status             string                    `gorm:"not null;index" json:"status"`
+	StartedAt          time.Time                 `json:"started_at"`
+	EndedAt            *time.Time                `json:"ended_at"`
+	CreatedAt          time.Time                 `json:"created_at"`
+	UpdatedAt          time.Time                 `json:"updated_at"`
+}
+
+// Validate validates a template experiment
+func (e *TemplateExperiment) Validate() error {
+	e.Name = strings.TrimSpace(e.Name)
+	if e.Name == "" {
+		return errors.New("experiment name is required")
+	}
+	if e.Event == "" || e.RecipientType == "" || e.Type == "" {
+		return errors.New("experiment event, recipient type and notification type are required")
+	}
+	if e.VariantATemplateID == 0 || e.VariantBTemplateID == 0 {
+		return errors.New("experiment variants need a template each")
+	}
+	if e.VariantATemplateID == e.VariantBTemplateID {
+		return errors.New("experiment variants must use different templates")
+	}
+	if e.SplitB < 0 || e.SplitB > 100 {
+		return errors.New("experiment split must be between 0 and 100 percent")
+	}
+	return nil
+}
+
+// TemplateID returns the template a variant is sent with
+func (e *TemplateExperiment) TemplateID(variant string) uint {
+	if variant == VariantB {
+		return e.VariantBTemplateID
+	}
+	return e.VariantATemplateID
+}
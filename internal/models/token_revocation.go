@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// Token revocation reasons
+const (
+	RevocationLogout          = "logout"
+	RevocationLogoutAll       = "logout_all"
+	RevocationPasswordChanged = "password_changed"
+	RevocationAdmin           = "revoked_by_admin"
+)
+
+// TokenRevocation refuses issued JWTs before they expire: one token, by its ID, or every token of
+// a user issued before a time, e.g. after a password change. Rows are kept until the tokens they
+// refuse would have expired anyway.
+type TokenRevocation struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	UserID       uint       `gorm:"not null;index" json:"user_id"`
+	TokenID      string     `gorm:"index" json:"token_id,omitempty"` // jti, or the SHA-256 of tokens without one; empty for every token of the user
+	IssuedBefore *time.Time `json:"issued_before,omitempty"`         // set when every token of the user issued earlier is refused
+	Reason       string     `gorm:"not null" json:"reason"`
+	ExpiresAt    time.Time  `gorm:"not null;index" json:"expires_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
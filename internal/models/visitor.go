@@ -0,0 +1,44 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// AppointmentVisitor represents an extra person (helper, co-driver) coming with a delivery
+// who needs a gate pass
+type AppointmentVisitor struct {
+	BaseModel
+	AppointmentID  uint       `gorm:"not null;index" json:"appointment_id"`
+	Name           string     `gorm:"not null" json:"name"`
+	DocumentType   string     `json:"document_type"` // e.g. "cpf", "rg", "passport"
+	DocumentNumber string     `gorm:"not null" json:"document_number"`
+	Role           string     `json:"role"` // e.g. "helper", "driver"
+	CheckedInAt    *time.Time `json:"checked_in_at"`
+}
+
+// Validate validates a visitor
+func (v *AppointmentVisitor) Validate() error {
+	if v.AppointmentID == 0 {
+		return errors.New("appointment is required")
+	}
+	if strings.TrimSpace(v.Name) == "" {
+		return errors.New("visitor name is required")
+	}
+	if strings.TrimSpace(v.DocumentNumber) == "" {
+		return errors.New("visitor document number is required")
+	}
+	return nil
+}
+
+// AppointmentLocationPing is a GPS position reported by the driver app on the way to an appointment
+type AppointmentLocationPing struct {
+	BaseModel
+	AppointmentID  uint      `gorm:"not null;index" json:"appointment_id"`
+	Latitude       float64   `gorm:"not null" json:"latitude"`
+	Longitude      float64   `gorm:"not null" json:"longitude"`
+	AccuracyMeters float64   `json:"accuracy_meters"`
+	DistanceMeters *float64  `json:"distance_meters"` // Distance to the operation, when it is geocoded
+	RecordedAt     time.Time `gorm:"not null" json:"recorded_at"`
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// WaitlistStatus defines where a supplier stands on an operation's waitlist
+type WaitlistStatus string
+
+const (
+	// WaitlistWaiting is an entry waiting for a slot to open on its date
+	WaitlistWaiting WaitlistStatus = "waiting"
+
+	// WaitlistOffered is an entry that was offered the slot of a cancelled appointment
+	WaitlistOffered WaitlistStatus = "offered"
+
+	// WaitlistWithdrawn is an entry the supplier left the waitlist with
+	WaitlistWithdrawn WaitlistStatus = "withdrawn"
+)
+
+// WaitlistEntry is a supplier waiting for a slot at an operation on a fully booked date. When an
+// appointment on that date is cancelled, the oldest waiting entry is offered its slot.
+type WaitlistEntry struct {
+	BaseModel
+	SupplierID           uint            `gorm:"not null;index" json:"supplier_id"`
+	OperationID          uint            `gorm:"not null;index:idx_waitlist_operation_date" json:"operation_id"`
+	Date                 time.Time       `gorm:"type:date;not null;index:idx_waitlist_operation_date" json:"date"` // civil date at midnight UTC
+	Type                 AppointmentType `gorm:"not null;default:'delivery'" json:"type"`
+	Notes                string          `json:"notes"`
+	Status               WaitlistStatus  `gorm:"not null;default:'waiting';index" json:"status"`
+	CreatedByID          uint            `json:"created_by_id"`
+	OfferedAt            *time.Time      `json:"offered_at"`
+	OfferedAppointmentID *uint           `json:"offered_appointment_id"` // cancelled appointment whose slot was offered
+}
+
+// Validate validates a waitlist entry
+func (w *WaitlistEntry) Validate() error {
+	if w.SupplierID == 0 {
+		return errors.New("supplier is required")
+	}
+	if w.OperationID == 0 {
+		return errors.New("operation is required")
+	}
+	if w.Date.IsZero() {
+		return errors.New("date is required")
+	}
+	if !w.Type.IsValid() {
+		return errors.New("invalid appointment type")
+	}
+	return nil
+}
+
+// DateKey returns the waitlisted date as "YYYY-MM-DD"
+func (w *WaitlistEntry) DateKey() string {
+	return w.Date.UTC().Format(BlackoutDateLayout)
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// applyAppointmentFilters applies the status, type, date and booking code filters shared by
+// appointment listings
+func applyAppointmentFilters(query *gorm.DB, filters AppointmentFilters) *gorm.DB {
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+	if filters.Type != nil {
+		query = query.Where("type = ?", *filters.Type)
+	}
+	if filters.StartDate != nil {
+		query = query.Where("scheduled_start >= ?", *filters.StartDate)
+	}
+	if filters.EndDate != nil {
+		query = query.Where("scheduled_end <= ?", *filters.EndDate)
+	}
+	if code := strings.TrimSpace(filters.BookingCode); code != "" {
+		query = query.Where("booking_code ILIKE ?", "%"+code+"%")
+	}
+	return query
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// AppointmentLinkRepository interface defines methods for cross-dock appointment links
+type AppointmentLinkRepository interface {
+	FindPickupsByInbound(inboundID uint) ([]models.Appointment, error)
+	SetInbound(pickupID uint, inboundID *uint) error
+}
+
+// appointmentLinkRepository implements AppointmentLinkRepository interface
+type appointmentLinkRepository struct {
+	db *gorm.DB
+}
+
+// NewAppointmentLinkRepository creates a new appointment link repository
+func NewAppointmentLinkRepository(db *gorm.DB) AppointmentLinkRepository {
+	return &appointmentLinkRepository{db: db}
+}
+
+// FindPickupsByInbound returns the pickups linked to an inbound delivery
+func (r *appointmentLinkRepository) FindPickupsByInbound(inboundID uint) ([]models.Appointment, error) {
+	var appointments []models.Appointment
+	err := r.db.Where("linked_inbound_id = ?", inboundID).
+		Preload("Supplier").Preload("Supplier.User").
+		Order("scheduled_start ASC").
+		Find(&appointments).Error
+	return appointments, err
+}
+
+// SetInbound links a pickup to an inbound delivery, or unlinks it when inboundID is nil
+func (r *appointmentLinkRepository) SetInbound(pickupID uint, inboundID *uint) error {
+	return r.db.Model(&models.Appointment{}).Where("id = ?", pickupID).
+		Update("linked_inbound_id", inboundID).Error
+}
@@ -1,454 +1,3 @@
-		Preload("Employee").Preload("Employee.User").
-		Preload("Operation").Preload("Product").
-		Find(&appointments).Error
-
-	if err != nil {
-		return nil, 0, err
-	}
-
-	return appointments, count, nil
-}
-
-// FindByOperation finds appointments by operation
-func (r *appointmentRepository) FindByOperation(operationID uint, filters AppointmentFilters) ([]models.Appointment, int64, error) {
-	var appointments []models.Appointment
-	var count int64
-
-	// Base query with operation filter
-	query := r.db.Model(&models.Appointment{}).Where("operation_id = ?", operationID)
-
-	// Apply other filters
-	query = applyAppointmentFilters(query, filters)
-
-	// Count total records
-	if err := query.Count(&count).Error; err != nil {
-		return nil, 0, err
-	}
-
-	// Apply pagination and sorting
-	if filters.Page > 0 && filters.Limit > 0 {
-		offset := (filters.Page - 1) * filters.Limit
-		query = query.Offset(offset).Limit(filters.Limit)
-	}
-
-	// Apply sorting
-	if filters.SortBy != "" {
-		sortOrder := "ASC"
-		if filters.SortOrder == "desc" {
-			sortOrder = "DESC"
-		}
-		query = query.Order(filters.SortBy + " " + sortOrder)
-	} else {
-		// Default sorting by scheduled start time
-		query = query.Order("scheduled_start ASC")
-	}
-
-	// Fetch appointments with preloaded relations
-	err := query.Preload("Supplier").Preload("Supplier.User").
-		Preload("Employee").Preload("Employee.User").
-		Preload("Operation").Preload("Product").
-		Find(&appointments).Error
-
-	if err != nil {
-		return nil, 0, err
-	}
-
-	return appointments, count, nil
-}
-
-// FindByDateRange finds appointments within a date range
-func (r *appointmentRepository) FindByDateRange(start, end time.Time, filters AppointmentFilters) ([]models.Appointment, int64, error) {
-	var appointments []models.Appointment
-	var count int64
-
-	// Base query with date range filter
-	query := r.db.Model(&models.Appointment{}).
-		Where("scheduled_start >= ? AND scheduled_start <= ?", start, end)
-
-	// Apply other filters
-	query = applyAppointmentFilters(query, filters)
-
-	// Count total records
-	if err := query.Count(&count).Error; err != nil {
-		return nil, 0, err
-	}
-
-	// Apply pagination and sorting
-	if filters.Page > 0 && filters.Limit > 0 {
-		offset := (filters.Page - 1) * filters.Limit
-		query = query.Offset(offset).Limit(filters.Limit)
-	}
-
-	// Apply sorting
-	if filters.SortBy != "" {
-		sortOrder := "ASC"
-		if filters.SortOrder == "desc" {
-			sortOrder = "DESC"
-		}
-		query = query
-
-package repository
-
-import (
-    "time"
-    "github.com/bernardofernandezz/scheduling-api/internal/models"
-    "gorm.io/gorm"
-)
-
-// AppointmentFilters defines filters for querying appointments
-type AppointmentFilters struct {
-    Status     *models.AppointmentStatus
-    StartDate  *time.Time
-    EndDate    *time.Time
-    Page       int
-    Limit      int
-    SortBy     string
-    SortOrder  string
-}
-
-// AppointmentRepository interface defines methods for appointment repository
-type AppointmentRepository interface {
-    Repository
-    FindBySupplier(supplierID uint, filters AppointmentFilters) ([]models.Appointment, int64, error)
-    FindByEmployee(employeeID uint, filters AppointmentFilters) ([]models.Appointment, int64, error)
-    FindByOperation(operationID uint, filters AppointmentFilters) ([]models.Appointment, int64, error)
-    FindByDateRange(start, end time.Time, filters AppointmentFilters) ([]models.Appointment, int64, error)
-    FindUpcoming(limit int) ([]models.Appointment, error)
-    HasConflict(appointment *models.Appointment) (bool, error)
-    UpdateStatus(id uint, status models.AppointmentStatus, reason string) error
-    List(filters AppointmentFilters) ([]models.Appointment, int64, error)
-}
-
-// appointmentRepository implements AppointmentRepository interface
-type appointmentRepository struct {
-    BaseRepository
-}
-
-// NewAppointmentRepository creates a new appointment repository
-func NewAppointmentRepository(db *gorm.DB) AppointmentRepository {
-    return &appointmentRepository{
-        BaseRepository: NewBaseRepository(db),
-    }
-}
-
-// List returns a paginated list of appointments
-func (r *appointmentRepository) List(filters AppointmentFilters) ([]models.Appointment, int64, error) {
-    var appointments []models.Appointment
-    var total int64
-
-    query := r.db.Model(&models.Appointment{})
-
-    // Apply filters
-    if filters.Status != nil {
-        query = query.Where("status = ?", *filters.Status)
-    }
-    if filters.StartDate != nil {
-        query = query.Where("scheduled_start >= ?", *filters.StartDate)
-    }
-    if filters.EndDate != nil {
-        query = query.Where("scheduled_end <= ?", *filters.EndDate)
-    }
-
-    // Get total count
-    if err := query.Count(&total).Error; err != nil {
-        return nil, 0, err
-    }
-
-    // Apply pagination
-    if filters.Page > 0 && filters.Limit > 0 {
-        offset := (filters.Page - 1) * filters.Limit
-        query = query.Offset(offset).Limit(filters.Limit)
-    }
-
-    // Apply sorting
-    if filters.SortBy != "" {
-        direction := "ASC"
-        if filters.SortOrder == "desc" {
-            direction = "DESC"
-        }
-        query = query.Order(filters.SortBy + " " + direction)
-    } else {
-        query = query.Order("scheduled_start ASC")
-    }
-
-    // Execute query with preloaded relationships
-    err := query.
-        Preload("Supplier").Preload("Supplier.User").
-        Preload("Employee").Preload("Employee.User").
-        Preload("Operation").
-        Preload("Product").
-        Find(&appointments).Error
-
-    return appointments, total, err
-}
-
-// FindBySupplier finds appointments for a specific supplier
-func (r *appointmentRepository) FindBySupplier(supplierID uint, filters AppointmentFilters) ([]models.Appointment, int64, error) {
-    var appointments []models.Appointment
-    var total int64
-
-    query := r.db.Model(&models.Appointment{}).Where("supplier_id = ?", supplierID)
-
-    // Apply filters
-    if filters.Status != nil {
-        query = query.Where("status = ?", *filters.Status)
-    }
-    if filters.StartDate != nil {
-        query = query.Where("scheduled_start >= ?", *filters.StartDate)
-    }
-    if filters.EndDate != nil {
-        query = query.Where("scheduled_end <= ?", *filters.EndDate)
-    }
-
-    // Get total count
-    if err := query.Count(&total).Error; err != nil {
-        return nil, 0, err
-    }
-
-    // Apply pagination
-    if filters.Page > 0 && filters.Limit > 0 {
-        offset := (filters.Page - 1) * filters.Limit
-        query = query.Offset(offset).Limit(filters.Limit)
-    }
-
-    // Apply sorting
-    if filters.SortBy != "" {
-        direction := "ASC"
-        if filters.SortOrder == "desc" {
-            direction = "DESC"
-        }
-        query = query.Order(filters.SortBy + " " + direction)
-    } else {
-        query = query.Order("scheduled_start ASC")
-    }
-
-    // Execute query with preloaded relationships
-    err := query.
-        Preload("Supplier").Preload("Supplier.User").
-        Preload("Employee").Preload("Employee.User").
-        Preload("Operation").
-        Preload("Product").
-        Find(&appointments).Error
-
-    return appointments, total, err
-}
-
-// FindByEmployee finds appointments for a specific employee
-func (r *appointmentRepository) FindByEmployee(employeeID uint, filters AppointmentFilters) ([]models.Appointment, int64, error) {
-    var appointments []models.Appointment
-    var total int64
-
-    query := r.db.Model(&models.Appointment{}).Where("employee_id = ?", employeeID)
-
-    // Apply filters
-    if filters.Status != nil {
-        query = query.Where("status = ?", *filters.Status)
-    }
-    if filters.StartDate != nil {
-        query = query.Where("scheduled_start >= ?", *filters.StartDate)
-    }
-    if filters.EndDate != nil {
-        query = query.Where("scheduled_end <= ?", *filters.EndDate)
-    }
-
-    // Get total count
-    if err := query.Count(&total).Error; err != nil {
-        return nil, 0, err
-    }
-
-    // Apply pagination
-    if filters.Page > 0 && filters.Limit > 0 {
-        offset := (filters.Page - 1) * filters.Limit
-        query = query.Offset(offset).Limit(filters.Limit)
-    }
-
-    // Apply sorting
-    if filters.SortBy != "" {
-        direction := "ASC"
-        if filters.SortOrder == "desc" {
-            direction = "DESC"
-        }
-        query = query.Order(filters.SortBy + " " + direction)
-    } else {
-        query = query.Order("scheduled_start ASC")
-    }
-
-    // Execute query with preloaded relationships
-    err := query.
-        Preload("Supplier").Preload("Supplier.User").
-        Preload("Employee").Preload("Employee.User").
-        Preload("Operation").
-        Preload("Product").
-        Find(&appointments).Error
-
-    return appointments, total, err
-}
-
-// FindByOperation finds appointments for a specific operation
-func (r *appointmentRepository) FindByOperation(operationID uint, filters AppointmentFilters) ([]models.Appointment, int64, error) {
-    var appointments []models.Appointment
-    var total int64
-
-    query := r.db.Model(&models.Appointment{}).Where("operation_id = ?", operationID)
-
-    // Apply filters
-    if filters.Status != nil {
-        query = query.Where("status = ?", *filters.Status)
-    }
-    if filters.StartDate != nil {
-        query = query.Where("scheduled_start >= ?", *filters.StartDate)
-    }
-    if filters.EndDate != nil {
-        query = query.Where("scheduled_end <= ?", *filters.EndDate)
-    }
-
-    // Get total count
-    if err := query.Count(&total).Error; err != nil {
-        return nil, 0, err
-    }
-
-    // Apply pagination
-    if filters.Page > 0 && filters.Limit > 0 {
-        offset := (filters.Page - 1) * filters.Limit
-        query = query.Offset(offset).Limit(filters.Limit)
-    }
-
-    // Apply sorting
-    if filters.SortBy != "" {
-        direction := "ASC"
-        if filters.SortOrder == "desc" {
-            direction = "DESC"
-        }
-        query = query.Order(filters.SortBy + " " + direction)
-    } else {
-        query = query.Order("scheduled_start ASC")
-    }
-
-    // Execute query with preloaded relationships
-    err := query.
-        Preload("Supplier").Preload("Supplier.User").
-        Preload("Employee").Preload("Employee.User").
-        Preload("Operation").
-        Preload("Product").
-        Find(&appointments).Error
-
-    return appointments, total, err
-}
-
-// FindByDateRange finds appointments within a specific date range
-func (r *appointmentRepository) FindByDateRange(start, end time.Time, filters AppointmentFilters) ([]models.Appointment, int64, error) {
-    var appointments []models.Appointment
-    var total int64
-
-    query := r.db.Model(&models.Appointment{}).
-        Where("scheduled_start >= ? AND scheduled_end <= ?", start, end)
-
-    // Apply filters
-    if filters.Status != nil {
-        query = query.Where("status = ?", *filters.Status)
-    }
-
-    // Get total count
-    if err := query.Count(&total).Error; err != nil {
-        return nil, 0, err
-    }
-
-    // Apply pagination
-    if filters.Page > 0 && filters.Limit > 0 {
-        offset := (filters.Page - 1) * filters.Limit
-        query = query.Offset(offset).Limit(filters.Limit)
-    }
-
-    // Apply sorting
-    if filters.SortBy != "" {
-        direction := "ASC"
-        if filters.SortOrder == "desc" {
-            direction = "DESC"
-        }
-        query = query.Order(filters.SortBy + " " + direction)
-    } else {
-        query = query.Order("scheduled_start ASC")
-    }
-
-    // Execute query with preloaded relationships
-    err := query.
-        Preload("Supplier").Preload("Supplier.User").
-        Preload("Employee").Preload("Employee.User").
-        Preload("Operation").
-        Preload("Product").
-        Find(&appointments).Error
-
-    return appointments, total, err
-}
-
-// FindUpcoming finds upcoming appointments
-func (r *appointmentRepository) FindUpcoming(limit int) ([]models.Appointment, error) {
-    var appointments []models.Appointment
-
-    query := r.db.Model(&models.Appointment{}).
-        Where("scheduled_start > ? AND status != ?", time.Now(), models.StatusCancelled).
-        Order("scheduled_start ASC")
-
-    if limit > 0 {
-        query = query.Limit(limit)
-    }
-
-    err := query.
-        Preload("Supplier").Preload("Supplier.User").
-        Preload("Employee").Preload("Employee.User").
-        Preload("Operation").
-        Preload("Product").
-        Find(&appointments).Error
-
-    return appointments, err
-}
-
-// HasConflict checks if an appointment conflicts with existing appointments
-func (r *appointmentRepository) HasConflict(appointment *models.Appointment) (bool, error) {
-    var count int64
-
-    // Check for conflicts with employee's schedule
-    query := r.db.Model(&models.Appointment{}).
-        Where("employee_id = ? AND id != ?", appointment.EmployeeID, appointment.ID).
-        Where("status NOT IN ?", []models.AppointmentStatus{models.StatusCancelled}).
-        Where("(scheduled_start < ? AND scheduled_end > ?) OR "+
-            "(scheduled_start >= ? AND scheduled_start < ?) OR "+
-            "(scheduled_end > ? AND scheduled_end <= ?)",
-            appointment.ScheduledEnd, appointment.ScheduledStart,
-            appointment.ScheduledStart, appointment.ScheduledEnd,
-            appointment.ScheduledStart, appointment.ScheduledEnd)
-
-    if err := query.Count(&count).Error; err != nil {
-        return false, err
-    }
-
-    if count > 0 {
-        return true, nil
-    }
-
-    // Check for conflicts with supplier's schedule
-    query = r.db.Model(&models.Appointment{}).
-        Where("supplier_id = ? AND id != ?", appointment.SupplierID, appointment.ID).
-        Where("status NOT IN ?", []models.AppointmentStatus{models.StatusCancelled}).
-        Where("(scheduled_start < ? AND scheduled_end > ?) OR "+
-            "(scheduled_start >= ? AND scheduled_start < ?) OR "+
-            "(scheduled_end > ? AND scheduled_end <= ?)",
-            appointment.ScheduledEnd, appointment.ScheduledStart,
-            appointment.ScheduledStart, appointment.ScheduledEnd,
-            appointment.ScheduledStart, appointment.ScheduledEnd)
-
-    if err := query.Count(&count).Error; err != nil {
-        return false, err
-    }
-
-    return count > 0, nil
-}
-
-// UpdateStatus updates the status of an appointment
-func (r *appointmentRepository) UpdateStatus(id uint, status models.AppointmentStatus, reason string) error {
-    var appointment models.Appointment
-    if err := r.db.First(&appointment, id).
-
 package repository
 
 import (
@@ -466,39 +15,46 @@ type AppointmentRepository interface {
 	Update(appointment *models.Appointment) error
 	Delete(id uint) error
 	List(filters AppointmentFilters) ([]models.Appointment, int64, error)
-	UpdateStatus(id uint, status models.AppointmentStatus, reason string) error
+	UpdateStatus(id uint, status models.AppointmentStatus, reason string, now time.Time) error
 	HasConflict(appointment *models.Appointment) (bool, error)
 	FindBySupplier(supplierID uint, filters AppointmentFilters) ([]models.Appointment, int64, error)
 	FindByEmployee(employeeID uint, filters AppointmentFilters) ([]models.Appointment, int64, error)
 	FindByOperation(operationID uint, filters AppointmentFilters) ([]models.Appointment, int64, error)
 	FindByDateRange(start, end time.Time, filters AppointmentFilters) ([]models.Appointment, int64, error)
-	FindUpcoming(limit int) ([]models.Appointment, error)
-	GetStatistics() (*AppointmentStatistics, error)
+	FindUpcoming(now time.Time, limit int) ([]models.Appointment, error)
+	FindByIDs(ids []uint) ([]models.Appointment, error)
+	EachBatch(filters AppointmentFilters, batchSize int, fn func(appointments []models.Appointment) error) error
+	GetStatistics(now time.Time) (*AppointmentStatistics, error)
 }
 
 // AppointmentFilters defines filters for appointment queries
 type AppointmentFilters struct {
-	Status    *models.AppointmentStatus
-	StartDate *time.Time
-	EndDate   *time.Time
-	Page      int
-	Limit     int
-	SortBy    string
-	SortOrder string
+	Status      *models.AppointmentStatus
+	Type        *models.AppointmentType
+	StartDate   *time.Time
+	EndDate     *time.Time
+	Page        int
+	Limit       int
+	SortBy      string
+	SortOrder   string
+	BookingCode string // Matches codes containing it, case-insensitively
 }
 
 // AppointmentStatistics represents appointment statistics
 type AppointmentStatistics struct {
-	TotalAppointments   int64
-	PendingAppointments int64
-	ConfirmedAppointments int64
-	CancelledAppointments int64
-	CompletedAppointments int64
+	TotalAppointments       int64
+	PendingAppointments     int64
+	ConfirmedAppointments   int64
+	CancelledAppointments   int64
+	CompletedAppointments   int64
 	RescheduledAppointments int64
-	AppointmentsByDay    map[string]int64
-	AppointmentsByMonth  map[string]int64
+	AppointmentsByDay       map[string]int64
+	AppointmentsByMonth     map[string]int64
 }
 
+// ErrAppointmentNotFound is returned when no appointment has the ID or booking code looked up
+var ErrAppointmentNotFound = errors.New("appointment not found")
+
 // appointmentRepository implements AppointmentRepository interface
 type appointmentRepository struct {
 	db *gorm.DB
@@ -547,7 +103,7 @@ func (r *appointmentRepository) FindByID(id uint) (*models.Appointment, error) {
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("appointment not found")
+			return nil, ErrAppointmentNotFound
 		}
 		return nil, err
 	}
@@ -588,8 +144,9 @@ func (r *appointmentRepository) Delete(id uint) error {
 	return r.db.Delete(&models.Appointment{}, id).Error
 }
 
-// UpdateStatus updates an appointment's status
-func (r *appointmentRepository) UpdateStatus(id uint, status models.AppointmentStatus, reason string) error {
+// UpdateStatus updates an appointment's status, stamping the confirmation, cancellation or
+// completion with now
+func (r *appointmentRepository) UpdateStatus(id uint, status models.AppointmentStatus, reason string, now time.Time) error {
 	appointment, err := r.FindByID(id)
 	if err != nil {
 		return err
@@ -597,7 +154,6 @@ func (r *appointmentRepository) UpdateStatus(id uint, status models.AppointmentS
 
 	// Update status and related fields
 	appointment.Status = status
-	now := time.Now()
 
 	switch status {
 	case models.StatusConfirmed:
@@ -865,5 +421,143 @@ func (r *appointmentRepository) FindByDateRange(start, end time.Time, filters Ap
 	// Apply pagination and sorting
 	if filters.Page > 0 && filters.Limit > 0 {
 		offset := (filters.Page - 1) * filters.Limit
-		query = query.Offset(offset).Limit(filters.
+		query = query.Offset(offset).Limit(filters.Limit)
+	}
+
+	// Apply sorting
+	if filters.SortBy != "" {
+		sortOrder := "ASC"
+		if filters.SortOrder == "desc" {
+			sortOrder = "DESC"
+		}
+		query = query.Order(filters.SortBy + " " + sortOrder)
+	} else {
+		// Default sorting by scheduled start time
+		query = query.Order("scheduled_start ASC")
+	}
+
+	// Fetch appointments with preloaded relations
+	err := query.Preload("Supplier").Preload("Supplier.User").
+		Preload("Employee").Preload("Employee.User").
+		Preload("Operation").Preload("Product").
+		Find(&appointments).Error
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return appointments, count, nil
+}
+
+// FindUpcoming finds the appointments starting after now that aren't cancelled
+func (r *appointmentRepository) FindUpcoming(now time.Time, limit int) ([]models.Appointment, error) {
+	var appointments []models.Appointment
+
+	query := r.db.Model(&models.Appointment{}).
+		Where("scheduled_start > ? AND status != ?", now, models.StatusCancelled).
+		Order("scheduled_start ASC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	err := query.Preload("Supplier").Preload("Supplier.User").
+		Preload("Employee").Preload("Employee.User").
+		Preload("Operation").Preload("Product").
+		Find(&appointments).Error
+
+	return appointments, err
+}
+
+// FindByIDs finds the appointments with the IDs, without their relations. Missing IDs are left out.
+func (r *appointmentRepository) FindByIDs(ids []uint) ([]models.Appointment, error) {
+	var appointments []models.Appointment
+	if len(ids) == 0 {
+		return appointments, nil
+	}
+	err := r.db.Where("id IN ?", ids).Find(&appointments).Error
+	return appointments, err
+}
 
+// EachBatch calls fn with the appointments matching the filters, batchSize at a time in ID
+// order. The next batch is only read once fn returns, so a slow consumer holds back the reads
+// instead of piling rows up in memory, and an error from fn stops it. Pagination and sorting are
+// ignored. The slice is reused between batches and must not be kept.
+func (r *appointmentRepository) EachBatch(filters AppointmentFilters, batchSize int, fn func(appointments []models.Appointment) error) error {
+	var appointments []models.Appointment
+
+	query := applyAppointmentFilters(r.db.Model(&models.Appointment{}), filters)
+	return query.Preload("Supplier").Preload("Supplier.User").
+		Preload("Employee").Preload("Employee.User").
+		Preload("Operation").Preload("Product").
+		FindInBatches(&appointments, batchSize, func(tx *gorm.DB, batch int) error {
+			return fn(appointments)
+		}).Error
+}
+
+// GetStatistics counts appointments by status, by day over the 30 days and by month over the 12
+// months before now
+func (r *appointmentRepository) GetStatistics(now time.Time) (*AppointmentStatistics, error) {
+	stats := &AppointmentStatistics{
+		AppointmentsByDay:   make(map[string]int64),
+		AppointmentsByMonth: make(map[string]int64),
+	}
+
+	var byStatus []struct {
+		Status models.AppointmentStatus
+		Count  int64
+	}
+	if err := r.db.Model(&models.Appointment{}).
+		Select("status, COUNT(*) AS count").
+		Group("status").
+		Scan(&byStatus).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range byStatus {
+		stats.TotalAppointments += row.Count
+		switch row.Status {
+		case models.StatusPending:
+			stats.PendingAppointments = row.Count
+		case models.StatusConfirmed:
+			stats.ConfirmedAppointments = row.Count
+		case models.StatusCancelled:
+			stats.CancelledAppointments = row.Count
+		case models.StatusCompleted:
+			stats.CompletedAppointments = row.Count
+		case models.StatusRescheduled:
+			stats.RescheduledAppointments = row.Count
+		}
+	}
+
+	var byDay []struct {
+		Day   string
+		Count int64
+	}
+	if err := r.db.Model(&models.Appointment{}).
+		Select("TO_CHAR(scheduled_start, 'YYYY-MM-DD') AS day, COUNT(*) AS count").
+		Where("scheduled_start >= ?", now.AddDate(0, 0, -30)).
+		Group("day").
+		Scan(&byDay).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range byDay {
+		stats.AppointmentsByDay[row.Day] = row.Count
+	}
+
+	var byMonth []struct {
+		Month string
+		Count int64
+	}
+	if err := r.db.Model(&models.Appointment{}).
+		Select("TO_CHAR(scheduled_start, 'YYYY-MM') AS month, COUNT(*) AS count").
+		Where("scheduled_start >= ?", now.AddDate(0, -12, 0)).
+		Group("month").
+		Scan(&byMonth).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range byMonth {
+		stats.AppointmentsByMonth[row.Month] = row.Count
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// benchmarkCalendarDays is how many days of booked calendar the conflict benchmarks search
+const benchmarkCalendarDays = 90
+
+// seedCalendar books an employee and a supplier eight hourly appointments a day, from 08:00, for
+// benchmarkCalendarDays days after from, and removes them when tb ends
+func seedCalendar(tb testing.TB, db *gorm.DB, from time.Time) *testParties {
+	tb.Helper()
+	parties := seedParties(tb, db)
+
+	appointments := make([]models.Appointment, 0, benchmarkCalendarDays*8)
+	for day := 0; day < benchmarkCalendarDays; day++ {
+		for hour := 8; hour < 16; hour++ {
+			start := from.AddDate(0, 0, day).Add(time.Duration(hour) * time.Hour)
+			appointments = append(appointments, parties.appointment(start))
+		}
+	}
+	if err := db.Omit("Supplier", "Employee", "Operation", "Product").CreateInBatches(appointments, 200).Error; err != nil {
+		tb.Fatalf("appointments: %v", err)
+	}
+	return parties
+}
+
+// BenchmarkHasConflict measures the conflict check a booking runs before it is saved, against a
+// calendar of benchmarkCalendarDays fully booked days, so changes to its locking and the
+// appointment indexes can be compared with go test -bench HasConflict -benchmem
+func BenchmarkHasConflict(b *testing.B) {
+	db := newTestDB(b)
+	from := time.Date(2030, time.January, 7, 0, 0, 0, 0, time.UTC)
+	parties := seedCalendar(b, db, from)
+	repo := NewAppointmentRepository(db)
+
+	// Halfway through the calendar: overlapping the 10:00 booking, and the free evening
+	day := from.AddDate(0, 0, benchmarkCalendarDays/2)
+	candidates := []struct {
+		name  string
+		start time.Time
+		want  bool
+	}{
+		{"conflict", day.Add(10*time.Hour + 30*time.Minute), true},
+		{"free", day.Add(18 * time.Hour), false},
+	}
+
+	for _, candidate := range candidates {
+		appointment := parties.appointment(candidate.start)
+
+		b.Run(candidate.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				conflict, err := repo.HasConflict(&appointment)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if conflict != candidate.want {
+					b.Fatalf("HasConflict = %v, want %v", conflict, candidate.want)
+				}
+			}
+		})
+
+		// Concurrent bookings for the same employee, as when several suppliers race for a slot
+		b.Run(candidate.name+"/parallel", func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if _, err := repo.HasConflict(&appointment); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// AvailabilityExceptionRepository interface defines methods for employee availability exceptions
+type AvailabilityExceptionRepository interface {
+	Create(exception *models.AvailabilityException) error
+	FindByID(id uint) (*models.AvailabilityException, error)
+	FindByEmployee(employeeID uint) ([]models.AvailabilityException, error)
+	FindOverlapping(employeeID uint, start, end time.Time) ([]models.AvailabilityException, error)
+	FindAffectedAppointments(exception *models.AvailabilityException) ([]models.Appointment, error)
+	Delete(id uint) error
+}
+
+// availabilityExceptionRepository implements AvailabilityExceptionRepository interface
+type availabilityExceptionRepository struct {
+	db *gorm.DB
+}
+
+// NewAvailabilityExceptionRepository creates a new availability exception repository
+func NewAvailabilityExceptionRepository(db *gorm.DB) AvailabilityExceptionRepository {
+	return &availabilityExceptionRepository{db: db}
+}
+
+// Create records an availability exception
+func (r *availabilityExceptionRepository) Create(exception *models.AvailabilityException) error {
+	return r.db.Create(exception).Error
+}
+
+// FindByID finds an availability exception by ID
+func (r *availabilityExceptionRepository) FindByID(id uint) (*models.AvailabilityException, error) {
+	var exception models.AvailabilityException
+	err := r.db.First(&exception, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("availability exception not found")
+		}
+		return nil, err
+	}
+	return &exception, nil
+}
+
+// FindByEmployee returns an employee's availability exceptions, latest first
+func (r *availabilityExceptionRepository) FindByEmployee(employeeID uint) ([]models.AvailabilityException, error) {
+	var exceptions []models.AvailabilityException
+	err := r.db.Where("employee_id = ?", employeeID).
+		Order("starts_at DESC").
+		Find(&exceptions).Error
+	return exceptions, err
+}
+
+// FindOverlapping returns an employee's exceptions overlapping a period
+func (r *availabilityExceptionRepository) FindOverlapping(employeeID uint, start, end time.Time) ([]models.AvailabilityException, error) {
+	var exceptions []models.AvailabilityException
+	err := r.db.Where("employee_id = ? AND starts_at < ? AND ends_at > ?", employeeID, end, start).
+		Order("starts_at ASC").
+		Find(&exceptions).Error
+	return exceptions, err
+}
+
+// FindAffectedAppointments returns the employee's open appointments overlapping an exception
+func (r *availabilityExceptionRepository) FindAffectedAppointments(exception *models.AvailabilityException) ([]models.Appointment, error) {
+	var appointments []models.Appointment
+	query := r.db.Where("employee_id = ? AND scheduled_start < ? AND scheduled_end > ?",
+		exception.EmployeeID, exception.EndsAt, exception.StartsAt).
+		Where("status NOT IN ?", []models.AppointmentStatus{
+			models.StatusCancelled, models.StatusCompleted, models.StatusPartiallyCompleted,
+		})
+	if exception.OperationID != nil {
+		query = query.Where("operation_id = ?", *exception.OperationID)
+	}
+	err := query.Preload("Supplier").
+		Order("scheduled_start ASC").
+		Find(&appointments).Error
+	return appointments, err
+}
+
+// Delete deletes an availability exception
+func (r *availabilityExceptionRepository) Delete(id uint) error {
+	return r.db.Delete(&models.AvailabilityException{}, id).Error
+}
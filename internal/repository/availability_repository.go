@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// AvailabilityRepository interface defines methods for availability slot repository
+type AvailabilityRepository interface {
+	Create(slot *models.AvailabilitySlot) error
+	FindByID(id uint) (*models.AvailabilitySlot, error)
+	FindByEmployee(employeeID uint) ([]models.AvailabilitySlot, error)
+	FindByOperation(operationID uint) ([]models.AvailabilitySlot, error)
+	Update(slot *models.AvailabilitySlot) error
+	Delete(id uint) error
+	ReplaceRecurring(employeeID uint, operationIDs []uint, slots []models.AvailabilitySlot) (int64, error)
+	CreateBatch(slots []models.AvailabilitySlot) error
+	UpdateBatch(slots []models.AvailabilitySlot) error
+}
+
+// availabilityRepository implements AvailabilityRepository interface
+type availabilityRepository struct {
+	db *gorm.DB
+}
+
+// NewAvailabilityRepository creates a new availability repository
+func NewAvailabilityRepository(db *gorm.DB) AvailabilityRepository {
+	return &availabilityRepository{db: db}
+}
+
+// Create creates a new availability slot
+func (r *availabilityRepository) Create(slot *models.AvailabilitySlot) error {
+	return r.db.Create(slot).Error
+}
+
+// FindByID finds an availability slot by ID
+func (r *availabilityRepository) FindByID(id uint) (*models.AvailabilitySlot, error) {
+	var slot models.AvailabilitySlot
+	err := r.db.Preload("Employee").First(&slot, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("availability slot not found")
+		}
+		return nil, err
+	}
+	return &slot, nil
+}
+
+// FindByEmployee finds all availability slots for an employee
+func (r *availabilityRepository) FindByEmployee(employeeID uint) ([]models.AvailabilitySlot, error) {
+	var slots []models.AvailabilitySlot
+	err := r.db.Where("employee_id = ?", employeeID).
+		Order("day_of_week ASC, start_time ASC").
+		Find(&slots).Error
+	return slots, err
+}
+
+// FindByOperation finds all availability slots for an operation
+func (r *availabilityRepository) FindByOperation(operationID uint) ([]models.AvailabilitySlot, error) {
+	var slots []models.AvailabilitySlot
+	err := r.db.Preload("Employee").
+		Where("operation_id = ?", operationID).
+		Order("employee_id ASC, day_of_week ASC, start_time ASC").
+		Find(&slots).Error
+	return slots, err
+}
+
+// Update updates an availability slot
+func (r *availabilityRepository) Update(slot *models.AvailabilitySlot) error {
+	return r.db.Save(slot).Error
+}
+
+// Delete deletes an availability slot
+func (r *availabilityRepository) Delete(id uint) error {
+	return r.db.Delete(&models.AvailabilitySlot{}, id).Error
+}
+
+// ReplaceRecurring deletes an employee's recurring slots at the given operations and creates the
+// new slots in one transaction. It returns how many slots were deleted.
+func (r *availabilityRepository) ReplaceRecurring(employeeID uint, operationIDs []uint, slots []models.AvailabilitySlot) (int64, error) {
+	var removed int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if len(operationIDs) > 0 {
+			result := tx.Where("employee_id = ? AND is_recurring = ? AND operation_id IN ?", employeeID, true, operationIDs).
+				Delete(&models.AvailabilitySlot{})
+			if result.Error != nil {
+				return result.Error
+			}
+			removed = result.RowsAffected
+		}
+		if len(slots) == 0 {
+			return nil
+		}
+		return tx.Create(&slots).Error
+	})
+	return removed, err
+}
+
+// CreateBatch creates availability slots in one transaction
+func (r *availabilityRepository) CreateBatch(slots []models.AvailabilitySlot) error {
+	if len(slots) == 0 {
+		return nil
+	}
+	return r.db.Create(&slots).Error
+}
+
+// UpdateBatch saves availability slots in one transaction, so either all change or none
+func (r *availabilityRepository) UpdateBatch(slots []models.AvailabilitySlot) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i := range slots {
+			if err := tx.Omit("Employee", "Operation").Save(&slots[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
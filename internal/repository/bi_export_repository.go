@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// biChangedAt is when an appointment last changed, its deletion included, in the fact query
+// where the appointments table is aliased
+const biChangedAt = "GREATEST(a.updated_at, COALESCE(a.deleted_at, a.updated_at))"
+
+// AppointmentFact is an appointment as the BI export reads it: the columns the export keeps,
+// with its operation's code and product's category instead of the related records
+type AppointmentFact struct {
+	ID                uint
+	OperationCode     string
+	Type              string
+	Status            string
+	ProductCategory   string
+	SupplierID        uint
+	EmployeeID        uint
+	ScheduledStart    time.Time
+	ScheduledEnd      time.Time
+	QuantityToDeliver int
+	ReceivedQuantity  *int
+	CreatedAt         time.Time
+	ConfirmedAt       *time.Time
+	CheckedInAt       *time.Time
+	CompletedAt       *time.Time
+	CancelledAt       *time.Time
+	AutoCompletedAt   *time.Time
+	OverdueFlaggedAt  *time.Time
+	FollowUpOfID      *uint
+	CostCenter        string
+	ChangedAt         time.Time
+	Deleted           bool
+}
+
+// BIExportRepository interface defines methods for BI export runs and the appointment facts
+// they export
+type BIExportRepository interface {
+	CreateRun(run *models.BIExportRun) error
+	UpdateRun(run *models.BIExportRun) error
+	FindRun(id uint) (*models.BIExportRun, error)
+	LastSucceeded() (*models.BIExportRun, error)
+	ListRuns(limit int) ([]models.BIExportRun, error)
+	AppointmentFacts(after SyncPosition, until time.Time, limit int) ([]AppointmentFact, error)
+}
+
+// biExportRepository implements BIExportRepository interface
+type biExportRepository struct {
+	db *gorm.DB
+}
+
+// ErrBIExportRunNotFound is returned when no BI export run has the ID looked up
+var ErrBIExportRunNotFound = errors.New("BI export run not found")
+
+// NewBIExportRepository creates a new BI export repository
+func NewBIExportRepository(db *gorm.DB) BIExportRepository {
+	return &biExportRepository{db: db}
+}
+
+// CreateRun adds a BI export run
+func (r *biExportRepository) CreateRun(run *models.BIExportRun) error {
+	return r.db.Create(run).Error
+}
+
+// UpdateRun saves a BI export run
+func (r *biExportRepository) UpdateRun(run *models.BIExportRun) error {
+	return r.db.Save(run).Error
+}
+
+// FindRun finds a BI export run by ID
+func (r *biExportRepository) FindRun(id uint) (*models.BIExportRun, error) {
+	var run models.BIExportRun
+	if err := r.db.First(&run, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBIExportRunNotFound
+		}
+		return nil, err
+	}
+	return &run, nil
+}
+
+// LastSucceeded returns the succeeded run that exported up to the latest time, nil when no run
+// succeeded yet
+func (r *biExportRepository) LastSucceeded() (*models.BIExportRun, error) {
+	var run models.BIExportRun
+	err := r.db.Where("status = ?", models.BIExportSucceeded).
+		Order("changed_until DESC").
+		First(&run).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// ListRuns returns the latest BI export runs, newest first
+func (r *biExportRepository) ListRuns(limit int) ([]models.BIExportRun, error) {
+	var runs []models.BIExportRun
+	err := r.db.Order("started_at DESC, id DESC").Limit(limit).Find(&runs).Error
+	return runs, err
+}
+
+// AppointmentFacts returns up to limit appointments changed after a position and no later than
+// until, in change order. Deleted appointments are included so the warehouse can drop them.
+func (r *biExportRepository) AppointmentFacts(after SyncPosition, until time.Time, limit int) ([]AppointmentFact, error) {
+	var facts []AppointmentFact
+	err := r.db.Table("appointments AS a").
+		Select(`a.id, o.code AS operation_code, a.type, a.status,
+			COALESCE(p.category, '') AS product_category, a.supplier_id, a.employee_id,
+			a.scheduled_start, a.scheduled_end, a.quantity_to_deliver, a.received_quantity,
+			a.created_at, a.confirmed_at, a.checked_in_at, a.completed_at, a.cancelled_at,
+			a.auto_completed_at, a.overdue_flagged_at, a.follow_up_of_id, a.cost_center,
+			`+biChangedAt+` AS changed_at, a.deleted_at IS NOT NULL AS deleted`).
+		Joins("LEFT JOIN operations AS o ON o.id = a.operation_id").
+		Joins("LEFT JOIN products AS p ON p.id = a.product_id").
+		Where("("+biChangedAt+", a.id) > (?, ?)", after.ChangedAt, after.ID).
+		Where(biChangedAt+" <= ?", until).
+		Order(biChangedAt + " ASC, a.id ASC").
+		Limit(limit).
+		Scan(&facts).Error
+	return facts, err
+}
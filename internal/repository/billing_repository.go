@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// BillingFilters defines filters for the billing export
+type BillingFilters struct {
+	OperationID *uint
+	Start       time.Time // appointments starting at or after
+	End         time.Time // appointments starting before
+}
+
+// DockTimeRow sums the dock time of a supplier's completed appointments charged to a cost center
+type DockTimeRow struct {
+	SupplierID   uint    `json:"supplier_id"`
+	SupplierName string  `json:"supplier_name"`
+	CostCenter   string  `json:"cost_center"` // empty for appointments without one
+	Appointments int64   `json:"appointments"`
+	DockMinutes  float64 `json:"dock_minutes"`
+}
+
+// FeeRow sums the fees a supplier was charged for appointments of a cost center, per kind and
+// currency
+type FeeRow struct {
+	SupplierID   uint            `json:"supplier_id"`
+	SupplierName string          `json:"supplier_name"`
+	CostCenter   string          `json:"cost_center"`
+	Kind         models.FeeKind  `json:"kind"`
+	Currency     string          `json:"currency"`
+	Fees         int64           `json:"fees"`
+	Amount       decimal.Decimal `json:"amount"`
+}
+
+// BillingRepository interface defines methods for billing codes and dock time reporting
+type BillingRepository interface {
+	ListCodes(codeType models.BillingCodeType) ([]models.BillingCode, error)
+	FindCodeByID(id uint) (*models.BillingCode, error)
+	FindCode(codeType models.BillingCodeType, code string) (*models.BillingCode, error)
+	CreateCode(code *models.BillingCode) error
+	UpdateCode(code *models.BillingCode) error
+	DockTime(filters BillingFilters) ([]DockTimeRow, error)
+	Fees(filters BillingFilters) ([]FeeRow, error)
+}
+
+// billingRepository implements BillingRepository interface
+type billingRepository struct {
+	db *gorm.DB
+}
+
+// NewBillingRepository creates a new billing repository
+func NewBillingRepository(db *gorm.DB) BillingRepository {
+	return &billingRepository{db: db}
+}
+
+// ListCodes returns the billing codes of a type, or of every type when empty, ordered by code
+func (r *billingRepository) ListCodes(codeType models.BillingCodeType) ([]models.BillingCode, error) {
+	var codes []models.BillingCode
+	query := r.db.Order("type, code")
+	if codeType != "" {
+		query = query.Where("type = ?", codeType)
+	}
+	err := query.Find(&codes).Error
+	return codes, err
+}
+
+// FindCodeByID finds a billing code by ID
+func (r *billingRepository) FindCodeByID(id uint) (*models.BillingCode, error) {
+	var code models.BillingCode
+	if err := r.db.First(&code, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("billing code not found")
+		}
+		return nil, err
+	}
+	return &code, nil
+}
+
+// FindCode finds a billing code of a type by its code
+func (r *billingRepository) FindCode(codeType models.BillingCodeType, code string) (*models.BillingCode, error) {
+	var billingCode models.BillingCode
+	err := r.db.Where("type = ? AND code = ?", codeType, code).First(&billingCode).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("billing code not found")
+		}
+		return nil, err
+	}
+	return &billingCode, nil
+}
+
+// CreateCode adds a billing code
+func (r *billingRepository) CreateCode(code *models.BillingCode) error {
+	return r.db.Create(code).Error
+}
+
+// UpdateCode saves a billing code
+func (r *billingRepository) UpdateCode(code *models.BillingCode) error {
+	return r.db.Save(code).Error
+}
+
+// DockTime sums, per supplier and cost center, the minutes completed appointments occupied the
+// dock: from check-in to completion when both were recorded, the scheduled slot otherwise
+func (r *billingRepository) DockTime(filters BillingFilters) ([]DockTimeRow, error) {
+	var rows []DockTimeRow
+
+	query := r.db.Model(&models.Appointment{}).
+		Select(
+			"appointments.supplier_id, suppliers.company_name AS supplier_name, appointments.cost_center, "+
+				"COUNT(*) AS appointments, "+
+				"COALESCE(SUM(EXTRACT(EPOCH FROM CASE "+
+				"WHEN appointments.checked_in_at IS NOT NULL AND appointments.completed_at > appointments.checked_in_at "+
+				"THEN appointments.completed_at - appointments.checked_in_at "+
+				"ELSE appointments.scheduled_end - appointments.scheduled_start END)) / 60, 0) AS dock_minutes",
+		).
+		Joins("JOIN suppliers ON suppliers.id = appointments.supplier_id").
+		Where("appointments.status IN ?", []models.AppointmentStatus{models.StatusCompleted, models.StatusPartiallyCompleted}).
+		Where("appointments.scheduled_start >= ? AND appointments.scheduled_start < ?", filters.Start, filters.End)
+
+	if filters.OperationID != nil {
+		query = query.Where("appointments.operation_id = ?", *filters.OperationID)
+	}
+
+	err := query.
+		Group("appointments.supplier_id, suppliers.company_name, appointments.cost_center").
+		Order("suppliers.company_name, appointments.cost_center").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// Fees sums, per supplier, cost center, kind and currency, the fees still charged for appointments
+// scheduled in the period. Disputed fees are left out until they are upheld, waived ones for good.
+func (r *billingRepository) Fees(filters BillingFilters) ([]FeeRow, error) {
+	var rows []FeeRow
+
+	query := r.db.Model(&models.Fee{}).
+		Select(
+			"fees.supplier_id, suppliers.company_name AS supplier_name, appointments.cost_center, "+
+				"fees.kind, fees.currency, COUNT(*) AS fees, SUM(fees.amount) AS amount",
+		).
+		Joins("JOIN appointments ON appointments.id = fees.appointment_id AND appointments.deleted_at IS NULL").
+		Joins("JOIN suppliers ON suppliers.id = fees.supplier_id").
+		Where("fees.status = ?", models.FeeCharged).
+		Where("appointments.scheduled_start >= ? AND appointments.scheduled_start < ?", filters.Start, filters.End)
+
+	if filters.OperationID != nil {
+		query = query.Where("fees.operation_id = ?", *filters.OperationID)
+	}
+
+	err := query.
+		Group("fees.supplier_id, suppliers.company_name, appointments.cost_center, fees.kind, fees.currency").
+		Order("suppliers.company_name, appointments.cost_center, fees.kind").
+		Scan(&rows).Error
+	return rows, err
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// BlackoutRepository interface defines methods for operation blackout calendars
+type BlackoutRepository interface {
+	FindByOperation(operationID uint, from, to time.Time) ([]models.OperationBlackout, error)
+	FindByDate(operationID uint, date time.Time) (*models.OperationBlackout, error)
+	Save(blackout *models.OperationBlackout) error
+	Delete(id uint) error
+	ReplaceHolidays(operationID uint, from, to time.Time, holidays []models.OperationBlackout) (int, error)
+}
+
+// blackoutRepository implements BlackoutRepository interface
+type blackoutRepository struct {
+	db *gorm.DB
+}
+
+// NewBlackoutRepository creates a new blackout repository
+func NewBlackoutRepository(db *gorm.DB) BlackoutRepository {
+	return &blackoutRepository{db: db}
+}
+
+// FindByOperation returns an operation's blackout dates between from and to, both included,
+// ordered by date
+func (r *blackoutRepository) FindByOperation(operationID uint, from, to time.Time) ([]models.OperationBlackout, error) {
+	var blackouts []models.OperationBlackout
+	err := r.db.Where("operation_id = ? AND date BETWEEN ? AND ?",
+		operationID, from.Format(models.BlackoutDateLayout), to.Format(models.BlackoutDateLayout)).
+		Order("date ASC").
+		Find(&blackouts).Error
+	return blackouts, err
+}
+
+// FindByDate finds an operation's blackout on a date
+func (r *blackoutRepository) FindByDate(operationID uint, date time.Time) (*models.OperationBlackout, error) {
+	var blackout models.OperationBlackout
+	err := r.db.Where("operation_id = ? AND date = ?", operationID, date.Format(models.BlackoutDateLayout)).
+		First(&blackout).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("blackout not found")
+		}
+		return nil, err
+	}
+	return &blackout, nil
+}
+
+// Save creates a blackout date or updates an existing one
+func (r *blackoutRepository) Save(blackout *models.OperationBlackout) error {
+	return r.db.Save(blackout).Error
+}
+
+// Delete removes a blackout date. It is deleted for good so the date can be set again.
+func (r *blackoutRepository) Delete(id uint) error {
+	return r.db.Unscoped().Delete(&models.OperationBlackout{}, id).Error
+}
+
+// ReplaceHolidays swaps an operation's imported holidays between from and to for the given ones
+// in one transaction. Dates with a manual blackout keep it; the number of holidays stored is
+// returned.
+func (r *blackoutRepository) ReplaceHolidays(operationID uint, from, to time.Time, holidays []models.OperationBlackout) (int, error) {
+	stored := 0
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		start, end := from.Format(models.BlackoutDateLayout), to.Format(models.BlackoutDateLayout)
+		if err := tx.Unscoped().
+			Where("operation_id = ? AND source = ? AND date BETWEEN ? AND ?", operationID, models.BlackoutHoliday, start, end).
+			Delete(&models.OperationBlackout{}).Error; err != nil {
+			return err
+		}
+
+		var manual []models.OperationBlackout
+		if err := tx.Where("operation_id = ? AND date BETWEEN ? AND ?", operationID, start, end).
+			Find(&manual).Error; err != nil {
+			return err
+		}
+		overridden := make(map[string]bool, len(manual))
+		for _, blackout := range manual {
+			overridden[blackout.DateKey()] = true
+		}
+
+		keep := make([]models.OperationBlackout, 0, len(holidays))
+		for _, holiday := range holidays {
+			if overridden[holiday.DateKey()] {
+				continue
+			}
+			holiday.OperationID = operationID
+			keep = append(keep, holiday)
+		}
+		if len(keep) == 0 {
+			return nil
+		}
+		if err := tx.Create(&keep).Error; err != nil {
+			return err
+		}
+		stored = len(keep)
+		return nil
+	})
+	return stored, err
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// BookingCodeRepository interface defines methods for numbering appointments per operation
+type BookingCodeRepository interface {
+	NextNumber(operationID uint, year int, now time.Time) (int, error)
+	FindAppointment(code string) (*models.Appointment, error)
+}
+
+// bookingCodeRepository implements BookingCodeRepository interface
+type bookingCodeRepository struct {
+	db *gorm.DB
+}
+
+// NewBookingCodeRepository creates a new booking code repository
+func NewBookingCodeRepository(db *gorm.DB) BookingCodeRepository {
+	return &bookingCodeRepository{db: db}
+}
+
+// NextNumber reserves the next booking number of an operation in a year. The increment is a
+// single upsert, so concurrent bookings never get the same number; a number reserved by a
+// booking that then fails is not reused.
+func (r *bookingCodeRepository) NextNumber(operationID uint, year int, now time.Time) (int, error) {
+	var number int
+	err := r.db.Raw(`INSERT INTO booking_sequences (operation_id, year, last_number, updated_at)
+		VALUES (?, ?, 1, ?)
+		ON CONFLICT (operation_id, year)
+		DO UPDATE SET last_number = booking_sequences.last_number + 1, updated_at = EXCLUDED.updated_at
+		RETURNING last_number`, operationID, year, now).
+		Scan(&number).Error
+	return number, err
+}
+
+// FindAppointment finds the appointment with a booking code, ignoring case
+func (r *bookingCodeRepository) FindAppointment(code string) (*models.Appointment, error) {
+	var appointment models.Appointment
+	err := r.db.Preload("Supplier").
+		Preload("Employee").
+		Preload("Operation").
+		Preload("Product").
+		Where("booking_code = ?", strings.ToUpper(strings.TrimSpace(code))).
+		First(&appointment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAppointmentNotFound
+		}
+		return nil, err
+	}
+	return &appointment, nil
+}
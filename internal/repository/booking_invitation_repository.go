@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// BookingInvitationRepository interface defines methods for booking invitation repository
+type BookingInvitationRepository interface {
+	Create(invitation *models.BookingInvitation) error
+	FindByID(id uint) (*models.BookingInvitation, error)
+	FindByTokenHash(hash string) (*models.BookingInvitation, error)
+	FindByInviter(inviterID uint) ([]models.BookingInvitation, error)
+	Claim(id uint, at time.Time) (bool, error)
+	Release(id uint) error
+	SetAppointment(id, appointmentID uint) error
+	Revoke(id uint, at time.Time) error
+}
+
+// bookingInvitationRepository implements BookingInvitationRepository interface
+type bookingInvitationRepository struct {
+	db *gorm.DB
+}
+
+// NewBookingInvitationRepository creates a new booking invitation repository
+func NewBookingInvitationRepository(db *gorm.DB) BookingInvitationRepository {
+	return &bookingInvitationRepository{db: db}
+}
+
+// Create creates a new booking invitation
+func (r *bookingInvitationRepository) Create(invitation *models.BookingInvitation) error {
+	return r.db.Create(invitation).Error
+}
+
+// FindByID finds a booking invitation by ID
+func (r *bookingInvitationRepository) FindByID(id uint) (*models.BookingInvitation, error) {
+	var invitation models.BookingInvitation
+	if err := r.db.First(&invitation, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("booking invitation not found")
+		}
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// FindByTokenHash finds the booking invitation whose token has a hash
+func (r *bookingInvitationRepository) FindByTokenHash(hash string) (*models.BookingInvitation, error) {
+	var invitation models.BookingInvitation
+	if err := r.db.Where("token_hash = ?", hash).First(&invitation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("booking invitation not found")
+		}
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// FindByInviter returns the invitations sent on behalf of an employee, newest first
+func (r *bookingInvitationRepository) FindByInviter(inviterID uint) ([]models.BookingInvitation, error) {
+	var invitations []models.BookingInvitation
+	err := r.db.Where("inviter_id = ?", inviterID).Order("created_at DESC").Find(&invitations).Error
+	return invitations, err
+}
+
+// Claim marks an unused, unrevoked invitation as used and reports whether it was available.
+// The check and the update are one statement, so two bookings cannot both claim it.
+func (r *bookingInvitationRepository) Claim(id uint, at time.Time) (bool, error) {
+	result := r.db.Model(&models.BookingInvitation{}).
+		Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", id).
+		Update("used_at", at)
+	return result.RowsAffected > 0, result.Error
+}
+
+// Release makes a claimed invitation usable again after its booking failed
+func (r *bookingInvitationRepository) Release(id uint) error {
+	return r.db.Model(&models.BookingInvitation{}).
+		Where("id = ? AND appointment_id IS NULL", id).
+		Update("used_at", nil).Error
+}
+
+// SetAppointment records the appointment booked through an invitation
+func (r *bookingInvitationRepository) SetAppointment(id, appointmentID uint) error {
+	return r.db.Model(&models.BookingInvitation{}).Where("id = ?", id).
+		Update("appointment_id", appointmentID).Error
+}
+
+// Revoke disables an invitation
+func (r *bookingInvitationRepository) Revoke(id uint, at time.Time) error {
+	return r.db.Model(&models.BookingInvitation{}).Where("id = ?", id).
+		Update("revoked_at", at).Error
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// BroadcastRepository interface defines methods for admin broadcasts and the audiences they reach
+type BroadcastRepository interface {
+	Create(broadcast *models.Broadcast) error
+	Update(broadcast *models.Broadcast) error
+	List(limit int) ([]models.Broadcast, error)
+	SupplierAudience(operationID *uint, from, to *time.Time, limit int) ([]uint, error)
+	EmployeeAudience(limit int) ([]uint, error)
+}
+
+// broadcastRepository implements BroadcastRepository interface
+type broadcastRepository struct {
+	db *gorm.DB
+}
+
+// NewBroadcastRepository creates a new broadcast repository
+func NewBroadcastRepository(db *gorm.DB) BroadcastRepository {
+	return &broadcastRepository{db: db}
+}
+
+// Create records a broadcast
+func (r *broadcastRepository) Create(broadcast *models.Broadcast) error {
+	return r.db.Create(broadcast).Error
+}
+
+// Update updates a broadcast
+func (r *broadcastRepository) Update(broadcast *models.Broadcast) error {
+	return r.db.Save(broadcast).Error
+}
+
+// List returns the latest broadcasts, newest first
+func (r *broadcastRepository) List(limit int) ([]models.Broadcast, error) {
+	var broadcasts []models.Broadcast
+	err := r.db.Order("created_at DESC").Limit(limit).Find(&broadcasts).Error
+	return broadcasts, err
+}
+
+// SupplierAudience returns the IDs of the suppliers with active users that have appointments,
+// at an operation when one is given and starting in [from, to) when a range is given. Cancelled
+// appointments only count without a range, so suppliers that ever booked at an operation are
+// all reached.
+func (r *broadcastRepository) SupplierAudience(operationID *uint, from, to *time.Time, limit int) ([]uint, error) {
+	query := r.db.Model(&models.Appointment{}).
+		Joins("JOIN suppliers ON suppliers.id = appointments.supplier_id AND suppliers.deleted_at IS NULL").
+		Joins("JOIN users ON users.id = suppliers.user_id AND users.active AND users.deleted_at IS NULL")
+	if operationID != nil {
+		query = query.Where("appointments.operation_id = ?", *operationID)
+	}
+	if from != nil && to != nil {
+		query = query.Where("appointments.scheduled_start >= ? AND appointments.scheduled_start < ?", *from, *to).
+			Where("appointments.status <> ?", models.StatusCancelled)
+	}
+
+	var ids []uint
+	err := query.Distinct("appointments.supplier_id").
+		Order("appointments.supplier_id ASC").
+		Limit(limit).
+		Pluck("appointments.supplier_id", &ids).Error
+	return ids, err
+}
+
+// EmployeeAudience returns the IDs of the employees with active users
+func (r *broadcastRepository) EmployeeAudience(limit int) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&models.Employee{}).
+		Joins("JOIN users ON users.id = employees.user_id AND users.active AND users.deleted_at IS NULL").
+		Order("employees.id ASC").
+		Limit(limit).
+		Pluck("employees.id", &ids).Error
+	return ids, err
+}
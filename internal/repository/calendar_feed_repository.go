@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// CalendarFeedScope selects the appointments a calendar feed lists: those starting in
+// [From, To) that match every filter set
+type CalendarFeedScope struct {
+	SupplierID  *uint
+	EmployeeID  *uint
+	OperationID *uint
+	Status      *models.AppointmentStatus
+	Type        *models.AppointmentType
+	From        time.Time
+	To          time.Time
+	Limit       int
+}
+
+// CalendarFeedRepository interface defines methods for calendar feed repository
+type CalendarFeedRepository interface {
+	Create(feed *models.CalendarFeed) error
+	FindByID(id uint) (*models.CalendarFeed, error)
+	FindByTokenHash(hash string) (*models.CalendarFeed, error)
+	FindByUser(userID uint) ([]models.CalendarFeed, error)
+	CountByUser(userID uint) (int64, error)
+	Update(feed *models.CalendarFeed) error
+	Delete(id uint) error
+	MarkFetched(id uint, at time.Time) error
+	Appointments(scope CalendarFeedScope) ([]models.Appointment, error)
+}
+
+// calendarFeedRepository implements CalendarFeedRepository interface
+type calendarFeedRepository struct {
+	db *gorm.DB
+}
+
+// NewCalendarFeedRepository creates a new calendar feed repository
+func NewCalendarFeedRepository(db *gorm.DB) CalendarFeedRepository {
+	return &calendarFeedRepository{db: db}
+}
+
+// Create creates a new calendar feed
+func (r *calendarFeedRepository) Create(feed *models.CalendarFeed) error {
+	return r.db.Create(feed).Error
+}
+
+// FindByID finds a calendar feed by ID
+func (r *calendarFeedRepository) FindByID(id uint) (*models.CalendarFeed, error) {
+	var feed models.CalendarFeed
+	if err := r.db.First(&feed, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("calendar feed not found")
+		}
+		return nil, err
+	}
+	return &feed, nil
+}
+
+// FindByTokenHash finds the calendar feed whose token has a hash
+func (r *calendarFeedRepository) FindByTokenHash(hash string) (*models.CalendarFeed, error) {
+	var feed models.CalendarFeed
+	if err := r.db.Where("token_hash = ?", hash).First(&feed).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("calendar feed not found")
+		}
+		return nil, err
+	}
+	return &feed, nil
+}
+
+// FindByUser returns a user's calendar feeds, oldest first
+func (r *calendarFeedRepository) FindByUser(userID uint) ([]models.CalendarFeed, error) {
+	var feeds []models.CalendarFeed
+	err := r.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&feeds).Error
+	return feeds, err
+}
+
+// CountByUser counts a user's calendar feeds
+func (r *calendarFeedRepository) CountByUser(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.CalendarFeed{}).Where("user_id = ?", userID).Count(&count).Error
+	return count, err
+}
+
+// Update updates a calendar feed
+func (r *calendarFeedRepository) Update(feed *models.CalendarFeed) error {
+	return r.db.Save(feed).Error
+}
+
+// Delete deletes a calendar feed
+func (r *calendarFeedRepository) Delete(id uint) error {
+	return r.db.Delete(&models.CalendarFeed{}, id).Error
+}
+
+// MarkFetched records when a calendar app last fetched a feed
+func (r *calendarFeedRepository) MarkFetched(id uint, at time.Time) error {
+	return r.db.Model(&models.CalendarFeed{}).Where("id = ?", id).
+		UpdateColumn("last_fetched_at", at).Error
+}
+
+// Appointments returns the appointments a feed lists with the parties, operation and product
+// its events show, the soonest first
+func (r *calendarFeedRepository) Appointments(scope CalendarFeedScope) ([]models.Appointment, error) {
+	query := r.db.Where("scheduled_start >= ? AND scheduled_start < ?", scope.From, scope.To)
+	if scope.SupplierID != nil {
+		query = query.Where("supplier_id = ?", *scope.SupplierID)
+	}
+	if scope.EmployeeID != nil {
+		query = query.Where("employee_id = ?", *scope.EmployeeID)
+	}
+	if scope.OperationID != nil {
+		query = query.Where("operation_id = ?", *scope.OperationID)
+	}
+	if scope.Status != nil {
+		query = query.Where("status = ?", *scope.Status)
+	}
+	if scope.Type != nil {
+		query = query.Where("type = ?", *scope.Type)
+	}
+	if scope.Limit > 0 {
+		query = query.Limit(scope.Limit)
+	}
+
+	var appointments []models.Appointment
+	err := query.
+		Preload("Supplier").
+		Preload("Employee.User").
+		Preload("Operation").
+		Preload("Product").
+		Order("scheduled_start ASC").
+		Find(&appointments).Error
+	return appointments, err
+}
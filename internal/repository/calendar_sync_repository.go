@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// CalendarSyncRepository interface defines methods for the records of appointments synced to
+// external calendars
+type CalendarSyncRepository interface {
+	Create(sync *models.CalendarSync) error
+	GetByAppointmentAndProvider(appointmentID uint, provider string) (*models.CalendarSync, error)
+	Delete(id uint) error
+}
+
+// calendarSyncRepository implements CalendarSyncRepository interface
+type calendarSyncRepository struct {
+	db *gorm.DB
+}
+
+// NewCalendarSyncRepository creates a new calendar sync repository
+func NewCalendarSyncRepository(db *gorm.DB) CalendarSyncRepository {
+	return &calendarSyncRepository{db: db}
+}
+
+// Create records an appointment synced to an external calendar
+func (r *calendarSyncRepository) Create(sync *models.CalendarSync) error {
+	return r.db.Create(sync).Error
+}
+
+// GetByAppointmentAndProvider finds the sync record of an appointment with a calendar provider
+func (r *calendarSyncRepository) GetByAppointmentAndProvider(appointmentID uint, provider string) (*models.CalendarSync, error) {
+	var sync models.CalendarSync
+	err := r.db.Where("appointment_id = ? AND provider = ?", appointmentID, provider).First(&sync).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("calendar sync not found")
+		}
+		return nil, err
+	}
+	return &sync, nil
+}
+
+// Delete deletes a sync record
+func (r *calendarSyncRepository) Delete(id uint) error {
+	return r.db.Delete(&models.CalendarSync{}, id).Error
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// CapacityRepository interface defines methods for appointment type capacity repository
+type CapacityRepository interface {
+	FindByOperation(operationID uint) ([]models.AppointmentTypeCapacity, error)
+	FindByOperationAndType(operationID uint, appointmentType models.AppointmentType) (*models.AppointmentTypeCapacity, error)
+	ReplaceForOperation(operationID uint, capacities []models.AppointmentTypeCapacity) error
+	CountOverlapping(operationID uint, appointmentType models.AppointmentType, start, end time.Time, excludeID uint) (int64, error)
+	CountSupplierOverlapping(operationID, supplierID uint, start, end time.Time, excludeID uint) (int64, error)
+	CountAllOverlapping(operationID uint, start, end time.Time, excludeID uint) (int64, error)
+	FindActiveOverlapping(operationID uint, start, end time.Time) ([]models.Appointment, error)
+	FindOverrides(operationID uint, from, to time.Time) ([]models.CapacityOverride, error)
+	ReplaceOverrides(operationID uint, date time.Time, overrides []models.CapacityOverride, change *models.CapacityChange) error
+	FindChanges(operationID uint, from, to time.Time) ([]models.CapacityChange, error)
+}
+
+// capacityRepository implements CapacityRepository interface
+type capacityRepository struct {
+	db *gorm.DB
+}
+
+// NewCapacityRepository creates a new capacity repository
+func NewCapacityRepository(db *gorm.DB) CapacityRepository {
+	return &capacityRepository{db: db}
+}
+
+// FindByOperation returns the capacity rules of an operation
+func (r *capacityRepository) FindByOperation(operationID uint) ([]models.AppointmentTypeCapacity, error) {
+	var capacities []models.AppointmentTypeCapacity
+	err := r.db.Where("operation_id = ?", operationID).Order("type ASC").Find(&capacities).Error
+	return capacities, err
+}
+
+// FindByOperationAndType returns the capacity rule of an appointment type, or nil if there is none
+func (r *capacityRepository) FindByOperationAndType(operationID uint, appointmentType models.AppointmentType) (*models.AppointmentTypeCapacity, error) {
+	var capacity models.AppointmentTypeCapacity
+	err := r.db.Where("operation_id = ? AND type = ?", operationID, appointmentType).First(&capacity).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &capacity, nil
+}
+
+// ReplaceForOperation replaces all capacity rules of an operation in a single transaction
+func (r *capacityRepository) ReplaceForOperation(operationID uint, capacities []models.AppointmentTypeCapacity) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("operation_id = ?", operationID).Delete(&models.AppointmentTypeCapacity{}).Error; err != nil {
+			return err
+		}
+		for i := range capacities {
+			capacities[i].ID = 0
+			capacities[i].OperationID = operationID
+			if err := tx.Create(&capacities[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// CountOverlapping counts the active appointments of a type at an operation that overlap a time range
+func (r *capacityRepository) CountOverlapping(operationID uint, appointmentType models.AppointmentType, start, end time.Time, excludeID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Appointment{}).
+		Where("operation_id = ? AND type = ? AND id != ?", operationID, appointmentType, excludeID).
+		Where("status NOT IN ?", []models.AppointmentStatus{models.StatusCancelled}).
+		Where("scheduled_start < ? AND scheduled_end > ?", end, start).
+		Count(&count).Error
+	return count, err
+}
+
+// CountSupplierOverlapping counts a supplier's active appointments at an operation that overlap a
+// time range, whatever their type
+func (r *capacityRepository) CountSupplierOverlapping(operationID, supplierID uint, start, end time.Time, excludeID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Appointment{}).
+		Where("operation_id = ? AND supplier_id = ? AND id != ?", operationID, supplierID, excludeID).
+		Where("status NOT IN ?", []models.AppointmentStatus{models.StatusCancelled}).
+		Where("scheduled_start < ? AND scheduled_end > ?", end, start).
+		Count(&count).Error
+	return count, err
+}
+
+// CountAllOverlapping counts the active appointments at an operation that overlap a time range,
+// whatever their type
+func (r *capacityRepository) CountAllOverlapping(operationID uint, start, end time.Time, excludeID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Appointment{}).
+		Where("operation_id = ? AND id != ?", operationID, excludeID).
+		Where("status NOT IN ?", []models.AppointmentStatus{models.StatusCancelled}).
+		Where("scheduled_start < ? AND scheduled_end > ?", end, start).
+		Count(&count).Error
+	return count, err
+}
+
+// FindActiveOverlapping returns the active appointments at an operation that overlap a time
+// range, in start order
+func (r *capacityRepository) FindActiveOverlapping(operationID uint, start, end time.Time) ([]models.Appointment, error) {
+	var appointments []models.Appointment
+	err := r.db.Where("operation_id = ?", operationID).
+		Where("status NOT IN ?", []models.AppointmentStatus{models.StatusCancelled}).
+		Where("scheduled_start < ? AND scheduled_end > ?", end, start).
+		Order("scheduled_start ASC, id ASC").
+		Find(&appointments).Error
+	return appointments, err
+}
+
+// FindOverrides returns an operation's capacity overrides between from and to, both included, by
+// date and start time
+func (r *capacityRepository) FindOverrides(operationID uint, from, to time.Time) ([]models.CapacityOverride, error) {
+	var overrides []models.CapacityOverride
+	err := r.db.Where("operation_id = ? AND date BETWEEN ? AND ?",
+		operationID, from.Format(models.BlackoutDateLayout), to.Format(models.BlackoutDateLayout)).
+		Order("date ASC, start_time ASC, type ASC").
+		Find(&overrides).Error
+	return overrides, err
+}
+
+// ReplaceOverrides replaces an operation's capacity overrides on a date and records the change,
+// in a single transaction
+func (r *capacityRepository) ReplaceOverrides(operationID uint, date time.Time, overrides []models.CapacityOverride, change *models.CapacityChange) error {
+	day := date.Format(models.BlackoutDateLayout)
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("operation_id = ? AND date = ?", operationID, day).Delete(&models.CapacityOverride{}).Error; err != nil {
+			return err
+		}
+		for i := range overrides {
+			overrides[i].ID = 0
+			overrides[i].OperationID = operationID
+			overrides[i].Date = date
+			if err := tx.Create(&overrides[i]).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(change).Error
+	})
+}
+
+// FindChanges returns the capacity changes of an operation's dates between from and to, both
+// included, the latest first
+func (r *capacityRepository) FindChanges(operationID uint, from, to time.Time) ([]models.CapacityChange, error) {
+	var changes []models.CapacityChange
+	err := r.db.Where("operation_id = ? AND date BETWEEN ? AND ?",
+		operationID, from.Format(models.BlackoutDateLayout), to.Format(models.BlackoutDateLayout)).
+		Order("created_at DESC").
+		Find(&changes).Error
+	return changes, err
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// openAppointmentStatuses are the statuses of appointments still to take place
+var openAppointmentStatuses = []models.AppointmentStatus{
+	models.StatusReserved, models.StatusPending, models.StatusConfirmed, models.StatusRescheduled,
+}
+
+// unsentQueueStatuses are the statuses of queue items a worker may still send
+var unsentQueueStatuses = []models.NotificationStatus{
+	models.NotificationStatusPending, models.NotificationStatusSending,
+}
+
+// ScheduledAppointment is an open appointment with the hours of its operation, as the
+// consistency check reads it
+type ScheduledAppointment struct {
+	ID             uint
+	OperationID    uint
+	ScheduledStart time.Time
+	ScheduledEnd   time.Time
+	OpeningTime    string
+	ClosingTime    string
+	Timezone       string
+}
+
+// InactiveProductAppointment is an open appointment delivering a product that was deactivated
+type InactiveProductAppointment struct {
+	ID          uint
+	ProductID   uint
+	ProductName string
+}
+
+// OrphanedQueueItem is a queue item still waiting to be sent whose notification was deleted or
+// is no longer waiting
+type OrphanedQueueItem struct {
+	ID                 uint
+	NotificationID     uint
+	NotificationStatus string // empty when the notification is gone
+}
+
+// OrphanedCalendarSync is a calendar sync record without an event in the external calendar, or
+// whose appointment is gone
+type OrphanedCalendarSync struct {
+	ID                 uint
+	AppointmentID      uint
+	Provider           string
+	MissingEvent       bool
+	MissingAppointment bool
+}
+
+// ConsistencyRepository interface defines methods for finding and repairing records left
+// inconsistent with each other
+type ConsistencyRepository interface {
+	UpcomingAppointments(from time.Time, afterID uint, limit int) ([]ScheduledAppointment, error)
+	AppointmentsWithInactiveProducts(from time.Time, limit int) ([]InactiveProductAppointment, error)
+	OrphanedQueueItems(limit int) ([]OrphanedQueueItem, error)
+	CancelQueueItems(ids []uint) (int64, error)
+	OrphanedCalendarSyncs(limit int) ([]OrphanedCalendarSync, error)
+	DeleteCalendarSyncs(ids []uint) (int64, error)
+}
+
+// consistencyRepository implements ConsistencyRepository interface
+type consistencyRepository struct {
+	db *gorm.DB
+}
+
+// NewConsistencyRepository creates a new consistency repository
+func NewConsistencyRepository(db *gorm.DB) ConsistencyRepository {
+	return &consistencyRepository{db: db}
+}
+
+// UpcomingAppointments returns up to limit open appointments starting from a time, with an ID
+// above afterID, in ID order
+func (r *consistencyRepository) UpcomingAppointments(from time.Time, afterID uint, limit int) ([]ScheduledAppointment, error) {
+	var appointments []ScheduledAppointment
+	err := r.db.Table("appointments AS a").
+		Select("a.id, a.operation_id, a.scheduled_start, a.scheduled_end, o.opening_time, o.closing_time, o.timezone").
+		Joins("JOIN operations AS o ON o.id = a.operation_id").
+		Where("a.deleted_at IS NULL AND a.status IN ? AND a.scheduled_start >= ? AND a.id > ?", openAppointmentStatuses, from, afterID).
+		Order("a.id ASC").
+		Limit(limit).
+		Scan(&appointments).Error
+	return appointments, err
+}
+
+// AppointmentsWithInactiveProducts returns up to limit open appointments starting from a time
+// whose product was deactivated, the soonest first
+func (r *consistencyRepository) AppointmentsWithInactiveProducts(from time.Time, limit int) ([]InactiveProductAppointment, error) {
+	var appointments []InactiveProductAppointment
+	err := r.db.Table("appointments AS a").
+		Select("a.id, a.product_id, p.name AS product_name").
+		Joins("JOIN products AS p ON p.id = a.product_id").
+		Where("a.deleted_at IS NULL AND a.status IN ? AND a.scheduled_start >= ? AND NOT p.active", openAppointmentStatuses, from).
+		Order("a.scheduled_start ASC, a.id ASC").
+		Limit(limit).
+		Scan(&appointments).Error
+	return appointments, err
+}
+
+// OrphanedQueueItems returns up to limit unsent queue items whose notification was deleted or
+// was already sent, failed for good or cancelled
+func (r *consistencyRepository) OrphanedQueueItems(limit int) ([]OrphanedQueueItem, error) {
+	var items []OrphanedQueueItem
+	err := r.db.Table("notification_queues AS q").
+		Select("q.id, q.notification_id, COALESCE(n.status, '') AS notification_status").
+		Joins("LEFT JOIN notifications AS n ON n.id = q.notification_id AND n.deleted_at IS NULL").
+		Where("q.deleted_at IS NULL AND q.status IN ?", unsentQueueStatuses).
+		Where("n.id IS NULL OR n.status NOT IN ?", unsentQueueStatuses).
+		Order("q.id ASC").
+		Limit(limit).
+		Scan(&items).Error
+	return items, err
+}
+
+// CancelQueueItems cancels the given queue items that are still unsent, returning how many were
+func (r *consistencyRepository) CancelQueueItems(ids []uint) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := r.db.Model(&models.NotificationQueue{}).
+		Where("id IN ? AND status IN ?", ids, unsentQueueStatuses).
+		Updates(map[string]interface{}{
+			"status":       models.NotificationStatusCancelled,
+			"locked_until": nil,
+			"processor_id": nil,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// OrphanedCalendarSyncs returns up to limit calendar sync records with no external event, or
+// whose appointment was deleted
+func (r *consistencyRepository) OrphanedCalendarSyncs(limit int) ([]OrphanedCalendarSync, error) {
+	var syncs []OrphanedCalendarSync
+	err := r.db.Table("calendar_syncs AS s").
+		Select(`s.id, s.appointment_id, s.provider, COALESCE(s.external_event_id, '') = '' AS missing_event,
+			a.id IS NULL AS missing_appointment`).
+		Joins("LEFT JOIN appointments AS a ON a.id = s.appointment_id AND a.deleted_at IS NULL").
+		Where("s.deleted_at IS NULL").
+		Where("COALESCE(s.external_event_id, '') = '' OR a.id IS NULL").
+		Order("s.id ASC").
+		Limit(limit).
+		Scan(&syncs).Error
+	return syncs, err
+}
+
+// DeleteCalendarSyncs deletes the given calendar sync records, returning how many were
+func (r *consistencyRepository) DeleteCalendarSyncs(ids []uint) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := r.db.Delete(&models.CalendarSync{}, ids)
+	return result.RowsAffected, result.Error
+}
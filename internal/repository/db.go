@@ -16,10 +16,60 @@ type Repositories struct {
 	UserRepo         UserRepository
 	SupplierRepo     SupplierRepository
 	EmployeeRepo     EmployeeRepository
+	SkillRepo        SkillRepository
 	ProductRepo      ProductRepository
 	OperationRepo    OperationRepository
 	AppointmentRepo  AppointmentRepository
 	AvailabilityRepo AvailabilityRepository
+	TemplateRepo     NotificationTemplateRepository
+	QueueRepo        NotificationQueueRepository
+	NotificationRepo NotificationRepository
+	PauseRepo        NotificationPauseRepository
+	PreferenceRepo   NotificationPreferenceRepository
+	DocumentRepo     SupplierDocumentRepository
+	RequirementRepo  DocumentRequirementRepository
+	VisitorRepo      VisitorRepository
+	ReportRepo       DeliveryReportRepository
+	CapacityRepo     CapacityRepository
+	LinkRepo         AppointmentLinkRepository
+	PingRepo         LocationPingRepository
+	DelayRepo        DelayRepository
+	FeedbackRepo     FeedbackRepository
+	IncidentRepo     IncidentRepository
+	ProofRepo        ProofOfDeliveryRepository
+	BillingRepo      BillingRepository
+	FeeRepo          FeeRepository
+	BlackoutRepo     BlackoutRepository
+	WaitlistRepo     WaitlistRepository
+	SlotWatchRepo    SlotWatchRepository
+	HandoverRepo     HandoverRepository
+	SyncRepo         SyncRepository
+	OverdueRepo      OverdueRepository
+	ExceptionRepo    AvailabilityExceptionRepository
+	ScheduleRepo     DefaultScheduleRepository
+	BookingCodeRepo  BookingCodeRepository
+	InvitationRepo   BookingInvitationRepository
+	WatcherRepo      WatcherRepository
+	MuteRepo         MuteRepository
+	StatusEventRepo  StatusEventRepository
+	VisibilityRepo   VisibilityRepository
+	PartnerRepo      PartnerRepository
+	BIExportRepo     BIExportRepository
+	JobRepo          JobRepository
+	UsageRepo        UsageRepository
+	SettingRepo      SettingRepository
+	LabelRepo        LabelTemplateRepository
+	CalendarFeedRepo CalendarFeedRepository
+	BroadcastRepo    BroadcastRepository
+	DepositRepo      DepositRepository
+	CalendarSyncRepo CalendarSyncRepository
+	ConsistencyRepo  ConsistencyRepository
+	DenialRepo       DenialRepository
+	DraftRepo        DraftRepository
+	EmployeeCalRepo  EmployeeCalendarRepository
+	RegionRepo       RegionRepository
+	ExperimentRepo   TemplateExperimentRepository
+	RevocationRepo   TokenRevocationRepository
 }
 
 // NewDBConnection creates a new database connection
@@ -46,24 +96,77 @@ func NewRepositories(db *gorm.DB) *Repositories {
 		UserRepo:         NewUserRepository(db),
 		SupplierRepo:     NewSupplierRepository(db),
 		EmployeeRepo:     NewEmployeeRepository(db),
+		SkillRepo:        NewSkillRepository(db),
 		ProductRepo:      NewProductRepository(db),
 		OperationRepo:    NewOperationRepository(db),
 		AppointmentRepo:  NewAppointmentRepository(db),
 		AvailabilityRepo: NewAvailabilityRepository(db),
+		TemplateRepo:     NewNotificationTemplateRepository(db),
+		QueueRepo:        NewNotificationQueueRepository(db),
+		NotificationRepo: NewNotificationRepository(db),
+		PauseRepo:        NewNotificationPauseRepository(db),
+		PreferenceRepo:   NewNotificationPreferenceRepository(db),
+		DocumentRepo:     NewSupplierDocumentRepository(db),
+		RequirementRepo:  NewDocumentRequirementRepository(db),
+		VisitorRepo:      NewVisitorRepository(db),
+		ReportRepo:       NewDeliveryReportRepository(db),
+		CapacityRepo:     NewCapacityRepository(db),
+		LinkRepo:         NewAppointmentLinkRepository(db),
+		PingRepo:         NewLocationPingRepository(db),
+		DelayRepo:        NewDelayRepository(db),
+		FeedbackRepo:     NewFeedbackRepository(db),
+		IncidentRepo:     NewIncidentRepository(db),
+		ProofRepo:        NewProofOfDeliveryRepository(db),
+		BillingRepo:      NewBillingRepository(db),
+		FeeRepo:          NewFeeRepository(db),
+		BlackoutRepo:     NewBlackoutRepository(db),
+		WaitlistRepo:     NewWaitlistRepository(db),
+		SlotWatchRepo:    NewSlotWatchRepository(db),
+		HandoverRepo:     NewHandoverRepository(db),
+		SyncRepo:         NewSyncRepository(db),
+		OverdueRepo:      NewOverdueRepository(db),
+		ExceptionRepo:    NewAvailabilityExceptionRepository(db),
+		ScheduleRepo:     NewDefaultScheduleRepository(db),
+		BookingCodeRepo:  NewBookingCodeRepository(db),
+		InvitationRepo:   NewBookingInvitationRepository(db),
+		WatcherRepo:      NewWatcherRepository(db),
+		MuteRepo:         NewMuteRepository(db),
+		StatusEventRepo:  NewStatusEventRepository(db),
+		VisibilityRepo:   NewVisibilityRepository(db),
+		PartnerRepo:      NewPartnerRepository(db),
+		BIExportRepo:     NewBIExportRepository(db),
+		JobRepo:          NewJobRepository(db),
+		UsageRepo:        NewUsageRepository(db),
+		SettingRepo:      NewSettingRepository(db),
+		LabelRepo:        NewLabelTemplateRepository(db),
+		CalendarFeedRepo: NewCalendarFeedRepository(db),
+		BroadcastRepo:    NewBroadcastRepository(db),
+		DepositRepo:      NewDepositRepository(db),
+		CalendarSyncRepo: NewCalendarSyncRepository(db),
+		ConsistencyRepo:  NewConsistencyRepository(db),
+		DenialRepo:       NewDenialRepository(db),
+		DraftRepo:        NewDraftRepository(db),
+		EmployeeCalRepo:  NewEmployeeCalendarRepository(db),
+		RegionRepo:       NewRegionRepository(db),
+		ExperimentRepo:   NewTemplateExperimentRepository(db),
+		RevocationRepo:   NewTokenRevocationRepository(db),
 	}
 }
 
 // AutoMigrate migrates all models
 func (r *Repositories) AutoMigrate() error {
-	return r.db.AutoMigrate(
-		&models.User{},
-		&models.Supplier{},
-		&models.Employee{},
-		&models.Product{},
-		&models.Operation{},
-		&models.Appointment{},
-		&models.AvailabilitySlot{},
-	)
+	if err := reconcileLegacySchema(r.db); err != nil {
+		return fmt.Errorf("failed to reconcile legacy schema: %w", err)
+	}
+	err := r.db.AutoMigrate(models.All()...)
+	if err != nil {
+		return err
+	}
+
+	if err := ensureUserEmailIndex(r.db); err != nil {
+		return fmt.Errorf("failed to index user emails: %w", err)
+	}
+	return nil
 }
 
 // GetDB returns the database instance
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// newTestDB connects to and migrates the database in DB_NAME. The tests write data, so they are
+// skipped unless DB_NAME ends in _test.
+func newTestDB(tb testing.TB) *gorm.DB {
+	tb.Helper()
+	if name := os.Getenv("DB_NAME"); !strings.HasSuffix(name, "_test") {
+		tb.Skipf("DB_NAME %q does not end in _test, skipping tests against the database", name)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		tb.Fatalf("loading configuration: %v", err)
+	}
+	db, err := NewDBConnection(cfg.Database)
+	if err != nil {
+		tb.Fatalf("connecting to database: %v", err)
+	}
+	if err := NewRepositories(db).AutoMigrate(); err != nil {
+		tb.Fatalf("migrating database: %v", err)
+	}
+	return db
+}
+
+// testParties are an employee, a supplier and an operation appointments can be booked with
+type testParties struct {
+	employeeID, supplierID, operationID uint
+}
+
+// seedParties creates an employee, a supplier and an operation, and removes them along with
+// their appointments when tb ends
+func seedParties(tb testing.TB, db *gorm.DB) *testParties {
+	tb.Helper()
+	run := strconv.FormatInt(time.Now().UnixNano(), 10)
+
+	employeeUser := &models.User{Name: "Test employee", Email: "test-employee-" + run + "@example.com", Role: "employee"}
+	supplierUser := &models.User{Name: "Test supplier", Email: "test-supplier-" + run + "@example.com", Role: "supplier"}
+	for _, user := range []*models.User{employeeUser, supplierUser} {
+		if err := db.Create(user).Error; err != nil {
+			tb.Fatalf("user: %v", err)
+		}
+	}
+	employee := &models.Employee{UserID: employeeUser.ID, Department: "Receiving", EmployeeNumber: "TEST-" + run}
+	if err := db.Create(employee).Error; err != nil {
+		tb.Fatalf("employee: %v", err)
+	}
+	supplier := &models.Supplier{UserID: supplierUser.ID, CompanyName: "Test Supplier " + run, CNPJ: run}
+	if err := db.Create(supplier).Error; err != nil {
+		tb.Fatalf("supplier: %v", err)
+	}
+	operation := &models.Operation{Name: "Test Operation " + run, Code: "TEST-" + run, ManagerID: employee.ID, Active: true}
+	if err := db.Create(operation).Error; err != nil {
+		tb.Fatalf("operation: %v", err)
+	}
+
+	tb.Cleanup(func() {
+		db.Unscoped().Where("operation_id = ?", operation.ID).Delete(&models.Appointment{})
+		db.Unscoped().Delete(operation)
+		db.Unscoped().Delete(supplier)
+		db.Unscoped().Delete(employee)
+		db.Unscoped().Delete(&models.User{}, []uint{employeeUser.ID, supplierUser.ID})
+	})
+	return &testParties{employeeID: employee.ID, supplierID: supplier.ID, operationID: operation.ID}
+}
+
+// appointment returns a confirmed hour-long service visit of the parties starting at start
+func (p *testParties) appointment(start time.Time) models.Appointment {
+	return models.Appointment{
+		SupplierID:     p.supplierID,
+		EmployeeID:     p.employeeID,
+		OperationID:    p.operationID,
+		Type:           models.AppointmentTypeServiceVisit,
+		ScheduledStart: start,
+		ScheduledEnd:   start.Add(time.Hour),
+		Status:         models.StatusConfirmed,
+	}
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// DefaultScheduleRepository interface defines methods for operation default schedules
+type DefaultScheduleRepository interface {
+	FindByOperation(operationID uint) ([]models.OperationDefaultSlot, error)
+	Replace(operationID uint, slots []models.OperationDefaultSlot) error
+}
+
+// defaultScheduleRepository implements DefaultScheduleRepository interface
+type defaultScheduleRepository struct {
+	db *gorm.DB
+}
+
+// NewDefaultScheduleRepository creates a new default schedule repository
+func NewDefaultScheduleRepository(db *gorm.DB) DefaultScheduleRepository {
+	return &defaultScheduleRepository{db: db}
+}
+
+// FindByOperation returns an operation's default weekly schedule
+func (r *defaultScheduleRepository) FindByOperation(operationID uint) ([]models.OperationDefaultSlot, error) {
+	var slots []models.OperationDefaultSlot
+	err := r.db.Where("operation_id = ?", operationID).
+		Order("day_of_week ASC, start_time ASC").
+		Find(&slots).Error
+	return slots, err
+}
+
+// Replace swaps an operation's default schedule for the given slots in one transaction
+func (r *defaultScheduleRepository) Replace(operationID uint, slots []models.OperationDefaultSlot) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("operation_id = ?", operationID).Delete(&models.OperationDefaultSlot{}).Error; err != nil {
+			return err
+		}
+		if len(slots) == 0 {
+			return nil
+		}
+		for i := range slots {
+			slots[i].OperationID = operationID
+		}
+		return tx.Create(&slots).Error
+	})
+}
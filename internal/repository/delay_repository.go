@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// DelayRepository interface defines methods for supplier delay declarations
+type DelayRepository interface {
+	Create(delay *models.AppointmentDelay) error
+	FindByAppointment(appointmentID uint) ([]models.AppointmentDelay, error)
+}
+
+// delayRepository implements DelayRepository interface
+type delayRepository struct {
+	db *gorm.DB
+}
+
+// NewDelayRepository creates a new delay repository
+func NewDelayRepository(db *gorm.DB) DelayRepository {
+	return &delayRepository{db: db}
+}
+
+// Create records a delay declaration
+func (r *delayRepository) Create(delay *models.AppointmentDelay) error {
+	return r.db.Create(delay).Error
+}
+
+// FindByAppointment returns the delays declared for an appointment, most recent first
+func (r *delayRepository) FindByAppointment(appointmentID uint) ([]models.AppointmentDelay, error) {
+	var delays []models.AppointmentDelay
+	err := r.db.Where("appointment_id = ?", appointmentID).
+		Order("created_at DESC").
+		Find(&delays).Error
+	return delays, err
+}
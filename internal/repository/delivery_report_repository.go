@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// DeliveryReportFilters defines filters for the delivered vs scheduled report
+type DeliveryReportFilters struct {
+	OperationID *uint
+	SupplierID  *uint
+	StartDate   *time.Time
+	EndDate     *time.Time
+}
+
+// DeliveryReportRow compares scheduled and received quantities for a supplier and product
+type DeliveryReportRow struct {
+	SupplierID          uint    `json:"supplier_id"`
+	ProductID           uint    `json:"product_id"`
+	Appointments        int64   `json:"appointments"`
+	PartialDeliveries   int64   `json:"partial_deliveries"`
+	ScheduledQuantity   int64   `json:"scheduled_quantity"`
+	DeliveredQuantity   int64   `json:"delivered_quantity"`
+	OutstandingQuantity int64   `json:"outstanding_quantity"`
+	FillRate            float64 `json:"fill_rate"`
+}
+
+// DeliveryReportRepository interface defines methods for delivery reporting
+type DeliveryReportRepository interface {
+	DeliveredVsScheduled(filters DeliveryReportFilters) ([]DeliveryReportRow, error)
+	SupplierPerformance(filters DeliveryReportFilters) ([]SupplierPerformanceRow, error)
+}
+
+// deliveryReportRepository implements DeliveryReportRepository interface
+type deliveryReportRepository struct {
+	db *gorm.DB
+}
+
+// NewDeliveryReportRepository creates a new delivery report repository
+func NewDeliveryReportRepository(db *gorm.DB) DeliveryReportRepository {
+	return &deliveryReportRepository{db: db}
+}
+
+// DeliveredVsScheduled sums, per supplier and product, the quantities originally scheduled and
+// the quantities actually received. Follow-up appointments created for a remainder don't add to
+// the scheduled quantity, but what they deliver counts as received.
+func (r *deliveryReportRepository) DeliveredVsScheduled(filters DeliveryReportFilters) ([]DeliveryReportRow, error) {
+	var rows []DeliveryReportRow
+
+	query := r.db.Model(&models.Appointment{}).
+		Select(
+			"supplier_id, product_id, "+
+				"COUNT(*) FILTER (WHERE follow_up_of_id IS NULL) AS appointments, "+
+				"COUNT(*) FILTER (WHERE status = ?) AS partial_deliveries, "+
+				"COALESCE(SUM(quantity_to_deliver) FILTER (WHERE follow_up_of_id IS NULL), 0) AS scheduled_quantity, "+
+				"COALESCE(SUM(COALESCE(received_quantity, quantity_to_deliver)) FILTER (WHERE status IN ?), 0) AS delivered_quantity",
+			models.StatusPartiallyCompleted,
+			[]models.AppointmentStatus{models.StatusCompleted, models.StatusPartiallyCompleted},
+		).
+		Where("status <> ? AND type <> ?", models.StatusCancelled, models.AppointmentTypeServiceVisit)
+
+	if filters.OperationID != nil {
+		query = query.Where("operation_id = ?", *filters.OperationID)
+	}
+	if filters.SupplierID != nil {
+		query = query.Where("supplier_id = ?", *filters.SupplierID)
+	}
+	if filters.StartDate != nil {
+		query = query.Where("scheduled_start >= ?", *filters.StartDate)
+	}
+	if filters.EndDate != nil {
+		query = query.Where("scheduled_end <= ?", *filters.EndDate)
+	}
+
+	err := query.Group("supplier_id, product_id").Order("supplier_id, product_id").Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		row := &rows[i]
+		row.OutstandingQuantity = row.ScheduledQuantity - row.DeliveredQuantity
+		if row.OutstandingQuantity < 0 {
+			row.OutstandingQuantity = 0
+		}
+		if row.ScheduledQuantity > 0 {
+			row.FillRate = float64(row.DeliveredQuantity) / float64(row.ScheduledQuantity)
+		}
+	}
+	return rows, nil
+}
+
+// SupplierPerformanceRow summarizes how a supplier's appointments went
+type SupplierPerformanceRow struct {
+	SupplierID          uint             `json:"supplier_id"`
+	Appointments        int64            `json:"appointments"`
+	Completed           int64            `json:"completed"`
+	PartialDeliveries   int64            `json:"partial_deliveries"`
+	Cancelled           int64            `json:"cancelled"`
+	Delays              int64            `json:"delays"`           // delays the supplier declared
+	Incidents           int64            `json:"incidents"`        // problems dock staff logged
+	SevereIncidents     int64            `json:"severe_incidents"` // high and critical incidents
+	OpenIncidents       int64            `json:"open_incidents"`
+	IncidentRate        float64          `json:"incident_rate"` // incidents per appointment
+	IncidentsByCategory map[string]int64 `json:"incidents_by_category" gorm:"-"`
+}
+
+// SupplierPerformance counts, per supplier, the appointments booked and how they went: declared
+// delays and the incidents logged on them. Filters apply to the appointments.
+func (r *deliveryReportRepository) SupplierPerformance(filters DeliveryReportFilters) ([]SupplierPerformanceRow, error) {
+	var rows []SupplierPerformanceRow
+	err := r.filterAppointments(r.db.Model(&models.Appointment{}), filters).
+		Select(
+			"appointments.supplier_id, "+
+				"COUNT(*) AS appointments, "+
+				"COUNT(*) FILTER (WHERE appointments.status = ?) AS completed, "+
+				"COUNT(*) FILTER (WHERE appointments.status = ?) AS partial_deliveries, "+
+				"COUNT(*) FILTER (WHERE appointments.status = ?) AS cancelled",
+			models.StatusCompleted, models.StatusPartiallyCompleted, models.StatusCancelled,
+		).
+		Group("appointments.supplier_id").
+		Order("appointments.supplier_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var delays []struct {
+		SupplierID uint
+		Delays     int64
+	}
+	err = r.filterAppointments(r.db.Model(&models.AppointmentDelay{}), filters).
+		Select("appointments.supplier_id, COUNT(*) AS delays").
+		Joins("JOIN appointments ON appointments.id = appointment_delays.appointment_id").
+		Group("appointments.supplier_id").
+		Scan(&delays).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var incidents []struct {
+		SupplierID uint
+		Category   string
+		Incidents  int64
+		Severe     int64
+		Open       int64
+	}
+	err = r.filterAppointments(r.db.Model(&models.AppointmentIncident{}), filters).
+		Select(
+			"appointment_incidents.supplier_id, appointment_incidents.category, "+
+				"COUNT(*) AS incidents, "+
+				"COUNT(*) FILTER (WHERE appointment_incidents.severity IN ?) AS severe, "+
+				"COUNT(*) FILTER (WHERE appointment_incidents.resolved_at IS NULL) AS open",
+			[]models.IncidentSeverity{models.SeverityHigh, models.SeverityCritical},
+		).
+		Joins("JOIN appointments ON appointments.id = appointment_incidents.appointment_id").
+		Group("appointment_incidents.supplier_id, appointment_incidents.category").
+		Scan(&incidents).Error
+	if err != nil {
+		return nil, err
+	}
+
+	bySupplier := make(map[uint]*SupplierPerformanceRow, len(rows))
+	for i := range rows {
+		rows[i].IncidentsByCategory = map[string]int64{}
+		bySupplier[rows[i].SupplierID] = &rows[i]
+	}
+	for _, delay := range delays {
+		if row, ok := bySupplier[delay.SupplierID]; ok {
+			row.Delays = delay.Delays
+		}
+	}
+	for _, incident := range incidents {
+		row, ok := bySupplier[incident.SupplierID]
+		if !ok {
+			continue
+		}
+		row.Incidents += incident.Incidents
+		row.SevereIncidents += incident.Severe
+		row.OpenIncidents += incident.Open
+		row.IncidentsByCategory[incident.Category] = incident.Incidents
+	}
+	for i := range rows {
+		if rows[i].Appointments > 0 {
+			rows[i].IncidentRate = float64(rows[i].Incidents) / float64(rows[i].Appointments)
+		}
+	}
+	return rows, nil
+}
+
+// filterAppointments applies the report filters to a query joined with appointments
+func (r *deliveryReportRepository) filterAppointments(query *gorm.DB, filters DeliveryReportFilters) *gorm.DB {
+	query = query.Where("appointments.type <> ?", models.AppointmentTypeServiceVisit)
+	if filters.OperationID != nil {
+		query = query.Where("appointments.operation_id = ?", *filters.OperationID)
+	}
+	if filters.SupplierID != nil {
+		query = query.Where("appointments.supplier_id = ?", *filters.SupplierID)
+	}
+	if filters.StartDate != nil {
+		query = query.Where("appointments.scheduled_start >= ?", *filters.StartDate)
+	}
+	if filters.EndDate != nil {
+		query = query.Where("appointments.scheduled_end <= ?", *filters.EndDate)
+	}
+	return query
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"strings"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// Dimensions booking denials can be grouped by
+const (
+	DenialGroupOperation = "operation"
+	DenialGroupReason    = "reason"
+	DenialGroupWeekday   = "weekday"
+	DenialGroupHour      = "hour"
+	DenialGroupDate      = "date"
+)
+
+// denialGroupColumns are the columns selected and grouped by for each dimension
+var denialGroupColumns = map[string]string{
+	DenialGroupOperation: "booking_denials.operation_id, operations.code AS operation_code, operations.name AS operation_name",
+	DenialGroupReason:    "booking_denials.reason",
+	DenialGroupWeekday:   "booking_denials.weekday",
+	DenialGroupHour:      "booking_denials.hour",
+	DenialGroupDate:      "booking_denials.local_date",
+}
+
+// DenialFilters defines filters for the booking denial analytics. The dates apply to the
+// requested start, so a period counts the demand for it rather than when it was turned away.
+type DenialFilters struct {
+	OperationID *uint
+	SupplierID  *uint
+	Reason      models.DenialReason
+	StartDate   *time.Time
+	EndDate     *time.Time
+	GroupBy     []string // dimensions, in the order rows are sorted by after the count
+	Limit       int
+}
+
+// DenialRow counts the bookings refused for a combination of the grouped dimensions; the others
+// are left out
+type DenialRow struct {
+	OperationID   *uint               `json:"operation_id,omitempty"`
+	OperationCode string              `json:"operation_code,omitempty"`
+	OperationName string              `json:"operation_name,omitempty"`
+	Reason        models.DenialReason `json:"reason,omitempty"`
+	Weekday       *int                `json:"weekday,omitempty"`
+	Hour          *int                `json:"hour,omitempty"`
+	LocalDate     string              `json:"date,omitempty"`
+	Denials       int64               `json:"denials"`
+	Suppliers     int64               `json:"suppliers"` // distinct suppliers turned away
+}
+
+// DenialRepository interface defines methods for the booking denial log
+type DenialRepository interface {
+	Create(denial *models.BookingDenial) error
+	Summarize(filters DenialFilters) ([]DenialRow, error)
+	DeleteBefore(before time.Time) (int64, error)
+}
+
+// denialRepository implements DenialRepository interface
+type denialRepository struct {
+	db *gorm.DB
+}
+
+// NewDenialRepository creates a new booking denial repository
+func NewDenialRepository(db *gorm.DB) DenialRepository {
+	return &denialRepository{db: db}
+}
+
+// Create records a refused booking
+func (r *denialRepository) Create(denial *models.BookingDenial) error {
+	return r.db.Create(denial).Error
+}
+
+// Summarize counts the refused bookings matching the filters per combination of the grouped
+// dimensions, the most refused first. Without dimensions a single row counts them all.
+func (r *denialRepository) Summarize(filters DenialFilters) ([]DenialRow, error) {
+	var rows []DenialRow
+
+	var columns, groups []string
+	for _, dimension := range filters.GroupBy {
+		columns = append(columns, denialGroupColumns[dimension])
+		if dimension == DenialGroupOperation {
+			groups = append(groups, "booking_denials.operation_id, operations.code, operations.name")
+		} else {
+			groups = append(groups, denialGroupColumns[dimension])
+		}
+	}
+	columns = append(columns, "COUNT(*) AS denials, COUNT(DISTINCT booking_denials.supplier_id) AS suppliers")
+
+	query := r.filter(r.db.Model(&models.BookingDenial{}), filters).
+		Select(strings.Join(columns, ", ")).
+		Joins("LEFT JOIN operations ON operations.id = booking_denials.operation_id")
+	order := "denials DESC"
+	if len(groups) > 0 {
+		query = query.Group(strings.Join(groups, ", "))
+		order += ", " + strings.Join(groups, ", ")
+	}
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+
+	err := query.Order(order).Scan(&rows).Error
+	return rows, err
+}
+
+// DeleteBefore deletes the refused bookings recorded before a time
+func (r *denialRepository) DeleteBefore(before time.Time) (int64, error) {
+	result := r.db.Where("created_at < ?", before).Delete(&models.BookingDenial{})
+	return result.RowsAffected, result.Error
+}
+
+// filter narrows a booking denial query down to the filters
+func (r *denialRepository) filter(query *gorm.DB, filters DenialFilters) *gorm.DB {
+	if filters.OperationID != nil {
+		query = query.Where("booking_denials.operation_id = ?", *filters.OperationID)
+	}
+	if filters.SupplierID != nil {
+		query = query.Where("booking_denials.supplier_id = ?", *filters.SupplierID)
+	}
+	if filters.Reason != "" {
+		query = query.Where("booking_denials.reason = ?", filters.Reason)
+	}
+	if filters.StartDate != nil {
+		query = query.Where("booking_denials.requested_start >= ?", *filters.StartDate)
+	}
+	if filters.EndDate != nil {
+		query = query.Where("booking_denials.requested_start <= ?", *filters.EndDate)
+	}
+	return query
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// DepositRepository interface defines methods for booking deposits and the reserved appointments
+// waiting for them
+type DepositRepository interface {
+	Create(deposit *models.Deposit) error
+	FindByAppointment(appointmentID uint) (*models.Deposit, error)
+	FindByPayment(provider, paymentID string) (*models.Deposit, error)
+	FindExpired(now time.Time, limit int) ([]models.Deposit, error)
+	MarkPaid(id uint, at time.Time) (bool, error)
+	MarkFailed(id uint, message string) error
+	Cancel(id uint, at time.Time) (bool, error)
+	ReleaseReservation(appointmentID uint, status models.AppointmentStatus, at time.Time) (bool, error)
+	CancelReservation(appointmentID uint, at time.Time, reason string) (bool, error)
+}
+
+// depositRepository implements DepositRepository interface
+type depositRepository struct {
+	db *gorm.DB
+}
+
+// ErrDepositNotFound is returned when no deposit has the appointment or payment looked up
+var ErrDepositNotFound = errors.New("deposit not found")
+
+// NewDepositRepository creates a new deposit repository
+func NewDepositRepository(db *gorm.DB) DepositRepository {
+	return &depositRepository{db: db}
+}
+
+// Create records a deposit
+func (r *depositRepository) Create(deposit *models.Deposit) error {
+	return r.db.Create(deposit).Error
+}
+
+// FindByAppointment finds the deposit of an appointment
+func (r *depositRepository) FindByAppointment(appointmentID uint) (*models.Deposit, error) {
+	var deposit models.Deposit
+	if err := r.db.Where("appointment_id = ?", appointmentID).First(&deposit).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDepositNotFound
+		}
+		return nil, err
+	}
+	return &deposit, nil
+}
+
+// FindByPayment finds a deposit by the provider's ID of its payment
+func (r *depositRepository) FindByPayment(provider, paymentID string) (*models.Deposit, error) {
+	var deposit models.Deposit
+	if err := r.db.Where("provider = ? AND payment_id = ?", provider, paymentID).First(&deposit).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDepositNotFound
+		}
+		return nil, err
+	}
+	return &deposit, nil
+}
+
+// FindExpired returns unpaid deposits whose hold expired, the oldest first
+func (r *depositRepository) FindExpired(now time.Time, limit int) ([]models.Deposit, error) {
+	var deposits []models.Deposit
+	err := r.db.Where("status = ? AND expires_at <= ?", models.DepositPending, now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&deposits).Error
+	return deposits, err
+}
+
+// MarkPaid records a deposit as paid, reporting false when it was paid already. A deposit
+// cancelled meanwhile is marked paid too, so the late payment is on record.
+func (r *depositRepository) MarkPaid(id uint, at time.Time) (bool, error) {
+	result := r.db.Model(&models.Deposit{}).
+		Where("id = ? AND status <> ?", id, models.DepositPaid).
+		Updates(map[string]interface{}{
+			"status":  models.DepositPaid,
+			"paid_at": at,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// MarkFailed records why the last attempt to pay a deposit failed
+func (r *depositRepository) MarkFailed(id uint, message string) error {
+	return r.db.Model(&models.Deposit{}).Where("id = ?", id).
+		Update("failure_message", message).Error
+}
+
+// Cancel cancels a deposit that is still unpaid, reporting false when it was paid or cancelled
+// meanwhile
+func (r *depositRepository) Cancel(id uint, at time.Time) (bool, error) {
+	result := r.db.Model(&models.Deposit{}).
+		Where("id = ? AND status = ?", id, models.DepositPending).
+		Updates(map[string]interface{}{
+			"status":       models.DepositCancelled,
+			"cancelled_at": at,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// ReleaseReservation moves an appointment that is still reserved to pending or confirmed,
+// reporting false when it left the reserved status meanwhile
+func (r *depositRepository) ReleaseReservation(appointmentID uint, status models.AppointmentStatus, at time.Time) (bool, error) {
+	updates := map[string]interface{}{"status": status}
+	if status == models.StatusConfirmed {
+		updates["confirmed_at"] = at
+	}
+	result := r.db.Model(&models.Appointment{}).
+		Where("id = ? AND status = ?", appointmentID, models.StatusReserved).
+		Updates(updates)
+	return result.RowsAffected > 0, result.Error
+}
+
+// CancelReservation cancels an appointment that is still reserved, reporting false when it left
+// the reserved status meanwhile
+func (r *depositRepository) CancelReservation(appointmentID uint, at time.Time, reason string) (bool, error) {
+	result := r.db.Model(&models.Appointment{}).
+		Where("id = ? AND status = ?", appointmentID, models.StatusReserved).
+		Updates(map[string]interface{}{
+			"status":              models.StatusCancelled,
+			"cancelled_at":        at,
+			"cancellation_reason": reason,
+		})
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// DraftRepository interface defines methods for appointment draft repository
+type DraftRepository interface {
+	Save(draft *models.AppointmentDraft) error
+	FindByKey(userID uint, key string) (*models.AppointmentDraft, error)
+	FindByUser(userID uint, now time.Time) ([]models.AppointmentDraft, error)
+	CountByUser(userID uint, now time.Time) (int64, error)
+	Delete(userID uint, key string) (bool, error)
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+// draftRepository implements DraftRepository interface
+type draftRepository struct {
+	db *gorm.DB
+}
+
+// ErrDraftNotFound is returned when a user has no draft under the key looked up
+var ErrDraftNotFound = errors.New("draft not found")
+
+// NewDraftRepository creates a new appointment draft repository
+func NewDraftRepository(db *gorm.DB) DraftRepository {
+	return &draftRepository{db: db}
+}
+
+// Save creates a draft, or updates it when it has an ID
+func (r *draftRepository) Save(draft *models.AppointmentDraft) error {
+	return r.db.Save(draft).Error
+}
+
+// FindByKey finds a user's draft by its key, expired or not
+func (r *draftRepository) FindByKey(userID uint, key string) (*models.AppointmentDraft, error) {
+	var draft models.AppointmentDraft
+	if err := r.db.Where("user_id = ? AND key = ?", userID, key).First(&draft).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDraftNotFound
+		}
+		return nil, err
+	}
+	return &draft, nil
+}
+
+// FindByUser returns a user's drafts that haven't expired, the last saved first
+func (r *draftRepository) FindByUser(userID uint, now time.Time) ([]models.AppointmentDraft, error) {
+	var drafts []models.AppointmentDraft
+	err := r.db.Where("user_id = ? AND expires_at > ?", userID, now).
+		Order("updated_at DESC").
+		Find(&drafts).Error
+	return drafts, err
+}
+
+// CountByUser counts a user's drafts that haven't expired
+func (r *draftRepository) CountByUser(userID uint, now time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.AppointmentDraft{}).
+		Where("user_id = ? AND expires_at > ?", userID, now).
+		Count(&count).Error
+	return count, err
+}
+
+// Delete deletes a user's draft, reporting whether there was one
+func (r *draftRepository) Delete(userID uint, key string) (bool, error) {
+	result := r.db.Where("user_id = ? AND key = ?", userID, key).Delete(&models.AppointmentDraft{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// DeleteExpired deletes the drafts that expired before now
+func (r *draftRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Where("expires_at <= ?", now).Delete(&models.AppointmentDraft{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// EmployeeCalendarRepository interface defines methods for the work calendars employees connect
+// and the busy times read from them
+type EmployeeCalendarRepository interface {
+	FindByEmployee(employeeID uint) (*models.EmployeeCalendar, error)
+	FindAll() ([]models.EmployeeCalendar, error)
+	Save(calendar *models.EmployeeCalendar) error
+	Delete(employeeID uint) (bool, error)
+	ReplaceBusyTimes(employeeID uint, busy []models.EmployeeBusyTime) error
+	FindBusyTimes(employeeID uint, from, to time.Time) ([]models.EmployeeBusyTime, error)
+}
+
+// employeeCalendarRepository implements EmployeeCalendarRepository interface
+type employeeCalendarRepository struct {
+	db *gorm.DB
+}
+
+// ErrEmployeeCalendarNotFound is returned when an employee has no connected calendar
+var ErrEmployeeCalendarNotFound = errors.New("employee calendar not found")
+
+// NewEmployeeCalendarRepository creates a new employee calendar repository
+func NewEmployeeCalendarRepository(db *gorm.DB) EmployeeCalendarRepository {
+	return &employeeCalendarRepository{db: db}
+}
+
+// FindByEmployee finds the calendar an employee connected
+func (r *employeeCalendarRepository) FindByEmployee(employeeID uint) (*models.EmployeeCalendar, error) {
+	var calendar models.EmployeeCalendar
+	if err := r.db.Where("employee_id = ?", employeeID).First(&calendar).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEmployeeCalendarNotFound
+		}
+		return nil, err
+	}
+	return &calendar, nil
+}
+
+// FindAll returns every connected calendar, in connection order
+func (r *employeeCalendarRepository) FindAll() ([]models.EmployeeCalendar, error) {
+	var calendars []models.EmployeeCalendar
+	err := r.db.Order("id ASC").Find(&calendars).Error
+	return calendars, err
+}
+
+// Save creates a calendar connection, or updates it when it has an ID
+func (r *employeeCalendarRepository) Save(calendar *models.EmployeeCalendar) error {
+	return r.db.Save(calendar).Error
+}
+
+// Delete disconnects an employee's calendar and forgets its busy times, reporting whether one was
+// connected
+func (r *employeeCalendarRepository) Delete(employeeID uint) (bool, error) {
+	var deleted bool
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("employee_id = ?", employeeID).Delete(&models.EmployeeCalendar{})
+		if result.Error != nil {
+			return result.Error
+		}
+		deleted = result.RowsAffected > 0
+		return tx.Where("employee_id = ?", employeeID).Delete(&models.EmployeeBusyTime{}).Error
+	})
+	return deleted, err
+}
+
+// ReplaceBusyTimes replaces an employee's busy times with those read last
+func (r *employeeCalendarRepository) ReplaceBusyTimes(employeeID uint, busy []models.EmployeeBusyTime) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("employee_id = ?", employeeID).Delete(&models.EmployeeBusyTime{}).Error; err != nil {
+			return err
+		}
+		if len(busy) == 0 {
+			return nil
+		}
+		for i := range busy {
+			busy[i].ID = 0
+			busy[i].EmployeeID = employeeID
+		}
+		return tx.CreateInBatches(busy, 500).Error
+	})
+}
+
+// FindBusyTimes returns an employee's busy times overlapping [from, to), the earliest first
+func (r *employeeCalendarRepository) FindBusyTimes(employeeID uint, from, to time.Time) ([]models.EmployeeBusyTime, error) {
+	var busy []models.EmployeeBusyTime
+	err := r.db.Where("employee_id = ? AND starts_at < ? AND ends_at > ?", employeeID, to, from).
+		Order("starts_at ASC").
+		Find(&busy).Error
+	return busy, err
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// EmployeeRepository interface defines methods for employee repository
+type EmployeeRepository interface {
+	Create(employee *models.Employee) error
+	FindByID(id uint) (*models.Employee, error)
+	GetByID(id uint) (*models.Employee, error)
+	FindByUserID(userID uint) (*models.Employee, error)
+	FindByEmployeeNumber(number string) (*models.Employee, error)
+	Update(employee *models.Employee) error
+}
+
+// employeeRepository implements EmployeeRepository interface
+type employeeRepository struct {
+	db *gorm.DB
+}
+
+// NewEmployeeRepository creates a new employee repository
+func NewEmployeeRepository(db *gorm.DB) EmployeeRepository {
+	return &employeeRepository{db: db}
+}
+
+// Create creates a new employee
+func (r *employeeRepository) Create(employee *models.Employee) error {
+	return r.db.Create(employee).Error
+}
+
+// FindByID finds an employee by ID
+func (r *employeeRepository) FindByID(id uint) (*models.Employee, error) {
+	var employee models.Employee
+	err := r.db.Preload("User").First(&employee, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("employee not found")
+		}
+		return nil, err
+	}
+	return &employee, nil
+}
+
+// GetByID is an alias of FindByID used by the notification and calendar services
+func (r *employeeRepository) GetByID(id uint) (*models.Employee, error) {
+	return r.FindByID(id)
+}
+
+// FindByUserID finds the employee record linked to a user
+func (r *employeeRepository) FindByUserID(userID uint) (*models.Employee, error) {
+	var employee models.Employee
+	err := r.db.Preload("User").Where("user_id = ?", userID).First(&employee).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("employee not found")
+		}
+		return nil, err
+	}
+	return &employee, nil
+}
+
+// FindByEmployeeNumber finds an employee by employee number
+func (r *employeeRepository) FindByEmployeeNumber(number string) (*models.Employee, error) {
+	var employee models.Employee
+	err := r.db.Preload("User").Where("employee_number = ?", number).First(&employee).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("employee not found")
+		}
+		return nil, err
+	}
+	return &employee, nil
+}
+
+// Update updates an employee
+func (r *employeeRepository) Update(employee *models.Employee) error {
+	return r.db.Save(employee).Error
+}
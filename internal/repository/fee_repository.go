@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// FeeFilters defines filters for listing fees
+type FeeFilters struct {
+	SupplierID    *uint
+	OperationID   *uint
+	AppointmentID *uint
+	Status        models.FeeStatus
+	Page          int
+	Limit         int
+}
+
+// FeeRepository interface defines methods for fees charged to suppliers
+type FeeRepository interface {
+	Create(fee *models.Fee) error
+	FindByID(id uint) (*models.Fee, error)
+	FindByAppointment(appointmentID uint, kind models.FeeKind) (*models.Fee, error)
+	List(filters FeeFilters) ([]models.Fee, int64, error)
+	Update(fee *models.Fee) error
+}
+
+// feeRepository implements FeeRepository interface
+type feeRepository struct {
+	db *gorm.DB
+}
+
+// NewFeeRepository creates a new fee repository
+func NewFeeRepository(db *gorm.DB) FeeRepository {
+	return &feeRepository{db: db}
+}
+
+// Create records a fee
+func (r *feeRepository) Create(fee *models.Fee) error {
+	return r.db.Create(fee).Error
+}
+
+// FindByID finds a fee by ID
+func (r *feeRepository) FindByID(id uint) (*models.Fee, error) {
+	var fee models.Fee
+	if err := r.db.First(&fee, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("fee not found")
+		}
+		return nil, err
+	}
+	return &fee, nil
+}
+
+// FindByAppointment finds the fee of a kind charged for an appointment
+func (r *feeRepository) FindByAppointment(appointmentID uint, kind models.FeeKind) (*models.Fee, error) {
+	var fee models.Fee
+	err := r.db.Where("appointment_id = ? AND kind = ?", appointmentID, kind).First(&fee).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("fee not found")
+		}
+		return nil, err
+	}
+	return &fee, nil
+}
+
+// List returns a page of fees matching the filters, newest first, with the total count
+func (r *feeRepository) List(filters FeeFilters) ([]models.Fee, int64, error) {
+	var fees []models.Fee
+	var count int64
+
+	query := r.db.Model(&models.Fee{})
+	if filters.SupplierID != nil {
+		query = query.Where("supplier_id = ?", *filters.SupplierID)
+	}
+	if filters.OperationID != nil {
+		query = query.Where("operation_id = ?", *filters.OperationID)
+	}
+	if filters.AppointmentID != nil {
+		query = query.Where("appointment_id = ?", *filters.AppointmentID)
+	}
+	if filters.Status != "" {
+		query = query.Where("status = ?", filters.Status)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filters.Page > 0 && filters.Limit > 0 {
+		query = query.Offset((filters.Page - 1) * filters.Limit).Limit(filters.Limit)
+	}
+
+	err := query.Order("created_at DESC").Find(&fees).Error
+	return fees, count, err
+}
+
+// Update saves a fee
+func (r *feeRepository) Update(fee *models.Fee) error {
+	return r.db.Save(fee).Error
+}
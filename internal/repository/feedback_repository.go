@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// FeedbackFilters defines filters for the feedback scores report
+type FeedbackFilters struct {
+	OperationID *uint
+	StartDate   *time.Time
+	EndDate     *time.Time
+}
+
+// OperationFeedbackScore aggregates the feedback left on an operation's appointments
+type OperationFeedbackScore struct {
+	OperationID     uint     `json:"operation_id"`
+	Responses       int64    `json:"responses"`
+	AverageRating   float64  `json:"average_rating"`
+	SupplierRating  *float64 `json:"supplier_rating"` // nil when no supplier rated
+	EmployeeRating  *float64 `json:"employee_rating"` // nil when no employee rated
+	LowRatings      int64    `json:"low_ratings"`     // ratings of 1 or 2
+	CommentsWritten int64    `json:"comments_written"`
+}
+
+// FeedbackRepository interface defines methods for appointment feedback
+type FeedbackRepository interface {
+	Create(feedback *models.AppointmentFeedback) error
+	FindByAppointment(appointmentID uint) ([]models.AppointmentFeedback, error)
+	FindByAppointmentAndAuthor(appointmentID, authorID uint) (*models.AppointmentFeedback, error)
+	ScoresByOperation(filters FeedbackFilters) ([]OperationFeedbackScore, error)
+}
+
+// feedbackRepository implements FeedbackRepository interface
+type feedbackRepository struct {
+	db *gorm.DB
+}
+
+// NewFeedbackRepository creates a new feedback repository
+func NewFeedbackRepository(db *gorm.DB) FeedbackRepository {
+	return &feedbackRepository{db: db}
+}
+
+// Create records feedback on an appointment
+func (r *feedbackRepository) Create(feedback *models.AppointmentFeedback) error {
+	return r.db.Create(feedback).Error
+}
+
+// FindByAppointment returns the feedback left on an appointment, oldest first
+func (r *feedbackRepository) FindByAppointment(appointmentID uint) ([]models.AppointmentFeedback, error) {
+	var feedback []models.AppointmentFeedback
+	err := r.db.Where("appointment_id = ?", appointmentID).
+		Order("created_at ASC").
+		Find(&feedback).Error
+	return feedback, err
+}
+
+// FindByAppointmentAndAuthor returns the feedback a user left on an appointment
+func (r *feedbackRepository) FindByAppointmentAndAuthor(appointmentID, authorID uint) (*models.AppointmentFeedback, error) {
+	var feedback models.AppointmentFeedback
+	err := r.db.Where("appointment_id = ? AND author_id = ?", appointmentID, authorID).First(&feedback).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("feedback not found")
+		}
+		return nil, err
+	}
+	return &feedback, nil
+}
+
+// ScoresByOperation averages the ratings left per operation. The date filters apply to when the
+// feedback was left.
+func (r *feedbackRepository) ScoresByOperation(filters FeedbackFilters) ([]OperationFeedbackScore, error) {
+	var scores []OperationFeedbackScore
+
+	query := r.db.Model(&models.AppointmentFeedback{}).
+		Select(
+			"operation_id, "+
+				"COUNT(*) AS responses, "+
+				"AVG(rating) AS average_rating, "+
+				"AVG(rating) FILTER (WHERE author_role = ?) AS supplier_rating, "+
+				"AVG(rating) FILTER (WHERE author_role = ?) AS employee_rating, "+
+				"COUNT(*) FILTER (WHERE rating <= 2) AS low_ratings, "+
+				"COUNT(*) FILTER (WHERE comment <> '') AS comments_written",
+			"supplier", "employee",
+		)
+
+	if filters.OperationID != nil {
+		query = query.Where("operation_id = ?", *filters.OperationID)
+	}
+	if filters.StartDate != nil {
+		query = query.Where("created_at >= ?", *filters.StartDate)
+	}
+	if filters.EndDate != nil {
+		query = query.Where("created_at <= ?", *filters.EndDate)
+	}
+
+	err := query.Group("operation_id").Order("operation_id").Scan(&scores).Error
+	return scores, err
+}
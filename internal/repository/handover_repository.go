@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// HandoverRepository interface defines methods for shift handover notes
+type HandoverRepository interface {
+	Create(handover *models.ShiftHandover) error
+	FindByDay(operationID uint, date time.Time) ([]models.ShiftHandover, error)
+}
+
+// handoverRepository implements HandoverRepository interface
+type handoverRepository struct {
+	db *gorm.DB
+}
+
+// NewHandoverRepository creates a new handover repository
+func NewHandoverRepository(db *gorm.DB) HandoverRepository {
+	return &handoverRepository{db: db}
+}
+
+// Create saves a handover note together with its mentions
+func (r *handoverRepository) Create(handover *models.ShiftHandover) error {
+	return r.db.Create(handover).Error
+}
+
+// FindByDay returns the handover notes of an operation on a date, oldest first
+func (r *handoverRepository) FindByDay(operationID uint, date time.Time) ([]models.ShiftHandover, error) {
+	var handovers []models.ShiftHandover
+	err := r.db.Preload("Mentions").
+		Where("operation_id = ? AND date = ?", operationID, date.Format(models.BlackoutDateLayout)).
+		Order("created_at ASC").
+		Find(&handovers).Error
+	return handovers, err
+}
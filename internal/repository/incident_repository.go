@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// IncidentRepository interface defines methods for appointment incidents
+type IncidentRepository interface {
+	Create(incident *models.AppointmentIncident) error
+	FindByID(id uint) (*models.AppointmentIncident, error)
+	FindByAppointment(appointmentID uint) ([]models.AppointmentIncident, error)
+	Update(incident *models.AppointmentIncident) error
+	AddAttachments(attachments []models.IncidentAttachment) error
+}
+
+// incidentRepository implements IncidentRepository interface
+type incidentRepository struct {
+	db *gorm.DB
+}
+
+// NewIncidentRepository creates a new incident repository
+func NewIncidentRepository(db *gorm.DB) IncidentRepository {
+	return &incidentRepository{db: db}
+}
+
+// Create records an incident together with its attachments
+func (r *incidentRepository) Create(incident *models.AppointmentIncident) error {
+	return r.db.Create(incident).Error
+}
+
+// FindByID finds an incident with its attachments
+func (r *incidentRepository) FindByID(id uint) (*models.AppointmentIncident, error) {
+	var incident models.AppointmentIncident
+	err := r.db.Preload("Attachments").First(&incident, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("incident not found")
+		}
+		return nil, err
+	}
+	return &incident, nil
+}
+
+// FindByAppointment returns the incidents logged on an appointment, most recent first
+func (r *incidentRepository) FindByAppointment(appointmentID uint) ([]models.AppointmentIncident, error) {
+	var incidents []models.AppointmentIncident
+	err := r.db.Preload("Attachments").
+		Where("appointment_id = ?", appointmentID).
+		Order("created_at DESC").
+		Find(&incidents).Error
+	return incidents, err
+}
+
+// Update saves an incident without touching its attachments
+func (r *incidentRepository) Update(incident *models.AppointmentIncident) error {
+	return r.db.Omit("Attachments").Save(incident).Error
+}
+
+// AddAttachments stores attachments on existing incidents
+func (r *incidentRepository) AddAttachments(attachments []models.IncidentAttachment) error {
+	if len(attachments) == 0 {
+		return nil
+	}
+	return r.db.Create(&attachments).Error
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// JobRepository interface defines methods for background job schedule and run repository
+type JobRepository interface {
+	ListSchedules() ([]models.JobSchedule, error)
+	SaveSchedule(schedule *models.JobSchedule) error
+	ClaimRun(run *models.JobRun) (bool, error)
+	FinishRun(job string, scheduledFor, finishedAt time.Time, duration time.Duration, errorMessage string) error
+	LastRuns() ([]models.JobRun, error)
+	PruneRuns(before time.Time) (int64, error)
+}
+
+// jobRepository implements JobRepository interface
+type jobRepository struct {
+	db *gorm.DB
+}
+
+// NewJobRepository creates a new job repository
+func NewJobRepository(db *gorm.DB) JobRepository {
+	return &jobRepository{db: db}
+}
+
+// ListSchedules returns the schedule overrides of every job
+func (r *jobRepository) ListSchedules() ([]models.JobSchedule, error) {
+	var schedules []models.JobSchedule
+	err := r.db.Order("name ASC").Find(&schedules).Error
+	return schedules, err
+}
+
+// SaveSchedule creates or replaces the schedule override of a job
+func (r *jobRepository) SaveSchedule(schedule *models.JobSchedule) error {
+	return r.db.Save(schedule).Error
+}
+
+// ClaimRun records a scheduled run, returning false when another replica already recorded it
+func (r *jobRepository) ClaimRun(run *models.JobRun) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(run)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// FinishRun records the outcome of a scheduled run
+func (r *jobRepository) FinishRun(job string, scheduledFor, finishedAt time.Time, duration time.Duration, errorMessage string) error {
+	return r.db.Model(&models.JobRun{}).
+		Where("job = ? AND scheduled_for = ?", job, scheduledFor).
+		Updates(map[string]interface{}{
+			"finished_at": finishedAt,
+			"duration_ms": duration.Milliseconds(),
+			"error":       errorMessage,
+		}).Error
+}
+
+// LastRuns returns the latest scheduled run of each job, on whichever replica it ran
+func (r *jobRepository) LastRuns() ([]models.JobRun, error) {
+	var runs []models.JobRun
+	err := r.db.Raw(`SELECT DISTINCT ON (job) * FROM job_runs ORDER BY job, scheduled_for DESC`).
+		Scan(&runs).Error
+	return runs, err
+}
+
+// PruneRuns deletes the runs scheduled before a time and returns how many were deleted
+func (r *jobRepository) PruneRuns(before time.Time) (int64, error) {
+	result := r.db.Where("scheduled_for < ?", before).Delete(&models.JobRun{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LabelTemplateRepository interface defines methods for appointment label template repository
+type LabelTemplateRepository interface {
+	Find(operationID uint, format string) (*models.LabelTemplate, error)
+	Save(template *models.LabelTemplate) error
+	Delete(operationID uint, format string) error
+}
+
+// labelTemplateRepository implements LabelTemplateRepository interface
+type labelTemplateRepository struct {
+	db *gorm.DB
+}
+
+// NewLabelTemplateRepository creates a new label template repository
+func NewLabelTemplateRepository(db *gorm.DB) LabelTemplateRepository {
+	return &labelTemplateRepository{db: db}
+}
+
+// Find returns an operation's label template of a format, nil if it uses the built-in one
+func (r *labelTemplateRepository) Find(operationID uint, format string) (*models.LabelTemplate, error) {
+	var template models.LabelTemplate
+	err := r.db.Where("operation_id = ? AND format = ?", operationID, format).First(&template).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// Save creates or replaces an operation's label template of a format
+func (r *labelTemplateRepository) Save(template *models.LabelTemplate) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "operation_id"}, {Name: "format"}},
+		DoUpdates: clause.AssignmentColumns([]string{"body", "updated_by_id", "updated_at"}),
+	}).Create(template).Error
+}
+
+// Delete removes an operation's label template of a format, bringing back the built-in one
+func (r *labelTemplateRepository) Delete(operationID uint, format string) error {
+	return r.db.Where("operation_id = ? AND format = ?", operationID, format).Delete(&models.LabelTemplate{}).Error
+}
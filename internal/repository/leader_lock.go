@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+
+	"github.com/bernardofernandezz/scheduling-api/pkg/leader"
+	"gorm.io/gorm"
+)
+
+// advisoryLock is a leader lock backed by a Postgres session-level advisory lock. It keeps a
+// connection of its own for as long as the lock is held, so the lock is freed by Postgres when
+// the replica dies or loses its connection.
+type advisoryLock struct {
+	db  *gorm.DB
+	key int64
+
+	mu   sync.Mutex
+	conn *sql.Conn
+}
+
+// NewLeaderLock creates a leader lock shared by every replica using the same name
+func (r *Repositories) NewLeaderLock(name string) leader.Lock {
+	hash := fnv.New64a()
+	hash.Write([]byte(name))
+	return &advisoryLock{db: r.db, key: int64(hash.Sum64())}
+}
+
+// TryAcquire takes the advisory lock on a dedicated connection without waiting
+func (l *advisoryLock) TryAcquire(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn != nil {
+		return true, nil
+	}
+
+	sqlDB, err := l.db.DB()
+	if err != nil {
+		return false, err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get a connection: %w", err)
+	}
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", l.key).Scan(&acquired); err != nil {
+		discard(conn)
+		return false, fmt.Errorf("failed to take the advisory lock: %w", err)
+	}
+	if !acquired {
+		conn.Close()
+		return false, nil
+	}
+
+	l.conn = conn
+	return true, nil
+}
+
+// Check makes sure the connection holding the lock is still alive
+func (l *advisoryLock) Check(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		return errors.New("advisory lock is not held")
+	}
+	return l.conn.PingContext(ctx)
+}
+
+// Release unlocks the advisory lock and gives its connection back
+func (l *advisoryLock) Release(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		return nil
+	}
+	conn := l.conn
+	l.conn = nil
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", l.key); err != nil {
+		// Closing the session frees the lock too
+		discard(conn)
+		return fmt.Errorf("failed to release the advisory lock: %w", err)
+	}
+	return conn.Close()
+}
+
+// discard closes a connection instead of returning it to the pool, ending its session
+func discard(conn *sql.Conn) {
+	_ = conn.Raw(func(any) error {
+		return driver.ErrBadConn
+	})
+	conn.Close()
+}
@@ -0,0 +1,538 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NotificationRepository interface defines methods for notification repository
+type NotificationRepository interface {
+	Create(notification *models.Notification) error
+	GetByID(id uint) (*models.Notification, error)
+	GetByRecipient(recipientType models.NotificationRecipientType, recipientID uint) ([]models.Notification, error)
+	GetPendingCreatedSince(since time.Time) ([]models.Notification, error)
+	FindPendingDuplicate(notification *models.Notification, since time.Time) (*models.Notification, error)
+	ExistsSince(notification *models.Notification, since time.Time) (bool, error)
+	FindPendingByAppointment(appointmentID uint) ([]models.Notification, error)
+	Update(notification *models.Notification) error
+	UpdateCollapsed(notification *models.Notification, now time.Time) (bool, error)
+}
+
+// notificationRepository implements NotificationRepository interface
+type notificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository creates a new notification repository
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+// Create creates a new notification
+func (r *notificationRepository) Create(notification *models.Notification) error {
+	return r.db.Create(notification).Error
+}
+
+// GetByID finds a notification by ID
+func (r *notificationRepository) GetByID(id uint) (*models.Notification, error) {
+	var notification models.Notification
+	if err := r.db.First(&notification, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("notification not found")
+		}
+		return nil, err
+	}
+	return &notification, nil
+}
+
+// GetByRecipient returns the notifications of a recipient, newest first
+func (r *notificationRepository) GetByRecipient(recipientType models.NotificationRecipientType, recipientID uint) ([]models.Notification, error) {
+	var notifications []models.Notification
+	err := r.db.Where("recipient_type = ? AND recipient_id = ?", recipientType, recipientID).
+		Order("created_at DESC").
+		Find(&notifications).Error
+	return notifications, err
+}
+
+// GetPendingCreatedSince returns pending notifications created at or after the given time, oldest first
+func (r *notificationRepository) GetPendingCreatedSince(since time.Time) ([]models.Notification, error) {
+	var notifications []models.Notification
+	err := r.db.Where("status = ? AND created_at >= ?", models.NotificationStatusPending, since).
+		Order("created_at ASC").
+		Find(&notifications).Error
+	return notifications, err
+}
+
+// FindPendingDuplicate returns the newest pending notification created since the given time for the
+// same channel, recipient, event, appointment and scheduled time, or nil if there is none.
+// Notifications scheduled for different times, such as the day-before and hour-before reminders of
+// an appointment, are never duplicates.
+func (r *notificationRepository) FindPendingDuplicate(notification *models.Notification, since time.Time) (*models.Notification, error) {
+	var duplicate models.Notification
+
+	query := r.db.Where("status = ? AND type = ? AND event = ? AND recipient_type = ? AND recipient_id = ? AND created_at >= ?",
+		models.NotificationStatusPending, notification.Type, notification.Event,
+		notification.RecipientType, notification.RecipientID, since)
+	if notification.AppointmentID != nil {
+		query = query.Where("appointment_id = ?", *notification.AppointmentID)
+	} else {
+		query = query.Where("appointment_id IS NULL")
+	}
+	if notification.ScheduledFor != nil {
+		query = query.Where("scheduled_for = ?", *notification.ScheduledFor)
+	} else {
+		query = query.Where("scheduled_for IS NULL")
+	}
+
+	if err := query.Order("created_at DESC").First(&duplicate).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &duplicate, nil
+}
+
+// ExistsSince reports whether a notification was created since the given time for the same
+// channel, recipient, event, appointment and template data, whatever its status
+func (r *notificationRepository) ExistsSince(notification *models.Notification, since time.Time) (bool, error) {
+	var count int64
+
+	query := r.db.Model(&models.Notification{}).
+		Where("type = ? AND event = ? AND recipient_type = ? AND recipient_id = ? AND template_data = ? AND created_at >= ?",
+			notification.Type, notification.Event, notification.RecipientType, notification.RecipientID,
+			notification.TemplateData, since)
+	if notification.AppointmentID != nil {
+		query = query.Where("appointment_id = ?", *notification.AppointmentID)
+	} else {
+		query = query.Where("appointment_id IS NULL")
+	}
+
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+// FindPendingByAppointment returns the notifications about an appointment that weren't sent yet,
+// such as scheduled reminders, oldest first
+func (r *notificationRepository) FindPendingByAppointment(appointmentID uint) ([]models.Notification, error) {
+	var notifications []models.Notification
+	err := r.db.Where("appointment_id = ? AND status = ?", appointmentID, models.NotificationStatusPending).
+		Order("created_at ASC").
+		Find(&notifications).Error
+	return notifications, err
+}
+
+// Update updates a notification
+func (r *notificationRepository) Update(notification *models.Notification) error {
+	return r.db.Save(notification).Error
+}
+
+// UpdateCollapsed saves a pending notification a newer one was merged into and raises the
+// priority of its queue items to the notification's, in one transaction. Queue items a worker
+// has locked are left alone: it reports false without saving if the notification has no
+// pending queue item that isn't locked at now, as it may already be on its way out.
+func (r *notificationRepository) UpdateCollapsed(notification *models.Notification, now time.Time) (bool, error) {
+	updated := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var items []models.NotificationQueue
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("notification_id = ? AND status = ?", notification.ID, models.NotificationStatusPending).
+			Where("locked_until IS NULL OR locked_until < ?", now).
+			Find(&items).Error
+		if err != nil || len(items) == 0 {
+			return err
+		}
+
+		if err := tx.Save(notification).Error; err != nil {
+			return err
+		}
+
+		ids := make([]uint, 0, len(items))
+		for _, item := range items {
+			ids = append(ids, item.ID)
+		}
+		err = tx.Model(&models.NotificationQueue{}).
+			Where("id IN ? AND priority < ?", ids, notification.Priority).
+			Update("priority", notification.Priority).Error
+		if err != nil {
+			return err
+		}
+
+		updated = true
+		return nil
+	})
+	return updated, err
+}
+
+// NotificationTemplateRepository interface defines methods for notification template repository
+type NotificationTemplateRepository interface {
+	Create(template *models.NotificationTemplate) error
+	GetByID(id uint) (*models.NotificationTemplate, error)
+	GetByName(name string) (*models.NotificationTemplate, error)
+	GetByEvent(event models.NotificationEvent, recipientType models.NotificationRecipientType, notificationType models.NotificationType) (*models.NotificationTemplate, error)
+	List() ([]models.NotificationTemplate, error)
+	Update(template *models.NotificationTemplate) error
+}
+
+// notificationTemplateRepository implements NotificationTemplateRepository interface
+type notificationTemplateRepository struct {
+	db *gorm.DB
+}
+
+// ErrNotificationTemplateNotFound is returned when no notification template has the ID, name or event looked up
+var ErrNotificationTemplateNotFound = errors.New("notification template not found")
+
+// NewNotificationTemplateRepository creates a new notification template repository
+func NewNotificationTemplateRepository(db *gorm.DB) NotificationTemplateRepository {
+	return &notificationTemplateRepository{db: db}
+}
+
+// Create creates a new notification template
+func (r *notificationTemplateRepository) Create(template *models.NotificationTemplate) error {
+	return r.db.Create(template).Error
+}
+
+// GetByID finds a notification template by ID
+func (r *notificationTemplateRepository) GetByID(id uint) (*models.NotificationTemplate, error) {
+	var template models.NotificationTemplate
+	if err := r.db.First(&template, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotificationTemplateNotFound
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+// GetByName finds a notification template by its unique name
+func (r *notificationTemplateRepository) GetByName(name string) (*models.NotificationTemplate, error) {
+	var template models.NotificationTemplate
+	if err := r.db.Where("name = ?", name).First(&template).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotificationTemplateNotFound
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+// GetByEvent finds the active template for an event, recipient type and channel
+func (r *notificationTemplateRepository) GetByEvent(event models.NotificationEvent, recipientType models.NotificationRecipientType, notificationType models.NotificationType) (*models.NotificationTemplate, error) {
+	var template models.NotificationTemplate
+	err := r.db.Where("event = ? AND recipient_type = ? AND type = ? AND is_active = ?",
+		event, recipientType, notificationType, true).
+		Order("updated_at DESC").
+		First(&template).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotificationTemplateNotFound
+		}
+		return nil, err
+	}
+	return &template, nil
+}
+
+// List returns all notification templates ordered by name
+func (r *notificationTemplateRepository) List() ([]models.NotificationTemplate, error) {
+	var templates []models.NotificationTemplate
+	err := r.db.Order("name ASC").Find(&templates).Error
+	return templates, err
+}
+
+// Update updates a notification template
+func (r *notificationTemplateRepository) Update(template *models.NotificationTemplate) error {
+	return r.db.Save(template).Error
+}
+
+// QueueAgingInterval is how long a queued item waits before its effective priority is raised by one.
+// Strict priority ordering is kept within this interval; beyond it, aging prevents low priority
+// items from starving behind a constant stream of high priority ones.
+const QueueAgingInterval = 10 * time.Minute
+
+// NotificationQueueRepository interface defines methods for notification queue repository
+type NotificationQueueRepository interface {
+	Create(item *models.NotificationQueue) error
+	GetByID(id uint) (*models.NotificationQueue, error)
+	Update(item *models.NotificationQueue) error
+	GetPendingByQueue(queueName string, now time.Time, limit int) ([]models.NotificationQueue, error)
+	GetPendingByQueueForEvents(queueName string, events []models.NotificationEvent, now time.Time, limit int) ([]models.NotificationQueue, error)
+	CancelByNotificationIDs(notificationIDs []uint) error
+	CountPendingByPriority(queueName string) (map[int]int64, error)
+	CountByQueue() ([]QueueDepth, error)
+	FindLocked(limit int) ([]models.NotificationQueue, error)
+	ReleaseLocks(ids []uint) (int64, error)
+	ReleaseExpiredLocks(before time.Time) (int64, error)
+}
+
+// QueueDepth is the number of unprocessed items of a queue with a given priority and status
+type QueueDepth struct {
+	QueueName string                    `json:"queue_name"`
+	Priority  int                       `json:"priority"`
+	Status    models.NotificationStatus `json:"status"`
+	Count     int64                     `json:"count"`
+}
+
+// notificationQueueRepository implements NotificationQueueRepository interface
+type notificationQueueRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationQueueRepository creates a new notification queue repository
+func NewNotificationQueueRepository(db *gorm.DB) NotificationQueueRepository {
+	return &notificationQueueRepository{db: db}
+}
+
+// Create adds an item to the queue
+func (r *notificationQueueRepository) Create(item *models.NotificationQueue) error {
+	return r.db.Create(item).Error
+}
+
+// GetByID finds a queue item by ID
+func (r *notificationQueueRepository) GetByID(id uint) (*models.NotificationQueue, error) {
+	var item models.NotificationQueue
+	if err := r.db.First(&item, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("queue item not found")
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+// Update updates a queue item
+func (r *notificationQueueRepository) Update(item *models.NotificationQueue) error {
+	return r.db.Save(item).Error
+}
+
+// GetPendingByQueue returns the next unlocked pending items of a queue that are due at now, in
+// priority order. Items are ordered by effective priority (priority plus one per
+// QueueAgingInterval waited since they were due), then by how long they have been due, so higher
+// priorities always go first but old items eventually catch up.
+func (r *notificationQueueRepository) GetPendingByQueue(queueName string, now time.Time, limit int) ([]models.NotificationQueue, error) {
+	var items []models.NotificationQueue
+	err := r.pendingQuery(queueName, now, limit).Find(&items).Error
+	return items, err
+}
+
+// GetPendingByQueueForEvents is like GetPendingByQueue but only returns items whose
+// notification was triggered by one of the given events
+func (r *notificationQueueRepository) GetPendingByQueueForEvents(queueName string, events []models.NotificationEvent, now time.Time, limit int) ([]models.NotificationQueue, error) {
+	var items []models.NotificationQueue
+	if len(events) == 0 {
+		return items, nil
+	}
+
+	err := r.pendingQuery(queueName, now, limit).
+		Where("notifications.event IN ?", events).
+		Find(&items).Error
+	return items, err
+}
+
+// CancelByNotificationIDs cancels the pending queue items of the given notifications
+func (r *notificationQueueRepository) CancelByNotificationIDs(notificationIDs []uint) error {
+	if len(notificationIDs) == 0 {
+		return nil
+	}
+
+	return r.db.Model(&models.NotificationQueue{}).
+		Where("notification_id IN ? AND status = ?", notificationIDs, models.NotificationStatusPending).
+		Update("status", models.NotificationStatusCancelled).Error
+}
+
+// queueItemDue is when a queue item became due: when it was queued or, for a notification
+// scheduled later, such as a held reminder or a broadcast, when it is scheduled for
+const queueItemDue = "GREATEST(notification_queues.created_at, COALESCE(notifications.scheduled_for, notification_queues.created_at))"
+
+// pendingQuery builds the query selecting the unlocked pending items of a queue that are due at
+// now, in effective priority order. Items only age once due, so notifications scheduled for later
+// never crowd out fresh work when they come up.
+func (r *notificationQueueRepository) pendingQuery(queueName string, now time.Time, limit int) *gorm.DB {
+	query := r.db.Model(&models.NotificationQueue{}).
+		Select("notification_queues.*").
+		Joins("JOIN notifications ON notifications.id = notification_queues.notification_id AND notifications.deleted_at IS NULL").
+		Where("notification_queues.queue_name = ? AND notification_queues.status = ?", queueName, models.NotificationStatusPending).
+		Where("notification_queues.locked_until IS NULL OR notification_queues.locked_until < ?", now).
+		Where("notifications.scheduled_for IS NULL OR notifications.scheduled_for <= ?", now).
+		Order(gorm.Expr("notification_queues.priority + FLOOR(EXTRACT(EPOCH FROM (? - "+queueItemDue+")) / ?) DESC",
+			now, QueueAgingInterval.Seconds())).
+		Order(queueItemDue + " ASC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	return query
+}
+
+// CountPendingByPriority returns the number of pending items of a queue per priority
+func (r *notificationQueueRepository) CountPendingByPriority(queueName string) (map[int]int64, error) {
+	var rows []struct {
+		Priority int
+		Count    int64
+	}
+
+	err := r.db.Model(&models.NotificationQueue{}).
+		Select("priority, COUNT(*) AS count").
+		Where("queue_name = ? AND status = ?", queueName, models.NotificationStatusPending).
+		Group("priority").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Priority] = row.Count
+	}
+	return counts, nil
+}
+
+// CountByQueue returns the number of pending and in-flight items per queue, priority and status
+func (r *notificationQueueRepository) CountByQueue() ([]QueueDepth, error) {
+	var depths []QueueDepth
+	err := r.db.Model(&models.NotificationQueue{}).
+		Select("queue_name, priority, status, COUNT(*) AS count").
+		Where("status IN ?", []models.NotificationStatus{models.NotificationStatusPending, models.NotificationStatusSending}).
+		Group("queue_name, priority, status").
+		Order("queue_name ASC, priority DESC, status ASC").
+		Scan(&depths).Error
+	return depths, err
+}
+
+// FindLocked returns the items a worker has locked for sending, the longest expired lock first
+func (r *notificationQueueRepository) FindLocked(limit int) ([]models.NotificationQueue, error) {
+	var items []models.NotificationQueue
+	query := r.db.Where("status = ? AND locked_until IS NOT NULL", models.NotificationStatusSending).
+		Order("locked_until ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	err := query.Find(&items).Error
+	return items, err
+}
+
+// ReleaseLocks puts locked items back in the queue as pending so any worker can pick them up
+func (r *notificationQueueRepository) ReleaseLocks(ids []uint) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	result := r.db.Model(&models.NotificationQueue{}).
+		Where("id IN ? AND status = ?", ids, models.NotificationStatusSending).
+		Updates(map[string]interface{}{
+			"status":       models.NotificationStatusPending,
+			"locked_until": nil,
+			"processor_id": nil,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// ReleaseExpiredLocks puts items whose lock expired before the given time back in the queue as
+// pending. Such items belong to a worker that crashed or stalled while sending.
+func (r *notificationQueueRepository) ReleaseExpiredLocks(before time.Time) (int64, error) {
+	result := r.db.Model(&models.NotificationQueue{}).
+		Where("status = ? AND locked_until < ?", models.NotificationStatusSending, before).
+		Updates(map[string]interface{}{
+			"status":       models.NotificationStatusPending,
+			"locked_until": nil,
+			"processor_id": nil,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// NotificationPauseRepository interface defines methods for notification pause repository
+type NotificationPauseRepository interface {
+	Create(pause *models.NotificationPause) error
+	GetActive() (*models.NotificationPause, error)
+	List(limit int) ([]models.NotificationPause, error)
+	Update(pause *models.NotificationPause) error
+}
+
+// notificationPauseRepository implements NotificationPauseRepository interface
+type notificationPauseRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationPauseRepository creates a new notification pause repository
+func NewNotificationPauseRepository(db *gorm.DB) NotificationPauseRepository {
+	return &notificationPauseRepository{db: db}
+}
+
+// Create creates a new notification pause
+func (r *notificationPauseRepository) Create(pause *models.NotificationPause) error {
+	return r.db.Create(pause).Error
+}
+
+// GetActive returns the pause that has not been released yet, or nil if notifications are not paused
+func (r *notificationPauseRepository) GetActive() (*models.NotificationPause, error) {
+	var pause models.NotificationPause
+	err := r.db.Where("released_at IS NULL").Order("started_at DESC").First(&pause).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &pause, nil
+}
+
+// List returns the most recent notification pauses, newest first
+func (r *notificationPauseRepository) List(limit int) ([]models.NotificationPause, error) {
+	var pauses []models.NotificationPause
+	query := r.db.Order("started_at DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	err := query.Find(&pauses).Error
+	return pauses, err
+}
+
+// Update updates a notification pause
+func (r *notificationPauseRepository) Update(pause *models.NotificationPause) error {
+	return r.db.Save(pause).Error
+}
+
+// NotificationPreferenceRepository interface defines methods for notification preference repository
+type NotificationPreferenceRepository interface {
+	Create(preference *models.NotificationPreference) error
+	GetByUserID(userID uint) (*models.NotificationPreference, error)
+	Update(preference *models.NotificationPreference) error
+}
+
+// notificationPreferenceRepository implements NotificationPreferenceRepository interface
+type notificationPreferenceRepository struct {
+	db *gorm.DB
+}
+
+// ErrNotificationPreferenceNotFound is returned when a user has no notification preferences
+var ErrNotificationPreferenceNotFound = errors.New("notification preference not found")
+
+// NewNotificationPreferenceRepository creates a new notification preference repository
+func NewNotificationPreferenceRepository(db *gorm.DB) NotificationPreferenceRepository {
+	return &notificationPreferenceRepository{db: db}
+}
+
+// Create creates notification preferences for a user
+func (r *notificationPreferenceRepository) Create(preference *models.NotificationPreference) error {
+	return r.db.Create(preference).Error
+}
+
+// GetByUserID finds the notification preferences of a user
+func (r *notificationPreferenceRepository) GetByUserID(userID uint) (*models.NotificationPreference, error) {
+	var preference models.NotificationPreference
+	if err := r.db.Where("user_id = ?", userID).First(&preference).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotificationPreferenceNotFound
+		}
+		return nil, err
+	}
+	return &preference, nil
+}
+
+// Update updates notification preferences
+func (r *notificationPreferenceRepository) Update(preference *models.NotificationPreference) error {
+	return r.db.Save(preference).Error
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindPendingDuplicateKeepsRemindersAtDifferentTimes(t *testing.T) {
+	db := newTestDB(t)
+	parties := seedParties(t, db)
+	appointment := parties.appointment(time.Date(2030, time.January, 8, 10, 0, 0, 0, time.UTC))
+	require.NoError(t, db.Omit("Supplier", "Employee", "Operation", "Product").Create(&appointment).Error)
+	t.Cleanup(func() {
+		db.Unscoped().Where("appointment_id = ?", appointment.ID).Delete(&models.Notification{})
+	})
+
+	since := time.Now().Add(-time.Minute)
+	reminder := func(before time.Duration) *models.Notification {
+		scheduledFor := appointment.ScheduledStart.Add(-before)
+		return &models.Notification{
+			Type:          models.NotificationTypeEmail,
+			Status:        models.NotificationStatusPending,
+			Event:         models.EventAppointmentReminder,
+			RecipientType: models.RecipientSupplier,
+			RecipientID:   parties.supplierID,
+			Subject:       "Appointment reminder",
+			Body:          "Your delivery is coming up",
+			AppointmentID: &appointment.ID,
+			ScheduledFor:  &scheduledFor,
+		}
+	}
+	repo := NewNotificationRepository(db)
+
+	dayBefore := reminder(24 * time.Hour)
+	require.NoError(t, repo.Create(dayBefore))
+
+	// The hour-before reminder is not a duplicate of the day-before one
+	duplicate, err := repo.FindPendingDuplicate(reminder(time.Hour), since)
+	require.NoError(t, err)
+	assert.Nil(t, duplicate)
+
+	// Nor is a notification sent right away
+	immediate := reminder(0)
+	immediate.ScheduledFor = nil
+	duplicate, err = repo.FindPendingDuplicate(immediate, since)
+	require.NoError(t, err)
+	assert.Nil(t, duplicate)
+
+	// A second day-before reminder is
+	duplicate, err = repo.FindPendingDuplicate(reminder(24*time.Hour), since)
+	require.NoError(t, err)
+	require.NotNil(t, duplicate)
+	assert.Equal(t, dayBefore.ID, duplicate.ID)
+}
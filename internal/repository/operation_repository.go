@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// OperationRepository interface defines methods for operation repository
+type OperationRepository interface {
+	Create(operation *models.Operation) error
+	FindByID(id uint) (*models.Operation, error)
+	FindByCode(code string) (*models.Operation, error)
+	Update(operation *models.Operation) error
+	List() ([]models.Operation, error)
+	FindMissingCoordinates(limit int) ([]models.Operation, error)
+	FindImportingHolidays() ([]models.Operation, error)
+	ImportConfig(operation *models.Operation, slots []models.AvailabilitySlot, templates []models.NotificationTemplate) error
+}
+
+// operationRepository implements OperationRepository interface
+type operationRepository struct {
+	db *gorm.DB
+}
+
+// ErrOperationNotFound is returned when no operation has the ID or code looked up
+var ErrOperationNotFound = errors.New("operation not found")
+
+// NewOperationRepository creates a new operation repository
+func NewOperationRepository(db *gorm.DB) OperationRepository {
+	return &operationRepository{db: db}
+}
+
+// Create creates a new operation
+func (r *operationRepository) Create(operation *models.Operation) error {
+	return r.db.Create(operation).Error
+}
+
+// FindByID finds an operation by ID
+func (r *operationRepository) FindByID(id uint) (*models.Operation, error) {
+	var operation models.Operation
+	err := r.db.Preload("Manager").First(&operation, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOperationNotFound
+		}
+		return nil, err
+	}
+	return &operation, nil
+}
+
+// FindByCode finds an operation by its unique code
+func (r *operationRepository) FindByCode(code string) (*models.Operation, error) {
+	var operation models.Operation
+	err := r.db.Preload("Manager").Where("code = ?", code).First(&operation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOperationNotFound
+		}
+		return nil, err
+	}
+	return &operation, nil
+}
+
+// Update updates an operation
+func (r *operationRepository) Update(operation *models.Operation) error {
+	return r.db.Save(operation).Error
+}
+
+// List returns all operations ordered by name
+func (r *operationRepository) List() ([]models.Operation, error) {
+	var operations []models.Operation
+	err := r.db.Order("name ASC").Find(&operations).Error
+	return operations, err
+}
+
+// FindMissingCoordinates returns active operations whose address has not been geocoded yet
+func (r *operationRepository) FindMissingCoordinates(limit int) ([]models.Operation, error) {
+	var operations []models.Operation
+	err := r.db.Where("active = ? AND (latitude IS NULL OR longitude IS NULL)", true).
+		Order("id ASC").
+		Limit(limit).
+		Find(&operations).Error
+	return operations, err
+}
+
+// FindImportingHolidays returns active operations that opted in to importing public holidays
+func (r *operationRepository) FindImportingHolidays() ([]models.Operation, error) {
+	var operations []models.Operation
+	err := r.db.Where("active = ? AND import_holidays = ?", true, true).
+		Order("id ASC").
+		Find(&operations).Error
+	return operations, err
+}
+
+// ImportConfig applies an imported operation configuration in a single transaction.
+// The operation is matched by code, its availability slots are replaced and the
+// notification templates given, if any, are upserted by name.
+func (r *operationRepository) ImportConfig(operation *models.Operation, slots []models.AvailabilitySlot, templates []models.NotificationTemplate) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Upsert the operation itself
+		var existing models.Operation
+		err := tx.Where("code = ?", operation.Code).First(&existing).Error
+		switch {
+		case err == nil:
+			operation.ID = existing.ID
+			operation.CreatedAt = existing.CreatedAt
+			// Coordinates are geocoded, not part of the configuration
+			if operation.Latitude == nil && operation.Longitude == nil {
+				operation.Latitude = existing.Latitude
+				operation.Longitude = existing.Longitude
+			}
+			if err := tx.Save(operation).Error; err != nil {
+				return err
+			}
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if err := tx.Create(operation).Error; err != nil {
+				return err
+			}
+		default:
+			return err
+		}
+
+		// Replace availability slots for the operation
+		if err := tx.Where("operation_id = ?", operation.ID).Delete(&models.AvailabilitySlot{}).Error; err != nil {
+			return err
+		}
+		for i := range slots {
+			slots[i].ID = 0
+			slots[i].OperationID = operation.ID
+			if err := tx.Create(&slots[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		// Upsert notification templates by name
+		for i := range templates {
+			var current models.NotificationTemplate
+			err := tx.Where("name = ?", templates[i].Name).First(&current).Error
+			switch {
+			case err == nil:
+				templates[i].ID = current.ID
+				templates[i].CreatedAt = current.CreatedAt
+				if err := tx.Save(&templates[i]).Error; err != nil {
+					return err
+				}
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				if err := tx.Create(&templates[i]).Error; err != nil {
+					return err
+				}
+			default:
+				return err
+			}
+		}
+
+		return nil
+	})
+}
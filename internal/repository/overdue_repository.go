@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// OverdueRepository interface defines methods for finding appointments nobody attended to:
+// confirmed ones left open after they end and pending ones nobody confirmed in time
+type OverdueRepository interface {
+	FindOverdue(now time.Time, limit int) ([]models.Appointment, error)
+	FindExpiringPending(now time.Time, lead time.Duration, limit int) ([]models.Appointment, error)
+	FindExpiredPending(now time.Time, lead time.Duration, limit int) ([]models.Appointment, error)
+	MarkExpiryWarned(id uint, at time.Time) error
+	ExpirePending(id uint, at time.Time, reason string) (bool, error)
+}
+
+// overdueRepository implements OverdueRepository interface
+type overdueRepository struct {
+	db *gorm.DB
+}
+
+// NewOverdueRepository creates a new overdue appointment repository
+func NewOverdueRepository(db *gorm.DB) OverdueRepository {
+	return &overdueRepository{db: db}
+}
+
+// pendingExpiry is when a pending appointment expires: its operation's pending expiry before it
+// starts, but no sooner than the warning lead, in seconds, after it was booked
+const pendingExpiry = "GREATEST(appointments.scheduled_start - operations.pending_expiry_hours * INTERVAL '1 hour', " +
+	"appointments.created_at + ? * INTERVAL '1 second')"
+
+// FindOverdue returns confirmed appointments not yet flagged whose operation's auto-complete
+// delay has passed since their scheduled end, oldest first. Operations with auto-completion off
+// are skipped.
+func (r *overdueRepository) FindOverdue(now time.Time, limit int) ([]models.Appointment, error) {
+	var appointments []models.Appointment
+	err := r.db.Joins("JOIN operations ON operations.id = appointments.operation_id").
+		Where("appointments.status = ? AND appointments.overdue_flagged_at IS NULL", models.StatusConfirmed).
+		Where("operations.auto_complete_mode <> ?", models.AutoCompleteOff).
+		Where("appointments.scheduled_end + operations.auto_complete_after_hours * INTERVAL '1 hour' <= ?", now).
+		Preload("Supplier").
+		Order("appointments.scheduled_end ASC").
+		Limit(limit).
+		Find(&appointments).Error
+	return appointments, err
+}
+
+// FindExpiringPending returns pending appointments not yet warned that expire within the lead,
+// the soonest first. Operations whose pending appointments don't expire are skipped.
+func (r *overdueRepository) FindExpiringPending(now time.Time, lead time.Duration, limit int) ([]models.Appointment, error) {
+	seconds := int64(lead / time.Second)
+	var appointments []models.Appointment
+	err := r.pending().
+		Where("appointments.expiry_warned_at IS NULL").
+		Where(pendingExpiry+" > ?", seconds, now).
+		Where(pendingExpiry+" <= ?", seconds, now.Add(lead)).
+		Order("appointments.scheduled_start ASC").
+		Limit(limit).
+		Find(&appointments).Error
+	return appointments, err
+}
+
+// FindExpiredPending returns pending appointments whose expiry passed, the soonest to start first
+func (r *overdueRepository) FindExpiredPending(now time.Time, lead time.Duration, limit int) ([]models.Appointment, error) {
+	var appointments []models.Appointment
+	err := r.pending().
+		Where(pendingExpiry+" <= ?", int64(lead/time.Second), now).
+		Order("appointments.scheduled_start ASC").
+		Limit(limit).
+		Find(&appointments).Error
+	return appointments, err
+}
+
+// MarkExpiryWarned records when an appointment's participants were warned it expires
+func (r *overdueRepository) MarkExpiryWarned(id uint, at time.Time) error {
+	return r.db.Model(&models.Appointment{}).Where("id = ?", id).
+		Update("expiry_warned_at", at).Error
+}
+
+// ExpirePending cancels an appointment that is still pending, reporting false when it was
+// confirmed or cancelled meanwhile
+func (r *overdueRepository) ExpirePending(id uint, at time.Time, reason string) (bool, error) {
+	result := r.db.Model(&models.Appointment{}).
+		Where("id = ? AND status = ?", id, models.StatusPending).
+		Updates(map[string]interface{}{
+			"status":              models.StatusCancelled,
+			"cancelled_at":        at,
+			"cancellation_reason": reason,
+			"expired_at":          at,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+// pending selects the pending appointments of operations whose pending appointments expire
+func (r *overdueRepository) pending() *gorm.DB {
+	return r.db.Joins("JOIN operations ON operations.id = appointments.operation_id").
+		Where("appointments.status = ? AND operations.pending_expiry_hours > 0", models.StatusPending).
+		Preload("Supplier").
+		Preload("Employee").
+		Preload("Operation")
+}
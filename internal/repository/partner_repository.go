@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PartnerRepository interface defines methods for partner API credentials and the nonces of
+// their signed requests
+type PartnerRepository interface {
+	Create(credential *models.PartnerCredential) error
+	FindByID(id uint) (*models.PartnerCredential, error)
+	FindByKeyID(keyID string) (*models.PartnerCredential, error)
+	List() ([]models.PartnerCredential, error)
+	Update(credential *models.PartnerCredential) error
+	TouchLastUsed(id uint, at time.Time) error
+	UseNonce(nonce *models.PartnerNonce) (bool, error)
+	PruneNonces(before time.Time) (int64, error)
+}
+
+// partnerRepository implements PartnerRepository interface
+type partnerRepository struct {
+	db *gorm.DB
+}
+
+// ErrPartnerCredentialNotFound is returned when no partner credential has the ID or key ID looked
+// up
+var ErrPartnerCredentialNotFound = errors.New("partner credential not found")
+
+// NewPartnerRepository creates a new partner repository
+func NewPartnerRepository(db *gorm.DB) PartnerRepository {
+	return &partnerRepository{db: db}
+}
+
+// Create adds a partner credential
+func (r *partnerRepository) Create(credential *models.PartnerCredential) error {
+	return r.db.Create(credential).Error
+}
+
+// FindByID finds a partner credential by ID
+func (r *partnerRepository) FindByID(id uint) (*models.PartnerCredential, error) {
+	var credential models.PartnerCredential
+	if err := r.db.First(&credential, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPartnerCredentialNotFound
+		}
+		return nil, err
+	}
+	return &credential, nil
+}
+
+// FindByKeyID finds a partner credential by the key ID partners sign requests with
+func (r *partnerRepository) FindByKeyID(keyID string) (*models.PartnerCredential, error) {
+	var credential models.PartnerCredential
+	if err := r.db.Where("key_id = ?", keyID).First(&credential).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPartnerCredentialNotFound
+		}
+		return nil, err
+	}
+	return &credential, nil
+}
+
+// List returns every partner credential, revoked ones included, ordered by name
+func (r *partnerRepository) List() ([]models.PartnerCredential, error) {
+	var credentials []models.PartnerCredential
+	err := r.db.Order("name ASC, id ASC").Find(&credentials).Error
+	return credentials, err
+}
+
+// Update saves a partner credential
+func (r *partnerRepository) Update(credential *models.PartnerCredential) error {
+	return r.db.Save(credential).Error
+}
+
+// TouchLastUsed records when a credential last signed an accepted request
+func (r *partnerRepository) TouchLastUsed(id uint, at time.Time) error {
+	return r.db.Model(&models.PartnerCredential{}).Where("id = ?", id).
+		UpdateColumn("last_used_at", at).Error
+}
+
+// UseNonce records a nonce, returning false when the partner already used it
+func (r *partnerRepository) UseNonce(nonce *models.PartnerNonce) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(nonce)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// PruneNonces deletes the nonces that expired before a time and returns how many were deleted
+func (r *partnerRepository) PruneNonces(before time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ?", before).Delete(&models.PartnerNonce{})
+	return result.RowsAffected, result.Error
+}
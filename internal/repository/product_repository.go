@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// ProductFilters defines filters for the product catalog
+type ProductFilters struct {
+	SupplierID *uint
+	Category   string
+	ActiveOnly bool
+}
+
+// ProductRepository interface defines methods for product repository
+type ProductRepository interface {
+	Create(product *models.Product) error
+	FindByID(id uint) (*models.Product, error)
+	FindBySKU(sku string) (*models.Product, error)
+	Update(product *models.Product) error
+	List(filters ProductFilters) ([]models.Product, error)
+}
+
+// productRepository implements ProductRepository interface
+type productRepository struct {
+	db *gorm.DB
+}
+
+// NewProductRepository creates a new product repository
+func NewProductRepository(db *gorm.DB) ProductRepository {
+	return &productRepository{db: db}
+}
+
+// Create creates a new product
+func (r *productRepository) Create(product *models.Product) error {
+	return r.db.Create(product).Error
+}
+
+// FindByID finds a product by ID
+func (r *productRepository) FindByID(id uint) (*models.Product, error) {
+	var product models.Product
+	err := r.db.First(&product, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("product not found")
+		}
+		return nil, err
+	}
+	return &product, nil
+}
+
+// FindBySKU finds a product by its SKU, ignoring case
+func (r *productRepository) FindBySKU(sku string) (*models.Product, error) {
+	var product models.Product
+	err := r.db.Where("UPPER(sku) = UPPER(?)", sku).First(&product).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("product not found")
+		}
+		return nil, err
+	}
+	return &product, nil
+}
+
+// Update updates a product
+func (r *productRepository) Update(product *models.Product) error {
+	return r.db.Save(product).Error
+}
+
+// List returns the products matching the filters ordered by name
+func (r *productRepository) List(filters ProductFilters) ([]models.Product, error) {
+	query := r.db.Model(&models.Product{})
+	if filters.SupplierID != nil {
+		query = query.Where("supplier_id = ?", *filters.SupplierID)
+	}
+	if filters.Category != "" {
+		query = query.Where("category = ?", filters.Category)
+	}
+	if filters.ActiveOnly {
+		query = query.Where("active = ?", true)
+	}
+
+	var products []models.Product
+	err := query.Order("name ASC").Find(&products).Error
+	return products, err
+}
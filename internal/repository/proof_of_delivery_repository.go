@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// ProofOfDeliveryRepository interface defines methods for proofs of delivery
+type ProofOfDeliveryRepository interface {
+	Create(proof *models.ProofOfDelivery) error
+	FindByAppointment(appointmentID uint) (*models.ProofOfDelivery, error)
+	AddAttachments(attachments []models.ProofOfDeliveryAttachment) error
+}
+
+// proofOfDeliveryRepository implements ProofOfDeliveryRepository interface
+type proofOfDeliveryRepository struct {
+	db *gorm.DB
+}
+
+// NewProofOfDeliveryRepository creates a new proof of delivery repository
+func NewProofOfDeliveryRepository(db *gorm.DB) ProofOfDeliveryRepository {
+	return &proofOfDeliveryRepository{db: db}
+}
+
+// Create records a proof of delivery together with its attachments
+func (r *proofOfDeliveryRepository) Create(proof *models.ProofOfDelivery) error {
+	return r.db.Create(proof).Error
+}
+
+// FindByAppointment finds the proof of delivery of an appointment with its attachments
+func (r *proofOfDeliveryRepository) FindByAppointment(appointmentID uint) (*models.ProofOfDelivery, error) {
+	var proof models.ProofOfDelivery
+	err := r.db.Preload("Attachments", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at ASC")
+	}).Where("appointment_id = ?", appointmentID).First(&proof).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("proof of delivery not found")
+		}
+		return nil, err
+	}
+	return &proof, nil
+}
+
+// AddAttachments adds documents or photos to an existing proof of delivery
+func (r *proofOfDeliveryRepository) AddAttachments(attachments []models.ProofOfDeliveryAttachment) error {
+	if len(attachments) == 0 {
+		return nil
+	}
+	return r.db.Create(&attachments).Error
+}
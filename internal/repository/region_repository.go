@@ -0,0 +1,304 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// regionAppointmentSorts are the columns region appointment lists can be sorted by
+var regionAppointmentSorts = map[string]bool{
+	"scheduled_start": true,
+	"scheduled_end":   true,
+	"created_at":      true,
+	"status":          true,
+	"operation_id":    true,
+}
+
+// AppointmentCounts counts appointments by status, with the quantities they schedule and received
+type AppointmentCounts struct {
+	Appointments      int64 `json:"appointments"`
+	Pending           int64 `json:"pending"`
+	Confirmed         int64 `json:"confirmed"`
+	Completed         int64 `json:"completed"` // including partially completed
+	Cancelled         int64 `json:"cancelled"`
+	NoShows           int64 `json:"no_shows"`
+	QuantityScheduled int64 `json:"quantity_scheduled"`
+	QuantityReceived  int64 `json:"quantity_received"`
+}
+
+// Add adds other counts to the counts
+func (c *AppointmentCounts) Add(other AppointmentCounts) {
+	c.Appointments += other.Appointments
+	c.Pending += other.Pending
+	c.Confirmed += other.Confirmed
+	c.Completed += other.Completed
+	c.Cancelled += other.Cancelled
+	c.NoShows += other.NoShows
+	c.QuantityScheduled += other.QuantityScheduled
+	c.QuantityReceived += other.QuantityReceived
+}
+
+// RegionOperationStatistics counts the appointments of one of a region's operations
+type RegionOperationStatistics struct {
+	OperationID   uint   `json:"operation_id"`
+	OperationCode string `json:"operation_code"`
+	OperationName string `json:"operation_name"`
+	AppointmentCounts
+}
+
+// RegionRepository interface defines methods for regions, the operations they group and the
+// users managing them
+type RegionRepository interface {
+	Create(region *models.Region) error
+	Update(region *models.Region) error
+	Delete(id uint) error
+	FindByID(id uint) (*models.Region, error)
+	FindAll() ([]models.Region, error)
+	FindByManager(userID uint) ([]models.Region, error)
+	IsManager(regionID, userID uint) (bool, error)
+	FindAppointments(operationIDs []uint, filters AppointmentFilters) ([]models.Appointment, int64, error)
+	Statistics(operationIDs []uint, from, to time.Time) ([]RegionOperationStatistics, error)
+}
+
+// regionRepository implements RegionRepository interface
+type regionRepository struct {
+	db *gorm.DB
+}
+
+// ErrRegionNotFound is returned when no region has the ID looked up
+var ErrRegionNotFound = errors.New("region not found")
+
+// NewRegionRepository creates a new region repository
+func NewRegionRepository(db *gorm.DB) RegionRepository {
+	return &regionRepository{db: db}
+}
+
+// Create creates a region with its operations and managers
+func (r *regionRepository) Create(region *models.Region) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(region).Error; err != nil {
+			return err
+		}
+		return saveRegionLinks(tx, region)
+	})
+}
+
+// Update updates a region, replacing its operations and managers
+func (r *regionRepository) Update(region *models.Region) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(region).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("region_id = ?", region.ID).Delete(&models.RegionOperation{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("region_id = ?", region.ID).Delete(&models.RegionManager{}).Error; err != nil {
+			return err
+		}
+		return saveRegionLinks(tx, region)
+	})
+}
+
+// Delete deletes a region. Its operations stay as they are; its managers lose access to them.
+func (r *regionRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&models.Region{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrRegionNotFound
+		}
+		if err := tx.Where("region_id = ?", id).Delete(&models.RegionOperation{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("region_id = ?", id).Delete(&models.RegionManager{}).Error
+	})
+}
+
+// FindByID finds a region by ID with its operations and managers
+func (r *regionRepository) FindByID(id uint) (*models.Region, error) {
+	var region models.Region
+	if err := r.db.First(&region, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRegionNotFound
+		}
+		return nil, err
+	}
+	regions := []models.Region{region}
+	if err := r.loadLinks(regions); err != nil {
+		return nil, err
+	}
+	return &regions[0], nil
+}
+
+// FindAll returns every region by code
+func (r *regionRepository) FindAll() ([]models.Region, error) {
+	var regions []models.Region
+	if err := r.db.Order("code ASC").Find(&regions).Error; err != nil {
+		return nil, err
+	}
+	return regions, r.loadLinks(regions)
+}
+
+// FindByManager returns the regions a user manages, by code
+func (r *regionRepository) FindByManager(userID uint) ([]models.Region, error) {
+	var regions []models.Region
+	err := r.db.Joins("JOIN region_managers ON region_managers.region_id = regions.id").
+		Where("region_managers.user_id = ?", userID).
+		Order("regions.code ASC").
+		Find(&regions).Error
+	if err != nil {
+		return nil, err
+	}
+	return regions, r.loadLinks(regions)
+}
+
+// IsManager reports whether a user manages a region
+func (r *regionRepository) IsManager(regionID, userID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.RegionManager{}).
+		Where("region_id = ? AND user_id = ?", regionID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FindAppointments returns a page of the appointments of several operations
+func (r *regionRepository) FindAppointments(operationIDs []uint, filters AppointmentFilters) ([]models.Appointment, int64, error) {
+	var appointments []models.Appointment
+	var total int64
+	if len(operationIDs) == 0 {
+		return appointments, 0, nil
+	}
+
+	query := r.db.Model(&models.Appointment{}).Where("operation_id IN ?", operationIDs)
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+	if filters.Type != nil {
+		query = query.Where("type = ?", *filters.Type)
+	}
+	if filters.StartDate != nil {
+		query = query.Where("scheduled_start >= ?", *filters.StartDate)
+	}
+	if filters.EndDate != nil {
+		query = query.Where("scheduled_end <= ?", *filters.EndDate)
+	}
+	if filters.BookingCode != "" {
+		query = query.Where("booking_code ILIKE ?", "%"+filters.BookingCode+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if filters.Page > 0 && filters.Limit > 0 {
+		query = query.Offset((filters.Page - 1) * filters.Limit).Limit(filters.Limit)
+	}
+	sortBy := "scheduled_start"
+	if regionAppointmentSorts[filters.SortBy] {
+		sortBy = filters.SortBy
+	}
+	direction := "ASC"
+	if filters.SortOrder == "desc" {
+		direction = "DESC"
+	}
+
+	err := query.Order(sortBy + " " + direction + ", id ASC").
+		Preload("Supplier").Preload("Supplier.User").
+		Preload("Employee").Preload("Employee.User").
+		Preload("Operation").
+		Preload("Product").
+		Find(&appointments).Error
+	return appointments, total, err
+}
+
+// Statistics counts the appointments of several operations scheduled to start in [from, to) by
+// operation and status. Operations without appointments in the period are left out.
+func (r *regionRepository) Statistics(operationIDs []uint, from, to time.Time) ([]RegionOperationStatistics, error) {
+	var rows []RegionOperationStatistics
+	if len(operationIDs) == 0 {
+		return rows, nil
+	}
+
+	err := r.db.Model(&models.Appointment{}).
+		Select(`appointments.operation_id, operations.code AS operation_code, operations.name AS operation_name,
+			COUNT(*) AS appointments,
+			COUNT(*) FILTER (WHERE appointments.status = ?) AS pending,
+			COUNT(*) FILTER (WHERE appointments.status = ?) AS confirmed,
+			COUNT(*) FILTER (WHERE appointments.status IN ?) AS completed,
+			COUNT(*) FILTER (WHERE appointments.status = ?) AS cancelled,
+			COUNT(*) FILTER (WHERE appointments.status = ?) AS no_shows,
+			COALESCE(SUM(appointments.quantity_to_deliver), 0) AS quantity_scheduled,
+			COALESCE(SUM(appointments.received_quantity), 0) AS quantity_received`,
+			models.StatusPending,
+			models.StatusConfirmed,
+			[]models.AppointmentStatus{models.StatusCompleted, models.StatusPartiallyCompleted},
+			models.StatusCancelled,
+			models.StatusNoShow,
+		).
+		Joins("JOIN operations ON operations.id = appointments.operation_id").
+		Where("appointments.operation_id IN ? AND appointments.scheduled_start >= ? AND appointments.scheduled_start < ?", operationIDs, from, to).
+		Group("appointments.operation_id, operations.code, operations.name").
+		Order("operations.code ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// loadLinks fills in the operations and managers of regions
+func (r *regionRepository) loadLinks(regions []models.Region) error {
+	if len(regions) == 0 {
+		return nil
+	}
+	index := make(map[uint]*models.Region, len(regions))
+	ids := make([]uint, 0, len(regions))
+	for i := range regions {
+		regions[i].OperationIDs = []uint{}
+		regions[i].ManagerIDs = []uint{}
+		index[regions[i].ID] = &regions[i]
+		ids = append(ids, regions[i].ID)
+	}
+
+	var operations []models.RegionOperation
+	if err := r.db.Where("region_id IN ?", ids).Order("operation_id ASC").Find(&operations).Error; err != nil {
+		return err
+	}
+	for _, link := range operations {
+		index[link.RegionID].OperationIDs = append(index[link.RegionID].OperationIDs, link.OperationID)
+	}
+
+	var managers []models.RegionManager
+	if err := r.db.Where("region_id IN ?", ids).Order("user_id ASC").Find(&managers).Error; err != nil {
+		return err
+	}
+	for _, link := range managers {
+		index[link.RegionID].ManagerIDs = append(index[link.RegionID].ManagerIDs, link.UserID)
+	}
+	return nil
+}
+
+// saveRegionLinks creates the operation and manager links of a region
+func saveRegionLinks(tx *gorm.DB, region *models.Region) error {
+	if len(region.OperationIDs) > 0 {
+		links := make([]models.RegionOperation, 0, len(region.OperationIDs))
+		for _, operationID := range region.OperationIDs {
+			links = append(links, models.RegionOperation{RegionID: region.ID, OperationID: operationID})
+		}
+		if err := tx.Create(&links).Error; err != nil {
+			return err
+		}
+	}
+	if len(region.ManagerIDs) > 0 {
+		links := make([]models.RegionManager, 0, len(region.ManagerIDs))
+		for _, userID := range region.ManagerIDs {
+			links = append(links, models.RegionManager{RegionID: region.ID, UserID: userID})
+		}
+		if err := tx.Create(&links).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
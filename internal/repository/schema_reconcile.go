@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// reconcileLegacySchema brings tables created from the earlier definitions of Operation,
+// AvailabilitySlot, Product and Notification in line with the current models before AutoMigrate
+// runs.
+// Every step first checks the old column is still there, so up-to-date databases are left
+// untouched.
+func reconcileLegacySchema(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := reconcileOperations(tx); err != nil {
+			return fmt.Errorf("operations: %w", err)
+		}
+		if err := reconcileAvailabilitySlots(tx); err != nil {
+			return fmt.Errorf("availability slots: %w", err)
+		}
+		// Soft-deleted products become inactive, as products are never deleted otherwise
+		if err := dropSoftDelete(tx, "products", "UPDATE products SET active = false WHERE deleted_at IS NOT NULL"); err != nil {
+			return fmt.Errorf("products: %w", err)
+		}
+		if err := reconcileNotifications(tx); err != nil {
+			return fmt.Errorf("notifications: %w", err)
+		}
+		return nil
+	})
+}
+
+// reconcileOperations renames contact_phone to phone, turns the opening and closing hours from
+// timestamps into "HH:MM" times, fills the code and manager the current model requires and
+// replaces soft deletes with deactivation
+func reconcileOperations(tx *gorm.DB) error {
+	migrator := tx.Migrator()
+	if !migrator.HasTable("operations") {
+		return nil
+	}
+
+	if migrator.HasColumn("operations", "contact_phone") && !migrator.HasColumn("operations", "phone") {
+		if err := migrator.RenameColumn("operations", "contact_phone", "phone"); err != nil {
+			return err
+		}
+	}
+	if err := convertHourColumn(tx, "opening_hour", "opening_time", "08:00"); err != nil {
+		return err
+	}
+	if err := convertHourColumn(tx, "closing_hour", "closing_time", "18:00"); err != nil {
+		return err
+	}
+
+	if !migrator.HasColumn("operations", "code") {
+		steps := []string{
+			"ALTER TABLE operations ADD COLUMN code text",
+			"UPDATE operations SET code = 'OP' || id",
+		}
+		if err := execAll(tx, steps); err != nil {
+			return err
+		}
+	}
+
+	// The managers come from the employees the operation was linked to
+	if !migrator.HasColumn("operations", "manager_id") {
+		if err := tx.Exec("ALTER TABLE operations ADD COLUMN manager_id bigint").Error; err != nil {
+			return err
+		}
+		if migrator.HasTable("operation_employees") {
+			err := tx.Exec(
+				"UPDATE operations SET manager_id = (" +
+					"SELECT MIN(employee_id) FROM operation_employees WHERE operation_employees.operation_id = operations.id)",
+			).Error
+			if err != nil {
+				return err
+			}
+		}
+	}
+	var unmanaged []uint
+	if err := tx.Table("operations").Where("manager_id IS NULL").Pluck("id", &unmanaged).Error; err != nil {
+		return err
+	}
+	if len(unmanaged) > 0 {
+		return fmt.Errorf("operations %v have no manager; set operations.manager_id before migrating", unmanaged)
+	}
+
+	return dropSoftDelete(tx, "operations", "UPDATE operations SET active = false WHERE deleted_at IS NOT NULL")
+}
+
+// reconcileAvailabilitySlots turns slot times from timestamps into "HH:MM" times and removes
+// the slots that were soft deleted
+func reconcileAvailabilitySlots(tx *gorm.DB) error {
+	if !tx.Migrator().HasTable("availability_slots") {
+		return nil
+	}
+
+	columnTypes, err := tx.Migrator().ColumnTypes("availability_slots")
+	if err != nil {
+		return err
+	}
+	for _, column := range columnTypes {
+		name := column.Name()
+		if name != "start_time" && name != "end_time" {
+			continue
+		}
+		if !strings.Contains(strings.ToLower(column.DatabaseTypeName()), "timestamp") {
+			continue
+		}
+		err := tx.Exec(fmt.Sprintf("ALTER TABLE availability_slots ALTER COLUMN %s TYPE text USING to_char(%s, 'HH24:MI')", name, name)).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return dropSoftDelete(tx, "availability_slots", "DELETE FROM availability_slots WHERE deleted_at IS NOT NULL")
+}
+
+// reconcileNotifications turns template_id from text into the numeric ID of the template.
+// AutoMigrate can't change the type itself: Postgres needs a USING clause to cast text. IDs that
+// aren't numbers didn't name a template and are cleared.
+func reconcileNotifications(tx *gorm.DB) error {
+	if !tx.Migrator().HasTable("notifications") {
+		return nil
+	}
+
+	columnTypes, err := tx.Migrator().ColumnTypes("notifications")
+	if err != nil {
+		return err
+	}
+	for _, column := range columnTypes {
+		if column.Name() != "template_id" {
+			continue
+		}
+		columnType := strings.ToLower(column.DatabaseTypeName())
+		if !strings.Contains(columnType, "char") && !strings.Contains(columnType, "text") {
+			return nil
+		}
+		return execAll(tx, []string{
+			"UPDATE notifications SET template_id = NULL WHERE template_id !~ '^[0-9]+$'",
+			"ALTER TABLE notifications ALTER COLUMN template_id TYPE bigint USING NULLIF(template_id, '')::bigint",
+		})
+	}
+	return nil
+}
+
+// convertHourColumn replaces a timestamp column of operations with an "HH:MM" text column
+func convertHourColumn(tx *gorm.DB, from, to, fallback string) error {
+	if !tx.Migrator().HasColumn("operations", from) {
+		return nil
+	}
+
+	steps := []string{
+		fmt.Sprintf("ALTER TABLE operations ADD COLUMN IF NOT EXISTS %s text NOT NULL DEFAULT '%s'", to, fallback),
+		fmt.Sprintf("UPDATE operations SET %s = to_char(%s, 'HH24:MI') WHERE %s IS NOT NULL", to, from, from),
+	}
+	if err := execAll(tx, steps); err != nil {
+		return err
+	}
+	return tx.Migrator().DropColumn("operations", from)
+}
+
+// dropSoftDelete settles the rows of a table that were soft deleted with settle, then drops
+// its deleted_at column
+func dropSoftDelete(tx *gorm.DB, table, settle string) error {
+	if !tx.Migrator().HasTable(table) || !tx.Migrator().HasColumn(table, "deleted_at") {
+		return nil
+	}
+	if err := tx.Exec(settle).Error; err != nil {
+		return err
+	}
+	return tx.Migrator().DropColumn(table, "deleted_at")
+}
+
+// execAll runs statements in order, stopping at the first failure
+func execAll(tx *gorm.DB, statements []string) error {
+	for _, statement := range statements {
+		if err := tx.Exec(statement).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
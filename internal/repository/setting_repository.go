@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SettingRepository interface defines methods for runtime setting repository
+type SettingRepository interface {
+	List() ([]models.Setting, error)
+	Save(setting *models.Setting) error
+	Delete(key string) error
+}
+
+// settingRepository implements SettingRepository interface
+type settingRepository struct {
+	db *gorm.DB
+}
+
+// NewSettingRepository creates a new runtime setting repository
+func NewSettingRepository(db *gorm.DB) SettingRepository {
+	return &settingRepository{db: db}
+}
+
+// List returns every saved setting override
+func (r *settingRepository) List() ([]models.Setting, error) {
+	var settings []models.Setting
+	err := r.db.Order("key ASC").Find(&settings).Error
+	return settings, err
+}
+
+// Save creates or replaces the override of a setting
+func (r *settingRepository) Save(setting *models.Setting) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "updated_by_id", "updated_at"}),
+	}).Create(setting).Error
+}
+
+// Delete removes the override of a setting, bringing back its configured value
+func (r *settingRepository) Delete(key string) error {
+	return r.db.Where("key = ?", key).Delete(&models.Setting{}).Error
+}
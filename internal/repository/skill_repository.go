@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// SkillRepository interface defines methods for employee skills and the skills appointments
+// require
+type SkillRepository interface {
+	FindByEmployee(employeeID uint) ([]models.EmployeeSkill, error)
+	FindByEmployees(employeeIDs []uint) ([]models.EmployeeSkill, error)
+	ReplaceEmployeeSkills(employeeID uint, skills []string) error
+	FindRequirementsByScope(operationID *uint) ([]models.SkillRequirement, error)
+	FindApplicableRequirements(operationID uint) ([]models.SkillRequirement, error)
+	ReplaceRequirements(operationID *uint, requirements []models.SkillRequirement) error
+}
+
+// skillRepository implements SkillRepository interface
+type skillRepository struct {
+	db *gorm.DB
+}
+
+// NewSkillRepository creates a new skill repository
+func NewSkillRepository(db *gorm.DB) SkillRepository {
+	return &skillRepository{db: db}
+}
+
+// FindByEmployee returns the skills of an employee in alphabetical order
+func (r *skillRepository) FindByEmployee(employeeID uint) ([]models.EmployeeSkill, error) {
+	var skills []models.EmployeeSkill
+	err := r.db.Where("employee_id = ?", employeeID).Order("skill ASC").Find(&skills).Error
+	return skills, err
+}
+
+// FindByEmployees returns the skills of several employees
+func (r *skillRepository) FindByEmployees(employeeIDs []uint) ([]models.EmployeeSkill, error) {
+	var skills []models.EmployeeSkill
+	if len(employeeIDs) == 0 {
+		return skills, nil
+	}
+	err := r.db.Where("employee_id IN ?", employeeIDs).Order("employee_id ASC, skill ASC").Find(&skills).Error
+	return skills, err
+}
+
+// ReplaceEmployeeSkills replaces the skills of an employee in a single transaction
+func (r *skillRepository) ReplaceEmployeeSkills(employeeID uint, skills []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("employee_id = ?", employeeID).Delete(&models.EmployeeSkill{}).Error; err != nil {
+			return err
+		}
+		for _, skill := range skills {
+			if err := tx.Create(&models.EmployeeSkill{EmployeeID: employeeID, Skill: skill}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindRequirementsByScope returns the requirements of an operation, or the ones applying at
+// every operation when operationID is nil
+func (r *skillRepository) FindRequirementsByScope(operationID *uint) ([]models.SkillRequirement, error) {
+	var requirements []models.SkillRequirement
+	err := skillScope(r.db, operationID).Order("product_category ASC, skill ASC").Find(&requirements).Error
+	return requirements, err
+}
+
+// FindApplicableRequirements returns the requirements applying at every operation and those of
+// an operation
+func (r *skillRepository) FindApplicableRequirements(operationID uint) ([]models.SkillRequirement, error) {
+	var requirements []models.SkillRequirement
+	err := r.db.Where("operation_id IS NULL OR operation_id = ?", operationID).
+		Order("skill ASC").
+		Find(&requirements).Error
+	return requirements, err
+}
+
+// ReplaceRequirements replaces the requirements of an operation, or the ones applying at every
+// operation, in a single transaction
+func (r *skillRepository) ReplaceRequirements(operationID *uint, requirements []models.SkillRequirement) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := skillScope(tx.Unscoped(), operationID).Delete(&models.SkillRequirement{}).Error; err != nil {
+			return err
+		}
+		for i := range requirements {
+			requirements[i].ID = 0
+			requirements[i].OperationID = operationID
+			if err := tx.Create(&requirements[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// skillScope restricts a query to the requirements of an operation, or to the ones applying at
+// every operation
+func skillScope(db *gorm.DB, operationID *uint) *gorm.DB {
+	if operationID == nil {
+		return db.Where("operation_id IS NULL")
+	}
+	return db.Where("operation_id = ?", *operationID)
+}
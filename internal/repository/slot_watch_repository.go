@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// SlotWatchRepository interface defines methods for slot watches and the openings broadcast to
+// them
+type SlotWatchRepository interface {
+	Create(watch *models.SlotWatch) error
+	FindByID(id uint) (*models.SlotWatch, error)
+	FindBySupplier(supplierID uint) ([]models.SlotWatch, error)
+	FindSame(watch *models.SlotWatch) (*models.SlotWatch, error)
+	Delete(id uint) error
+	Watchers(opening *models.SlotOpening, limit int) ([]models.SlotWatch, error)
+	Notified(opening *models.SlotOpening, supplierID uint) (bool, error)
+	MarkNotified(ids []uint, at time.Time) error
+	CreateOpening(opening *models.SlotOpening) error
+	FindOpening(id uint) (*models.SlotOpening, error)
+	RecordWave(opening *models.SlotOpening) error
+	DueOpenings(now time.Time, limit int) ([]models.SlotOpening, error)
+	ExpireOpenings(now time.Time) (int64, error)
+	ClaimOpening(id uint, now time.Time) (bool, error)
+	ReleaseOpening(id uint) error
+}
+
+// slotWatchRepository implements SlotWatchRepository interface
+type slotWatchRepository struct {
+	db *gorm.DB
+}
+
+// NewSlotWatchRepository creates a new slot watch repository
+func NewSlotWatchRepository(db *gorm.DB) SlotWatchRepository {
+	return &slotWatchRepository{db: db}
+}
+
+// Create adds a slot watch
+func (r *slotWatchRepository) Create(watch *models.SlotWatch) error {
+	return r.db.Create(watch).Error
+}
+
+// FindByID finds a slot watch by ID
+func (r *slotWatchRepository) FindByID(id uint) (*models.SlotWatch, error) {
+	var watch models.SlotWatch
+	if err := r.db.First(&watch, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("slot watch not found")
+		}
+		return nil, err
+	}
+	return &watch, nil
+}
+
+// FindBySupplier returns a supplier's slot watches, those on every date first and then the
+// nearest date
+func (r *slotWatchRepository) FindBySupplier(supplierID uint) ([]models.SlotWatch, error) {
+	var watches []models.SlotWatch
+	err := r.db.Where("supplier_id = ?", supplierID).
+		Order("date ASC NULLS FIRST, created_at ASC").
+		Find(&watches).Error
+	return watches, err
+}
+
+// FindSame returns the supplier's watch of the same operation, date and type, or nil if there is
+// none
+func (r *slotWatchRepository) FindSame(watch *models.SlotWatch) (*models.SlotWatch, error) {
+	query := r.db.Where("supplier_id = ? AND operation_id = ? AND type = ?", watch.SupplierID, watch.OperationID, watch.Type)
+	if watch.Date == nil {
+		query = query.Where("date IS NULL")
+	} else {
+		query = query.Where("date = ?", watch.DateKey())
+	}
+
+	var existing models.SlotWatch
+	if err := query.First(&existing).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &existing, nil
+}
+
+// Delete removes a slot watch
+func (r *slotWatchRepository) Delete(id uint) error {
+	return r.db.Delete(&models.SlotWatch{}, id).Error
+}
+
+// Watchers returns up to limit watches of an opening the broadcast hasn't reached yet, oldest
+// first. The supplier whose cancellation opened the slot and the waitlisted supplier offered it
+// are left out.
+func (r *slotWatchRepository) Watchers(opening *models.SlotOpening, limit int) ([]models.SlotWatch, error) {
+	var watches []models.SlotWatch
+	err := r.watching(opening).
+		Where("id > ?", opening.NotifiedThroughID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&watches).Error
+	return watches, err
+}
+
+// Notified reports whether a wave of an opening's broadcast reached one of a supplier's watches
+func (r *slotWatchRepository) Notified(opening *models.SlotOpening, supplierID uint) (bool, error) {
+	var count int64
+	err := r.watching(opening).
+		Where("supplier_id = ? AND id <= ?", supplierID, opening.NotifiedThroughID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// MarkNotified records when watches were last notified of an opening
+func (r *slotWatchRepository) MarkNotified(ids []uint, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Model(&models.SlotWatch{}).Where("id IN ?", ids).
+		Update("last_notified_at", at).Error
+}
+
+// CreateOpening adds a slot opening
+func (r *slotWatchRepository) CreateOpening(opening *models.SlotOpening) error {
+	return r.db.Create(opening).Error
+}
+
+// FindOpening finds a slot opening by ID
+func (r *slotWatchRepository) FindOpening(id uint) (*models.SlotOpening, error) {
+	var opening models.SlotOpening
+	if err := r.db.First(&opening, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("slot opening not found")
+		}
+		return nil, err
+	}
+	return &opening, nil
+}
+
+// RecordWave saves the progress of an opening's broadcast. The booked places are left alone, as
+// watchers may be claiming them meanwhile.
+func (r *slotWatchRepository) RecordWave(opening *models.SlotOpening) error {
+	return r.db.Model(opening).
+		Select("status", "waves", "notified", "notified_through_id", "next_wave_at").
+		Updates(opening).Error
+}
+
+// DueOpenings returns up to limit openings whose next wave is due and that still have places,
+// the longest due first
+func (r *slotWatchRepository) DueOpenings(now time.Time, limit int) ([]models.SlotOpening, error) {
+	var openings []models.SlotOpening
+	err := r.db.Where("status = ? AND next_wave_at <= ? AND window_end > ?", models.SlotOpeningBroadcasting, now, now).
+		Where("(places = 0 OR booked < places)").
+		Order("next_wave_at ASC").
+		Limit(limit).
+		Find(&openings).Error
+	return openings, err
+}
+
+// ExpireOpenings closes the openings whose time passed
+func (r *slotWatchRepository) ExpireOpenings(now time.Time) (int64, error) {
+	result := r.db.Model(&models.SlotOpening{}).
+		Where("status <> ? AND window_end <= ?", models.SlotOpeningExpired, now).
+		Updates(map[string]interface{}{"status": models.SlotOpeningExpired, "next_wave_at": nil})
+	return result.RowsAffected, result.Error
+}
+
+// ClaimOpening takes one of an opening's places, reporting false when they were all taken or
+// the opening expired. Concurrent claims can't take more places than there are.
+func (r *slotWatchRepository) ClaimOpening(id uint, now time.Time) (bool, error) {
+	result := r.db.Model(&models.SlotOpening{}).
+		Where("id = ? AND status <> ? AND window_end > ?", id, models.SlotOpeningExpired, now).
+		Where("(places = 0 OR booked < places)").
+		Update("booked", gorm.Expr("booked + 1"))
+	return result.RowsAffected > 0, result.Error
+}
+
+// ReleaseOpening gives back a place claimed by a booking that failed
+func (r *slotWatchRepository) ReleaseOpening(id uint) error {
+	return r.db.Model(&models.SlotOpening{}).
+		Where("id = ? AND booked > 0", id).
+		Update("booked", gorm.Expr("booked - 1")).Error
+}
+
+// watching selects the watches an opening is broadcast to: those of its operation on its date or
+// on every date, for its type or every type
+func (r *slotWatchRepository) watching(opening *models.SlotOpening) *gorm.DB {
+	excluded := []uint{opening.ExcludedSupplierID}
+	if opening.OfferedSupplierID != nil {
+		excluded = append(excluded, *opening.OfferedSupplierID)
+	}
+	return r.db.Model(&models.SlotWatch{}).
+		Where("operation_id = ? AND (date IS NULL OR date = ?)", opening.OperationID, opening.DateKey()).
+		Where("(? = '' OR type = '' OR type = ?)", opening.Type, opening.Type).
+		Where("supplier_id NOT IN ?", excluded)
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// StatusTransitionRow counts the transitions between two statuses
+type StatusTransitionRow struct {
+	FromStatus   models.AppointmentStatus `json:"from_status"`
+	ToStatus     models.AppointmentStatus `json:"to_status"`
+	Transitions  int64                    `json:"transitions"`
+	Appointments int64                    `json:"appointments"` // distinct appointments that made the transition
+}
+
+// StatusEventRepository interface defines methods for appointment status event repository
+type StatusEventRepository interface {
+	Create(event *models.AppointmentStatusEvent) error
+	FindByAppointment(appointmentID uint) ([]models.AppointmentStatusEvent, error)
+	TransitionCounts(filters DeliveryReportFilters) ([]StatusTransitionRow, error)
+	EachInPeriod(since, until time.Time, batchSize int, fn func(events []models.AppointmentStatusEvent) error) error
+}
+
+// statusEventRepository implements StatusEventRepository interface
+type statusEventRepository struct {
+	db *gorm.DB
+}
+
+// NewStatusEventRepository creates a new status event repository
+func NewStatusEventRepository(db *gorm.DB) StatusEventRepository {
+	return &statusEventRepository{db: db}
+}
+
+// Create records a status transition
+func (r *statusEventRepository) Create(event *models.AppointmentStatusEvent) error {
+	return r.db.Create(event).Error
+}
+
+// FindByAppointment returns the status history of an appointment, oldest first
+func (r *statusEventRepository) FindByAppointment(appointmentID uint) ([]models.AppointmentStatusEvent, error) {
+	var events []models.AppointmentStatusEvent
+	err := r.db.Where("appointment_id = ?", appointmentID).Order("occurred_at ASC, id ASC").Find(&events).Error
+	return events, err
+}
+
+// TransitionCounts counts the status transitions that happened in the period, per pair of
+// statuses. Operation and supplier filters apply to the appointments; the dates to when the
+// transitions happened.
+func (r *statusEventRepository) TransitionCounts(filters DeliveryReportFilters) ([]StatusTransitionRow, error) {
+	var rows []StatusTransitionRow
+
+	query := r.db.Model(&models.AppointmentStatusEvent{}).
+		Select(
+			"appointment_status_events.from_status, appointment_status_events.to_status, " +
+				"COUNT(*) AS transitions, " +
+				"COUNT(DISTINCT appointment_status_events.appointment_id) AS appointments",
+		).
+		Joins("JOIN appointments ON appointments.id = appointment_status_events.appointment_id")
+
+	if filters.OperationID != nil {
+		query = query.Where("appointments.operation_id = ?", *filters.OperationID)
+	}
+	if filters.SupplierID != nil {
+		query = query.Where("appointments.supplier_id = ?", *filters.SupplierID)
+	}
+	if filters.StartDate != nil {
+		query = query.Where("appointment_status_events.occurred_at >= ?", *filters.StartDate)
+	}
+	if filters.EndDate != nil {
+		query = query.Where("appointment_status_events.occurred_at <= ?", *filters.EndDate)
+	}
+
+	err := query.
+		Group("appointment_status_events.from_status, appointment_status_events.to_status").
+		Order("transitions DESC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// EachInPeriod calls fn with the status events that happened from since until until, batchSize
+// at a time in the order they were recorded. The next batch is only read once fn returns, and an
+// error from fn stops it. The slice is reused between batches and must not be kept.
+func (r *statusEventRepository) EachInPeriod(since, until time.Time, batchSize int, fn func(events []models.AppointmentStatusEvent) error) error {
+	var events []models.AppointmentStatusEvent
+	return r.db.Where("occurred_at >= ? AND occurred_at < ?", since, until).
+		FindInBatches(&events, batchSize, func(tx *gorm.DB, batch int) error {
+			return fn(events)
+		}).Error
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// SupplierDocumentRepository interface defines methods for supplier document repository
+type SupplierDocumentRepository interface {
+	Create(document *models.SupplierDocument) error
+	FindByID(id uint) (*models.SupplierDocument, error)
+	FindBySupplier(supplierID uint) ([]models.SupplierDocument, error)
+	FindLatestByType(supplierID uint, documentType models.SupplierDocumentType) (*models.SupplierDocument, error)
+	FindExpiringBefore(before time.Time, notifiedBefore time.Time) ([]models.SupplierDocument, error)
+	Update(document *models.SupplierDocument) error
+	Delete(id uint) error
+}
+
+// supplierDocumentRepository implements SupplierDocumentRepository interface
+type supplierDocumentRepository struct {
+	db *gorm.DB
+}
+
+// NewSupplierDocumentRepository creates a new supplier document repository
+func NewSupplierDocumentRepository(db *gorm.DB) SupplierDocumentRepository {
+	return &supplierDocumentRepository{db: db}
+}
+
+// Create creates a new supplier document
+func (r *supplierDocumentRepository) Create(document *models.SupplierDocument) error {
+	return r.db.Create(document).Error
+}
+
+// FindByID finds a supplier document by ID
+func (r *supplierDocumentRepository) FindByID(id uint) (*models.SupplierDocument, error) {
+	var document models.SupplierDocument
+	err := r.db.First(&document, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("supplier document not found")
+		}
+		return nil, err
+	}
+	return &document, nil
+}
+
+// FindBySupplier returns the documents of a supplier, soonest expiry first
+func (r *supplierDocumentRepository) FindBySupplier(supplierID uint) ([]models.SupplierDocument, error) {
+	var documents []models.SupplierDocument
+	err := r.db.Where("supplier_id = ?", supplierID).Order("expires_at ASC").Find(&documents).Error
+	return documents, err
+}
+
+// FindLatestByType returns the document of a type that expires last, or nil if the supplier has none
+func (r *supplierDocumentRepository) FindLatestByType(supplierID uint, documentType models.SupplierDocumentType) (*models.SupplierDocument, error) {
+	var document models.SupplierDocument
+	err := r.db.Where("supplier_id = ? AND type = ?", supplierID, documentType).
+		Order("expires_at DESC").
+		First(&document).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &document, nil
+}
+
+// FindExpiringBefore returns documents expiring before the given time whose supplier has not
+// been warned since notifiedBefore. Documents superseded by one of the same type expiring later,
+// such as a renewal the supplier already uploaded, aren't returned.
+func (r *supplierDocumentRepository) FindExpiringBefore(before time.Time, notifiedBefore time.Time) ([]models.SupplierDocument, error) {
+	var documents []models.SupplierDocument
+	err := r.db.Where("expires_at < ?", before).
+		Where("expiry_notified_at IS NULL OR expiry_notified_at < ?", notifiedBefore).
+		Where(`NOT EXISTS (SELECT 1 FROM supplier_documents newer
+			WHERE newer.supplier_id = supplier_documents.supplier_id AND newer.type = supplier_documents.type
+			AND newer.expires_at > supplier_documents.expires_at AND newer.deleted_at IS NULL)`).
+		Order("expires_at ASC").
+		Find(&documents).Error
+	return documents, err
+}
+
+// Update updates a supplier document
+func (r *supplierDocumentRepository) Update(document *models.SupplierDocument) error {
+	return r.db.Save(document).Error
+}
+
+// Delete deletes a supplier document
+func (r *supplierDocumentRepository) Delete(id uint) error {
+	return r.db.Delete(&models.SupplierDocument{}, id).Error
+}
+
+// DocumentRequirementRepository interface defines methods for operation document requirement repository
+type DocumentRequirementRepository interface {
+	FindByOperation(operationID uint) ([]models.SupplierDocumentRequirement, error)
+	ReplaceForOperation(operationID uint, requirements []models.SupplierDocumentRequirement) error
+}
+
+// documentRequirementRepository implements DocumentRequirementRepository interface
+type documentRequirementRepository struct {
+	db *gorm.DB
+}
+
+// NewDocumentRequirementRepository creates a new document requirement repository
+func NewDocumentRequirementRepository(db *gorm.DB) DocumentRequirementRepository {
+	return &documentRequirementRepository{db: db}
+}
+
+// FindByOperation returns the document requirements of an operation
+func (r *documentRequirementRepository) FindByOperation(operationID uint) ([]models.SupplierDocumentRequirement, error) {
+	var requirements []models.SupplierDocumentRequirement
+	err := r.db.Where("operation_id = ?", operationID).Order("document_type ASC").Find(&requirements).Error
+	return requirements, err
+}
+
+// ReplaceForOperation replaces all document requirements of an operation in a single transaction
+func (r *documentRequirementRepository) ReplaceForOperation(operationID uint, requirements []models.SupplierDocumentRequirement) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("operation_id = ?", operationID).Delete(&models.SupplierDocumentRequirement{}).Error; err != nil {
+			return err
+		}
+		for i := range requirements {
+			requirements[i].ID = 0
+			requirements[i].OperationID = operationID
+			if err := tx.Create(&requirements[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// SupplierRepository interface defines methods for supplier repository
+type SupplierRepository interface {
+	Create(supplier *models.Supplier) error
+	FindByID(id uint) (*models.Supplier, error)
+	GetByID(id uint) (*models.Supplier, error)
+	FindByUserID(userID uint) (*models.Supplier, error)
+	Update(supplier *models.Supplier) error
+	FindMissingCoordinates(limit int) ([]models.Supplier, error)
+	SearchByName(name string, limit int) ([]models.Supplier, error)
+}
+
+// supplierRepository implements SupplierRepository interface
+type supplierRepository struct {
+	db *gorm.DB
+}
+
+// NewSupplierRepository creates a new supplier repository
+func NewSupplierRepository(db *gorm.DB) SupplierRepository {
+	return &supplierRepository{db: db}
+}
+
+// Create creates a new supplier
+func (r *supplierRepository) Create(supplier *models.Supplier) error {
+	return r.db.Create(supplier).Error
+}
+
+// FindByID finds a supplier by ID
+func (r *supplierRepository) FindByID(id uint) (*models.Supplier, error) {
+	var supplier models.Supplier
+	err := r.db.Preload("User").First(&supplier, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("supplier not found")
+		}
+		return nil, err
+	}
+	return &supplier, nil
+}
+
+// GetByID is an alias of FindByID used by the notification and calendar services
+func (r *supplierRepository) GetByID(id uint) (*models.Supplier, error) {
+	return r.FindByID(id)
+}
+
+// FindByUserID finds the supplier record linked to a user
+func (r *supplierRepository) FindByUserID(userID uint) (*models.Supplier, error) {
+	var supplier models.Supplier
+	err := r.db.Preload("User").Where("user_id = ?", userID).First(&supplier).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("supplier not found")
+		}
+		return nil, err
+	}
+	return &supplier, nil
+}
+
+// Update updates a supplier
+func (r *supplierRepository) Update(supplier *models.Supplier) error {
+	return r.db.Save(supplier).Error
+}
+
+// FindMissingCoordinates returns suppliers with an address that has not been geocoded yet
+func (r *supplierRepository) FindMissingCoordinates(limit int) ([]models.Supplier, error) {
+	var suppliers []models.Supplier
+	err := r.db.Where("address <> '' AND (latitude IS NULL OR longitude IS NULL)").
+		Order("id ASC").
+		Limit(limit).
+		Find(&suppliers).Error
+	return suppliers, err
+}
+
+// SearchByName returns up to limit suppliers whose company name contains name, ignoring case,
+// those starting with it first
+func (r *supplierRepository) SearchByName(name string, limit int) ([]models.Supplier, error) {
+	var suppliers []models.Supplier
+	err := r.db.Where("company_name ILIKE ?", "%"+name+"%").
+		Order(gorm.Expr("company_name ILIKE ? DESC, company_name ASC", name+"%")).
+		Limit(limit).
+		Find(&suppliers).Error
+	return suppliers, err
+}
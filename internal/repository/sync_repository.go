@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// syncChangedAt is when a record last changed, its deletion included
+const syncChangedAt = "GREATEST(updated_at, COALESCE(deleted_at, updated_at))"
+
+// SyncPosition is where a delta sync stopped in a stream of changed records: the change time and
+// ID of the last record sent
+type SyncPosition struct {
+	ChangedAt time.Time
+	ID        uint
+}
+
+// NotificationRecipientKey identifies a recipient notifications are addressed to
+type NotificationRecipientKey struct {
+	Type models.NotificationRecipientType
+	ID   uint
+}
+
+// SyncRepository interface defines methods for reading the records changed since a delta sync
+// position, deleted ones included
+type SyncRepository interface {
+	AppointmentChanges(supplierID, employeeID uint, after SyncPosition, includeDeleted bool, limit int) ([]models.Appointment, error)
+	NotificationChanges(recipients []NotificationRecipientKey, after SyncPosition, includeDeleted bool, limit int) ([]models.Notification, error)
+}
+
+// syncRepository implements SyncRepository interface
+type syncRepository struct {
+	db *gorm.DB
+}
+
+// NewSyncRepository creates a new sync repository
+func NewSyncRepository(db *gorm.DB) SyncRepository {
+	return &syncRepository{db: db}
+}
+
+// AppointmentChanges returns up to limit appointments changed after a position, in change order.
+// A non-zero supplierID or employeeID restricts them to that supplier's or employee's.
+func (r *syncRepository) AppointmentChanges(supplierID, employeeID uint, after SyncPosition, includeDeleted bool, limit int) ([]models.Appointment, error) {
+	query := syncQuery(r.db, after, includeDeleted, limit).
+		Preload("Supplier").Preload("Supplier.User").
+		Preload("Employee").Preload("Employee.User").
+		Preload("Operation").Preload("Product")
+	if supplierID != 0 {
+		query = query.Where("supplier_id = ?", supplierID)
+	}
+	if employeeID != 0 {
+		query = query.Where("employee_id = ?", employeeID)
+	}
+
+	var appointments []models.Appointment
+	err := query.Find(&appointments).Error
+	return appointments, err
+}
+
+// NotificationChanges returns up to limit notifications to any of the recipients changed after a
+// position, in change order
+func (r *syncRepository) NotificationChanges(recipients []NotificationRecipientKey, after SyncPosition, includeDeleted bool, limit int) ([]models.Notification, error) {
+	var notifications []models.Notification
+	if len(recipients) == 0 {
+		return notifications, nil
+	}
+
+	addressed := r.db.Where("recipient_type = ? AND recipient_id = ?", recipients[0].Type, recipients[0].ID)
+	for _, recipient := range recipients[1:] {
+		addressed = addressed.Or("recipient_type = ? AND recipient_id = ?", recipient.Type, recipient.ID)
+	}
+	err := syncQuery(r.db, after, includeDeleted, limit).Where(addressed).Find(&notifications).Error
+	return notifications, err
+}
+
+// syncQuery selects the records changed after a position, soft-deleted ones included when asked,
+// in change order
+func syncQuery(db *gorm.DB, after SyncPosition, includeDeleted bool, limit int) *gorm.DB {
+	query := db.Unscoped().
+		Where("("+syncChangedAt+", id) > (?, ?)", after.ChangedAt, after.ID).
+		Order(syncChangedAt + " ASC, id ASC").
+		Limit(limit)
+	if !includeDeleted {
+		query = query.Where("deleted_at IS NULL")
+	}
+	return query
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// TemplateVariantResults counts the notifications a template experiment sent with one variant and
+// what became of them and their appointments
+type TemplateVariantResults struct {
+	Variant    string `json:"variant"`
+	Sent       int64  `json:"sent"`
+	Opened     int64  `json:"opened"`    // emails whose tracking image was loaded
+	Confirmed  int64  `json:"confirmed"` // appointments confirmed after the notification was sent
+	Completed  int64  `json:"completed"` // including partially completed
+	NoShows    int64  `json:"no_shows"`
+	Cancelled  int64  `json:"cancelled"`
+	EmailsSent int64  `json:"emails_sent"` // the ones opens can be tracked for
+}
+
+// TemplateExperimentRepository interface defines methods for template experiments and the
+// notifications sent in them
+type TemplateExperimentRepository interface {
+	Create(experiment *models.TemplateExperiment) error
+	Update(experiment *models.TemplateExperiment) error
+	FindByID(id uint) (*models.TemplateExperiment, error)
+	FindAll() ([]models.TemplateExperiment, error)
+	FindRunning(event models.NotificationEvent, recipientType models.NotificationRecipientType, notificationType models.NotificationType) (*models.TemplateExperiment, error)
+	MarkOpened(notificationID uint, at time.Time) error
+	Results(experimentID uint) ([]TemplateVariantResults, error)
+}
+
+// templateExperimentRepository implements TemplateExperimentRepository interface
+type templateExperimentRepository struct {
+	db *gorm.DB
+}
+
+// ErrTemplateExperimentNotFound is returned when no template experiment has the ID looked up, or none is running
+var ErrTemplateExperimentNotFound = errors.New("template experiment not found")
+
+// NewTemplateExperimentRepository creates a new template experiment repository
+func NewTemplateExperimentRepository(db *gorm.DB) TemplateExperimentRepository {
+	return &templateExperimentRepository{db: db}
+}
+
+// Create creates a template experiment
+func (r *templateExperimentRepository) Create(experiment *models.TemplateExperiment) error {
+	return r.db.Create(experiment).Error
+}
+
+// Update saves a template experiment
+func (r *templateExperimentRepository) Update(experiment *models.TemplateExperiment) error {
+	return r.db.Save(experiment).Error
+}
+
+// FindByID finds a template experiment by ID
+func (r *templateExperimentRepository) FindByID(id uint) (*models.TemplateExperiment, error) {
+	var experiment models.TemplateExperiment
+	if err := r.db.First(&experiment, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTemplateExperimentNotFound
+		}
+		return nil, err
+	}
+	return &experiment, nil
+}
+
+// FindAll returns every template experiment, the most recent first
+func (r *templateExperimentRepository) FindAll() ([]models.TemplateExperiment, error) {
+	var experiments []models.TemplateExperiment
+	err := r.db.Order("started_at DESC, id DESC").Find(&experiments).Error
+	return experiments, err
+}
+
+// FindRunning finds the experiment running for an event, recipient type and notification type
+func (r *templateExperimentRepository) FindRunning(event models.NotificationEvent, recipientType models.NotificationRecipientType, notificationType models.NotificationType) (*models.TemplateExperiment, error) {
+	var experiment models.TemplateExperiment
+	err := r.db.
+		Where("event = ? AND recipient_type = ? AND type = ? AND status = ?", event, recipientType, notificationType, models.ExperimentRunning).
+		Order("started_at DESC").
+		First(&experiment).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTemplateExperimentNotFound
+		}
+		return nil, err
+	}
+	return &experiment, nil
+}
+
+// MarkOpened records when a notification was first opened; later opens are ignored
+func (r *templateExperimentRepository) MarkOpened(notificationID uint, at time.Time) error {
+	return r.db.Model(&models.Notification{}).
+		Where("id = ? AND opened_at IS NULL", notificationID).
+		UpdateColumn("opened_at", at).Error
+}
+
+// Results counts, per variant, the notifications an experiment sent, how many were opened and
+// how their appointments turned out
+func (r *templateExperimentRepository) Results(experimentID uint) ([]TemplateVariantResults, error) {
+	var rows []TemplateVariantResults
+	err := r.db.Model(&models.Notification{}).
+		Select(`notifications.variant,
+			COUNT(*) AS sent,
+			COUNT(*) FILTER (WHERE notifications.type = ?) AS emails_sent,
+			COUNT(*) FILTER (WHERE notifications.opened_at IS NOT NULL) AS opened,
+			COUNT(*) FILTER (WHERE appointments.confirmed_at >= notifications.sent_at) AS confirmed,
+			COUNT(*) FILTER (WHERE appointments.status IN ?) AS completed,
+			COUNT(*) FILTER (WHERE appointments.status = ?) AS no_shows,
+			COUNT(*) FILTER (WHERE appointments.status = ?) AS cancelled`,
+			models.NotificationTypeEmail,
+			[]models.AppointmentStatus{models.StatusCompleted, models.StatusPartiallyCompleted},
+			models.StatusNoShow,
+			models.StatusCancelled,
+		).
+		Joins("LEFT JOIN appointments ON appointments.id = notifications.appointment_id").
+		Where("notifications.experiment_id = ? AND notifications.status = ?", experimentID, models.NotificationStatusSent).
+		Group("notifications.variant").
+		Order("notifications.variant ASC").
+		Scan(&rows).Error
+	return rows, err
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// TokenRevocationRepository interface defines methods for the JWTs refused before they expire
+type TokenRevocationRepository interface {
+	Create(revocation *models.TokenRevocation) error
+	IsRevoked(userID uint, tokenID string, issuedAt, now time.Time) (bool, error)
+	PruneExpired(before time.Time) (int64, error)
+}
+
+// tokenRevocationRepository implements TokenRevocationRepository interface
+type tokenRevocationRepository struct {
+	db *gorm.DB
+}
+
+// NewTokenRevocationRepository creates a new token revocation repository
+func NewTokenRevocationRepository(db *gorm.DB) TokenRevocationRepository {
+	return &tokenRevocationRepository{db: db}
+}
+
+// Create records a revocation
+func (r *tokenRevocationRepository) Create(revocation *models.TokenRevocation) error {
+	return r.db.Create(revocation).Error
+}
+
+// IsRevoked reports whether a token is refused: revoked by its ID, or issued before a revocation
+// of every token of its user that hasn't expired yet
+func (r *tokenRevocationRepository) IsRevoked(userID uint, tokenID string, issuedAt, now time.Time) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.TokenRevocation{}).
+		Where("expires_at > ?", now).
+		Where(r.db.Where("token_id = ? AND token_id <> ''", tokenID).
+			Or("user_id = ? AND token_id = '' AND issued_before > ?", userID, issuedAt)).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// PruneExpired deletes the revocations whose tokens expired before a time and returns how many
+// were deleted
+func (r *tokenRevocationRepository) PruneExpired(before time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ?", before).Delete(&models.TokenRevocation{})
+	return result.RowsAffected, result.Error
+}
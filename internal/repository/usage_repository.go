@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Ways API usage can be grouped
+const (
+	UsageGroupConsumer      = "consumer"       // per consumer, across routes
+	UsageGroupRoute         = "route"          // per route, across consumers
+	UsageGroupConsumerRoute = "consumer_route" // per consumer and route
+)
+
+// UsageFilters defines filters for the API usage summary
+type UsageFilters struct {
+	StartDate    *time.Time
+	EndDate      *time.Time
+	ConsumerType string
+	ConsumerID   *uint
+	Route        string
+	GroupBy      string
+	Limit        int
+}
+
+// UsageRow sums the API requests of a consumer, a route or both over a period
+type UsageRow struct {
+	ConsumerType     string  `json:"consumer_type,omitempty"`
+	ConsumerID       uint    `json:"consumer_id,omitempty"`
+	ConsumerName     string  `json:"consumer_name,omitempty"` // user email or partner credential name
+	Method           string  `json:"method,omitempty"`
+	Route            string  `json:"route,omitempty"`
+	Requests         int64   `json:"requests"`
+	ClientErrors     int64   `json:"client_errors"`
+	ServerErrors     int64   `json:"server_errors"`
+	ErrorRate        float64 `json:"error_rate"` // share of requests answered with a 5xx
+	AvgLatencyMillis float64 `json:"avg_latency_ms"`
+	MaxLatencyMillis int64   `json:"max_latency_ms"`
+}
+
+// UsageRepository interface defines methods for API usage analytics
+type UsageRepository interface {
+	Add(usage []models.APIUsage) error
+	Summarize(filters UsageFilters) ([]UsageRow, error)
+	DeleteBefore(before time.Time) (int64, error)
+}
+
+// usageRepository implements UsageRepository interface
+type usageRepository struct {
+	db *gorm.DB
+}
+
+// NewUsageRepository creates a new API usage repository
+func NewUsageRepository(db *gorm.DB) UsageRepository {
+	return &usageRepository{db: db}
+}
+
+// Add adds counted requests to their hour's rows. Replicas flushing the same hour add up rather
+// than overwrite each other.
+func (r *usageRepository) Add(usage []models.APIUsage) error {
+	if len(usage) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "hour"}, {Name: "consumer_type"}, {Name: "consumer_id"}, {Name: "method"}, {Name: "route"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"requests":           gorm.Expr("api_usages.requests + EXCLUDED.requests"),
+			"client_errors":      gorm.Expr("api_usages.client_errors + EXCLUDED.client_errors"),
+			"server_errors":      gorm.Expr("api_usages.server_errors + EXCLUDED.server_errors"),
+			"latency_millis":     gorm.Expr("api_usages.latency_millis + EXCLUDED.latency_millis"),
+			"max_latency_millis": gorm.Expr("GREATEST(api_usages.max_latency_millis, EXCLUDED.max_latency_millis)"),
+		}),
+	}).CreateInBatches(usage, 500).Error
+}
+
+// Summarize sums the API usage of the hours in the period per consumer, route or both, the busiest
+// first. Consumers are named after the user's email or the partner credential.
+func (r *usageRepository) Summarize(filters UsageFilters) ([]UsageRow, error) {
+	var rows []UsageRow
+
+	var columns string
+	switch filters.GroupBy {
+	case UsageGroupConsumer:
+		columns = "api_usages.consumer_type, api_usages.consumer_id, consumer_name"
+	case UsageGroupRoute:
+		columns = "api_usages.method, api_usages.route"
+	default:
+		columns = "api_usages.consumer_type, api_usages.consumer_id, consumer_name, api_usages.method, api_usages.route"
+	}
+
+	query := r.db.Table("api_usages").
+		Select(columns+", "+
+			"SUM(api_usages.requests) AS requests, "+
+			"SUM(api_usages.client_errors) AS client_errors, "+
+			"SUM(api_usages.server_errors) AS server_errors, "+
+			"SUM(api_usages.server_errors)::float / NULLIF(SUM(api_usages.requests), 0) AS error_rate, "+
+			"SUM(api_usages.latency_millis)::float / NULLIF(SUM(api_usages.requests), 0) AS avg_latency_millis, "+
+			"MAX(api_usages.max_latency_millis) AS max_latency_millis").
+		Joins("LEFT JOIN (SELECT ?::text AS type, id, email AS consumer_name FROM users UNION ALL "+
+			"SELECT ?::text, id, name FROM partner_credentials) consumers "+
+			"ON consumers.type = api_usages.consumer_type AND consumers.id = api_usages.consumer_id",
+			models.UsageConsumerUser, models.UsageConsumerPartner)
+
+	if filters.StartDate != nil {
+		query = query.Where("api_usages.hour >= ?", *filters.StartDate)
+	}
+	if filters.EndDate != nil {
+		query = query.Where("api_usages.hour <= ?", *filters.EndDate)
+	}
+	if filters.ConsumerType != "" {
+		query = query.Where("api_usages.consumer_type = ?", filters.ConsumerType)
+	}
+	if filters.ConsumerID != nil {
+		query = query.Where("api_usages.consumer_id = ?", *filters.ConsumerID)
+	}
+	if filters.Route != "" {
+		query = query.Where("api_usages.route = ?", filters.Route)
+	}
+	if filters.Limit > 0 {
+		query = query.Limit(filters.Limit)
+	}
+
+	err := query.
+		Group(columns).
+		Order("requests DESC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// DeleteBefore deletes the usage of the hours before a time
+func (r *usageRepository) DeleteBefore(before time.Time) (int64, error) {
+	result := r.db.Where("hour < ?", before).Delete(&models.APIUsage{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// ensureUserEmailIndex makes email addresses unique regardless of case. Addresses stored before
+// emails were normalized may differ only in case or surrounding spaces; in each such group the
+// oldest account that isn't deleted keeps the address, and the others are deactivated and given
+// a "duplicate-<id>-" prefix so they can be merged or removed by hand. The remaining addresses are
+// then normalized and the unique index on lower(email) is created.
+func ensureUserEmailIndex(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		renamed := tx.Exec(`
+			WITH ranked AS (
+				SELECT id, ROW_NUMBER() OVER (
+					PARTITION BY lower(trim(email)) ORDER BY (deleted_at IS NOT NULL), id
+				) AS position
+				FROM users
+				WHERE email <> ''
+			)
+			UPDATE users
+			SET email = 'duplicate-' || users.id || '-' || lower(trim(users.email)), active = false
+			FROM ranked
+			WHERE ranked.id = users.id AND ranked.position > 1`)
+		if renamed.Error != nil {
+			return renamed.Error
+		}
+		if renamed.RowsAffected > 0 {
+			log.Printf("Deactivated %d users whose email duplicates another account's regardless of case", renamed.RowsAffected)
+		}
+
+		if err := tx.Exec("UPDATE users SET email = lower(trim(email)) WHERE email <> lower(trim(email))").Error; err != nil {
+			return err
+		}
+		return tx.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_lower ON users (lower(email))").Error
+	})
+}
@@ -1,5 +1,70 @@
 package repository
 
 import (
-	"github.com/bernardofernandezz/
+	"errors"
+	"strings"
 
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// UserRepository interface defines methods for user repository
+type UserRepository interface {
+	Create(user *models.User) error
+	GetByID(id uint) (*models.User, error)
+	GetByEmail(email string) (*models.User, error)
+	Update(user *models.User) error
+	Delete(id uint) error
+}
+
+// userRepository implements UserRepository interface
+type userRepository struct {
+	db *gorm.DB
+}
+
+// NewUserRepository creates a new user repository
+func NewUserRepository(db *gorm.DB) UserRepository {
+	return &userRepository{db: db}
+}
+
+// Create creates a new user
+func (r *userRepository) Create(user *models.User) error {
+	return r.db.Create(user).Error
+}
+
+// GetByID finds a user by ID
+func (r *userRepository) GetByID(id uint) (*models.User, error) {
+	var user models.User
+	err := r.db.First(&user, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetByEmail finds a user by email address regardless of case, matching the unique index on
+// lower(email)
+func (r *userRepository) GetByEmail(email string) (*models.User, error) {
+	var user models.User
+	err := r.db.Where("lower(email) = ?", strings.ToLower(strings.TrimSpace(email))).First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Update updates a user
+func (r *userRepository) Update(user *models.User) error {
+	return r.db.Save(user).Error
+}
+
+// Delete soft deletes a user
+func (r *userRepository) Delete(id uint) error {
+	return r.db.Delete(&models.User{}, id).Error
+}
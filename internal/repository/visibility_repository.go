@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// VisibilityRepository interface defines methods for field visibility rule repository
+type VisibilityRepository interface {
+	FindByScope(operationID *uint) ([]models.FieldVisibilityRule, error)
+	FindApplicable(operationID uint) ([]models.FieldVisibilityRule, error)
+	ReplaceForScope(operationID *uint, rules []models.FieldVisibilityRule) error
+}
+
+// visibilityRepository implements VisibilityRepository interface
+type visibilityRepository struct {
+	db *gorm.DB
+}
+
+// NewVisibilityRepository creates a new field visibility rule repository
+func NewVisibilityRepository(db *gorm.DB) VisibilityRepository {
+	return &visibilityRepository{db: db}
+}
+
+// FindByScope returns the rules of an operation, or the global rules when operationID is nil
+func (r *visibilityRepository) FindByScope(operationID *uint) ([]models.FieldVisibilityRule, error) {
+	var rules []models.FieldVisibilityRule
+	err := visibilityScope(r.db, operationID).Order("role ASC, field ASC").Find(&rules).Error
+	return rules, err
+}
+
+// FindApplicable returns the global rules followed by the rules of an operation, so applying
+// them in order lets the operation's rules win
+func (r *visibilityRepository) FindApplicable(operationID uint) ([]models.FieldVisibilityRule, error) {
+	var rules []models.FieldVisibilityRule
+	err := r.db.Where("operation_id IS NULL OR operation_id = ?", operationID).
+		Order("operation_id ASC NULLS FIRST, id ASC").
+		Find(&rules).Error
+	return rules, err
+}
+
+// ReplaceForScope replaces the rules of an operation, or the global rules, in a single transaction
+func (r *visibilityRepository) ReplaceForScope(operationID *uint, rules []models.FieldVisibilityRule) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := visibilityScope(tx.Unscoped(), operationID).Delete(&models.FieldVisibilityRule{}).Error; err != nil {
+			return err
+		}
+		for i := range rules {
+			rules[i].ID = 0
+			rules[i].OperationID = operationID
+			if err := tx.Create(&rules[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// visibilityScope restricts a query to the rules of an operation, or to the global rules
+func visibilityScope(db *gorm.DB, operationID *uint) *gorm.DB {
+	if operationID == nil {
+		return db.Where("operation_id IS NULL")
+	}
+	return db.Where("operation_id = ?", *operationID)
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// VisitorRepository interface defines methods for appointment visitor repository
+type VisitorRepository interface {
+	Create(visitor *models.AppointmentVisitor) error
+	FindByID(id uint) (*models.AppointmentVisitor, error)
+	FindByAppointment(appointmentID uint) ([]models.AppointmentVisitor, error)
+	FindByAppointments(appointmentIDs []uint) ([]models.AppointmentVisitor, error)
+	CountByAppointment(appointmentID uint) (int64, error)
+	MarkCheckedIn(appointmentID uint, at time.Time) error
+	Delete(id uint) error
+}
+
+// visitorRepository implements VisitorRepository interface
+type visitorRepository struct {
+	db *gorm.DB
+}
+
+// NewVisitorRepository creates a new visitor repository
+func NewVisitorRepository(db *gorm.DB) VisitorRepository {
+	return &visitorRepository{db: db}
+}
+
+// Create registers a visitor on an appointment
+func (r *visitorRepository) Create(visitor *models.AppointmentVisitor) error {
+	return r.db.Create(visitor).Error
+}
+
+// FindByID finds a visitor by ID
+func (r *visitorRepository) FindByID(id uint) (*models.AppointmentVisitor, error) {
+	var visitor models.AppointmentVisitor
+	err := r.db.First(&visitor, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("visitor not found")
+		}
+		return nil, err
+	}
+	return &visitor, nil
+}
+
+// FindByAppointment returns the visitors of an appointment
+func (r *visitorRepository) FindByAppointment(appointmentID uint) ([]models.AppointmentVisitor, error) {
+	var visitors []models.AppointmentVisitor
+	err := r.db.Where("appointment_id = ?", appointmentID).Order("id ASC").Find(&visitors).Error
+	return visitors, err
+}
+
+// FindByAppointments returns the visitors of several appointments
+func (r *visitorRepository) FindByAppointments(appointmentIDs []uint) ([]models.AppointmentVisitor, error) {
+	var visitors []models.AppointmentVisitor
+	if len(appointmentIDs) == 0 {
+		return visitors, nil
+	}
+	err := r.db.Where("appointment_id IN ?", appointmentIDs).Order("appointment_id ASC, id ASC").Find(&visitors).Error
+	return visitors, err
+}
+
+// CountByAppointment counts the visitors of an appointment
+func (r *visitorRepository) CountByAppointment(appointmentID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.AppointmentVisitor{}).Where("appointment_id = ?", appointmentID).Count(&count).Error
+	return count, err
+}
+
+// MarkCheckedIn records the gate check-in of all visitors of an appointment
+func (r *visitorRepository) MarkCheckedIn(appointmentID uint, at time.Time) error {
+	return r.db.Model(&models.AppointmentVisitor{}).
+		Where("appointment_id = ? AND checked_in_at IS NULL", appointmentID).
+		Update("checked_in_at", at).Error
+}
+
+// Delete removes a visitor
+func (r *visitorRepository) Delete(id uint) error {
+	return r.db.Delete(&models.AppointmentVisitor{}, id).Error
+}
+
+// LocationPingRepository interface defines methods for driver location ping repository
+type LocationPingRepository interface {
+	Create(ping *models.AppointmentLocationPing) error
+	FindLatestByAppointment(appointmentID uint) (*models.AppointmentLocationPing, error)
+}
+
+// locationPingRepository implements LocationPingRepository interface
+type locationPingRepository struct {
+	db *gorm.DB
+}
+
+// NewLocationPingRepository creates a new location ping repository
+func NewLocationPingRepository(db *gorm.DB) LocationPingRepository {
+	return &locationPingRepository{db: db}
+}
+
+// Create stores a location ping
+func (r *locationPingRepository) Create(ping *models.AppointmentLocationPing) error {
+	return r.db.Create(ping).Error
+}
+
+// FindLatestByAppointment returns the most recent ping of an appointment, or nil if there is none
+func (r *locationPingRepository) FindLatestByAppointment(appointmentID uint) (*models.AppointmentLocationPing, error) {
+	var ping models.AppointmentLocationPing
+	err := r.db.Where("appointment_id = ?", appointmentID).Order("recorded_at DESC").First(&ping).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ping, nil
+}
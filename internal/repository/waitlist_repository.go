@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// WaitlistRepository interface defines methods for operation waitlists
+type WaitlistRepository interface {
+	Create(entry *models.WaitlistEntry) error
+	FindByID(id uint) (*models.WaitlistEntry, error)
+	FindBySupplier(supplierID uint) ([]models.WaitlistEntry, error)
+	FindByOperation(operationID uint, from, to time.Time) ([]models.WaitlistEntry, error)
+	FindWaiting(supplierID, operationID uint, date time.Time, appointmentType models.AppointmentType) (*models.WaitlistEntry, error)
+	NextWaiting(operationID uint, date time.Time, appointmentType models.AppointmentType, excludeSupplierID uint) (*models.WaitlistEntry, error)
+	Update(entry *models.WaitlistEntry) error
+}
+
+// waitlistRepository implements WaitlistRepository interface
+type waitlistRepository struct {
+	db *gorm.DB
+}
+
+// NewWaitlistRepository creates a new waitlist repository
+func NewWaitlistRepository(db *gorm.DB) WaitlistRepository {
+	return &waitlistRepository{db: db}
+}
+
+// Create adds an entry to a waitlist
+func (r *waitlistRepository) Create(entry *models.WaitlistEntry) error {
+	return r.db.Create(entry).Error
+}
+
+// FindByID finds a waitlist entry by ID
+func (r *waitlistRepository) FindByID(id uint) (*models.WaitlistEntry, error) {
+	var entry models.WaitlistEntry
+	if err := r.db.First(&entry, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("waitlist entry not found")
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// FindBySupplier returns a supplier's waitlist entries, the nearest date first
+func (r *waitlistRepository) FindBySupplier(supplierID uint) ([]models.WaitlistEntry, error) {
+	var entries []models.WaitlistEntry
+	err := r.db.Where("supplier_id = ?", supplierID).
+		Order("date ASC, created_at ASC").
+		Find(&entries).Error
+	return entries, err
+}
+
+// FindByOperation returns an operation's waitlist entries between from and to, both included,
+// by date and in the order suppliers joined
+func (r *waitlistRepository) FindByOperation(operationID uint, from, to time.Time) ([]models.WaitlistEntry, error) {
+	var entries []models.WaitlistEntry
+	err := r.db.Where("operation_id = ? AND date BETWEEN ? AND ?",
+		operationID, from.Format(models.BlackoutDateLayout), to.Format(models.BlackoutDateLayout)).
+		Order("date ASC, created_at ASC").
+		Find(&entries).Error
+	return entries, err
+}
+
+// FindWaiting returns a supplier's waiting entry for an operation, date and appointment type, or
+// nil if there is none
+func (r *waitlistRepository) FindWaiting(supplierID, operationID uint, date time.Time, appointmentType models.AppointmentType) (*models.WaitlistEntry, error) {
+	var entry models.WaitlistEntry
+	err := r.db.Where("supplier_id = ? AND operation_id = ? AND date = ? AND type = ? AND status = ?",
+		supplierID, operationID, date.Format(models.BlackoutDateLayout), appointmentType, models.WaitlistWaiting).
+		First(&entry).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// NextWaiting returns the oldest waiting entry for an operation, date and appointment type that
+// isn't the given supplier's, or nil if there is none
+func (r *waitlistRepository) NextWaiting(operationID uint, date time.Time, appointmentType models.AppointmentType, excludeSupplierID uint) (*models.WaitlistEntry, error) {
+	var entry models.WaitlistEntry
+	err := r.db.Where("operation_id = ? AND date = ? AND type = ? AND status = ? AND supplier_id <> ?",
+		operationID, date.Format(models.BlackoutDateLayout), appointmentType, models.WaitlistWaiting, excludeSupplierID).
+		Order("created_at ASC").
+		First(&entry).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// Update updates a waitlist entry
+func (r *waitlistRepository) Update(entry *models.WaitlistEntry) error {
+	return r.db.Save(entry).Error
+}
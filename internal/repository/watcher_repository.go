@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"gorm.io/gorm"
+)
+
+// WatcherRepository interface defines methods for appointment watcher repository
+type WatcherRepository interface {
+	Create(watcher *models.AppointmentWatcher) error
+	FindByID(id uint) (*models.AppointmentWatcher, error)
+	FindByAppointment(appointmentID uint) ([]models.AppointmentWatcher, error)
+	FindByAppointmentAndUser(appointmentID, userID uint) (*models.AppointmentWatcher, error)
+	Update(watcher *models.AppointmentWatcher) error
+	Delete(id uint) error
+}
+
+// watcherRepository implements WatcherRepository interface
+type watcherRepository struct {
+	db *gorm.DB
+}
+
+// NewWatcherRepository creates a new appointment watcher repository
+func NewWatcherRepository(db *gorm.DB) WatcherRepository {
+	return &watcherRepository{db: db}
+}
+
+// Create subscribes a watcher to an appointment
+func (r *watcherRepository) Create(watcher *models.AppointmentWatcher) error {
+	return r.db.Create(watcher).Error
+}
+
+// FindByID finds a watcher by ID
+func (r *watcherRepository) FindByID(id uint) (*models.AppointmentWatcher, error) {
+	var watcher models.AppointmentWatcher
+	if err := r.db.Preload("User").First(&watcher, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("watcher not found")
+		}
+		return nil, err
+	}
+	return &watcher, nil
+}
+
+// FindByAppointment returns the watchers of an appointment
+func (r *watcherRepository) FindByAppointment(appointmentID uint) ([]models.AppointmentWatcher, error) {
+	var watchers []models.AppointmentWatcher
+	err := r.db.Preload("User").Where("appointment_id = ?", appointmentID).Order("id ASC").Find(&watchers).Error
+	return watchers, err
+}
+
+// FindByAppointmentAndUser finds a user's subscription to an appointment, or nil if there is none
+func (r *watcherRepository) FindByAppointmentAndUser(appointmentID, userID uint) (*models.AppointmentWatcher, error) {
+	var watcher models.AppointmentWatcher
+	err := r.db.Where("appointment_id = ? AND user_id = ?", appointmentID, userID).First(&watcher).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &watcher, nil
+}
+
+// Update updates a watcher's channels
+func (r *watcherRepository) Update(watcher *models.AppointmentWatcher) error {
+	return r.db.Model(watcher).Select("EmailEnabled", "SMSEnabled", "PushEnabled").Updates(watcher).Error
+}
+
+// Delete removes a watcher. The row is removed for good so the user can be subscribed again.
+func (r *watcherRepository) Delete(id uint) error {
+	return r.db.Unscoped().Delete(&models.AppointmentWatcher{}, id).Error
+}
+
+// MuteRepository interface defines methods for appointment mute repository
+type MuteRepository interface {
+	Mute(appointmentID, userID uint) (*models.AppointmentMute, error)
+	Unmute(appointmentID, userID uint) error
+	IsMuted(appointmentID, userID uint) (bool, error)
+}
+
+// muteRepository implements MuteRepository interface
+type muteRepository struct {
+	db *gorm.DB
+}
+
+// NewMuteRepository creates a new appointment mute repository
+func NewMuteRepository(db *gorm.DB) MuteRepository {
+	return &muteRepository{db: db}
+}
+
+// Mute silences an appointment for a user. Muting an appointment twice keeps the first mute.
+func (r *muteRepository) Mute(appointmentID, userID uint) (*models.AppointmentMute, error) {
+	mute := models.AppointmentMute{AppointmentID: appointmentID, UserID: userID}
+	err := r.db.Where("appointment_id = ? AND user_id = ?", appointmentID, userID).FirstOrCreate(&mute).Error
+	return &mute, err
+}
+
+// Unmute lets an appointment's notifications reach a user again
+func (r *muteRepository) Unmute(appointmentID, userID uint) error {
+	return r.db.Unscoped().Where("appointment_id = ? AND user_id = ?", appointmentID, userID).
+		Delete(&models.AppointmentMute{}).Error
+}
+
+// IsMuted reports whether a user muted an appointment
+func (r *muteRepository) IsMuted(appointmentID, userID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.AppointmentMute{}).
+		Where("appointment_id = ? AND user_id = ?", appointmentID, userID).Count(&count).Error
+	return count > 0, err
+}
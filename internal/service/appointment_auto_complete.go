@@ -0,0 +1,164 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+)
+
+// Auto-completion errors
+var (
+	ErrNotAutoCompleted  = errors.New("appointment was not completed automatically")
+	ErrUndoWindowExpired = errors.New("the automatic completion can no longer be undone")
+)
+
+// defaultAutoCompleteBatchSize is how many overdue appointments a run handles when not configured
+const defaultAutoCompleteBatchSize = 200
+
+// AutoCompleteResult counts what a run of the overdue appointment job did
+type AutoCompleteResult struct {
+	Completed int `json:"completed"`
+	Flagged   int `json:"flagged"`
+}
+
+// AutoCompleteOverdue handles confirmed appointments still open after their operation's
+// auto-complete delay: depending on the operation they are completed with the scheduled quantity
+// or flagged for review. Either way the employee is notified; automatic completions can be undone
+// within the configured window.
+func (s *appointmentService) AutoCompleteOverdue() (*AutoCompleteResult, error) {
+	now := s.clock.Now()
+	appointments, err := s.overdueRepo.FindOverdue(now, s.autoCompleteBatchSize())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AutoCompleteResult{}
+	modes := make(map[uint]models.AutoCompleteMode)
+	for i := range appointments {
+		appointment := &appointments[i]
+
+		mode, ok := modes[appointment.OperationID]
+		if !ok {
+			operation, err := s.operationRepo.FindByID(appointment.OperationID)
+			if err != nil {
+				log.Printf("Failed to load operation %d of overdue appointment %d: %v", appointment.OperationID, appointment.ID, err)
+				continue
+			}
+			mode = operation.AutoCompleteMode
+			modes[appointment.OperationID] = mode
+		}
+
+		oldStatus := appointment.Status
+		appointment.OverdueFlaggedAt = &now
+		if mode == models.AutoCompleteComplete {
+			received := appointment.QuantityToDeliver
+			appointment.ReceivedQuantity = &received
+			appointment.CompletedAt = &now
+			appointment.AutoCompletedAt = &now
+			appointment.Status = models.StatusCompleted
+		}
+		if err := s.appointmentRepo.Update(appointment); err != nil {
+			log.Printf("Failed to close overdue appointment %d: %v", appointment.ID, err)
+			continue
+		}
+
+		s.RecordStatusChange(appointment, oldStatus, StatusChange{
+			Source: models.StatusSourceAutoComplete,
+			Reason: "Still open after its scheduled end",
+		})
+
+		if appointment.AutoCompletedAt != nil {
+			result.Completed++
+		} else {
+			result.Flagged++
+		}
+		s.notifyOverdue(appointment)
+	}
+
+	if result.Completed > 0 || result.Flagged > 0 {
+		log.Printf("Overdue appointments: %d completed automatically, %d flagged for review", result.Completed, result.Flagged)
+	}
+	return result, nil
+}
+
+// UndoAutoComplete reopens an automatically completed appointment within the undo window. It goes
+// back to confirmed and stays flagged, so the job leaves it for staff to close.
+func (s *appointmentService) UndoAutoComplete(id uint, actorID uint) (*models.Appointment, error) {
+	appointment, err := s.appointmentRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if appointment.AutoCompletedAt == nil || appointment.Status != models.StatusCompleted {
+		return nil, ErrNotAutoCompleted
+	}
+	if s.clock.Now().After(appointment.AutoCompletedAt.Add(s.autoCompleteUndoWindow())) {
+		return nil, ErrUndoWindowExpired
+	}
+
+	appointment.Status = models.StatusConfirmed
+	appointment.ReceivedQuantity = nil
+	appointment.CompletedAt = nil
+	appointment.AutoCompletedAt = nil
+	if err := s.appointmentRepo.Update(appointment); err != nil {
+		return nil, err
+	}
+	s.RecordStatusChange(appointment, models.StatusCompleted, StatusChange{
+		ActorID: &actorID,
+		Source:  models.StatusSourceUndo,
+		Reason:  "Automatic completion undone",
+	})
+	return appointment, nil
+}
+
+// notifyOverdue tells the employee an appointment was left open and what was done about it
+func (s *appointmentService) notifyOverdue(appointment *models.Appointment) {
+	if s.notificationService == nil {
+		return
+	}
+
+	var subject, body string
+	if appointment.AutoCompletedAt != nil {
+		subject = fmt.Sprintf("%s %s was completed automatically", appointment.Type.Label(), appointment.Reference())
+		body = fmt.Sprintf("%s %s, scheduled to end at %s, was still confirmed and has been marked completed with the scheduled quantity. "+
+			"If it didn't take place or the quantity differs, undo the completion before %s.",
+			appointment.Type.Label(), appointment.Reference(), appointment.ScheduledEnd.Format("2006-01-02 15:04"),
+			appointment.AutoCompletedAt.Add(s.autoCompleteUndoWindow()).Format("2006-01-02 15:04"))
+	} else {
+		subject = fmt.Sprintf("%s %s needs to be closed", appointment.Type.Label(), appointment.Reference())
+		body = fmt.Sprintf("%s %s, scheduled to end at %s, is still confirmed. Complete or cancel it.",
+			appointment.Type.Label(), appointment.Reference(), appointment.ScheduledEnd.Format("2006-01-02 15:04"))
+	}
+
+	notification := &models.Notification{
+		Type:          models.NotificationTypeEmail,
+		Status:        models.NotificationStatusPending,
+		Event:         models.EventAppointmentOverdue,
+		RecipientType: models.RecipientEmployee,
+		RecipientID:   appointment.EmployeeID,
+		Subject:       subject,
+		Body:          body,
+		AppointmentID: &appointment.ID,
+	}
+	if err := s.notificationService.EnqueueNotification(notification, QueueAppointmentNotifications, 2); err != nil {
+		log.Printf("Failed to enqueue overdue notification for appointment %d: %v", appointment.ID, err)
+	}
+}
+
+// autoCompleteBatchSize returns how many overdue appointments a run handles
+func (s *appointmentService) autoCompleteBatchSize() int {
+	if s.config == nil || s.config.AutoComplete.BatchSize <= 0 {
+		return defaultAutoCompleteBatchSize
+	}
+	return s.config.AutoComplete.BatchSize
+}
+
+// autoCompleteUndoWindow returns how long an automatic completion can be undone
+func (s *appointmentService) autoCompleteUndoWindow() time.Duration {
+	if s.settingsService == nil {
+		return 0
+	}
+	return s.settingsService.Duration(SettingAutoCompleteUndoWindow)
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+)
+
+// SetBilling charges an appointment to a cost center and billing code. Unlike Update it works at
+// any status, so finance can correct completed appointments before the month is exported.
+func (s *appointmentService) SetBilling(id uint, costCenter, billingCode string) (*models.Appointment, error) {
+	appointment, err := s.appointmentRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := *appointment
+	appointment.CostCenter = strings.TrimSpace(costCenter)
+	appointment.BillingCode = strings.TrimSpace(billingCode)
+	if err := checkBillingCodes(s.billingRepo, appointment, &previous); err != nil {
+		return nil, err
+	}
+
+	if err := s.appointmentRepo.Update(appointment); err != nil {
+		return nil, err
+	}
+	return appointment, nil
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+)
+
+// checkBlackout refuses appointments on a day the operation is closed, such as an imported
+// public holiday. Days are counted in the operation's timezone.
+func (s *appointmentService) checkBlackout(appointment *models.Appointment, operation *models.Operation) error {
+	if s.blackoutRepo == nil {
+		return nil
+	}
+	location := operation.Location()
+
+	// An appointment running past midnight must not touch a closed day either
+	closed, err := closedDates(s.blackoutRepo, operation.ID, appointment.ScheduledStart, appointment.ScheduledEnd, location)
+	if err != nil {
+		return fmt.Errorf("failed to check the operation's blackout dates: %w", err)
+	}
+	for day := civilDate(appointment.ScheduledStart, location); !day.After(civilDate(appointment.ScheduledEnd, location)); day = day.AddDate(0, 0, 1) {
+		if blackout, ok := closed[day.Format(models.BlackoutDateLayout)]; ok {
+			return fmt.Errorf("%w: %s (%s)", ErrOperationClosed, blackout.DateKey(), blackout.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+)
+
+// assignBookingCode gives a new appointment the next booking code of its operation. Numbers
+// restart every year, counted in the operation's timezone.
+func (s *appointmentService) assignBookingCode(appointment *models.Appointment, operation *models.Operation) error {
+	now := s.clock.Now()
+	year := now.In(operation.Location()).Year()
+
+	number, err := s.bookingCodeRepo.NextNumber(operation.ID, year, now)
+	if err != nil {
+		return fmt.Errorf("failed to number appointment: %w", err)
+	}
+	appointment.BookingCode = models.FormatBookingCode(operation.Code, year, number)
+	return nil
+}
+
+// GetByBookingCode gets an appointment by its booking code
+func (s *appointmentService) GetByBookingCode(code string) (*models.Appointment, error) {
+	return s.bookingCodeRepo.FindAppointment(code)
+}
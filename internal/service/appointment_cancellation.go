@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+)
+
+// cascadeCancellation clears up what a cancelled appointment leaves behind, such as queued
+// reminders and its waitlisted slot. Failures are logged and don't undo the cancellation.
+func (s *appointmentService) cascadeCancellation(appointment *models.Appointment) {
+	if s.cancellationService == nil {
+		return
+	}
+	s.cancellationService.Cascade(context.Background(), appointment)
+}
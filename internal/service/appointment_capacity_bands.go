@@ -0,0 +1,62 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+)
+
+// checkCapacityBands enforces the capacity bands set on the appointment's date at the operation.
+// It reports whether a band for the appointment's own type covers the whole appointment, in which
+// case the band's limit replaces the type's concurrent limit.
+func (s *appointmentService) checkCapacityBands(appointment *models.Appointment) (bool, error) {
+	operation, err := s.operationRepo.FindByID(appointment.OperationID)
+	if err != nil {
+		return false, errors.New("invalid operation: " + err.Error())
+	}
+	day := operationDayAt(operation, appointment.ScheduledStart)
+	date, _ := ParseBlackoutDate(day.Date)
+	overrides, err := s.capacityRepo.FindOverrides(operation.ID, date, date)
+	if err != nil {
+		return false, err
+	}
+
+	covered := false
+	for i := range overrides {
+		override := &overrides[i]
+		if !override.AppliesToType(appointment.Type) {
+			continue
+		}
+		start, end := bandBounds(operation, date, override)
+		if !appointment.ScheduledStart.Before(end) || !appointment.ScheduledEnd.After(start) {
+			continue
+		}
+		if override.Type != "" && !appointment.ScheduledStart.Before(start) && !appointment.ScheduledEnd.After(end) {
+			covered = true
+		}
+
+		// Only the part of the appointment inside the band counts against it
+		from, to := start, end
+		if appointment.ScheduledStart.After(from) {
+			from = appointment.ScheduledStart
+		}
+		if appointment.ScheduledEnd.Before(to) {
+			to = appointment.ScheduledEnd
+		}
+		var count int64
+		if override.Type == "" {
+			count, err = s.capacityRepo.CountAllOverlapping(operation.ID, from, to, appointment.ID)
+		} else {
+			count, err = s.capacityRepo.CountOverlapping(operation.ID, override.Type, from, to, appointment.ID)
+		}
+		if err != nil {
+			return false, err
+		}
+		if int(count) >= override.MaxConcurrent {
+			return false, fmt.Errorf("%w: at most %d from %s to %s on %s", ErrBandCapacityReached,
+				override.MaxConcurrent, override.StartTime, override.EndTime, day.Date)
+		}
+	}
+	return covered, nil
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+)
+
+// Capacity limits a warning can be about
+const (
+	CapacityLimitBand       = "band"            // a capacity band set on the date
+	CapacityLimitConcurrent = "type_concurrent" // the type's limit of overlapping appointments
+	CapacityLimitDaily      = "type_daily"      // the type's limit of appointments per day
+)
+
+// CapacityWarning reports a capacity limit a booking leaves almost full. The booking is accepted;
+// the warning makes tight days visible before they turn into refusals.
+type CapacityWarning struct {
+	OperationID uint                   `json:"operation_id"`
+	Limit       string                 `json:"limit"`
+	Type        models.AppointmentType `json:"type,omitempty"` // type the limit applies to, empty for a band capping every type
+	Start       time.Time              `json:"start"`          // when the limit applies: the band, the appointment or the day
+	End         time.Time              `json:"end"`
+	Booked      int                    `json:"booked"` // appointments counted against the limit, this one included
+	Capacity    int                    `json:"capacity"`
+	Percent     int                    `json:"percent"`   // booked share of the capacity
+	Threshold   int                    `json:"threshold"` // percent above which bookings are flagged
+	Message     string                 `json:"message"`
+}
+
+// crossed reports whether the booking is the one that took the limit over the threshold
+func (w CapacityWarning) crossed() bool {
+	return !overThreshold(w.Booked-1, w.Capacity, w.Threshold)
+}
+
+// CapacityWarnings returns the capacity limits an appointment takes above the warning threshold,
+// whether it is booked already or only being considered. Limits the appointment would exceed
+// aren't reported: the booking is refused for those.
+func (s *appointmentService) CapacityWarnings(appointment *models.Appointment) ([]CapacityWarning, error) {
+	threshold := s.capacityWarningPercent()
+	if threshold <= 0 || s.capacityRepo == nil {
+		return nil, nil
+	}
+	operation, err := s.operationRepo.FindByID(appointment.OperationID)
+	if err != nil {
+		return nil, err
+	}
+	appointmentType := appointment.Type
+	if appointmentType == "" {
+		appointmentType = models.AppointmentTypeDelivery
+	}
+
+	var warnings []CapacityWarning
+	warn := func(limit string, limitType models.AppointmentType, start, end time.Time, booked, capacity int) {
+		if capacity <= 0 || booked > capacity || !overThreshold(booked, capacity, threshold) {
+			return
+		}
+		warnings = append(warnings, CapacityWarning{
+			OperationID: operation.ID,
+			Limit:       limit,
+			Type:        limitType,
+			Start:       start,
+			End:         end,
+			Booked:      booked,
+			Capacity:    capacity,
+			Percent:     booked * 100 / capacity,
+			Threshold:   threshold,
+			Message:     capacityWarningMessage(limit, limitType, start, end, booked, capacity, operation.Location()),
+		})
+	}
+
+	// Capacity bands on the appointment's date, counting only the part of the appointment inside
+	day := operationDayAt(operation, appointment.ScheduledStart)
+	date, _ := ParseBlackoutDate(day.Date)
+	overrides, err := s.capacityRepo.FindOverrides(operation.ID, date, date)
+	if err != nil {
+		return nil, err
+	}
+	covered := false
+	for i := range overrides {
+		override := &overrides[i]
+		if !override.AppliesToType(appointmentType) {
+			continue
+		}
+		start, end := bandBounds(operation, date, override)
+		if !appointment.ScheduledStart.Before(end) || !appointment.ScheduledEnd.After(start) {
+			continue
+		}
+		if override.Type != "" && !appointment.ScheduledStart.Before(start) && !appointment.ScheduledEnd.After(end) {
+			covered = true
+		}
+
+		from, to := start, end
+		if appointment.ScheduledStart.After(from) {
+			from = appointment.ScheduledStart
+		}
+		if appointment.ScheduledEnd.Before(to) {
+			to = appointment.ScheduledEnd
+		}
+		var count int64
+		if override.Type == "" {
+			count, err = s.capacityRepo.CountAllOverlapping(operation.ID, from, to, appointment.ID)
+		} else {
+			count, err = s.capacityRepo.CountOverlapping(operation.ID, override.Type, from, to, appointment.ID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		warn(CapacityLimitBand, override.Type, start, end, int(count)+1, override.MaxConcurrent)
+	}
+
+	// The type's own limits; a band for the type covering the appointment replaces the concurrent one
+	capacity, err := s.capacityRepo.FindByOperationAndType(operation.ID, appointmentType)
+	if err != nil || capacity == nil {
+		return warnings, err
+	}
+	if capacity.MaxConcurrent > 0 && !covered {
+		count, err := s.capacityRepo.CountOverlapping(operation.ID, appointmentType,
+			appointment.ScheduledStart, appointment.ScheduledEnd, appointment.ID)
+		if err != nil {
+			return nil, err
+		}
+		warn(CapacityLimitConcurrent, appointmentType, appointment.ScheduledStart, appointment.ScheduledEnd, int(count)+1, capacity.MaxConcurrent)
+	}
+	if capacity.MaxPerDay > 0 {
+		count, err := s.capacityRepo.CountOverlapping(operation.ID, appointmentType, day.Start, day.End, appointment.ID)
+		if err != nil {
+			return nil, err
+		}
+		warn(CapacityLimitDaily, appointmentType, day.Start, day.End, int(count)+1, capacity.MaxPerDay)
+	}
+	return warnings, nil
+}
+
+// WarnOperationManager subscribes the capacity warnings of new bookings: the operation's manager
+// is told about each limit the booking took over the warning threshold. Later bookings on the
+// same tight limit don't repeat the warning.
+func WarnOperationManager(appointmentService AppointmentService, operationRepo repository.OperationRepository, notificationService NotificationService) AppointmentEventHandler {
+	return func(event AppointmentEvent) error {
+		appointment := event.Appointment
+		if appointment.Status.IsFinal() {
+			return nil
+		}
+		warnings, err := appointmentService.CapacityWarnings(appointment)
+		if err != nil || len(warnings) == 0 {
+			return err
+		}
+		operation, err := operationRepo.FindByID(appointment.OperationID)
+		if err != nil {
+			return err
+		}
+
+		for _, warning := range warnings {
+			if !warning.crossed() {
+				continue
+			}
+			notification := &models.Notification{
+				Type:          models.NotificationTypeEmail,
+				Status:        models.NotificationStatusPending,
+				Event:         models.EventCapacityWarning,
+				RecipientType: models.RecipientEmployee,
+				RecipientID:   operation.ManagerID,
+				Subject:       fmt.Sprintf("%s is almost full on %s", operation.Name, warning.Start.In(operation.Location()).Format("2006-01-02")),
+				Body:          fmt.Sprintf("Appointment %s was booked at %s. %s.", appointment.Reference(), operation.Name, warning.Message),
+				AppointmentID: &appointment.ID,
+			}
+			if err := notificationService.EnqueueNotification(notification, QueueAppointmentNotifications, 2); err != nil {
+				log.Printf("Failed to enqueue capacity warning of appointment %d: %v", appointment.ID, err)
+			}
+		}
+		return nil
+	}
+}
+
+// capacityWarningPercent returns the percent of a limit above which bookings are flagged
+func (s *appointmentService) capacityWarningPercent() int {
+	if s.settingsService == nil {
+		if s.config == nil {
+			return 0
+		}
+		return s.config.CapacityWarnings.Percent
+	}
+	return s.settingsService.Int(SettingCapacityWarningPercent)
+}
+
+// overThreshold reports whether booked is more than threshold percent of capacity
+func overThreshold(booked, capacity, threshold int) bool {
+	return booked*100 > capacity*threshold
+}
+
+// capacityWarningMessage describes a tight limit in the operation's local time
+func capacityWarningMessage(limit string, limitType models.AppointmentType, start, end time.Time, booked, capacity int, location *time.Location) string {
+	start, end = start.In(location), end.In(location)
+	switch limit {
+	case CapacityLimitBand:
+		what := "appointments"
+		if limitType != "" {
+			what = strings.ToLower(limitType.Label()) + " appointments"
+		}
+		return fmt.Sprintf("The capacity band from %s to %s on %s holds %d of %d %s at the same time",
+			start.Format("15:04"), end.Format("15:04"), start.Format("2006-01-02"), booked, capacity, what)
+	case CapacityLimitConcurrent:
+		return fmt.Sprintf("%d of %d %s appointments at the same time are booked from %s to %s on %s",
+			booked, capacity, strings.ToLower(limitType.Label()), start.Format("15:04"), end.Format("15:04"), start.Format("2006-01-02"))
+	default:
+		return fmt.Sprintf("%d of %d %s appointments a day are booked on %s",
+			booked, capacity, strings.ToLower(limitType.Label()), start.Format("2006-01-02"))
+	}
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+)
+
+// followUpSearchDays is how many days ahead a free slot is searched for a follow-up delivery
+const followUpSearchDays = 14
+
+// Complete records the quantity received for an appointment. A delivery short of the scheduled
+// quantity is marked partially completed and a pending follow-up appointment is suggested for the
+// remainder, at the same time of day on the next day without conflicts. It returns the completed
+// appointment and the follow-up, if one was created. A proof of delivery captured at the dock may
+// be recorded with the completion; the supplier is sent its links.
+func (s *appointmentService) Complete(id uint, receivedQuantity int, completedByID uint, proof *models.ProofOfDelivery) (*models.Appointment, *models.Appointment, error) {
+	if receivedQuantity < 0 {
+		return nil, nil, errors.New("received quantity cannot be negative")
+	}
+	if proof != nil {
+		proof.AppointmentID = id
+		proof.CapturedByID = completedByID
+		if err := proof.Validate(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	appointment, err := s.appointmentRepo.FindByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if appointment.Status.IsFinal() {
+		return nil, nil, errors.New("appointment is already cancelled or completed")
+	}
+	if appointment.LinkedInboundID != nil {
+		inbound, err := s.appointmentRepo.FindByID(*appointment.LinkedInboundID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if inbound.Status != models.StatusCompleted && inbound.Status != models.StatusPartiallyCompleted {
+			return nil, nil, ErrInboundNotCompleted
+		}
+	}
+
+	oldStatus := appointment.Status
+	now := s.clock.Now()
+	appointment.ReceivedQuantity = &receivedQuantity
+	appointment.CompletedAt = &now
+	appointment.Status = models.StatusCompleted
+	if receivedQuantity < appointment.QuantityToDeliver {
+		appointment.Status = models.StatusPartiallyCompleted
+	}
+
+	if err := s.appointmentRepo.Update(appointment); err != nil {
+		return nil, nil, err
+	}
+	s.RecordStatusChange(appointment, oldStatus, StatusChange{
+		ActorID: &completedByID,
+		Source:  models.StatusSourceCompletion,
+		Reason:  fmt.Sprintf("Received %d of %d", receivedQuantity, appointment.QuantityToDeliver),
+	})
+	if proof != nil {
+		if err := s.proofRepo.Create(proof); err != nil {
+			// The completion itself is recorded; the proof can still be sent on its own
+			log.Printf("Failed to record proof of delivery of appointment %d: %v", appointment.ID, err)
+			proof = nil
+		}
+	}
+	s.notifyCompletion(appointment, proof)
+	s.requestFeedback(appointment)
+
+	if appointment.Status != models.StatusPartiallyCompleted {
+		return appointment, nil, nil
+	}
+
+	followUp, err := s.suggestFollowUp(appointment, appointment.QuantityToDeliver-receivedQuantity)
+	if err != nil {
+		// The completion itself is recorded; the remainder can still be booked by hand
+		log.Printf("Failed to create follow-up for partially completed appointment %d: %v", appointment.ID, err)
+		return appointment, nil, nil
+	}
+	return appointment, followUp, nil
+}
+
+// GetDeliveryReport compares delivered and scheduled quantities
+func (s *appointmentService) GetDeliveryReport(filters repository.DeliveryReportFilters) ([]repository.DeliveryReportRow, error) {
+	return s.reportRepo.DeliveredVsScheduled(filters)
+}
+
+// suggestFollowUp creates a pending appointment for the remaining quantity of a partial delivery
+func (s *appointmentService) suggestFollowUp(original *models.Appointment, remaining int) (*models.Appointment, error) {
+	originalID := original.ID
+	followUp := &models.Appointment{
+		SupplierID:        original.SupplierID,
+		EmployeeID:        original.EmployeeID,
+		OperationID:       original.OperationID,
+		Type:              original.Type,
+		ProductID:         original.ProductID,
+		Status:            models.StatusPending,
+		QuantityToDeliver: remaining,
+		FollowUpOfID:      &originalID,
+		Notes:             fmt.Sprintf("Follow-up for the remaining %d units of appointment %s", remaining, original.Reference()),
+	}
+
+	duration := original.ScheduledEnd.Sub(original.ScheduledStart)
+	for day := 1; day <= followUpSearchDays; day++ {
+		followUp.ScheduledStart = original.ScheduledStart.AddDate(0, 0, day)
+		followUp.ScheduledEnd = followUp.ScheduledStart.Add(duration)
+
+		conflict, err := s.appointmentRepo.HasConflict(followUp)
+		if err != nil {
+			return nil, err
+		}
+		if conflict {
+			continue
+		}
+
+		operation, err := s.operationRepo.FindByID(followUp.OperationID)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.assignBookingCode(followUp, operation); err != nil {
+			return nil, err
+		}
+		if err := s.appointmentRepo.Create(followUp); err != nil {
+			return nil, err
+		}
+		s.publishCreated(followUp, StatusChange{
+			Source: models.StatusSourceBooking,
+			Reason: fmt.Sprintf("Follow-up for the remainder of %s", original.Reference()),
+		})
+		return followUp, nil
+	}
+
+	return nil, fmt.Errorf("no free slot in the next %d days", followUpSearchDays)
+}
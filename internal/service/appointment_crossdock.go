@@ -0,0 +1,135 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+)
+
+// Cross-docking errors
+var (
+	ErrInvalidInbound      = errors.New("linked appointment must be an active inbound delivery at the same operation")
+	ErrPickupBeforeInbound = errors.New("pickup cannot start before the linked inbound delivery is completed")
+	ErrInboundNotCompleted = errors.New("linked inbound delivery has not been completed yet")
+)
+
+// LinkInbound links a pickup to the inbound delivery it depends on
+func (s *appointmentService) LinkInbound(pickupID, inboundID uint) (*models.Appointment, error) {
+	pickup, err := s.appointmentRepo.FindByID(pickupID)
+	if err != nil {
+		return nil, err
+	}
+	if pickup.Type != models.AppointmentTypePickup {
+		return nil, errors.New("only pickups can be linked to an inbound delivery")
+	}
+	if pickup.Status.IsFinal() {
+		return nil, errors.New("cannot link a cancelled or completed appointment")
+	}
+
+	pickup.LinkedInboundID = &inboundID
+	if err := s.checkInboundOrdering(pickup); err != nil {
+		return nil, err
+	}
+	if err := s.linkRepo.SetInbound(pickup.ID, &inboundID); err != nil {
+		return nil, err
+	}
+	return pickup, nil
+}
+
+// UnlinkInbound removes the inbound link of a pickup
+func (s *appointmentService) UnlinkInbound(pickupID uint) error {
+	if _, err := s.appointmentRepo.FindByID(pickupID); err != nil {
+		return err
+	}
+	return s.linkRepo.SetInbound(pickupID, nil)
+}
+
+// GetLinkedPickups lists the pickups that depend on an inbound delivery
+func (s *appointmentService) GetLinkedPickups(inboundID uint) ([]models.Appointment, error) {
+	return s.linkRepo.FindPickupsByInbound(inboundID)
+}
+
+// checkInboundOrdering checks that a pickup starts no earlier than its inbound delivery completes:
+// the completion time once it is completed, the scheduled end until then
+func (s *appointmentService) checkInboundOrdering(pickup *models.Appointment) error {
+	inbound, err := s.appointmentRepo.FindByID(*pickup.LinkedInboundID)
+	if err != nil {
+		return err
+	}
+	if inbound.ID == pickup.ID || inbound.Type != models.AppointmentTypeDelivery ||
+		inbound.Status == models.StatusCancelled || inbound.OperationID != pickup.OperationID {
+		return ErrInvalidInbound
+	}
+
+	readyAt := inbound.ScheduledEnd
+	if inbound.CompletedAt != nil {
+		readyAt = *inbound.CompletedAt
+	}
+	if pickup.ScheduledStart.Before(readyAt) {
+		return fmt.Errorf("%w (inbound ends at %s)", ErrPickupBeforeInbound, readyAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// PropagateToLinked applies a change of an inbound delivery to the pickups linked to it. A
+// cancelled or no-show inbound cancels its pickups; a rescheduled inbound moves them by the same
+// offset. Suppliers of the affected pickups are notified. Pickups that cannot be moved are left
+// in place and reported in the log.
+func (s *appointmentService) PropagateToLinked(previous, current *models.Appointment) error {
+	if current.Type != models.AppointmentTypeDelivery {
+		return nil
+	}
+
+	// Goods of an inbound marked no-show never arrived, so its pickups are cancelled too
+	cancelled := (current.Status == models.StatusCancelled || current.Status == models.StatusNoShow) && previous.Status != current.Status
+	shift := current.ScheduledEnd.Sub(previous.ScheduledEnd)
+	if !cancelled && shift == 0 {
+		return nil
+	}
+
+	pickups, err := s.linkRepo.FindPickupsByInbound(current.ID)
+	if err != nil {
+		return err
+	}
+
+	for i := range pickups {
+		pickup := &pickups[i]
+		if pickup.Status.IsFinal() {
+			continue
+		}
+
+		if cancelled {
+			oldStatus := pickup.Status
+			reason := fmt.Sprintf("Linked inbound appointment %s was cancelled", current.Reference())
+			if current.Status == models.StatusNoShow {
+				reason = fmt.Sprintf("Linked inbound appointment %s was a no-show", current.Reference())
+			}
+			if err := s.appointmentRepo.UpdateStatus(pickup.ID, models.StatusCancelled, reason, s.clock.Now()); err != nil {
+				log.Printf("Failed to cancel pickup %d linked to inbound %d: %v", pickup.ID, current.ID, err)
+				continue
+			}
+			pickup.Status = models.StatusCancelled
+			pickup.CancellationReason = reason
+			s.RecordStatusChange(pickup, oldStatus, StatusChange{Source: models.StatusSourceCrossDock, Reason: reason})
+			s.cascadeCancellation(pickup)
+			continue
+		}
+
+		before := *pickup
+		pickup.ScheduledStart = before.ScheduledStart.Add(shift)
+		pickup.ScheduledEnd = before.ScheduledEnd.Add(shift)
+		if err := s.appointmentRepo.Update(pickup); err != nil {
+			log.Printf("Failed to reschedule pickup %d linked to inbound %d: %v", pickup.ID, current.ID, err)
+			continue
+		}
+		s.RecordUpdate(&before, pickup, StatusChange{
+			Source: models.StatusSourceCrossDock,
+			Reason: fmt.Sprintf("Linked inbound appointment %s was rescheduled", current.Reference()),
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+)
+
+// Delay errors
+var (
+	ErrETAInPast = errors.New("ETA cannot be in the past")
+	ErrNotADelay = errors.New("ETA is not later than the scheduled start")
+)
+
+// DelayDeclaration is a supplier's report that a delivery will arrive late
+type DelayDeclaration struct {
+	ETA            time.Time
+	Reason         string
+	AutoReschedule bool // move the appointment to the ETA if the delay is within tolerance and the slot is free
+	DeclaredByID   uint
+}
+
+// DelayResult describes the outcome of a delay declaration
+type DelayResult struct {
+	Appointment *models.Appointment      `json:"appointment"`
+	Delay       *models.AppointmentDelay `json:"delay"`
+	Message     string                   `json:"message"`
+}
+
+// DeclareDelay records a new ETA for an appointment. The slot shifted to the ETA is re-validated
+// against the employee's schedule and the operation's capacity rules; when requested, the delay is
+// within the configured tolerance and the shifted slot is free, the appointment is moved to it.
+// The dock team is notified either way.
+func (s *appointmentService) DeclareDelay(id uint, declaration DelayDeclaration) (*DelayResult, error) {
+	appointment, err := s.appointmentRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if appointment.Status.IsFinal() {
+		return nil, ErrAppointmentClosed
+	}
+	if appointment.CheckedInAt != nil {
+		return nil, ErrAlreadyCheckedIn
+	}
+	if declaration.ETA.Before(s.clock.Now()) {
+		return nil, ErrETAInPast
+	}
+
+	delay := declaration.ETA.Sub(appointment.ScheduledStart)
+	if delay <= 0 {
+		return nil, ErrNotADelay
+	}
+
+	previous := *appointment
+	record := &models.AppointmentDelay{
+		AppointmentID: appointment.ID,
+		DeclaredByID:  declaration.DeclaredByID,
+		PreviousStart: appointment.ScheduledStart,
+		ETA:           declaration.ETA,
+		DelayMinutes:  int(delay.Minutes()),
+		Reason:        declaration.Reason,
+	}
+
+	shifted := *appointment
+	shifted.ScheduledStart = declaration.ETA
+	shifted.ScheduledEnd = declaration.ETA.Add(appointment.ScheduledEnd.Sub(appointment.ScheduledStart))
+	record.SlotAvailable, err = s.slotAvailable(&shifted)
+	if err != nil {
+		return nil, err
+	}
+
+	var message string
+	tolerance := s.rescheduleTolerance()
+	switch {
+	case !record.SlotAvailable:
+		message = "The slot at the new ETA is taken; the dock team will contact you to reschedule"
+	case declaration.AutoReschedule && tolerance > 0 && delay <= tolerance:
+		appointment.ScheduledStart = shifted.ScheduledStart
+		appointment.ScheduledEnd = shifted.ScheduledEnd
+		record.Rescheduled = true
+		message = fmt.Sprintf("Appointment moved to %s", appointment.ScheduledStart.Format("2006-01-02 15:04"))
+	case declaration.AutoReschedule:
+		message = fmt.Sprintf("Delays over %s are not rescheduled automatically; the dock team has been notified", tolerance)
+	default:
+		message = "The dock team has been notified of the new ETA"
+	}
+
+	eta := declaration.ETA
+	appointment.EstimatedArrival = &eta
+	if err := s.appointmentRepo.Update(appointment); err != nil {
+		return nil, err
+	}
+	if err := s.delayRepo.Create(record); err != nil {
+		return nil, err
+	}
+
+	if record.Rescheduled {
+		if err := s.PropagateToLinked(&previous, appointment); err != nil {
+			log.Printf("Failed to propagate delay of appointment %d to linked pickups: %v", appointment.ID, err)
+		}
+	}
+	s.notifyDelay(appointment, record)
+
+	return &DelayResult{Appointment: appointment, Delay: record, Message: message}, nil
+}
+
+// GetDelays lists the delays declared for an appointment
+func (s *appointmentService) GetDelays(id uint) ([]models.AppointmentDelay, error) {
+	return s.delayRepo.FindByAppointment(id)
+}
+
+// slotAvailable reports whether an appointment's slot is free of employee conflicts and
+// within the operation's capacity rules for its type
+func (s *appointmentService) slotAvailable(appointment *models.Appointment) (bool, error) {
+	conflict, err := s.appointmentRepo.HasConflict(appointment)
+	if err != nil {
+		return false, err
+	}
+	if conflict {
+		return false, nil
+	}
+
+	if err := s.checkTypeRules(appointment); err != nil {
+		if errors.Is(err, ErrTypeCapacityReached) || errors.Is(err, ErrBandCapacityReached) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// rescheduleTolerance returns the largest delay an appointment may be moved automatically for
+func (s *appointmentService) rescheduleTolerance() time.Duration {
+	if s.settingsService == nil {
+		return 0
+	}
+	return s.settingsService.Duration(SettingDelayRescheduleTolerance)
+}
+
+// notifyDelay tells the dock team a supplier expects to arrive late
+func (s *appointmentService) notifyDelay(appointment *models.Appointment, delay *models.AppointmentDelay) {
+	if s.notificationService == nil {
+		return
+	}
+
+	subject := fmt.Sprintf("%s %s is delayed by %d minutes", appointment.Type.Label(), appointment.Reference(), delay.DelayMinutes)
+	if appointment.Supplier.CompanyName != "" {
+		subject = fmt.Sprintf("%s from %s is delayed by %d minutes", appointment.Type.Label(), appointment.Supplier.CompanyName, delay.DelayMinutes)
+	}
+	body := fmt.Sprintf("%s. Scheduled for %s, new ETA %s.", subject,
+		delay.PreviousStart.Format("15:04"), delay.ETA.Format("15:04"))
+	if delay.Reason != "" {
+		body += " Reason: " + delay.Reason + "."
+	}
+	switch {
+	case delay.Rescheduled:
+		body += " The appointment was moved to the new ETA."
+	case !delay.SlotAvailable:
+		body += " The slot at the new ETA is taken; please reschedule."
+	}
+
+	notification := &models.Notification{
+		Type:          models.NotificationTypeEmail,
+		Status:        models.NotificationStatusPending,
+		Event:         models.EventAppointmentDelayed,
+		RecipientType: models.RecipientEmployee,
+		RecipientID:   appointment.EmployeeID,
+		Subject:       subject,
+		Body:          body,
+		AppointmentID: &appointment.ID,
+	}
+	if err := s.notificationService.EnqueueNotification(notification, QueueAppointmentNotifications, 3); err != nil {
+		log.Printf("Failed to enqueue delay notification for appointment %d: %v", appointment.ID, err)
+	}
+}
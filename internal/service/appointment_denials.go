@@ -0,0 +1,114 @@
+package service
+
+import (
+	"errors"
+	"log"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/metrics"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+)
+
+// Booking denial analytics errors
+var (
+	ErrDenialGroup  = errors.New("unknown denial grouping")
+	ErrDenialReason = errors.New("unknown denial reason")
+)
+
+// denialGroups are the dimensions booking denials can be grouped by
+var denialGroups = map[string]bool{
+	repository.DenialGroupOperation: true,
+	repository.DenialGroupReason:    true,
+	repository.DenialGroupWeekday:   true,
+	repository.DenialGroupHour:      true,
+	repository.DenialGroupDate:      true,
+}
+
+// denialReason returns why a booking was refused when it was for lack of room at the time asked
+// for. Bookings refused for invalid data aren't demand the operation failed to meet.
+func denialReason(err error) (models.DenialReason, bool) {
+	switch {
+	case errors.Is(err, ErrTypeCapacityReached), errors.Is(err, ErrBandCapacityReached):
+		return models.DenialCapacity, true
+	case errors.Is(err, ErrSupplierLimitReached):
+		return models.DenialQuota, true
+	case errors.Is(err, ErrOperationClosed):
+		return models.DenialClosed, true
+	}
+	switch err.Error() {
+	case "appointment conflicts with an existing appointment":
+		return models.DenialConflict, true
+	case "appointment must be scheduled for a future date":
+		return models.DenialLeadTime, true
+	case "appointment must be within operation hours":
+		return models.DenialClosed, true
+	}
+	return "", false
+}
+
+// recordDenial logs a refused booking when it was refused for lack of room. The requested time is
+// read in the operation's timezone; failures are logged and don't change the booking's error.
+func (s *appointmentService) recordDenial(appointment *models.Appointment, cause error) {
+	reason, ok := denialReason(cause)
+	if !ok || s.denialRepo == nil {
+		return
+	}
+
+	operation, err := s.operationRepo.FindByID(appointment.OperationID)
+	if err != nil {
+		log.Printf("Failed to record booking denied at operation %d (%s): %v", appointment.OperationID, reason, err)
+		return
+	}
+	metrics.BookingDenials.WithLabelValues(operation.Code, string(reason)).Inc()
+
+	start := appointment.ScheduledStart.In(operation.Location())
+	denial := &models.BookingDenial{
+		OperationID:    appointment.OperationID,
+		SupplierID:     appointment.SupplierID,
+		EmployeeID:     appointment.EmployeeID,
+		ProductID:      appointment.ProductID,
+		Type:           appointment.Type,
+		Reason:         reason,
+		Detail:         cause.Error(),
+		RequestedStart: appointment.ScheduledStart,
+		RequestedEnd:   appointment.ScheduledEnd,
+		LocalDate:      start.Format("2006-01-02"),
+		Weekday:        int(start.Weekday()),
+		Hour:           start.Hour(),
+		CreatedAt:      s.clock.Now(),
+	}
+	if err := s.denialRepo.Create(denial); err != nil {
+		log.Printf("Failed to record booking denied at operation %d (%s): %v", appointment.OperationID, reason, err)
+	}
+}
+
+// GetDenials counts the bookings refused for lack of room per combination of the grouped
+// dimensions, e.g. per operation and hour to see which time bands turn suppliers away
+func (s *appointmentService) GetDenials(filters repository.DenialFilters) ([]repository.DenialRow, error) {
+	seen := make(map[string]bool, len(filters.GroupBy))
+	for _, group := range filters.GroupBy {
+		if !denialGroups[group] || seen[group] {
+			return nil, ErrDenialGroup
+		}
+		seen[group] = true
+	}
+	if filters.Reason != "" && !filters.Reason.IsValid() {
+		return nil, ErrDenialReason
+	}
+	return s.denialRepo.Summarize(filters)
+}
+
+// PruneDenials deletes the refused bookings older than the configured retention
+func (s *appointmentService) PruneDenials() (int64, error) {
+	if s.config == nil || s.config.Denials.Retention <= 0 {
+		return 0, nil
+	}
+	deleted, err := s.denialRepo.DeleteBefore(s.clock.Now().Add(-s.config.Denials.Retention))
+	if err != nil {
+		return 0, err
+	}
+	if deleted > 0 {
+		log.Printf("Pruned %d booking denials", deleted)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/payments"
+)
+
+// Deposit errors
+var (
+	ErrDepositsUnavailable = errors.New("operation requires a deposit but no payment provider is configured")
+	ErrDepositRequest      = errors.New("payment provider request failed")
+	ErrInvalidPaymentEvent = errors.New("invalid payment event")
+)
+
+// Defaults for operations charging deposits when they are not configured
+const (
+	defaultDepositHold            = 30 * time.Minute
+	defaultDepositExpiryBatchSize = 200
+)
+
+// DepositExpiryResult counts what a run of the deposit expiry job did
+type DepositExpiryResult struct {
+	Cancelled int `json:"cancelled"`
+}
+
+// NewPaymentProvider creates the configured payment provider, or nil when deposits can't be
+// collected
+func NewPaymentProvider(cfg config.PaymentConfig, httpClient *http.Client) payments.Provider {
+	switch strings.ToLower(cfg.Provider) {
+	case "stripe":
+		if cfg.SecretKey == "" || cfg.WebhookSecret == "" {
+			log.Printf("The stripe payment provider needs a secret key and a webhook secret, deposits are not collected")
+			return nil
+		}
+		var transport http.RoundTripper
+		if httpClient != nil {
+			transport = httpClient.Transport
+		}
+		return payments.NewStripe(cfg.BaseURL, cfg.SecretKey, cfg.WebhookSecret, cfg.Methods, cfg.Timeout, transport)
+	case "", "none":
+		return nil
+	default:
+		log.Printf("Unknown payment provider %q, deposits are not collected", cfg.Provider)
+		return nil
+	}
+}
+
+// requestDeposit creates the payment of the deposit an operation charges for a booking and
+// reserves the appointment until it is paid. It returns nil when the operation charges none.
+// Nothing is saved yet, so a provider failure refuses the booking, and a booking that fails
+// afterwards must cancel the payment.
+func (s *appointmentService) requestDeposit(appointment *models.Appointment, operation *models.Operation) (*models.Deposit, error) {
+	if !operation.RequiresDeposit() {
+		return nil, nil
+	}
+	if s.paymentProvider == nil {
+		return nil, ErrDepositsUnavailable
+	}
+
+	places, err := models.CurrencyMinorUnits(operation.DepositCurrency)
+	if err != nil {
+		return nil, err
+	}
+	// Each booking attempt gets a new reference and so a new payment; an attempt that fails
+	// after this cancels its payment
+	reference, err := newLinkToken()
+	if err != nil {
+		return nil, err
+	}
+	request := payments.Request{
+		Reference:   reference,
+		Amount:      operation.DepositAmount.Shift(places).IntPart(),
+		Currency:    operation.DepositCurrency,
+		Description: fmt.Sprintf("Deposit for %s %s at %s", strings.ToLower(appointment.Type.Label()), appointment.Reference(), operation.Name),
+		Metadata: map[string]string{
+			"booking_code": appointment.BookingCode,
+			"operation":    operation.Code,
+		},
+	}
+
+	var payment *payments.Payment
+	retryAt, err := callProvider(s.breakers, s.clock, ProviderPayments, "refuse_booking", func() error {
+		var err error
+		payment, err = s.paymentProvider.CreatePayment(context.Background(), request)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDepositRequest, err)
+	}
+	if payment == nil {
+		return nil, fmt.Errorf("%w: try again after %s", ErrDepositRequest, retryAt.Format(time.RFC3339))
+	}
+
+	appointment.Status = models.StatusReserved
+	return &models.Deposit{
+		SupplierID:   appointment.SupplierID,
+		OperationID:  appointment.OperationID,
+		Amount:       operation.DepositAmount,
+		Currency:     operation.DepositCurrency,
+		Provider:     s.paymentProvider.Name(),
+		PaymentID:    payment.ID,
+		ClientSecret: payment.ClientSecret,
+		Status:       models.DepositPending,
+		ExpiresAt:    s.clock.Now().Add(s.depositHold()),
+	}, nil
+}
+
+// saveDeposit records the deposit of a newly reserved appointment. When it can't be recorded
+// the payment could never release the appointment, so the booking is undone.
+func (s *appointmentService) saveDeposit(appointment *models.Appointment, deposit *models.Deposit) error {
+	deposit.AppointmentID = appointment.ID
+	if err := s.depositRepo.Create(deposit); err != nil {
+		if deleteErr := s.appointmentRepo.Delete(appointment.ID); deleteErr != nil {
+			log.Printf("Failed to undo appointment %d after its deposit couldn't be recorded: %v", appointment.ID, deleteErr)
+		}
+		s.cancelPayment(deposit)
+		return fmt.Errorf("failed to record deposit: %w", err)
+	}
+	return nil
+}
+
+// GetDeposit returns the deposit of an appointment
+func (s *appointmentService) GetDeposit(id uint) (*models.Deposit, error) {
+	if _, err := s.appointmentRepo.FindByID(id); err != nil {
+		return nil, err
+	}
+	return s.depositRepo.FindByAppointment(id)
+}
+
+// HandlePaymentEvent applies a payment provider's webhook event to the deposit it is about. A paid
+// deposit moves its reserved appointment to pending, or to confirmed when configured; a payment
+// cancelled at the provider cancels the appointment. Events about other payments are ignored.
+// An error asks the provider to send the event again.
+func (s *appointmentService) HandlePaymentEvent(header http.Header, body []byte) error {
+	if s.paymentProvider == nil {
+		return ErrDepositsUnavailable
+	}
+	event, err := s.paymentProvider.ParseEvent(header, body, s.clock.Now())
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPaymentEvent, err)
+	}
+	if event.Type == "" || event.PaymentID == "" {
+		return nil
+	}
+
+	deposit, err := s.depositRepo.FindByPayment(s.paymentProvider.Name(), event.PaymentID)
+	if err != nil {
+		if errors.Is(err, repository.ErrDepositNotFound) {
+			log.Printf("Ignoring %s payment event %s about unknown payment %s", event.Type, event.ID, event.PaymentID)
+			return nil
+		}
+		return err
+	}
+
+	switch event.Type {
+	case payments.EventSucceeded:
+		return s.depositPaid(deposit)
+	case payments.EventFailed:
+		return s.depositRepo.MarkFailed(deposit.ID, event.Message)
+	case payments.EventCanceled:
+		ok, err := s.depositRepo.Cancel(deposit.ID, s.clock.Now())
+		if err != nil {
+			return err
+		}
+		if ok {
+			s.cancelReservation(deposit, "Deposit payment was cancelled")
+		}
+	}
+	return nil
+}
+
+// depositPaid records a deposit as paid and releases its appointment. A payment arriving after
+// the reservation was cancelled is only recorded and logged, for staff to refund it.
+func (s *appointmentService) depositPaid(deposit *models.Deposit) error {
+	if deposit.Status == models.DepositPaid {
+		return nil
+	}
+
+	now := s.clock.Now()
+	status := models.StatusPending
+	if s.config != nil && s.config.Payments.ConfirmOnPayment {
+		status = models.StatusConfirmed
+	}
+	released := false
+	if deposit.Status == models.DepositPending {
+		var err error
+		if released, err = s.depositRepo.ReleaseReservation(deposit.AppointmentID, status, now); err != nil {
+			return err
+		}
+	}
+	if _, err := s.depositRepo.MarkPaid(deposit.ID, now); err != nil {
+		return err
+	}
+
+	appointment, err := s.appointmentRepo.FindByID(deposit.AppointmentID)
+	if err != nil {
+		log.Printf("Failed to load appointment %d after its deposit was paid: %v", deposit.AppointmentID, err)
+		return nil
+	}
+	if !released {
+		if appointment.Status == models.StatusCancelled {
+			log.Printf("Deposit %d was paid after appointment %s was cancelled; refund payment %s at %s",
+				deposit.ID, appointment.Reference(), deposit.PaymentID, deposit.Provider)
+		}
+		return nil
+	}
+
+	s.RecordStatusChange(appointment, models.StatusReserved, StatusChange{Source: models.StatusSourceDeposit, Reason: "Deposit paid"})
+	return nil
+}
+
+// ExpireUnpaidDeposits cancels reserved appointments whose deposit wasn't paid before its hold
+// expired, so they stop holding capacity, and cancels their payments so they can't be paid late.
+// Cancellations cascade like any other: the slot goes to the waitlist and the suppliers watching
+// the operation.
+func (s *appointmentService) ExpireUnpaidDeposits() (*DepositExpiryResult, error) {
+	deposits, err := s.depositRepo.FindExpired(s.clock.Now(), defaultDepositExpiryBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DepositExpiryResult{}
+	for i := range deposits {
+		deposit := &deposits[i]
+		if !s.voidDeposit(deposit) {
+			continue
+		}
+		if s.cancelReservation(deposit, "Deposit not paid in time") {
+			result.Cancelled++
+		}
+	}
+
+	if result.Cancelled > 0 {
+		log.Printf("Reserved appointments: %d cancelled with their deposit unpaid", result.Cancelled)
+	}
+	return result, nil
+}
+
+// releaseDeposit voids the unpaid deposit of an appointment a user moved out of the reserved
+// status, e.g. cancelled or confirmed without waiting for the payment
+func (s *appointmentService) releaseDeposit(appointment *models.Appointment) {
+	if s.depositRepo == nil {
+		return
+	}
+	deposit, err := s.depositRepo.FindByAppointment(appointment.ID)
+	if err != nil {
+		return
+	}
+	s.voidDeposit(deposit)
+}
+
+// voidDeposit cancels an unpaid deposit and its payment at the provider, reporting false when it
+// was paid or cancelled meanwhile
+func (s *appointmentService) voidDeposit(deposit *models.Deposit) bool {
+	ok, err := s.depositRepo.Cancel(deposit.ID, s.clock.Now())
+	if err != nil {
+		log.Printf("Failed to cancel deposit %d of appointment %d: %v", deposit.ID, deposit.AppointmentID, err)
+		return false
+	}
+	if !ok {
+		return false
+	}
+	s.cancelPayment(deposit)
+	return true
+}
+
+// cancelPayment cancels the payment of a deposit at the provider. Failures are logged: a payment
+// made anyway is reported by the webhook and recorded for a refund.
+func (s *appointmentService) cancelPayment(deposit *models.Deposit) {
+	if s.paymentProvider == nil {
+		return
+	}
+	_, err := callProvider(s.breakers, s.clock, ProviderPayments, "leave_payment_open", func() error {
+		return s.paymentProvider.CancelPayment(context.Background(), deposit.PaymentID)
+	})
+	if err != nil {
+		log.Printf("Failed to cancel payment %s of deposit %d: %v", deposit.PaymentID, deposit.ID, err)
+	}
+}
+
+// cancelReservation cancels the appointment a cancelled deposit reserved, reporting false when
+// it left the reserved status meanwhile
+func (s *appointmentService) cancelReservation(deposit *models.Deposit, reason string) bool {
+	ok, err := s.depositRepo.CancelReservation(deposit.AppointmentID, s.clock.Now(), reason)
+	if err != nil {
+		log.Printf("Failed to cancel appointment %d reserved for deposit %d: %v", deposit.AppointmentID, deposit.ID, err)
+		return false
+	}
+	if !ok {
+		return false
+	}
+
+	appointment, err := s.appointmentRepo.FindByID(deposit.AppointmentID)
+	if err != nil {
+		log.Printf("Failed to load appointment %d cancelled for its deposit: %v", deposit.AppointmentID, err)
+		return true
+	}
+	previous := *appointment
+	previous.Status = models.StatusReserved
+
+	s.RecordStatusChange(appointment, previous.Status, StatusChange{Source: models.StatusSourceDeposit, Reason: reason})
+	if err := s.PropagateToLinked(&previous, appointment); err != nil {
+		log.Printf("Failed to update pickups linked to appointment %d cancelled for its deposit: %v", appointment.ID, err)
+	}
+	s.cascadeCancellation(appointment)
+	return true
+}
+
+// depositHold returns how long a reserved appointment waits for its deposit
+func (s *appointmentService) depositHold() time.Duration {
+	if s.config == nil || s.config.Payments.HoldDuration <= 0 {
+		return defaultDepositHold
+	}
+	return s.config.Payments.HoldDuration
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+)
+
+// AppointmentEventType is what happened to an appointment
+type AppointmentEventType string
+
+// Appointment event types
+const (
+	AppointmentEventCreated       AppointmentEventType = "created"        // the appointment was booked
+	AppointmentEventUpdated       AppointmentEventType = "updated"        // its time, participants or quantity changed
+	AppointmentEventStatusChanged AppointmentEventType = "status_changed" // it moved from one status to another
+)
+
+// AppointmentEvent is a change of an appointment, published once the change is saved
+type AppointmentEvent struct {
+	Type           AppointmentEventType
+	Appointment    *models.Appointment      // as saved
+	Previous       *models.Appointment      // before an update, nil for other events
+	PreviousStatus models.AppointmentStatus // before a status change, "" for a new appointment
+	Change         StatusChange             // who made the change and why
+	Changes        map[string]interface{}   // old and new value of each field an update changed
+	OccurredAt     time.Time
+}
+
+// AppointmentEventHandler handles the events a subscriber registered for
+type AppointmentEventHandler func(event AppointmentEvent) error
+
+// appointmentSubscriber is a handler and the event types it registered for
+type appointmentSubscriber struct {
+	name    string
+	types   map[AppointmentEventType]bool // nil for every type
+	handler AppointmentEventHandler
+}
+
+// AppointmentEvents dispatches the events of appointments to the side effects subscribed to them,
+// such as notifications, calendar sync, webhooks and the status history, so the booking logic
+// publishes what happened instead of calling each of them.
+//
+// Handlers run in the order they subscribed, in the goroutine publishing the event: one that
+// calls a slow third party should hand the work off. A handler failing, or panicking, is logged
+// and neither stops the others nor undoes the change.
+type AppointmentEvents struct {
+	mu          sync.RWMutex
+	subscribers []appointmentSubscriber
+}
+
+// NewAppointmentEvents creates a dispatcher without subscribers
+func NewAppointmentEvents() *AppointmentEvents {
+	return &AppointmentEvents{}
+}
+
+// Subscribe registers a handler for the given event types, or for every type when none is given.
+// The name identifies the subscriber in the log.
+func (d *AppointmentEvents) Subscribe(name string, handler AppointmentEventHandler, types ...AppointmentEventType) {
+	subscriber := appointmentSubscriber{name: name, handler: handler}
+	if len(types) > 0 {
+		subscriber.types = make(map[AppointmentEventType]bool, len(types))
+		for _, eventType := range types {
+			subscriber.types[eventType] = true
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.subscribers = append(d.subscribers, subscriber)
+}
+
+// Publish hands an event to every subscriber registered for its type. A nil dispatcher drops it.
+func (d *AppointmentEvents) Publish(event AppointmentEvent) {
+	if d == nil || event.Appointment == nil {
+		return
+	}
+
+	d.mu.RLock()
+	subscribers := d.subscribers
+	d.mu.RUnlock()
+
+	for _, subscriber := range subscribers {
+		if subscriber.types != nil && !subscriber.types[event.Type] {
+			continue
+		}
+		if err := subscriber.handle(event); err != nil {
+			log.Printf("Appointment event subscriber %s failed on %s of appointment %d: %v",
+				subscriber.name, event.Type, event.Appointment.ID, err)
+		}
+	}
+}
+
+// handle runs the subscriber's handler, turning a panic into an error
+func (s appointmentSubscriber) handle(event AppointmentEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return s.handler(event)
+}
+
+// publish stamps an event with the current time and publishes it
+func (s *appointmentService) publish(event AppointmentEvent) {
+	event.OccurredAt = s.clock.Now()
+	s.events.Publish(event)
+}
+
+// publishCreated publishes the booking of a new appointment
+func (s *appointmentService) publishCreated(appointment *models.Appointment, change StatusChange) {
+	s.publish(AppointmentEvent{
+		Type:        AppointmentEventCreated,
+		Appointment: appointment,
+		Change:      change,
+	})
+}
+
+// RecordUpdate publishes the events of an update saved through Update: updated when the time,
+// participants or quantity changed, then status_changed when the status moved
+func (s *appointmentService) RecordUpdate(previous, appointment *models.Appointment, change StatusChange) {
+	if changes := appointmentChanges(previous, appointment); len(changes) > 0 {
+		if change.Reason != "" {
+			changes["reason"] = change.Reason
+		}
+		s.publish(AppointmentEvent{
+			Type:        AppointmentEventUpdated,
+			Appointment: appointment,
+			Previous:    previous,
+			Change:      change,
+			Changes:     changes,
+		})
+	}
+	s.RecordStatusChange(appointment, previous.Status, change)
+}
+
+// appointmentChanges returns the old and new value of the fields participants are told about
+// that differ between two versions of an appointment
+func appointmentChanges(previous, current *models.Appointment) map[string]interface{} {
+	changes := make(map[string]interface{})
+	change := func(field string, from, to interface{}) {
+		changes[field] = map[string]interface{}{"old": from, "new": to}
+	}
+	if !previous.ScheduledStart.Equal(current.ScheduledStart) {
+		change("scheduled_start", previous.ScheduledStart, current.ScheduledStart)
+	}
+	if !previous.ScheduledEnd.Equal(current.ScheduledEnd) {
+		change("scheduled_end", previous.ScheduledEnd, current.ScheduledEnd)
+	}
+	if previous.EmployeeID != current.EmployeeID {
+		change("employee_id", previous.EmployeeID, current.EmployeeID)
+	}
+	if previous.SupplierID != current.SupplierID {
+		change("supplier_id", previous.SupplierID, current.SupplierID)
+	}
+	if previous.OperationID != current.OperationID {
+		change("operation_id", previous.OperationID, current.OperationID)
+	}
+	if previous.QuantityToDeliver != current.QuantityToDeliver {
+		change("quantity_to_deliver", previous.QuantityToDeliver, current.QuantityToDeliver)
+	}
+	return changes
+}
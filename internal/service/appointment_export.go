@@ -0,0 +1,25 @@
+package service
+
+import (
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+)
+
+// defaultExportBatchSize is how many rows exports and replays read at a time when not configured
+const defaultExportBatchSize = 1000
+
+// Export calls fn with every appointment matching the filters, a batch at a time, so exports of
+// any size are written out without holding them in memory. The next batch is read once fn
+// returns; an error from fn stops the export and is returned.
+func (s *appointmentService) Export(filters repository.AppointmentFilters, fn func(appointments []models.Appointment) error) error {
+	return s.appointmentRepo.EachBatch(filters, exportBatchSize(s.config), fn)
+}
+
+// exportBatchSize returns how many rows exports read from the database at a time
+func exportBatchSize(cfg *config.Config) int {
+	if cfg == nil || cfg.Exports.BatchSize <= 0 {
+		return defaultExportBatchSize
+	}
+	return cfg.Exports.BatchSize
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+)
+
+// Feedback errors
+var (
+	ErrFeedbackNotOpen          = errors.New("feedback can only be left on completed appointments")
+	ErrFeedbackAlreadySubmitted = errors.New("feedback was already left on this appointment")
+)
+
+// FeedbackSubmission is a user's rating of a completed appointment
+type FeedbackSubmission struct {
+	AuthorID   uint
+	AuthorRole string
+	Rating     int
+	Comment    string
+}
+
+// SubmitFeedback records a supplier's or employee's rating of a completed appointment. Each user
+// may rate an appointment once.
+func (s *appointmentService) SubmitFeedback(id uint, submission FeedbackSubmission) (*models.AppointmentFeedback, error) {
+	appointment, err := s.appointmentRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if appointment.Status != models.StatusCompleted && appointment.Status != models.StatusPartiallyCompleted {
+		return nil, ErrFeedbackNotOpen
+	}
+
+	feedback := &models.AppointmentFeedback{
+		AppointmentID: appointment.ID,
+		OperationID:   appointment.OperationID,
+		AuthorID:      submission.AuthorID,
+		AuthorRole:    submission.AuthorRole,
+		Rating:        submission.Rating,
+		Comment:       strings.TrimSpace(submission.Comment),
+	}
+	if err := feedback.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.feedbackRepo.FindByAppointmentAndAuthor(appointment.ID, submission.AuthorID); err == nil {
+		return nil, ErrFeedbackAlreadySubmitted
+	}
+	if err := s.feedbackRepo.Create(feedback); err != nil {
+		return nil, err
+	}
+	return feedback, nil
+}
+
+// GetFeedback lists the feedback left on an appointment
+func (s *appointmentService) GetFeedback(id uint) ([]models.AppointmentFeedback, error) {
+	return s.feedbackRepo.FindByAppointment(id)
+}
+
+// GetFeedbackScores aggregates the feedback left per operation
+func (s *appointmentService) GetFeedbackScores(filters repository.FeedbackFilters) ([]repository.OperationFeedbackScore, error) {
+	return s.feedbackRepo.ScoresByOperation(filters)
+}
+
+// requestFeedback asks the supplier and the employee of a completed appointment to rate it
+func (s *appointmentService) requestFeedback(appointment *models.Appointment) {
+	if s.notificationService == nil {
+		return
+	}
+
+	fallback := ""
+	if s.config != nil && s.config.Notification != nil {
+		fallback = s.config.Notification.LinkBaseURL
+	}
+	link := buildLink(portalURL(s.operationRepo, appointment.OperationID, fallback), "appointments", appointment.ID, "feedback")
+
+	subject := fmt.Sprintf("How did %s %s go?", strings.ToLower(appointment.Type.Label()), appointment.Reference())
+	body := fmt.Sprintf("%s %s is complete. Rate it from 1 to 5 and tell us what could be better: %s",
+		appointment.Type.Label(), appointment.Reference(), link)
+
+	recipients := []struct {
+		recipientType models.NotificationRecipientType
+		recipientID   uint
+	}{
+		{models.RecipientSupplier, appointment.SupplierID},
+		{models.RecipientEmployee, appointment.EmployeeID},
+	}
+	for _, recipient := range recipients {
+		notification := &models.Notification{
+			Type:          models.NotificationTypeEmail,
+			Status:        models.NotificationStatusPending,
+			Event:         models.EventFeedbackRequested,
+			RecipientType: recipient.recipientType,
+			RecipientID:   recipient.recipientID,
+			Subject:       subject,
+			Body:          body,
+			AppointmentID: &appointment.ID,
+		}
+		if err := s.notificationService.EnqueueNotification(notification, QueueAppointmentNotifications, 1); err != nil {
+			log.Printf("Failed to enqueue feedback request for appointment %d: %v", appointment.ID, err)
+		}
+	}
+}
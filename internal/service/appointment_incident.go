@@ -0,0 +1,103 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+)
+
+// Incident errors
+var (
+	ErrIncidentNotFound   = errors.New("incident not found")
+	ErrIncidentResolved   = errors.New("incident is already resolved")
+	ErrResolutionRequired = errors.New("resolution is required")
+	ErrTooManyAttachments = errors.New("an incident can have at most 10 attachments")
+)
+
+// ReportIncident logs a problem on an appointment. The supplier and operation are copied from the
+// appointment so incidents can be reported on per supplier.
+func (s *appointmentService) ReportIncident(id uint, incident *models.AppointmentIncident) error {
+	appointment, err := s.appointmentRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+
+	incident.AppointmentID = appointment.ID
+	incident.SupplierID = appointment.SupplierID
+	incident.OperationID = appointment.OperationID
+	incident.ResolvedAt = nil
+	incident.ResolvedByID = nil
+	incident.Resolution = ""
+	if err := incident.Validate(); err != nil {
+		return err
+	}
+
+	return s.incidentRepo.Create(incident)
+}
+
+// GetIncidents lists the incidents logged on an appointment
+func (s *appointmentService) GetIncidents(id uint) ([]models.AppointmentIncident, error) {
+	return s.incidentRepo.FindByAppointment(id)
+}
+
+// ResolveIncident closes an incident with a note on how it was handled
+func (s *appointmentService) ResolveIncident(id, incidentID, resolvedByID uint, resolution string) (*models.AppointmentIncident, error) {
+	incident, err := s.findIncident(id, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	if incident.IsResolved() {
+		return nil, ErrIncidentResolved
+	}
+	resolution = strings.TrimSpace(resolution)
+	if resolution == "" {
+		return nil, ErrResolutionRequired
+	}
+
+	now := s.clock.Now()
+	incident.ResolvedAt = &now
+	incident.ResolvedByID = &resolvedByID
+	incident.Resolution = resolution
+	if err := s.incidentRepo.Update(incident); err != nil {
+		return nil, err
+	}
+	return incident, nil
+}
+
+// AddIncidentAttachments adds photos or files to an incident, resolved or not
+func (s *appointmentService) AddIncidentAttachments(id, incidentID uint, attachments []models.IncidentAttachment) (*models.AppointmentIncident, error) {
+	incident, err := s.findIncident(id, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	if len(incident.Attachments)+len(attachments) > models.MaxIncidentAttachments {
+		return nil, ErrTooManyAttachments
+	}
+
+	for i := range attachments {
+		if attachments[i].FileURL == "" {
+			return nil, errors.New("attachment file URL is required")
+		}
+		attachments[i].IncidentID = incident.ID
+	}
+	if err := s.incidentRepo.AddAttachments(attachments); err != nil {
+		return nil, err
+	}
+	return s.incidentRepo.FindByID(incident.ID)
+}
+
+// GetSupplierPerformance summarizes the appointments, delays and incidents of each supplier
+func (s *appointmentService) GetSupplierPerformance(filters repository.DeliveryReportFilters) ([]repository.SupplierPerformanceRow, error) {
+	return s.reportRepo.SupplierPerformance(filters)
+}
+
+// findIncident loads an incident and checks it belongs to the appointment
+func (s *appointmentService) findIncident(id, incidentID uint) (*models.AppointmentIncident, error) {
+	incident, err := s.incidentRepo.FindByID(incidentID)
+	if err != nil || incident.AppointmentID != id {
+		return nil, ErrIncidentNotFound
+	}
+	return incident, nil
+}
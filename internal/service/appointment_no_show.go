@@ -0,0 +1,97 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+)
+
+// No-show errors
+var (
+	ErrNoShowRequiresConfirmed = errors.New("only confirmed appointments can be marked no-show")
+	ErrNoShowTooEarly          = errors.New("an appointment can't be marked no-show before its scheduled start")
+)
+
+// checkNoShow makes sure an appointment may be marked no-show: it was confirmed and its slot
+// has started
+func (s *appointmentService) checkNoShow(appointment *models.Appointment) error {
+	if appointment.Status != models.StatusConfirmed {
+		return ErrNoShowRequiresConfirmed
+	}
+	if s.clock.Now().Before(appointment.ScheduledStart) {
+		return ErrNoShowTooEarly
+	}
+	return nil
+}
+
+// chargeNoShowFee charges the supplier of an appointment marked no-show the fee its operation
+// sets, if any, and emails them about it. Failures are logged and don't undo the status change.
+func (s *appointmentService) chargeNoShowFee(appointment *models.Appointment) {
+	if s.feeRepo == nil {
+		return
+	}
+
+	operation, err := s.operationRepo.FindByID(appointment.OperationID)
+	if err != nil {
+		log.Printf("Failed to load operation %d to charge the no-show fee of appointment %d: %v", appointment.OperationID, appointment.ID, err)
+		return
+	}
+	if !operation.NoShowFee.IsPositive() {
+		return
+	}
+	// Marking the same appointment no-show again, e.g. after an admin reverted it, charges once
+	if _, err := s.feeRepo.FindByAppointment(appointment.ID, models.FeeNoShow); err == nil {
+		return
+	}
+
+	fee := &models.Fee{
+		AppointmentID: appointment.ID,
+		Kind:          models.FeeNoShow,
+		SupplierID:    appointment.SupplierID,
+		OperationID:   appointment.OperationID,
+		Amount:        operation.NoShowFee,
+		Currency:      operation.NoShowFeeCurrency,
+		Status:        models.FeeCharged,
+	}
+	if err := fee.Validate(); err != nil {
+		log.Printf("Invalid no-show fee for appointment %d: %v", appointment.ID, err)
+		return
+	}
+	if err := s.feeRepo.Create(fee); err != nil {
+		log.Printf("Failed to charge the no-show fee of appointment %d: %v", appointment.ID, err)
+		return
+	}
+
+	s.notifyFeeCharged(appointment, fee)
+}
+
+// notifyFeeCharged tells the supplier they were charged a fee and how to dispute it
+func (s *appointmentService) notifyFeeCharged(appointment *models.Appointment, fee *models.Fee) {
+	if s.notificationService == nil {
+		return
+	}
+
+	fallback := ""
+	if s.config != nil && s.config.Notification != nil {
+		fallback = s.config.Notification.LinkBaseURL
+	}
+	link := buildLink(portalURL(s.operationRepo, appointment.OperationID, fallback), "appointments", appointment.ID)
+
+	notification := &models.Notification{
+		Type:          models.NotificationTypeEmail,
+		Status:        models.NotificationStatusPending,
+		Event:         models.EventFeeCharged,
+		RecipientType: models.RecipientSupplier,
+		RecipientID:   appointment.SupplierID,
+		Subject:       fmt.Sprintf("Fee charged for appointment %s", appointment.Reference()),
+		Body: fmt.Sprintf("A %s fee of %s %s was charged for appointment %s on %s. You can dispute it from the appointment: %s",
+			fee.Kind.Label(), fee.Amount.String(), fee.Currency, appointment.Reference(),
+			appointment.ScheduledStart.Format("2006-01-02 15:04"), link),
+		AppointmentID: &appointment.ID,
+	}
+	if err := s.notificationService.EnqueueNotification(notification, QueueAppointmentNotifications, 2); err != nil {
+		log.Printf("Failed to enqueue fee notification for appointment %d: %v", appointment.ID, err)
+	}
+}
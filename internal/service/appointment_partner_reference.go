@@ -0,0 +1,48 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+)
+
+// maxPartnerReferenceLength is the longest external reference or purchase order partners can set
+const maxPartnerReferenceLength = 100
+
+// PartnerReference is what a partner system pushes about an appointment. Nil fields are left as
+// they are and empty ones clear them.
+type PartnerReference struct {
+	ExternalRef   *string
+	PurchaseOrder *string
+}
+
+// SetPartnerReference records a partner's reference and purchase order on the appointment with a
+// booking code. Like SetBilling it works at any status, since ERPs often confirm receipts after
+// the delivery was completed.
+func (s *appointmentService) SetPartnerReference(bookingCode string, reference PartnerReference) (*models.Appointment, error) {
+	appointment, err := s.GetByBookingCode(bookingCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if reference.ExternalRef != nil {
+		ref := strings.TrimSpace(*reference.ExternalRef)
+		if len(ref) > maxPartnerReferenceLength {
+			return nil, errors.New("external reference cannot exceed 100 characters")
+		}
+		appointment.ExternalRef = ref
+	}
+	if reference.PurchaseOrder != nil {
+		order := strings.TrimSpace(*reference.PurchaseOrder)
+		if len(order) > maxPartnerReferenceLength {
+			return nil, errors.New("purchase order cannot exceed 100 characters")
+		}
+		appointment.PurchaseOrder = order
+	}
+
+	if err := s.appointmentRepo.Update(appointment); err != nil {
+		return nil, err
+	}
+	return appointment, nil
+}
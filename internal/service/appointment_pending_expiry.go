@@ -0,0 +1,156 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/metrics"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+)
+
+// defaultPendingExpiryBatchSize is how many pending appointments a run warns and cancels when not
+// configured
+const defaultPendingExpiryBatchSize = 200
+
+// PendingExpiryResult counts what a run of the pending expiry job did
+type PendingExpiryResult struct {
+	Warned  int `json:"warned"`
+	Expired int `json:"expired"`
+}
+
+// ExpirePending cancels pending appointments nobody confirmed by their operation's pending expiry,
+// so they stop holding capacity, and warns the supplier and employee of those expiring within the
+// warning lead. Cancellations cascade like any other: the slot goes to the waitlist and the
+// suppliers watching the operation.
+func (s *appointmentService) ExpirePending() (*PendingExpiryResult, error) {
+	now := s.clock.Now()
+	lead := s.pendingExpiryWarningLead()
+	result := &PendingExpiryResult{}
+
+	expired, err := s.overdueRepo.FindExpiredPending(now, lead, s.pendingExpiryBatchSize())
+	if err != nil {
+		return nil, err
+	}
+	for i := range expired {
+		if s.expirePending(&expired[i], now) {
+			result.Expired++
+		}
+	}
+
+	expiring, err := s.overdueRepo.FindExpiringPending(now, lead, s.pendingExpiryBatchSize())
+	if err != nil {
+		return result, err
+	}
+	for i := range expiring {
+		appointment := &expiring[i]
+		if err := s.overdueRepo.MarkExpiryWarned(appointment.ID, now); err != nil {
+			log.Printf("Failed to record expiry warning of appointment %d: %v", appointment.ID, err)
+			continue
+		}
+		appointment.ExpiryWarnedAt = &now
+		s.notifyExpiring(appointment, s.pendingExpiresAt(appointment))
+		metrics.PendingExpiryWarnings.WithLabelValues(appointment.Operation.Code).Inc()
+		result.Warned++
+	}
+
+	if result.Warned > 0 || result.Expired > 0 {
+		log.Printf("Pending appointments: %d warned of their expiry, %d cancelled unconfirmed", result.Warned, result.Expired)
+	}
+	return result, nil
+}
+
+// expirePending cancels one expired appointment, reporting false when it was confirmed or
+// cancelled since it was found
+func (s *appointmentService) expirePending(appointment *models.Appointment, now time.Time) bool {
+	reason := fmt.Sprintf("Not confirmed %d hours before it starts", appointment.Operation.PendingExpiryHours)
+	ok, err := s.overdueRepo.ExpirePending(appointment.ID, now, reason)
+	if err != nil {
+		log.Printf("Failed to cancel expired appointment %d: %v", appointment.ID, err)
+		return false
+	}
+	if !ok {
+		return false
+	}
+
+	previous := *appointment
+	appointment.Status = models.StatusCancelled
+	appointment.CancelledAt = &now
+	appointment.CancellationReason = reason
+	appointment.ExpiredAt = &now
+	metrics.PendingExpired.WithLabelValues(appointment.Operation.Code).Inc()
+
+	s.RecordStatusChange(appointment, previous.Status, StatusChange{Source: models.StatusSourceExpiry, Reason: reason})
+	if err := s.PropagateToLinked(&previous, appointment); err != nil {
+		log.Printf("Failed to update pickups linked to expired appointment %d: %v", appointment.ID, err)
+	}
+	s.cascadeCancellation(appointment)
+	return true
+}
+
+// notifyExpiring warns the employee, who can confirm the appointment, and the supplier that it is
+// cancelled unless confirmed in time
+func (s *appointmentService) notifyExpiring(appointment *models.Appointment, expiresAt time.Time) {
+	if s.notificationService == nil {
+		return
+	}
+
+	location := appointment.Operation.Location()
+	subject := fmt.Sprintf("%s %s expires unless confirmed", appointment.Type.Label(), appointment.Reference())
+	recipients := []struct {
+		recipientType models.NotificationRecipientType
+		id            uint
+		body          string
+	}{
+		{models.RecipientEmployee, appointment.EmployeeID, fmt.Sprintf(
+			"%s %s, scheduled for %s, is still pending. Confirm it before %s or it is cancelled and its slot released.",
+			appointment.Type.Label(), appointment.Reference(),
+			appointment.ScheduledStart.In(location).Format("2006-01-02 15:04"), expiresAt.In(location).Format("2006-01-02 15:04"))},
+		{models.RecipientSupplier, appointment.SupplierID, fmt.Sprintf(
+			"%s %s, scheduled for %s, has not been confirmed by the operation yet. Unless it is confirmed before %s it is cancelled.",
+			appointment.Type.Label(), appointment.Reference(),
+			appointment.ScheduledStart.In(location).Format("2006-01-02 15:04"), expiresAt.In(location).Format("2006-01-02 15:04"))},
+	}
+
+	for _, recipient := range recipients {
+		notification := &models.Notification{
+			Type:          models.NotificationTypeEmail,
+			Status:        models.NotificationStatusPending,
+			Event:         models.EventAppointmentExpiring,
+			RecipientType: recipient.recipientType,
+			RecipientID:   recipient.id,
+			Subject:       subject,
+			Body:          recipient.body,
+			AppointmentID: &appointment.ID,
+		}
+		if err := s.notificationService.EnqueueNotification(notification, QueueAppointmentNotifications, 2); err != nil {
+			log.Printf("Failed to enqueue expiry warning for appointment %d: %v", appointment.ID, err)
+		}
+	}
+}
+
+// pendingExpiresAt returns when a pending appointment expires: its operation's pending expiry
+// before it starts, but no sooner than the warning lead after it was booked
+func (s *appointmentService) pendingExpiresAt(appointment *models.Appointment) time.Time {
+	expiresAt := appointment.ScheduledStart.Add(-time.Duration(appointment.Operation.PendingExpiryHours) * time.Hour)
+	if earliest := appointment.CreatedAt.Add(s.pendingExpiryWarningLead()); expiresAt.Before(earliest) {
+		return earliest
+	}
+	return expiresAt
+}
+
+// pendingExpiryBatchSize returns how many pending appointments a run warns and cancels
+func (s *appointmentService) pendingExpiryBatchSize() int {
+	if s.config == nil || s.config.PendingExpiry.BatchSize <= 0 {
+		return defaultPendingExpiryBatchSize
+	}
+	return s.config.PendingExpiry.BatchSize
+}
+
+// pendingExpiryWarningLead returns how long before their expiry pending appointments are warned
+func (s *appointmentService) pendingExpiryWarningLead() time.Duration {
+	if s.settingsService == nil {
+		return 0
+	}
+	return s.settingsService.Duration(SettingPendingExpiryWarningLead)
+}
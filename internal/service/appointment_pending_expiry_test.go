@@ -0,0 +1,93 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// pendingAt returns a pending service visit starting at start at an operation cancelling pending
+// appointments 24 hours before they start, booked two days before bookingNow
+func pendingAt(start time.Time) *models.Appointment {
+	appointment := serviceVisit(start)
+	appointment.ID = 11
+	appointment.BookingCode = "GRU-2024-000011"
+	appointment.Status = models.StatusPending
+	appointment.CreatedAt = bookingNow.Add(-48 * time.Hour)
+	appointment.Operation = *testOperation()
+	appointment.Operation.PendingExpiryHours = 24
+	return appointment
+}
+
+func TestCreateRefusesBookingInThePast(t *testing.T) {
+	s, m := newAppointmentService(t, nil)
+	m.operations.EXPECT().FindByID(uint(3)).Return(testOperation(), nil)
+
+	var denial *models.BookingDenial
+	m.denials.EXPECT().Create(mock.Anything).Run(func(d *models.BookingDenial) { denial = d }).Return(nil)
+
+	err := s.Create(serviceVisit(bookingNow.Add(-time.Minute)))
+	require.EqualError(t, err, "appointment must be scheduled for a future date")
+	require.NotNil(t, denial)
+	assert.Equal(t, models.DenialLeadTime, denial.Reason)
+}
+
+func TestExpirePendingCancelsAtFrozenTime(t *testing.T) {
+	events := service.NewAppointmentEvents()
+	var published []service.AppointmentEvent
+	events.Subscribe("test", func(event service.AppointmentEvent) error {
+		published = append(published, event)
+		return nil
+	})
+	s, m := newAppointmentService(t, events)
+
+	// Expired four hours ago: it starts in 20 hours and the operation wants confirmation 24 hours before
+	expired := pendingAt(bookingNow.Add(20 * time.Hour))
+	reason := "Not confirmed 24 hours before it starts"
+	m.overdue.EXPECT().FindExpiredPending(bookingNow, time.Duration(0), 200).Return([]models.Appointment{*expired}, nil)
+	m.overdue.EXPECT().ExpirePending(uint(11), bookingNow, reason).Return(true, nil)
+	m.overdue.EXPECT().FindExpiringPending(bookingNow, time.Duration(0), 200).Return(nil, nil)
+
+	result, err := s.ExpirePending()
+	require.NoError(t, err)
+	assert.Equal(t, &service.PendingExpiryResult{Expired: 1}, result)
+
+	require.Len(t, published, 1)
+	event := published[0]
+	assert.Equal(t, models.StatusPending, event.PreviousStatus)
+	assert.Equal(t, models.StatusSourceExpiry, event.Change.Source)
+	assert.Equal(t, models.StatusCancelled, event.Appointment.Status)
+	require.NotNil(t, event.Appointment.ExpiredAt)
+	assert.Equal(t, bookingNow, *event.Appointment.ExpiredAt)
+	assert.Equal(t, bookingNow, event.OccurredAt)
+}
+
+func TestExpirePendingWarnsWithExpiryTime(t *testing.T) {
+	s, m := newAppointmentService(t, nil)
+
+	// Expires in six hours: it starts in 30 hours
+	expiring := pendingAt(bookingNow.Add(30 * time.Hour))
+	m.overdue.EXPECT().FindExpiredPending(bookingNow, time.Duration(0), 200).Return(nil, nil)
+	m.overdue.EXPECT().FindExpiringPending(bookingNow, time.Duration(0), 200).Return([]models.Appointment{*expiring}, nil)
+	m.overdue.EXPECT().MarkExpiryWarned(uint(11), bookingNow).Return(nil)
+
+	var warnings []*models.Notification
+	m.notify.EXPECT().EnqueueNotification(mock.Anything, "appointment_notifications", 2).
+		Run(func(notification *models.Notification, _ string, _ int) { warnings = append(warnings, notification) }).
+		Return(nil)
+
+	result, err := s.ExpirePending()
+	require.NoError(t, err)
+	assert.Equal(t, &service.PendingExpiryResult{Warned: 1}, result)
+
+	require.Len(t, warnings, 2)
+	for _, warning := range warnings {
+		assert.Equal(t, models.EventAppointmentExpiring, warning.Event)
+		assert.Contains(t, warning.Body, "before 2024-03-04 15:00")
+	}
+}
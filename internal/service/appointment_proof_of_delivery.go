@@ -0,0 +1,127 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+)
+
+// Proof of delivery errors
+var (
+	ErrProofOfDeliveryNotFound = errors.New("proof of delivery not found")
+	ErrProofOfDeliveryExists   = errors.New("appointment already has a proof of delivery")
+	ErrProofRequiresCompletion = errors.New("proof of delivery can only be recorded for completed appointments")
+	ErrTooManyProofAttachments = errors.New("a proof of delivery can have at most 20 attachments")
+)
+
+// RecordProofOfDelivery attaches the receiver's name, signature and signed documents or photos to
+// an appointment completed without them, and sends the supplier the links
+func (s *appointmentService) RecordProofOfDelivery(id uint, proof *models.ProofOfDelivery) (*models.ProofOfDelivery, error) {
+	appointment, err := s.appointmentRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if appointment.Status != models.StatusCompleted && appointment.Status != models.StatusPartiallyCompleted {
+		return nil, ErrProofRequiresCompletion
+	}
+	if _, err := s.proofRepo.FindByAppointment(id); err == nil {
+		return nil, ErrProofOfDeliveryExists
+	}
+
+	proof.AppointmentID = appointment.ID
+	if err := proof.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.proofRepo.Create(proof); err != nil {
+		return nil, err
+	}
+
+	s.notifyCompletion(appointment, proof)
+	return proof, nil
+}
+
+// GetProofOfDelivery returns the proof of delivery of an appointment
+func (s *appointmentService) GetProofOfDelivery(id uint) (*models.ProofOfDelivery, error) {
+	proof, err := s.proofRepo.FindByAppointment(id)
+	if err != nil {
+		return nil, ErrProofOfDeliveryNotFound
+	}
+	return proof, nil
+}
+
+// AddProofOfDeliveryAttachments adds documents or photos to the proof of delivery of an appointment
+func (s *appointmentService) AddProofOfDeliveryAttachments(id uint, attachments []models.ProofOfDeliveryAttachment) (*models.ProofOfDelivery, error) {
+	proof, err := s.GetProofOfDelivery(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(proof.Attachments)+len(attachments) > models.MaxProofOfDeliveryAttachments {
+		return nil, ErrTooManyProofAttachments
+	}
+
+	for i := range attachments {
+		if err := attachments[i].Validate(); err != nil {
+			return nil, err
+		}
+		attachments[i].ProofOfDeliveryID = proof.ID
+	}
+	if err := s.proofRepo.AddAttachments(attachments); err != nil {
+		return nil, err
+	}
+	return s.proofRepo.FindByAppointment(id)
+}
+
+// notifyCompletion tells the supplier how much was received, with links to the proof of delivery
+// when there is one
+func (s *appointmentService) notifyCompletion(appointment *models.Appointment, proof *models.ProofOfDelivery) {
+	if s.notificationService == nil {
+		return
+	}
+
+	subject := fmt.Sprintf("%s %s completed", appointment.Type.Label(), appointment.Reference())
+	var body strings.Builder
+	fmt.Fprintf(&body, "%s %s was completed.", appointment.Type.Label(), appointment.Reference())
+	if appointment.ReceivedQuantity != nil {
+		fmt.Fprintf(&body, " Received %d of %d.", *appointment.ReceivedQuantity, appointment.QuantityToDeliver)
+	}
+
+	if proof != nil {
+		subject = fmt.Sprintf("Proof of delivery for %s %s", strings.ToLower(appointment.Type.Label()), appointment.Reference())
+
+		fallback := ""
+		if s.config != nil && s.config.Notification != nil {
+			fallback = s.config.Notification.LinkBaseURL
+		}
+		link := buildLink(portalURL(s.operationRepo, appointment.OperationID, fallback), "appointments", appointment.ID, "proof-of-delivery")
+
+		fmt.Fprintf(&body, " Received by %s.", proof.ReceiverName)
+		fmt.Fprintf(&body, "\n\nProof of delivery: %s", link)
+		if proof.SignatureURL != "" {
+			fmt.Fprintf(&body, "\nSignature: %s", proof.SignatureURL)
+		}
+		for _, attachment := range proof.Attachments {
+			label := attachment.Caption
+			if label == "" {
+				label = string(attachment.Kind)
+			}
+			fmt.Fprintf(&body, "\n%s: %s", label, attachment.FileURL)
+		}
+	}
+
+	notification := &models.Notification{
+		Type:          models.NotificationTypeEmail,
+		Status:        models.NotificationStatusPending,
+		Event:         models.EventAppointmentCompleted,
+		RecipientType: models.RecipientSupplier,
+		RecipientID:   appointment.SupplierID,
+		Subject:       subject,
+		Body:          body.String(),
+		AppointmentID: &appointment.ID,
+	}
+	if err := s.notificationService.EnqueueNotification(notification, QueueAppointmentNotifications, 2); err != nil {
+		log.Printf("Failed to enqueue completion notification for appointment %d: %v", appointment.ID, err)
+	}
+}
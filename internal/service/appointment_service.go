@@ -2,10 +2,15 @@ package service
 
 import (
 	"errors"
+	"net/http"
 	"time"
 
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
 	"github.com/bernardofernandezz/scheduling-api/internal/models"
 	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/circuitbreaker"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+	"github.com/bernardofernandezz/scheduling-api/pkg/payments"
 )
 
 // AppointmentService interface defines methods for appointment service
@@ -16,22 +21,123 @@ type AppointmentService interface {
 	Delete(id uint) error
 	List(filters repository.AppointmentFilters) ([]models.Appointment, int64, error)
 	UpdateStatus(id uint, status models.AppointmentStatus, reason string) error
+	ChangeStatus(id uint, status models.AppointmentStatus, reason string, actorID uint) (*models.Appointment, error)
+	RecordStatusChange(appointment *models.Appointment, from models.AppointmentStatus, change StatusChange)
+	RecordUpdate(previous, appointment *models.Appointment, change StatusChange)
+	GetStatusHistory(id uint) ([]models.AppointmentStatusEvent, error)
+	GetStatusTransitions(filters repository.DeliveryReportFilters) ([]repository.StatusTransitionRow, error)
+	GetDenials(filters repository.DenialFilters) ([]repository.DenialRow, error)
+	PruneDenials() (int64, error)
 	GetBySupplier(supplierID uint, filters repository.AppointmentFilters) ([]models.Appointment, int64, error)
 	GetByEmployee(employeeID uint, filters repository.AppointmentFilters) ([]models.Appointment, int64, error)
 	GetByOperation(operationID uint, filters repository.AppointmentFilters) ([]models.Appointment, int64, error)
 	GetByDateRange(start, end time.Time, filters repository.AppointmentFilters) ([]models.Appointment, int64, error)
 	GetUpcoming(limit int) ([]models.Appointment, error)
 	GetStatistics() (*repository.AppointmentStatistics, error)
+	Export(filters repository.AppointmentFilters, fn func(appointments []models.Appointment) error) error
+	GetOperationUpcoming(operationID uint, limit int) (*OperationUpcoming, error)
+	GetOperationStatistics(operationID uint, days int) (*OperationStatistics, error)
 	CheckAvailability(operationID, employeeID uint, start, end time.Time) (bool, error)
+	MissingSkills(employeeID, operationID uint, productID *uint) ([]string, error)
+	Complete(id uint, receivedQuantity int, completedByID uint, proof *models.ProofOfDelivery) (*models.Appointment, *models.Appointment, error)
+	RecordProofOfDelivery(id uint, proof *models.ProofOfDelivery) (*models.ProofOfDelivery, error)
+	GetProofOfDelivery(id uint) (*models.ProofOfDelivery, error)
+	AddProofOfDeliveryAttachments(id uint, attachments []models.ProofOfDeliveryAttachment) (*models.ProofOfDelivery, error)
+	GetDeliveryReport(filters repository.DeliveryReportFilters) ([]repository.DeliveryReportRow, error)
+	GetTypeCapacities(operationID uint) ([]models.AppointmentTypeCapacity, error)
+	SetTypeCapacities(operationID uint, capacities []models.AppointmentTypeCapacity) error
+	LinkInbound(pickupID, inboundID uint) (*models.Appointment, error)
+	UnlinkInbound(pickupID uint) error
+	GetLinkedPickups(inboundID uint) ([]models.Appointment, error)
+	PropagateToLinked(previous, current *models.Appointment) error
+	DeclareDelay(id uint, declaration DelayDeclaration) (*DelayResult, error)
+	GetDelays(id uint) ([]models.AppointmentDelay, error)
+	SubmitFeedback(id uint, submission FeedbackSubmission) (*models.AppointmentFeedback, error)
+	GetFeedback(id uint) ([]models.AppointmentFeedback, error)
+	GetFeedbackScores(filters repository.FeedbackFilters) ([]repository.OperationFeedbackScore, error)
+	ReportIncident(id uint, incident *models.AppointmentIncident) error
+	GetIncidents(id uint) ([]models.AppointmentIncident, error)
+	ResolveIncident(id, incidentID, resolvedByID uint, resolution string) (*models.AppointmentIncident, error)
+	AddIncidentAttachments(id, incidentID uint, attachments []models.IncidentAttachment) (*models.AppointmentIncident, error)
+	GetSupplierPerformance(filters repository.DeliveryReportFilters) ([]repository.SupplierPerformanceRow, error)
+	AutoCompleteOverdue() (*AutoCompleteResult, error)
+	UndoAutoComplete(id uint, actorID uint) (*models.Appointment, error)
+	ExpirePending() (*PendingExpiryResult, error)
+	GetByBookingCode(code string) (*models.Appointment, error)
+	CheckSupplierLimit(appointment *models.Appointment) (*SupplierLimitWarning, error)
+	CapacityWarnings(appointment *models.Appointment) ([]CapacityWarning, error)
+	SetBilling(id uint, costCenter, billingCode string) (*models.Appointment, error)
+	SetPartnerReference(bookingCode string, reference PartnerReference) (*models.Appointment, error)
+	GetDeposit(id uint) (*models.Deposit, error)
+	HandlePaymentEvent(header http.Header, body []byte) error
+	ExpireUnpaidDeposits() (*DepositExpiryResult, error)
 }
 
 // appointmentService implements AppointmentService interface
 type appointmentService struct {
-	appointmentRepo repository.AppointmentRepository
-	employeeRepo    repository.EmployeeRepository
-	supplierRepo    repository.SupplierRepository
-	operationRepo   repository.OperationRepository
-	productRepo     repository.ProductRepository
+	appointmentRepo     repository.AppointmentRepository
+	employeeRepo        repository.EmployeeRepository
+	supplierRepo        repository.SupplierRepository
+	operationRepo       repository.OperationRepository
+	productRepo         repository.ProductRepository
+	documentService     SupplierDocumentService
+	reportRepo          repository.DeliveryReportRepository
+	capacityRepo        repository.CapacityRepository
+	linkRepo            repository.AppointmentLinkRepository
+	delayRepo           repository.DelayRepository
+	feedbackRepo        repository.FeedbackRepository
+	incidentRepo        repository.IncidentRepository
+	proofRepo           repository.ProofOfDeliveryRepository
+	billingRepo         repository.BillingRepository
+	feeRepo             repository.FeeRepository
+	blackoutRepo        repository.BlackoutRepository
+	overdueRepo         repository.OverdueRepository
+	bookingCodeRepo     repository.BookingCodeRepository
+	statusEventRepo     repository.StatusEventRepository
+	denialRepo          repository.DenialRepository
+	skillRepo           repository.SkillRepository
+	depositRepo         repository.DepositRepository
+	notificationService NotificationService
+	cancellationService CancellationService
+	settingsService     SettingsService
+	paymentProvider     payments.Provider
+	breakers            *circuitbreaker.Registry
+	events              *AppointmentEvents
+	config              *config.Config
+	clock               clock.Clock
+}
+
+// AppointmentDeps are the collaborators of an appointment service beyond its core repositories.
+// The ones under "optional" may be left nil, which skips the checks and side effects that use
+// them; the others back a single feature and are only needed when it is used.
+type AppointmentDeps struct {
+	ReportRepo      repository.DeliveryReportRepository
+	LinkRepo        repository.AppointmentLinkRepository
+	DelayRepo       repository.DelayRepository
+	FeedbackRepo    repository.FeedbackRepository
+	IncidentRepo    repository.IncidentRepository
+	ProofRepo       repository.ProofOfDeliveryRepository
+	BillingRepo     repository.BillingRepository
+	OverdueRepo     repository.OverdueRepository
+	BookingCodeRepo repository.BookingCodeRepository
+	StatusEventRepo repository.StatusEventRepository
+
+	// Optional
+	ProductRepo         repository.ProductRepository
+	DocumentService     SupplierDocumentService
+	CapacityRepo        repository.CapacityRepository
+	FeeRepo             repository.FeeRepository
+	BlackoutRepo        repository.BlackoutRepository
+	DenialRepo          repository.DenialRepository
+	SkillRepo           repository.SkillRepository
+	DepositRepo         repository.DepositRepository
+	NotificationService NotificationService
+	CancellationService CancellationService
+	SettingsService     SettingsService
+	PaymentProvider     payments.Provider
+	Breakers            *circuitbreaker.Registry
+	Events              *AppointmentEvents
+	Config              *config.Config
 }
 
 // NewAppointmentService creates a new appointment service
@@ -40,19 +146,59 @@ func NewAppointmentService(
 	employeeRepo repository.EmployeeRepository,
 	supplierRepo repository.SupplierRepository,
 	operationRepo repository.OperationRepository,
-	productRepo repository.ProductRepository,
+	deps AppointmentDeps,
+	clock clock.Clock,
 ) AppointmentService {
 	return &appointmentService{
-		appointmentRepo: appointmentRepo,
-		employeeRepo:    employeeRepo,
-		supplierRepo:    supplierRepo,
-		operationRepo:   operationRepo,
-		productRepo:     productRepo,
+		appointmentRepo:     appointmentRepo,
+		employeeRepo:        employeeRepo,
+		supplierRepo:        supplierRepo,
+		operationRepo:       operationRepo,
+		productRepo:         deps.ProductRepo,
+		documentService:     deps.DocumentService,
+		reportRepo:          deps.ReportRepo,
+		capacityRepo:        deps.CapacityRepo,
+		linkRepo:            deps.LinkRepo,
+		delayRepo:           deps.DelayRepo,
+		feedbackRepo:        deps.FeedbackRepo,
+		incidentRepo:        deps.IncidentRepo,
+		proofRepo:           deps.ProofRepo,
+		billingRepo:         deps.BillingRepo,
+		feeRepo:             deps.FeeRepo,
+		blackoutRepo:        deps.BlackoutRepo,
+		overdueRepo:         deps.OverdueRepo,
+		bookingCodeRepo:     deps.BookingCodeRepo,
+		statusEventRepo:     deps.StatusEventRepo,
+		denialRepo:          deps.DenialRepo,
+		skillRepo:           deps.SkillRepo,
+		depositRepo:         deps.DepositRepo,
+		notificationService: deps.NotificationService,
+		cancellationService: deps.CancellationService,
+		settingsService:     deps.SettingsService,
+		paymentProvider:     deps.PaymentProvider,
+		breakers:            deps.Breakers,
+		events:              deps.Events,
+		config:              deps.Config,
+		clock:               clock,
 	}
 }
 
-// Create creates a new appointment
+// Create creates a new appointment. Bookings refused for lack of room are logged as denials.
 func (s *appointmentService) Create(appointment *models.Appointment) error {
+	if err := s.create(appointment); err != nil {
+		s.recordDenial(appointment, err)
+		return err
+	}
+	return nil
+}
+
+// create checks and creates a new appointment
+func (s *appointmentService) create(appointment *models.Appointment) error {
+	// Check the appointment is complete and in the future
+	if err := appointment.ValidateAt(s.clock.Now()); err != nil {
+		return err
+	}
+
 	// Check if supplier exists
 	_, err := s.supplierRepo.FindByID(appointment.SupplierID)
 	if err != nil {
@@ -72,23 +218,55 @@ func (s *appointmentService) Create(appointment *models.Appointment) error {
 	}
 
 	// Check if product exists
-	_, err = s.productRepo.FindByID(appointment.ProductID)
-	if err != nil {
-		return errors.New("invalid product: " + err.Error())
+	if appointment.ProductID != nil {
+		_, err = s.productRepo.FindByID(*appointment.ProductID)
+		if err != nil {
+			return errors.New("invalid product: " + err.Error())
+		}
+	}
+
+	// Check the cost center and billing code are on the admin-managed list
+	if err := checkBillingCodes(s.billingRepo, appointment, nil); err != nil {
+		return err
+	}
+
+	// Check the appointment type's validation and capacity rules
+	if err := s.checkTypeRules(appointment); err != nil {
+		return err
+	}
+
+	// Check the supplier stays within the operation's limit of simultaneous appointments
+	if err := s.checkSupplierLimit(appointment, operation); err != nil {
+		return err
+	}
+
+	// Check the employee holds the skills the operation and the product's category require
+	if err := s.checkSkills(appointment); err != nil {
+		return err
+	}
+
+	// Check a cross-dock pickup comes after the inbound it depends on
+	if appointment.LinkedInboundID != nil {
+		if err := s.checkInboundOrdering(appointment); err != nil {
+			return err
+		}
+	}
+
+	// Check the supplier holds the documents the operation requires
+	if s.documentService != nil {
+		if err := s.documentService.EnsureCanBook(appointment.SupplierID, appointment.OperationID, appointment.ScheduledStart); err != nil {
+			return err
+		}
+	}
+
+	// Check the operation isn't closed that day, e.g. for a public holiday
+	if err := s.checkBlackout(appointment, operation); err != nil {
+		return err
 	}
 
 	// Check if appointment is within operation hours
-	startTime := appointment.ScheduledStart
-	endTime := appointment.ScheduledEnd
-	
-	// Extract just the time portion for comparison
-	startTimeOfDay := time.Date(2000, 1, 1, startTime.Hour(), startTime.Minute(), 0, 0, startTime.Location())
-	endTimeOfDay := time.Date(2000, 1, 1, endTime.Hour(), endTime.Minute(), 0, 0, endTime.Location())
-	operationOpen := time.Date(2000, 1, 1, operation.OpeningHour.Hour(), operation.OpeningHour.Minute(), 0, 0, operation.OpeningHour.Location())
-	operationClose := time.Date(2000, 1, 1, operation.ClosingHour.Hour(), operation.ClosingHour.Minute(), 0, 0, operation.ClosingHour.Location())
-	
-	if startTimeOfDay.Before(operationOpen) || endTimeOfDay.After(operationClose) {
-		return errors.New("appointment must be within operation hours")
+	if err := checkOperationHours(appointment, operation); err != nil {
+		return err
 	}
 
 	// Set default status if not provided
@@ -96,8 +274,31 @@ func (s *appointmentService) Create(appointment *models.Appointment) error {
 		appointment.Status = models.StatusPending
 	}
 
-	// Create appointment
-	return s.appointmentRepo.Create(appointment)
+	// Number the appointment within its operation
+	if err := s.assignBookingCode(appointment, operation); err != nil {
+		return err
+	}
+
+	// Reserve the appointment until its deposit is paid at operations charging one
+	deposit, err := s.requestDeposit(appointment, operation)
+	if err != nil {
+		return err
+	}
+
+	// Create appointment; a booking that isn't saved leaves no payment open at the provider
+	if err := s.appointmentRepo.Create(appointment); err != nil {
+		if deposit != nil {
+			s.cancelPayment(deposit)
+		}
+		return err
+	}
+	if deposit != nil {
+		if err := s.saveDeposit(appointment, deposit); err != nil {
+			return err
+		}
+	}
+	s.publishCreated(appointment, StatusChange{Source: models.StatusSourceBooking})
+	return nil
 }
 
 // GetByID gets an appointment by ID
@@ -114,10 +315,15 @@ func (s *appointmentService) Update(appointment *models.Appointment) error {
 	}
 
 	// Check if status allows updates
-	if existing.Status == models.StatusCancelled || existing.Status == models.StatusCompleted {
+	if existing.Status.IsFinal() {
 		return errors.New("cannot update cancelled or completed appointments")
 	}
 
+	// Check changed cost center and billing codes are on the admin-managed list
+	if err := checkBillingCodes(s.billingRepo, appointment, existing); err != nil {
+		return err
+	}
+
 	// Check if supplier exists
 	_, err = s.supplierRepo.FindByID(appointment.SupplierID)
 	if err != nil {
@@ -137,7 +343,166 @@ func (s *appointmentService) Update(appointment *models.Appointment) error {
 	}
 
 	// Check if product exists
-	_, err = s.productRepo.FindByID(appointment.ProductID)
+	if appointment.ProductID != nil {
+		_, err = s.productRepo.FindByID(*appointment.ProductID)
+	}
 	if err != nil {
-		return errors.New("invali
+		return errors.New("invalid product: " + err.Error())
+	}
 
+	// Moving the appointment, or giving it to another employee, checks the new slot like a booking
+	moved := !existing.ScheduledStart.Equal(appointment.ScheduledStart) ||
+		!existing.ScheduledEnd.Equal(appointment.ScheduledEnd) ||
+		existing.OperationID != appointment.OperationID ||
+		existing.Type != appointment.Type
+	if moved {
+		if err := s.checkTypeRules(appointment); err != nil {
+			return err
+		}
+		if err := s.checkSupplierLimit(appointment, operation); err != nil {
+			return err
+		}
+		if err := s.checkBlackout(appointment, operation); err != nil {
+			return err
+		}
+		if err := checkOperationHours(appointment, operation); err != nil {
+			return err
+		}
+	}
+	if moved || existing.EmployeeID != appointment.EmployeeID || !sameProduct(existing.ProductID, appointment.ProductID) {
+		if err := s.checkSkills(appointment); err != nil {
+			return err
+		}
+	}
+
+	// Update appointment
+	return s.appointmentRepo.Update(appointment)
+}
+
+// Delete deletes an appointment
+func (s *appointmentService) Delete(id uint) error {
+	// Check if appointment exists
+	if _, err := s.appointmentRepo.FindByID(id); err != nil {
+		return err
+	}
+	return s.appointmentRepo.Delete(id)
+}
+
+// List lists appointments with filters
+func (s *appointmentService) List(filters repository.AppointmentFilters) ([]models.Appointment, int64, error) {
+	return s.appointmentRepo.List(filters)
+}
+
+// UpdateStatus moves an appointment to a new status without a user behind the change and
+// publishes the transition
+func (s *appointmentService) UpdateStatus(id uint, status models.AppointmentStatus, reason string) error {
+	appointment, err := s.appointmentRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	oldStatus := appointment.Status
+
+	if err := s.appointmentRepo.UpdateStatus(id, status, reason, s.clock.Now()); err != nil {
+		return err
+	}
+	updated, err := s.appointmentRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	s.RecordStatusChange(updated, oldStatus, StatusChange{Source: models.StatusSourceUser, Reason: reason})
+	return nil
+}
+
+// GetBySupplier gets the appointments of a supplier
+func (s *appointmentService) GetBySupplier(supplierID uint, filters repository.AppointmentFilters) ([]models.Appointment, int64, error) {
+	return s.appointmentRepo.FindBySupplier(supplierID, filters)
+}
+
+// GetByEmployee gets the appointments of an employee
+func (s *appointmentService) GetByEmployee(employeeID uint, filters repository.AppointmentFilters) ([]models.Appointment, int64, error) {
+	return s.appointmentRepo.FindByEmployee(employeeID, filters)
+}
+
+// GetByOperation gets the appointments of an operation
+func (s *appointmentService) GetByOperation(operationID uint, filters repository.AppointmentFilters) ([]models.Appointment, int64, error) {
+	return s.appointmentRepo.FindByOperation(operationID, filters)
+}
+
+// GetByDateRange gets the appointments starting within a date range
+func (s *appointmentService) GetByDateRange(start, end time.Time, filters repository.AppointmentFilters) ([]models.Appointment, int64, error) {
+	if end.Before(start) {
+		return nil, 0, errors.New("end date must be after start date")
+	}
+	return s.appointmentRepo.FindByDateRange(start, end, filters)
+}
+
+// GetUpcoming gets the next appointments that aren't cancelled
+func (s *appointmentService) GetUpcoming(limit int) ([]models.Appointment, error) {
+	return s.appointmentRepo.FindUpcoming(s.clock.Now(), limit)
+}
+
+// GetStatistics gets appointment statistics across all operations
+func (s *appointmentService) GetStatistics() (*repository.AppointmentStatistics, error) {
+	return s.appointmentRepo.GetStatistics(s.clock.Now())
+}
+
+// CheckAvailability checks whether an employee can take an appointment at an operation in a
+// time slot in the future: the operation is open and the employee has no other appointment then
+func (s *appointmentService) CheckAvailability(operationID, employeeID uint, start, end time.Time) (bool, error) {
+	if start.Before(s.clock.Now()) {
+		return false, errors.New("appointment must be scheduled for a future date")
+	}
+
+	// Check if operation exists
+	operation, err := s.operationRepo.FindByID(operationID)
+	if err != nil {
+		return false, errors.New("invalid operation: " + err.Error())
+	}
+
+	// Check if employee exists
+	if _, err := s.employeeRepo.FindByID(employeeID); err != nil {
+		return false, errors.New("invalid employee: " + err.Error())
+	}
+
+	probe := &models.Appointment{
+		OperationID:    operationID,
+		EmployeeID:     employeeID,
+		ScheduledStart: start,
+		ScheduledEnd:   end,
+	}
+	if checkOperationHours(probe, operation) != nil {
+		return false, nil
+	}
+	if err := s.checkBlackout(probe, operation); err != nil {
+		if errors.Is(err, ErrOperationClosed) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	conflict, err := s.appointmentRepo.HasConflict(probe)
+	if err != nil {
+		return false, err
+	}
+	return !conflict, nil
+}
+
+// checkOperationHours refuses appointments outside the operation's opening hours. Opening and
+// closing times are "HH:MM", so the times of day compare as strings.
+func checkOperationHours(appointment *models.Appointment, operation *models.Operation) error {
+	startTimeOfDay := appointment.ScheduledStart.Format("15:04")
+	endTimeOfDay := appointment.ScheduledEnd.Format("15:04")
+
+	if startTimeOfDay < operation.OpeningTime || endTimeOfDay > operation.ClosingTime {
+		return errors.New("appointment must be within operation hours")
+	}
+	return nil
+}
+
+// sameProduct reports whether two optional product IDs are the same
+func sameProduct(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
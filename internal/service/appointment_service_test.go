@@ -0,0 +1,239 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/mocks/repository"
+	mockservice "github.com/bernardofernandezz/scheduling-api/internal/mocks/service"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// bookingNow is the instant the appointment service tests run at, a Monday morning
+var bookingNow = time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)
+
+// appointmentMocks are the repositories and services an appointment service under test uses
+type appointmentMocks struct {
+	appointments *repository.AppointmentRepository
+	employees    *repository.EmployeeRepository
+	suppliers    *repository.SupplierRepository
+	operations   *repository.OperationRepository
+	bookingCodes *repository.BookingCodeRepository
+	denials      *repository.DenialRepository
+	overdue      *repository.OverdueRepository
+	notify       *mockservice.NotificationService
+}
+
+// newAppointmentService creates an appointment service backed by mocks at bookingNow. Optional
+// checks, such as capacity and blackouts, have no repository and are skipped.
+func newAppointmentService(t *testing.T, events *service.AppointmentEvents) (service.AppointmentService, *appointmentMocks) {
+	m := &appointmentMocks{
+		appointments: repository.NewAppointmentRepository(t),
+		employees:    repository.NewEmployeeRepository(t),
+		suppliers:    repository.NewSupplierRepository(t),
+		operations:   repository.NewOperationRepository(t),
+		bookingCodes: repository.NewBookingCodeRepository(t),
+		denials:      repository.NewDenialRepository(t),
+		overdue:      repository.NewOverdueRepository(t),
+		notify:       mockservice.NewNotificationService(t),
+	}
+	s := service.NewAppointmentService(m.appointments, m.employees, m.suppliers, m.operations,
+		service.AppointmentDeps{
+			OverdueRepo:         m.overdue,
+			BookingCodeRepo:     m.bookingCodes,
+			DenialRepo:          m.denials,
+			NotificationService: m.notify,
+			Events:              events,
+		},
+		clock.NewFrozen(bookingNow),
+	)
+	return s, m
+}
+
+// testOperation is an operation open from 06:00 to 22:00 UTC
+func testOperation() *models.Operation {
+	operation := &models.Operation{Code: "GRU", Name: "Guarulhos DC", OpeningTime: "06:00", ClosingTime: "22:00"}
+	operation.ID = 3
+	return operation
+}
+
+// serviceVisit returns a service visit at the test operation starting at start
+func serviceVisit(start time.Time) *models.Appointment {
+	return &models.Appointment{
+		SupplierID:     1,
+		EmployeeID:     2,
+		OperationID:    3,
+		Type:           models.AppointmentTypeServiceVisit,
+		ScheduledStart: start,
+		ScheduledEnd:   start.Add(time.Hour),
+	}
+}
+
+// expectBookingChecks sets up the lookups every booking makes before it is saved
+func (m *appointmentMocks) expectBookingChecks() {
+	m.suppliers.EXPECT().FindByID(uint(1)).Return(&models.Supplier{}, nil)
+	m.employees.EXPECT().FindByID(uint(2)).Return(&models.Employee{}, nil)
+	m.operations.EXPECT().FindByID(uint(3)).Return(testOperation(), nil)
+}
+
+func TestCreateSavesBookingWithBookingCode(t *testing.T) {
+	s, m := newAppointmentService(t, nil)
+	m.expectBookingChecks()
+	m.bookingCodes.EXPECT().NextNumber(uint(3), 2024, bookingNow).Return(42, nil)
+	m.appointments.EXPECT().Create(mock.Anything).Return(nil)
+
+	appointment := serviceVisit(bookingNow.Add(25 * time.Hour))
+	require.NoError(t, s.Create(appointment))
+
+	assert.Equal(t, models.StatusPending, appointment.Status)
+	assert.Equal(t, models.FormatBookingCode("GRU", 2024, 42), appointment.BookingCode)
+}
+
+func TestCreateRecordsConflictAsDenial(t *testing.T) {
+	s, m := newAppointmentService(t, nil)
+	m.expectBookingChecks()
+	m.bookingCodes.EXPECT().NextNumber(uint(3), 2024, bookingNow).Return(43, nil)
+	conflict := errors.New("appointment conflicts with an existing appointment")
+	m.appointments.EXPECT().Create(mock.Anything).Return(conflict)
+
+	var denial *models.BookingDenial
+	m.denials.EXPECT().Create(mock.Anything).Run(func(d *models.BookingDenial) { denial = d }).Return(nil)
+
+	err := s.Create(serviceVisit(bookingNow.Add(25 * time.Hour)))
+	require.ErrorIs(t, err, conflict)
+	require.NotNil(t, denial)
+	assert.Equal(t, models.DenialConflict, denial.Reason)
+	assert.Equal(t, 10, denial.Hour)
+	assert.Equal(t, bookingNow, denial.CreatedAt)
+}
+
+func TestCreateRefusesBookingOutsideOperationHours(t *testing.T) {
+	s, m := newAppointmentService(t, nil)
+	m.expectBookingChecks()
+	m.denials.EXPECT().Create(mock.Anything).Return(nil)
+
+	start := time.Date(2024, time.March, 5, 21, 30, 0, 0, time.UTC)
+	err := s.Create(serviceVisit(start))
+	require.EqualError(t, err, "appointment must be within operation hours")
+	m.appointments.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestCheckAvailabilityReportsConflict(t *testing.T) {
+	s, m := newAppointmentService(t, nil)
+	m.operations.EXPECT().FindByID(uint(3)).Return(testOperation(), nil)
+	m.employees.EXPECT().FindByID(uint(2)).Return(&models.Employee{}, nil)
+	m.appointments.EXPECT().HasConflict(mock.Anything).Return(true, nil)
+
+	start := bookingNow.Add(25 * time.Hour)
+	available, err := s.CheckAvailability(3, 2, start, start.Add(time.Hour))
+	require.NoError(t, err)
+	assert.False(t, available)
+}
+
+func TestChangeStatusPublishesTransition(t *testing.T) {
+	events := service.NewAppointmentEvents()
+	var published []service.AppointmentEvent
+	events.Subscribe("test", func(event service.AppointmentEvent) error {
+		published = append(published, event)
+		return nil
+	})
+	s, m := newAppointmentService(t, events)
+
+	pending := serviceVisit(bookingNow.Add(25 * time.Hour))
+	pending.ID = 7
+	pending.Status = models.StatusPending
+	confirmed := *pending
+	confirmed.Status = models.StatusConfirmed
+	m.appointments.EXPECT().FindByID(uint(7)).Return(pending, nil).Once()
+	m.appointments.EXPECT().UpdateStatus(uint(7), models.StatusConfirmed, "dock ready", bookingNow).Return(nil)
+	m.appointments.EXPECT().FindByID(uint(7)).Return(&confirmed, nil).Once()
+
+	updated, err := s.ChangeStatus(7, models.StatusConfirmed, "dock ready", 9)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusConfirmed, updated.Status)
+
+	require.Len(t, published, 1)
+	event := published[0]
+	assert.Equal(t, service.AppointmentEventStatusChanged, event.Type)
+	assert.Equal(t, models.StatusPending, event.PreviousStatus)
+	assert.Equal(t, "dock ready", event.Change.Reason)
+	require.NotNil(t, event.Change.ActorID)
+	assert.Equal(t, uint(9), *event.Change.ActorID)
+	assert.Equal(t, bookingNow, event.OccurredAt)
+}
+
+func TestChangeStatusToSameStatusPublishesNothing(t *testing.T) {
+	events := service.NewAppointmentEvents()
+	published := 0
+	events.Subscribe("test", func(service.AppointmentEvent) error {
+		published++
+		return nil
+	})
+	s, m := newAppointmentService(t, events)
+
+	confirmed := serviceVisit(bookingNow.Add(25 * time.Hour))
+	confirmed.ID = 7
+	confirmed.Status = models.StatusConfirmed
+	m.appointments.EXPECT().FindByID(uint(7)).Return(confirmed, nil)
+	m.appointments.EXPECT().UpdateStatus(uint(7), models.StatusConfirmed, "", bookingNow).Return(nil)
+
+	_, err := s.ChangeStatus(7, models.StatusConfirmed, "", 9)
+	require.NoError(t, err)
+	assert.Zero(t, published)
+}
+
+func TestChangeStatusKeepsStatusWhenSaveFails(t *testing.T) {
+	events := service.NewAppointmentEvents()
+	published := 0
+	events.Subscribe("test", func(service.AppointmentEvent) error {
+		published++
+		return nil
+	})
+	s, m := newAppointmentService(t, events)
+
+	pending := serviceVisit(bookingNow.Add(25 * time.Hour))
+	pending.ID = 7
+	pending.Status = models.StatusPending
+	saveErr := errors.New("connection reset")
+	m.appointments.EXPECT().FindByID(uint(7)).Return(pending, nil)
+	m.appointments.EXPECT().UpdateStatus(uint(7), models.StatusCancelled, "", bookingNow).Return(saveErr)
+
+	_, err := s.ChangeStatus(7, models.StatusCancelled, "", 9)
+	require.ErrorIs(t, err, saveErr)
+	assert.Zero(t, published)
+}
+
+func TestNoShowRefusedBeforeScheduledStart(t *testing.T) {
+	s, m := newAppointmentService(t, nil)
+
+	confirmed := serviceVisit(bookingNow.Add(time.Minute))
+	confirmed.ID = 7
+	confirmed.Status = models.StatusConfirmed
+	m.appointments.EXPECT().FindByID(uint(7)).Return(confirmed, nil)
+
+	_, err := s.ChangeStatus(7, models.StatusNoShow, "", 9)
+	require.ErrorIs(t, err, service.ErrNoShowTooEarly)
+}
+
+func TestNoShowMarkedOnceScheduledStartHasPassed(t *testing.T) {
+	s, m := newAppointmentService(t, nil)
+
+	confirmed := serviceVisit(bookingNow)
+	confirmed.ID = 7
+	confirmed.Status = models.StatusConfirmed
+	noShow := *confirmed
+	noShow.Status = models.StatusNoShow
+	m.appointments.EXPECT().FindByID(uint(7)).Return(confirmed, nil).Once()
+	m.appointments.EXPECT().UpdateStatus(uint(7), models.StatusNoShow, "", bookingNow).Return(nil)
+	m.appointments.EXPECT().FindByID(uint(7)).Return(&noShow, nil).Once()
+
+	updated, err := s.ChangeStatus(7, models.StatusNoShow, "", 9)
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusNoShow, updated.Status)
+}
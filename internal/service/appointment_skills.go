@@ -0,0 +1,31 @@
+package service
+
+import (
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+)
+
+// MissingSkills returns the skills an appointment at an operation for a product, if any,
+// requires that an employee doesn't hold; none means the employee is qualified
+func (s *appointmentService) MissingSkills(employeeID, operationID uint, productID *uint) ([]string, error) {
+	if s.skillRepo == nil {
+		return nil, nil
+	}
+	required, err := requiredSkills(s.skillRepo, s.productRepo, operationID, productID)
+	if err != nil {
+		return nil, err
+	}
+	return missingSkills(s.skillRepo, employeeID, required)
+}
+
+// checkSkills refuses appointments booked with an employee who lacks a skill the operation or the
+// product's category requires, such as a forklift certification
+func (s *appointmentService) checkSkills(appointment *models.Appointment) error {
+	missing, err := s.MissingSkills(appointment.EmployeeID, appointment.OperationID, appointment.ProductID)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return unqualifiedError(missing)
+	}
+	return nil
+}
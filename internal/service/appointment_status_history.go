@@ -0,0 +1,78 @@
+package service
+
+import (
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+)
+
+// StatusChange describes who changed an appointment's status and why
+type StatusChange struct {
+	ActorID *uint // nil for automatic changes
+	Source  string
+	Reason  string
+}
+
+// ChangeStatus moves an appointment to a new status on behalf of a user and publishes the
+// transition
+func (s *appointmentService) ChangeStatus(id uint, status models.AppointmentStatus, reason string, actorID uint) (*models.Appointment, error) {
+	appointment, err := s.appointmentRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	oldStatus := appointment.Status
+	if status == models.StatusNoShow && oldStatus != status {
+		if err := s.checkNoShow(appointment); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.appointmentRepo.UpdateStatus(id, status, reason, s.clock.Now()); err != nil {
+		return nil, err
+	}
+	updated, err := s.appointmentRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.RecordStatusChange(updated, oldStatus, StatusChange{ActorID: &actorID, Source: models.StatusSourceUser, Reason: reason})
+	if status == models.StatusNoShow && oldStatus != status {
+		s.chargeNoShowFee(updated)
+	}
+	// Cancelling a reserved appointment, or confirming it without the payment, voids its deposit
+	if oldStatus == models.StatusReserved && oldStatus != status {
+		s.releaseDeposit(updated)
+	}
+	// Runs after the cancellation notice is queued, so the notice is the one notification kept
+	if status == models.StatusCancelled && oldStatus != status {
+		s.cascadeCancellation(updated)
+	}
+	return updated, nil
+}
+
+// RecordStatusChange publishes the change of an appointment's status, which the subscribers add
+// to its history and tell the participants about. Nothing is published when the status did not
+// change.
+func (s *appointmentService) RecordStatusChange(appointment *models.Appointment, from models.AppointmentStatus, change StatusChange) {
+	if appointment.Status == from {
+		return
+	}
+	s.publish(AppointmentEvent{
+		Type:           AppointmentEventStatusChanged,
+		Appointment:    appointment,
+		PreviousStatus: from,
+		Change:         change,
+	})
+}
+
+// GetStatusHistory returns every status transition of an appointment, oldest first
+func (s *appointmentService) GetStatusHistory(id uint) ([]models.AppointmentStatusEvent, error) {
+	if _, err := s.appointmentRepo.FindByID(id); err != nil {
+		return nil, err
+	}
+	return s.statusEventRepo.FindByAppointment(id)
+}
+
+// GetStatusTransitions counts the status transitions made in a period
+func (s *appointmentService) GetStatusTransitions(filters repository.DeliveryReportFilters) ([]repository.StatusTransitionRow, error) {
+	return s.statusEventRepo.TransitionCounts(filters)
+}
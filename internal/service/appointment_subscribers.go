@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+)
+
+// calendarSyncTimeout bounds the sync of one appointment to an external calendar
+const calendarSyncTimeout = 30 * time.Second
+
+// selfNotifyingSources are the status changes that send their own notice, e.g. with the quantity
+// received or the deadline to undo an automatic completion, rather than the generic one
+var selfNotifyingSources = map[string]bool{
+	models.StatusSourceCompletion:   true,
+	models.StatusSourceAutoComplete: true,
+}
+
+// RecordStatusHistory subscribes the status history: each booking and status change is added to
+// the appointment's history with who made it and why. Subscribe it to created and status_changed.
+func RecordStatusHistory(statusEventRepo repository.StatusEventRepository) AppointmentEventHandler {
+	return func(event AppointmentEvent) error {
+		return statusEventRepo.Create(&models.AppointmentStatusEvent{
+			AppointmentID: event.Appointment.ID,
+			FromStatus:    event.PreviousStatus,
+			ToStatus:      event.Appointment.Status,
+			ActorID:       event.Change.ActorID,
+			Source:        event.Change.Source,
+			Reason:        event.Change.Reason,
+			OccurredAt:    event.OccurredAt,
+		})
+	}
+}
+
+// NotifyParticipants subscribes the notifications telling the supplier and the employee their
+// appointment was booked, updated or changed status
+func NotifyParticipants(notificationService NotificationService) AppointmentEventHandler {
+	return func(event AppointmentEvent) error {
+		switch event.Type {
+		case AppointmentEventCreated:
+			return notificationService.NotifyAppointmentCreated(event.Appointment)
+		case AppointmentEventUpdated:
+			return notificationService.NotifyAppointmentUpdated(event.Appointment, event.Changes)
+		case AppointmentEventStatusChanged:
+			if selfNotifyingSources[event.Change.Source] {
+				return nil
+			}
+			return notificationService.NotifyAppointmentStatusChanged(event.Appointment, event.PreviousStatus)
+		}
+		return nil
+	}
+}
+
+// SyncEmployeeCalendar subscribes the external calendar of the employee an appointment is booked
+// with: open appointments are synced to it, and moved out of the previous employee's calendar
+// when the appointment changes hands. Cancellations remove the synced event as part of their
+// cascade. The sync calls the calendar provider, so it runs in the background.
+func SyncEmployeeCalendar(calendarService CalendarService, employeeRepo repository.EmployeeRepository) AppointmentEventHandler {
+	return func(event AppointmentEvent) error {
+		if event.Appointment.Status.IsFinal() {
+			return nil
+		}
+		userID, enabled, err := calendarSyncUser(calendarService, employeeRepo, event.Appointment.EmployeeID)
+		if err != nil || !enabled {
+			return err
+		}
+		var previousUserID uint
+		if event.Previous != nil && event.Previous.EmployeeID != event.Appointment.EmployeeID {
+			if id, enabled, err := calendarSyncUser(calendarService, employeeRepo, event.Previous.EmployeeID); err == nil && enabled {
+				previousUserID = id
+			}
+		}
+
+		// The publisher may change the appointment once this returns
+		appointment := *event.Appointment
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), calendarSyncTimeout)
+			defer cancel()
+
+			if previousUserID != 0 {
+				if err := calendarService.RemoveAppointmentFromCalendar(ctx, &appointment, previousUserID, GoogleCalendar); err != nil {
+					log.Printf("Failed to remove appointment %d from the calendar of its previous employee: %v", appointment.ID, err)
+				}
+			}
+			if _, err := calendarService.SyncAppointmentToCalendar(ctx, &appointment, userID, GoogleCalendar); err != nil && !errors.Is(err, ErrCalendarSyncSkipped) {
+				log.Printf("Failed to sync appointment %d to the calendar of user %d: %v", appointment.ID, userID, err)
+			}
+		}()
+		return nil
+	}
+}
+
+// calendarSyncUser returns the user of an employee and whether they sync their Google Calendar
+func calendarSyncUser(calendarService CalendarService, employeeRepo repository.EmployeeRepository, employeeID uint) (uint, bool, error) {
+	employee, err := employeeRepo.FindByID(employeeID)
+	if err != nil {
+		return 0, false, err
+	}
+	if employee.UserID == 0 {
+		return 0, false, nil
+	}
+	preferences, err := calendarService.GetUserCalendarPreferences(employee.UserID)
+	if err != nil {
+		return 0, false, err
+	}
+	enabled, _ := preferences[string(GoogleCalendar)+"_enabled"].(bool)
+	return employee.UserID, enabled, nil
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+)
+
+// ErrSupplierLimitReached is returned when a supplier already holds as many overlapping
+// appointments at an operation as it allows
+var ErrSupplierLimitReached = errors.New("supplier limit of simultaneous appointments reached at this operation")
+
+// SupplierLimitWarning reports a booking that takes its supplier over the operation's limit of
+// simultaneous appointments
+type SupplierLimitWarning struct {
+	OperationID uint   `json:"operation_id"`
+	SupplierID  uint   `json:"supplier_id"`
+	Limit       int    `json:"limit"`
+	Overlapping int    `json:"overlapping"` // the supplier's other appointments overlapping this one
+	Message     string `json:"message"`
+}
+
+// checkSupplierLimit enforces the operation's limit on simultaneous appointments per supplier.
+// Operations in warn mode accept the booking; CheckSupplierLimit then reports it.
+func (s *appointmentService) checkSupplierLimit(appointment *models.Appointment, operation *models.Operation) error {
+	warning, err := s.supplierLimitWarning(appointment, operation)
+	if err != nil || warning == nil {
+		return err
+	}
+	if operation.SupplierLimitMode == models.SupplierLimitWarn {
+		return nil
+	}
+	return fmt.Errorf("%w: at most %d at the same time", ErrSupplierLimitReached, warning.Limit)
+}
+
+// CheckSupplierLimit reports whether an appointment takes its supplier over the operation's
+// limit of simultaneous appointments, or nil when it is within the limit
+func (s *appointmentService) CheckSupplierLimit(appointment *models.Appointment) (*SupplierLimitWarning, error) {
+	operation, err := s.operationRepo.FindByID(appointment.OperationID)
+	if err != nil {
+		return nil, err
+	}
+	return s.supplierLimitWarning(appointment, operation)
+}
+
+// supplierLimitWarning counts the supplier's other appointments overlapping this one and returns
+// a warning when they already fill the operation's limit
+func (s *appointmentService) supplierLimitWarning(appointment *models.Appointment, operation *models.Operation) (*SupplierLimitWarning, error) {
+	limit := operation.MaxConcurrentPerSupplier
+	if limit <= 0 || s.capacityRepo == nil {
+		return nil, nil
+	}
+
+	count, err := s.capacityRepo.CountSupplierOverlapping(appointment.OperationID, appointment.SupplierID,
+		appointment.ScheduledStart, appointment.ScheduledEnd, appointment.ID)
+	if err != nil {
+		return nil, err
+	}
+	if int(count) < limit {
+		return nil, nil
+	}
+
+	return &SupplierLimitWarning{
+		OperationID: appointment.OperationID,
+		SupplierID:  appointment.SupplierID,
+		Limit:       limit,
+		Overlapping: int(count),
+		Message: fmt.Sprintf("The supplier already has %d overlapping appointments at this operation, which allows %d at the same time",
+			count, limit),
+	}, nil
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+)
+
+// maxStatisticsDays is the longest period of daily operation statistics computed at once
+const maxStatisticsDays = 92
+
+// ErrInvalidStatisticsDays is returned for a period outside 1 to maxStatisticsDays days
+var ErrInvalidStatisticsDays = errors.New("days must be between 1 and 92")
+
+// OperationUpcoming is an operation's next appointments, with how many of them are still to
+// come today at the operation
+type OperationUpcoming struct {
+	Today        OperationDay         `json:"today"`
+	TodayCount   int                  `json:"today_count"`
+	Appointments []models.Appointment `json:"appointments"`
+}
+
+// OperationStatistics counts an operation's appointments over its last days, by day and status.
+// Days are counted in the operation's timezone and end with today.
+type OperationStatistics struct {
+	Today         OperationDay                       `json:"today"`
+	From          string                             `json:"from"` // first day counted, "YYYY-MM-DD"
+	Total         int64                              `json:"total"`
+	ByStatus      map[models.AppointmentStatus]int64 `json:"by_status"`
+	ByDay         map[string]int64                   `json:"by_day"` // keyed by "YYYY-MM-DD", every day listed
+	TodayByStatus map[models.AppointmentStatus]int64 `json:"today_by_status"`
+}
+
+// GetOperationUpcoming returns an operation's next appointments that aren't cancelled
+func (s *appointmentService) GetOperationUpcoming(operationID uint, limit int) (*OperationUpcoming, error) {
+	operation, err := s.operationRepo.FindByID(operationID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	appointments, _, err := s.appointmentRepo.FindByOperation(operationID, repository.AppointmentFilters{
+		StartDate: &now,
+		Page:      1,
+		Limit:     limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	upcoming := &OperationUpcoming{
+		Today:        operationToday(operation, s.clock),
+		Appointments: make([]models.Appointment, 0, len(appointments)),
+	}
+	for _, appointment := range appointments {
+		if appointment.Status == models.StatusCancelled {
+			continue
+		}
+		if upcoming.Today.Contains(appointment.ScheduledStart) {
+			upcoming.TodayCount++
+		}
+		upcoming.Appointments = append(upcoming.Appointments, appointment)
+	}
+	return upcoming, nil
+}
+
+// GetOperationStatistics counts the appointments starting on an operation's last days, today
+// included
+func (s *appointmentService) GetOperationStatistics(operationID uint, days int) (*OperationStatistics, error) {
+	if days < 1 || days > maxStatisticsDays {
+		return nil, ErrInvalidStatisticsDays
+	}
+	operation, err := s.operationRepo.FindByID(operationID)
+	if err != nil {
+		return nil, err
+	}
+
+	today := operationToday(operation, s.clock)
+	first := operationDayAt(operation, today.Start.AddDate(0, 0, -(days-1)))
+	// Listings match appointments ending by EndDate; allow a day for ones running past midnight
+	until := today.End.AddDate(0, 0, 1)
+	appointments, _, err := s.appointmentRepo.FindByOperation(operationID, repository.AppointmentFilters{
+		StartDate: &first.Start,
+		EndDate:   &until,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	statistics := &OperationStatistics{
+		Today:         today,
+		From:          first.Date,
+		ByStatus:      make(map[models.AppointmentStatus]int64),
+		ByDay:         make(map[string]int64, days),
+		TodayByStatus: make(map[models.AppointmentStatus]int64),
+	}
+	for day := first; !day.Start.After(today.Start); day = operationDayAt(operation, day.End) {
+		statistics.ByDay[day.Date] = 0
+	}
+	for _, appointment := range appointments {
+		if !appointment.ScheduledStart.Before(today.End) {
+			continue
+		}
+		statistics.Total++
+		statistics.ByStatus[appointment.Status]++
+		statistics.ByDay[operationDayAt(operation, appointment.ScheduledStart).Date]++
+		if today.Contains(appointment.ScheduledStart) {
+			statistics.TodayByStatus[appointment.Status]++
+		}
+	}
+	return statistics, nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+)
+
+// ErrTypeCapacityReached is returned when an operation has no room left for an appointment type
+var ErrTypeCapacityReached = errors.New("operation capacity reached for this appointment type")
+
+// checkTypeRules enforces the operation's capacity rules for the appointment's type and the
+// capacity bands set on its date
+func (s *appointmentService) checkTypeRules(appointment *models.Appointment) error {
+	if appointment.Type == "" {
+		appointment.Type = models.AppointmentTypeDelivery
+	}
+	if !appointment.Type.IsValid() {
+		return fmt.Errorf("invalid appointment type %q", appointment.Type)
+	}
+	if !appointment.Type.MovesGoods() && appointment.QuantityToDeliver != 0 {
+		return fmt.Errorf("%s appointments cannot carry a quantity", appointment.Type.Label())
+	}
+	if s.capacityRepo == nil {
+		return nil
+	}
+
+	overridden, err := s.checkCapacityBands(appointment)
+	if err != nil {
+		return err
+	}
+
+	capacity, err := s.capacityRepo.FindByOperationAndType(appointment.OperationID, appointment.Type)
+	if err != nil {
+		return err
+	}
+	if capacity == nil {
+		return nil
+	}
+
+	if capacity.MaxConcurrent > 0 && !overridden {
+		count, err := s.capacityRepo.CountOverlapping(appointment.OperationID, appointment.Type,
+			appointment.ScheduledStart, appointment.ScheduledEnd, appointment.ID)
+		if err != nil {
+			return err
+		}
+		if int(count) >= capacity.MaxConcurrent {
+			return fmt.Errorf("%w: at most %d at the same time", ErrTypeCapacityReached, capacity.MaxConcurrent)
+		}
+	}
+
+	if capacity.MaxPerDay > 0 {
+		// The day is the operation's, whatever offset the appointment was booked with
+		operation, err := s.operationRepo.FindByID(appointment.OperationID)
+		if err != nil {
+			return errors.New("invalid operation: " + err.Error())
+		}
+		day := operationDayAt(operation, appointment.ScheduledStart)
+		count, err := s.capacityRepo.CountOverlapping(appointment.OperationID, appointment.Type,
+			day.Start, day.End, appointment.ID)
+		if err != nil {
+			return err
+		}
+		if int(count) >= capacity.MaxPerDay {
+			return fmt.Errorf("%w: at most %d per day", ErrTypeCapacityReached, capacity.MaxPerDay)
+		}
+	}
+
+	return nil
+}
+
+// GetTypeCapacities gets the appointment type capacity rules of an operation
+func (s *appointmentService) GetTypeCapacities(operationID uint) ([]models.AppointmentTypeCapacity, error) {
+	return s.capacityRepo.FindByOperation(operationID)
+}
+
+// SetTypeCapacities replaces the appointment type capacity rules of an operation
+func (s *appointmentService) SetTypeCapacities(operationID uint, capacities []models.AppointmentTypeCapacity) error {
+	if _, err := s.operationRepo.FindByID(operationID); err != nil {
+		return errors.New("invalid operation: " + err.Error())
+	}
+
+	seen := make(map[models.AppointmentType]bool)
+	for i := range capacities {
+		if err := capacities[i].Validate(); err != nil {
+			return err
+		}
+		if seen[capacities[i].Type] {
+			return fmt.Errorf("duplicate capacity rule for %q", capacities[i].Type)
+		}
+		seen[capacities[i].Type] = true
+	}
+	return s.capacityRepo.ReplaceForOperation(operationID, capacities)
+}
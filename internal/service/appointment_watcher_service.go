@@ -0,0 +1,107 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+)
+
+// Watcher errors
+var (
+	ErrWatcherNotFound = errors.New("watcher not found")
+	ErrAlreadyWatching = errors.New("the user is already watching this appointment")
+	ErrInactiveWatcher = errors.New("inactive users cannot watch appointments")
+)
+
+// WatcherService defines the interface for appointment watchers
+type WatcherService interface {
+	AddWatcher(watcher *models.AppointmentWatcher) error
+	ListWatchers(appointmentID uint) ([]models.AppointmentWatcher, error)
+	GetWatcher(appointmentID, watcherID uint) (*models.AppointmentWatcher, error)
+	UpdateChannels(watcher *models.AppointmentWatcher) error
+	RemoveWatcher(appointmentID, watcherID uint) error
+	IsWatching(appointmentID, userID uint) (bool, error)
+}
+
+// watcherService implements the WatcherService interface
+type watcherService struct {
+	watcherRepo repository.WatcherRepository
+	userRepo    repository.UserRepository
+}
+
+// NewWatcherService creates a new watcher service
+func NewWatcherService(
+	watcherRepo repository.WatcherRepository,
+	userRepo repository.UserRepository,
+) WatcherService {
+	return &watcherService{
+		watcherRepo: watcherRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// AddWatcher subscribes a user to the notifications of an appointment
+func (s *watcherService) AddWatcher(watcher *models.AppointmentWatcher) error {
+	if err := watcher.Validate(); err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetByID(watcher.UserID)
+	if err != nil {
+		return errors.New("invalid user: " + err.Error())
+	}
+	if !user.Active {
+		return ErrInactiveWatcher
+	}
+
+	existing, err := s.watcherRepo.FindByAppointmentAndUser(watcher.AppointmentID, watcher.UserID)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return ErrAlreadyWatching
+	}
+
+	if err := s.watcherRepo.Create(watcher); err != nil {
+		return err
+	}
+	watcher.User = user
+	return nil
+}
+
+// ListWatchers lists the watchers of an appointment
+func (s *watcherService) ListWatchers(appointmentID uint) ([]models.AppointmentWatcher, error) {
+	return s.watcherRepo.FindByAppointment(appointmentID)
+}
+
+// GetWatcher gets a watcher of an appointment
+func (s *watcherService) GetWatcher(appointmentID, watcherID uint) (*models.AppointmentWatcher, error) {
+	watcher, err := s.watcherRepo.FindByID(watcherID)
+	if err != nil || watcher.AppointmentID != appointmentID {
+		return nil, ErrWatcherNotFound
+	}
+	return watcher, nil
+}
+
+// UpdateChannels changes the channels a watcher is copied on
+func (s *watcherService) UpdateChannels(watcher *models.AppointmentWatcher) error {
+	if err := watcher.Validate(); err != nil {
+		return err
+	}
+	return s.watcherRepo.Update(watcher)
+}
+
+// RemoveWatcher unsubscribes a watcher from an appointment
+func (s *watcherService) RemoveWatcher(appointmentID, watcherID uint) error {
+	if _, err := s.GetWatcher(appointmentID, watcherID); err != nil {
+		return err
+	}
+	return s.watcherRepo.Delete(watcherID)
+}
+
+// IsWatching reports whether a user watches an appointment
+func (s *watcherService) IsWatching(appointmentID, userID uint) (bool, error) {
+	watcher, err := s.watcherRepo.FindByAppointmentAndUser(appointmentID, userID)
+	return watcher != nil, err
+}
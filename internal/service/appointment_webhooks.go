@@ -0,0 +1,131 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+	"github.com/bernardofernandezz/scheduling-api/pkg/events"
+)
+
+// PublishWebhooks subscribes the webhook: bookings, reschedules and status changes are sent to the
+// configured endpoint as CloudEvents following the published schemas. Each delivery is signed in
+// the X-Webhook-Signature header, "t=<unix time>,v1=<hex HMAC-SHA256 of t.body>", and sent in the
+// background, so a slow endpoint doesn't hold up bookings.
+func PublishWebhooks(catalog *events.Catalog, cfg config.WebhookConfig, schemaBaseURL string, httpClient *http.Client, clock clock.Clock) AppointmentEventHandler {
+	return func(event AppointmentEvent) error {
+		eventType, data, ok := cloudEventData(event)
+		if !ok {
+			return nil
+		}
+		cloudEvent, err := catalog.New(eventType, cfg.Source, event.Appointment.BookingCode, schemaBaseURL, event.OccurredAt, data)
+		if err != nil {
+			return err
+		}
+		body, err := json.Marshal(cloudEvent)
+		if err != nil {
+			return err
+		}
+
+		go func() {
+			if err := deliverWebhook(httpClient, cfg, body, clock.Now()); err != nil {
+				log.Printf("Failed to deliver %s event %s to the webhook: %v", cloudEvent.Type, cloudEvent.ID, err)
+			}
+		}()
+		return nil
+	}
+}
+
+// cloudEventData returns the CloudEvent type and data of an appointment event. Updates are only
+// published when they moved the appointment.
+func cloudEventData(event AppointmentEvent) (string, interface{}, bool) {
+	appointment := event.Appointment
+	switch event.Type {
+	case AppointmentEventCreated:
+		return events.AppointmentCreated, events.AppointmentCreatedData{
+			ID:                appointment.ID,
+			BookingCode:       appointment.BookingCode,
+			Type:              string(appointment.Type),
+			Status:            string(appointment.Status),
+			OperationID:       appointment.OperationID,
+			SupplierID:        appointment.SupplierID,
+			EmployeeID:        appointment.EmployeeID,
+			ProductID:         appointment.ProductID,
+			QuantityToDeliver: appointment.QuantityToDeliver,
+			ScheduledStart:    appointment.ScheduledStart,
+			ScheduledEnd:      appointment.ScheduledEnd,
+			ExternalRef:       appointment.ExternalRef,
+			PurchaseOrder:     appointment.PurchaseOrder,
+		}, true
+	case AppointmentEventStatusChanged:
+		return events.AppointmentStatusChanged, events.AppointmentStatusChangedData{
+			ID:               appointment.ID,
+			BookingCode:      appointment.BookingCode,
+			PreviousStatus:   string(event.PreviousStatus),
+			Status:           string(appointment.Status),
+			Reason:           event.Change.Reason,
+			ChangedAt:        event.OccurredAt,
+			ChangedByID:      event.Change.ActorID,
+			ReceivedQuantity: appointment.ReceivedQuantity,
+		}, true
+	case AppointmentEventUpdated:
+		previous := event.Previous
+		if previous == nil || (previous.ScheduledStart.Equal(appointment.ScheduledStart) && previous.ScheduledEnd.Equal(appointment.ScheduledEnd)) {
+			return "", nil, false
+		}
+		return events.AppointmentRescheduled, events.AppointmentRescheduledData{
+			ID:             appointment.ID,
+			BookingCode:    appointment.BookingCode,
+			PreviousStart:  previous.ScheduledStart,
+			PreviousEnd:    previous.ScheduledEnd,
+			ScheduledStart: appointment.ScheduledStart,
+			ScheduledEnd:   appointment.ScheduledEnd,
+			Reason:         event.Change.Reason,
+		}, true
+	}
+	return "", nil, false
+}
+
+// deliverWebhook posts a structured mode CloudEvent to the webhook endpoint
+func deliverWebhook(httpClient *http.Client, cfg config.WebhookConfig, body []byte, now time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/cloudevents+json")
+	if cfg.Secret != "" {
+		req.Header.Set("X-Webhook-Signature", signWebhook(cfg.Secret, body, now))
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("endpoint answered %s", resp.Status)
+	}
+	return nil
+}
+
+// signWebhook signs a webhook body with the time it is sent, so receivers can refuse replays
+func signWebhook(secret string, body []byte, now time.Time) string {
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,325 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+)
+
+// Availability errors
+var (
+	ErrExceptionNotFound = errors.New("availability exception not found")
+	ErrInvalidSlotRange  = errors.New("end date must be after start date")
+	ErrSlotRangeTooLong  = errors.New("the range can span at most 31 days")
+)
+
+// maxOpenSlotRange is the longest period open slots are computed for at once
+const maxOpenSlotRange = 31 * 24 * time.Hour
+
+// OpenSlot is a period in which an employee is available and has nothing booked
+type OpenSlot struct {
+	OperationID uint      `json:"operation_id"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+}
+
+// ExceptionReport is an availability exception with the booked appointments it conflicts with
+type ExceptionReport struct {
+	Exception *models.AvailabilityException `json:"exception"`
+	Conflicts []models.Appointment          `json:"conflicts"`
+}
+
+// AvailabilityService defines the interface for employee availability business logic
+type AvailabilityService interface {
+	GetEmployee(id uint) (*models.Employee, error)
+	CreateException(exception *models.AvailabilityException) (*ExceptionReport, error)
+	ListExceptions(employeeID uint) ([]models.AvailabilityException, error)
+	GetExceptionConflicts(employeeID, exceptionID uint) (*ExceptionReport, error)
+	DeleteException(employeeID, exceptionID uint) error
+	OpenSlots(employeeID uint, operationID *uint, from, to time.Time) ([]OpenSlot, error)
+	CalendarBusy(employeeID uint, start, end time.Time) ([]models.EmployeeBusyTime, error)
+	QualifiedEmployees(operationID uint, productID *uint) ([]uint, error)
+	AssignEmployee(operationID uint, productID *uint, start, end time.Time) (*models.Employee, error)
+	CopyWeeklyAvailability(sourceID uint, targetIDs []uint, operationID *uint, replace bool) (*AvailabilityBulkResult, error)
+	GetDefaultSchedule(operationID uint) ([]models.OperationDefaultSlot, error)
+	SetDefaultSchedule(operationID uint, slots []models.OperationDefaultSlot) ([]models.OperationDefaultSlot, error)
+	ApplyDefaultSchedule(operationID uint, employeeIDs []uint, replace bool) (*AvailabilityBulkResult, error)
+	ShiftSlots(operationID uint, minutes int, employeeIDs []uint) (int, error)
+}
+
+// availabilityService implements the AvailabilityService interface
+type availabilityService struct {
+	availabilityRepo repository.AvailabilityRepository
+	exceptionRepo    repository.AvailabilityExceptionRepository
+	scheduleRepo     repository.DefaultScheduleRepository
+	employeeRepo     repository.EmployeeRepository
+	operationRepo    repository.OperationRepository
+	appointmentRepo  repository.AppointmentRepository
+	blackoutRepo     repository.BlackoutRepository
+	skillRepo        repository.SkillRepository
+	productRepo      repository.ProductRepository
+	calendarRepo     repository.EmployeeCalendarRepository
+	clock            clock.Clock
+}
+
+// NewAvailabilityService creates a new availability service
+func NewAvailabilityService(
+	availabilityRepo repository.AvailabilityRepository,
+	exceptionRepo repository.AvailabilityExceptionRepository,
+	scheduleRepo repository.DefaultScheduleRepository,
+	employeeRepo repository.EmployeeRepository,
+	operationRepo repository.OperationRepository,
+	appointmentRepo repository.AppointmentRepository,
+	blackoutRepo repository.BlackoutRepository,
+	skillRepo repository.SkillRepository,
+	productRepo repository.ProductRepository,
+	calendarRepo repository.EmployeeCalendarRepository,
+	clock clock.Clock,
+) AvailabilityService {
+	return &availabilityService{
+		availabilityRepo: availabilityRepo,
+		exceptionRepo:    exceptionRepo,
+		scheduleRepo:     scheduleRepo,
+		employeeRepo:     employeeRepo,
+		operationRepo:    operationRepo,
+		appointmentRepo:  appointmentRepo,
+		blackoutRepo:     blackoutRepo,
+		skillRepo:        skillRepo,
+		productRepo:      productRepo,
+		calendarRepo:     calendarRepo,
+		clock:            clock,
+	}
+}
+
+// GetEmployee returns an employee
+func (s *availabilityService) GetEmployee(id uint) (*models.Employee, error) {
+	return s.employeeRepo.FindByID(id)
+}
+
+// CreateException records a period in which an employee is unavailable and reports the
+// appointments already booked with them in it, which need another employee or a new time
+func (s *availabilityService) CreateException(exception *models.AvailabilityException) (*ExceptionReport, error) {
+	if err := exception.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := s.employeeRepo.FindByID(exception.EmployeeID); err != nil {
+		return nil, err
+	}
+	if err := s.exceptionRepo.Create(exception); err != nil {
+		return nil, err
+	}
+
+	conflicts, err := s.exceptionRepo.FindAffectedAppointments(exception)
+	if err != nil {
+		return nil, err
+	}
+	if len(conflicts) > 0 {
+		log.Printf("Availability exception %d of employee %d conflicts with %d booked appointments",
+			exception.ID, exception.EmployeeID, len(conflicts))
+	}
+	return &ExceptionReport{Exception: exception, Conflicts: conflicts}, nil
+}
+
+// ListExceptions lists an employee's availability exceptions
+func (s *availabilityService) ListExceptions(employeeID uint) ([]models.AvailabilityException, error) {
+	return s.exceptionRepo.FindByEmployee(employeeID)
+}
+
+// GetExceptionConflicts reports the booked appointments an exception still conflicts with
+func (s *availabilityService) GetExceptionConflicts(employeeID, exceptionID uint) (*ExceptionReport, error) {
+	exception, err := s.findException(employeeID, exceptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	conflicts, err := s.exceptionRepo.FindAffectedAppointments(exception)
+	if err != nil {
+		return nil, err
+	}
+	return &ExceptionReport{Exception: exception, Conflicts: conflicts}, nil
+}
+
+// DeleteException removes an availability exception
+func (s *availabilityService) DeleteException(employeeID, exceptionID uint) error {
+	if _, err := s.findException(employeeID, exceptionID); err != nil {
+		return err
+	}
+	return s.exceptionRepo.Delete(exceptionID)
+}
+
+// OpenSlots computes when an employee can take appointments between from and to: the
+// occurrences of their active availability slots, in each operation's timezone, minus the days
+// the operation is closed, their availability exceptions, their booked appointments, the busy
+// times of their connected work calendar and anything already in the past.
+func (s *availabilityService) OpenSlots(employeeID uint, operationID *uint, from, to time.Time) ([]OpenSlot, error) {
+	if !to.After(from) {
+		return nil, ErrInvalidSlotRange
+	}
+	if to.Sub(from) > maxOpenSlotRange {
+		return nil, ErrSlotRangeTooLong
+	}
+	if now := s.clock.Now(); from.Before(now) {
+		from = now
+	}
+
+	slots, err := s.availabilityRepo.FindByEmployee(employeeID)
+	if err != nil {
+		return nil, err
+	}
+	exceptions, err := s.exceptionRepo.FindOverlapping(employeeID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	busy, err := s.calendarRepo.FindBusyTimes(employeeID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	// Listings only match appointments inside the range; widen it so ones crossing its edges count
+	widenedFrom, widenedTo := from.AddDate(0, 0, -1), to.AddDate(0, 0, 1)
+	booked, _, err := s.appointmentRepo.FindByEmployee(employeeID, repository.AppointmentFilters{
+		StartDate: &widenedFrom,
+		EndDate:   &widenedTo,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	locations := make(map[uint]*time.Location)
+	closedDays := make(map[uint]map[string]models.OperationBlackout)
+	open := []OpenSlot{}
+	for _, slot := range slots {
+		if !slot.Active || (operationID != nil && slot.OperationID != *operationID) {
+			continue
+		}
+		location, ok := locations[slot.OperationID]
+		if !ok {
+			location = s.operationLocation(slot.OperationID)
+			locations[slot.OperationID] = location
+		}
+		closed, ok := closedDays[slot.OperationID]
+		if !ok {
+			closed, err = closedDates(s.blackoutRepo, slot.OperationID, from, to, location)
+			if err != nil {
+				return nil, err
+			}
+			closedDays[slot.OperationID] = closed
+		}
+
+		for _, window := range slotOccurrences(slot, from, to, location) {
+			if _, isClosed := closed[civilDate(window.Start, location).Format(models.BlackoutDateLayout)]; isClosed {
+				continue
+			}
+			windows := []OpenSlot{window}
+			for _, exception := range exceptions {
+				if exception.AppliesTo(slot.OperationID) {
+					windows = subtractPeriod(windows, exception.StartsAt, exception.EndsAt)
+				}
+			}
+			// An employee can't be at two docks at once, so bookings at any operation count
+			for _, appointment := range booked {
+				if appointment.Status != models.StatusCancelled {
+					windows = subtractPeriod(windows, appointment.ScheduledStart, appointment.ScheduledEnd)
+				}
+			}
+			for _, period := range busy {
+				windows = subtractPeriod(windows, period.StartsAt, period.EndsAt)
+			}
+			windows = subtractPeriod(windows, time.Time{}, from)
+			windows = subtractPeriod(windows, to, to.AddDate(100, 0, 0))
+			open = append(open, windows...)
+		}
+	}
+
+	sort.Slice(open, func(i, j int) bool {
+		if !open[i].Start.Equal(open[j].Start) {
+			return open[i].Start.Before(open[j].Start)
+		}
+		return open[i].OperationID < open[j].OperationID
+	})
+	return open, nil
+}
+
+// CalendarBusy returns the busy times of the employee's connected work calendar overlapping the
+// period, none when they connected no calendar
+func (s *availabilityService) CalendarBusy(employeeID uint, start, end time.Time) ([]models.EmployeeBusyTime, error) {
+	return s.calendarRepo.FindBusyTimes(employeeID, start, end)
+}
+
+// findException loads an exception and checks it belongs to the employee
+func (s *availabilityService) findException(employeeID, exceptionID uint) (*models.AvailabilityException, error) {
+	exception, err := s.exceptionRepo.FindByID(exceptionID)
+	if err != nil || exception.EmployeeID != employeeID {
+		return nil, ErrExceptionNotFound
+	}
+	return exception, nil
+}
+
+// operationLocation returns the timezone of an operation, UTC when unknown
+func (s *availabilityService) operationLocation(operationID uint) *time.Location {
+	operation, err := s.operationRepo.FindByID(operationID)
+	if err != nil {
+		return time.UTC
+	}
+	return operation.Location()
+}
+
+// slotOccurrences returns the periods an availability slot covers on the days between from and to
+func slotOccurrences(slot models.AvailabilitySlot, from, to time.Time, location *time.Location) []OpenSlot {
+	startClock, err := time.Parse("15:04", slot.StartTime)
+	if err != nil {
+		return nil
+	}
+	endClock, err := time.Parse("15:04", slot.EndTime)
+	if err != nil {
+		return nil
+	}
+
+	var occurrences []OpenSlot
+	first := from.In(location)
+	last := to.In(location)
+	for day := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, location); day.Before(last); day = day.AddDate(0, 0, 1) {
+		if slot.IsRecurring {
+			if int(day.Weekday()) != slot.DayOfWeek {
+				continue
+			}
+		} else {
+			if slot.SpecificDate == nil {
+				continue
+			}
+			date := slot.SpecificDate.In(location)
+			if date.Year() != day.Year() || date.YearDay() != day.YearDay() {
+				continue
+			}
+		}
+
+		start := time.Date(day.Year(), day.Month(), day.Day(), startClock.Hour(), startClock.Minute(), 0, 0, location)
+		end := time.Date(day.Year(), day.Month(), day.Day(), endClock.Hour(), endClock.Minute(), 0, 0, location)
+		if end.After(start) {
+			occurrences = append(occurrences, OpenSlot{OperationID: slot.OperationID, Start: start, End: end})
+		}
+	}
+	return occurrences
+}
+
+// subtractPeriod removes the period between start and end from the windows
+func subtractPeriod(windows []OpenSlot, start, end time.Time) []OpenSlot {
+	remaining := make([]OpenSlot, 0, len(windows))
+	for _, window := range windows {
+		if !start.Before(window.End) || !end.After(window.Start) {
+			remaining = append(remaining, window)
+			continue
+		}
+		if start.After(window.Start) {
+			remaining = append(remaining, OpenSlot{OperationID: window.OperationID, Start: window.Start, End: start})
+		}
+		if end.Before(window.End) {
+			remaining = append(remaining, OpenSlot{OperationID: window.OperationID, Start: end, End: window.End})
+		}
+	}
+	return remaining
+}
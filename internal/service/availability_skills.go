@@ -0,0 +1,73 @@
+package service
+
+import (
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+)
+
+// QualifiedEmployees returns the employees with active availability at an operation who hold
+// every skill an appointment there for a product, if any, requires, in ID order
+func (s *availabilityService) QualifiedEmployees(operationID uint, productID *uint) ([]uint, error) {
+	slots, err := s.availabilityRepo.FindByOperation(operationID)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []uint
+	seen := make(map[uint]bool)
+	for _, slot := range slots {
+		if slot.Active && !seen[slot.EmployeeID] {
+			seen[slot.EmployeeID] = true
+			candidates = append(candidates, slot.EmployeeID)
+		}
+	}
+	if len(candidates) == 0 || s.skillRepo == nil {
+		return candidates, nil
+	}
+
+	required, err := requiredSkills(s.skillRepo, s.productRepo, operationID, productID)
+	if err != nil || len(required) == 0 {
+		return candidates, err
+	}
+	skills, err := s.skillRepo.FindByEmployees(candidates)
+	if err != nil {
+		return nil, err
+	}
+	held := make(map[uint][]models.EmployeeSkill, len(candidates))
+	for _, skill := range skills {
+		held[skill.EmployeeID] = append(held[skill.EmployeeID], skill)
+	}
+
+	qualified := make([]uint, 0, len(candidates))
+	for _, employeeID := range candidates {
+		if len(lackedSkills(held[employeeID], required)) == 0 {
+			qualified = append(qualified, employeeID)
+		}
+	}
+	return qualified, nil
+}
+
+// AssignEmployee picks the employee to book an appointment with when none is given: the first
+// qualified employee, in ID order, who is open at the operation for the whole slot
+func (s *availabilityService) AssignEmployee(operationID uint, productID *uint, start, end time.Time) (*models.Employee, error) {
+	if !end.After(start) {
+		return nil, ErrInvalidSlotRange
+	}
+	qualified, err := s.QualifiedEmployees(operationID, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, employeeID := range qualified {
+		open, err := s.OpenSlots(employeeID, &operationID, start, end)
+		if err != nil {
+			return nil, err
+		}
+		for _, slot := range open {
+			if !slot.Start.After(start) && !slot.End.Before(end) {
+				return s.employeeRepo.FindByID(employeeID)
+			}
+		}
+	}
+	return nil, ErrNoQualifiedEmployee
+}
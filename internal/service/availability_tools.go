@@ -0,0 +1,278 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+)
+
+// Availability tool errors
+var (
+	ErrNoSlotsToCopy     = errors.New("the source employee has no recurring slots to copy")
+	ErrNoDefaultSchedule = errors.New("the operation has no default schedule")
+	ErrShiftOutOfDay     = errors.New("shifted slot would cross midnight")
+)
+
+// AvailabilitySkip is an employee a bulk availability change left alone
+type AvailabilitySkip struct {
+	EmployeeID uint   `json:"employee_id"`
+	Reason     string `json:"reason"`
+}
+
+// AvailabilityBulkResult describes what a bulk availability change did
+type AvailabilityBulkResult struct {
+	Updated      []uint             `json:"updated"` // employees whose slots changed
+	Skipped      []AvailabilitySkip `json:"skipped"`
+	SlotsCreated int                `json:"slots_created"`
+	SlotsRemoved int64              `json:"slots_removed"`
+}
+
+// CopyWeeklyAvailability copies an employee's recurring slots, optionally only those at one
+// operation, to other employees. With replace, the targets' recurring slots at the copied
+// operations are removed first; otherwise copied slots overlapping a slot the target already has
+// are left out.
+func (s *availabilityService) CopyWeeklyAvailability(sourceID uint, targetIDs []uint, operationID *uint, replace bool) (*AvailabilityBulkResult, error) {
+	slots, err := s.availabilityRepo.FindByEmployee(sourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var weekly []models.AvailabilitySlot
+	for _, slot := range slots {
+		if slot.IsRecurring && slot.Active && (operationID == nil || slot.OperationID == *operationID) {
+			weekly = append(weekly, slot)
+		}
+	}
+	if len(weekly) == 0 {
+		return nil, ErrNoSlotsToCopy
+	}
+
+	result := &AvailabilityBulkResult{Updated: []uint{}, Skipped: []AvailabilitySkip{}}
+	for _, targetID := range targetIDs {
+		if targetID == sourceID {
+			result.Skipped = append(result.Skipped, AvailabilitySkip{EmployeeID: targetID, Reason: "source employee"})
+			continue
+		}
+		if err := s.applyWeeklySlots(targetID, weekly, replace, result); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// GetDefaultSchedule returns the weekly schedule an operation gives new employees
+func (s *availabilityService) GetDefaultSchedule(operationID uint) ([]models.OperationDefaultSlot, error) {
+	if _, err := s.operationRepo.FindByID(operationID); err != nil {
+		return nil, err
+	}
+	return s.scheduleRepo.FindByOperation(operationID)
+}
+
+// SetDefaultSchedule replaces an operation's default weekly schedule
+func (s *availabilityService) SetDefaultSchedule(operationID uint, slots []models.OperationDefaultSlot) ([]models.OperationDefaultSlot, error) {
+	if _, err := s.operationRepo.FindByID(operationID); err != nil {
+		return nil, err
+	}
+	for i := range slots {
+		if err := slots[i].Validate(); err != nil {
+			return nil, fmt.Errorf("slot %d: %w", i+1, err)
+		}
+		for j := 0; j < i; j++ {
+			if slots[i].DayOfWeek == slots[j].DayOfWeek && slots[i].StartTime < slots[j].EndTime && slots[j].StartTime < slots[i].EndTime {
+				return nil, fmt.Errorf("slot %d overlaps slot %d", i+1, j+1)
+			}
+		}
+	}
+
+	if err := s.scheduleRepo.Replace(operationID, slots); err != nil {
+		return nil, err
+	}
+	return s.scheduleRepo.FindByOperation(operationID)
+}
+
+// ApplyDefaultSchedule gives employees the operation's default weekly schedule. Without replace,
+// employees who already have recurring slots at the operation are skipped, so it can be run for
+// every new employee without touching schedules already set up.
+func (s *availabilityService) ApplyDefaultSchedule(operationID uint, employeeIDs []uint, replace bool) (*AvailabilityBulkResult, error) {
+	defaults, err := s.GetDefaultSchedule(operationID)
+	if err != nil {
+		return nil, err
+	}
+	if len(defaults) == 0 {
+		return nil, ErrNoDefaultSchedule
+	}
+
+	weekly := make([]models.AvailabilitySlot, 0, len(defaults))
+	for _, slot := range defaults {
+		weekly = append(weekly, models.AvailabilitySlot{
+			OperationID: operationID,
+			DayOfWeek:   slot.DayOfWeek,
+			StartTime:   slot.StartTime,
+			EndTime:     slot.EndTime,
+			IsRecurring: true,
+			Active:      true,
+		})
+	}
+
+	result := &AvailabilityBulkResult{Updated: []uint{}, Skipped: []AvailabilitySkip{}}
+	for _, employeeID := range employeeIDs {
+		if !replace {
+			existing, err := s.availabilityRepo.FindByEmployee(employeeID)
+			if err != nil {
+				return result, err
+			}
+			if hasRecurringSlotAt(existing, operationID) {
+				result.Skipped = append(result.Skipped, AvailabilitySkip{EmployeeID: employeeID, Reason: "already has a weekly schedule at the operation"})
+				continue
+			}
+		}
+		if err := s.applyWeeklySlots(employeeID, weekly, replace, result); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// ShiftSlots moves the recurring slots of an operation by a number of minutes, for example to
+// follow a daylight saving change, optionally only for some employees. Either every slot moves
+// or none does; a slot that would cross midnight fails the whole shift.
+func (s *availabilityService) ShiftSlots(operationID uint, minutes int, employeeIDs []uint) (int, error) {
+	if _, err := s.operationRepo.FindByID(operationID); err != nil {
+		return 0, err
+	}
+	slots, err := s.availabilityRepo.FindByOperation(operationID)
+	if err != nil {
+		return 0, err
+	}
+
+	only := make(map[uint]bool, len(employeeIDs))
+	for _, id := range employeeIDs {
+		only[id] = true
+	}
+
+	var shifted []models.AvailabilitySlot
+	for _, slot := range slots {
+		if !slot.IsRecurring || (len(only) > 0 && !only[slot.EmployeeID]) {
+			continue
+		}
+		start, err := shiftClock(slot.StartTime, minutes)
+		if err != nil {
+			return 0, fmt.Errorf("slot %d: %w", slot.ID, err)
+		}
+		end, err := shiftClock(slot.EndTime, minutes)
+		if err != nil {
+			return 0, fmt.Errorf("slot %d: %w", slot.ID, err)
+		}
+		slot.StartTime, slot.EndTime = start, end
+		if err := slot.Validate(); err != nil {
+			return 0, fmt.Errorf("slot %d: %w", slot.ID, err)
+		}
+		shifted = append(shifted, slot)
+	}
+
+	if err := s.availabilityRepo.UpdateBatch(shifted); err != nil {
+		return 0, err
+	}
+	return len(shifted), nil
+}
+
+// applyWeeklySlots gives an employee copies of weekly slots and records the outcome
+func (s *availabilityService) applyWeeklySlots(employeeID uint, weekly []models.AvailabilitySlot, replace bool, result *AvailabilityBulkResult) error {
+	if _, err := s.employeeRepo.FindByID(employeeID); err != nil {
+		result.Skipped = append(result.Skipped, AvailabilitySkip{EmployeeID: employeeID, Reason: err.Error()})
+		return nil
+	}
+
+	existing, err := s.availabilityRepo.FindByEmployee(employeeID)
+	if err != nil {
+		return err
+	}
+
+	operations := map[uint]bool{}
+	var operationIDs []uint
+	copies := make([]models.AvailabilitySlot, 0, len(weekly))
+	for _, slot := range weekly {
+		copied := models.AvailabilitySlot{
+			EmployeeID:  employeeID,
+			OperationID: slot.OperationID,
+			DayOfWeek:   slot.DayOfWeek,
+			StartTime:   slot.StartTime,
+			EndTime:     slot.EndTime,
+			IsRecurring: true,
+			Active:      true,
+		}
+		if err := copied.Validate(); err != nil {
+			return fmt.Errorf("slot %s-%s: %w", slot.StartTime, slot.EndTime, err)
+		}
+		if !operations[slot.OperationID] {
+			operations[slot.OperationID] = true
+			operationIDs = append(operationIDs, slot.OperationID)
+		}
+		if !replace && overlapsAny(&copied, existing) {
+			continue
+		}
+		copies = append(copies, copied)
+	}
+
+	if !replace {
+		if len(copies) == 0 {
+			result.Skipped = append(result.Skipped, AvailabilitySkip{EmployeeID: employeeID, Reason: "every slot overlaps an existing slot"})
+			return nil
+		}
+		if err := s.availabilityRepo.CreateBatch(copies); err != nil {
+			return err
+		}
+	} else {
+		removed, err := s.availabilityRepo.ReplaceRecurring(employeeID, operationIDs, copies)
+		if err != nil {
+			return err
+		}
+		result.SlotsRemoved += removed
+	}
+
+	result.Updated = append(result.Updated, employeeID)
+	result.SlotsCreated += len(copies)
+	return nil
+}
+
+// overlapsAny reports whether a slot overlaps any active slot at the same operation
+func overlapsAny(slot *models.AvailabilitySlot, existing []models.AvailabilitySlot) bool {
+	for i := range existing {
+		if existing[i].Active && existing[i].OperationID == slot.OperationID && slot.OverlapsWith(&existing[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasRecurringSlotAt reports whether any slot is a weekly slot at the operation
+func hasRecurringSlotAt(slots []models.AvailabilitySlot, operationID uint) bool {
+	for _, slot := range slots {
+		if slot.IsRecurring && slot.OperationID == operationID {
+			return true
+		}
+	}
+	return false
+}
+
+// shiftClock moves an "HH:MM" time of day by minutes. "24:00" is accepted as the end of the day.
+func shiftClock(value string, minutes int) (string, error) {
+	var total int
+	if value == "24:00" {
+		total = 24 * 60
+	} else {
+		parsed, err := time.Parse("15:04", value)
+		if err != nil {
+			return "", fmt.Errorf("invalid time %q", value)
+		}
+		total = parsed.Hour()*60 + parsed.Minute()
+	}
+
+	total += minutes
+	if total < 0 || total > 24*60 {
+		return "", ErrShiftOutOfDay
+	}
+	return fmt.Sprintf("%02d:%02d", total/60, total%60), nil
+}
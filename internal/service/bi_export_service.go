@@ -0,0 +1,418 @@
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+	"github.com/bernardofernandezz/scheduling-api/pkg/leader"
+	"github.com/bernardofernandezz/scheduling-api/pkg/objectstore"
+)
+
+// BIFactSchemaVersion is the version of the appointment fact columns. Adding a column at the end
+// keeps the version; renaming, removing, reordering or changing the meaning of one starts a new
+// version, written under its own prefix so the warehouse can load both while it migrates.
+const BIFactSchemaVersion = 1
+
+// biExportSettleTime is how far behind the run's start its export stops, so appointments being
+// written while it starts are left whole to the next run
+const biExportSettleTime = time.Minute
+
+// BI export errors
+var (
+	ErrBIExportDisabled = errors.New("BI export is disabled")
+	ErrBIExportRunning  = errors.New("a BI export is already running")
+)
+
+// BIFactColumn describes a column of the appointment facts
+type BIFactColumn struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"` // string, integer, boolean or timestamp (RFC 3339, UTC)
+	Nullable    bool   `json:"nullable"`
+	Description string `json:"description"`
+}
+
+// BIFactSchema describes the appointment facts files, written next to them as _schema.json
+type BIFactSchema struct {
+	Dataset     string         `json:"dataset"`
+	Version     int            `json:"version"`
+	Format      string         `json:"format"`
+	Compression string         `json:"compression"`
+	Columns     []BIFactColumn `json:"columns"`
+}
+
+// biFactColumns are the columns of version 1 of the appointment facts. Nothing that identifies a
+// person is exported: suppliers and employees are pseudonyms, and notes, cancellation reasons,
+// booking codes and partner references are left out.
+var biFactColumns = []BIFactColumn{
+	{"appointment_id", "integer", false, "Appointment ID; a fact is exported again each time its appointment changes"},
+	{"operation_code", "string", false, "Code of the operation"},
+	{"type", "string", false, "delivery, pickup or service"},
+	{"status", "string", false, "Status when exported"},
+	{"product_category", "string", true, "Category of the product, empty for service visits"},
+	{"supplier_key", "string", false, "Pseudonym of the supplier, stable across exports"},
+	{"employee_key", "string", false, "Pseudonym of the employee, stable across exports"},
+	{"scheduled_start", "timestamp", false, "Start of the booked slot"},
+	{"scheduled_end", "timestamp", false, "End of the booked slot"},
+	{"scheduled_minutes", "integer", false, "Length of the booked slot"},
+	{"quantity_to_deliver", "integer", false, "Quantity booked"},
+	{"received_quantity", "integer", true, "Quantity received at completion"},
+	{"created_at", "timestamp", false, "When the appointment was booked"},
+	{"confirmed_at", "timestamp", true, ""},
+	{"checked_in_at", "timestamp", true, ""},
+	{"completed_at", "timestamp", true, ""},
+	{"cancelled_at", "timestamp", true, ""},
+	{"auto_completed", "boolean", false, "Completed by the overdue job rather than by staff"},
+	{"overdue_flagged", "boolean", false, "Flagged as left open after its end"},
+	{"follow_up", "boolean", false, "Delivers the remainder of an earlier appointment"},
+	{"cost_center", "string", true, "Cost center dock time is charged to"},
+	{"changed_at", "timestamp", false, "When the appointment last changed; the latest fact of an appointment wins"},
+	{"deleted", "boolean", false, "The appointment was deleted and should be dropped"},
+}
+
+// BIExportService defines the interface for exporting anonymized appointment facts to the
+// bucket the data warehouse loads from
+type BIExportService interface {
+	Export(ctx context.Context) (*models.BIExportRun, error)
+	Trigger(triggeredByID uint) (*models.BIExportRun, error)
+	GetRun(id uint) (*models.BIExportRun, error)
+	ListRuns(limit int) ([]models.BIExportRun, error)
+	Schema() BIFactSchema
+}
+
+// biExportService implements the BIExportService interface
+type biExportService struct {
+	biExportRepo repository.BIExportRepository
+	store        objectstore.Store
+	lock         leader.Lock // keeps replicas from exporting at the same time
+	config       config.BIExportConfig
+	clock        clock.Clock
+	running      sync.Mutex
+}
+
+// NewObjectStore creates the object store selected in the BI export configuration. It returns
+// nil when the export is disabled.
+func NewObjectStore(cfg config.BIExportConfig, httpClient *http.Client) objectstore.Store {
+	switch strings.ToLower(cfg.Store) {
+	case "s3", "gcs":
+		var transport http.RoundTripper
+		if httpClient != nil {
+			transport = httpClient.Transport
+		}
+		store, err := objectstore.NewS3(cfg.Endpoint, cfg.Region, cfg.Bucket, cfg.AccessKey, cfg.SecretKey, cfg.Timeout, transport)
+		if err != nil {
+			log.Printf("Invalid BI export store settings, BI export is disabled: %v", err)
+			return nil
+		}
+		return store
+	case "filesystem":
+		return objectstore.NewFilesystem(cfg.Directory)
+	case "", "none":
+		return nil
+	default:
+		log.Printf("Unknown BI export store %q, BI export is disabled", cfg.Store)
+		return nil
+	}
+}
+
+// NewBIExportService creates a new BI export service
+func NewBIExportService(
+	biExportRepo repository.BIExportRepository,
+	store objectstore.Store,
+	lock leader.Lock,
+	config config.BIExportConfig,
+	clock clock.Clock,
+) BIExportService {
+	return &biExportService{
+		biExportRepo: biExportRepo,
+		store:        store,
+		lock:         lock,
+		config:       config,
+		clock:        clock,
+	}
+}
+
+// Export runs a scheduled export of the appointments changed since the last successful run and
+// waits for it to finish. A failed run is recorded and returned with its error. Nothing is done
+// when the export is disabled.
+func (s *biExportService) Export(ctx context.Context) (*models.BIExportRun, error) {
+	if s.store == nil {
+		return nil, nil
+	}
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer s.release()
+
+	run, err := s.start(models.BIExportTriggerScheduled, nil)
+	if err != nil {
+		return nil, err
+	}
+	return run, s.execute(ctx, run)
+}
+
+// Trigger starts an export in the background and returns its run while it is still running
+func (s *biExportService) Trigger(triggeredByID uint) (*models.BIExportRun, error) {
+	ctx := context.Background()
+	if err := s.acquire(ctx); err != nil {
+		return nil, err
+	}
+
+	run, err := s.start(models.BIExportTriggerManual, &triggeredByID)
+	if err != nil {
+		s.release()
+		return nil, err
+	}
+
+	started := *run
+	go func() {
+		defer s.release()
+		if err := s.execute(ctx, run); err != nil {
+			log.Printf("BI export run %d failed: %v", run.ID, err)
+		}
+	}()
+	return &started, nil
+}
+
+// GetRun returns a BI export run
+func (s *biExportService) GetRun(id uint) (*models.BIExportRun, error) {
+	return s.biExportRepo.FindRun(id)
+}
+
+// ListRuns returns the latest BI export runs, newest first
+func (s *biExportService) ListRuns(limit int) ([]models.BIExportRun, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	return s.biExportRepo.ListRuns(limit)
+}
+
+// Schema returns the description of the appointment facts files
+func (s *biExportService) Schema() BIFactSchema {
+	return BIFactSchema{
+		Dataset:     "appointment_facts",
+		Version:     BIFactSchemaVersion,
+		Format:      "csv",
+		Compression: "gzip",
+		Columns:     biFactColumns,
+	}
+}
+
+// acquire makes sure no other export runs, on this replica or another one
+func (s *biExportService) acquire(ctx context.Context) error {
+	if s.store == nil {
+		return ErrBIExportDisabled
+	}
+	if !s.running.TryLock() {
+		return ErrBIExportRunning
+	}
+	if s.lock != nil {
+		acquired, err := s.lock.TryAcquire(ctx)
+		if err != nil || !acquired {
+			s.running.Unlock()
+			if err != nil {
+				return err
+			}
+			return ErrBIExportRunning
+		}
+	}
+	return nil
+}
+
+// release lets the next export run
+func (s *biExportService) release() {
+	if s.lock != nil {
+		if err := s.lock.Release(context.Background()); err != nil {
+			log.Printf("Failed to release the BI export lock: %v", err)
+		}
+	}
+	s.running.Unlock()
+}
+
+// start records a run covering the appointments changed since the last successful one
+func (s *biExportService) start(trigger models.BIExportTrigger, triggeredByID *uint) (*models.BIExportRun, error) {
+	now := s.clock.Now()
+	run := &models.BIExportRun{
+		Trigger:       trigger,
+		TriggeredByID: triggeredByID,
+		SchemaVersion: BIFactSchemaVersion,
+		ChangedUntil:  now.Add(-biExportSettleTime),
+		Status:        models.BIExportRunning,
+		StartedAt:     now,
+	}
+
+	last, err := s.biExportRepo.LastSucceeded()
+	if err != nil {
+		return nil, err
+	}
+	if last != nil {
+		run.ChangedAfter = last.ChangedUntil
+	}
+
+	if err := s.biExportRepo.CreateRun(run); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// execute writes the facts of a run and the schema to the store, recording how it ended
+func (s *biExportService) execute(ctx context.Context, run *models.BIExportRun) error {
+	err := s.write(ctx, run)
+
+	finished := s.clock.Now()
+	run.FinishedAt = &finished
+	run.Status = models.BIExportSucceeded
+	if err != nil {
+		run.Status = models.BIExportFailed
+		run.Error = err.Error()
+	}
+	if saveErr := s.biExportRepo.UpdateRun(run); saveErr != nil {
+		log.Printf("Failed to record BI export run %d: %v", run.ID, saveErr)
+		if err == nil {
+			err = saveErr
+		}
+	}
+	return err
+}
+
+// write exports the facts of a run as a gzipped CSV file and refreshes the schema next to it.
+// Nothing but the schema is written when no appointment changed.
+func (s *biExportService) write(ctx context.Context, run *models.BIExportRun) error {
+	var buffer bytes.Buffer
+	compressor := gzip.NewWriter(&buffer)
+	writer := csv.NewWriter(compressor)
+
+	header := make([]string, len(biFactColumns))
+	for i, column := range biFactColumns {
+		header[i] = column.Name
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	batchSize := s.config.BatchSize
+	if batchSize <= 0 {
+		batchSize = 5000
+	}
+	position := repository.SyncPosition{ChangedAt: run.ChangedAfter}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		facts, err := s.biExportRepo.AppointmentFacts(position, run.ChangedUntil, batchSize)
+		if err != nil {
+			return fmt.Errorf("failed to read appointments: %w", err)
+		}
+		for _, fact := range facts {
+			if err := writer.Write(s.record(fact)); err != nil {
+				return err
+			}
+		}
+		run.Rows += len(facts)
+		if len(facts) < batchSize {
+			break
+		}
+		last := facts[len(facts)-1]
+		position = repository.SyncPosition{ChangedAt: last.ChangedAt, ID: last.ID}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return err
+	}
+	if err := compressor.Close(); err != nil {
+		return err
+	}
+
+	if run.Rows > 0 {
+		key := s.key(fmt.Sprintf("export_date=%s/run-%06d.csv.gz", run.StartedAt.UTC().Format("2006-01-02"), run.ID))
+		if err := s.store.Put(ctx, key, buffer.Bytes(), "application/gzip"); err != nil {
+			return fmt.Errorf("failed to upload %s: %w", key, err)
+		}
+		run.ObjectKey = key
+	}
+
+	schema, err := json.MarshalIndent(s.Schema(), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := s.store.Put(ctx, s.key("_schema.json"), schema, "application/json"); err != nil {
+		return fmt.Errorf("failed to upload the schema: %w", err)
+	}
+	return nil
+}
+
+// key returns the object key of a file of the current schema version
+func (s *biExportService) key(name string) string {
+	key := fmt.Sprintf("appointment_facts/v%d/%s", BIFactSchemaVersion, name)
+	if s.config.Prefix != "" {
+		key = s.config.Prefix + "/" + key
+	}
+	return key
+}
+
+// record returns the CSV record of an appointment fact, in biFactColumns order
+func (s *biExportService) record(fact repository.AppointmentFact) []string {
+	received := ""
+	if fact.ReceivedQuantity != nil {
+		received = strconv.Itoa(*fact.ReceivedQuantity)
+	}
+	return []string{
+		strconv.FormatUint(uint64(fact.ID), 10),
+		fact.OperationCode,
+		fact.Type,
+		fact.Status,
+		fact.ProductCategory,
+		s.pseudonym("supplier", fact.SupplierID),
+		s.pseudonym("employee", fact.EmployeeID),
+		biTimestamp(&fact.ScheduledStart),
+		biTimestamp(&fact.ScheduledEnd),
+		strconv.Itoa(int(fact.ScheduledEnd.Sub(fact.ScheduledStart).Minutes())),
+		strconv.Itoa(fact.QuantityToDeliver),
+		received,
+		biTimestamp(&fact.CreatedAt),
+		biTimestamp(fact.ConfirmedAt),
+		biTimestamp(fact.CheckedInAt),
+		biTimestamp(fact.CompletedAt),
+		biTimestamp(fact.CancelledAt),
+		strconv.FormatBool(fact.AutoCompletedAt != nil),
+		strconv.FormatBool(fact.OverdueFlaggedAt != nil),
+		strconv.FormatBool(fact.FollowUpOfID != nil),
+		fact.CostCenter,
+		biTimestamp(&fact.ChangedAt),
+		strconv.FormatBool(fact.Deleted),
+	}
+}
+
+// pseudonym returns a stable key standing in for the ID of a supplier or employee, which cannot
+// be turned back into the ID without the pseudonym key
+func (s *biExportService) pseudonym(kind string, id uint) string {
+	mac := hmac.New(sha256.New, []byte(s.config.PseudonymKey))
+	mac.Write([]byte(kind + ":" + strconv.FormatUint(uint64(id), 10)))
+	return hex.EncodeToString(mac.Sum(nil))[:20]
+}
+
+// biTimestamp formats an optional time as an RFC 3339 UTC timestamp, empty when unset
+func biTimestamp(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
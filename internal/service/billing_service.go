@@ -0,0 +1,149 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+)
+
+// ErrBillingCodeExists is returned when adding a code already on the list
+var ErrBillingCodeExists = errors.New("billing code already exists")
+
+// BillingCodeUpdate changes a billing code; nil fields are left as they are
+type BillingCodeUpdate struct {
+	Name   *string
+	Active *bool
+}
+
+// BillingExport is the dock time and the fees charged in a month per supplier and cost center,
+// for invoicing
+type BillingExport struct {
+	Month       string                   `json:"month"` // YYYY-MM
+	OperationID *uint                    `json:"operation_id,omitempty"`
+	Rows        []repository.DockTimeRow `json:"rows"`
+	DockMinutes float64                  `json:"dock_minutes"` // total of all rows
+	Fees        []repository.FeeRow      `json:"fees"`
+}
+
+// BillingService defines the interface for the cost center and billing code list and the billing
+// export finance allocates dock time with
+type BillingService interface {
+	ListCodes(codeType models.BillingCodeType) ([]models.BillingCode, error)
+	CreateCode(code *models.BillingCode) error
+	UpdateCode(id uint, update BillingCodeUpdate) (*models.BillingCode, error)
+	MonthlyExport(month time.Time, operationID *uint) (*BillingExport, error)
+}
+
+// billingService implements the BillingService interface
+type billingService struct {
+	billingRepo repository.BillingRepository
+}
+
+// NewBillingService creates a new billing service
+func NewBillingService(billingRepo repository.BillingRepository) BillingService {
+	return &billingService{billingRepo: billingRepo}
+}
+
+// ListCodes returns the billing codes of a type, or of every type when empty
+func (s *billingService) ListCodes(codeType models.BillingCodeType) ([]models.BillingCode, error) {
+	if codeType != "" && !codeType.IsValid() {
+		return nil, errors.New("billing code type must be cost_center or billing_code")
+	}
+	return s.billingRepo.ListCodes(codeType)
+}
+
+// CreateCode adds an active code to the list
+func (s *billingService) CreateCode(code *models.BillingCode) error {
+	if err := code.Validate(); err != nil {
+		return err
+	}
+	if _, err := s.billingRepo.FindCode(code.Type, code.Code); err == nil {
+		return ErrBillingCodeExists
+	}
+	code.Active = true
+	return s.billingRepo.CreateCode(code)
+}
+
+// UpdateCode renames a code or activates and deactivates it. Codes are never deleted so past
+// appointments keep pointing at them.
+func (s *billingService) UpdateCode(id uint, update BillingCodeUpdate) (*models.BillingCode, error) {
+	code, err := s.billingRepo.FindCodeByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if update.Name != nil {
+		code.Name = *update.Name
+	}
+	if update.Active != nil {
+		code.Active = *update.Active
+	}
+	if err := s.billingRepo.UpdateCode(code); err != nil {
+		return nil, err
+	}
+	return code, nil
+}
+
+// MonthlyExport sums the dock time of the completed appointments starting in a month, in the
+// month's timezone, and the fees charged for the month's appointments, per supplier and cost center
+func (s *billingService) MonthlyExport(month time.Time, operationID *uint) (*BillingExport, error) {
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	filters := repository.BillingFilters{
+		OperationID: operationID,
+		Start:       start,
+		End:         start.AddDate(0, 1, 0),
+	}
+	rows, err := s.billingRepo.DockTime(filters)
+	if err != nil {
+		return nil, err
+	}
+	fees, err := s.billingRepo.Fees(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &BillingExport{
+		Month:       start.Format("2006-01"),
+		OperationID: operationID,
+		Rows:        rows,
+		Fees:        fees,
+	}
+	for _, row := range rows {
+		export.DockMinutes += row.DockMinutes
+	}
+	return export, nil
+}
+
+// checkBillingCodes makes sure the cost center and billing code of an appointment are active
+// entries of the admin-managed list. Either may be left empty, and codes unchanged from the
+// previous version of the appointment are kept even if they were deactivated since.
+func checkBillingCodes(billingRepo repository.BillingRepository, appointment, previous *models.Appointment) error {
+	if billingRepo == nil {
+		return nil
+	}
+
+	fields := []struct {
+		codeType models.BillingCodeType
+		value    string
+		previous string
+	}{
+		{models.BillingCodeCostCenter, appointment.CostCenter, ""},
+		{models.BillingCodeProject, appointment.BillingCode, ""},
+	}
+	if previous != nil {
+		fields[0].previous = previous.CostCenter
+		fields[1].previous = previous.BillingCode
+	}
+	for _, field := range fields {
+		if field.value == "" || field.value == field.previous {
+			continue
+		}
+		code, err := billingRepo.FindCode(field.codeType, field.value)
+		if err != nil || !code.Active {
+			return fmt.Errorf("unknown or inactive %s %q", field.codeType, field.value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,475 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+)
+
+// Invitation errors
+var (
+	ErrInvitationNotFound   = errors.New("booking invitation not found")
+	ErrInvitationExpired    = errors.New("booking invitation has expired")
+	ErrInvitationUsed       = errors.New("booking invitation was already used")
+	ErrInvitationRevoked    = errors.New("booking invitation was revoked")
+	ErrInviterRequired      = errors.New("the employee the appointment is booked with is required")
+	ErrSlotOutsideWindow    = errors.New("the slot is outside the invitation's booking window")
+	ErrSlotNotOpen          = errors.New("the slot is no longer open")
+	ErrInvitationValidity   = errors.New("invitations can be valid for at most 30 days")
+	ErrInvitationQuantity   = errors.New("quantity to deliver must be greater than zero")
+	ErrInvitationSlotsRange = errors.New("slots can be listed for at most 31 days at a time")
+)
+
+// Invitation limits
+const (
+	defaultInvitationValidity = 7 * 24 * time.Hour
+	maxInvitationValidity     = 30 * 24 * time.Hour
+	defaultBookingWindow      = 14 * 24 * time.Hour
+	invitationSlotStep        = 15 * time.Minute // slot starts are aligned to this
+)
+
+// IssuedInvitation is a new invitation together with its link. The token is only available
+// when the invitation is created.
+type IssuedInvitation struct {
+	Invitation *models.BookingInvitation `json:"invitation"`
+	Token      string                    `json:"token"`
+	Link       string                    `json:"link"`
+}
+
+// InvitationView is what the supplier following an invitation link sees
+type InvitationView struct {
+	OperationID       uint                   `json:"operation_id"`
+	OperationName     string                 `json:"operation_name"`
+	SupplierName      string                 `json:"supplier_name"`
+	Type              models.AppointmentType `json:"type"`
+	ProductID         *uint                  `json:"product_id"`
+	ProductName       string                 `json:"product_name,omitempty"`
+	QuantityToDeliver int                    `json:"quantity_to_deliver"` // 0 when the supplier enters it
+	DurationMinutes   int                    `json:"duration_minutes"`
+	BookFrom          time.Time              `json:"book_from"`
+	BookUntil         time.Time              `json:"book_until"`
+	ExpiresAt         time.Time              `json:"expires_at"`
+	Notes             string                 `json:"notes"`
+}
+
+// InvitationBooking is the slot a supplier picks through an invitation
+type InvitationBooking struct {
+	ScheduledStart    time.Time
+	QuantityToDeliver int // ignored when the invitation fixes the quantity
+	Notes             string
+}
+
+// InvitationService defines the interface for self-service booking links
+type InvitationService interface {
+	CreateInvitation(user *models.User, invitation *models.BookingInvitation, validFor time.Duration, notifySupplier bool) (*IssuedInvitation, error)
+	ListInvitations(inviterID uint) ([]models.BookingInvitation, error)
+	GetInvitation(id uint) (*models.BookingInvitation, error)
+	RevokeInvitation(id uint) (*models.BookingInvitation, error)
+	InviterFor(user *models.User) (uint, error)
+	ViewInvitation(token string) (*InvitationView, error)
+	InvitationSlots(token string, from, to time.Time) ([]OpenSlot, error)
+	BookInvitation(token string, booking InvitationBooking) (*models.Appointment, error)
+}
+
+// invitationService implements the InvitationService interface
+type invitationService struct {
+	invitationRepo      repository.BookingInvitationRepository
+	employeeRepo        repository.EmployeeRepository
+	supplierRepo        repository.SupplierRepository
+	operationRepo       repository.OperationRepository
+	productRepo         repository.ProductRepository
+	skillRepo           repository.SkillRepository
+	appointmentService  AppointmentService
+	availabilityService AvailabilityService
+	notificationService NotificationService
+	config              *config.Config
+	clock               clock.Clock
+}
+
+// NewInvitationService creates a new invitation service
+func NewInvitationService(
+	invitationRepo repository.BookingInvitationRepository,
+	employeeRepo repository.EmployeeRepository,
+	supplierRepo repository.SupplierRepository,
+	operationRepo repository.OperationRepository,
+	productRepo repository.ProductRepository,
+	skillRepo repository.SkillRepository,
+	appointmentService AppointmentService,
+	availabilityService AvailabilityService,
+	notificationService NotificationService,
+	config *config.Config,
+	clock clock.Clock,
+) InvitationService {
+	return &invitationService{
+		invitationRepo:      invitationRepo,
+		employeeRepo:        employeeRepo,
+		supplierRepo:        supplierRepo,
+		operationRepo:       operationRepo,
+		productRepo:         productRepo,
+		skillRepo:           skillRepo,
+		appointmentService:  appointmentService,
+		availabilityService: availabilityService,
+		notificationService: notificationService,
+		config:              config,
+		clock:               clock,
+	}
+}
+
+// CreateInvitation issues a single-use booking link. Employees invite on their own behalf;
+// admins name the employee the appointment will be booked with. The booking window defaults to
+// the next two weeks and the link to a week.
+func (s *invitationService) CreateInvitation(user *models.User, invitation *models.BookingInvitation, validFor time.Duration, notifySupplier bool) (*IssuedInvitation, error) {
+	if user.Role == "employee" {
+		inviterID, err := s.InviterFor(user)
+		if err != nil {
+			return nil, err
+		}
+		invitation.InviterID = inviterID
+	} else if invitation.InviterID == 0 {
+		return nil, ErrInviterRequired
+	} else if _, err := s.employeeRepo.FindByID(invitation.InviterID); err != nil {
+		return nil, errors.New("invalid employee: " + err.Error())
+	}
+
+	if validFor <= 0 {
+		validFor = defaultInvitationValidity
+	}
+	if validFor > maxInvitationValidity {
+		return nil, ErrInvitationValidity
+	}
+
+	now := s.clock.Now()
+	if invitation.Type == "" {
+		invitation.Type = models.AppointmentTypeDelivery
+	}
+	if invitation.BookFrom.IsZero() || invitation.BookFrom.Before(now) {
+		invitation.BookFrom = now
+	}
+	if invitation.BookUntil.IsZero() {
+		invitation.BookUntil = invitation.BookFrom.Add(defaultBookingWindow)
+	}
+	if err := invitation.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.supplierRepo.FindByID(invitation.SupplierID); err != nil {
+		return nil, errors.New("invalid supplier: " + err.Error())
+	}
+	operation, err := s.operationRepo.FindByID(invitation.OperationID)
+	if err != nil {
+		return nil, errors.New("invalid operation: " + err.Error())
+	}
+	if invitation.ProductID != nil {
+		if _, err := s.productRepo.FindByID(*invitation.ProductID); err != nil {
+			return nil, errors.New("invalid product: " + err.Error())
+		}
+	}
+	// The link would only offer slots that can't be booked with an unqualified employee
+	if s.skillRepo != nil {
+		required, err := requiredSkills(s.skillRepo, s.productRepo, invitation.OperationID, invitation.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		missing, err := missingSkills(s.skillRepo, invitation.InviterID, required)
+		if err != nil {
+			return nil, err
+		}
+		if len(missing) > 0 {
+			return nil, unqualifiedError(missing)
+		}
+	}
+
+	token, err := newLinkToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate invitation token: %w", err)
+	}
+	invitation.ID = 0
+	invitation.TokenHash = hashLinkToken(token)
+	invitation.CreatedByID = user.ID
+	invitation.ExpiresAt = now.Add(validFor)
+	invitation.UsedAt = nil
+	invitation.AppointmentID = nil
+	invitation.RevokedAt = nil
+	if err := s.invitationRepo.Create(invitation); err != nil {
+		return nil, err
+	}
+
+	issued := &IssuedInvitation{
+		Invitation: invitation,
+		Token:      token,
+		Link:       s.invitationLink(invitation.OperationID, token),
+	}
+	if notifySupplier {
+		s.notifyInvitation(issued, operation.Name)
+	}
+	return issued, nil
+}
+
+// ListInvitations returns the invitations sent on behalf of an employee, newest first
+func (s *invitationService) ListInvitations(inviterID uint) ([]models.BookingInvitation, error) {
+	return s.invitationRepo.FindByInviter(inviterID)
+}
+
+// GetInvitation gets an invitation by ID
+func (s *invitationService) GetInvitation(id uint) (*models.BookingInvitation, error) {
+	invitation, err := s.invitationRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrInvitationNotFound
+	}
+	return invitation, nil
+}
+
+// RevokeInvitation disables an invitation's link. Appointments already booked through it are
+// kept.
+func (s *invitationService) RevokeInvitation(id uint) (*models.BookingInvitation, error) {
+	invitation, err := s.GetInvitation(id)
+	if err != nil {
+		return nil, err
+	}
+	if invitation.RevokedAt != nil {
+		return invitation, nil
+	}
+
+	now := s.clock.Now()
+	if err := s.invitationRepo.Revoke(id, now); err != nil {
+		return nil, err
+	}
+	invitation.RevokedAt = &now
+	return invitation, nil
+}
+
+// InviterFor returns the employee record of a staff user
+func (s *invitationService) InviterFor(user *models.User) (uint, error) {
+	employee, err := s.employeeRepo.FindByUserID(user.ID)
+	if err != nil {
+		return 0, ErrInviterRequired
+	}
+	return employee.ID, nil
+}
+
+// ViewInvitation describes what an invitation link offers
+func (s *invitationService) ViewInvitation(token string) (*InvitationView, error) {
+	invitation, err := s.usableInvitation(token)
+	if err != nil {
+		return nil, err
+	}
+
+	view := &InvitationView{
+		OperationID:       invitation.OperationID,
+		Type:              invitation.Type,
+		ProductID:         invitation.ProductID,
+		QuantityToDeliver: invitation.QuantityToDeliver,
+		DurationMinutes:   invitation.DurationMinutes,
+		BookFrom:          invitation.BookFrom,
+		BookUntil:         invitation.BookUntil,
+		ExpiresAt:         invitation.ExpiresAt,
+		Notes:             invitation.Notes,
+	}
+	if operation, err := s.operationRepo.FindByID(invitation.OperationID); err == nil {
+		view.OperationName = operation.Name
+	}
+	if supplier, err := s.supplierRepo.FindByID(invitation.SupplierID); err == nil {
+		view.SupplierName = supplier.CompanyName
+	}
+	if invitation.ProductID != nil {
+		if product, err := s.productRepo.FindByID(*invitation.ProductID); err == nil {
+			view.ProductName = product.Name
+		}
+	}
+	return view, nil
+}
+
+// InvitationSlots lists the slots of the invitation's length the inviting employee has open at
+// the operation between from and to, kept inside the booking window. A zero from or to means
+// the start or end of the window.
+func (s *invitationService) InvitationSlots(token string, from, to time.Time) ([]OpenSlot, error) {
+	invitation, err := s.usableInvitation(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if from.IsZero() || from.Before(invitation.BookFrom) {
+		from = invitation.BookFrom
+	}
+	// Slots may start until the end of the window, so they may also end after it
+	windowEnd := invitation.BookUntil.Add(invitation.Duration())
+	if to.IsZero() || to.After(windowEnd) {
+		to = windowEnd
+	}
+	if to.Sub(from) > maxOpenSlotRange {
+		return nil, ErrInvitationSlotsRange
+	}
+	if !to.After(from) {
+		return []OpenSlot{}, nil
+	}
+
+	open, err := s.availabilityService.OpenSlots(invitation.InviterID, &invitation.OperationID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := invitation.Duration()
+	slots := []OpenSlot{}
+	for _, period := range open {
+		start := period.Start.Truncate(invitationSlotStep)
+		if start.Before(period.Start) {
+			start = start.Add(invitationSlotStep)
+		}
+		for ; !start.Add(duration).After(period.End) && start.Before(invitation.BookUntil); start = start.Add(duration) {
+			slots = append(slots, OpenSlot{OperationID: period.OperationID, Start: start, End: start.Add(duration)})
+		}
+	}
+	return slots, nil
+}
+
+// BookInvitation creates a pending appointment through an invitation, with the inviting
+// employee and the invitation's constraints. The invitation is used up by the booking.
+func (s *invitationService) BookInvitation(token string, booking InvitationBooking) (*models.Appointment, error) {
+	invitation, err := s.usableInvitation(token)
+	if err != nil {
+		return nil, err
+	}
+
+	start := booking.ScheduledStart
+	end := start.Add(invitation.Duration())
+	if start.Before(invitation.BookFrom) || !start.Before(invitation.BookUntil) {
+		return nil, ErrSlotOutsideWindow
+	}
+
+	quantity := invitation.QuantityToDeliver
+	if quantity == 0 && invitation.Type.MovesGoods() {
+		if booking.QuantityToDeliver <= 0 {
+			return nil, ErrInvitationQuantity
+		}
+		quantity = booking.QuantityToDeliver
+	}
+
+	open, err := s.availabilityService.OpenSlots(invitation.InviterID, &invitation.OperationID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	covered := false
+	for _, period := range open {
+		if !period.Start.After(start) && !period.End.Before(end) {
+			covered = true
+			break
+		}
+	}
+	if !covered {
+		return nil, ErrSlotNotOpen
+	}
+
+	notes := booking.Notes
+	if notes == "" {
+		notes = invitation.Notes
+	}
+	appointment := &models.Appointment{
+		SupplierID:        invitation.SupplierID,
+		EmployeeID:        invitation.InviterID,
+		OperationID:       invitation.OperationID,
+		Type:              invitation.Type,
+		ProductID:         invitation.ProductID,
+		ScheduledStart:    start,
+		ScheduledEnd:      end,
+		QuantityToDeliver: quantity,
+		Notes:             notes,
+		Status:            models.StatusPending,
+	}
+
+	// Claim the invitation first so two tabs cannot book it twice; give it back if booking fails
+	claimed, err := s.invitationRepo.Claim(invitation.ID, s.clock.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, ErrInvitationUsed
+	}
+	if err := s.appointmentService.Create(appointment); err != nil {
+		if releaseErr := s.invitationRepo.Release(invitation.ID); releaseErr != nil {
+			log.Printf("Failed to release booking invitation %d: %v", invitation.ID, releaseErr)
+		}
+		return nil, err
+	}
+	if err := s.invitationRepo.SetAppointment(invitation.ID, appointment.ID); err != nil {
+		log.Printf("Failed to link booking invitation %d to appointment %d: %v", invitation.ID, appointment.ID, err)
+	}
+	return appointment, nil
+}
+
+// usableInvitation finds the invitation of a token and checks it can still be used
+func (s *invitationService) usableInvitation(token string) (*models.BookingInvitation, error) {
+	if token == "" {
+		return nil, ErrInvitationNotFound
+	}
+	invitation, err := s.invitationRepo.FindByTokenHash(hashLinkToken(token))
+	if err != nil {
+		return nil, ErrInvitationNotFound
+	}
+
+	switch {
+	case invitation.RevokedAt != nil:
+		return nil, ErrInvitationRevoked
+	case invitation.UsedAt != nil:
+		return nil, ErrInvitationUsed
+	case !s.clock.Now().Before(invitation.ExpiresAt):
+		return nil, ErrInvitationExpired
+	}
+	return invitation, nil
+}
+
+// invitationLink returns the portal link of an invitation token
+func (s *invitationService) invitationLink(operationID uint, token string) string {
+	fallback := ""
+	if s.config != nil && s.config.Notification != nil {
+		fallback = s.config.Notification.LinkBaseURL
+	}
+	return buildLink(portalURL(s.operationRepo, operationID, fallback), "book", token)
+}
+
+// notifyInvitation emails the invited supplier the booking link
+func (s *invitationService) notifyInvitation(issued *IssuedInvitation, operationName string) {
+	if s.notificationService == nil {
+		return
+	}
+
+	invitation := issued.Invitation
+	notification := &models.Notification{
+		Type:          models.NotificationTypeEmail,
+		Status:        models.NotificationStatusPending,
+		Event:         models.EventBookingInvitation,
+		RecipientType: models.RecipientSupplier,
+		RecipientID:   invitation.SupplierID,
+		Subject:       fmt.Sprintf("Book your appointment at %s", operationName),
+		Body: fmt.Sprintf("You are invited to book a %s at %s. The link works until %s: %s",
+			strings.ToLower(invitation.Type.Label()), operationName, invitation.ExpiresAt.Format("2006-01-02 15:04"), issued.Link),
+	}
+	if err := s.notificationService.EnqueueNotification(notification, QueueAppointmentNotifications, 1); err != nil {
+		log.Printf("Failed to enqueue booking invitation %d: %v", invitation.ID, err)
+	}
+}
+
+// newLinkToken returns a random URL-safe token for a secret link
+func newLinkToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashLinkToken returns the stored form of a secret link token
+func hashLinkToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+)
+
+// Broadcast errors
+var (
+	ErrInvalidBroadcastMessage = errors.New("invalid broadcast message")
+	ErrBroadcastAudienceEmpty  = errors.New("broadcast audience is empty: nobody matches it")
+	ErrBroadcastAudienceLimit  = errors.New("broadcast audience too large: narrow it down")
+	ErrBroadcastSendAt         = errors.New("invalid broadcast send time")
+)
+
+// broadcastPreviewSamples is how many rendered messages a preview shows
+const broadcastPreviewSamples = 3
+
+// BroadcastMessage is a broadcast rendered for one recipient
+type BroadcastMessage struct {
+	RecipientType models.NotificationRecipientType `json:"recipient_type"`
+	RecipientID   uint                             `json:"recipient_id"`
+	Name          string                           `json:"name"`
+	Subject       string                           `json:"subject"`
+	Body          string                           `json:"body"`
+}
+
+// BroadcastPreview is what sending a broadcast would do, without sending it
+type BroadcastPreview struct {
+	Recipients int                `json:"recipients"`
+	SendAt     time.Time          `json:"send_at"`
+	Samples    []BroadcastMessage `json:"samples"` // the messages of the first recipients
+}
+
+// BroadcastService defines the interface for the ad-hoc messages admins send to an audience
+type BroadcastService interface {
+	Preview(broadcast *models.Broadcast) (*BroadcastPreview, error)
+	Send(broadcast *models.Broadcast, user *models.User) (*models.Broadcast, error)
+	List(limit int) ([]models.Broadcast, error)
+}
+
+// broadcastService implements the BroadcastService interface
+type broadcastService struct {
+	broadcastRepo       repository.BroadcastRepository
+	operationRepo       repository.OperationRepository
+	recipients          RecipientResolver
+	notificationService NotificationService
+	config              config.BroadcastConfig
+	clock               clock.Clock
+}
+
+// NewBroadcastService creates a new broadcast service
+func NewBroadcastService(
+	broadcastRepo repository.BroadcastRepository,
+	operationRepo repository.OperationRepository,
+	recipients RecipientResolver,
+	notificationService NotificationService,
+	config config.BroadcastConfig,
+	clock clock.Clock,
+) BroadcastService {
+	return &broadcastService{
+		broadcastRepo:       broadcastRepo,
+		operationRepo:       operationRepo,
+		recipients:          recipients,
+		notificationService: notificationService,
+		config:              config,
+		clock:               clock,
+	}
+}
+
+// broadcastTarget is the audience of a broadcast resolved to notification recipients
+type broadcastTarget struct {
+	recipientType models.NotificationRecipientType
+	ids           []uint
+	operation     *models.Operation // nil unless the broadcast is about an operation
+}
+
+// Preview renders a broadcast for its first recipients and counts its audience, sending nothing
+func (s *broadcastService) Preview(broadcast *models.Broadcast) (*BroadcastPreview, error) {
+	target, err := s.prepare(broadcast)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &BroadcastPreview{Recipients: len(target.ids), SendAt: broadcast.SendAt, Samples: []BroadcastMessage{}}
+	for _, id := range target.ids {
+		if len(preview.Samples) == broadcastPreviewSamples {
+			break
+		}
+		message, err := s.render(broadcast, target, id)
+		if err != nil {
+			return nil, err
+		}
+		preview.Samples = append(preview.Samples, *message)
+	}
+	return preview, nil
+}
+
+// Send queues a broadcast for every recipient of its audience, held until its send time. A
+// recipient whose notification can't be queued is logged and counted, and doesn't stop the rest.
+func (s *broadcastService) Send(broadcast *models.Broadcast, user *models.User) (*models.Broadcast, error) {
+	target, err := s.prepare(broadcast)
+	if err != nil {
+		return nil, err
+	}
+
+	broadcast.ID = 0
+	broadcast.CreatedByID = user.ID
+	broadcast.RecipientCount = 0
+	broadcast.FailedCount = 0
+	if err := s.broadcastRepo.Create(broadcast); err != nil {
+		return nil, fmt.Errorf("failed to record broadcast: %w", err)
+	}
+	metadata, _ := json.Marshal(map[string]interface{}{"broadcast_id": broadcast.ID})
+	sendAt := broadcast.SendAt
+
+	for _, id := range target.ids {
+		message, err := s.render(broadcast, target, id)
+		if err == nil {
+			err = s.notificationService.EnqueueNotification(&models.Notification{
+				Type:          broadcast.Channel,
+				Status:        models.NotificationStatusPending,
+				Event:         models.EventBroadcast,
+				RecipientType: message.RecipientType,
+				RecipientID:   message.RecipientID,
+				Subject:       message.Subject,
+				Body:          message.Body,
+				ScheduledFor:  &sendAt,
+				Metadata:      string(metadata),
+			}, QueueAppointmentNotifications, 1)
+		}
+		if err != nil {
+			log.Printf("Failed to queue broadcast %d for %s %d: %v", broadcast.ID, target.recipientType, id, err)
+			broadcast.FailedCount++
+			continue
+		}
+		broadcast.RecipientCount++
+	}
+
+	log.Printf("Broadcast %d by user %d queued for %d %ss, %d failed, sending at %s",
+		broadcast.ID, user.ID, broadcast.RecipientCount, target.recipientType, broadcast.FailedCount, sendAt.Format(time.RFC3339))
+	if err := s.broadcastRepo.Update(broadcast); err != nil {
+		log.Printf("Failed to record the recipients of broadcast %d: %v", broadcast.ID, err)
+	}
+	return broadcast, nil
+}
+
+// List returns the latest broadcasts
+func (s *broadcastService) List(limit int) ([]models.Broadcast, error) {
+	return s.broadcastRepo.List(limit)
+}
+
+// prepare validates a broadcast, defaults its send time to now and resolves its audience
+func (s *broadcastService) prepare(broadcast *models.Broadcast) (*broadcastTarget, error) {
+	if broadcast.Channel == "" {
+		broadcast.Channel = models.NotificationTypeEmail
+	}
+	if err := broadcast.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	if broadcast.SendAt.IsZero() {
+		broadcast.SendAt = now
+	}
+	if broadcast.SendAt.Before(now.Add(-time.Minute)) {
+		return nil, fmt.Errorf("%w: it is in the past", ErrBroadcastSendAt)
+	}
+	if broadcast.SendAt.After(now.Add(s.config.MaxScheduleAhead)) {
+		return nil, fmt.Errorf("%w: broadcasts can be scheduled at most %s ahead", ErrBroadcastSendAt, s.config.MaxScheduleAhead)
+	}
+
+	target := &broadcastTarget{recipientType: models.RecipientSupplier}
+	if broadcast.Audience == models.BroadcastEmployees {
+		target.recipientType = models.RecipientEmployee
+	}
+	if broadcast.OperationID != nil {
+		operation, err := s.operationRepo.FindByID(*broadcast.OperationID)
+		if err != nil {
+			return nil, err
+		}
+		target.operation = operation
+	}
+	if err := s.validateMessage(broadcast, target); err != nil {
+		return nil, err
+	}
+
+	// One more than allowed tells a full audience from one over the limit
+	limit := s.config.MaxRecipients + 1
+	var err error
+	switch broadcast.Audience {
+	case models.BroadcastEmployees:
+		target.ids, err = s.broadcastRepo.EmployeeAudience(limit)
+	case models.BroadcastOperationSuppliers:
+		target.ids, err = s.broadcastRepo.SupplierAudience(broadcast.OperationID, nil, nil, limit)
+	case models.BroadcastSuppliersInRange:
+		target.ids, err = s.broadcastRepo.SupplierAudience(broadcast.OperationID, broadcast.RangeFrom, broadcast.RangeTo, limit)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the broadcast audience: %w", err)
+	}
+
+	switch {
+	case len(target.ids) == 0:
+		return nil, ErrBroadcastAudienceEmpty
+	case len(target.ids) > s.config.MaxRecipients:
+		return nil, fmt.Errorf("%w: it reaches more than %d recipients", ErrBroadcastAudienceLimit, s.config.MaxRecipients)
+	}
+	return target, nil
+}
+
+// validateMessage checks the subject and body render with sample recipient data, so a broken
+// template is refused before anything is queued
+func (s *broadcastService) validateMessage(broadcast *models.Broadcast, target *broadcastTarget) error {
+	sample := &Recipient{Type: target.recipientType, Name: "Maria Silva"}
+	if target.recipientType == models.RecipientSupplier {
+		sample.Company = "Acme Foods Ltda"
+	}
+	_, _, err := s.renderFor(broadcast, target, sample)
+	return err
+}
+
+// render resolves a recipient and renders the broadcast for them
+func (s *broadcastService) render(broadcast *models.Broadcast, target *broadcastTarget, id uint) (*BroadcastMessage, error) {
+	recipient, err := s.recipients.Resolve(target.recipientType, id)
+	if err != nil {
+		return nil, err
+	}
+	subject, body, err := s.renderFor(broadcast, target, recipient)
+	if err != nil {
+		return nil, err
+	}
+	return &BroadcastMessage{
+		RecipientType: target.recipientType,
+		RecipientID:   id,
+		Name:          recipient.DisplayName(),
+		Subject:       subject,
+		Body:          body,
+	}, nil
+}
+
+// renderFor renders a broadcast's subject and body for a recipient. Templates may use the
+// notification template helpers and:
+//
+//	{{.name}}            the supplier's company or the employee's name
+//	{{.recipient_name}}  the name of the person behind the account
+//	{{.company}}         the supplier's company, empty for employees
+//	{{.operation_name}}  the operation the broadcast is about, if any
+func (s *broadcastService) renderFor(broadcast *models.Broadcast, target *broadcastTarget, recipient *Recipient) (string, string, error) {
+	data := map[string]interface{}{
+		"name":           recipient.DisplayName(),
+		"recipient_name": recipient.Name,
+		"company":        recipient.Company,
+		"operation_name": "",
+	}
+	if target.operation != nil {
+		data["operation_id"] = target.operation.ID
+		data["operation_name"] = target.operation.Name
+	}
+
+	subject, body, _, err := s.notificationService.RenderTemplate(&models.NotificationTemplate{
+		Subject:  broadcast.Subject,
+		BodyText: broadcast.Body,
+	}, data)
+	if err != nil {
+		return "", "", fmt.Errorf("%w: %v", ErrInvalidBroadcastMessage, err)
+	}
+	return strings.TrimSpace(subject), strings.TrimSpace(body), nil
+}
@@ -0,0 +1,359 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+)
+
+// Calendar feed errors
+var (
+	ErrCalendarFeedNotFound    = errors.New("calendar feed not found")
+	ErrCalendarFeedLimit       = errors.New("calendar feed limit reached: delete a feed before adding another")
+	ErrCalendarFeedAssignments = errors.New("only staff with an employee record can subscribe to their assignments")
+)
+
+// icalTimeFormat is how iCalendar writes UTC times
+const icalTimeFormat = "20060102T150405Z"
+
+// icalRefreshInterval is how often calendar apps are asked to fetch a feed again
+const icalRefreshInterval = "PT15M"
+
+// IssuedCalendarFeed is a calendar feed together with its subscription link. The token is only
+// available when the feed is created or its token is rotated.
+type IssuedCalendarFeed struct {
+	Feed  *models.CalendarFeed `json:"feed"`
+	Token string               `json:"token"`
+	URL   string               `json:"url"`
+}
+
+// CalendarFeedService defines the interface for the filtered iCalendar feeds users subscribe to
+// from calendar apps
+type CalendarFeedService interface {
+	CreateFeed(user *models.User, feed *models.CalendarFeed) (*IssuedCalendarFeed, error)
+	ListFeeds(user *models.User) ([]models.CalendarFeed, error)
+	GetFeed(user *models.User, id uint) (*models.CalendarFeed, error)
+	UpdateFeed(user *models.User, id uint, changes *models.CalendarFeed) (*models.CalendarFeed, error)
+	RotateToken(user *models.User, id uint) (*IssuedCalendarFeed, error)
+	DeleteFeed(user *models.User, id uint) error
+	RenderFeed(token string) ([]byte, error)
+}
+
+// calendarFeedService implements the CalendarFeedService interface
+type calendarFeedService struct {
+	feedRepo      repository.CalendarFeedRepository
+	userRepo      repository.UserRepository
+	employeeRepo  repository.EmployeeRepository
+	supplierRepo  repository.SupplierRepository
+	operationRepo repository.OperationRepository
+	config        *config.Config
+	clock         clock.Clock
+}
+
+// NewCalendarFeedService creates a new calendar feed service
+func NewCalendarFeedService(
+	feedRepo repository.CalendarFeedRepository,
+	userRepo repository.UserRepository,
+	employeeRepo repository.EmployeeRepository,
+	supplierRepo repository.SupplierRepository,
+	operationRepo repository.OperationRepository,
+	config *config.Config,
+	clock clock.Clock,
+) CalendarFeedService {
+	return &calendarFeedService{
+		feedRepo:      feedRepo,
+		userRepo:      userRepo,
+		employeeRepo:  employeeRepo,
+		supplierRepo:  supplierRepo,
+		operationRepo: operationRepo,
+		config:        config,
+		clock:         clock,
+	}
+}
+
+// CreateFeed adds a calendar feed for a user and returns its subscription link
+func (s *calendarFeedService) CreateFeed(user *models.User, feed *models.CalendarFeed) (*IssuedCalendarFeed, error) {
+	count, err := s.feedRepo.CountByUser(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= int64(s.config.CalendarFeeds.MaxPerUser) {
+		return nil, ErrCalendarFeedLimit
+	}
+	if err := s.validate(user, feed); err != nil {
+		return nil, err
+	}
+
+	token, err := newLinkToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate calendar feed token: %w", err)
+	}
+	feed.ID = 0
+	feed.UserID = user.ID
+	feed.Name = strings.TrimSpace(feed.Name)
+	feed.TokenHash = hashLinkToken(token)
+	feed.LastFetchedAt = nil
+	if err := s.feedRepo.Create(feed); err != nil {
+		return nil, err
+	}
+	return s.issue(feed, token), nil
+}
+
+// ListFeeds returns a user's calendar feeds
+func (s *calendarFeedService) ListFeeds(user *models.User) ([]models.CalendarFeed, error) {
+	return s.feedRepo.FindByUser(user.ID)
+}
+
+// GetFeed returns one of a user's calendar feeds
+func (s *calendarFeedService) GetFeed(user *models.User, id uint) (*models.CalendarFeed, error) {
+	feed, err := s.feedRepo.FindByID(id)
+	if err != nil || feed.UserID != user.ID {
+		return nil, ErrCalendarFeedNotFound
+	}
+	return feed, nil
+}
+
+// UpdateFeed renames a calendar feed and replaces its filters. Its link stays the same.
+func (s *calendarFeedService) UpdateFeed(user *models.User, id uint, changes *models.CalendarFeed) (*models.CalendarFeed, error) {
+	feed, err := s.GetFeed(user, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validate(user, changes); err != nil {
+		return nil, err
+	}
+
+	feed.Name = strings.TrimSpace(changes.Name)
+	feed.OperationID = changes.OperationID
+	feed.Status = changes.Status
+	feed.Type = changes.Type
+	feed.AssignedOnly = changes.AssignedOnly
+	if err := s.feedRepo.Update(feed); err != nil {
+		return nil, err
+	}
+	return feed, nil
+}
+
+// RotateToken replaces the link of a calendar feed, for when it was shared by mistake. Calendar
+// apps subscribed through the old link stop getting updates.
+func (s *calendarFeedService) RotateToken(user *models.User, id uint) (*IssuedCalendarFeed, error) {
+	feed, err := s.GetFeed(user, id)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := newLinkToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate calendar feed token: %w", err)
+	}
+	feed.TokenHash = hashLinkToken(token)
+	if err := s.feedRepo.Update(feed); err != nil {
+		return nil, err
+	}
+	return s.issue(feed, token), nil
+}
+
+// DeleteFeed deletes one of a user's calendar feeds; its link stops working
+func (s *calendarFeedService) DeleteFeed(user *models.User, id uint) error {
+	feed, err := s.GetFeed(user, id)
+	if err != nil {
+		return err
+	}
+	return s.feedRepo.Delete(feed.ID)
+}
+
+// RenderFeed returns the iCalendar document of the feed a link token belongs to. What the feed
+// lists is decided by its owner's role when it is fetched: suppliers only ever get their own
+// appointments, and feeds of deactivated users stop working.
+func (s *calendarFeedService) RenderFeed(token string) ([]byte, error) {
+	feed, err := s.feedRepo.FindByTokenHash(hashLinkToken(token))
+	if err != nil {
+		return nil, ErrCalendarFeedNotFound
+	}
+	user, err := s.userRepo.GetByID(feed.UserID)
+	if err != nil || !user.Active {
+		return nil, ErrCalendarFeedNotFound
+	}
+
+	now := s.clock.Now()
+	scope := repository.CalendarFeedScope{
+		OperationID: feed.OperationID,
+		Status:      feed.Status,
+		Type:        feed.Type,
+		From:        now.Add(-s.config.CalendarFeeds.Past),
+		To:          now.Add(s.config.CalendarFeeds.Ahead),
+		Limit:       s.config.CalendarFeeds.MaxEvents,
+	}
+	if user.Role == "supplier" {
+		supplier, err := s.supplierRepo.FindByUserID(user.ID)
+		if err != nil {
+			return nil, ErrCalendarFeedNotFound
+		}
+		scope.SupplierID = &supplier.ID
+	} else if feed.AssignedOnly {
+		employee, err := s.employeeRepo.FindByUserID(user.ID)
+		if err != nil {
+			return nil, ErrCalendarFeedAssignments
+		}
+		scope.EmployeeID = &employee.ID
+	}
+
+	appointments, err := s.feedRepo.Appointments(scope)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list calendar feed appointments: %w", err)
+	}
+	if err := s.feedRepo.MarkFetched(feed.ID, now); err != nil {
+		log.Printf("Failed to record the fetch of calendar feed %d: %v", feed.ID, err)
+	}
+	return s.renderICal(feed, appointments, now), nil
+}
+
+// validate checks a feed's name and filters, and that the user can subscribe to their
+// assignments when the feed is limited to them
+func (s *calendarFeedService) validate(user *models.User, feed *models.CalendarFeed) error {
+	if err := feed.Validate(); err != nil {
+		return err
+	}
+	if feed.OperationID != nil {
+		if _, err := s.operationRepo.FindByID(*feed.OperationID); err != nil {
+			return err
+		}
+	}
+	if feed.AssignedOnly {
+		if user.Role == "supplier" {
+			return ErrCalendarFeedAssignments
+		}
+		if _, err := s.employeeRepo.FindByUserID(user.ID); err != nil {
+			return ErrCalendarFeedAssignments
+		}
+	}
+	return nil
+}
+
+// issue returns a feed with its subscription link
+func (s *calendarFeedService) issue(feed *models.CalendarFeed, token string) *IssuedCalendarFeed {
+	return &IssuedCalendarFeed{
+		Feed:  feed,
+		Token: token,
+		URL:   s.config.CalendarFeeds.URL + "/" + token + ".ics",
+	}
+}
+
+// renderICal writes the appointments of a feed as an iCalendar (RFC 5545) document. Events keep
+// the UIDs of single appointment downloads, and cancelled appointments are published with the
+// CANCELLED status so calendar apps strike them out instead of silently dropping them.
+func (s *calendarFeedService) renderICal(feed *models.CalendarFeed, appointments []models.Appointment, now time.Time) []byte {
+	host := strings.TrimPrefix(s.config.Server.BaseURL, "https://")
+
+	var buffer bytes.Buffer
+	writeICalLine(&buffer, "BEGIN:VCALENDAR")
+	writeICalLine(&buffer, "VERSION:2.0")
+	writeICalLine(&buffer, "PRODID:-//Scheduling API//Calendar Feed//EN")
+	writeICalLine(&buffer, "CALSCALE:GREGORIAN")
+	writeICalLine(&buffer, "METHOD:PUBLISH")
+	writeICalLine(&buffer, "X-WR-CALNAME:"+icalText(feed.Name))
+	writeICalLine(&buffer, "REFRESH-INTERVAL;VALUE=DURATION:"+icalRefreshInterval)
+	writeICalLine(&buffer, "X-PUBLISHED-TTL:"+icalRefreshInterval)
+
+	for i := range appointments {
+		appointment := &appointments[i]
+		writeICalLine(&buffer, "BEGIN:VEVENT")
+		writeICalLine(&buffer, fmt.Sprintf("UID:appointment-%d@%s", appointment.ID, host))
+		writeICalLine(&buffer, "DTSTAMP:"+now.UTC().Format(icalTimeFormat))
+		writeICalLine(&buffer, "LAST-MODIFIED:"+appointment.UpdatedAt.UTC().Format(icalTimeFormat))
+		writeICalLine(&buffer, "DTSTART:"+appointment.ScheduledStart.UTC().Format(icalTimeFormat))
+		writeICalLine(&buffer, "DTEND:"+appointment.ScheduledEnd.UTC().Format(icalTimeFormat))
+		writeICalLine(&buffer, "SUMMARY:"+icalText(appointmentSummary(appointment, appointment.Supplier.CompanyName, appointment.Product.Name)))
+		writeICalLine(&buffer, "DESCRIPTION:"+icalText(feedEventDescription(appointment)))
+		if appointment.Operation.Name != "" {
+			writeICalLine(&buffer, "LOCATION:"+icalText(strings.TrimSuffix(appointment.Operation.Name+", "+appointment.Operation.Address, ", ")))
+		}
+		writeICalLine(&buffer, "STATUS:"+icalStatus(appointment.Status))
+		writeICalLine(&buffer, fmt.Sprintf("URL:%s/appointments/%d", s.appointmentPortal(appointment), appointment.ID))
+		writeICalLine(&buffer, "END:VEVENT")
+	}
+
+	writeICalLine(&buffer, "END:VCALENDAR")
+	return buffer.Bytes()
+}
+
+// appointmentPortal returns the portal base URL of an appointment's preloaded operation
+func (s *calendarFeedService) appointmentPortal(appointment *models.Appointment) string {
+	if portal := strings.TrimSpace(appointment.Operation.PortalURL); portal != "" {
+		return strings.TrimRight(portal, "/")
+	}
+	return strings.TrimRight(s.config.Server.BaseURL, "/")
+}
+
+// feedEventDescription returns the details a feed event lists about an appointment
+func feedEventDescription(appointment *models.Appointment) string {
+	lines := []string{
+		"Booking code: " + appointment.Reference(),
+		"Type: " + appointment.Type.Label(),
+		"Status: " + string(appointment.Status),
+		"Supplier: " + appointment.Supplier.CompanyName,
+		"Employee: " + appointment.Employee.User.Name,
+	}
+	if appointment.Type.MovesGoods() {
+		lines = append(lines,
+			"Product: "+appointment.Product.Name,
+			fmt.Sprintf("Quantity: %d", appointment.QuantityToDeliver))
+	}
+	if appointment.PurchaseOrder != "" {
+		lines = append(lines, "Purchase order: "+appointment.PurchaseOrder)
+	}
+	if appointment.Notes != "" {
+		lines = append(lines, "", "Notes: "+appointment.Notes)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// icalStatus maps an appointment status to the status of its event
+func icalStatus(status models.AppointmentStatus) string {
+	switch status {
+	case models.StatusCancelled:
+		return "CANCELLED"
+	case models.StatusPending, models.StatusRescheduled, models.StatusReserved:
+		return "TENTATIVE"
+	}
+	return "CONFIRMED"
+}
+
+// icalText escapes text for an iCalendar property value
+func icalText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", "",
+	).Replace(value)
+}
+
+// writeICalLine writes a content line, folded to lines of at most 75 octets without splitting
+// a character, and ends it with CRLF
+func writeICalLine(buffer *bytes.Buffer, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		buffer.WriteString(line[:cut])
+		buffer.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74 // continuation lines start with a space
+	}
+	buffer.WriteString(line)
+	buffer.WriteString("\r\n")
+}
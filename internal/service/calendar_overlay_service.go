@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/circuitbreaker"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+	"github.com/bernardofernandezz/scheduling-api/pkg/freebusy"
+)
+
+// Work calendar errors
+var (
+	ErrCalendarNotConnected = errors.New("no work calendar connected")
+	ErrCalendarProvider     = errors.New("calendar provider is not configured")
+	ErrCalendarEmployee     = errors.New("only staff with an employee record can connect a work calendar")
+	ErrCalendarUnreadable   = errors.New("work calendar could not be read")
+)
+
+// CalendarOverlayService defines the interface for the work calendars employees connect, whose
+// busy times availability treats as unavailable
+type CalendarOverlayService interface {
+	Connect(ctx context.Context, user *models.User, calendar *models.EmployeeCalendar) (*models.EmployeeCalendar, error)
+	GetConnection(user *models.User) (*models.EmployeeCalendar, error)
+	Disconnect(user *models.User) error
+	RefreshAll(ctx context.Context) (int, error)
+}
+
+// calendarOverlayService implements the CalendarOverlayService interface
+type calendarOverlayService struct {
+	calendarRepo repository.EmployeeCalendarRepository
+	employeeRepo repository.EmployeeRepository
+	providers    map[string]freebusy.Provider
+	breakers     *circuitbreaker.Registry
+	config       config.CalendarOverlayConfig
+	clock        clock.Clock
+}
+
+// NewFreeBusyProviders creates the free/busy providers whose OAuth client is configured, keyed by
+// calendar provider
+func NewFreeBusyProviders(cfg config.CalendarOverlayConfig, httpClient *http.Client) map[string]freebusy.Provider {
+	var transport http.RoundTripper
+	if httpClient != nil {
+		transport = httpClient.Transport
+	}
+
+	providers := make(map[string]freebusy.Provider)
+	if cfg.GoogleClientID != "" {
+		providers[models.CalendarProviderGoogle] = freebusy.NewGoogle(cfg.GoogleClientID, cfg.GoogleClientSecret, cfg.GoogleBaseURL, cfg.Timeout, transport)
+	}
+	if cfg.MicrosoftClientID != "" {
+		providers[models.CalendarProviderMicrosoft] = freebusy.NewMicrosoft(cfg.MicrosoftClientID, cfg.MicrosoftClientSecret, cfg.MicrosoftTenant, cfg.MicrosoftBaseURL, cfg.Timeout, transport)
+	}
+	return providers
+}
+
+// NewCalendarOverlayService creates a new work calendar service
+func NewCalendarOverlayService(
+	calendarRepo repository.EmployeeCalendarRepository,
+	employeeRepo repository.EmployeeRepository,
+	providers map[string]freebusy.Provider,
+	breakers *circuitbreaker.Registry,
+	config config.CalendarOverlayConfig,
+	clock clock.Clock,
+) CalendarOverlayService {
+	return &calendarOverlayService{
+		calendarRepo: calendarRepo,
+		employeeRepo: employeeRepo,
+		providers:    providers,
+		breakers:     breakers,
+		config:       config,
+		clock:        clock,
+	}
+}
+
+// Connect connects the user's work calendar, replacing the one connected before. The calendar is
+// read right away so a token that can't read it is refused; when the provider is short-circuited
+// the calendar is connected anyway and read by the next refresh.
+func (s *calendarOverlayService) Connect(ctx context.Context, user *models.User, calendar *models.EmployeeCalendar) (*models.EmployeeCalendar, error) {
+	employee, err := s.employeeRepo.FindByUserID(user.ID)
+	if err != nil {
+		return nil, ErrCalendarEmployee
+	}
+	if err := calendar.Validate(); err != nil {
+		return nil, err
+	}
+	if s.providers[calendar.Provider] == nil {
+		return nil, fmt.Errorf("%w: %s", ErrCalendarProvider, calendar.Provider)
+	}
+
+	calendar.ID = 0
+	calendar.EmployeeID = employee.ID
+	calendar.LastRefreshedAt = nil
+	calendar.LastError = ""
+	if existing, err := s.calendarRepo.FindByEmployee(employee.ID); err == nil {
+		calendar.ID = existing.ID
+		calendar.CreatedAt = existing.CreatedAt
+	} else if !errors.Is(err, repository.ErrEmployeeCalendarNotFound) {
+		return nil, err
+	}
+
+	busy, err := s.read(ctx, calendar)
+	if errors.Is(err, ErrCalendarSyncSkipped) {
+		calendar.LastError = err.Error()
+		if err := s.calendarRepo.Save(calendar); err != nil {
+			return nil, err
+		}
+		return calendar, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCalendarUnreadable, err)
+	}
+
+	now := s.clock.Now()
+	calendar.LastRefreshedAt = &now
+	if err := s.calendarRepo.Save(calendar); err != nil {
+		return nil, err
+	}
+	if err := s.calendarRepo.ReplaceBusyTimes(employee.ID, busy); err != nil {
+		return nil, err
+	}
+	return calendar, nil
+}
+
+// GetConnection returns the work calendar the user connected
+func (s *calendarOverlayService) GetConnection(user *models.User) (*models.EmployeeCalendar, error) {
+	employee, err := s.employeeRepo.FindByUserID(user.ID)
+	if err != nil {
+		return nil, ErrCalendarNotConnected
+	}
+	calendar, err := s.calendarRepo.FindByEmployee(employee.ID)
+	if err != nil {
+		if errors.Is(err, repository.ErrEmployeeCalendarNotFound) {
+			return nil, ErrCalendarNotConnected
+		}
+		return nil, err
+	}
+	return calendar, nil
+}
+
+// Disconnect disconnects the user's work calendar. Its busy times stop counting right away.
+func (s *calendarOverlayService) Disconnect(user *models.User) error {
+	employee, err := s.employeeRepo.FindByUserID(user.ID)
+	if err != nil {
+		return ErrCalendarNotConnected
+	}
+	deleted, err := s.calendarRepo.Delete(employee.ID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrCalendarNotConnected
+	}
+	return nil
+}
+
+// RefreshAll reads the busy times of every connected calendar again. A calendar that can't be
+// read keeps the busy times read before and records why, which the employee sees on their
+// connection. Returns the number of calendars refreshed.
+func (s *calendarOverlayService) RefreshAll(ctx context.Context) (int, error) {
+	calendars, err := s.calendarRepo.FindAll()
+	if err != nil {
+		return 0, err
+	}
+
+	refreshed := 0
+	for i := range calendars {
+		if ctx.Err() != nil {
+			return refreshed, ctx.Err()
+		}
+		calendar := &calendars[i]
+
+		busy, err := s.read(ctx, calendar)
+		switch {
+		case errors.Is(err, ErrCalendarSyncSkipped):
+			continue
+		case err != nil:
+			log.Printf("Failed to refresh the work calendar of employee %d: %v", calendar.EmployeeID, err)
+			calendar.LastError = err.Error()
+		default:
+			if err := s.calendarRepo.ReplaceBusyTimes(calendar.EmployeeID, busy); err != nil {
+				return refreshed, err
+			}
+			now := s.clock.Now()
+			calendar.LastRefreshedAt = &now
+			calendar.LastError = ""
+			refreshed++
+		}
+		// Keep the token the provider refreshed, and the reason a read failed
+		if err := s.calendarRepo.Save(calendar); err != nil {
+			return refreshed, err
+		}
+	}
+	return refreshed, nil
+}
+
+// read reads a calendar's busy times from now to the horizon, updating its token when the
+// provider refreshed it. Returns ErrCalendarSyncSkipped when the provider is short-circuited.
+func (s *calendarOverlayService) read(ctx context.Context, calendar *models.EmployeeCalendar) ([]models.EmployeeBusyTime, error) {
+	provider := s.providers[calendar.Provider]
+	if provider == nil {
+		return nil, fmt.Errorf("%w: %s", ErrCalendarProvider, calendar.Provider)
+	}
+
+	from := s.clock.Now()
+	to := from.Add(s.config.Horizon)
+	token := freebusy.Token{AccessToken: calendar.AccessToken, RefreshToken: calendar.RefreshToken, Expiry: calendar.TokenExpiry}
+
+	var periods []freebusy.Period
+	var revoked error
+	retryAt, err := callProvider(s.breakers, s.clock, calendarBreaker(calendar.Provider), "keep_busy_times", func() error {
+		var err error
+		periods, token, err = provider.Busy(ctx, token, calendar.CalendarID, from, to)
+		// A revoked token is the employee's to fix; it says nothing about the provider's health
+		if errors.Is(err, freebusy.ErrUnauthorized) {
+			revoked = err
+			return nil
+		}
+		return err
+	})
+	if !retryAt.IsZero() {
+		return nil, ErrCalendarSyncSkipped
+	}
+	if err == nil {
+		err = revoked
+	}
+
+	calendar.AccessToken = token.AccessToken
+	calendar.RefreshToken = token.RefreshToken
+	calendar.TokenExpiry = token.Expiry
+	if err != nil {
+		return nil, err
+	}
+
+	busy := make([]models.EmployeeBusyTime, 0, len(periods))
+	for _, period := range periods {
+		if period.End.After(period.Start) {
+			busy = append(busy, models.EmployeeBusyTime{EmployeeID: calendar.EmployeeID, StartsAt: period.Start.UTC(), EndsAt: period.End.UTC()})
+		}
+	}
+	return busy, nil
+}
+
+// calendarBreaker returns the circuit breaker guarding a calendar provider
+func calendarBreaker(provider string) string {
+	if provider == models.CalendarProviderMicrosoft {
+		return ProviderMicrosoftCalendar
+	}
+	return ProviderGoogleCalendar
+}
@@ -3,20 +3,22 @@ package service
 import (
 	"bytes"
 	"context"
-	"encoding/base64"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"net/url"
 	"strings"
 	"time"
 
 	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/metrics"
 	"github.com/bernardofernandezz/scheduling-api/internal/models"
 	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/circuitbreaker"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
 	"github.com/google/uuid"
 	"golang.org/x/oauth2"
-	"golang.org/x/oauth2/google"
 	"google.golang.org/api/calendar/v3"
 	"google.golang.org/api/option"
 )
@@ -35,6 +37,13 @@ const (
 	ICalFormat CalendarProvider = "ical"
 )
 
+// ErrCalendarSyncSkipped is returned when a sync is skipped because the provider is short-circuited
+var ErrCalendarSyncSkipped = errors.New("calendar sync skipped: provider temporarily unavailable")
+
+// ErrCalendarPreferencesUnsupported is returned when saving calendar preferences, which have no
+// store yet
+var ErrCalendarPreferencesUnsupported = errors.New("calendar preferences can't be saved yet")
+
 // CalendarService defines the interface for calendar operations
 type CalendarService interface {
 	// iCalendar operations
@@ -63,37 +72,141 @@ type CalendarService interface {
 // calendarService implements the CalendarService interface
 type calendarService struct {
 	appointmentRepo   repository.AppointmentRepository
-	employeeRepo      repository.EmployeeRepository
-	supplierRepo      repository.SupplierRepository
+	recipients        RecipientResolver
+	operationRepo     repository.OperationRepository
+	productRepo       repository.ProductRepository
 	userRepo          repository.UserRepository
 	calendarSyncRepo  repository.CalendarSyncRepository
 	config            *config.Config
+	breakers          *circuitbreaker.Registry
+	httpClient        *http.Client // shared outbound client, rate limiting and retrying Google API calls
 	baseURL           string
+	clock             clock.Clock
 }
 
 // NewCalendarService creates a new calendar service
 func NewCalendarService(
 	appointmentRepo repository.AppointmentRepository,
-	employeeRepo repository.EmployeeRepository,
-	supplierRepo repository.SupplierRepository,
+	recipients RecipientResolver,
+	operationRepo repository.OperationRepository,
+	productRepo repository.ProductRepository,
 	userRepo repository.UserRepository,
 	calendarSyncRepo repository.CalendarSyncRepository,
 	config *config.Config,
+	breakers *circuitbreaker.Registry,
+	httpClient *http.Client,
+	clock clock.Clock,
 ) CalendarService {
 	baseURL := "https://scheduling-api.example.com"
-	if config != nil && config.Server != nil && config.Server.BaseURL != "" {
+	if config != nil && config.Server.BaseURL != "" {
 		baseURL = config.Server.BaseURL
 	}
 	
 	return &calendarService{
 		appointmentRepo:   appointmentRepo,
-		employeeRepo:      employeeRepo,
-		supplierRepo:      supplierRepo,
+		recipients:        recipients,
+		operationRepo:     operationRepo,
+		productRepo:       productRepo,
 		userRepo:          userRepo,
 		calendarSyncRepo:  calendarSyncRepo,
 		config:            config,
+		breakers:          breakers,
+		httpClient:        httpClient,
 		baseURL:           baseURL,
+		clock:             clock,
+	}
+}
+
+// portalURL returns the portal base URL for links to an operation's appointments. The
+// default base URL is still used for event UIDs so they stay stable if the portal moves.
+func (s *calendarService) portalURL(operationID uint) string {
+	return portalURL(s.operationRepo, operationID, s.baseURL)
+}
+
+// googleClient returns a client authorizing Google API calls with an access token. Requests go
+// through the shared outbound client when there is one.
+func (s *calendarService) googleClient(ctx context.Context, accessToken string) *http.Client {
+	if s.httpClient != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, s.httpClient)
+	}
+	return oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+	}))
+}
+
+// partyNames returns the supplier company and employee names shown on calendar events, empty
+// for whoever can't be found
+func (s *calendarService) partyNames(supplierID, employeeID uint) (string, string) {
+	var supplierName, employeeName string
+	if supplier, err := s.recipients.Resolve(models.RecipientSupplier, supplierID); err == nil {
+		supplierName = supplier.DisplayName()
+	}
+	if employee, err := s.recipients.Resolve(models.RecipientEmployee, employeeID); err == nil {
+		employeeName = employee.Name
+	}
+	return supplierName, employeeName
+}
+
+// placeNames returns the operation and product names shown on calendar links and the event
+// location, the operation's name and address. Relations loaded with the appointment are used as
+// they are; others are looked up, and whatever can't be found is left empty.
+func (s *calendarService) placeNames(appointment *models.Appointment) (string, string, string) {
+	var operationName, productName, location string
+
+	operation := &appointment.Operation
+	if operation.ID == 0 {
+		operation = nil
+		if found, err := s.operationRepo.FindByID(appointment.OperationID); err == nil {
+			operation = found
+		}
+	}
+	if operation != nil {
+		operationName = operation.Name
+		location = operation.Name
+		if address := strings.Join(nonEmpty(operation.Address, operation.City, operation.State), ", "); address != "" {
+			location += ", " + address
+		}
+	}
+
+	if appointment.ProductID != nil {
+		if appointment.Product.ID != 0 {
+			productName = appointment.Product.Name
+		} else if s.productRepo != nil {
+			if product, err := s.productRepo.FindByID(*appointment.ProductID); err == nil {
+				productName = product.Name
+			}
+		}
+	}
+	return operationName, productName, location
+}
+
+// nonEmpty returns the values that aren't empty, in order
+func nonEmpty(values ...string) []string {
+	kept := make([]string, 0, len(values))
+	for _, value := range values {
+		if value != "" {
+			kept = append(kept, value)
+		}
 	}
+	return kept
+}
+
+// appointmentSummary returns the calendar event title of an appointment, naming its type and,
+// when known, the product
+func appointmentSummary(appointment *models.Appointment, supplierName, productName string) string {
+	label := appointment.Type.Label()
+	if supplierName == "" {
+		return fmt.Sprintf("%s: %s", label, productName)
+	}
+	summary := fmt.Sprintf("%s from %s", label, supplierName)
+	if appointment.Type == models.AppointmentTypePickup {
+		summary = fmt.Sprintf("%s by %s", label, supplierName)
+	}
+	if productName != "" {
+		summary += fmt.Sprintf(" (%s)", productName)
+	}
+	return summary
 }
 
 // GenerateICalForAppointment generates an iCalendar (RFC 5545) format string for an appointment
@@ -101,17 +214,8 @@ func (s *calendarService) GenerateICalForAppointment(appointment *models.Appoint
 	// Retrieve related entities for more detailed calendar entry
 	var supplierName, employeeName, operationName, productName string
 	
-	// Get supplier name
-	supplier, err := s.supplierRepo.GetByID(appointment.SupplierID)
-	if err == nil && supplier != nil {
-		supplierName = supplier.Name
-	}
-	
-	// Get employee name
-	employee, err := s.employeeRepo.GetByID(appointment.EmployeeID)
-	if err == nil && employee != nil {
-		employeeName = employee.Name
-	}
+	// Get the supplier and employee names
+	supplierName, employeeName = s.partyNames(appointment.SupplierID, appointment.EmployeeID)
 	
 	// Generate a unique identifier for the event
 	uid := fmt.Sprintf("appointment-%d@%s", appointment.ID, strings.Replace(s.baseURL, "https://", "", 1))
@@ -121,17 +225,14 @@ func (s *calendarService) GenerateICalForAppointment(appointment *models.Appoint
 	endTime := appointment.ScheduledEnd.UTC().Format("20060102T150405Z")
 	
 	// Get current time for DTSTAMP
-	now := time.Now().UTC().Format("20060102T150405Z")
+	now := s.clock.Now().UTC().Format("20060102T150405Z")
 	
 	// Create appointment summary
-	summary := fmt.Sprintf("Delivery: %s", productName)
-	if supplierName != "" {
-		summary = fmt.Sprintf("Delivery from %s", supplierName)
-	}
+	summary := appointmentSummary(appointment, supplierName, productName)
 	
 	// Create appointment description
-	description := fmt.Sprintf("Supplier: %s\nEmployee: %s\nOperation: %s\nProduct: %s\nQuantity: %d\nStatus: %s",
-		supplierName, employeeName, operationName, productName, appointment.QuantityToDeliver, appointment.Status)
+	description := fmt.Sprintf("Type: %s\nSupplier: %s\nEmployee: %s\nOperation: %s\nProduct: %s\nQuantity: %d\nStatus: %s",
+		appointment.Type.Label(), supplierName, employeeName, operationName, productName, appointment.QuantityToDeliver, appointment.Status)
 	
 	if appointment.Notes != "" {
 		description += fmt.Sprintf("\n\nNotes: %s", appointment.Notes)
@@ -173,7 +274,7 @@ func (s *calendarService) GenerateICalForAppointment(appointment *models.Appoint
 	buffer.WriteString(fmt.Sprintf("STATUS:%s\r\n", status))
 	
 	// Add URL to the appointment in the system
-	buffer.WriteString(fmt.Sprintf("URL:%s/appointments/%d\r\n", s.baseURL, appointment.ID))
+	buffer.WriteString(fmt.Sprintf("URL:%s/appointments/%d\r\n", s.portalURL(appointment.OperationID), appointment.ID))
 	
 	// Add organizer if we have employee information
 	if employeeName != "" {
@@ -191,23 +292,14 @@ func (s *calendarService) GenerateICalForRecurringAppointment(recurringAppointme
 	// Retrieve related entities for more detailed calendar entry
 	var supplierName, employeeName, operationName, productName string
 	
-	// Get supplier name
-	supplier, err := s.supplierRepo.GetByID(recurringAppointment.SupplierID)
-	if err == nil && supplier != nil {
-		supplierName = supplier.Name
-	}
-	
-	// Get employee name
-	employee, err := s.employeeRepo.GetByID(recurringAppointment.EmployeeID)
-	if err == nil && employee != nil {
-		employeeName = employee.Name
-	}
+	// Get the supplier and employee names
+	supplierName, employeeName = s.partyNames(recurringAppointment.SupplierID, recurringAppointment.EmployeeID)
 	
 	// Generate a unique identifier for the event
 	uid := fmt.Sprintf("recurring-%d@%s", recurringAppointment.ID, strings.Replace(s.baseURL, "https://", "", 1))
 	
 	// Get current time for DTSTAMP
-	now := time.Now().UTC().Format("20060102T150405Z")
+	now := s.clock.Now().UTC().Format("20060102T150405Z")
 	
 	// Calculate the start and end times for each occurrence
 	startHour := recurringAppointment.StartTimeMinutes / 60
@@ -358,7 +450,7 @@ func (s *calendarService) GenerateICalForRecurringAppointment(recurringAppointme
 	buffer.WriteString("STATUS:CONFIRMED\r\n")
 	
 	// Add URL to the recurring appointment in the system
-	buffer.WriteString(fmt.Sprintf("URL:%s/recurring-appointments/%d\r\n", s.baseURL, recurringAppointment.ID))
+	buffer.WriteString(fmt.Sprintf("URL:%s/recurring-appointments/%d\r\n", s.portalURL(recurringAppointment.OperationID), recurringAppointment.ID))
 	
 	// Add organizer if we have employee information
 	if employeeName != "" {
@@ -374,12 +466,7 @@ func (s *calendarService) GenerateICalForRecurringAppointment(recurringAppointme
 // CreateGoogleCalendarEvent creates a new event in Google Calendar
 func (s *calendarService) CreateGoogleCalendarEvent(ctx context.Context, appointment *models.Appointment, calendarID string, accessToken string) (string, error) {
 	// Initialize Google Calendar API client
-	client := oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{
-		AccessToken: accessToken,
-		TokenType:   "Bearer",
-	}))
-	
-	srv, err := calendar.NewService(ctx, option.WithHTTPClient(client))
+	srv, err := calendar.NewService(ctx, option.WithHTTPClient(s.googleClient(ctx, accessToken)))
 	if err != nil {
 		return "", fmt.Errorf("failed to create Google Calendar service: %v", err)
 	}
@@ -387,33 +474,21 @@ func (s *calendarService) CreateGoogleCalendarEvent(ctx context.Context, appoint
 	// Retrieve related entities for more detailed calendar entry
 	var supplierName, employeeName, operationName, productName string
 	
-	// Get supplier name
-	supplier, err := s.supplierRepo.GetByID(appointment.SupplierID)
-	if err == nil && supplier != nil {
-		supplierName = supplier.Name
-	}
-	
-	// Get employee name
-	employee, err := s.employeeRepo.GetByID(appointment.EmployeeID)
-	if err == nil && employee != nil {
-		employeeName = employee.Name
-	}
+	// Get the supplier and employee names
+	supplierName, employeeName = s.partyNames(appointment.SupplierID, appointment.EmployeeID)
 	
 	// Create appointment summary and description
-	summary := fmt.Sprintf("Delivery: %s", productName)
-	if supplierName != "" {
-		summary = fmt.Sprintf("Delivery from %s", supplierName)
-	}
+	summary := appointmentSummary(appointment, supplierName, productName)
 	
-	description := fmt.Sprintf("Supplier: %s\nEmployee: %s\nOperation: %s\nProduct: %s\nQuantity: %d\nStatus: %s",
-		supplierName, employeeName, operationName, productName, appointment.QuantityToDeliver, appointment.Status)
+	description := fmt.Sprintf("Type: %s\nSupplier: %s\nEmployee: %s\nOperation: %s\nProduct: %s\nQuantity: %d\nStatus: %s",
+		appointment.Type.Label(), supplierName, employeeName, operationName, productName, appointment.QuantityToDeliver, appointment.Status)
 	
 	if appointment.Notes != "" {
 		description += fmt.Sprintf("\n\nNotes: %s", appointment.Notes)
 	}
 	
 	// Add a link back to the appointment in our system
-	description += fmt.Sprintf("\n\nView in Scheduling Portal: %s/appointments/%d", s.baseURL, appointment.ID)
+	description += fmt.Sprintf("\n\nView in Scheduling Portal: %s/appointments/%d", s.portalURL(appointment.OperationID), appointment.ID)
 	
 	// Create Google Calendar event
 	event := &calendar.Event{
@@ -430,7 +505,7 @@ func (s *calendarService) CreateGoogleCalendarEvent(ctx context.Context, appoint
 		Location: operationName,
 		Source: &calendar.EventSource{
 			Title: "Scheduling Portal",
-			Url:   fmt.Sprintf("%s/appointments/%d", s.baseURL, appointment.ID),
+			Url:   fmt.Sprintf("%s/appointments/%d", s.portalURL(appointment.OperationID), appointment.ID),
 		},
 	}
 	
@@ -445,9 +520,17 @@ func (s *calendarService) CreateGoogleCalendarEvent(ctx context.Context, appoint
 	}
 	
 	// Insert the event
-	createdEvent, err := srv.Events.Insert(calendarID, event).Do()
+	var createdEvent *calendar.Event
+	_, err = callProvider(s.breakers, s.clock, ProviderGoogleCalendar, "skip_sync", func() error {
+		var insertErr error
+		createdEvent, insertErr = srv.Events.Insert(calendarID, event).Do()
+		return insertErr
+	})
 	if err != nil {
-		return "", fmt.Errorf("failed to create Google Calendar event: %v", err)
+		return "", fmt.Errorf("failed to create Google Calendar event: %w", err)
+	}
+	if createdEvent == nil {
+		return "", ErrCalendarSyncSkipped
 	}
 	
 	return createdEvent.Id, nil
@@ -456,52 +539,43 @@ func (s *calendarService) CreateGoogleCalendarEvent(ctx context.Context, appoint
 // UpdateGoogleCalendarEvent updates an existing event in Google Calendar
 func (s *calendarService) UpdateGoogleCalendarEvent(ctx context.Context, appointment *models.Appointment, eventID string, calendarID string, accessToken string) error {
 	// Initialize Google Calendar API client
-	client := oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{
-		AccessToken: accessToken,
-		TokenType:   "Bearer",
-	}))
-	
-	srv, err := calendar.NewService(ctx, option.WithHTTPClient(client))
+	srv, err := calendar.NewService(ctx, option.WithHTTPClient(s.googleClient(ctx, accessToken)))
 	if err != nil {
 		return fmt.Errorf("failed to create Google Calendar service: %v", err)
 	}
 	
 	// Retrieve the existing event
-	existingEvent, err := srv.Events.Get(calendarID, eventID).Do()
+	var existingEvent *calendar.Event
+	_, err = callProvider(s.breakers, s.clock, ProviderGoogleCalendar, "skip_sync", func() error {
+		var getErr error
+		existingEvent, getErr = srv.Events.Get(calendarID, eventID).Do()
+		return getErr
+	})
 	if err != nil {
-		return fmt.Errorf("failed to retrieve Google Calendar event: %v", err)
+		return fmt.Errorf("failed to retrieve Google Calendar event: %w", err)
+	}
+	if existingEvent == nil {
+		return ErrCalendarSyncSkipped
 	}
 	
 	// Retrieve related entities for more detailed calendar entry
 	var supplierName, employeeName, operationName, productName string
 	
-	// Get supplier name
-	supplier, err := s.supplierRepo.GetByID(appointment.SupplierID)
-	if err == nil && supplier != nil {
-		supplierName = supplier.Name
-	}
-	
-	// Get employee name
-	employee, err := s.employeeRepo.GetByID(appointment.EmployeeID)
-	if err == nil && employee != nil {
-		employeeName = employee.Name
-	}
+	// Get the supplier and employee names
+	supplierName, employeeName = s.partyNames(appointment.SupplierID, appointment.EmployeeID)
 	
 	// Create appointment summary and description
-	summary := fmt.Sprintf("Delivery: %s", productName)
-	if supplierName != "" {
-		summary = fmt.Sprintf("Delivery from %s", supplierName)
-	}
+	summary := appointmentSummary(appointment, supplierName, productName)
 	
-	description := fmt.Sprintf("Supplier: %s\nEmployee: %s\nOperation: %s\nProduct: %s\nQuantity: %d\nStatus: %s",
-		supplierName, employeeName, operationName, productName, appointment.QuantityToDeliver, appointment.Status)
+	description := fmt.Sprintf("Type: %s\nSupplier: %s\nEmployee: %s\nOperation: %s\nProduct: %s\nQuantity: %d\nStatus: %s",
+		appointment.Type.Label(), supplierName, employeeName, operationName, productName, appointment.QuantityToDeliver, appointment.Status)
 	
 	if appointment.Notes != "" {
 		description += fmt.Sprintf("\n\nNotes: %s", appointment.Notes)
 	}
 	
 	// Add a link back to the appointment in our system
-	description += fmt.Sprintf("\n\nView in Scheduling Portal: %s/appointments/%d", s.baseURL, appointment.ID)
+	description += fmt.Sprintf("\n\nView in Scheduling Portal: %s/appointments/%d", s.portalURL(appointment.OperationID), appointment.ID)
 	
 	// Update event fields
 	existingEvent.Summary = summary
@@ -527,9 +601,15 @@ func (s *calendarService) UpdateGoogleCalendarEvent(ctx context.Context, appoint
 	}
 	
 	// Update the event
-	_, err = srv.Events.Update(calendarID, eventID, existingEvent).Do()
+	deferred, err := callProvider(s.breakers, s.clock, ProviderGoogleCalendar, "skip_sync", func() error {
+		_, updateErr := srv.Events.Update(calendarID, eventID, existingEvent).Do()
+		return updateErr
+	})
 	if err != nil {
-		return fmt.Errorf("failed to update Google Calendar event: %v", err)
+		return fmt.Errorf("failed to update Google Calendar event: %w", err)
+	}
+	if !deferred.IsZero() {
+		return ErrCalendarSyncSkipped
 	}
 	
 	return nil
@@ -538,26 +618,28 @@ func (s *calendarService) UpdateGoogleCalendarEvent(ctx context.Context, appoint
 // DeleteGoogleCalendarEvent deletes an event from Google Calendar
 func (s *calendarService) DeleteGoogleCalendarEvent(ctx context.Context, eventID string, calendarID string, accessToken string) error {
 	// Initialize Google Calendar API client
-	client := oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{
-		AccessToken: accessToken,
-		TokenType:   "Bearer",
-	}))
-	
-	srv, err := calendar.NewService(ctx, option.WithHTTPClient(client))
+	srv, err := calendar.NewService(ctx, option.WithHTTPClient(s.googleClient(ctx, accessToken)))
 	if err != nil {
 		return fmt.Errorf("failed to create Google Calendar service: %v", err)
 	}
 	
 	// Delete the event
-	err = srv.Events.Delete(calendarID, eventID).Do()
+	deferred, err := callProvider(s.breakers, s.clock, ProviderGoogleCalendar, "skip_sync", func() error {
+		return srv.Events.Delete(calendarID, eventID).Do()
+	})
 	if err != nil {
-		return fmt.Errorf("failed to delete Google Calendar event: %v", err)
+		return fmt.Errorf("failed to delete Google Calendar event: %w", err)
+	}
+	if !deferred.IsZero() {
+		return ErrCalendarSyncSkipped
 	}
 	
 	return nil
 }
 
-// SyncAppointmentToCalendar syncs an appointment to a user's external calendar
+// SyncAppointmentToCalendar syncs an appointment to a user's external calendar. Calendar
+// preferences aren't stored yet, so no user has a provider enabled and the sync stops at that
+// check.
 func (s *calendarService) SyncAppointmentToCalendar(ctx context.Context, appointment *models.Appointment, userID uint, provider CalendarProvider) (string, error) {
 	// Get user's calendar preferences
 	preferences, err := s.GetUserCalendarPreferences(userID)
@@ -576,6 +658,13 @@ func (s *calendarService) SyncAppointmentToCalendar(ctx context.Context, appoint
 	// Sync based on provider
 	switch provider {
 	case GoogleCalendar:
+		// Skip the sync while Google Calendar is short-circuited; the next sync catches up
+		if s.breakers != nil && s.breakers.Get(ProviderGoogleCalendar).State() == circuitbreaker.StateOpen {
+			metrics.CircuitBreakerFallbacks.WithLabelValues(ProviderGoogleCalendar, "skip_sync").Inc()
+			log.Printf("Skipping Google Calendar sync for appointment %d: circuit breaker open", appointment.ID)
+			return "", ErrCalendarSyncSkipped
+		}
+		
 		// Get Google Calendar settings
 		accessToken, ok := preferences["google_access_token"].(string)
 		if !ok || accessToken == "" {
@@ -593,14 +682,14 @@ func (s *calendarService) SyncAppointmentToCalendar(ctx context.Context, appoint
 			// Update existing event
 			err = s.UpdateGoogleCalendarEvent(ctx, appointment, existingSync.ExternalEventID, calendarID, accessToken)
 			if err != nil {
-				return "", fmt.Errorf("failed to update Google Calendar event: %v", err)
+				return "", fmt.Errorf("failed to update Google Calendar event: %w", err)
 			}
 			externalEventID = existingSync.ExternalEventID
 		} else {
 			// Create new event
 			externalEventID, err = s.CreateGoogleCalendarEvent(ctx, appointment, calendarID, accessToken)
 			if err != nil {
-				return "", fmt.Errorf("failed to create Google Calendar event: %v", err)
+				return "", fmt.Errorf("failed to create Google Calendar event: %w", err)
 			}
 			
 			// Save the sync record
@@ -609,7 +698,7 @@ func (s *calendarService) SyncAppointmentToCalendar(ctx context.Context, appoint
 				AppointmentID:   appointment.ID,
 				Provider:        string(GoogleCalendar),
 				ExternalEventID: externalEventID,
-				LastSynced:      time.Now(),
+				LastSynced:      s.clock.Now(),
 			}
 			
 			err = s.calendarSyncRepo.Create(syncRecord)
@@ -634,7 +723,7 @@ func (s *calendarService) SyncAppointmentToCalendar(ctx context.Context, appoint
 			AppointmentID:   appointment.ID,
 			Provider:        string(ICalFormat),
 			ExternalEventID: externalEventID,
-			LastSynced:      time.Now(),
+			LastSynced:      s.clock.Now(),
 		}
 		
 		err = s.calendarSyncRepo.Create(syncRecord)
@@ -702,20 +791,81 @@ func (s *calendarService) RemoveAppointmentFromCalendar(ctx context.Context, app
 // GenerateGoogleCalendarLink generates a URL for adding an appointment to Google Calendar
 func (s *calendarService) GenerateGoogleCalendarLink(appointment *models.Appointment) string {
 	// Retrieve related entities for more detailed calendar entry
-	var supplierName, employeeName, operationName, productName string
+	supplierName, employeeName := s.partyNames(appointment.SupplierID, appointment.EmployeeID)
+	operationName, productName, location := s.placeNames(appointment)
 	
-	// Get supplier name
-	supplier, err := s.supplierRepo.GetByID(appointment.SupplierID)
-	if err == nil && supplier != nil {
-		supplierName = supplier.Name
-	}
+	// Create appointment summary and description
+	summary := appointmentSummary(appointment, supplierName, productName)
+	details := fmt.Sprintf("Type: %s\nSupplier: %s\nEmployee: %s\nOperation: %s\nProduct: %s\nQuantity: %d\n\n%s/appointments/%d",
+		appointment.Type.Label(), supplierName, employeeName, operationName, productName, appointment.QuantityToDeliver,
+		s.portalURL(appointment.OperationID), appointment.ID)
 	
-	// Get employee name
-	employee, err := s.employeeRepo.GetByID(appointment.EmployeeID)
-	if err == nil && employee != nil {
-		employeeName = employee.Name
+	// Google expects the times in UTC in its compact format
+	dates := appointment.ScheduledStart.UTC().Format("20060102T150405Z") + "/" + appointment.ScheduledEnd.UTC().Format("20060102T150405Z")
+	
+	params := url.Values{}
+	params.Set("action", "TEMPLATE")
+	params.Set("text", summary)
+	params.Set("dates", dates)
+	params.Set("details", details)
+	if location != "" {
+		params.Set("location", location)
 	}
 	
+	return "https://calendar.google.com/calendar/render?" + params.Encode()
+}
+
+// GenerateOutlookCalendarLink generates a URL for adding an appointment to Outlook Calendar
+func (s *calendarService) GenerateOutlookCalendarLink(appointment *models.Appointment) string {
+	// Retrieve related entities for more detailed calendar entry
+	supplierName, employeeName := s.partyNames(appointment.SupplierID, appointment.EmployeeID)
+	operationName, productName, location := s.placeNames(appointment)
+	
 	// Create appointment summary and description
-	summary := fmt.Sprintf("Delivery:
+	summary := appointmentSummary(appointment, supplierName, productName)
+	body := fmt.Sprintf("Type: %s\nSupplier: %s\nEmployee: %s\nOperation: %s\nProduct: %s\nQuantity: %d\n\n%s/appointments/%d",
+		appointment.Type.Label(), supplierName, employeeName, operationName, productName, appointment.QuantityToDeliver,
+		s.portalURL(appointment.OperationID), appointment.ID)
+	
+	params := url.Values{}
+	params.Set("path", "/calendar/action/compose")
+	params.Set("rru", "addevent")
+	params.Set("subject", summary)
+	params.Set("startdt", appointment.ScheduledStart.UTC().Format(time.RFC3339))
+	params.Set("enddt", appointment.ScheduledEnd.UTC().Format(time.RFC3339))
+	params.Set("body", body)
+	if location != "" {
+		params.Set("location", location)
+	}
+	
+	return "https://outlook.live.com/calendar/0/deeplink/compose?" + params.Encode()
+}
 
+// GenerateICalDownloadLink generates the URL the iCalendar file of an appointment is downloaded from
+func (s *calendarService) GenerateICalDownloadLink(appointment *models.Appointment) string {
+	return fmt.Sprintf("%s/api/appointments/%d/calendar.ics", s.portalURL(appointment.OperationID), appointment.ID)
+}
+
+// GetUserCalendarPreferences returns the external calendars a user syncs their appointments to,
+// e.g. google_enabled, google_calendar_id and google_access_token. Preferences aren't stored
+// yet, so no user syncs an external calendar: SyncAppointmentToCalendar, and with it the Google
+// Calendar circuit breaker fallback, can't be reached until they are.
+func (s *calendarService) GetUserCalendarPreferences(userID uint) (map[string]interface{}, error) {
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{}, nil
+}
+
+// UpdateUserCalendarPreferences saves the external calendars a user syncs their appointments to
+func (s *calendarService) UpdateUserCalendarPreferences(userID uint, preferences map[string]interface{}) error {
+	return ErrCalendarPreferencesUnsupported
+}
+
+// foldLines escapes a content line's text and folds it to lines of at most 75 octets, each
+// ended with CRLF
+func foldLines(line string) string {
+	var buffer bytes.Buffer
+	writeICalLine(&buffer, icalText(line))
+	return buffer.String()
+}
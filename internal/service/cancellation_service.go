@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+)
+
+// keptOnCancellation are the events whose pending notifications still go out once an appointment
+// is cancelled: the cancellation notice itself
+var keptOnCancellation = map[models.NotificationEvent]bool{
+	models.EventAppointmentCancelled: true,
+}
+
+// CancellationReport lists the side effects of cancelling an appointment. Steps that failed are
+// described in Failures; the others still ran.
+type CancellationReport struct {
+	AppointmentID          uint     `json:"appointment_id"`
+	NotificationsCancelled int      `json:"notifications_cancelled"`
+	CalendarEventsRemoved  int      `json:"calendar_events_removed"`
+	PromotedWaitlistID     *uint    `json:"promoted_waitlist_id,omitempty"`
+	PromotedSupplierID     *uint    `json:"promoted_supplier_id,omitempty"`
+	SlotOpeningID          *uint    `json:"slot_opening_id,omitempty"`
+	WatchersNotified       int      `json:"watchers_notified"`
+	Failures               []string `json:"failures,omitempty"`
+}
+
+// CancellationService defines the interface for clearing up what a cancelled appointment leaves
+// behind
+type CancellationService interface {
+	Cascade(ctx context.Context, appointment *models.Appointment) *CancellationReport
+}
+
+// cancellationService implements the CancellationService interface
+type cancellationService struct {
+	notificationRepo repository.NotificationRepository
+	queueRepo        repository.NotificationQueueRepository
+	calendarService  CalendarService // nil while external calendar sync isn't set up
+	waitlistService  WaitlistService
+	slotWatchService SlotWatchService
+}
+
+// NewCancellationService creates a new cancellation service
+func NewCancellationService(
+	notificationRepo repository.NotificationRepository,
+	queueRepo repository.NotificationQueueRepository,
+	calendarService CalendarService,
+	waitlistService WaitlistService,
+	slotWatchService SlotWatchService,
+) CancellationService {
+	return &cancellationService{
+		notificationRepo: notificationRepo,
+		queueRepo:        queueRepo,
+		calendarService:  calendarService,
+		waitlistService:  waitlistService,
+		slotWatchService: slotWatchService,
+	}
+}
+
+// Cascade runs the side effects of an appointment's cancellation: pending notifications about it,
+// such as reminders, are cancelled, the events synced to the employee's external calendar are
+// removed, its slot is offered to the first waitlisted supplier and then broadcast to the suppliers
+// watching its operation and date. Each step is independent, so one failing doesn't stop the
+// others; the outcome is logged and returned.
+func (s *cancellationService) Cascade(ctx context.Context, appointment *models.Appointment) *CancellationReport {
+	report := &CancellationReport{AppointmentID: appointment.ID}
+
+	cancelled, err := s.cancelPendingNotifications(appointment)
+	report.NotificationsCancelled = cancelled
+	if err != nil {
+		report.fail("notifications", err)
+	}
+
+	removed, err := s.removeCalendarEvents(ctx, appointment)
+	report.CalendarEventsRemoved = removed
+	if err != nil {
+		report.fail("calendar", err)
+	}
+
+	if s.waitlistService != nil {
+		entry, err := s.waitlistService.Promote(appointment)
+		if err != nil {
+			report.fail("waitlist", err)
+		} else if entry != nil {
+			report.PromotedWaitlistID = &entry.ID
+			report.PromotedSupplierID = &entry.SupplierID
+		}
+	}
+
+	if s.slotWatchService != nil {
+		opening, err := s.slotWatchService.OpenCancelled(appointment, report.PromotedSupplierID)
+		if err != nil {
+			report.fail("slot watches", err)
+		}
+		if opening != nil {
+			report.SlotOpeningID = &opening.ID
+			report.WatchersNotified = opening.Notified
+		}
+	}
+
+	log.Printf("Cancelled appointment %d: %s", appointment.ID, report)
+	return report
+}
+
+// cancelPendingNotifications cancels the notifications about an appointment that are still
+// waiting to be sent, and their queue items, except the cancellation notice
+func (s *cancellationService) cancelPendingNotifications(appointment *models.Appointment) (int, error) {
+	notifications, err := s.notificationRepo.FindPendingByAppointment(appointment.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	var cancelled []uint
+	for i := range notifications {
+		notification := &notifications[i]
+		if keptOnCancellation[notification.Event] {
+			continue
+		}
+
+		errorMsg := fmt.Sprintf("appointment %s was cancelled", appointment.Reference())
+		notification.Status = models.NotificationStatusCancelled
+		notification.ErrorMessage = &errorMsg
+		if err := s.notificationRepo.Update(notification); err != nil {
+			return len(cancelled), err
+		}
+		cancelled = append(cancelled, notification.ID)
+	}
+
+	if err := s.queueRepo.CancelByNotificationIDs(cancelled); err != nil {
+		return len(cancelled), err
+	}
+	return len(cancelled), nil
+}
+
+// removeCalendarEvents deletes the event synced to the external calendar of the employee the
+// appointment was booked with. iCalendar feeds need nothing: they publish the cancelled status.
+func (s *cancellationService) removeCalendarEvents(ctx context.Context, appointment *models.Appointment) (int, error) {
+	if s.calendarService == nil || appointment.Employee.UserID == 0 {
+		return 0, nil
+	}
+
+	err := s.calendarService.RemoveAppointmentFromCalendar(ctx, appointment, appointment.Employee.UserID, GoogleCalendar)
+	if err != nil {
+		return 0, err
+	}
+	return 1, nil
+}
+
+// fail records a step that failed
+func (r *CancellationReport) fail(step string, err error) {
+	r.Failures = append(r.Failures, fmt.Sprintf("%s: %v", step, err))
+}
+
+// String summarizes the report for the log
+func (r *CancellationReport) String() string {
+	parts := []string{
+		fmt.Sprintf("%d pending notifications cancelled", r.NotificationsCancelled),
+		fmt.Sprintf("%d calendar events removed", r.CalendarEventsRemoved),
+	}
+	if r.PromotedWaitlistID != nil {
+		parts = append(parts, fmt.Sprintf("slot offered to supplier %d (waitlist entry %d)", *r.PromotedSupplierID, *r.PromotedWaitlistID))
+	} else {
+		parts = append(parts, "no waitlisted supplier promoted")
+	}
+	if r.SlotOpeningID != nil {
+		parts = append(parts, fmt.Sprintf("slot opening %d recorded, %d watchers notified so far", *r.SlotOpeningID, r.WatchersNotified))
+	}
+	if len(r.Failures) > 0 {
+		parts = append(parts, "failed steps: "+strings.Join(r.Failures, "; "))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,470 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+)
+
+// Capacity calendar errors
+var (
+	ErrBandCapacityReached = errors.New("operation capacity reached for this time band")
+	ErrCapacityPastDate    = errors.New("only today or a later date can have its capacity changed")
+	ErrCapacityRange       = errors.New("capacity calendars can be shown for at most 92 days at a time")
+	ErrCapacityOverlap     = errors.New("capacity bands of the same type cannot overlap")
+)
+
+// maxCapacityCalendarDays is the longest period of an operation's capacity calendar shown at once
+const maxCapacityCalendarDays = 92
+
+// defaultCapacityCalendarDays is how many days of an operation's capacity calendar are shown
+// without a to date
+const defaultCapacityCalendarDays = 27
+
+// ConflictingAppointment is a booked appointment in a time band that is over capacity
+type ConflictingAppointment struct {
+	ID             uint                   `json:"id"`
+	BookingCode    string                 `json:"booking_code"`
+	Type           models.AppointmentType `json:"type"`
+	ScheduledStart time.Time              `json:"scheduled_start"`
+	ScheduledEnd   time.Time              `json:"scheduled_end"`
+}
+
+// CapacityConflict is a time band whose booked appointments exceed its capacity, e.g. after the
+// band's capacity was reduced
+type CapacityConflict struct {
+	Date          string                   `json:"date"`
+	StartTime     string                   `json:"start_time"`
+	EndTime       string                   `json:"end_time"`
+	Type          models.AppointmentType   `json:"type"`
+	MaxConcurrent int                      `json:"max_concurrent"`
+	Booked        int                      `json:"booked"` // most appointments at the same time in the band
+	Appointments  []ConflictingAppointment `json:"appointments"`
+}
+
+// CapacityDay is one date of an operation's capacity calendar
+type CapacityDay struct {
+	Date         string                    `json:"date"`
+	Weekday      string                    `json:"weekday"`
+	Closed       bool                      `json:"closed"`
+	ClosedReason string                    `json:"closed_reason,omitempty"`
+	Booked       int                       `json:"booked"`
+	Overrides    []models.CapacityOverride `json:"overrides"`
+	Conflicts    []CapacityConflict        `json:"conflicts"`
+	SlotOpenings []models.SlotOpening      `json:"slot_openings,omitempty"` // capacity the change freed, broadcast to watchers
+}
+
+// CapacityCalendar is an operation's capacity over a range of dates: the appointment type rules
+// that apply by default and the days with their overrides
+type CapacityCalendar struct {
+	OperationID uint                             `json:"operation_id"`
+	Timezone    string                           `json:"timezone"`
+	Defaults    []models.AppointmentTypeCapacity `json:"defaults"`
+	Days        []CapacityDay                    `json:"days"`
+}
+
+// CapacityCalendarService defines the interface for editing an operation's capacity per date and
+// time band
+type CapacityCalendarService interface {
+	Calendar(operationID uint, from, to time.Time) (*CapacityCalendar, error)
+	SetDay(operationID uint, date time.Time, overrides []models.CapacityOverride, actorID uint) (*CapacityDay, error)
+	History(operationID uint, from, to time.Time) ([]models.CapacityChange, error)
+}
+
+// capacityCalendarService implements the CapacityCalendarService interface
+type capacityCalendarService struct {
+	capacityRepo     repository.CapacityRepository
+	operationRepo    repository.OperationRepository
+	blackoutRepo     repository.BlackoutRepository
+	slotWatchService SlotWatchService
+	clock            clock.Clock
+}
+
+// NewCapacityCalendarService creates a new capacity calendar service
+func NewCapacityCalendarService(
+	capacityRepo repository.CapacityRepository,
+	operationRepo repository.OperationRepository,
+	blackoutRepo repository.BlackoutRepository,
+	slotWatchService SlotWatchService,
+	clock clock.Clock,
+) CapacityCalendarService {
+	return &capacityCalendarService{
+		capacityRepo:     capacityRepo,
+		operationRepo:    operationRepo,
+		blackoutRepo:     blackoutRepo,
+		slotWatchService: slotWatchService,
+		clock:            clock,
+	}
+}
+
+// Calendar returns the capacity of an operation on each date between from and to, both included,
+// with the booked bands that are over capacity. A zero from is today and a zero to is
+// defaultCapacityCalendarDays after from.
+func (s *capacityCalendarService) Calendar(operationID uint, from, to time.Time) (*CapacityCalendar, error) {
+	from, to, err := s.capacityRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	operation, err := s.operationRepo.FindByID(operationID)
+	if err != nil {
+		return nil, err
+	}
+	defaults, err := s.capacityRepo.FindByOperation(operationID)
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := s.capacityRepo.FindOverrides(operationID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	byDate := make(map[string][]models.CapacityOverride)
+	for _, override := range overrides {
+		byDate[override.DateKey()] = append(byDate[override.DateKey()], override)
+	}
+
+	location := operation.Location()
+	first, last := operationDayOn(operation, from), operationDayOn(operation, to)
+	closed, err := closedDates(s.blackoutRepo, operationID, first.Start, last.End.Add(-time.Nanosecond), location)
+	if err != nil {
+		return nil, err
+	}
+	appointments, err := s.capacityRepo.FindActiveOverlapping(operationID, first.Start, last.End)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := &CapacityCalendar{
+		OperationID: operationID,
+		Timezone:    location.String(),
+		Defaults:    defaults,
+		Days:        []CapacityDay{},
+	}
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		day := operationDayOn(operation, date)
+		entry := CapacityDay{
+			Date:      day.Date,
+			Weekday:   date.Weekday().String(),
+			Overrides: byDate[day.Date],
+			Conflicts: []CapacityConflict{},
+		}
+		if entry.Overrides == nil {
+			entry.Overrides = []models.CapacityOverride{}
+		}
+		if blackout, ok := closed[day.Date]; ok {
+			entry.Closed = true
+			entry.ClosedReason = blackout.Name
+		}
+		for _, appointment := range appointments {
+			if day.Contains(appointment.ScheduledStart) {
+				entry.Booked++
+			}
+		}
+		entry.Conflicts = capacityConflicts(operation, date, entry.Overrides, appointments)
+		calendar.Days = append(calendar.Days, entry)
+	}
+	return calendar, nil
+}
+
+// SetDay replaces the capacity overrides of an operation on a date, recording the change. No
+// overrides puts the date back on the appointment type rules. Appointments already booked are
+// kept; the bands they now put over capacity are returned as conflicts. Bands whose capacity went
+// up are broadcast to the suppliers watching the operation.
+func (s *capacityCalendarService) SetDay(operationID uint, date time.Time, overrides []models.CapacityOverride, actorID uint) (*CapacityDay, error) {
+	operation, err := s.operationRepo.FindByID(operationID)
+	if err != nil {
+		return nil, err
+	}
+	if date.Before(civilDate(s.clock.Now(), operation.Location())) {
+		return nil, ErrCapacityPastDate
+	}
+
+	for i := range overrides {
+		if err := overrides[i].Validate(); err != nil {
+			return nil, fmt.Errorf("band %d: %w", i+1, err)
+		}
+		for j := 0; j < i; j++ {
+			if overrides[i].OverlapsWith(&overrides[j]) {
+				return nil, fmt.Errorf("%w: bands %d and %d", ErrCapacityOverlap, j+1, i+1)
+			}
+		}
+	}
+	sort.SliceStable(overrides, func(i, j int) bool { return overrides[i].StartTime < overrides[j].StartTime })
+
+	previous, err := s.capacityRepo.FindOverrides(operationID, date, date)
+	if err != nil {
+		return nil, err
+	}
+	day := operationDayOn(operation, date)
+	appointments, err := s.capacityRepo.FindActiveOverlapping(operationID, day.Start, day.End)
+	if err != nil {
+		return nil, err
+	}
+	conflicts := capacityConflicts(operation, date, overrides, appointments)
+
+	change := &models.CapacityChange{
+		OperationID: operationID,
+		Date:        date,
+		ChangedByID: actorID,
+		Previous:    capacityBandsJSON(previous),
+		Current:     capacityBandsJSON(overrides),
+		Conflicts:   len(conflicts),
+	}
+	if err := s.capacityRepo.ReplaceOverrides(operationID, date, overrides, change); err != nil {
+		return nil, err
+	}
+
+	booked := 0
+	for _, appointment := range appointments {
+		if day.Contains(appointment.ScheduledStart) {
+			booked++
+		}
+	}
+	openings := s.openFreedCapacity(operation, date, previous, overrides)
+	if overrides == nil {
+		overrides = []models.CapacityOverride{}
+	}
+	return &CapacityDay{
+		Date:         day.Date,
+		Weekday:      date.Weekday().String(),
+		Booked:       booked,
+		Overrides:    overrides,
+		Conflicts:    conflicts,
+		SlotOpenings: openings,
+	}, nil
+}
+
+// History returns the capacity changes of an operation's dates between from and to, the latest
+// first, with the same defaults as Calendar
+func (s *capacityCalendarService) History(operationID uint, from, to time.Time) ([]models.CapacityChange, error) {
+	from, to, err := s.capacityRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.operationRepo.FindByID(operationID); err != nil {
+		return nil, err
+	}
+	return s.capacityRepo.FindChanges(operationID, from, to)
+}
+
+// capacityRange fills in the defaults of a calendar range and checks it isn't backwards or longer
+// than maxCapacityCalendarDays
+func (s *capacityCalendarService) capacityRange(from, to time.Time) (time.Time, time.Time, error) {
+	if from.IsZero() {
+		from = s.clock.Now().UTC().Truncate(24 * time.Hour)
+	}
+	if to.IsZero() {
+		to = from.AddDate(0, 0, defaultCapacityCalendarDays)
+	}
+	if to.Before(from) || to.Sub(from) > maxCapacityCalendarDays*24*time.Hour {
+		return time.Time{}, time.Time{}, ErrCapacityRange
+	}
+	return from, to, nil
+}
+
+// openFreedCapacity broadcasts the parts of a date whose capacity went up to the suppliers
+// watching the operation. Failures are only logged, as the capacity change is already saved.
+func (s *capacityCalendarService) openFreedCapacity(operation *models.Operation, date time.Time, previous, current []models.CapacityOverride) []models.SlotOpening {
+	if s.slotWatchService == nil || len(previous) == 0 {
+		return nil
+	}
+	defaults, err := s.capacityRepo.FindByOperation(operation.ID)
+	if err != nil {
+		log.Printf("Failed to load the capacity rules of operation %d to broadcast freed capacity: %v", operation.ID, err)
+		return nil
+	}
+
+	var openings []models.SlotOpening
+	for _, band := range capacityIncreases(previous, current, defaults) {
+		start, end := bandBounds(operation, date, &band)
+		opening, err := s.slotWatchService.Open(&models.SlotOpening{
+			OperationID: operation.ID,
+			Date:        date,
+			Type:        band.Type,
+			Cause:       models.SlotOpeningCapacityIncrease,
+			WindowStart: start,
+			WindowEnd:   end,
+			Places:      band.MaxConcurrent,
+		})
+		if err != nil {
+			log.Printf("Failed to broadcast the capacity freed at operation %d on %s from %s to %s: %v",
+				operation.ID, date.Format(models.BlackoutDateLayout), band.StartTime, band.EndTime, err)
+		}
+		if opening != nil {
+			openings = append(openings, *opening)
+		}
+	}
+	return openings
+}
+
+// capacityIncreases returns the parts of a date's previous bands whose capacity the current bands
+// raised, or gave back to the appointment type rules, as bands holding the places gained. Zero
+// places means no limit is left in the part. current must be sorted by start time.
+func capacityIncreases(previous, current []models.CapacityOverride, defaults []models.AppointmentTypeCapacity) []models.CapacityOverride {
+	var increases []models.CapacityOverride
+	for i := range previous {
+		before := &previous[i]
+		gained := func(start, end string, places int) {
+			increases = append(increases, models.CapacityOverride{StartTime: start, EndTime: end, Type: before.Type, MaxConcurrent: places})
+		}
+		// Where no current band limits the type anymore, its rule applies again; bands without a
+		// type leave every type to its own rule
+		lifted := func(start, end string) {
+			if before.Type == "" {
+				gained(start, end, 0)
+				return
+			}
+			limit := 0
+			for _, rule := range defaults {
+				if rule.Type == before.Type {
+					limit = rule.MaxConcurrent
+				}
+			}
+			if limit == 0 {
+				gained(start, end, 0)
+			} else if limit > before.MaxConcurrent {
+				gained(start, end, limit-before.MaxConcurrent)
+			}
+		}
+
+		cursor := before.StartTime
+		for j := range current {
+			after := &current[j]
+			if !after.OverlapsWith(before) {
+				continue
+			}
+			start, end := before.StartTime, before.EndTime
+			if after.StartTime > start {
+				start = after.StartTime
+			}
+			if after.EndTime < end {
+				end = after.EndTime
+			}
+			if start > cursor {
+				lifted(cursor, start)
+			}
+			if after.MaxConcurrent > before.MaxConcurrent {
+				gained(start, end, after.MaxConcurrent-before.MaxConcurrent)
+			}
+			cursor = end
+		}
+		if cursor < before.EndTime {
+			lifted(cursor, before.EndTime)
+		}
+	}
+	return increases
+}
+
+// capacityConflicts returns the bands of a date whose booked appointments exceed their capacity
+func capacityConflicts(operation *models.Operation, date time.Time, overrides []models.CapacityOverride, appointments []models.Appointment) []CapacityConflict {
+	conflicts := []CapacityConflict{}
+	for i := range overrides {
+		override := &overrides[i]
+		start, end := bandBounds(operation, date, override)
+
+		var inBand []models.Appointment
+		for _, appointment := range appointments {
+			if override.AppliesToType(appointment.Type) && appointment.ScheduledStart.Before(end) && appointment.ScheduledEnd.After(start) {
+				inBand = append(inBand, appointment)
+			}
+		}
+		booked := peakConcurrent(inBand, start, end)
+		if booked <= override.MaxConcurrent {
+			continue
+		}
+
+		conflict := CapacityConflict{
+			Date:          operationDayOn(operation, date).Date,
+			StartTime:     override.StartTime,
+			EndTime:       override.EndTime,
+			Type:          override.Type,
+			MaxConcurrent: override.MaxConcurrent,
+			Booked:        booked,
+		}
+		for _, appointment := range inBand {
+			conflict.Appointments = append(conflict.Appointments, ConflictingAppointment{
+				ID:             appointment.ID,
+				BookingCode:    appointment.BookingCode,
+				Type:           appointment.Type,
+				ScheduledStart: appointment.ScheduledStart,
+				ScheduledEnd:   appointment.ScheduledEnd,
+			})
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	return conflicts
+}
+
+// bandBounds returns when a capacity band starts and ends on a date at the operation
+func bandBounds(operation *models.Operation, date time.Time, override *models.CapacityOverride) (time.Time, time.Time) {
+	day := operationDayOn(operation, date)
+	at := func(value string) time.Time {
+		if value == "24:00" {
+			return day.End
+		}
+		parsed, _ := time.Parse("15:04", value)
+		local := day.Start
+		return time.Date(local.Year(), local.Month(), local.Day(), parsed.Hour(), parsed.Minute(), 0, 0, local.Location())
+	}
+	return at(override.StartTime), at(override.EndTime)
+}
+
+// peakConcurrent returns the most appointments taking place at the same time between start and end
+func peakConcurrent(appointments []models.Appointment, start, end time.Time) int {
+	type edge struct {
+		at    time.Time
+		delta int
+	}
+	edges := make([]edge, 0, 2*len(appointments))
+	for _, appointment := range appointments {
+		from, to := appointment.ScheduledStart, appointment.ScheduledEnd
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(end) {
+			to = end
+		}
+		if to.After(from) {
+			edges = append(edges, edge{from, 1}, edge{to, -1})
+		}
+	}
+	// Ends sort before starts at the same instant, so back-to-back appointments don't overlap
+	sort.Slice(edges, func(i, j int) bool {
+		if !edges[i].at.Equal(edges[j].at) {
+			return edges[i].at.Before(edges[j].at)
+		}
+		return edges[i].delta < edges[j].delta
+	})
+
+	current, peak := 0, 0
+	for _, e := range edges {
+		current += e.delta
+		if current > peak {
+			peak = current
+		}
+	}
+	return peak
+}
+
+// capacityBandsJSON serializes the bands of a date for the change history
+func capacityBandsJSON(overrides []models.CapacityOverride) string {
+	type band struct {
+		StartTime     string                 `json:"start_time"`
+		EndTime       string                 `json:"end_time"`
+		Type          models.AppointmentType `json:"type,omitempty"`
+		MaxConcurrent int                    `json:"max_concurrent"`
+		Reason        string                 `json:"reason,omitempty"`
+	}
+	bands := make([]band, 0, len(overrides))
+	for _, override := range overrides {
+		bands = append(bands, band{override.StartTime, override.EndTime, override.Type, override.MaxConcurrent, override.Reason})
+	}
+	encoded, _ := json.Marshal(bands)
+	return string(encoded)
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+)
+
+// CatalogService defines the interface for the read-mostly reference data clients cache:
+// operations, products and notification templates
+type CatalogService interface {
+	ListOperations(activeOnly bool) ([]models.Operation, error)
+	ListProducts(filters repository.ProductFilters) ([]models.Product, error)
+	ListTemplates() ([]models.NotificationTemplate, error)
+}
+
+// catalogService implements the CatalogService interface
+type catalogService struct {
+	operationRepo repository.OperationRepository
+	productRepo   repository.ProductRepository
+	templateRepo  repository.NotificationTemplateRepository
+}
+
+// NewCatalogService creates a new catalog service
+func NewCatalogService(
+	operationRepo repository.OperationRepository,
+	productRepo repository.ProductRepository,
+	templateRepo repository.NotificationTemplateRepository,
+) CatalogService {
+	return &catalogService{
+		operationRepo: operationRepo,
+		productRepo:   productRepo,
+		templateRepo:  templateRepo,
+	}
+}
+
+// ListOperations lists operations ordered by name
+func (s *catalogService) ListOperations(activeOnly bool) ([]models.Operation, error) {
+	operations, err := s.operationRepo.List()
+	if err != nil {
+		return nil, err
+	}
+	if !activeOnly {
+		return operations, nil
+	}
+
+	active := make([]models.Operation, 0, len(operations))
+	for _, operation := range operations {
+		if operation.Active {
+			active = append(active, operation)
+		}
+	}
+	return active, nil
+}
+
+// ListProducts lists the product catalog
+func (s *catalogService) ListProducts(filters repository.ProductFilters) ([]models.Product, error) {
+	return s.productRepo.List(filters)
+}
+
+// ListTemplates lists the notification templates
+func (s *catalogService) ListTemplates() ([]models.NotificationTemplate, error) {
+	return s.templateRepo.List()
+}
@@ -0,0 +1,321 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+)
+
+// consistencyBatchSize is how many appointments are read at a time when checking them against
+// their operation's hours
+const consistencyBatchSize = 500
+
+// defaultMaxConsistencyFindings is how many findings of each kind are reported when no limit is
+// configured
+const defaultMaxConsistencyFindings = 500
+
+// ConsistencyCheck is a kind of inconsistency the consistency check looks for
+type ConsistencyCheck string
+
+const (
+	// CheckOutsideHours finds open appointments outside their operation's hours, e.g. after the
+	// hours were shortened
+	CheckOutsideHours ConsistencyCheck = "appointment_outside_hours"
+
+	// CheckInactiveProduct finds open appointments delivering a product that was deactivated
+	CheckInactiveProduct ConsistencyCheck = "inactive_product"
+
+	// CheckOrphanedQueueItem finds queue items still waiting to be sent whose notification was
+	// deleted, sent or cancelled
+	CheckOrphanedQueueItem ConsistencyCheck = "orphaned_queue_item"
+
+	// CheckCalendarSync finds calendar sync records without an event in the external calendar,
+	// or whose appointment was deleted
+	CheckCalendarSync ConsistencyCheck = "calendar_sync_without_event"
+)
+
+// ConsistencyFinding is a record found inconsistent
+type ConsistencyFinding struct {
+	Check    ConsistencyCheck `json:"check"`
+	Record   string           `json:"record"` // appointment, notification_queue or calendar_sync
+	RecordID uint             `json:"record_id"`
+	Detail   string           `json:"detail"`
+	Repair   string           `json:"repair,omitempty"` // what a repair does; empty when someone has to decide
+	Repaired bool             `json:"repaired"`
+}
+
+// ConsistencySummary is the outcome of one check. Failed checks describe their error; the others
+// still ran.
+type ConsistencySummary struct {
+	Check     ConsistencyCheck `json:"check"`
+	Found     int              `json:"found"`
+	Repaired  int              `json:"repaired"`
+	Truncated bool             `json:"truncated"` // more were found than the report lists
+	Error     string           `json:"error,omitempty"`
+}
+
+// ConsistencyReport is the outcome of a consistency check
+type ConsistencyReport struct {
+	CheckedAt time.Time            `json:"checked_at"`
+	Repair    bool                 `json:"repair"`
+	Summary   []ConsistencySummary `json:"summary"`
+	Findings  []ConsistencyFinding `json:"findings"`
+}
+
+// ConsistencyService defines the interface for finding records left inconsistent with each other
+// by configuration changes and partial failures, and repairing those that can be repaired safely
+type ConsistencyService interface {
+	Check(ctx context.Context, repair bool) *ConsistencyReport
+	CheckScheduled(ctx context.Context) error
+}
+
+// consistencyService implements the ConsistencyService interface
+type consistencyService struct {
+	consistencyRepo repository.ConsistencyRepository
+	config          config.ConsistencyConfig
+	clock           clock.Clock
+}
+
+// NewConsistencyService creates a new consistency service
+func NewConsistencyService(
+	consistencyRepo repository.ConsistencyRepository,
+	config config.ConsistencyConfig,
+	clock clock.Clock,
+) ConsistencyService {
+	return &consistencyService{
+		consistencyRepo: consistencyRepo,
+		config:          config,
+		clock:           clock,
+	}
+}
+
+// Check runs every check and, with repair, repairs what can be repaired without someone deciding:
+// orphaned queue items are cancelled and calendar sync records without an event are deleted.
+// Appointments outside their operation's hours or delivering inactive products are only
+// reported, since moving or cancelling them is up to staff and the supplier.
+func (s *consistencyService) Check(ctx context.Context, repair bool) *ConsistencyReport {
+	report := &ConsistencyReport{
+		CheckedAt: s.clock.Now(),
+		Repair:    repair,
+		Findings:  []ConsistencyFinding{},
+	}
+
+	checks := []struct {
+		check ConsistencyCheck
+		run   func(context.Context, time.Time, bool) ([]ConsistencyFinding, bool, int, error)
+	}{
+		{CheckOutsideHours, s.checkOperationHours},
+		{CheckInactiveProduct, s.checkInactiveProducts},
+		{CheckOrphanedQueueItem, s.checkQueueItems},
+		{CheckCalendarSync, s.checkCalendarSyncs},
+	}
+	for _, check := range checks {
+		findings, truncated, repaired, err := check.run(ctx, report.CheckedAt, repair)
+		summary := ConsistencySummary{
+			Check:     check.check,
+			Found:     len(findings),
+			Repaired:  repaired,
+			Truncated: truncated,
+		}
+		if err != nil {
+			summary.Error = err.Error()
+		}
+		report.Summary = append(report.Summary, summary)
+		report.Findings = append(report.Findings, findings...)
+	}
+	return report
+}
+
+// CheckScheduled runs the check from the background job, repairing when auto-repair is
+// configured. The summary is logged; the job fails when a check did.
+func (s *consistencyService) CheckScheduled(ctx context.Context) error {
+	report := s.Check(ctx, s.config.AutoRepair)
+
+	var failures []string
+	for _, summary := range report.Summary {
+		if summary.Error != "" {
+			failures = append(failures, fmt.Sprintf("%s: %s", summary.Check, summary.Error))
+			continue
+		}
+		if summary.Found > 0 {
+			log.Printf("Consistency check: %d %s found, %d repaired", summary.Found, summary.Check, summary.Repaired)
+		}
+	}
+	if len(failures) > 0 {
+		return errors.New("consistency check failed: " + strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// checkOperationHours finds open appointments starting before their operation opens or ending
+// after it closes, in the operation's timezone. Bookings are held to the hours when they are
+// made, so these were booked before the hours changed.
+func (s *consistencyService) checkOperationHours(ctx context.Context, now time.Time, repair bool) ([]ConsistencyFinding, bool, int, error) {
+	var findings []ConsistencyFinding
+	limit := s.maxFindings()
+	var lastID uint
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return findings, false, 0, err
+		}
+		appointments, err := s.consistencyRepo.UpcomingAppointments(now, lastID, consistencyBatchSize)
+		if err != nil {
+			return findings, false, 0, err
+		}
+		if len(appointments) == 0 {
+			return findings, false, 0, nil
+		}
+		lastID = appointments[len(appointments)-1].ID
+
+		for _, appointment := range appointments {
+			location := (&models.Operation{Timezone: appointment.Timezone}).Location()
+			start := appointment.ScheduledStart.In(location).Format("15:04")
+			end := appointment.ScheduledEnd.In(location).Format("15:04")
+			if start >= appointment.OpeningTime && end <= appointment.ClosingTime {
+				continue
+			}
+
+			if len(findings) == limit {
+				return findings, true, 0, nil
+			}
+			findings = append(findings, ConsistencyFinding{
+				Check:    CheckOutsideHours,
+				Record:   "appointment",
+				RecordID: appointment.ID,
+				Detail: fmt.Sprintf("booked %s-%s, operation %d is open %s-%s (%s)",
+					start, end, appointment.OperationID, appointment.OpeningTime, appointment.ClosingTime, location),
+			})
+		}
+	}
+}
+
+// checkInactiveProducts finds open appointments delivering a product that was deactivated after
+// they were booked
+func (s *consistencyService) checkInactiveProducts(ctx context.Context, now time.Time, repair bool) ([]ConsistencyFinding, bool, int, error) {
+	limit := s.maxFindings()
+	appointments, err := s.consistencyRepo.AppointmentsWithInactiveProducts(now, limit+1)
+	if err != nil {
+		return nil, false, 0, err
+	}
+
+	truncated := len(appointments) > limit
+	if truncated {
+		appointments = appointments[:limit]
+	}
+	findings := make([]ConsistencyFinding, 0, len(appointments))
+	for _, appointment := range appointments {
+		findings = append(findings, ConsistencyFinding{
+			Check:    CheckInactiveProduct,
+			Record:   "appointment",
+			RecordID: appointment.ID,
+			Detail:   fmt.Sprintf("product %d (%s) is inactive", appointment.ProductID, appointment.ProductName),
+		})
+	}
+	return findings, truncated, 0, nil
+}
+
+// checkQueueItems finds queue items still waiting to be sent whose notification was deleted or
+// already sent, failed or cancelled. Sending them would deliver nothing or deliver twice; the
+// repair cancels them.
+func (s *consistencyService) checkQueueItems(ctx context.Context, now time.Time, repair bool) ([]ConsistencyFinding, bool, int, error) {
+	limit := s.maxFindings()
+	items, err := s.consistencyRepo.OrphanedQueueItems(limit + 1)
+	if err != nil {
+		return nil, false, 0, err
+	}
+
+	truncated := len(items) > limit
+	if truncated {
+		items = items[:limit]
+	}
+	findings := make([]ConsistencyFinding, 0, len(items))
+	ids := make([]uint, 0, len(items))
+	for _, item := range items {
+		detail := fmt.Sprintf("notification %d was deleted", item.NotificationID)
+		if item.NotificationStatus != "" {
+			detail = fmt.Sprintf("notification %d is %s", item.NotificationID, item.NotificationStatus)
+		}
+		findings = append(findings, ConsistencyFinding{
+			Check:    CheckOrphanedQueueItem,
+			Record:   "notification_queue",
+			RecordID: item.ID,
+			Detail:   detail,
+			Repair:   "cancel the queue item",
+		})
+		ids = append(ids, item.ID)
+	}
+	if !repair {
+		return findings, truncated, 0, nil
+	}
+
+	// Items a worker picked up meanwhile are left to it; either way none is waiting any more
+	repaired, err := s.consistencyRepo.CancelQueueItems(ids)
+	if err != nil {
+		return findings, truncated, 0, err
+	}
+	for i := range findings {
+		findings[i].Repaired = true
+	}
+	return findings, truncated, int(repaired), nil
+}
+
+// checkCalendarSyncs finds calendar sync records without an event in the external calendar,
+// which keep the appointment from being synced again, and records of deleted appointments. The
+// repair deletes them, so the next sync creates the event anew.
+func (s *consistencyService) checkCalendarSyncs(ctx context.Context, now time.Time, repair bool) ([]ConsistencyFinding, bool, int, error) {
+	limit := s.maxFindings()
+	syncs, err := s.consistencyRepo.OrphanedCalendarSyncs(limit + 1)
+	if err != nil {
+		return nil, false, 0, err
+	}
+
+	truncated := len(syncs) > limit
+	if truncated {
+		syncs = syncs[:limit]
+	}
+	findings := make([]ConsistencyFinding, 0, len(syncs))
+	ids := make([]uint, 0, len(syncs))
+	for _, sync := range syncs {
+		detail := fmt.Sprintf("no %s event for appointment %d", sync.Provider, sync.AppointmentID)
+		if sync.MissingAppointment {
+			detail = fmt.Sprintf("appointment %d was deleted; remove its %s event by hand if it is left", sync.AppointmentID, sync.Provider)
+		}
+		findings = append(findings, ConsistencyFinding{
+			Check:    CheckCalendarSync,
+			Record:   "calendar_sync",
+			RecordID: sync.ID,
+			Detail:   detail,
+			Repair:   "delete the sync record",
+		})
+		ids = append(ids, sync.ID)
+	}
+	if !repair {
+		return findings, truncated, 0, nil
+	}
+
+	repaired, err := s.consistencyRepo.DeleteCalendarSyncs(ids)
+	if err != nil {
+		return findings, truncated, 0, err
+	}
+	for i := range findings {
+		findings[i].Repaired = true
+	}
+	return findings, truncated, int(repaired), nil
+}
+
+// maxFindings returns how many findings of each kind are reported
+func (s *consistencyService) maxFindings() int {
+	if s.config.MaxFindings <= 0 {
+		return defaultMaxConsistencyFindings
+	}
+	return s.config.MaxFindings
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+)
+
+// Appointment draft errors
+var (
+	ErrDraftNotFound = errors.New("draft not found")
+	ErrDraftLimit    = errors.New("draft limit reached: delete a draft before saving another")
+	ErrDraftPayload  = errors.New("draft must be a JSON object")
+	ErrDraftTooLarge = errors.New("draft is too large")
+)
+
+// DraftService defines the interface for the booking forms users save as they fill them in, so
+// an unfinished booking can be restored later or on another device
+type DraftService interface {
+	Create(user *models.User, payload json.RawMessage) (*models.AppointmentDraft, error)
+	Save(user *models.User, key string, payload json.RawMessage) (*models.AppointmentDraft, error)
+	Get(user *models.User, key string) (*models.AppointmentDraft, error)
+	List(user *models.User) ([]models.AppointmentDraft, error)
+	Delete(user *models.User, key string) error
+	PruneExpired() (int64, error)
+}
+
+// draftService implements the DraftService interface
+type draftService struct {
+	draftRepo repository.DraftRepository
+	config    config.DraftConfig
+	clock     clock.Clock
+}
+
+// NewDraftService creates a new appointment draft service
+func NewDraftService(draftRepo repository.DraftRepository, config config.DraftConfig, clock clock.Clock) DraftService {
+	return &draftService{
+		draftRepo: draftRepo,
+		config:    config,
+		clock:     clock,
+	}
+}
+
+// Create saves a new draft under a generated key
+func (s *draftService) Create(user *models.User, payload json.RawMessage) (*models.AppointmentDraft, error) {
+	key, err := newDraftKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate draft key: %w", err)
+	}
+	return s.Save(user, key, payload)
+}
+
+// Save replaces the payload of a user's draft, creating the draft when the user has none under
+// the key. Every save keeps the draft for another TTL.
+func (s *draftService) Save(user *models.User, key string, payload json.RawMessage) (*models.AppointmentDraft, error) {
+	if err := models.ValidateDraftKey(key); err != nil {
+		return nil, err
+	}
+	payload = bytes.TrimSpace(payload)
+	if s.config.MaxBytes > 0 && len(payload) > s.config.MaxBytes {
+		return nil, fmt.Errorf("%w: at most %d bytes", ErrDraftTooLarge, s.config.MaxBytes)
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &object); err != nil || object == nil {
+		return nil, ErrDraftPayload
+	}
+
+	now := s.clock.Now()
+	draft, err := s.draftRepo.FindByKey(user.ID, key)
+	if err != nil {
+		if !errors.Is(err, repository.ErrDraftNotFound) {
+			return nil, err
+		}
+		draft = &models.AppointmentDraft{UserID: user.ID, Key: key}
+	}
+	// Expired drafts are reused in place, so they count against the limit again
+	if draft.ID == 0 || !draft.ExpiresAt.After(now) {
+		count, err := s.draftRepo.CountByUser(user.ID, now)
+		if err != nil {
+			return nil, err
+		}
+		if s.config.MaxPerUser > 0 && count >= int64(s.config.MaxPerUser) {
+			return nil, ErrDraftLimit
+		}
+	}
+
+	draft.Payload = string(payload)
+	draft.ExpiresAt = now.Add(s.config.TTL)
+	if err := s.draftRepo.Save(draft); err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+// Get returns a user's draft. Expired drafts are gone even before the prune job deletes them.
+func (s *draftService) Get(user *models.User, key string) (*models.AppointmentDraft, error) {
+	draft, err := s.draftRepo.FindByKey(user.ID, key)
+	if err != nil {
+		if errors.Is(err, repository.ErrDraftNotFound) {
+			return nil, ErrDraftNotFound
+		}
+		return nil, err
+	}
+	if !draft.ExpiresAt.After(s.clock.Now()) {
+		return nil, ErrDraftNotFound
+	}
+	return draft, nil
+}
+
+// List returns a user's drafts that haven't expired, the last saved first
+func (s *draftService) List(user *models.User) ([]models.AppointmentDraft, error) {
+	return s.draftRepo.FindByUser(user.ID, s.clock.Now())
+}
+
+// Delete deletes a user's draft, e.g. once it was booked or abandoned
+func (s *draftService) Delete(user *models.User, key string) error {
+	deleted, err := s.draftRepo.Delete(user.ID, key)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrDraftNotFound
+	}
+	return nil
+}
+
+// PruneExpired deletes the drafts not saved within their TTL
+func (s *draftService) PruneExpired() (int64, error) {
+	deleted, err := s.draftRepo.DeleteExpired(s.clock.Now())
+	if err != nil {
+		return 0, err
+	}
+	if deleted > 0 {
+		log.Printf("Pruned %d expired appointment drafts", deleted)
+	}
+	return deleted, nil
+}
+
+// newDraftKey returns a random key for a draft the client didn't name
+func newDraftKey() (string, error) {
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
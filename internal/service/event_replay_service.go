@@ -0,0 +1,165 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+	"github.com/bernardofernandezz/scheduling-api/pkg/events"
+)
+
+// Errors returned by the event replay service
+var (
+	ErrInvalidReplayPeriod = errors.New("until must be after since")
+	ErrReplayPeriodTooLong = errors.New("replay period is longer than allowed")
+)
+
+// EventReplayService rebuilds the CloudEvents of past bookings and status changes from the status
+// history, so a webhook consumer that missed deliveries or starts fresh can catch up
+type EventReplayService interface {
+	CheckPeriod(since, until time.Time) error
+	Replay(since, until time.Time, fn func(events []*events.Event) error) error
+}
+
+// eventReplayService implements the EventReplayService interface
+type eventReplayService struct {
+	statusEventRepo repository.StatusEventRepository
+	appointmentRepo repository.AppointmentRepository
+	catalog         *events.Catalog
+	schemaBaseURL   string
+	config          *config.Config
+	clock           clock.Clock
+}
+
+// NewEventReplayService creates a new event replay service. schemaBaseURL is where the event
+// schemas are published, as for the webhook.
+func NewEventReplayService(
+	statusEventRepo repository.StatusEventRepository,
+	appointmentRepo repository.AppointmentRepository,
+	catalog *events.Catalog,
+	schemaBaseURL string,
+	config *config.Config,
+	clock clock.Clock,
+) EventReplayService {
+	return &eventReplayService{
+		statusEventRepo: statusEventRepo,
+		appointmentRepo: appointmentRepo,
+		catalog:         catalog,
+		schemaBaseURL:   schemaBaseURL,
+		config:          config,
+		clock:           clock,
+	}
+}
+
+// Replay calls fn with the events of the status changes recorded from since until until, a batch
+// at a time in the order they happened. The creation of an appointment is replayed as
+// appointment.created, with the appointment's current booking details; later changes as
+// appointment.status_changed. Reschedules aren't in the status history and aren't replayed.
+// Event IDs are derived from the status history, so replaying a period twice gives the same IDs
+// and consumers can drop the ones they already have. A zero until replays up to now.
+func (s *eventReplayService) Replay(since, until time.Time, fn func(events []*events.Event) error) error {
+	until = s.untilOrNow(until)
+	if err := s.CheckPeriod(since, until); err != nil {
+		return err
+	}
+
+	return s.statusEventRepo.EachInPeriod(since, until, exportBatchSize(s.config), func(statusEvents []models.AppointmentStatusEvent) error {
+		appointments, err := s.findAppointments(statusEvents)
+		if err != nil {
+			return err
+		}
+
+		batch := make([]*events.Event, 0, len(statusEvents))
+		for i := range statusEvents {
+			statusEvent := &statusEvents[i]
+			appointment, ok := appointments[statusEvent.AppointmentID]
+			if !ok {
+				continue // deleted since
+			}
+			event, err := s.rebuild(statusEvent, appointment)
+			if err != nil {
+				log.Printf("Failed to replay status event %d: %v", statusEvent.ID, err)
+				continue
+			}
+			batch = append(batch, event)
+		}
+		return fn(batch)
+	})
+}
+
+// CheckPeriod returns an error if a replay can't cover the period: until (now when zero) isn't
+// after since, or the period is longer than EXPORT_REPLAY_MAX_PERIOD
+func (s *eventReplayService) CheckPeriod(since, until time.Time) error {
+	until = s.untilOrNow(until)
+	if !until.After(since) {
+		return ErrInvalidReplayPeriod
+	}
+	if maxPeriod := s.config.Exports.MaxPeriod; maxPeriod > 0 && until.Sub(since) > maxPeriod {
+		return ErrReplayPeriodTooLong
+	}
+	return nil
+}
+
+// untilOrNow returns until, or now when it is zero
+func (s *eventReplayService) untilOrNow(until time.Time) time.Time {
+	if until.IsZero() {
+		return s.clock.Now()
+	}
+	return until
+}
+
+// findAppointments loads the appointments of a batch of status events, by ID
+func (s *eventReplayService) findAppointments(statusEvents []models.AppointmentStatusEvent) (map[uint]*models.Appointment, error) {
+	ids := make([]uint, 0, len(statusEvents))
+	seen := make(map[uint]bool, len(statusEvents))
+	for _, statusEvent := range statusEvents {
+		if !seen[statusEvent.AppointmentID] {
+			seen[statusEvent.AppointmentID] = true
+			ids = append(ids, statusEvent.AppointmentID)
+		}
+	}
+
+	found, err := s.appointmentRepo.FindByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	appointments := make(map[uint]*models.Appointment, len(found))
+	for i := range found {
+		appointments[found[i].ID] = &found[i]
+	}
+	return appointments, nil
+}
+
+// rebuild creates the CloudEvent of a recorded status change, as the webhook would have sent it
+func (s *eventReplayService) rebuild(statusEvent *models.AppointmentStatusEvent, appointment *models.Appointment) (*events.Event, error) {
+	// The appointment as it was right after the change
+	then := *appointment
+	then.Status = statusEvent.ToStatus
+	if then.Status != models.StatusCompleted && then.Status != models.StatusPartiallyCompleted {
+		then.ReceivedQuantity = nil
+	}
+
+	appointmentEvent := AppointmentEvent{
+		Type:           AppointmentEventStatusChanged,
+		Appointment:    &then,
+		PreviousStatus: statusEvent.FromStatus,
+		Change:         StatusChange{ActorID: statusEvent.ActorID, Source: statusEvent.Source, Reason: statusEvent.Reason},
+		OccurredAt:     statusEvent.OccurredAt,
+	}
+	if statusEvent.FromStatus == "" {
+		appointmentEvent.Type = AppointmentEventCreated
+	}
+
+	eventType, data, _ := cloudEventData(appointmentEvent)
+	event, err := s.catalog.New(eventType, s.config.Webhooks.Source, appointment.BookingCode, s.schemaBaseURL, statusEvent.OccurredAt, data)
+	if err != nil {
+		return nil, err
+	}
+	event.ID = fmt.Sprintf("status-event-%d", statusEvent.ID)
+	return event, nil
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+)
+
+// Fee errors
+var (
+	ErrFeeNotFound         = errors.New("fee not found")
+	ErrFeeNotDisputable    = errors.New("only charged fees can be disputed")
+	ErrFeeNotDisputed      = errors.New("only disputed fees can be upheld")
+	ErrFeeWaived           = errors.New("fee is already waived")
+	ErrDisputeReasonNeeded = errors.New("dispute reason is required")
+	ErrSupplierRequired    = errors.New("only suppliers can dispute fees")
+)
+
+// FeeService defines the interface for the fees charged to suppliers: suppliers dispute them and
+// admins uphold or waive them
+type FeeService interface {
+	List(filters repository.FeeFilters) ([]models.Fee, int64, error)
+	GetByID(id uint) (*models.Fee, error)
+	Dispute(id uint, reason string) (*models.Fee, error)
+	Uphold(id, actorID uint, resolution string) (*models.Fee, error)
+	Waive(id, actorID uint, resolution string) (*models.Fee, error)
+	SupplierFor(user *models.User) (uint, error)
+}
+
+// feeService implements the FeeService interface
+type feeService struct {
+	feeRepo             repository.FeeRepository
+	appointmentRepo     repository.AppointmentRepository
+	supplierRepo        repository.SupplierRepository
+	operationRepo       repository.OperationRepository
+	notificationService NotificationService
+	config              *config.Config
+	clock               clock.Clock
+}
+
+// NewFeeService creates a new fee service
+func NewFeeService(
+	feeRepo repository.FeeRepository,
+	appointmentRepo repository.AppointmentRepository,
+	supplierRepo repository.SupplierRepository,
+	operationRepo repository.OperationRepository,
+	notificationService NotificationService,
+	config *config.Config,
+	clock clock.Clock,
+) FeeService {
+	return &feeService{
+		feeRepo:             feeRepo,
+		appointmentRepo:     appointmentRepo,
+		supplierRepo:        supplierRepo,
+		operationRepo:       operationRepo,
+		notificationService: notificationService,
+		config:              config,
+		clock:               clock,
+	}
+}
+
+// List returns a page of fees matching the filters
+func (s *feeService) List(filters repository.FeeFilters) ([]models.Fee, int64, error) {
+	return s.feeRepo.List(filters)
+}
+
+// GetByID gets a fee by ID
+func (s *feeService) GetByID(id uint) (*models.Fee, error) {
+	fee, err := s.feeRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrFeeNotFound
+	}
+	return fee, nil
+}
+
+// Dispute contests a charged fee. It stays out of the billing export until an admin decides.
+func (s *feeService) Dispute(id uint, reason string) (*models.Fee, error) {
+	fee, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if fee.Status != models.FeeCharged {
+		return nil, ErrFeeNotDisputable
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrDisputeReasonNeeded
+	}
+
+	now := s.clock.Now()
+	fee.Status = models.FeeDisputed
+	fee.DisputeReason = reason
+	fee.DisputedAt = &now
+	if err := fee.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.feeRepo.Update(fee); err != nil {
+		return nil, err
+	}
+	return fee, nil
+}
+
+// Uphold rejects the dispute of a fee, charging it again
+func (s *feeService) Uphold(id, actorID uint, resolution string) (*models.Fee, error) {
+	fee, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if fee.Status != models.FeeDisputed {
+		return nil, ErrFeeNotDisputed
+	}
+	resolution = strings.TrimSpace(resolution)
+	if resolution == "" {
+		return nil, ErrResolutionRequired
+	}
+
+	return s.resolve(fee, models.FeeCharged, actorID, resolution)
+}
+
+// Waive cancels a fee, disputed or not
+func (s *feeService) Waive(id, actorID uint, resolution string) (*models.Fee, error) {
+	fee, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if fee.Status == models.FeeWaived {
+		return nil, ErrFeeWaived
+	}
+
+	return s.resolve(fee, models.FeeWaived, actorID, strings.TrimSpace(resolution))
+}
+
+// SupplierFor returns the supplier record of a supplier user
+func (s *feeService) SupplierFor(user *models.User) (uint, error) {
+	supplier, err := s.supplierRepo.FindByUserID(user.ID)
+	if err != nil {
+		return 0, ErrSupplierRequired
+	}
+	return supplier.ID, nil
+}
+
+// resolve records an admin's decision on a fee and tells the supplier
+func (s *feeService) resolve(fee *models.Fee, status models.FeeStatus, actorID uint, resolution string) (*models.Fee, error) {
+	now := s.clock.Now()
+	fee.Status = status
+	fee.ResolvedAt = &now
+	fee.ResolvedByID = &actorID
+	fee.Resolution = resolution
+	if err := s.feeRepo.Update(fee); err != nil {
+		return nil, err
+	}
+
+	s.notifyResolution(fee)
+	return fee, nil
+}
+
+// notifyResolution emails the supplier whether their fee was upheld or waived
+func (s *feeService) notifyResolution(fee *models.Fee) {
+	if s.notificationService == nil {
+		return
+	}
+	appointment, err := s.appointmentRepo.FindByID(fee.AppointmentID)
+	if err != nil {
+		log.Printf("Failed to load appointment %d to notify the resolution of fee %d: %v", fee.AppointmentID, fee.ID, err)
+		return
+	}
+
+	fallback := ""
+	if s.config != nil && s.config.Notification != nil {
+		fallback = s.config.Notification.LinkBaseURL
+	}
+	link := buildLink(portalURL(s.operationRepo, appointment.OperationID, fallback), "appointments", appointment.ID)
+
+	subject := fmt.Sprintf("%s fee for appointment %s waived", fee.Kind.Label(), appointment.Reference())
+	body := fmt.Sprintf("The %s fee of %s %s for appointment %s was waived.",
+		strings.ToLower(fee.Kind.Label()), fee.Amount.String(), fee.Currency, appointment.Reference())
+	if fee.Status == models.FeeCharged {
+		subject = fmt.Sprintf("%s fee for appointment %s upheld", fee.Kind.Label(), appointment.Reference())
+		body = fmt.Sprintf("Your dispute of the %s fee of %s %s for appointment %s was reviewed and the fee stands.",
+			strings.ToLower(fee.Kind.Label()), fee.Amount.String(), fee.Currency, appointment.Reference())
+	}
+	if fee.Resolution != "" {
+		body += " " + fee.Resolution
+	}
+	body += "\n\n" + link
+
+	notification := &models.Notification{
+		Type:          models.NotificationTypeEmail,
+		Status:        models.NotificationStatusPending,
+		Event:         models.EventFeeCharged,
+		RecipientType: models.RecipientSupplier,
+		RecipientID:   fee.SupplierID,
+		Subject:       subject,
+		Body:          body,
+		AppointmentID: &appointment.ID,
+	}
+	if err := s.notificationService.EnqueueNotification(notification, QueueAppointmentNotifications, 2); err != nil {
+		log.Printf("Failed to enqueue the resolution of fee %d: %v", fee.ID, err)
+	}
+}
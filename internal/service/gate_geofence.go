@@ -0,0 +1,138 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/pkg/geo"
+)
+
+// Defaults for geofencing radii
+const (
+	defaultArrivalRadiusMeters  = 150
+	defaultApproachRadiusMeters = 5000
+)
+
+// ErrInvalidPosition is returned for coordinates out of range
+var ErrInvalidPosition = errors.New("invalid latitude or longitude")
+
+// LocationUpdate is the outcome of a driver location ping
+type LocationUpdate struct {
+	Ping           *models.AppointmentLocationPing `json:"ping"`
+	DistanceMeters *float64                        `json:"distance_meters"`
+	Ignored        string                          `json:"ignored,omitempty"` // Why the ping triggered nothing
+	ArrivingSoon   bool                            `json:"arriving_soon"`     // The dock team was notified by this ping
+	CheckedIn      bool                            `json:"checked_in"`        // The appointment was checked in by this ping
+}
+
+// RecordLocation stores a GPS ping from the driver app. Entering the approach radius of the
+// operation tells the dock team the delivery is arriving soon; entering the arrival radius checks
+// the appointment in. Each happens once per appointment.
+func (s *gateService) RecordLocation(appointmentID uint, position geo.Point, accuracyMeters float64) (*LocationUpdate, error) {
+	if !position.Valid() {
+		return nil, ErrInvalidPosition
+	}
+
+	appointment, err := s.appointmentRepo.FindByID(appointmentID)
+	if err != nil {
+		return nil, err
+	}
+	if appointment.Status.IsFinal() {
+		return nil, ErrAppointmentClosed
+	}
+
+	operation, err := s.operationRepo.FindByID(appointment.OperationID)
+	if err != nil {
+		return nil, errors.New("invalid operation: " + err.Error())
+	}
+
+	now := s.clock.Now()
+	ping := &models.AppointmentLocationPing{
+		AppointmentID:  appointment.ID,
+		Latitude:       position.Latitude,
+		Longitude:      position.Longitude,
+		AccuracyMeters: accuracyMeters,
+		RecordedAt:     now,
+	}
+	if target, ok := operationPoint(operation); ok {
+		distance := geo.Distance(position, target)
+		ping.DistanceMeters = &distance
+	}
+	if err := s.pingRepo.Create(ping); err != nil {
+		return nil, err
+	}
+
+	update := &LocationUpdate{Ping: ping, DistanceMeters: ping.DistanceMeters}
+	geofence := s.config.Geofence
+	switch {
+	case ping.DistanceMeters == nil:
+		update.Ignored = "operation address is not geocoded"
+		return update, nil
+	case geofence.MaxAccuracyMeters > 0 && accuracyMeters > geofence.MaxAccuracyMeters:
+		update.Ignored = "position is not accurate enough"
+		return update, nil
+	}
+
+	approachRadius := geofence.ApproachRadiusMeters
+	if approachRadius <= 0 {
+		approachRadius = defaultApproachRadiusMeters
+	}
+	arrivalRadius := geofence.ArrivalRadiusMeters
+	if arrivalRadius <= 0 {
+		arrivalRadius = defaultArrivalRadiusMeters
+	}
+	distance := *ping.DistanceMeters
+
+	if distance <= approachRadius && appointment.ArrivingNotifiedAt == nil && appointment.CheckedInAt == nil {
+		s.notifyArriving(appointment, distance)
+		appointment.ArrivingNotifiedAt = &now
+		if err := s.appointmentRepo.Update(appointment); err != nil {
+			return nil, err
+		}
+		update.ArrivingSoon = true
+	}
+
+	if distance <= arrivalRadius && appointment.CheckedInAt == nil {
+		err := s.checkIn(appointment, now)
+		switch {
+		case err == nil:
+			update.CheckedIn = true
+		case errors.Is(err, ErrCheckInOutsideWindow):
+			update.Ignored = "appointment is not due yet"
+		default:
+			return nil, err
+		}
+	}
+
+	return update, nil
+}
+
+// notifyArriving tells the dock team a delivery is close to the operation
+func (s *gateService) notifyArriving(appointment *models.Appointment, distanceMeters float64) {
+	if s.notificationService == nil {
+		return
+	}
+
+	subject := fmt.Sprintf("%s %s is arriving soon", appointment.Type.Label(), appointment.Reference())
+	if appointment.Supplier.CompanyName != "" {
+		subject = fmt.Sprintf("%s from %s is arriving soon", appointment.Type.Label(), appointment.Supplier.CompanyName)
+	}
+	body := fmt.Sprintf("%s. The driver is %.1f km away; the appointment is scheduled for %s.",
+		subject, distanceMeters/1000, appointment.ScheduledStart.Format("15:04"))
+
+	notification := &models.Notification{
+		Type:          models.NotificationTypeEmail,
+		Status:        models.NotificationStatusPending,
+		Event:         models.EventAppointmentArriving,
+		RecipientType: models.RecipientEmployee,
+		RecipientID:   appointment.EmployeeID,
+		Subject:       subject,
+		Body:          body,
+		AppointmentID: &appointment.ID,
+	}
+	if err := s.notificationService.EnqueueNotification(notification, QueueAppointmentNotifications, 3); err != nil {
+		log.Printf("Failed to enqueue arriving notification for appointment %d: %v", appointment.ID, err)
+	}
+}
@@ -0,0 +1,310 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+	"github.com/bernardofernandezz/scheduling-api/pkg/geo"
+)
+
+// Gate errors
+var (
+	ErrVisitorLimitReached  = errors.New("visitor limit reached for this appointment")
+	ErrAppointmentClosed    = errors.New("appointment is cancelled or completed")
+	ErrInvalidCheckInCode   = errors.New("invalid check-in code")
+	ErrCheckInCodeExpired   = errors.New("check-in code has expired")
+	ErrAlreadyCheckedIn     = errors.New("appointment is already checked in")
+	ErrCheckInOutsideWindow = errors.New("check-in is only allowed on the day of the appointment")
+)
+
+// checkInCodeValidity is how long a check-in code stays valid after the appointment ends
+const checkInCodeValidity = 12 * time.Hour
+
+// ManifestEntry is one expected delivery on the gate manifest
+type ManifestEntry struct {
+	AppointmentID  uint                        `json:"appointment_id"`
+	BookingCode    string                      `json:"booking_code"`
+	ScheduledStart time.Time                   `json:"scheduled_start"`
+	ScheduledEnd   time.Time                   `json:"scheduled_end"`
+	Type           models.AppointmentType      `json:"type"`
+	Status         models.AppointmentStatus    `json:"status"`
+	SupplierName   string                      `json:"supplier_name"`
+	ProductName    string                      `json:"product_name"`
+	CheckedInAt    *time.Time                  `json:"checked_in_at"`
+	Visitors       []models.AppointmentVisitor `json:"visitors"`
+}
+
+// Manifest lists the people expected at an operation's gate on a day
+type Manifest struct {
+	OperationID   uint                   `json:"operation_id"`
+	Date          string                 `json:"date"`
+	Timezone      string                 `json:"timezone"`
+	Appointments  []ManifestEntry        `json:"appointments"`
+	TotalVisitors int                    `json:"total_visitors"`
+	Handovers     []models.ShiftHandover `json:"handovers"` // notes the shifts left each other that day
+}
+
+// GateService defines the interface for visitor registration and gate check-in
+type GateService interface {
+	AddVisitor(visitor *models.AppointmentVisitor) error
+	ListVisitors(appointmentID uint) ([]models.AppointmentVisitor, error)
+	RemoveVisitor(appointmentID, visitorID uint) error
+	Manifest(operationID uint, date *time.Time) (*Manifest, error)
+	CheckInCode(appointmentID uint) (string, time.Time, error)
+	CheckIn(code string) (*models.Appointment, error)
+	RecordLocation(appointmentID uint, position geo.Point, accuracyMeters float64) (*LocationUpdate, error)
+}
+
+// gateService implements the GateService interface
+type gateService struct {
+	appointmentRepo     repository.AppointmentRepository
+	visitorRepo         repository.VisitorRepository
+	operationRepo       repository.OperationRepository
+	pingRepo            repository.LocationPingRepository
+	handoverRepo        repository.HandoverRepository
+	notificationService NotificationService
+	config              *config.Config
+	clock               clock.Clock
+}
+
+// NewGateService creates a new gate service
+func NewGateService(
+	appointmentRepo repository.AppointmentRepository,
+	visitorRepo repository.VisitorRepository,
+	operationRepo repository.OperationRepository,
+	pingRepo repository.LocationPingRepository,
+	handoverRepo repository.HandoverRepository,
+	notificationService NotificationService,
+	config *config.Config,
+	clock clock.Clock,
+) GateService {
+	return &gateService{
+		appointmentRepo:     appointmentRepo,
+		visitorRepo:         visitorRepo,
+		operationRepo:       operationRepo,
+		pingRepo:            pingRepo,
+		handoverRepo:        handoverRepo,
+		notificationService: notificationService,
+		config:              config,
+		clock:               clock,
+	}
+}
+
+// AddVisitor registers an extra person on an appointment, enforcing the operation's cap
+func (s *gateService) AddVisitor(visitor *models.AppointmentVisitor) error {
+	if err := visitor.Validate(); err != nil {
+		return err
+	}
+
+	appointment, err := s.appointmentRepo.FindByID(visitor.AppointmentID)
+	if err != nil {
+		return err
+	}
+	if appointment.Status.IsFinal() {
+		return ErrAppointmentClosed
+	}
+
+	operation, err := s.operationRepo.FindByID(appointment.OperationID)
+	if err != nil {
+		return errors.New("invalid operation: " + err.Error())
+	}
+
+	count, err := s.visitorRepo.CountByAppointment(appointment.ID)
+	if err != nil {
+		return err
+	}
+	if int(count) >= operation.MaxVisitorsPerAppointment {
+		return fmt.Errorf("%w (maximum %d)", ErrVisitorLimitReached, operation.MaxVisitorsPerAppointment)
+	}
+
+	visitor.CheckedInAt = nil
+	return s.visitorRepo.Create(visitor)
+}
+
+// ListVisitors lists the visitors of an appointment
+func (s *gateService) ListVisitors(appointmentID uint) ([]models.AppointmentVisitor, error) {
+	return s.visitorRepo.FindByAppointment(appointmentID)
+}
+
+// RemoveVisitor removes a visitor from an appointment
+func (s *gateService) RemoveVisitor(appointmentID, visitorID uint) error {
+	visitor, err := s.visitorRepo.FindByID(visitorID)
+	if err != nil {
+		return err
+	}
+	if visitor.AppointmentID != appointmentID {
+		return errors.New("visitor not found")
+	}
+	return s.visitorRepo.Delete(visitorID)
+}
+
+// Manifest lists the appointments of an operation on a date, today at the operation when nil,
+// together with everyone expected to come with each delivery and the day's handover notes. Days
+// are counted in the operation's timezone.
+func (s *gateService) Manifest(operationID uint, date *time.Time) (*Manifest, error) {
+	operation, err := s.operationRepo.FindByID(operationID)
+	if err != nil {
+		return nil, err
+	}
+
+	day := operationToday(operation, s.clock)
+	if date != nil {
+		day = operationDayOn(operation, *date)
+	}
+	start, end := day.Start, day.End
+
+	appointments, _, err := s.appointmentRepo.FindByOperation(operationID, repository.AppointmentFilters{
+		StartDate: &start,
+		EndDate:   &end,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint, 0, len(appointments))
+	for _, appointment := range appointments {
+		ids = append(ids, appointment.ID)
+	}
+	visitors, err := s.visitorRepo.FindByAppointments(ids)
+	if err != nil {
+		return nil, err
+	}
+	byAppointment := make(map[uint][]models.AppointmentVisitor)
+	for _, visitor := range visitors {
+		byAppointment[visitor.AppointmentID] = append(byAppointment[visitor.AppointmentID], visitor)
+	}
+
+	civil, _ := ParseBlackoutDate(day.Date)
+	handovers, err := s.handoverRepo.FindByDay(operationID, civil)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{
+		OperationID:  operationID,
+		Date:         day.Date,
+		Timezone:     day.Timezone,
+		Appointments: make([]ManifestEntry, 0, len(appointments)),
+		Handovers:    handovers,
+	}
+	for _, appointment := range appointments {
+		if appointment.Status == models.StatusCancelled {
+			continue
+		}
+		entry := ManifestEntry{
+			AppointmentID:  appointment.ID,
+			BookingCode:    appointment.BookingCode,
+			ScheduledStart: appointment.ScheduledStart,
+			ScheduledEnd:   appointment.ScheduledEnd,
+			Type:           appointment.Type,
+			Status:         appointment.Status,
+			SupplierName:   appointment.Supplier.CompanyName,
+			ProductName:    appointment.Product.Name,
+			CheckedInAt:    appointment.CheckedInAt,
+			Visitors:       byAppointment[appointment.ID],
+		}
+		if entry.Visitors == nil {
+			entry.Visitors = []models.AppointmentVisitor{}
+		}
+		manifest.TotalVisitors += len(entry.Visitors)
+		manifest.Appointments = append(manifest.Appointments, entry)
+	}
+
+	return manifest, nil
+}
+
+// CheckInCode returns a signed code for the appointment, meant to be rendered as a QR code
+// and scanned at the gate. The code is valid until some hours after the appointment ends.
+func (s *gateService) CheckInCode(appointmentID uint) (string, time.Time, error) {
+	appointment, err := s.appointmentRepo.FindByID(appointmentID)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if appointment.Status.IsFinal() {
+		return "", time.Time{}, ErrAppointmentClosed
+	}
+
+	expiresAt := appointment.ScheduledEnd.Add(checkInCodeValidity)
+	payload := strconv.FormatUint(uint64(appointment.ID), 10) + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	return payload + "." + s.sign(payload), expiresAt, nil
+}
+
+// CheckIn validates a scanned code and records the arrival of the delivery and its visitors
+func (s *gateService) CheckIn(code string) (*models.Appointment, error) {
+	parts := strings.Split(strings.TrimSpace(code), ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidCheckInCode
+	}
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(s.sign(payload))) {
+		return nil, ErrInvalidCheckInCode
+	}
+
+	id, err := strconv.ParseUint(parts[0], 10, 32)
+	if err != nil {
+		return nil, ErrInvalidCheckInCode
+	}
+	expiry, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCheckInCode
+	}
+
+	now := s.clock.Now()
+	if now.After(time.Unix(expiry, 0)) {
+		return nil, ErrCheckInCodeExpired
+	}
+
+	appointment, err := s.appointmentRepo.FindByID(uint(id))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkIn(appointment, now); err != nil {
+		return nil, err
+	}
+	return appointment, nil
+}
+
+// checkIn records the arrival of an appointment and its visitors
+func (s *gateService) checkIn(appointment *models.Appointment, now time.Time) error {
+	if appointment.Status.IsFinal() {
+		return ErrAppointmentClosed
+	}
+	if appointment.CheckedInAt != nil {
+		return ErrAlreadyCheckedIn
+	}
+	if now.Before(appointment.ScheduledStart.Add(-checkInCodeValidity)) {
+		return ErrCheckInOutsideWindow
+	}
+
+	appointment.CheckedInAt = &now
+	if err := s.appointmentRepo.Update(appointment); err != nil {
+		return err
+	}
+	if err := s.visitorRepo.MarkCheckedIn(appointment.ID, now); err != nil {
+		return err
+	}
+
+	visitors, err := s.visitorRepo.FindByAppointment(appointment.ID)
+	if err != nil {
+		return err
+	}
+	appointment.Visitors = visitors
+	return nil
+}
+
+// sign returns the URL-safe HMAC of a check-in payload
+func (s *gateService) sign(payload string) string {
+	mac := hmac.New(sha256.New, []byte(s.config.Auth.JWTSecret))
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
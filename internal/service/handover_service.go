@@ -0,0 +1,312 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+)
+
+// Handover errors
+var (
+	ErrNotAnEmployee = errors.New("only employees have a day to show")
+)
+
+// handoverMentionPattern matches an @employee_number mention in a handover note
+var handoverMentionPattern = regexp.MustCompile(`@([A-Za-z0-9][A-Za-z0-9_-]*)`)
+
+// EmployeeDay is what an employee has on a day at one operation: their appointments and the
+// handover notes left there
+type EmployeeDay struct {
+	OperationDay
+	OperationName string                 `json:"operation_name"`
+	Appointments  []models.Appointment   `json:"-"`
+	Handovers     []models.ShiftHandover `json:"handovers"`
+}
+
+// MyDay is an employee's day across the operations they work at or have appointments at
+type MyDay struct {
+	EmployeeID uint          `json:"employee_id"`
+	Operations []EmployeeDay `json:"operations"`
+}
+
+// HandoverService defines the interface for shift handover notes and the employee's day
+type HandoverService interface {
+	Create(handover *models.ShiftHandover, author *models.User) error
+	ListByDay(operationID uint, date *time.Time) ([]models.ShiftHandover, error)
+	MyDay(user *models.User, date *time.Time) (*MyDay, error)
+}
+
+// handoverService implements the HandoverService interface
+type handoverService struct {
+	handoverRepo        repository.HandoverRepository
+	operationRepo       repository.OperationRepository
+	employeeRepo        repository.EmployeeRepository
+	availabilityRepo    repository.AvailabilityRepository
+	exceptionRepo       repository.AvailabilityExceptionRepository
+	appointmentRepo     repository.AppointmentRepository
+	notificationService NotificationService
+	clock               clock.Clock
+}
+
+// NewHandoverService creates a new handover service
+func NewHandoverService(
+	handoverRepo repository.HandoverRepository,
+	operationRepo repository.OperationRepository,
+	employeeRepo repository.EmployeeRepository,
+	availabilityRepo repository.AvailabilityRepository,
+	exceptionRepo repository.AvailabilityExceptionRepository,
+	appointmentRepo repository.AppointmentRepository,
+	notificationService NotificationService,
+	clock clock.Clock,
+) HandoverService {
+	return &handoverService{
+		handoverRepo:        handoverRepo,
+		operationRepo:       operationRepo,
+		employeeRepo:        employeeRepo,
+		availabilityRepo:    availabilityRepo,
+		exceptionRepo:       exceptionRepo,
+		appointmentRepo:     appointmentRepo,
+		notificationService: notificationService,
+		clock:               clock,
+	}
+}
+
+// Create saves a handover note at an operation and notifies the employees of the incoming shift
+// and the ones the note mentions by @employee_number. Without a date the note is for today at
+// the operation.
+func (s *handoverService) Create(handover *models.ShiftHandover, author *models.User) error {
+	operation, err := s.operationRepo.FindByID(handover.OperationID)
+	if err != nil {
+		return err
+	}
+	if handover.Date.IsZero() {
+		handover.Date = civilDate(s.clock.Now(), operation.Location())
+	}
+	handover.Note = strings.TrimSpace(handover.Note)
+	handover.AuthorID = author.ID
+	handover.AuthorName = author.Name
+	if err := handover.Validate(); err != nil {
+		return err
+	}
+
+	// The author doesn't need to hear about their own note
+	authorEmployeeID := uint(0)
+	if employee, err := s.employeeRepo.FindByUserID(author.ID); err == nil {
+		authorEmployeeID = employee.ID
+	}
+
+	incoming, err := s.incomingShift(handover, operation)
+	if err != nil {
+		return err
+	}
+	mentioned := make(map[uint]bool)
+	for _, employeeID := range incoming {
+		if employeeID != authorEmployeeID && !mentioned[employeeID] {
+			mentioned[employeeID] = true
+			handover.Mentions = append(handover.Mentions, models.ShiftHandoverMention{EmployeeID: employeeID, Reason: models.MentionIncomingShift})
+		}
+	}
+	for _, match := range handoverMentionPattern.FindAllStringSubmatch(handover.Note, -1) {
+		employee, err := s.employeeRepo.FindByEmployeeNumber(match[1])
+		if err != nil || employee.ID == authorEmployeeID || mentioned[employee.ID] {
+			continue
+		}
+		mentioned[employee.ID] = true
+		handover.Mentions = append(handover.Mentions, models.ShiftHandoverMention{EmployeeID: employee.ID, Reason: models.MentionNamed})
+	}
+
+	if err := s.handoverRepo.Create(handover); err != nil {
+		return err
+	}
+	s.notifyMentions(handover, operation)
+	return nil
+}
+
+// ListByDay returns the handover notes of an operation on a date, today at the operation when nil
+func (s *handoverService) ListByDay(operationID uint, date *time.Time) ([]models.ShiftHandover, error) {
+	operation, err := s.operationRepo.FindByID(operationID)
+	if err != nil {
+		return nil, err
+	}
+	day := civilDate(s.clock.Now(), operation.Location())
+	if date != nil {
+		day = civilDate(*date, time.UTC)
+	}
+	return s.handoverRepo.FindByDay(operationID, day)
+}
+
+// MyDay returns an employee user's appointments and the handover notes at each operation they
+// work at or have appointments at on a date, today at each operation when nil
+func (s *handoverService) MyDay(user *models.User, date *time.Time) (*MyDay, error) {
+	employee, err := s.employeeRepo.FindByUserID(user.ID)
+	if err != nil {
+		return nil, ErrNotAnEmployee
+	}
+
+	slots, err := s.availabilityRepo.FindByEmployee(employee.ID)
+	if err != nil {
+		return nil, err
+	}
+	// Days are counted per operation, so widen the range to catch appointments in every timezone
+	reference := s.clock.Now()
+	if date != nil {
+		reference = time.Date(date.Year(), date.Month(), date.Day(), 12, 0, 0, 0, time.UTC)
+	}
+	from, to := reference.Add(-36*time.Hour), reference.Add(36*time.Hour)
+	appointments, _, err := s.appointmentRepo.FindByEmployee(employee.ID, repository.AppointmentFilters{
+		StartDate: &from,
+		EndDate:   &to,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var operationIDs []uint
+	seen := make(map[uint]bool)
+	for _, slot := range slots {
+		if slot.Active && !seen[slot.OperationID] {
+			seen[slot.OperationID] = true
+			operationIDs = append(operationIDs, slot.OperationID)
+		}
+	}
+	for _, appointment := range appointments {
+		if !seen[appointment.OperationID] {
+			seen[appointment.OperationID] = true
+			operationIDs = append(operationIDs, appointment.OperationID)
+		}
+	}
+	sort.Slice(operationIDs, func(i, j int) bool { return operationIDs[i] < operationIDs[j] })
+
+	result := &MyDay{EmployeeID: employee.ID, Operations: []EmployeeDay{}}
+	for _, operationID := range operationIDs {
+		operation, err := s.operationRepo.FindByID(operationID)
+		if err != nil {
+			continue
+		}
+		day := operationToday(operation, s.clock)
+		if date != nil {
+			day = operationDayOn(operation, *date)
+		}
+
+		entry := EmployeeDay{OperationDay: day, OperationName: operation.Name, Appointments: []models.Appointment{}}
+		for _, appointment := range appointments {
+			if appointment.OperationID == operationID && appointment.Status != models.StatusCancelled && day.Contains(appointment.ScheduledStart) {
+				entry.Appointments = append(entry.Appointments, appointment)
+			}
+		}
+		civil, _ := ParseBlackoutDate(day.Date)
+		if entry.Handovers, err = s.handoverRepo.FindByDay(operationID, civil); err != nil {
+			return nil, err
+		}
+		// Operations the employee neither works at nor has appointments at that day stay out
+		if len(entry.Appointments) == 0 && len(entry.Handovers) == 0 && !worksOn(slots, operation, day) {
+			continue
+		}
+		result.Operations = append(result.Operations, entry)
+	}
+	return result, nil
+}
+
+// incomingShift returns the employees of the shift taking over at an operation on the handover's
+// date: the ones whose availability slots start at its shift start or, without one, at the first
+// start time still to come that day. Employees away on an availability exception are left out.
+func (s *handoverService) incomingShift(handover *models.ShiftHandover, operation *models.Operation) ([]uint, error) {
+	slots, err := s.availabilityRepo.FindByOperation(operation.ID)
+	if err != nil {
+		return nil, err
+	}
+	day := operationDayOn(operation, handover.Date)
+	after := day.Start
+	if now := s.clock.Now(); now.After(after) {
+		after = now
+	}
+
+	starts := make(map[uint]OpenSlot)
+	for _, slot := range slots {
+		if !slot.Active {
+			continue
+		}
+		for _, occurrence := range slotOccurrences(slot, day.Start, day.End, operation.Location()) {
+			if handover.ShiftStart != "" && slot.StartTime != handover.ShiftStart {
+				continue
+			}
+			if handover.ShiftStart == "" && occurrence.Start.Before(after) {
+				continue
+			}
+			if current, ok := starts[slot.EmployeeID]; !ok || occurrence.Start.Before(current.Start) {
+				starts[slot.EmployeeID] = occurrence
+			}
+		}
+	}
+
+	var first time.Time
+	for _, occurrence := range starts {
+		if first.IsZero() || occurrence.Start.Before(first) {
+			first = occurrence.Start
+		}
+	}
+
+	var employeeIDs []uint
+	for employeeID, occurrence := range starts {
+		if !occurrence.Start.Equal(first) {
+			continue
+		}
+		exceptions, err := s.exceptionRepo.FindOverlapping(employeeID, occurrence.Start, occurrence.End)
+		if err != nil {
+			return nil, err
+		}
+		away := false
+		for _, exception := range exceptions {
+			away = away || exception.AppliesTo(operation.ID)
+		}
+		if !away {
+			employeeIDs = append(employeeIDs, employeeID)
+		}
+	}
+	sort.Slice(employeeIDs, func(i, j int) bool { return employeeIDs[i] < employeeIDs[j] })
+	return employeeIDs, nil
+}
+
+// notifyMentions emails the employees a handover note mentions
+func (s *handoverService) notifyMentions(handover *models.ShiftHandover, operation *models.Operation) {
+	if s.notificationService == nil {
+		return
+	}
+
+	for _, mention := range handover.Mentions {
+		intro := fmt.Sprintf("%s left a handover note for your shift at %s on %s", handover.AuthorName, operation.Name, handover.DateKey())
+		if mention.Reason == models.MentionNamed {
+			intro = fmt.Sprintf("%s mentioned you in a handover note at %s on %s", handover.AuthorName, operation.Name, handover.DateKey())
+		}
+		notification := &models.Notification{
+			Type:          models.NotificationTypeEmail,
+			Status:        models.NotificationStatusPending,
+			Event:         models.EventShiftHandover,
+			RecipientType: models.RecipientEmployee,
+			RecipientID:   mention.EmployeeID,
+			Subject:       fmt.Sprintf("Shift handover at %s", operation.Name),
+			Body:          fmt.Sprintf("%s: %s", intro, handover.Note),
+		}
+		if err := s.notificationService.EnqueueNotification(notification, QueueAppointmentNotifications, 2); err != nil {
+			log.Printf("Failed to enqueue handover note %d for employee %d: %v", handover.ID, mention.EmployeeID, err)
+		}
+	}
+}
+
+// worksOn reports whether any of an employee's active slots falls on a day at an operation
+func worksOn(slots []models.AvailabilitySlot, operation *models.Operation, day OperationDay) bool {
+	for _, slot := range slots {
+		if slot.Active && slot.OperationID == operation.ID && len(slotOccurrences(slot, day.Start, day.End, operation.Location())) > 0 {
+			return true
+		}
+	}
+	return false
+}
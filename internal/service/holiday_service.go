@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/circuitbreaker"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+	"github.com/bernardofernandezz/scheduling-api/pkg/holidays"
+)
+
+// Holiday and blackout errors
+var (
+	ErrHolidaysDisabled    = errors.New("no holiday provider is configured")
+	ErrHolidaysNotImported = errors.New("operation does not import public holidays")
+	ErrHolidaySyncFailed   = errors.New("holiday provider request failed")
+	ErrBlackoutNotFound    = errors.New("blackout date not found")
+	ErrBlackoutImported    = errors.New("imported holidays cannot be deleted, set the date open instead")
+	ErrInvalidBlackoutDate = errors.New("date must be in YYYY-MM-DD format")
+	ErrOperationClosed     = errors.New("operation is closed on this date")
+)
+
+// HolidayService defines the interface for operation blackout calendars: public holidays
+// imported from the holiday provider and dates admins close or open by hand
+type HolidayService interface {
+	ListBlackouts(operationID uint, year int) ([]models.OperationBlackout, error)
+	SetBlackout(operationID uint, date time.Time, closed bool, name string, actorID uint) (*models.OperationBlackout, error)
+	RemoveBlackout(ctx context.Context, operationID uint, date time.Time) error
+	Sync(ctx context.Context, operationID uint, year int) (int, error)
+	SyncAll(ctx context.Context) (int, error)
+}
+
+// holidayService implements the HolidayService interface
+type holidayService struct {
+	blackoutRepo  repository.BlackoutRepository
+	operationRepo repository.OperationRepository
+	provider      holidays.Provider
+	breakers      *circuitbreaker.Registry
+	clock         clock.Clock
+}
+
+// NewHolidayProvider creates the configured public holiday provider, or nil when holidays are
+// not imported
+func NewHolidayProvider(cfg config.HolidayConfig, httpClient *http.Client) holidays.Provider {
+	switch strings.ToLower(cfg.Provider) {
+	case "embedded":
+		return holidays.NewEmbedded()
+	case "brasilapi":
+		var transport http.RoundTripper
+		if httpClient != nil {
+			transport = httpClient.Transport
+		}
+		return holidays.NewBrasilAPI(cfg.BaseURL, cfg.Timeout, transport)
+	case "", "none":
+		return nil
+	default:
+		log.Printf("Unknown holiday provider %q, holidays are not imported", cfg.Provider)
+		return nil
+	}
+}
+
+// NewHolidayService creates a new holiday service
+func NewHolidayService(
+	blackoutRepo repository.BlackoutRepository,
+	operationRepo repository.OperationRepository,
+	provider holidays.Provider,
+	breakers *circuitbreaker.Registry,
+	clock clock.Clock,
+) HolidayService {
+	return &holidayService{
+		blackoutRepo:  blackoutRepo,
+		operationRepo: operationRepo,
+		provider:      provider,
+		breakers:      breakers,
+		clock:         clock,
+	}
+}
+
+// ListBlackouts returns an operation's blackout calendar for a year, open overrides included. A
+// zero year is the current one.
+func (s *holidayService) ListBlackouts(operationID uint, year int) ([]models.OperationBlackout, error) {
+	if _, err := s.operationRepo.FindByID(operationID); err != nil {
+		return nil, err
+	}
+	from, to := yearRange(s.yearOrCurrent(year))
+	return s.blackoutRepo.FindByOperation(operationID, from, to)
+}
+
+// SetBlackout closes an operation on a date, or keeps it open on a holiday. The date becomes a
+// manual one that later holiday syncs leave alone.
+func (s *holidayService) SetBlackout(operationID uint, date time.Time, closed bool, name string, actorID uint) (*models.OperationBlackout, error) {
+	if _, err := s.operationRepo.FindByID(operationID); err != nil {
+		return nil, err
+	}
+
+	blackout, err := s.blackoutRepo.FindByDate(operationID, date)
+	if err != nil {
+		blackout = &models.OperationBlackout{OperationID: operationID, Date: date}
+	}
+	name = strings.TrimSpace(name)
+	if name == "" && blackout.Name == "" {
+		name = "Closed"
+		if !closed {
+			name = "Open"
+		}
+	}
+	if name != "" {
+		blackout.Name = name
+	}
+	blackout.Source = models.BlackoutManual
+	blackout.Closed = closed
+	blackout.UpdatedByID = &actorID
+	if err := blackout.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.blackoutRepo.Save(blackout); err != nil {
+		return nil, err
+	}
+	return blackout, nil
+}
+
+// RemoveBlackout deletes a manual date. When the operation imports holidays, the year is synced
+// again so a holiday the date overrode comes back.
+func (s *holidayService) RemoveBlackout(ctx context.Context, operationID uint, date time.Time) error {
+	operation, err := s.operationRepo.FindByID(operationID)
+	if err != nil {
+		return err
+	}
+	blackout, err := s.blackoutRepo.FindByDate(operationID, date)
+	if err != nil {
+		return ErrBlackoutNotFound
+	}
+	if blackout.Source != models.BlackoutManual {
+		return ErrBlackoutImported
+	}
+	if err := s.blackoutRepo.Delete(blackout.ID); err != nil {
+		return err
+	}
+
+	if operation.ImportHolidays && s.provider != nil {
+		if _, err := s.syncOperation(ctx, operation, date.Year()); err != nil {
+			log.Printf("Failed to restore the holidays of operation %d after removing %s: %v", operationID, blackout.DateKey(), err)
+		}
+	}
+	return nil
+}
+
+// Sync imports a year of public holidays into an operation's blackout calendar, returning how
+// many were stored. A zero year is the current one.
+func (s *holidayService) Sync(ctx context.Context, operationID uint, year int) (int, error) {
+	if s.provider == nil {
+		return 0, ErrHolidaysDisabled
+	}
+	operation, err := s.operationRepo.FindByID(operationID)
+	if err != nil {
+		return 0, err
+	}
+	if !operation.ImportHolidays {
+		return 0, ErrHolidaysNotImported
+	}
+	return s.syncOperation(ctx, operation, s.yearOrCurrent(year))
+}
+
+// yearOrCurrent returns year, or the current year when it's zero
+func (s *holidayService) yearOrCurrent(year int) int {
+	if year == 0 {
+		return s.clock.Now().Year()
+	}
+	return year
+}
+
+// SyncAll imports the current and next year's public holidays into the calendars of every
+// operation that opted in. Operations that fail are logged and skipped.
+func (s *holidayService) SyncAll(ctx context.Context) (int, error) {
+	if s.provider == nil {
+		return 0, nil
+	}
+	operations, err := s.operationRepo.FindImportingHolidays()
+	if err != nil {
+		return 0, err
+	}
+
+	year := s.clock.Now().Year()
+	stored := 0
+	for i := range operations {
+		for _, y := range []int{year, year + 1} {
+			if ctx.Err() != nil {
+				return stored, ctx.Err()
+			}
+			count, err := s.syncOperation(ctx, &operations[i], y)
+			if err != nil {
+				log.Printf("Failed to import %d holidays for operation %d: %v", y, operations[i].ID, err)
+				continue
+			}
+			stored += count
+		}
+	}
+	return stored, nil
+}
+
+// syncOperation replaces an operation's imported holidays of a year with the provider's
+func (s *holidayService) syncOperation(ctx context.Context, operation *models.Operation, year int) (int, error) {
+	var list []holidays.Holiday
+	call := func() error {
+		var err error
+		list, err = s.provider.Holidays(ctx, year, operation.State)
+		return err
+	}
+
+	var err error
+	if s.breakers != nil {
+		err = s.breakers.Get(ProviderHolidays).Execute(call)
+	} else {
+		err = call()
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrHolidaySyncFailed, err)
+	}
+
+	blackouts := make([]models.OperationBlackout, 0, len(list))
+	for _, holiday := range list {
+		blackouts = append(blackouts, models.OperationBlackout{
+			OperationID: operation.ID,
+			Date:        holiday.Date,
+			Name:        holiday.Name,
+			Source:      models.BlackoutHoliday,
+			Closed:      true,
+		})
+	}
+	from, to := yearRange(year)
+	return s.blackoutRepo.ReplaceHolidays(operation.ID, from, to, blackouts)
+}
+
+// ParseBlackoutDate parses a "YYYY-MM-DD" blackout date
+func ParseBlackoutDate(value string) (time.Time, error) {
+	date, err := time.Parse(models.BlackoutDateLayout, value)
+	if err != nil {
+		return time.Time{}, ErrInvalidBlackoutDate
+	}
+	return date, nil
+}
+
+// yearRange returns the first and last day of a year
+func yearRange(year int) (time.Time, time.Time) {
+	return time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC)
+}
+
+// civilDate returns the date of an instant in a timezone, at midnight UTC like blackout dates
+func civilDate(t time.Time, location *time.Location) time.Time {
+	local := t.In(location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// closedDates returns the dates an operation is closed on between two instants, keyed by
+// "YYYY-MM-DD" in the operation's timezone
+func closedDates(blackoutRepo repository.BlackoutRepository, operationID uint, from, to time.Time, location *time.Location) (map[string]models.OperationBlackout, error) {
+	blackouts, err := blackoutRepo.FindByOperation(operationID, civilDate(from, location), civilDate(to, location))
+	if err != nil {
+		return nil, err
+	}
+	closed := make(map[string]models.OperationBlackout, len(blackouts))
+	for _, blackout := range blackouts {
+		if blackout.Closed {
+			closed[blackout.DateKey()] = blackout
+		}
+	}
+	return closed, nil
+}
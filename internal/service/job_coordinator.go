@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+	"github.com/bernardofernandezz/scheduling-api/pkg/jobs"
+	"github.com/bernardofernandezz/scheduling-api/pkg/leader"
+)
+
+// jobCoordinator shares scheduled job runs between replicas: only the elected leader claims
+// runs, and the run it records in the job_runs table guards against two replicas believing they
+// lead at once
+type jobCoordinator struct {
+	jobRepo  repository.JobRepository
+	elector  *leader.Elector
+	instance string
+	clock    clock.Clock
+}
+
+// NewJobCoordinator creates a coordinator recording the runs this replica claims under instance.
+// A nil elector lets every replica claim runs.
+func NewJobCoordinator(jobRepo repository.JobRepository, elector *leader.Elector, instance string, clock clock.Clock) jobs.Coordinator {
+	return &jobCoordinator{
+		jobRepo:  jobRepo,
+		elector:  elector,
+		instance: instance,
+		clock:    clock,
+	}
+}
+
+// Claim records the run scheduled for a time, returning false if this replica doesn't lead or
+// another replica got the run first
+func (c *jobCoordinator) Claim(ctx context.Context, job string, scheduledFor time.Time) (bool, error) {
+	if !c.elector.IsLeader() {
+		return false, nil
+	}
+	return c.jobRepo.ClaimRun(&models.JobRun{
+		Job:          job,
+		ScheduledFor: scheduledFor.UTC(),
+		Instance:     c.instance,
+		StartedAt:    c.clock.Now(),
+	})
+}
+
+// Finish records how a claimed run went
+func (c *jobCoordinator) Finish(ctx context.Context, job string, scheduledFor time.Time, duration time.Duration, runErr error) error {
+	errorMessage := ""
+	if runErr != nil {
+		errorMessage = runErr.Error()
+	}
+	return c.jobRepo.FinishRun(job, scheduledFor.UTC(), c.clock.Now(), duration, errorMessage)
+}
@@ -0,0 +1,319 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+)
+
+// Appointment label errors
+var (
+	ErrUnsupportedLabelFormat = errors.New("unsupported label format: use zpl")
+	ErrInvalidLabelCount      = errors.New("invalid label count: print between 1 and 100 labels")
+	ErrInvalidLabelTemplate   = errors.New("invalid label template")
+)
+
+const (
+	// maxLabelCount is the most labels printed for an appointment at once
+	maxLabelCount = 100
+
+	// maxLabelTemplateBytes is the largest label template an operation may save
+	maxLabelTemplateBytes = 16 * 1024
+)
+
+// defaultZPLLabel is the built-in 4x3 inch (203 dpi) pallet label: operation and slot, supplier,
+// purchase order and product, the booking code in large type and as a QR code, and the label's
+// number among the appointment's labels
+const defaultZPLLabel = `^XA
+^CI28
+^PW812
+^LL609
+^FO30,30^A0N,42,42^FD{{.Operation}} ({{.OperationCode}})^FS
+^FO30,82^A0N,32,32^FD{{.Date}} {{.StartTime}}-{{.EndTime}}^FS
+^FO30,140^A0N,30,30^FDSupplier: {{truncate 32 .Supplier}}^FS
+^FO30,180^A0N,30,30^FDPO: {{default "-" .PurchaseOrder}}^FS
+^FO30,220^A0N,30,30^FDProduct: {{truncate 32 (default "-" .Product)}}^FS
+^FO30,260^A0N,30,30^FDQuantity: {{.Quantity}}^FS
+^FO30,340^A0N,64,64^FD{{.BookingCode}}^FS
+^FO560,300^BQN,2,7^FDQA,{{.QRData}}^FS
+^FO30,540^A0N,32,32^FDLabel {{.Label}}/{{.Labels}}^FS
+^XZ
+`
+
+// LabelData is what a label template is run with. Text is cleaned of the ZPL command characters
+// ^ and ~ and of line breaks, so appointment data can't inject printer commands.
+type LabelData struct {
+	AppointmentID uint
+	BookingCode   string // booking code, or #ID for appointments booked before codes
+	Type          string
+	Status        string
+	Supplier      string
+	SupplierCNPJ  string
+	PurchaseOrder string
+	ExternalRef   string
+	Product       string
+	ProductSKU    string
+	Quantity      int
+	Operation     string
+	OperationCode string
+	Date          string // scheduled day at the operation, e.g. 2025-03-14
+	StartTime     string // scheduled start at the operation, e.g. 08:30
+	EndTime       string
+	QRData        string // what the QR code encodes: the booking code
+	Label         int    // number of this label, from 1
+	Labels        int    // labels printed for the appointment
+}
+
+// LabelTemplateView is the label template in effect for an operation
+type LabelTemplateView struct {
+	OperationID uint       `json:"operation_id"`
+	Format      string     `json:"format"`
+	Body        string     `json:"body"`
+	Custom      bool       `json:"custom"` // false while the built-in template is used
+	UpdatedByID *uint      `json:"updated_by_id,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+// LabelService defines the interface for the pallet labels docks print for appointments
+type LabelService interface {
+	Render(appointment *models.Appointment, format string, count int) ([]byte, error)
+	Template(operationID uint, format string) (*LabelTemplateView, error)
+	SetTemplate(operationID uint, format, body string, userID uint) (*LabelTemplateView, error)
+	ResetTemplate(operationID uint, format string) (*LabelTemplateView, error)
+}
+
+// labelService implements the LabelService interface
+type labelService struct {
+	labelRepo     repository.LabelTemplateRepository
+	operationRepo repository.OperationRepository
+}
+
+// NewLabelService creates a new appointment label service
+func NewLabelService(
+	labelRepo repository.LabelTemplateRepository,
+	operationRepo repository.OperationRepository,
+) LabelService {
+	return &labelService{
+		labelRepo:     labelRepo,
+		operationRepo: operationRepo,
+	}
+}
+
+// Render prints count labels for an appointment with its operation's template, one after the
+// other in a single payload the printer accepts as is
+func (s *labelService) Render(appointment *models.Appointment, format string, count int) ([]byte, error) {
+	if count < 1 || count > maxLabelCount {
+		return nil, ErrInvalidLabelCount
+	}
+	view, err := s.Template(appointment.OperationID, format)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := parseLabelTemplate(view.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	data := newLabelData(appointment)
+	data.Labels = count
+	var output bytes.Buffer
+	for label := 1; label <= count; label++ {
+		data.Label = label
+		if err := tmpl.Execute(&output, data); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidLabelTemplate, err)
+		}
+	}
+	return output.Bytes(), nil
+}
+
+// Template returns the label template an operation prints a format with
+func (s *labelService) Template(operationID uint, format string) (*LabelTemplateView, error) {
+	builtIn, err := builtInLabelTemplate(format)
+	if err != nil {
+		return nil, err
+	}
+
+	custom, err := s.labelRepo.Find(operationID, format)
+	if err != nil {
+		return nil, err
+	}
+	if custom == nil {
+		return &LabelTemplateView{OperationID: operationID, Format: format, Body: builtIn}, nil
+	}
+	return &LabelTemplateView{
+		OperationID: operationID,
+		Format:      format,
+		Body:        custom.Body,
+		Custom:      true,
+		UpdatedByID: custom.UpdatedByID,
+		UpdatedAt:   &custom.UpdatedAt,
+	}, nil
+}
+
+// SetTemplate replaces the label template of an operation after checking it renders a label
+func (s *labelService) SetTemplate(operationID uint, format, body string, userID uint) (*LabelTemplateView, error) {
+	if _, err := builtInLabelTemplate(format); err != nil {
+		return nil, err
+	}
+	if _, err := s.operationRepo.FindByID(operationID); err != nil {
+		return nil, err
+	}
+	if err := validateLabelTemplate(format, body); err != nil {
+		return nil, err
+	}
+
+	label := &models.LabelTemplate{
+		OperationID: operationID,
+		Format:      format,
+		Body:        body,
+		UpdatedByID: &userID,
+	}
+	if err := s.labelRepo.Save(label); err != nil {
+		return nil, fmt.Errorf("failed to save label template: %w", err)
+	}
+	return s.Template(operationID, format)
+}
+
+// ResetTemplate brings back the built-in label template of an operation
+func (s *labelService) ResetTemplate(operationID uint, format string) (*LabelTemplateView, error) {
+	if _, err := builtInLabelTemplate(format); err != nil {
+		return nil, err
+	}
+	if err := s.labelRepo.Delete(operationID, format); err != nil {
+		return nil, fmt.Errorf("failed to reset label template: %w", err)
+	}
+	return s.Template(operationID, format)
+}
+
+// builtInLabelTemplate returns the template operations use for a format until they save their own
+func builtInLabelTemplate(format string) (string, error) {
+	switch format {
+	case models.LabelFormatZPL:
+		return defaultZPLLabel, nil
+	}
+	return "", ErrUnsupportedLabelFormat
+}
+
+// validateLabelTemplate checks a template renders a complete label from sample data
+func validateLabelTemplate(format, body string) error {
+	if strings.TrimSpace(body) == "" {
+		return fmt.Errorf("%w: the template is empty", ErrInvalidLabelTemplate)
+	}
+	if len(body) > maxLabelTemplateBytes {
+		return fmt.Errorf("%w: the template is larger than %d bytes", ErrInvalidLabelTemplate, maxLabelTemplateBytes)
+	}
+	tmpl, err := parseLabelTemplate(body)
+	if err != nil {
+		return err
+	}
+
+	var output bytes.Buffer
+	if err := tmpl.Execute(&output, sampleLabelData()); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidLabelTemplate, err)
+	}
+	if format == models.LabelFormatZPL {
+		label := output.String()
+		if !strings.Contains(label, "^XA") || !strings.Contains(label, "^XZ") {
+			return fmt.Errorf("%w: a ZPL label starts with ^XA and ends with ^XZ", ErrInvalidLabelTemplate)
+		}
+	}
+	return nil
+}
+
+// parseLabelTemplate parses a label template with the helpers label templates may use:
+//
+//	{{upper .Supplier}} {{lower .Type}}
+//	{{default "-" .PurchaseOrder}}
+//	{{truncate 30 .Product}}
+func parseLabelTemplate(body string) (*template.Template, error) {
+	tmpl, err := template.New("label").Funcs(template.FuncMap{
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"default": func(fallback, value string) string {
+			if strings.TrimSpace(value) == "" {
+				return fallback
+			}
+			return value
+		},
+		"truncate": func(length int, value string) string {
+			if runes := []rune(value); len(runes) > length {
+				return string(runes[:length])
+			}
+			return value
+		},
+	}).Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLabelTemplate, err)
+	}
+	return tmpl, nil
+}
+
+// newLabelData collects what a label shows about an appointment, with times at its operation
+func newLabelData(appointment *models.Appointment) LabelData {
+	location := appointment.Operation.Location()
+	start := appointment.ScheduledStart.In(location)
+	reference := labelText(appointment.Reference())
+
+	return LabelData{
+		AppointmentID: appointment.ID,
+		BookingCode:   reference,
+		Type:          labelText(string(appointment.Type)),
+		Status:        labelText(string(appointment.Status)),
+		Supplier:      labelText(appointment.Supplier.CompanyName),
+		SupplierCNPJ:  labelText(appointment.Supplier.CNPJ),
+		PurchaseOrder: labelText(appointment.PurchaseOrder),
+		ExternalRef:   labelText(appointment.ExternalRef),
+		Product:       labelText(appointment.Product.Name),
+		ProductSKU:    labelText(appointment.Product.SKU),
+		Quantity:      appointment.QuantityToDeliver,
+		Operation:     labelText(appointment.Operation.Name),
+		OperationCode: labelText(appointment.Operation.Code),
+		Date:          start.Format("2006-01-02"),
+		StartTime:     start.Format("15:04"),
+		EndTime:       appointment.ScheduledEnd.In(location).Format("15:04"),
+		QRData:        reference,
+	}
+}
+
+// sampleLabelData is what templates are checked with before they are saved
+func sampleLabelData() LabelData {
+	return LabelData{
+		AppointmentID: 423,
+		BookingCode:   "SP01-2025-00423",
+		Type:          string(models.AppointmentTypeDelivery),
+		Status:        string(models.StatusConfirmed),
+		Supplier:      "Acme Foods Ltda",
+		SupplierCNPJ:  "12.345.678/0001-90",
+		PurchaseOrder: "PO-778812",
+		ExternalRef:   "RCV-55120",
+		Product:       "Frozen vegetables",
+		ProductSKU:    "FV-1000",
+		Quantity:      24,
+		Operation:     "São Paulo DC",
+		OperationCode: "SP01",
+		Date:          "2025-03-14",
+		StartTime:     "08:30",
+		EndTime:       "09:30",
+		QRData:        "SP01-2025-00423",
+		Label:         1,
+		Labels:        2,
+	}
+}
+
+// labelText cleans text printed on a label: the ZPL command prefixes ^ and ~ and control
+// characters such as line breaks become spaces
+func labelText(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '^' || r == '~' || unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, value)
+}
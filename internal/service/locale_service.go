@@ -0,0 +1,107 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/i18n"
+)
+
+// Locale preference errors
+var (
+	ErrUnsupportedLocale = errors.New("unsupported locale")
+	ErrUnknownTimezone   = errors.New("unknown timezone")
+)
+
+// LocalePreferences are the language and timezone a user set in their profile
+type LocalePreferences struct {
+	Locale   string `json:"locale"`
+	Timezone string `json:"timezone"` // empty uses the employee's operation's timezone, or UTC
+}
+
+// LocaleService defines the interface for the locale and timezone users are served in when
+// their requests don't name one
+type LocaleService interface {
+	ForUser(user *models.User) i18n.Preferences
+	GetPreferences(userID uint) (*LocalePreferences, error)
+	UpdatePreferences(userID uint, preferences LocalePreferences) (*LocalePreferences, error)
+}
+
+// localeService implements the LocaleService interface
+type localeService struct {
+	preferenceRepo repository.NotificationPreferenceRepository
+	recipients     RecipientResolver
+}
+
+// NewLocaleService creates a new locale service
+func NewLocaleService(
+	preferenceRepo repository.NotificationPreferenceRepository,
+	recipients RecipientResolver,
+) LocaleService {
+	return &localeService{
+		preferenceRepo: preferenceRepo,
+		recipients:     recipients,
+	}
+}
+
+// ForUser returns the locale and timezone of a user's profile, resolved the way their
+// notifications are: the timezone they set or, for employees, their operation's
+func (s *localeService) ForUser(user *models.User) i18n.Preferences {
+	recipient := s.recipients.ResolveUser(user)
+	return i18n.Preferences{
+		Locale:   i18n.MessageLocale(recipient.Locale),
+		Location: recipient.Timezone,
+	}
+}
+
+// GetPreferences returns the locale and timezone a user set, the defaults when they never set
+// any
+func (s *localeService) GetPreferences(userID uint) (*LocalePreferences, error) {
+	prefs, err := s.preferenceRepo.GetByUserID(userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotificationPreferenceNotFound) {
+			return &LocalePreferences{Locale: i18n.DefaultLocale}, nil
+		}
+		return nil, err
+	}
+	return &LocalePreferences{Locale: prefs.Locale, Timezone: prefs.Timezone}, nil
+}
+
+// UpdatePreferences sets the locale and timezone of a user, creating their notification
+// preferences when they have none. The locale must be in a language with a message catalog, e.g.
+// "pt" is stored as pt-BR; an empty timezone goes back to the operation's.
+func (s *localeService) UpdatePreferences(userID uint, preferences LocalePreferences) (*LocalePreferences, error) {
+	locale, ok := i18n.MatchLocale(preferences.Locale)
+	if !ok {
+		return nil, ErrUnsupportedLocale
+	}
+	timezone := strings.TrimSpace(preferences.Timezone)
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil {
+			return nil, ErrUnknownTimezone
+		}
+	}
+
+	prefs, err := s.preferenceRepo.GetByUserID(userID)
+	if err != nil {
+		if !errors.Is(err, repository.ErrNotificationPreferenceNotFound) {
+			return nil, err
+		}
+		prefs = &models.NotificationPreference{UserID: userID, EmailEnabled: true, ReminderHours: 24}
+	}
+	prefs.Locale = locale
+	prefs.Timezone = timezone
+
+	if prefs.ID == 0 {
+		err = s.preferenceRepo.Create(prefs)
+	} else {
+		err = s.preferenceRepo.Update(prefs)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &LocalePreferences{Locale: prefs.Locale, Timezone: prefs.Timezone}, nil
+}
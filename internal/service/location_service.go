@@ -0,0 +1,331 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/circuitbreaker"
+	"github.com/bernardofernandezz/scheduling-api/pkg/geo"
+)
+
+// Location errors
+var (
+	ErrGeocodingDisabled = errors.New("geocoding is disabled")
+	ErrMissingAddress    = errors.New("no address to geocode")
+	ErrGeocodingFailed   = errors.New("geocoding failed")
+)
+
+// geocodeBatchSize is how many operations and suppliers the background job geocodes per run
+const geocodeBatchSize = 50
+
+// TravelWarning flags a neighbouring appointment of the same supplier at another operation
+// that cannot be reached in time
+type TravelWarning struct {
+	AppointmentID    uint      `json:"appointment_id"`
+	OperationID      uint      `json:"operation_id"`
+	OperationName    string    `json:"operation_name"`
+	ScheduledStart   time.Time `json:"scheduled_start"`
+	ScheduledEnd     time.Time `json:"scheduled_end"`
+	DistanceKm       float64   `json:"distance_km"`
+	TravelMinutes    int       `json:"travel_minutes"`
+	AvailableMinutes int       `json:"available_minutes"`
+	Message          string    `json:"message"`
+}
+
+// LocationService defines the interface for address geocoding and travel time checks
+type LocationService interface {
+	GeocodeOperation(id uint) (*models.Operation, error)
+	GeocodeSupplier(id uint) (*models.Supplier, error)
+	GeocodeMissing(ctx context.Context) (int, error)
+	CheckTravel(appointment *models.Appointment) ([]TravelWarning, error)
+}
+
+// locationService implements the LocationService interface
+type locationService struct {
+	appointmentRepo repository.AppointmentRepository
+	operationRepo   repository.OperationRepository
+	supplierRepo    repository.SupplierRepository
+	geocoder        geo.Geocoder
+	estimator       geo.TravelEstimator
+	breakers        *circuitbreaker.Registry
+	config          *config.Config
+}
+
+// NewGeocoder creates the geocoder selected in the configuration. It returns nil when
+// geocoding is disabled.
+func NewGeocoder(cfg config.GeocodingConfig, httpClient *http.Client) geo.Geocoder {
+	switch strings.ToLower(cfg.Provider) {
+	case "nominatim":
+		var transport http.RoundTripper
+		if httpClient != nil {
+			transport = httpClient.Transport
+		}
+		return geo.NewNominatimGeocoder(cfg.BaseURL, cfg.UserAgent, cfg.Timeout, transport)
+	case "", "none":
+		return nil
+	default:
+		log.Printf("Unknown geocoding provider %q, geocoding is disabled", cfg.Provider)
+		return nil
+	}
+}
+
+// NewLocationService creates a new location service
+func NewLocationService(
+	appointmentRepo repository.AppointmentRepository,
+	operationRepo repository.OperationRepository,
+	supplierRepo repository.SupplierRepository,
+	geocoder geo.Geocoder,
+	breakers *circuitbreaker.Registry,
+	config *config.Config,
+) LocationService {
+	return &locationService{
+		appointmentRepo: appointmentRepo,
+		operationRepo:   operationRepo,
+		supplierRepo:    supplierRepo,
+		geocoder:        geocoder,
+		estimator: geo.TravelEstimator{
+			AverageSpeedKmh: config.Geocoding.AverageSpeedKmh,
+			RoadFactor:      config.Geocoding.RoadFactor,
+		},
+		breakers: breakers,
+		config:   config,
+	}
+}
+
+// GeocodeOperation resolves and stores the coordinates of an operation's address
+func (s *locationService) GeocodeOperation(id uint) (*models.Operation, error) {
+	operation, err := s.operationRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	point, err := s.geocode(context.Background(), operationAddress(operation))
+	if err != nil {
+		return nil, err
+	}
+
+	operation.Latitude = &point.Latitude
+	operation.Longitude = &point.Longitude
+	if err := s.operationRepo.Update(operation); err != nil {
+		return nil, err
+	}
+	return operation, nil
+}
+
+// GeocodeSupplier resolves and stores the coordinates of a supplier's address
+func (s *locationService) GeocodeSupplier(id uint) (*models.Supplier, error) {
+	supplier, err := s.supplierRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	point, err := s.geocode(context.Background(), supplier.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	supplier.Latitude = &point.Latitude
+	supplier.Longitude = &point.Longitude
+	if err := s.supplierRepo.Update(supplier); err != nil {
+		return nil, err
+	}
+	return supplier, nil
+}
+
+// GeocodeMissing geocodes a batch of operations and suppliers that have no coordinates yet.
+// Addresses that cannot be resolved are logged and skipped. It returns how many records were updated.
+func (s *locationService) GeocodeMissing(ctx context.Context) (int, error) {
+	if s.geocoder == nil {
+		return 0, nil
+	}
+
+	updated := 0
+
+	operations, err := s.operationRepo.FindMissingCoordinates(geocodeBatchSize)
+	if err != nil {
+		return updated, err
+	}
+	for i := range operations {
+		if ctx.Err() != nil {
+			return updated, ctx.Err()
+		}
+		operation := &operations[i]
+		point, err := s.geocode(ctx, operationAddress(operation))
+		if err != nil {
+			log.Printf("Failed to geocode operation %d: %v", operation.ID, err)
+			continue
+		}
+		operation.Latitude = &point.Latitude
+		operation.Longitude = &point.Longitude
+		if err := s.operationRepo.Update(operation); err != nil {
+			return updated, err
+		}
+		updated++
+	}
+
+	suppliers, err := s.supplierRepo.FindMissingCoordinates(geocodeBatchSize)
+	if err != nil {
+		return updated, err
+	}
+	for i := range suppliers {
+		if ctx.Err() != nil {
+			return updated, ctx.Err()
+		}
+		supplier := &suppliers[i]
+		point, err := s.geocode(ctx, supplier.Address)
+		if err != nil {
+			log.Printf("Failed to geocode supplier %d: %v", supplier.ID, err)
+			continue
+		}
+		supplier.Latitude = &point.Latitude
+		supplier.Longitude = &point.Longitude
+		if err := s.supplierRepo.Update(supplier); err != nil {
+			return updated, err
+		}
+		updated++
+	}
+
+	return updated, nil
+}
+
+// CheckTravel compares an appointment with the supplier's other appointments within a day of it at
+// other operations and warns about every one that cannot be reached in time, either from the
+// previous appointment to this one or from this one to the next. Operations without coordinates
+// are skipped, so no warning is no guarantee.
+func (s *locationService) CheckTravel(appointment *models.Appointment) ([]TravelWarning, error) {
+	warnings := []TravelWarning{}
+
+	operation, err := s.operationRepo.FindByID(appointment.OperationID)
+	if err != nil {
+		return nil, err
+	}
+	origin, ok := operationPoint(operation)
+	if !ok {
+		return warnings, nil
+	}
+
+	start := appointment.ScheduledStart.Add(-24 * time.Hour)
+	end := appointment.ScheduledEnd.Add(24 * time.Hour)
+	others, _, err := s.appointmentRepo.FindBySupplier(appointment.SupplierID, repository.AppointmentFilters{
+		StartDate: &start,
+		EndDate:   &end,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	operations := map[uint]*models.Operation{operation.ID: operation}
+	for _, other := range others {
+		if other.ID == appointment.ID || other.OperationID == appointment.OperationID || other.Status.IsFinal() {
+			continue
+		}
+
+		otherOperation, ok := operations[other.OperationID]
+		if !ok {
+			otherOperation, err = s.operationRepo.FindByID(other.OperationID)
+			if err != nil {
+				return nil, err
+			}
+			operations[other.OperationID] = otherOperation
+		}
+		destination, ok := operationPoint(otherOperation)
+		if !ok {
+			continue
+		}
+
+		// Time between the end of the earlier appointment and the start of the later one
+		var available time.Duration
+		switch {
+		case !other.ScheduledStart.Before(appointment.ScheduledStart):
+			available = other.ScheduledStart.Sub(appointment.ScheduledEnd)
+		default:
+			available = appointment.ScheduledStart.Sub(other.ScheduledEnd)
+		}
+
+		travel := s.estimator.Estimate(origin, destination)
+		if available >= travel {
+			continue
+		}
+
+		distanceKm := math.Round(geo.Distance(origin, destination)/100) / 10
+		warnings = append(warnings, TravelWarning{
+			AppointmentID:    other.ID,
+			OperationID:      otherOperation.ID,
+			OperationName:    otherOperation.Name,
+			ScheduledStart:   other.ScheduledStart,
+			ScheduledEnd:     other.ScheduledEnd,
+			DistanceKm:       distanceKm,
+			TravelMinutes:    int(travel.Minutes()),
+			AvailableMinutes: int(available.Minutes()),
+			Message: fmt.Sprintf("Appointment %s at %s is %.1f km away: about %d minutes of travel, but only %d minutes between appointments",
+				other.Reference(), otherOperation.Name, distanceKm, int(travel.Minutes()), int(available.Minutes())),
+		})
+	}
+
+	return warnings, nil
+}
+
+// geocode resolves an address through the geocoding circuit breaker. Unknown addresses do not
+// count as provider failures.
+func (s *locationService) geocode(ctx context.Context, address string) (geo.Point, error) {
+	if s.geocoder == nil {
+		return geo.Point{}, ErrGeocodingDisabled
+	}
+	if strings.TrimSpace(address) == "" {
+		return geo.Point{}, ErrMissingAddress
+	}
+
+	var point geo.Point
+	var lookupErr error
+	call := func() error {
+		var err error
+		point, err = s.geocoder.Geocode(ctx, address)
+		if errors.Is(err, geo.ErrAddressNotFound) {
+			lookupErr = err
+			return nil
+		}
+		return err
+	}
+
+	var err error
+	if s.breakers != nil {
+		err = s.breakers.Get(ProviderGeocoding).Execute(call)
+	} else {
+		err = call()
+	}
+	if err != nil {
+		return geo.Point{}, fmt.Errorf("%w: %v", ErrGeocodingFailed, err)
+	}
+	if lookupErr != nil {
+		return geo.Point{}, lookupErr
+	}
+	return point, nil
+}
+
+// operationAddress returns the full postal address of an operation
+func operationAddress(operation *models.Operation) string {
+	parts := make([]string, 0, 5)
+	for _, part := range []string{operation.Address, operation.City, operation.State, operation.ZipCode, operation.Country} {
+		if strings.TrimSpace(part) != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// operationPoint returns the coordinates of an operation, if it has been geocoded
+func operationPoint(operation *models.Operation) (geo.Point, bool) {
+	if operation.Latitude == nil || operation.Longitude == nil {
+		return geo.Point{}, false
+	}
+	return geo.Point{Latitude: *operation.Latitude, Longitude: *operation.Longitude}, true
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+)
+
+// collapseIntoPending merges a new notification into a pending duplicate queued within the
+// collapse window for the same recipient, appointment and event. It reports whether the
+// notification was merged, in which case nothing new needs to be queued. A duplicate whose
+// queue item is locked by a worker isn't merged into.
+func (s *notificationService) collapseIntoPending(notification *models.Notification, priority int) (bool, error) {
+	if s.config == nil || s.config.Notification == nil || s.config.Notification.CollapseWindow <= 0 {
+		return false, nil
+	}
+	if notification.AppointmentID == nil {
+		return false, nil
+	}
+
+	since := s.clock.Now().Add(-s.config.Notification.CollapseWindow)
+	existing, err := s.notificationRepo.FindPendingDuplicate(notification, since)
+	if err != nil || existing == nil {
+		return false, err
+	}
+
+	templateData, err := mergeTemplateData(existing.TemplateData, notification.TemplateData)
+	if err != nil {
+		return false, err
+	}
+
+	// Re-render from the template with the cumulative data; notifications without a
+	// template keep the content of the latest one
+	existing.TemplateData = templateData
+	existing.TemplateID = notification.TemplateID
+	existing.Subject = notification.Subject
+	existing.Body = notification.Body
+	s.assignVariant(existing)
+	if err := s.renderNotification(existing); err != nil {
+		return false, err
+	}
+
+	if priority > existing.Priority {
+		existing.Priority = priority
+	}
+	existing.MergedCount++
+
+	// The queue orders by the queue item's priority, so it's raised along with the
+	// notification's. A duplicate a worker already picked up is left to go out as it is.
+	updated, err := s.notificationRepo.UpdateCollapsed(existing, s.clock.Now())
+	if err != nil {
+		return false, fmt.Errorf("failed to update collapsed notification: %w", err)
+	}
+	if !updated {
+		return false, nil
+	}
+
+	// Point the caller at the notification that will actually be delivered
+	*notification = *existing
+	return true, nil
+}
+
+// mergeTemplateData combines the template data of two notifications. Fields of the newer data
+// win, except for "changes" which accumulate: a field changed twice keeps its original old
+// value and takes the latest new value.
+func mergeTemplateData(older, newer string) (string, error) {
+	if older == "" {
+		return newer, nil
+	}
+	if newer == "" {
+		return older, nil
+	}
+
+	var olderData, newerData map[string]interface{}
+	if err := json.Unmarshal([]byte(older), &olderData); err != nil {
+		return "", fmt.Errorf("failed to parse template data: %w", err)
+	}
+	if err := json.Unmarshal([]byte(newer), &newerData); err != nil {
+		return "", fmt.Errorf("failed to parse template data: %w", err)
+	}
+
+	olderChanges, _ := olderData["changes"].(map[string]interface{})
+	newerChanges, _ := newerData["changes"].(map[string]interface{})
+	if olderChanges != nil || newerChanges != nil {
+		changes := make(map[string]interface{}, len(olderChanges)+len(newerChanges))
+		for field, change := range olderChanges {
+			changes[field] = change
+		}
+		for field, change := range newerChanges {
+			changes[field] = mergeChange(changes[field], change)
+		}
+		newerData["changes"] = changes
+	}
+
+	merged, err := json.Marshal(newerData)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal template data: %w", err)
+	}
+	return string(merged), nil
+}
+
+// mergeChange combines two changes of the same field, keeping the first "old" value
+func mergeChange(previous, latest interface{}) interface{} {
+	previousChange, ok := previous.(map[string]interface{})
+	if !ok {
+		return latest
+	}
+	latestChange, ok := latest.(map[string]interface{})
+	if !ok {
+		return latest
+	}
+
+	oldValue, hasOld := previousChange["old"]
+	if !hasOld {
+		return latest
+	}
+
+	merged := make(map[string]interface{}, len(latestChange))
+	for key, value := range latestChange {
+		merged[key] = value
+	}
+	merged["old"] = oldValue
+	return merged
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"html"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+)
+
+// assignVariant puts a notification rendered from its event's template in the experiment running
+// for the event, recipient type and channel, if any, and points it at the template of its
+// variant. The variant depends only on the recipient and appointment, so every notification of an
+// appointment reaches the recipient with the same copy.
+func (s *notificationService) assignVariant(notification *models.Notification) {
+	if s.experimentRepo == nil || notification.TemplateID == nil || *notification.TemplateID == 0 {
+		return
+	}
+	if notification.Subject != "" && notification.Body != "" {
+		return
+	}
+
+	notification.ExperimentID = nil
+	notification.Variant = ""
+	experiment, err := s.experimentRepo.FindRunning(notification.Event, notification.RecipientType, notification.Type)
+	if err != nil {
+		if !errors.Is(err, repository.ErrTemplateExperimentNotFound) {
+			log.Printf("Failed to look up the template experiment of %s notifications: %v", notification.Event, err)
+		}
+		return
+	}
+
+	variant := experimentVariant(experiment, notification)
+	templateID := experiment.TemplateID(variant)
+	notification.TemplateID = &templateID
+	notification.ExperimentID = &experiment.ID
+	notification.Variant = variant
+}
+
+// experimentVariant picks a notification's variant: the recipient and appointment are hashed
+// into 100 buckets, the first SplitB of which get variant B
+func experimentVariant(experiment *models.TemplateExperiment, notification *models.Notification) string {
+	var appointmentID uint
+	if notification.AppointmentID != nil {
+		appointmentID = *notification.AppointmentID
+	}
+	hash := fnv.New32a()
+	fmt.Fprintf(hash, "%d:%s:%d:%d", experiment.ID, notification.RecipientType, notification.RecipientID, appointmentID)
+	if int(hash.Sum32()%100) < experiment.SplitB {
+		return models.VariantB
+	}
+	return models.VariantA
+}
+
+// withOpenTracking adds the image tracking opens to the HTML body of an email sent in a template
+// experiment. Plain text bodies and notifications outside experiments are returned unchanged.
+func (s *notificationService) withOpenTracking(notification *models.Notification, body string) string {
+	if notification.ExperimentID == nil || s.config == nil || s.config.Experiments.TrackingURL == "" {
+		return body
+	}
+	if !strings.Contains(body, "</") {
+		return body
+	}
+
+	url := s.config.Experiments.TrackingURL + "/" + openTrackingToken(s.config.Auth.JWTSecret, notification.ID)
+	pixel := `<img src="` + html.EscapeString(url) + `" width="1" height="1" alt="" style="display:none">`
+	if i := strings.LastIndex(strings.ToLower(body), "</body>"); i >= 0 {
+		return body[:i] + pixel + body[i:]
+	}
+	return body + pixel
+}
+
+// openTrackingToken returns the token of a notification's tracking image: its ID and the
+// URL-safe HMAC of the ID, so opens can't be recorded for other notifications
+func openTrackingToken(secret string, notificationID uint) string {
+	id := strconv.FormatUint(uint64(notificationID), 10)
+	return id + "." + signOpenTracking(secret, id)
+}
+
+// parseOpenTrackingToken returns the notification a tracking image token was issued for
+func parseOpenTrackingToken(secret, token string) (uint, bool) {
+	id, signature, found := strings.Cut(token, ".")
+	if !found {
+		return 0, false
+	}
+	notificationID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil || notificationID == 0 {
+		return 0, false
+	}
+	if !hmac.Equal([]byte(signature), []byte(signOpenTracking(secret, id))) {
+		return 0, false
+	}
+	return uint(notificationID), true
+}
+
+// signOpenTracking signs the notification ID of a tracking image
+func signOpenTracking(secret, id string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("notification-open:" + id))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/metrics"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+)
+
+// genericFallbackContent is the fallback wording of events without built-in templates
+var genericFallbackContent = defaultTemplateContent{
+	subject: "Appointment {{.reference}} update",
+	message: `There is an update on appointment {{.reference}}{{if .status}}, which is now {{.status}}{{end}}.`,
+	linked:  true,
+}
+
+// MissingTemplate is an event, recipient and channel combination without an active template
+type MissingTemplate struct {
+	Event         models.NotificationEvent         `json:"event"`
+	RecipientType models.NotificationRecipientType `json:"recipient_type"`
+	Type          models.NotificationType          `json:"type"`
+	FallbackUses  int64                            `json:"fallback_uses"` // notifications sent with the fallback since startup
+	LastUsedAt    *time.Time                       `json:"last_used_at,omitempty"`
+}
+
+// templateKey identifies an event, recipient and channel combination
+type templateKey struct {
+	event     models.NotificationEvent
+	recipient models.NotificationRecipientType
+	channel   models.NotificationType
+}
+
+// missingTemplateTracker counts fallback template uses per combination
+type missingTemplateTracker struct {
+	mu   sync.Mutex
+	uses map[templateKey]*MissingTemplate
+}
+
+// newMissingTemplateTracker creates an empty tracker
+func newMissingTemplateTracker() *missingTemplateTracker {
+	return &missingTemplateTracker{uses: make(map[templateKey]*MissingTemplate)}
+}
+
+// fallbackTemplate returns the built-in plain-text template of an event. It is not stored, so it
+// has no ID.
+func fallbackTemplate(event models.NotificationEvent, recipientType models.NotificationRecipientType, notificationType models.NotificationType) *models.NotificationTemplate {
+	content, ok := defaultTemplateContents[event]
+	if !ok {
+		content = genericFallbackContent
+	}
+
+	body := content.message
+	if content.linked {
+		body += ` {{link "appointments" .appointment_id}}`
+	}
+	return &models.NotificationTemplate{
+		Name:          fmt.Sprintf("fallback_%s", event),
+		Description:   "Built-in fallback used when no template is found",
+		Subject:       content.subject,
+		BodyText:      body,
+		Type:          notificationType,
+		Event:         event,
+		RecipientType: recipientType,
+		IsActive:      true,
+		IsSystem:      true,
+	}
+}
+
+// recordMissingTemplate logs and counts a notification sent with the fallback template
+func (s *notificationService) recordMissingTemplate(event models.NotificationEvent, recipientType models.NotificationRecipientType, notificationType models.NotificationType, cause error) {
+	log.Printf("No %s template for %s to %s, using the fallback: %v", notificationType, event, recipientType, cause)
+	metrics.NotificationTemplateMissing.WithLabelValues(string(event), string(recipientType), string(notificationType)).Inc()
+
+	if s.missingTemplates == nil {
+		return
+	}
+	now := s.clock.Now()
+	key := templateKey{event: event, recipient: recipientType, channel: notificationType}
+
+	s.missingTemplates.mu.Lock()
+	defer s.missingTemplates.mu.Unlock()
+
+	entry, ok := s.missingTemplates.uses[key]
+	if !ok {
+		entry = &MissingTemplate{Event: event, RecipientType: recipientType, Type: notificationType}
+		s.missingTemplates.uses[key] = entry
+	}
+	entry.FallbackUses++
+	entry.LastUsedAt = &now
+}
+
+// MissingTemplates reports every event, recipient and channel combination without an active
+// template, together with how often the fallback was used for it since startup. Combinations a
+// fallback was used for are listed even if a template has been added since.
+func (s *notificationService) MissingTemplates() ([]MissingTemplate, error) {
+	report := make(map[templateKey]MissingTemplate)
+
+	for _, event := range defaultTemplateEvents {
+		for _, recipient := range defaultTemplateRecipients {
+			for _, channel := range defaultTemplateChannels {
+				if _, err := s.templateRepo.GetByEvent(event, recipient, channel); err == nil {
+					continue
+				} else if !errors.Is(err, repository.ErrNotificationTemplateNotFound) {
+					return nil, err
+				}
+				key := templateKey{event: event, recipient: recipient, channel: channel}
+				report[key] = MissingTemplate{Event: event, RecipientType: recipient, Type: channel}
+			}
+		}
+	}
+
+	if s.missingTemplates != nil {
+		s.missingTemplates.mu.Lock()
+		for key, entry := range s.missingTemplates.uses {
+			report[key] = *entry
+		}
+		s.missingTemplates.mu.Unlock()
+	}
+
+	missing := make([]MissingTemplate, 0, len(report))
+	for _, entry := range report {
+		missing = append(missing, entry)
+	}
+	sort.Slice(missing, func(i, j int) bool {
+		if missing[i].Event != missing[j].Event {
+			return missing[i].Event < missing[j].Event
+		}
+		if missing[i].RecipientType != missing[j].RecipientType {
+			return missing[i].RecipientType < missing[j].RecipientType
+		}
+		return missing[i].Type < missing[j].Type
+	})
+	return missing, nil
+}
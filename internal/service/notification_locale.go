@@ -0,0 +1,52 @@
+package service
+
+import (
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/pkg/i18n"
+)
+
+// recipientFormat returns the locale and timezone to render a notification in: the recipient's
+// locale, and the timezone of the operation referenced by the template data or, without one, the
+// recipient's own
+func (s *notificationService) recipientFormat(notification *models.Notification, data map[string]interface{}) (string, *time.Location) {
+	locale, location := i18n.DefaultLocale, s.operationLocation(data)
+	recipient, err := s.recipients.Resolve(notification.RecipientType, notification.RecipientID)
+	if err == nil {
+		locale = recipient.Locale
+		if location == nil {
+			location = recipient.Timezone
+		}
+	}
+	return locale, location
+}
+
+// recipientUserID returns the user a notification is addressed to, or 0 if it cannot be found
+func (s *notificationService) recipientUserID(notification *models.Notification) uint {
+	recipient, err := s.recipients.Resolve(notification.RecipientType, notification.RecipientID)
+	if err != nil {
+		return 0
+	}
+	return recipient.UserID
+}
+
+// operationLocation returns the timezone of the operation referenced by the template data, or
+// nil when there is none
+func (s *notificationService) operationLocation(data map[string]interface{}) *time.Location {
+	if s.operationRepo == nil {
+		return nil
+	}
+
+	// Template data round-trips through JSON, so IDs arrive as float64
+	id, err := i18n.ToFloat(data["operation_id"])
+	if err != nil || id <= 0 {
+		return nil
+	}
+
+	operation, err := s.operationRepo.FindByID(uint(id))
+	if err != nil {
+		return nil
+	}
+	return i18n.LoadLocation(operation.Timezone)
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"log"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+)
+
+// MuteAppointment silences further notifications of an appointment for a user
+func (s *notificationService) MuteAppointment(appointmentID, userID uint) (*models.AppointmentMute, error) {
+	return s.muteRepo.Mute(appointmentID, userID)
+}
+
+// UnmuteAppointment lets an appointment's notifications reach a user again
+func (s *notificationService) UnmuteAppointment(appointmentID, userID uint) error {
+	return s.muteRepo.Unmute(appointmentID, userID)
+}
+
+// IsAppointmentMuted reports whether a user muted an appointment
+func (s *notificationService) IsAppointmentMuted(appointmentID, userID uint) (bool, error) {
+	return s.muteRepo.IsMuted(appointmentID, userID)
+}
+
+// mutedFor reports whether the recipient muted the appointment a notification is about. A
+// failed lookup lets the notification through.
+func (s *notificationService) mutedFor(notification *models.Notification, userID uint) bool {
+	if s.muteRepo == nil || notification.AppointmentID == nil || userID == 0 {
+		return false
+	}
+
+	muted, err := s.muteRepo.IsMuted(*notification.AppointmentID, userID)
+	if err != nil {
+		log.Printf("Failed to check whether user %d muted appointment %d: %v", userID, *notification.AppointmentID, err)
+		return false
+	}
+	return muted
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+)
+
+// ErrNotificationsPaused is returned when pausing while a pause is already active
+var ErrNotificationsPaused = errors.New("notifications are already paused")
+
+// ErrNotificationsNotPaused is returned when resuming while no pause is active
+var ErrNotificationsNotPaused = errors.New("notifications are not paused")
+
+// PauseRequest describes a maintenance window to start
+type PauseRequest struct {
+	Reason         string
+	EndsAt         *time.Time
+	CriticalEvents []models.NotificationEvent // Defaults to the configured critical events
+}
+
+// NotificationPauseService defines the interface for notification maintenance windows
+type NotificationPauseService interface {
+	Pause(request PauseRequest, userID uint) (*models.NotificationPause, error)
+	Resume(userID uint) (*models.NotificationPause, error)
+	ActivePause() (*models.NotificationPause, error)
+	History(limit int) ([]models.NotificationPause, error)
+	CriticalEvents(pause *models.NotificationPause) []models.NotificationEvent
+}
+
+// notificationPauseService implements the NotificationPauseService interface
+type notificationPauseService struct {
+	pauseRepo        repository.NotificationPauseRepository
+	notificationRepo repository.NotificationRepository
+	queueRepo        repository.NotificationQueueRepository
+	config           *config.Config
+	clock            clock.Clock
+}
+
+// NewNotificationPauseService creates a new notification pause service
+func NewNotificationPauseService(
+	pauseRepo repository.NotificationPauseRepository,
+	notificationRepo repository.NotificationRepository,
+	queueRepo repository.NotificationQueueRepository,
+	config *config.Config,
+	clock clock.Clock,
+) NotificationPauseService {
+	return &notificationPauseService{
+		pauseRepo:        pauseRepo,
+		notificationRepo: notificationRepo,
+		queueRepo:        queueRepo,
+		config:           config,
+		clock:            clock,
+	}
+}
+
+// Pause starts a maintenance window holding all non-critical notifications in the queue
+func (s *notificationPauseService) Pause(request PauseRequest, userID uint) (*models.NotificationPause, error) {
+	if strings.TrimSpace(request.Reason) == "" {
+		return nil, errors.New("reason is required")
+	}
+
+	now := s.clock.Now()
+	if request.EndsAt != nil && !request.EndsAt.After(now) {
+		return nil, errors.New("end time must be in the future")
+	}
+
+	active, err := s.ActivePause()
+	if err != nil {
+		return nil, err
+	}
+	if active != nil {
+		return nil, ErrNotificationsPaused
+	}
+
+	events := request.CriticalEvents
+	if events == nil && s.config != nil && s.config.Notification != nil {
+		for _, event := range s.config.Notification.PauseCriticalEvents {
+			events = append(events, models.NotificationEvent(event))
+		}
+	}
+	names := make([]string, 0, len(events))
+	for _, event := range events {
+		names = append(names, string(event))
+	}
+
+	pause := &models.NotificationPause{
+		Reason:         request.Reason,
+		StartedAt:      now,
+		EndsAt:         request.EndsAt,
+		CreatedByID:    userID,
+		CriticalEvents: strings.Join(names, ","),
+	}
+	if err := s.pauseRepo.Create(pause); err != nil {
+		return nil, fmt.Errorf("failed to pause notifications: %w", err)
+	}
+
+	log.Printf("Notifications paused by user %d: %s", userID, pause.Reason)
+	return pause, nil
+}
+
+// Resume ends the active maintenance window and releases the held notifications
+func (s *notificationPauseService) Resume(userID uint) (*models.NotificationPause, error) {
+	pause, err := s.pauseRepo.GetActive()
+	if err != nil {
+		return nil, err
+	}
+	if pause == nil {
+		return nil, ErrNotificationsNotPaused
+	}
+
+	if err := s.release(pause, &userID); err != nil {
+		return nil, err
+	}
+	return pause, nil
+}
+
+// ActivePause returns the active maintenance window, or nil if notifications are flowing.
+// A window whose end time has passed is released on the first call after it expires.
+func (s *notificationPauseService) ActivePause() (*models.NotificationPause, error) {
+	pause, err := s.pauseRepo.GetActive()
+	if err != nil || pause == nil {
+		return nil, err
+	}
+
+	if pause.EndsAt != nil && !pause.EndsAt.After(s.clock.Now()) {
+		if err := s.release(pause, nil); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	return pause, nil
+}
+
+// History returns the most recent maintenance windows
+func (s *notificationPauseService) History(limit int) ([]models.NotificationPause, error) {
+	return s.pauseRepo.List(limit)
+}
+
+// CriticalEvents returns the events that are delivered despite the pause
+func (s *notificationPauseService) CriticalEvents(pause *models.NotificationPause) []models.NotificationEvent {
+	var events []models.NotificationEvent
+	for _, event := range strings.Split(pause.CriticalEvents, ",") {
+		if event = strings.TrimSpace(event); event != "" {
+			events = append(events, models.NotificationEvent(event))
+		}
+	}
+	return events
+}
+
+// release marks a pause as released after collapsing the notifications queued during it
+func (s *notificationPauseService) release(pause *models.NotificationPause, userID *uint) error {
+	collapsed, err := s.collapseDuplicates(pause.StartedAt)
+	if err != nil {
+		return fmt.Errorf("failed to collapse held notifications: %w", err)
+	}
+
+	now := s.clock.Now()
+	pause.ReleasedAt = &now
+	pause.ReleasedByID = userID
+	pause.CollapsedCount = collapsed
+	if err := s.pauseRepo.Update(pause); err != nil {
+		return fmt.Errorf("failed to resume notifications: %w", err)
+	}
+
+	log.Printf("Notifications resumed after pause %d, %d duplicate notifications collapsed", pause.ID, collapsed)
+	return nil
+}
+
+// collapseDuplicates cancels held notifications superseded by a newer notification for the
+// same recipient, channel, event, appointment and scheduled time, so recipients get one message per change
+func (s *notificationPauseService) collapseDuplicates(since time.Time) (int, error) {
+	notifications, err := s.notificationRepo.GetPendingCreatedSince(since)
+	if err != nil {
+		return 0, err
+	}
+
+	// Notifications are ordered oldest first, so the last one seen per key is kept
+	latest := make(map[string]uint)
+	for _, notification := range notifications {
+		latest[collapseKey(&notification)] = notification.ID
+	}
+
+	var cancelled []uint
+	for i := range notifications {
+		notification := &notifications[i]
+		keepID := latest[collapseKey(notification)]
+		if keepID == notification.ID {
+			continue
+		}
+
+		errorMsg := fmt.Sprintf("collapsed into notification %d after maintenance pause", keepID)
+		notification.Status = models.NotificationStatusCancelled
+		notification.ErrorMessage = &errorMsg
+		if err := s.notificationRepo.Update(notification); err != nil {
+			return len(cancelled), err
+		}
+		cancelled = append(cancelled, notification.ID)
+	}
+
+	if err := s.queueRepo.CancelByNotificationIDs(cancelled); err != nil {
+		return len(cancelled), err
+	}
+	return len(cancelled), nil
+}
+
+// collapseKey identifies notifications that would deliver the same message to the same recipient.
+// Notifications scheduled for different times, such as the day-before and hour-before reminders
+// of an appointment, are different messages and get different keys.
+func collapseKey(notification *models.Notification) string {
+	appointmentID := uint(0)
+	if notification.AppointmentID != nil {
+		appointmentID = *notification.AppointmentID
+	}
+	scheduledFor := ""
+	if notification.ScheduledFor != nil {
+		scheduledFor = notification.ScheduledFor.UTC().Format(time.RFC3339)
+	}
+	return fmt.Sprintf("%s/%s/%d/%s/%d/%s", notification.Type, notification.RecipientType,
+		notification.RecipientID, notification.Event, appointmentID, scheduledFor)
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/metrics"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+)
+
+// Notification queues
+const (
+	QueueAppointmentNotifications = "appointment_notifications"
+	QueueSupplierNotifications    = "supplier_notifications"
+)
+
+// NotificationQueues are the queues notifications are enqueued on, each sent by its own worker job
+var NotificationQueues = []string{QueueAppointmentNotifications, QueueSupplierNotifications}
+
+// isHighPriority reports whether a queue priority belongs to the high priority lane
+func (s *notificationService) isHighPriority(priority int) bool {
+	threshold := 3
+	if s.config != nil && s.config.Notification != nil && s.config.Notification.HighPriorityThreshold > 0 {
+		threshold = s.config.Notification.HighPriorityThreshold
+	}
+	return priority >= threshold
+}
+
+// acquireWorker blocks until a worker is free and returns the pool it was taken from.
+// High priority items may take a worker from either the shared or the reserved pool,
+// so a backlog of normal notifications can never occupy every worker.
+func (s *notificationService) acquireWorker(priority int) chan struct{} {
+	if s.isHighPriority(priority) && cap(s.reservedPool) > 0 {
+		select {
+		case s.workerPool <- struct{}{}:
+			return s.workerPool
+		case s.reservedPool <- struct{}{}:
+			return s.reservedPool
+		}
+	}
+
+	s.workerPool <- struct{}{}
+	return s.workerPool
+}
+
+// WorkerStats describes the notification worker pools of this instance
+type WorkerStats struct {
+	WorkerID     string `json:"worker_id"` // ProcessorID this instance locks queue items with
+	PoolSize     int    `json:"pool_size"`
+	Busy         int    `json:"busy"`
+	ReservedSize int    `json:"reserved_size"` // workers only high priority items may use
+	ReservedBusy int    `json:"reserved_busy"`
+}
+
+// WorkerStats returns how many workers of each pool are sending a notification right now
+func (s *notificationService) WorkerStats() WorkerStats {
+	return WorkerStats{
+		WorkerID:     s.workerID,
+		PoolSize:     cap(s.workerPool),
+		Busy:         len(s.workerPool),
+		ReservedSize: cap(s.reservedPool),
+		ReservedBusy: len(s.reservedPool),
+	}
+}
+
+// recordQueueDepth publishes the number of pending items per priority of a queue
+func (s *notificationService) recordQueueDepth(queueName string) {
+	counts, err := s.queueRepo.CountPendingByPriority(queueName)
+	if err != nil {
+		log.Printf("Failed to count pending items of queue %s: %v", queueName, err)
+		return
+	}
+
+	metrics.NotificationQueueDepth.DeletePartialMatch(map[string]string{"queue": queueName})
+	for priority, count := range counts {
+		metrics.NotificationQueueDepth.WithLabelValues(queueName, strconv.Itoa(priority)).Set(float64(count))
+	}
+}
+
+// activePause returns the active notification maintenance pause, if any
+func (s *notificationService) activePause() *models.NotificationPause {
+	if s.pauses == nil {
+		return nil
+	}
+
+	pause, err := s.pauses.ActivePause()
+	if err != nil {
+		// Keep delivering rather than silently holding everything on a lookup failure
+		log.Printf("Failed to check notification pause: %v", err)
+		return nil
+	}
+	return pause
+}
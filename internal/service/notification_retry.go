@@ -0,0 +1,66 @@
+package service
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+)
+
+// defaultRetryPolicy is used when no notification configuration is loaded
+var defaultRetryPolicy = config.RetryPolicy{
+	MaxRetries: 3,
+	BaseDelay:  5 * time.Minute,
+	Multiplier: 3,
+	MaxDelay:   24 * time.Hour,
+}
+
+// retryPolicyFor resolves the retry policy for a notification from its channel and priority.
+// Lookup order is "<channel>_high" (for high priority), "<channel>", then "default".
+func (s *notificationService) retryPolicyFor(notification *models.Notification) config.RetryPolicy {
+	if s.config == nil || s.config.Notification == nil {
+		return defaultRetryPolicy
+	}
+
+	policies := s.config.Notification.RetryPolicies
+	channel := string(notification.Type)
+	if notification.Priority >= s.config.Notification.HighPriorityThreshold {
+		if policy, ok := policies[channel+"_high"]; ok {
+			return policy
+		}
+	}
+	if policy, ok := policies[channel]; ok {
+		return policy
+	}
+	if policy, ok := policies["default"]; ok {
+		return policy
+	}
+	return defaultRetryPolicy
+}
+
+// retryDelay computes the delay before a retry attempt (1-based) using exponential
+// backoff with optional jitter, capped at the policy's maximum delay
+func retryDelay(policy config.RetryPolicy, attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	multiplier := policy.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay := float64(policy.BaseDelay) * math.Pow(multiplier, float64(attempt-1))
+
+	// Spread retries to avoid synchronized bursts against a recovering provider
+	if policy.Jitter > 0 {
+		jitter := math.Min(policy.Jitter, 1)
+		delay += delay * jitter * (2*rand.Float64() - 1)
+	}
+
+	if policy.MaxDelay > 0 && delay > float64(policy.MaxDelay) {
+		delay = float64(policy.MaxDelay)
+	}
+	return time.Duration(delay)
+}
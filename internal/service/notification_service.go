@@ -1,19 +1,22 @@
 package service
 
 import (
-	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"html/template"
+	htmlTemplate "html/template"
 	"log"
+	"net/http"
 	"sync"
-	"text/template"
+	textTemplate "text/template"
 	"time"
 
 	"github.com/bernardofernandezz/scheduling-api/internal/config"
 	"github.com/bernardofernandezz/scheduling-api/internal/models"
 	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/circuitbreaker"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+	"github.com/bernardofernandezz/scheduling-api/pkg/i18n"
 )
 
 // NotificationService defines the interface for notification operations
@@ -28,6 +31,7 @@ type NotificationService interface {
 	// Template management
 	GetTemplateByEvent(event models.NotificationEvent, recipientType models.NotificationRecipientType, notificationType models.NotificationType) (*models.NotificationTemplate, error)
 	RenderTemplate(template *models.NotificationTemplate, data map[string]interface{}) (subject string, bodyText string, bodyHTML string, err error)
+	MissingTemplates() ([]MissingTemplate, error)
 	
 	// Notification sending
 	SendNotification(notification *models.Notification) error
@@ -38,12 +42,18 @@ type NotificationService interface {
 	// Queue management
 	EnqueueNotification(notification *models.Notification, queueName string, priority int) error
 	ProcessQueue(queueName string, batchSize int) error
+	WorkerStats() WorkerStats
 	
 	// Appointment event notifications
 	NotifyAppointmentCreated(appointment *models.Appointment) error
 	NotifyAppointmentUpdated(appointment *models.Appointment, changes map[string]interface{}) error
 	NotifyAppointmentStatusChanged(appointment *models.Appointment, oldStatus models.AppointmentStatus) error
 	ScheduleAppointmentReminder(appointment *models.Appointment, hoursBeforeAppointment int) error
+	
+	// Per-appointment mutes
+	MuteAppointment(appointmentID, userID uint) (*models.AppointmentMute, error)
+	UnmuteAppointment(appointmentID, userID uint) error
+	IsAppointmentMuted(appointmentID, userID uint) (bool, error)
 }
 
 // notificationService implements the NotificationService interface
@@ -51,17 +61,26 @@ type notificationService struct {
 	notificationRepo   repository.NotificationRepository
 	templateRepo       repository.NotificationTemplateRepository
 	queueRepo          repository.NotificationQueueRepository
-	preferenceRepo     repository.NotificationPreferenceRepository
-	userRepo           repository.UserRepository
-	employeeRepo       repository.EmployeeRepository
-	supplierRepo       repository.SupplierRepository
+	recipients         RecipientResolver
+	operationRepo      repository.OperationRepository
+	watcherRepo        repository.WatcherRepository
+	muteRepo           repository.MuteRepository
+	experimentRepo     repository.TemplateExperimentRepository
 	config             *config.Config
+	breakers           *circuitbreaker.Registry
+	httpClient         *http.Client // shared outbound client provider integrations send through
+	pauses             NotificationPauseService
+	clock              clock.Clock
 	
 	// Worker pool for processing notifications
 	workerPool         chan struct{}
+	reservedPool       chan struct{} // only used by high priority items
 	workerPoolSize     int
 	workerMutex        sync.Mutex
 	workerID           string
+	
+	// Fallback template uses since startup, for the missing template report
+	missingTemplates   *missingTemplateTracker
 }
 
 // NewNotificationService creates a new notification service
@@ -69,30 +88,46 @@ func NewNotificationService(
 	notificationRepo repository.NotificationRepository,
 	templateRepo repository.NotificationTemplateRepository,
 	queueRepo repository.NotificationQueueRepository,
-	preferenceRepo repository.NotificationPreferenceRepository,
-	userRepo repository.UserRepository,
-	employeeRepo repository.EmployeeRepository,
-	supplierRepo repository.SupplierRepository,
+	recipients RecipientResolver,
+	operationRepo repository.OperationRepository,
+	watcherRepo repository.WatcherRepository,
+	muteRepo repository.MuteRepository,
+	experimentRepo repository.TemplateExperimentRepository,
 	config *config.Config,
+	breakers *circuitbreaker.Registry,
+	httpClient *http.Client,
+	pauses NotificationPauseService,
+	clock clock.Clock,
 ) NotificationService {
 	// Initialize worker pool
 	workerPoolSize := 5 // Default worker pool size
 	if config != nil && config.Notification != nil && config.Notification.WorkerPoolSize > 0 {
 		workerPoolSize = config.Notification.WorkerPoolSize
 	}
+	reservedWorkers := 1
+	if config != nil && config.Notification != nil && config.Notification.ReservedWorkers >= 0 {
+		reservedWorkers = config.Notification.ReservedWorkers
+	}
 
 	return &notificationService{
 		notificationRepo:   notificationRepo,
 		templateRepo:       templateRepo,
 		queueRepo:          queueRepo,
-		preferenceRepo:     preferenceRepo,
-		userRepo:           userRepo,
-		employeeRepo:       employeeRepo,
-		supplierRepo:       supplierRepo,
+		recipients:         recipients,
+		operationRepo:      operationRepo,
+		watcherRepo:        watcherRepo,
+		muteRepo:           muteRepo,
+		experimentRepo:     experimentRepo,
 		config:             config,
+		breakers:           breakers,
+		httpClient:         httpClient,
+		pauses:             pauses,
+		clock:              clock,
 		workerPool:         make(chan struct{}, workerPoolSize),
+		reservedPool:       make(chan struct{}, reservedWorkers),
 		workerPoolSize:     workerPoolSize,
 		workerID:           fmt.Sprintf("worker-%d", time.Now().UnixNano()),
+		missingTemplates:   newMissingTemplateTracker(),
 	}
 }
 
@@ -117,8 +152,20 @@ func (s *notificationService) CreateNotification(notification *models.Notificati
 		return errors.New("recipient ID is required")
 	}
 	
-	// If template ID is provided but no content, render the template
-	if notification.TemplateID != nil && *notification.TemplateID != "" && 
+	// Notifications of an event under a template experiment are rendered from their variant
+	s.assignVariant(notification)
+	if err := s.renderNotification(notification); err != nil {
+		return err
+	}
+	
+	// Create notification in repository
+	return s.notificationRepo.Create(notification)
+}
+
+// renderNotification renders the subject and body of a notification from its template
+// if a template ID is provided but no content
+func (s *notificationService) renderNotification(notification *models.Notification) error {
+	if notification.TemplateID != nil && *notification.TemplateID != 0 && 
 	   (notification.Subject == "" || notification.Body == "") {
 		// Parse template data
 		var templateData map[string]interface{}
@@ -128,29 +175,34 @@ func (s *notificationService) CreateNotification(notification *models.Notificati
 			}
 		}
 		
-		// Fetch template
-		template, err := s.templateRepo.GetByID(notification.TemplateID)
+		// Fetch template, falling back to the built-in one of the event when it is gone
+		template, err := s.templateRepo.GetByID(*notification.TemplateID)
 		if err != nil {
-			return fmt.Errorf("failed to fetch template: %w", err)
+			if notification.Event == "" {
+				return fmt.Errorf("failed to fetch template: %w", err)
+			}
+			log.Printf("Template of notification %d not found, using the fallback: %v", notification.ID, err)
+			template = fallbackTemplate(notification.Event, notification.RecipientType, notification.Type)
 		}
 		
-		// Render template
-		subject, bodyText, bodyHTML, err := s.RenderTemplate(template, templateData)
+		// Render template with the recipient's locale and the operation's timezone
+		locale, location := s.recipientFormat(notification, templateData)
+		formatter := i18n.NewFormatter(locale, location)
+		subject, bodyText, bodyHTML, err := s.renderTemplate(template, templateData, formatter)
 		if err != nil {
 			return fmt.Errorf("failed to render template: %w", err)
 		}
 		
 		// Set notification content
 		notification.Subject = subject
-		if notification.Type == models.NotificationTypeEmail {
+		if notification.Type == models.NotificationTypeEmail && bodyHTML != "" {
 			notification.Body = bodyHTML
 		} else {
 			notification.Body = bodyText
 		}
 	}
 	
-	// Create notification in repository
-	return s.notificationRepo.Create(notification)
+	return nil
 }
 
 // GetNotificationByID retrieves a notification by ID
@@ -177,7 +229,7 @@ func (s *notificationService) UpdateNotificationStatus(id uint, status models.No
 	}
 	
 	if status == models.NotificationStatusSent {
-		now := time.Now()
+		now := s.clock.Now()
 		notification.SentAt = &now
 	}
 	
@@ -201,49 +253,60 @@ func (s *notificationService) CancelNotification(id uint) error {
 	return s.notificationRepo.Update(notification)
 }
 
-// GetTemplateByEvent retrieves a template for a specific event, recipient type, and notification type
+// GetTemplateByEvent retrieves a template for a specific event, recipient type, and notification type.
+// When none is found the built-in fallback template of the event is returned, so the notification
+// is still sent, and the gap is recorded for the missing template report.
 func (s *notificationService) GetTemplateByEvent(event models.NotificationEvent, recipientType models.NotificationRecipientType, notificationType models.NotificationType) (*models.NotificationTemplate, error) {
-	return s.templateRepo.GetByEvent(event, recipientType, notificationType)
+	template, err := s.templateRepo.GetByEvent(event, recipientType, notificationType)
+	if err != nil {
+		s.recordMissingTemplate(event, recipientType, notificationType, err)
+		return fallbackTemplate(event, recipientType, notificationType), nil
+	}
+	return template, nil
 }
 
 // RenderTemplate renders a notification template with the provided data
 func (s *notificationService) RenderTemplate(template *models.NotificationTemplate, data map[string]interface{}) (subject string, bodyText string, bodyHTML string, err error) {
+	return s.renderTemplate(template, data, i18n.NewFormatter(i18n.DefaultLocale, time.UTC))
+}
+
+// renderTemplate renders a notification template with the formatting helpers of the given formatter
+func (s *notificationService) renderTemplate(template *models.NotificationTemplate, data map[string]interface{}, formatter *i18n.Formatter) (subject string, bodyText string, bodyHTML string, err error) {
+	funcs := s.templateFuncs(formatter, s.linkBaseURL(data))
+	
 	// Render subject
-	subjectTmpl, err := textTemplate.New("subject").Parse(template.Subject)
+	subjectTmpl, err := textTemplate.New("subject").Funcs(funcs).Parse(template.Subject)
 	if err != nil {
 		return "", "", "", fmt.Errorf("failed to parse subject template: %w", err)
 	}
 	
-	var subjectBuf bytes.Buffer
-	if err := subjectTmpl.Execute(&subjectBuf, data); err != nil {
+	subject, err = s.executeSandboxed(subjectTmpl, data)
+	if err != nil {
 		return "", "", "", fmt.Errorf("failed to render subject template: %w", err)
 	}
-	subject = subjectBuf.String()
 	
 	// Render body text
-	bodyTextTmpl, err := textTemplate.New("bodyText").Parse(template.BodyText)
+	bodyTextTmpl, err := textTemplate.New("bodyText").Funcs(funcs).Parse(template.BodyText)
 	if err != nil {
 		return "", "", "", fmt.Errorf("failed to parse body text template: %w", err)
 	}
 	
-	var bodyTextBuf bytes.Buffer
-	if err := bodyTextTmpl.Execute(&bodyTextBuf, data); err != nil {
+	bodyText, err = s.executeSandboxed(bodyTextTmpl, data)
+	if err != nil {
 		return "", "", "", fmt.Errorf("failed to render body text template: %w", err)
 	}
-	bodyText = bodyTextBuf.String()
 	
 	// Render body HTML if available
 	if template.BodyHTML != "" {
-		bodyHTMLTmpl, err := htmlTemplate.New("bodyHTML").Parse(template.BodyHTML)
+		bodyHTMLTmpl, err := htmlTemplate.New("bodyHTML").Funcs(funcs).Parse(template.BodyHTML)
 		if err != nil {
 			return "", "", "", fmt.Errorf("failed to parse body HTML template: %w", err)
 		}
 		
-		var bodyHTMLBuf bytes.Buffer
-		if err := bodyHTMLTmpl.Execute(&bodyHTMLBuf, data); err != nil {
+		bodyHTML, err = s.executeSandboxed(bodyHTMLTmpl, data)
+		if err != nil {
 			return "", "", "", fmt.Errorf("failed to render body HTML template: %w", err)
 		}
-		bodyHTML = bodyHTMLBuf.String()
 	}
 	
 	return subject, bodyText, bodyHTML, nil
@@ -260,79 +323,33 @@ func (s *notificationService) SendNotification(notification *models.Notification
 	var err error
 	errorMsg := ""
 	
-	// Get recipient contact information based on recipient type
+	// Set when a provider's circuit breaker is open and the send is deferred
+	var deferUntil time.Time
+	
+	// Get recipient contact information
 	var email string
 	var phoneNumber string
 	var userID uint
 	
-	switch notification.RecipientType {
-	case models.RecipientSupplier:
-		supplier, err := s.supplierRepo.GetByID(notification.RecipientID)
-		if err != nil {
-			errorMsg = fmt.Sprintf("failed to get supplier: %s", err.Error())
-			goto updateStatus
-		}
-		
-		// Get user associated with this supplier
-		user, err := s.userRepo.GetByID(supplier.UserID)
-		if err != nil {
-			errorMsg = fmt.Sprintf("failed to get supplier user: %s", err.Error())
-			goto updateStatus
-		}
-		
-		email = user.Email
-		userID = user.ID
-		
-		// Get phone from notification preferences if available
-		prefs, err := s.preferenceRepo.GetByUserID(user.ID)
-		if err == nil && prefs != nil {
-			phoneNumber = prefs.PhoneNumber
-		}
-		
-	case models.RecipientEmployee:
-		employee, err := s.employeeRepo.GetByID(notification.RecipientID)
-		if err != nil {
-			errorMsg = fmt.Sprintf("failed to get employee: %s", err.Error())
-			goto updateStatus
-		}
-		
-		// Get user associated with this employee
-		user, err := s.userRepo.GetByID(employee.UserID)
-		if err != nil {
-			errorMsg = fmt.Sprintf("failed to get employee user: %s", err.Error())
-			goto updateStatus
-		}
-		
-		email = user.Email
-		userID = user.ID
-		
-		// Get phone from notification preferences if available
-		prefs, err := s.preferenceRepo.GetByUserID(user.ID)
-		if err == nil && prefs != nil {
-			phoneNumber = prefs.PhoneNumber
-		}
-		
-	case models.RecipientAdmin:
-		// Get admin user
-		user, err := s.userRepo.GetByID(notification.RecipientID)
-		if err != nil {
-			errorMsg = fmt.Sprintf("failed to get admin user: %s", err.Error())
-			goto updateStatus
-		}
-		
-		email = user.Email
-		userID = user.ID
-		
-		// Get phone from notification preferences if available
-		prefs, err := s.preferenceRepo.GetByUserID(user.ID)
-		if err == nil && prefs != nil {
-			phoneNumber = prefs.PhoneNumber
-		}
+	recipient, resolveErr := s.recipients.Resolve(notification.RecipientType, notification.RecipientID)
+	if resolveErr != nil {
+		errorMsg = resolveErr.Error()
+		goto updateStatus
+	}
+	email = recipient.Email
+	phoneNumber = recipient.Phone
+	userID = recipient.UserID
+	
+	// A muted appointment is silenced for this recipient only; nothing is retried
+	if s.mutedFor(notification, userID) {
+		reason := "notifications muted for this appointment"
+		notification.Status = models.NotificationStatusCancelled
+		notification.ErrorMessage = &reason
+		return s.notificationRepo.Update(notification)
 	}
 	
 	// Check user notification preferences
-	prefs, err := s.preferenceRepo.GetByUserID(userID)
-	if err == nil && prefs != nil {
+	if prefs := recipient.Preferences; prefs != nil {
 		// Parse event preferences
 		var eventPrefs map[string]bool
 		if prefs.EventPrefs != "" {
@@ -374,7 +391,7 @@ func (s *notificationService) SendNotification(notification *models.Notification
 		}
 		
 		// Extract or generate HTML version if needed
-		bodyHTML := notification.Body
+		bodyHTML := s.withOpenTracking(notification, notification.Body)
 		bodyText := notification.Body
 		
 		// Attempt to extract metadata for additional content
@@ -387,7 +404,9 @@ func (s *notificationService) SendNotification(notification *models.Notification
 			}
 		}
 		
-		err = s.SendEmail(email, notification.Subject, bodyText, bodyHTML)
+		deferUntil, err = callProvider(s.breakers, s.clock, ProviderEmail, "defer_notification", func() error {
+			return s.SendEmail(email, notification.Subject, bodyText, bodyHTML)
+		})
 		if err != nil {
 			errorMsg = fmt.Sprintf("failed to send email: %s", err.Error())
 		}
@@ -398,7 +417,9 @@ func (s *notificationService) SendNotification(notification *models.Notification
 			goto updateStatus
 		}
 		
-		err = s.SendSMS(phoneNumber, notification.Body)
+		deferUntil, err = callProvider(s.breakers, s.clock, ProviderSMS, "defer_notification", func() error {
+			return s.SendSMS(phoneNumber, notification.Body)
+		})
 		if err != nil {
 			errorMsg = fmt.Sprintf("failed to send SMS: %s", err.Error())
 		}
@@ -412,31 +433,42 @@ func (s *notificationService) SendNotification(notification *models.Notification
 			}
 		}
 		
-		err = s.SendPush(userID, notification.Subject, notification.Body, pushData)
+		deferUntil, err = callProvider(s.breakers, s.clock, ProviderPush, "defer_notification", func() error {
+			return s.SendPush(userID, notification.Subject, notification.Body, pushData)
+		})
 		if err != nil {
 			errorMsg = fmt.Sprintf("failed to send push notification: %s", err.Error())
 		}
 	}
 	
 updateStatus:
+	// Provider is short-circuited: keep the notification pending until its breaker allows a retry
+	if !deferUntil.IsZero() {
+		notification.Status = models.NotificationStatusPending
+		notification.ScheduledFor = &deferUntil
+		return s.notificationRepo.Update(notification)
+	}
+	
 	// Update notification status based on result
 	if errorMsg != "" {
 		notification.Status = models.NotificationStatusFailed
 		notification.ErrorMessage = &errorMsg
 		notification.RetryCount++
 		
+		// Apply the retry policy configured for this channel and priority
+		policy := s.retryPolicyFor(notification)
+		notification.MaxRetries = policy.MaxRetries
+		
 		// If retry count is less than max retries, requeue for later
 		if notification.RetryCount < notification.MaxRetries {
-			// Schedule retry with exponential backoff (5 min, 15 min, 45 min)
-			backoffMinutes := 5 * (1 << (notification.RetryCount - 1))
-			scheduledFor := time.Now().Add(time.Duration(backoffMinutes) * time.Minute)
+			scheduledFor := s.clock.Now().Add(retryDelay(policy, notification.RetryCount))
 			notification.ScheduledFor = &scheduledFor
 			notification.Status = models.NotificationStatusPending
 		}
 	} else {
 		// Success
 		notification.Status = models.NotificationStatusSent
-		now := time.Now()
+		now := s.clock.Now()
 		notification.SentAt = &now
 	}
 	
@@ -450,7 +482,8 @@ func (s *notificationService) SendEmail(to string, subject string, bodyText stri
 	log.Printf("EMAIL TO: %s, SUBJECT: %s\nTEXT: %s\nHTML: %s", to, subject, bodyText, bodyHTML)
 	
 	// TODO: Implement actual email sending logic
-	// This would typically integrate with a third-party email service
+	// This would typically integrate with a third-party email service, called through s.httpClient
+	// so its rate limits and retries apply
 	
 	return nil
 }
@@ -462,7 +495,8 @@ func (s *notificationService) SendSMS(to string, message string) error {
 	log.Printf("SMS TO: %s, MESSAGE: %s", to, message)
 	
 	// TODO: Implement actual SMS sending logic
-	// This would typically integrate with a third-party SMS service
+	// This would typically integrate with a third-party SMS service, called through s.httpClient
+	// so its rate limits and retries apply
 	
 	return nil
 }
@@ -475,15 +509,38 @@ func (s *notificationService) SendPush(userID uint, title string, message string
 	log.Printf("PUSH TO USER: %d, TITLE: %s, MESSAGE: %s, DATA: %s", userID, title, message, dataJson)
 	
 	// TODO: Implement actual push notification sending logic
-	// This would typically integrate with a third-party push notification service
+	// This would typically integrate with a third-party push notification service, called through
+	// s.httpClient so its rate limits and retries apply
 	
 	return nil
 }
 
-// EnqueueNotification adds a notification to the processing queue
+// EnqueueNotification adds a notification to the processing queue. Notifications about an
+// appointment are also copied to the appointment's watchers.
 func (s *notificationService) EnqueueNotification(notification *models.Notification, queueName string, priority int) error {
+	if err := s.enqueueNotification(notification, queueName, priority); err != nil {
+		return err
+	}
+	s.copyToWatchers(notification, queueName, priority)
+	return nil
+}
+
+// enqueueNotification adds a single notification to the processing queue
+func (s *notificationService) enqueueNotification(notification *models.Notification, queueName string, priority int) error {
+	// Merge into a pending duplicate instead of queueing another near-identical message
+	if notification.ID == 0 {
+		merged, err := s.collapseIntoPending(notification, priority)
+		if err != nil {
+			log.Printf("Failed to collapse notification for recipient %d: %v", notification.RecipientID, err)
+		} else if merged {
+			return nil
+		}
+	}
+	
 	// Create notification if it doesn't exist
 	if notification.ID == 0 {
+		notification.Priority = priority
+		notification.MaxRetries = s.retryPolicyFor(notification).MaxRetries
 		if err := s.CreateNotification(notification); err != nil {
 			return fmt.Errorf("failed to create notification: %w", err)
 		}
@@ -500,26 +557,39 @@ func (s *notificationService) EnqueueNotification(notification *models.Notificat
 	return s.queueRepo.Create(queue)
 }
 
-// ProcessQueue processes notifications from the queue
+// ProcessQueue sends the next batch of due notifications of a queue and returns once the whole
+// batch has been sent or given up on
 func (s *notificationService) ProcessQueue(queueName string, batchSize int) error {
 	// Lock to prevent multiple workers from processing the same queue
 	s.workerMutex.Lock()
 	defer s.workerMutex.Unlock()
 	
-	// Get the next batch of notifications to process, ordered by priority and creation time
-	queueItems, err := s.queueRepo.GetPendingByQueue(queueName, batchSize)
+	// Get the next batch of notifications to process, ordered by priority and creation time.
+	// During a maintenance pause only critical events are picked up, the rest stay queued.
+	var queueItems []models.NotificationQueue
+	var err error
+	now := s.clock.Now()
+	pause := s.activePause()
+	if pause != nil {
+		queueItems, err = s.queueRepo.GetPendingByQueueForEvents(queueName, s.pauses.CriticalEvents(pause), now, batchSize)
+	} else {
+		queueItems, err = s.queueRepo.GetPendingByQueue(queueName, now, batchSize)
+	}
 	if err != nil {
 		return err
 	}
 	
+	s.recordQueueDepth(queueName)
+	
 	if len(queueItems) == 0 {
 		return nil // Nothing to process
 	}
 	
 	// Process each queue item
+	var sending sync.WaitGroup
 	for _, item := range queueItems {
 		// Lock this item for processing
-		now := time.Now()
+		now := s.clock.Now()
 		lockUntil := now.Add(5 * time.Minute) // Lock for 5 minutes
 		item.LockedUntil = &lockUntil
 		item.ProcessorID = &s.workerID
@@ -547,10 +617,12 @@ func (s *notificationService) ProcessQueue(queueName string, batchSize int) erro
 		}
 		
 		// Process the notification in a worker from the pool
-		s.workerPool <- struct{}{} // Acquire a worker
-		go func(item models.NotificationQueue, notification *models.Notification) {
+		pool := s.acquireWorker(item.Priority)
+		sending.Add(1)
+		go func(item models.NotificationQueue, notification *models.Notification, pool chan struct{}) {
 			defer func() {
-				<-s.workerPool // Release the worker
+				<-pool // Release the worker
+				sending.Done()
 			}()
 			
 			// Send the notification
@@ -560,12 +632,13 @@ func (s *notificationService) ProcessQueue(queueName string, batchSize int) erro
 			}
 			
 			// Update queue item status
-			processed := time.Now()
+			processed := s.clock.Now()
 			item.ProcessedAt = &processed
 			item.Status = notification.Status
 			s.queueRepo.Update(&item)
-		}(item, notification)
+		}(item, notification, pool)
 	}
+	sending.Wait()
 	
 	return nil
 }
@@ -575,6 +648,8 @@ func (s *notificationService) NotifyAppointmentCreated(appointment *models.Appoi
 	// Prepare common template data
 	templateData := map[string]interface{}{
 		"appointment_id":      appointment.ID,
+		"booking_code":        appointment.BookingCode,
+		"reference":           appointment.Reference(),
 		"supplier_id":         appointment.SupplierID,
 		"employee_id":         appointment.EmployeeID,
 		"operation_id":        appointment.OperationID,
@@ -612,7 +687,7 @@ func (s *notificationService) NotifyAppointmentCreated(appointment *models.Appoi
 			AppointmentID: &appointment.ID,
 		}
 		
-		if err := s.EnqueueNotification(notification, "appointment_notifications", 2); err != nil {
+		if err := s.EnqueueNotification(notification, QueueAppointmentNotifications, 2); err != nil {
 			log.Printf("Failed to enqueue supplier notification for appointment %d: %v", appointment.ID, err)
 		}
 	}
@@ -635,7 +710,7 @@ func (s *notificationService) NotifyAppointmentCreated(appointment *models.Appoi
 			AppointmentID: &appointment.ID,
 		}
 		
-		if err := s.EnqueueNotification(notification, "appointment_notifications", 2); err != nil {
+		if err := s.EnqueueNotification(notification, QueueAppointmentNotifications, 2); err != nil {
 			log.Printf("Failed to enqueue employee notification for appointment %d: %v", appointment.ID, err)
 		}
 	}
@@ -648,6 +723,8 @@ func (s *notificationService) NotifyAppointmentUpdated(appointment *models.Appoi
 	// Prepare common template data
 	templateData := map[string]interface{}{
 		"appointment_id":      appointment.ID,
+		"booking_code":        appointment.BookingCode,
+		"reference":           appointment.Reference(),
 		"supplier_id":         appointment.SupplierID,
 		"employee_id":         appointment.EmployeeID,
 		"operation_id":        appointment.OperationID,
@@ -686,7 +763,7 @@ func (s *notificationService) NotifyAppointmentUpdated(appointment *models.Appoi
 			AppointmentID: &appointment.ID,
 		}
 		
-		if err := s.EnqueueNotification(notification, "appointment_notifications", 2); err != nil {
+		if err := s.EnqueueNotification(notification, QueueAppointmentNotifications, 2); err != nil {
 			log.Printf("Failed to enqueue supplier notification for appointment %d: %v", appointment.ID, err)
 		}
 	}
@@ -709,7 +786,7 @@ func (s *notificationService) NotifyAppointmentUpdated(appointment *models.Appoi
 			AppointmentID: &appointment.ID,
 		}
 		
-		if err := s.EnqueueNotification(notification, "appointment_notifications", 2); err != nil {
+		if err := s.EnqueueNotification(notification, QueueAppointmentNotifications, 2); err != nil {
 			log.Printf("Failed to enqueue employee notification for appointment %d: %v", appointment.ID, err)
 		}
 	}
@@ -735,6 +812,8 @@ func (s *notificationService) NotifyAppointmentStatusChanged(appointment *models
 	// Prepare common template data
 	templateData := map[string]interface{}{
 		"appointment_id":      appointment.ID,
+		"booking_code":        appointment.BookingCode,
+		"reference":           appointment.Reference(),
 		"supplier_id":         appointment.SupplierID,
 		"employee_id":         appointment.EmployeeID,
 		"operation_id":        appointment.OperationID,
@@ -760,8 +839,80 @@ func (s *notificationService) NotifyAppointmentStatusChanged(appointment *models
 		return fmt.Errorf("failed to marshal template data: %w", err)
 	}
 	
-	// Create a notification for the supplier
-	supplierTemplate, err := s.GetTemplateByEvent(
-		event,
-		models.Recipient
+	s.notifyParticipants(appointment, event, string(templateDataJSON), nil)
+	return nil
+}
 
+// ScheduleAppointmentReminder queues the reminders of an appointment to go out the given number
+// of hours before it starts. Reminders that would be due already aren't sent.
+func (s *notificationService) ScheduleAppointmentReminder(appointment *models.Appointment, hoursBeforeAppointment int) error {
+	if hoursBeforeAppointment <= 0 {
+		return errors.New("reminder must be sent before the appointment")
+	}
+	sendAt := appointment.ScheduledStart.Add(-time.Duration(hoursBeforeAppointment) * time.Hour)
+	if !sendAt.After(s.clock.Now()) {
+		return nil
+	}
+
+	// Prepare common template data
+	templateData := map[string]interface{}{
+		"appointment_id":      appointment.ID,
+		"booking_code":        appointment.BookingCode,
+		"reference":           appointment.Reference(),
+		"supplier_id":         appointment.SupplierID,
+		"employee_id":         appointment.EmployeeID,
+		"operation_id":        appointment.OperationID,
+		"product_id":          appointment.ProductID,
+		"scheduled_start":     appointment.ScheduledStart.Format(time.RFC3339),
+		"scheduled_end":       appointment.ScheduledEnd.Format(time.RFC3339),
+		"scheduled_date":      appointment.ScheduledStart.Format("Monday, January 2, 2006"),
+		"scheduled_time":      appointment.ScheduledStart.Format("3:04 PM"),
+		"quantity_to_deliver": appointment.QuantityToDeliver,
+		"status":              string(appointment.Status),
+		"notes":               appointment.Notes,
+		"hours_before":        hoursBeforeAppointment,
+	}
+	
+	// Convert template data to JSON
+	templateDataJSON, err := json.Marshal(templateData)
+	if err != nil {
+		return fmt.Errorf("failed to marshal template data: %w", err)
+	}
+	
+	s.notifyParticipants(appointment, models.EventAppointmentReminder, string(templateDataJSON), &sendAt)
+	return nil
+}
+
+// notifyParticipants queues an email about an appointment to its supplier and its employee, for
+// each that has a template for the event, optionally held until sendAt
+func (s *notificationService) notifyParticipants(appointment *models.Appointment, event models.NotificationEvent, templateData string, sendAt *time.Time) {
+	recipients := []struct {
+		recipientType models.NotificationRecipientType
+		recipientID   uint
+		label         string
+	}{
+		{models.RecipientSupplier, appointment.SupplierID, "supplier"},
+		{models.RecipientEmployee, appointment.EmployeeID, "employee"},
+	}
+	for _, recipient := range recipients {
+		template, err := s.GetTemplateByEvent(event, recipient.recipientType, models.NotificationTypeEmail)
+		if err != nil || template == nil {
+			continue
+		}
+		notification := &models.Notification{
+			Type:          models.NotificationTypeEmail,
+			Status:        models.NotificationStatusPending,
+			Event:         event,
+			RecipientType: recipient.recipientType,
+			RecipientID:   recipient.recipientID,
+			TemplateID:    &template.ID,
+			TemplateData:  templateData,
+			AppointmentID: &appointment.ID,
+			ScheduledFor:  sendAt,
+		}
+		
+		if err := s.EnqueueNotification(notification, QueueAppointmentNotifications, 2); err != nil {
+			log.Printf("Failed to enqueue %s notification for appointment %d: %v", recipient.label, appointment.ID, err)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/mocks/repository"
+	mockservice "github.com/bernardofernandezz/scheduling-api/internal/mocks/service"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// sendNow is the instant the notification service tests send at
+var sendNow = time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)
+
+// testRetryPolicy retries three times, 5, 15 then 45 minutes apart, without jitter
+var testRetryPolicy = config.RetryPolicy{MaxRetries: 3, BaseDelay: 5 * time.Minute, Multiplier: 3, MaxDelay: time.Hour}
+
+// notificationMocks are the repositories and resolver a notification service under test uses
+type notificationMocks struct {
+	notifications *repository.NotificationRepository
+	templates     *repository.NotificationTemplateRepository
+	queue         *repository.NotificationQueueRepository
+	recipients    *mockservice.RecipientResolver
+}
+
+// newNotificationService creates a notification service backed by mocks at sendNow, with the
+// test retry policy for every channel
+func newNotificationService(t *testing.T) (service.NotificationService, *notificationMocks) {
+	m := &notificationMocks{
+		notifications: repository.NewNotificationRepository(t),
+		templates:     repository.NewNotificationTemplateRepository(t),
+		queue:         repository.NewNotificationQueueRepository(t),
+		recipients:    mockservice.NewRecipientResolver(t),
+	}
+	cfg := &config.Config{Notification: &config.NotificationConfig{
+		HighPriorityThreshold: 3,
+		RetryPolicies:         map[string]config.RetryPolicy{"default": testRetryPolicy},
+	}}
+	s := service.NewNotificationService(m.notifications, m.templates, m.queue, m.recipients, nil, nil, nil, nil,
+		cfg, nil, nil, nil, clock.NewFrozen(sendNow))
+	return s, m
+}
+
+// supplierEmail returns a pending email to supplier 5 about a new appointment
+func supplierEmail() *models.Notification {
+	return &models.Notification{
+		Type:          models.NotificationTypeEmail,
+		Status:        models.NotificationStatusPending,
+		Event:         models.EventAppointmentCreated,
+		RecipientType: models.RecipientSupplier,
+		RecipientID:   5,
+		Subject:       "Appointment booked",
+		Body:          "Your delivery is booked",
+	}
+}
+
+func TestSendNotificationMarksSent(t *testing.T) {
+	s, m := newNotificationService(t)
+	m.recipients.EXPECT().Resolve(models.RecipientSupplier, uint(5)).
+		Return(&service.Recipient{Email: "dock@supplier.example"}, nil)
+	m.notifications.EXPECT().Update(mock.Anything).Return(nil)
+
+	notification := supplierEmail()
+	require.NoError(t, s.SendNotification(notification))
+
+	assert.Equal(t, models.NotificationStatusSent, notification.Status)
+	require.NotNil(t, notification.SentAt)
+	assert.Equal(t, sendNow, *notification.SentAt)
+}
+
+func TestSendNotificationRespectsPreferences(t *testing.T) {
+	tests := []struct {
+		name        string
+		preferences models.NotificationPreference
+		reason      string
+	}{
+		{
+			name:        "event disabled",
+			preferences: models.NotificationPreference{EmailEnabled: true, EventPrefs: `{"appointment_created": false}`},
+			reason:      "notification disabled by user preferences",
+		},
+		{
+			name:        "channel disabled",
+			preferences: models.NotificationPreference{EmailEnabled: false, EventPrefs: `{"appointment_created": true}`},
+			reason:      "email notifications disabled by user preferences",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := newNotificationService(t)
+			preferences := tt.preferences
+			m.recipients.EXPECT().Resolve(models.RecipientSupplier, uint(5)).
+				Return(&service.Recipient{Email: "dock@supplier.example", Preferences: &preferences}, nil)
+			m.notifications.EXPECT().Update(mock.Anything).Return(nil)
+
+			notification := supplierEmail()
+			require.NoError(t, s.SendNotification(notification))
+
+			assert.NotEqual(t, models.NotificationStatusSent, notification.Status)
+			assert.Nil(t, notification.SentAt)
+			require.NotNil(t, notification.ErrorMessage)
+			assert.Equal(t, tt.reason, *notification.ErrorMessage)
+		})
+	}
+}
+
+func TestSendNotificationSendsEventsEnabledInPreferences(t *testing.T) {
+	s, m := newNotificationService(t)
+	preferences := models.NotificationPreference{EmailEnabled: true, EventPrefs: `{"appointment_cancelled": false}`}
+	m.recipients.EXPECT().Resolve(models.RecipientSupplier, uint(5)).
+		Return(&service.Recipient{Email: "dock@supplier.example", Preferences: &preferences}, nil)
+	m.notifications.EXPECT().Update(mock.Anything).Return(nil)
+
+	notification := supplierEmail()
+	require.NoError(t, s.SendNotification(notification))
+	assert.Equal(t, models.NotificationStatusSent, notification.Status)
+}
+
+func TestSendNotificationSchedulesRetryWithBackoff(t *testing.T) {
+	tests := []struct {
+		retryCount int
+		delay      time.Duration
+	}{
+		{0, 5 * time.Minute},
+		{1, 15 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		s, m := newNotificationService(t)
+		// Without an address the send fails like a provider error would
+		m.recipients.EXPECT().Resolve(models.RecipientSupplier, uint(5)).Return(&service.Recipient{}, nil)
+		m.notifications.EXPECT().Update(mock.Anything).Return(nil)
+
+		notification := supplierEmail()
+		notification.RetryCount = tt.retryCount
+		require.NoError(t, s.SendNotification(notification))
+
+		assert.Equal(t, tt.retryCount+1, notification.RetryCount)
+		assert.Equal(t, models.NotificationStatusPending, notification.Status)
+		require.NotNil(t, notification.ScheduledFor)
+		assert.Equal(t, sendNow.Add(tt.delay), *notification.ScheduledFor)
+	}
+}
+
+func TestSendNotificationGivesUpAfterMaxRetries(t *testing.T) {
+	s, m := newNotificationService(t)
+	m.recipients.EXPECT().Resolve(models.RecipientSupplier, uint(5)).Return(&service.Recipient{}, nil)
+	m.notifications.EXPECT().Update(mock.Anything).Return(nil)
+
+	notification := supplierEmail()
+	notification.RetryCount = 2
+	notification.MaxRetries = 10
+	require.NoError(t, s.SendNotification(notification))
+
+	// The policy in effect when sending wins over the limit stored with the notification
+	assert.Equal(t, testRetryPolicy.MaxRetries, notification.MaxRetries)
+	assert.Equal(t, models.NotificationStatusFailed, notification.Status)
+	assert.Nil(t, notification.ScheduledFor)
+	require.NotNil(t, notification.ErrorMessage)
+	assert.Equal(t, "recipient email address not available", *notification.ErrorMessage)
+}
+
+func TestProcessQueueSendsBatch(t *testing.T) {
+	s, m := newNotificationService(t)
+
+	item := models.NotificationQueue{QueueName: service.QueueAppointmentNotifications, Priority: 2, NotificationID: 21,
+		Status: models.NotificationStatusPending}
+	item.ID = 31
+	notification := supplierEmail()
+	notification.ID = 21
+
+	m.queue.EXPECT().GetPendingByQueue(service.QueueAppointmentNotifications, sendNow, 10).
+		Return([]models.NotificationQueue{item}, nil)
+	m.queue.EXPECT().CountPendingByPriority(service.QueueAppointmentNotifications).Return(map[int]int64{2: 1}, nil)
+	var updates []models.NotificationQueue
+	m.queue.EXPECT().Update(mock.Anything).
+		Run(func(item *models.NotificationQueue) { updates = append(updates, *item) }).
+		Return(nil)
+	m.notifications.EXPECT().GetByID(uint(21)).Return(notification, nil)
+	m.recipients.EXPECT().Resolve(models.RecipientSupplier, uint(5)).
+		Return(&service.Recipient{Email: "dock@supplier.example"}, nil)
+	m.notifications.EXPECT().Update(mock.Anything).Return(nil)
+
+	require.NoError(t, s.ProcessQueue(service.QueueAppointmentNotifications, 10))
+
+	// Locked while sending, then marked with the notification's outcome
+	require.Len(t, updates, 2)
+	locked := updates[0]
+	assert.Equal(t, models.NotificationStatusSending, locked.Status)
+	require.NotNil(t, locked.LockedUntil)
+	assert.Equal(t, sendNow.Add(5*time.Minute), *locked.LockedUntil)
+	require.NotNil(t, locked.ProcessorID)
+
+	processed := updates[1]
+	assert.Equal(t, models.NotificationStatusSent, processed.Status)
+	require.NotNil(t, processed.ProcessedAt)
+	assert.Equal(t, sendNow, *processed.ProcessedAt)
+	assert.Equal(t, models.NotificationStatusSent, notification.Status)
+}
+
+func TestProcessQueueWithNothingDue(t *testing.T) {
+	s, m := newNotificationService(t)
+	m.queue.EXPECT().GetPendingByQueue(service.QueueSupplierNotifications, sendNow, 10).Return(nil, nil)
+	m.queue.EXPECT().CountPendingByPriority(service.QueueSupplierNotifications).Return(map[int]int64{}, nil)
+
+	require.NoError(t, s.ProcessQueue(service.QueueSupplierNotifications, 10))
+	m.notifications.AssertNotCalled(t, "GetByID", mock.Anything)
+}
+
+func TestScheduleAppointmentReminderHoldsRemindersUntilDue(t *testing.T) {
+	s, m := newNotificationService(t)
+	appointment := &models.Appointment{SupplierID: 5, EmployeeID: 6, OperationID: 3, ScheduledStart: sendNow.Add(48 * time.Hour)}
+	appointment.ID = 7
+
+	template := &models.NotificationTemplate{Subject: "Delivery tomorrow", BodyText: "Your delivery starts in {{.hours_before}} hours"}
+	template.ID = 11
+	m.templates.EXPECT().GetByEvent(models.EventAppointmentReminder, mock.Anything, models.NotificationTypeEmail).Return(template, nil)
+	m.templates.EXPECT().GetByID(uint(11)).Return(template, nil)
+	m.recipients.EXPECT().Resolve(mock.Anything, mock.Anything).Return(&service.Recipient{}, nil)
+	var reminders []*models.Notification
+	m.notifications.EXPECT().Create(mock.Anything).Run(func(n *models.Notification) { reminders = append(reminders, n) }).Return(nil)
+	m.queue.EXPECT().Create(mock.Anything).Return(nil)
+
+	require.NoError(t, s.ScheduleAppointmentReminder(appointment, 24))
+
+	require.Len(t, reminders, 2)
+	for _, reminder := range reminders {
+		require.NotNil(t, reminder.ScheduledFor)
+		assert.Equal(t, sendNow.Add(24*time.Hour), *reminder.ScheduledFor)
+		assert.Equal(t, "Your delivery starts in 24 hours", reminder.Body)
+	}
+}
+
+func TestScheduleAppointmentReminderSkipsRemindersAlreadyDue(t *testing.T) {
+	s, _ := newNotificationService(t)
+
+	// Due exactly now, and an hour ago: the mocks fail the test if anything is queued
+	for _, startsIn := range []time.Duration{24 * time.Hour, 23 * time.Hour} {
+		appointment := &models.Appointment{SupplierID: 5, EmployeeID: 6, ScheduledStart: sendNow.Add(startsIn)}
+		require.NoError(t, s.ScheduleAppointmentReminder(appointment, 24))
+	}
+}
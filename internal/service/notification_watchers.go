@@ -0,0 +1,76 @@
+package service
+
+import (
+	"log"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+)
+
+// watcherCopyWindow is how long a watcher's copy of a message suppresses identical copies. Events
+// notifying both the supplier and the employee reach each watcher once.
+const watcherCopyWindow = 5 * time.Minute
+
+// copyToWatchers queues a copy of an appointment notification for each of the appointment's
+// watchers, on every channel they chose. Watchers who are the notification's recipient already
+// get it and are skipped.
+func (s *notificationService) copyToWatchers(notification *models.Notification, queueName string, priority int) {
+	if s.watcherRepo == nil || notification.AppointmentID == nil || notification.RecipientType == models.RecipientWatcher {
+		return
+	}
+
+	appointmentID := *notification.AppointmentID
+	watchers, err := s.watcherRepo.FindByAppointment(appointmentID)
+	if err != nil {
+		log.Printf("Failed to load the watchers of appointment %d: %v", appointmentID, err)
+		return
+	}
+	if len(watchers) == 0 {
+		return
+	}
+
+	recipientUserID := s.recipientUserID(notification)
+	since := s.clock.Now().Add(-watcherCopyWindow)
+	for _, watcher := range watchers {
+		if watcher.UserID == recipientUserID {
+			continue
+		}
+		for _, channel := range watcher.Channels() {
+			copied := watcherCopy(notification, watcher.UserID, channel)
+			exists, err := s.notificationRepo.ExistsSince(copied, since)
+			if err != nil {
+				log.Printf("Failed to check the copies sent to watcher %d of appointment %d: %v", watcher.UserID, appointmentID, err)
+				continue
+			}
+			if exists {
+				continue
+			}
+			if err := s.enqueueNotification(copied, queueName, priority); err != nil {
+				log.Printf("Failed to copy a notification to watcher %d of appointment %d: %v", watcher.UserID, appointmentID, err)
+			}
+		}
+	}
+}
+
+// watcherCopy returns a copy of a notification addressed to a watching user. Templated
+// notifications are rendered again for the watcher's language and channel.
+func watcherCopy(notification *models.Notification, userID uint, channel models.NotificationType) *models.Notification {
+	copied := &models.Notification{
+		Type:          channel,
+		Status:        models.NotificationStatusPending,
+		Event:         notification.Event,
+		RecipientType: models.RecipientWatcher,
+		RecipientID:   userID,
+		Subject:       notification.Subject,
+		Body:          notification.Body,
+		TemplateID:    notification.TemplateID,
+		TemplateData:  notification.TemplateData,
+		AppointmentID: notification.AppointmentID,
+		ScheduledFor:  notification.ScheduledFor,
+	}
+	if copied.TemplateID != nil && *copied.TemplateID != 0 {
+		copied.Subject = ""
+		copied.Body = ""
+	}
+	return copied
+}
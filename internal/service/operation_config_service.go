@@ -0,0 +1,611 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+	"github.com/shopspring/decimal"
+)
+
+// OperationConfigVersion is the schema version written to exported configuration documents
+const OperationConfigVersion = 1
+
+// OperationConfigDocument is the portable scheduling configuration of an operation.
+// Entities are referenced by stable business keys (operation code, employee number,
+// template name) instead of database IDs so a document exported from one environment
+// can be imported into another. Notification templates are shared by all operations, so
+// they are only in documents exported with them and only imported when present.
+type OperationConfigDocument struct {
+	Version      int                          `json:"version" yaml:"version"`
+	ExportedAt   time.Time                    `json:"exported_at" yaml:"exported_at"`
+	Operation    OperationProfileConfig       `json:"operation" yaml:"operation"`
+	Availability []AvailabilitySlotConfig     `json:"availability" yaml:"availability"`
+	Templates    []NotificationTemplateConfig `json:"templates,omitempty" yaml:"templates,omitempty"`
+}
+
+// OperationProfileConfig holds the operation profile and opening hours
+type OperationProfileConfig struct {
+	Code                  string `json:"code" yaml:"code"`
+	Name                  string `json:"name" yaml:"name"`
+	Address               string `json:"address" yaml:"address"`
+	City                  string `json:"city" yaml:"city"`
+	State                 string `json:"state" yaml:"state"`
+	ZipCode               string `json:"zip_code" yaml:"zip_code"`
+	Country               string `json:"country" yaml:"country"`
+	Phone                 string `json:"phone" yaml:"phone"`
+	Email                 string `json:"email" yaml:"email"`
+	ManagerEmployeeNumber string `json:"manager_employee_number" yaml:"manager_employee_number"`
+	OpeningTime           string `json:"opening_time" yaml:"opening_time"`
+	ClosingTime           string `json:"closing_time" yaml:"closing_time"`
+	Timezone              string `json:"timezone" yaml:"timezone"`
+	MaxVisitors           int    `json:"max_visitors_per_appointment" yaml:"max_visitors_per_appointment"`
+	PortalURL             string `json:"portal_url" yaml:"portal_url"`
+	AutoCompleteMode      string `json:"auto_complete_mode" yaml:"auto_complete_mode"`
+	AutoCompleteAfter     int    `json:"auto_complete_after_hours" yaml:"auto_complete_after_hours"`
+	PendingExpiryHours    int    `json:"pending_expiry_hours" yaml:"pending_expiry_hours"` // cancel pending appointments not confirmed this long before they start; 0 never does
+	MaxPerSupplier        int    `json:"max_concurrent_per_supplier" yaml:"max_concurrent_per_supplier"`
+	SupplierLimitMode     string `json:"supplier_limit_mode" yaml:"supplier_limit_mode"`
+	NoShowFee             string `json:"no_show_fee" yaml:"no_show_fee"` // decimal amount, e.g. "150.00"; empty or 0 charges nothing
+	NoShowFeeCurrency     string `json:"no_show_fee_currency" yaml:"no_show_fee_currency"`
+	DepositAmount         string `json:"deposit_amount" yaml:"deposit_amount"` // decimal amount suppliers pay for bookings to be kept; empty or 0 charges no deposit
+	DepositCurrency       string `json:"deposit_currency" yaml:"deposit_currency"`
+	ImportHolidays        bool   `json:"import_holidays" yaml:"import_holidays"` // close on the public holidays of the operation's state
+	Active                bool   `json:"active" yaml:"active"`
+}
+
+// AvailabilitySlotConfig holds one employee availability slot of the operation
+type AvailabilitySlotConfig struct {
+	EmployeeNumber string     `json:"employee_number" yaml:"employee_number"`
+	DayOfWeek      int        `json:"day_of_week" yaml:"day_of_week"`
+	StartTime      string     `json:"start_time" yaml:"start_time"`
+	EndTime        string     `json:"end_time" yaml:"end_time"`
+	IsRecurring    bool       `json:"is_recurring" yaml:"is_recurring"`
+	SpecificDate   *time.Time `json:"specific_date,omitempty" yaml:"specific_date,omitempty"`
+	Active         bool       `json:"active" yaml:"active"`
+}
+
+// NotificationTemplateConfig holds a notification template, shared by all operations
+type NotificationTemplateConfig struct {
+	Name          string                           `json:"name" yaml:"name"`
+	Description   string                           `json:"description" yaml:"description"`
+	Subject       string                           `json:"subject" yaml:"subject"`
+	BodyText      string                           `json:"body_text" yaml:"body_text"`
+	BodyHTML      string                           `json:"body_html" yaml:"body_html"`
+	Type          models.NotificationType          `json:"type" yaml:"type"`
+	Event         models.NotificationEvent         `json:"event" yaml:"event"`
+	RecipientType models.NotificationRecipientType `json:"recipient_type" yaml:"recipient_type"`
+	IsActive      bool                             `json:"is_active" yaml:"is_active"`
+	Variables     string                           `json:"variables" yaml:"variables"`
+}
+
+// ConfigChangeAction describes what an import does to a configuration entry
+type ConfigChangeAction string
+
+const (
+	// ConfigChangeCreate indicates the entry does not exist in the target environment
+	ConfigChangeCreate ConfigChangeAction = "create"
+
+	// ConfigChangeUpdate indicates a field of an existing entry will change
+	ConfigChangeUpdate ConfigChangeAction = "update"
+
+	// ConfigChangeDelete indicates the entry exists only in the target environment and will be removed
+	ConfigChangeDelete ConfigChangeAction = "delete"
+)
+
+// ConfigChange is a single entry of an import diff preview
+type ConfigChange struct {
+	Section string             `json:"section"`
+	Key     string             `json:"key"`
+	Action  ConfigChangeAction `json:"action"`
+	Field   string             `json:"field,omitempty"`
+	From    interface{}        `json:"from,omitempty"`
+	To      interface{}        `json:"to,omitempty"`
+}
+
+// OperationConfigService defines the interface for operation configuration export and import
+type OperationConfigService interface {
+	Export(operationID uint, withTemplates bool) (*OperationConfigDocument, error)
+	Preview(doc *OperationConfigDocument) ([]ConfigChange, error)
+	Import(doc *OperationConfigDocument) ([]ConfigChange, error)
+}
+
+// operationConfigService implements OperationConfigService interface
+type operationConfigService struct {
+	operationRepo    repository.OperationRepository
+	availabilityRepo repository.AvailabilityRepository
+	employeeRepo     repository.EmployeeRepository
+	templateRepo     repository.NotificationTemplateRepository
+	clock            clock.Clock
+}
+
+// NewOperationConfigService creates a new operation configuration service
+func NewOperationConfigService(
+	operationRepo repository.OperationRepository,
+	availabilityRepo repository.AvailabilityRepository,
+	employeeRepo repository.EmployeeRepository,
+	templateRepo repository.NotificationTemplateRepository,
+	clock clock.Clock,
+) OperationConfigService {
+	return &operationConfigService{
+		operationRepo:    operationRepo,
+		availabilityRepo: availabilityRepo,
+		employeeRepo:     employeeRepo,
+		templateRepo:     templateRepo,
+		clock:            clock,
+	}
+}
+
+// Export builds the configuration document of an operation, with the notification templates
+// when withTemplates is set
+func (s *operationConfigService) Export(operationID uint, withTemplates bool) (*OperationConfigDocument, error) {
+	operation, err := s.operationRepo.FindByID(operationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.snapshot(operation, withTemplates)
+}
+
+// Preview returns the changes an import of the document would apply without applying them
+func (s *operationConfigService) Preview(doc *OperationConfigDocument) ([]ConfigChange, error) {
+	if err := validateOperationConfig(doc); err != nil {
+		return nil, err
+	}
+
+	current, err := s.currentConfig(doc.Operation.Code, len(doc.Templates) > 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return diffOperationConfig(current, doc), nil
+}
+
+// Import applies the document to the target environment and returns the applied changes
+func (s *operationConfigService) Import(doc *OperationConfigDocument) ([]ConfigChange, error) {
+	changes, err := s.Preview(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the no-show fee and deposit; the document was validated by Preview
+	noShowFee := decimal.Zero
+	if doc.Operation.NoShowFee != "" {
+		noShowFee = decimal.RequireFromString(doc.Operation.NoShowFee)
+	}
+	deposit := decimal.Zero
+	if doc.Operation.DepositAmount != "" {
+		deposit = decimal.RequireFromString(doc.Operation.DepositAmount)
+	}
+
+	// Resolve the operation manager
+	manager, err := s.employeeRepo.FindByEmployeeNumber(doc.Operation.ManagerEmployeeNumber)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manager %q: %w", doc.Operation.ManagerEmployeeNumber, err)
+	}
+
+	operation := &models.Operation{
+		Name:                      doc.Operation.Name,
+		Code:                      doc.Operation.Code,
+		Address:                   doc.Operation.Address,
+		City:                      doc.Operation.City,
+		State:                     doc.Operation.State,
+		ZipCode:                   doc.Operation.ZipCode,
+		Country:                   doc.Operation.Country,
+		Phone:                     doc.Operation.Phone,
+		Email:                     doc.Operation.Email,
+		ManagerID:                 manager.ID,
+		OpeningTime:               doc.Operation.OpeningTime,
+		ClosingTime:               doc.Operation.ClosingTime,
+		Timezone:                  doc.Operation.Timezone,
+		MaxVisitorsPerAppointment: doc.Operation.MaxVisitors,
+		PortalURL:                 doc.Operation.PortalURL,
+		AutoCompleteMode:          models.AutoCompleteMode(doc.Operation.AutoCompleteMode),
+		AutoCompleteAfterHours:    doc.Operation.AutoCompleteAfter,
+		PendingExpiryHours:        doc.Operation.PendingExpiryHours,
+		MaxConcurrentPerSupplier:  doc.Operation.MaxPerSupplier,
+		SupplierLimitMode:         models.SupplierLimitMode(doc.Operation.SupplierLimitMode),
+		NoShowFee:                 noShowFee,
+		NoShowFeeCurrency:         doc.Operation.NoShowFeeCurrency,
+		DepositAmount:             deposit,
+		DepositCurrency:           doc.Operation.DepositCurrency,
+		ImportHolidays:            doc.Operation.ImportHolidays,
+		Active:                    doc.Operation.Active,
+	}
+	if err := operation.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid operation: %w", err)
+	}
+
+	// Resolve employees referenced by availability slots
+	employeeIDs := make(map[string]uint)
+	slots := make([]models.AvailabilitySlot, 0, len(doc.Availability))
+	for _, slot := range doc.Availability {
+		employeeID, ok := employeeIDs[slot.EmployeeNumber]
+		if !ok {
+			employee, err := s.employeeRepo.FindByEmployeeNumber(slot.EmployeeNumber)
+			if err != nil {
+				return nil, fmt.Errorf("invalid availability employee %q: %w", slot.EmployeeNumber, err)
+			}
+			employeeID = employee.ID
+			employeeIDs[slot.EmployeeNumber] = employeeID
+		}
+
+		slots = append(slots, models.AvailabilitySlot{
+			EmployeeID:   employeeID,
+			DayOfWeek:    slot.DayOfWeek,
+			StartTime:    slot.StartTime,
+			EndTime:      slot.EndTime,
+			IsRecurring:  slot.IsRecurring,
+			SpecificDate: slot.SpecificDate,
+			Active:       slot.Active,
+		})
+		// The operation ID is only known once the import saves the operation
+		if err := slots[len(slots)-1].ValidateSchedule(); err != nil {
+			return nil, fmt.Errorf("availability slot %d: %w", len(slots), err)
+		}
+	}
+
+	templates := make([]models.NotificationTemplate, 0, len(doc.Templates))
+	for _, t := range doc.Templates {
+		templates = append(templates, models.NotificationTemplate{
+			Name:          t.Name,
+			Description:   t.Description,
+			Subject:       t.Subject,
+			BodyText:      t.BodyText,
+			BodyHTML:      t.BodyHTML,
+			Type:          t.Type,
+			Event:         t.Event,
+			RecipientType: t.RecipientType,
+			IsActive:      t.IsActive,
+			Variables:     t.Variables,
+		})
+	}
+
+	if err := s.operationRepo.ImportConfig(operation, slots, templates); err != nil {
+		return nil, fmt.Errorf("failed to import operation configuration: %w", err)
+	}
+
+	return changes, nil
+}
+
+// currentConfig returns the configuration currently stored for an operation code,
+// or an empty document if the operation does not exist yet
+func (s *operationConfigService) currentConfig(code string, withTemplates bool) (*OperationConfigDocument, error) {
+	operation, err := s.operationRepo.FindByCode(code)
+	if err != nil {
+		if errors.Is(err, repository.ErrOperationNotFound) {
+			return &OperationConfigDocument{}, nil
+		}
+		return nil, err
+	}
+
+	return s.snapshot(operation, withTemplates)
+}
+
+// snapshot converts the stored configuration of an operation into a document, with the
+// notification templates when withTemplates is set
+func (s *operationConfigService) snapshot(operation *models.Operation, withTemplates bool) (*OperationConfigDocument, error) {
+	doc := &OperationConfigDocument{
+		Version:    OperationConfigVersion,
+		ExportedAt: s.clock.Now().UTC(),
+		Operation: OperationProfileConfig{
+			Code:                  operation.Code,
+			Name:                  operation.Name,
+			Address:               operation.Address,
+			City:                  operation.City,
+			State:                 operation.State,
+			ZipCode:               operation.ZipCode,
+			Country:               operation.Country,
+			Phone:                 operation.Phone,
+			Email:                 operation.Email,
+			ManagerEmployeeNumber: operation.Manager.EmployeeNumber,
+			OpeningTime:           operation.OpeningTime,
+			ClosingTime:           operation.ClosingTime,
+			Timezone:              operation.Timezone,
+			MaxVisitors:           operation.MaxVisitorsPerAppointment,
+			PortalURL:             operation.PortalURL,
+			AutoCompleteMode:      string(operation.AutoCompleteMode),
+			AutoCompleteAfter:     operation.AutoCompleteAfterHours,
+			PendingExpiryHours:    operation.PendingExpiryHours,
+			MaxPerSupplier:        operation.MaxConcurrentPerSupplier,
+			SupplierLimitMode:     string(operation.SupplierLimitMode),
+			NoShowFee:             formatAmount(operation.NoShowFee, operation.NoShowFeeCurrency),
+			NoShowFeeCurrency:     operation.NoShowFeeCurrency,
+			DepositAmount:         formatAmount(operation.DepositAmount, operation.DepositCurrency),
+			DepositCurrency:       operation.DepositCurrency,
+			ImportHolidays:        operation.ImportHolidays,
+			Active:                operation.Active,
+		},
+	}
+
+	slots, err := s.availabilityRepo.FindByOperation(operation.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load availability: %w", err)
+	}
+	for _, slot := range slots {
+		doc.Availability = append(doc.Availability, AvailabilitySlotConfig{
+			EmployeeNumber: slot.Employee.EmployeeNumber,
+			DayOfWeek:      slot.DayOfWeek,
+			StartTime:      slot.StartTime,
+			EndTime:        slot.EndTime,
+			IsRecurring:    slot.IsRecurring,
+			SpecificDate:   slot.SpecificDate,
+			Active:         slot.Active,
+		})
+	}
+
+	if !withTemplates {
+		return doc, nil
+	}
+	templates, err := s.templateRepo.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load notification templates: %w", err)
+	}
+	for _, t := range templates {
+		doc.Templates = append(doc.Templates, NotificationTemplateConfig{
+			Name:          t.Name,
+			Description:   t.Description,
+			Subject:       t.Subject,
+			BodyText:      t.BodyText,
+			BodyHTML:      t.BodyHTML,
+			Type:          t.Type,
+			Event:         t.Event,
+			RecipientType: t.RecipientType,
+			IsActive:      t.IsActive,
+			Variables:     t.Variables,
+		})
+	}
+
+	return doc, nil
+}
+
+// validateOperationConfig checks that a document can be imported
+func validateOperationConfig(doc *OperationConfigDocument) error {
+	if doc == nil {
+		return errors.New("configuration document is required")
+	}
+	if doc.Version != OperationConfigVersion {
+		return fmt.Errorf("unsupported configuration version %d (expected %d)", doc.Version, OperationConfigVersion)
+	}
+	if doc.Operation.Code == "" {
+		return errors.New("operation code is required")
+	}
+	if doc.Operation.ManagerEmployeeNumber == "" {
+		return errors.New("operation manager employee number is required")
+	}
+	if doc.Operation.Timezone != "" {
+		if _, err := time.LoadLocation(doc.Operation.Timezone); err != nil {
+			return fmt.Errorf("invalid operation timezone %q", doc.Operation.Timezone)
+		}
+	}
+	if doc.Operation.MaxVisitors < 0 {
+		return errors.New("operation max visitors per appointment cannot be negative")
+	}
+	if doc.Operation.AutoCompleteMode != "" && !models.AutoCompleteMode(doc.Operation.AutoCompleteMode).IsValid() {
+		return fmt.Errorf("invalid operation auto-complete mode %q", doc.Operation.AutoCompleteMode)
+	}
+	if doc.Operation.AutoCompleteAfter < 0 {
+		return errors.New("operation auto-complete delay cannot be negative")
+	}
+	if doc.Operation.PendingExpiryHours < 0 {
+		return errors.New("operation pending expiry cannot be negative")
+	}
+	if doc.Operation.MaxPerSupplier < 0 {
+		return errors.New("operation per-supplier limit cannot be negative")
+	}
+	if doc.Operation.SupplierLimitMode != "" && !models.SupplierLimitMode(doc.Operation.SupplierLimitMode).IsValid() {
+		return fmt.Errorf("invalid operation supplier limit mode %q", doc.Operation.SupplierLimitMode)
+	}
+	if doc.Operation.NoShowFeeCurrency == "" {
+		doc.Operation.NoShowFeeCurrency = models.DefaultCurrency
+	}
+	places, err := models.CurrencyMinorUnits(doc.Operation.NoShowFeeCurrency)
+	if err != nil {
+		return fmt.Errorf("invalid operation no-show fee currency %q", doc.Operation.NoShowFeeCurrency)
+	}
+	if doc.Operation.NoShowFee != "" {
+		fee, err := decimal.NewFromString(doc.Operation.NoShowFee)
+		if err != nil || fee.IsNegative() || !fee.Equal(fee.Truncate(places)) {
+			return fmt.Errorf("invalid operation no-show fee %q", doc.Operation.NoShowFee)
+		}
+		// Compare and store amounts in one form, so "150" and "150.00" are the same fee
+		doc.Operation.NoShowFee = formatAmount(fee, doc.Operation.NoShowFeeCurrency)
+	}
+	if doc.Operation.DepositCurrency == "" {
+		doc.Operation.DepositCurrency = models.DefaultCurrency
+	}
+	places, err = models.CurrencyMinorUnits(doc.Operation.DepositCurrency)
+	if err != nil {
+		return fmt.Errorf("invalid operation deposit currency %q", doc.Operation.DepositCurrency)
+	}
+	if doc.Operation.DepositAmount != "" {
+		deposit, err := decimal.NewFromString(doc.Operation.DepositAmount)
+		if err != nil || deposit.IsNegative() || !deposit.Equal(deposit.Truncate(places)) {
+			return fmt.Errorf("invalid operation deposit %q", doc.Operation.DepositAmount)
+		}
+		doc.Operation.DepositAmount = formatAmount(deposit, doc.Operation.DepositCurrency)
+	}
+	if doc.Operation.PortalURL != "" {
+		portal, err := url.Parse(doc.Operation.PortalURL)
+		if err != nil || (portal.Scheme != "https" && portal.Scheme != "http") || portal.Host == "" {
+			return fmt.Errorf("invalid operation portal URL %q", doc.Operation.PortalURL)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, slot := range doc.Availability {
+		if slot.EmployeeNumber == "" {
+			return errors.New("availability employee number is required")
+		}
+		if slot.DayOfWeek < 0 || slot.DayOfWeek > 6 {
+			return errors.New("availability day of week must be between 0 and 6")
+		}
+		if slot.StartTime == "" || slot.EndTime == "" || slot.StartTime >= slot.EndTime {
+			return fmt.Errorf("invalid availability time range %s-%s", slot.StartTime, slot.EndTime)
+		}
+		key := slotConfigKey(slot)
+		if seen[key] {
+			return fmt.Errorf("duplicate availability slot %s", key)
+		}
+		seen[key] = true
+	}
+
+	names := make(map[string]bool)
+	for _, t := range doc.Templates {
+		if t.Name == "" {
+			return errors.New("template name is required")
+		}
+		if names[t.Name] {
+			return fmt.Errorf("duplicate template %q", t.Name)
+		}
+		names[t.Name] = true
+	}
+
+	return nil
+}
+
+// formatAmount writes an amount, such as the no-show fee, with the decimal places of its
+// currency, or empty when it is zero
+func formatAmount(amount decimal.Decimal, currency string) string {
+	if amount.IsZero() {
+		return ""
+	}
+	places, err := models.CurrencyMinorUnits(currency)
+	if err != nil {
+		return amount.String()
+	}
+	return amount.StringFixed(places)
+}
+
+// diffOperationConfig compares the current configuration with the incoming document
+func diffOperationConfig(current, incoming *OperationConfigDocument) []ConfigChange {
+	var changes []ConfigChange
+
+	// Operation profile
+	if current.Operation.Code == "" {
+		changes = append(changes, ConfigChange{Section: "operation", Key: incoming.Operation.Code, Action: ConfigChangeCreate})
+	} else {
+		from, to := current.Operation, incoming.Operation
+		fields := []struct {
+			name     string
+			from, to interface{}
+		}{
+			{"name", from.Name, to.Name},
+			{"address", from.Address, to.Address},
+			{"city", from.City, to.City},
+			{"state", from.State, to.State},
+			{"zip_code", from.ZipCode, to.ZipCode},
+			{"country", from.Country, to.Country},
+			{"phone", from.Phone, to.Phone},
+			{"email", from.Email, to.Email},
+			{"manager_employee_number", from.ManagerEmployeeNumber, to.ManagerEmployeeNumber},
+			{"opening_time", from.OpeningTime, to.OpeningTime},
+			{"closing_time", from.ClosingTime, to.ClosingTime},
+			{"timezone", from.Timezone, to.Timezone},
+			{"max_visitors_per_appointment", from.MaxVisitors, to.MaxVisitors},
+			{"portal_url", from.PortalURL, to.PortalURL},
+			{"auto_complete_mode", from.AutoCompleteMode, to.AutoCompleteMode},
+			{"auto_complete_after_hours", from.AutoCompleteAfter, to.AutoCompleteAfter},
+			{"pending_expiry_hours", from.PendingExpiryHours, to.PendingExpiryHours},
+			{"max_concurrent_per_supplier", from.MaxPerSupplier, to.MaxPerSupplier},
+			{"supplier_limit_mode", from.SupplierLimitMode, to.SupplierLimitMode},
+			{"no_show_fee", from.NoShowFee, to.NoShowFee},
+			{"no_show_fee_currency", from.NoShowFeeCurrency, to.NoShowFeeCurrency},
+			{"deposit_amount", from.DepositAmount, to.DepositAmount},
+			{"deposit_currency", from.DepositCurrency, to.DepositCurrency},
+			{"import_holidays", from.ImportHolidays, to.ImportHolidays},
+			{"active", from.Active, to.Active},
+		}
+		for _, f := range fields {
+			if f.from != f.to {
+				changes = append(changes, ConfigChange{
+					Section: "operation", Key: to.Code, Action: ConfigChangeUpdate,
+					Field: f.name, From: f.from, To: f.to,
+				})
+			}
+		}
+	}
+
+	// Availability slots, keyed by employee, day, time range and date
+	currentSlots := make(map[string]AvailabilitySlotConfig)
+	for _, slot := range current.Availability {
+		currentSlots[slotConfigKey(slot)] = slot
+	}
+	incomingSlots := make(map[string]bool)
+	for _, slot := range incoming.Availability {
+		key := slotConfigKey(slot)
+		incomingSlots[key] = true
+		existing, ok := currentSlots[key]
+		if !ok {
+			changes = append(changes, ConfigChange{Section: "availability", Key: key, Action: ConfigChangeCreate})
+			continue
+		}
+		if existing.Active != slot.Active {
+			changes = append(changes, ConfigChange{
+				Section: "availability", Key: key, Action: ConfigChangeUpdate,
+				Field: "active", From: existing.Active, To: slot.Active,
+			})
+		}
+	}
+	var removed []string
+	for key := range currentSlots {
+		if !incomingSlots[key] {
+			removed = append(removed, key)
+		}
+	}
+	sort.Strings(removed)
+	for _, key := range removed {
+		changes = append(changes, ConfigChange{Section: "availability", Key: key, Action: ConfigChangeDelete})
+	}
+
+	// Notification templates, keyed by name. Templates missing from the document are kept.
+	currentTemplates := make(map[string]NotificationTemplateConfig)
+	for _, t := range current.Templates {
+		currentTemplates[t.Name] = t
+	}
+	for _, t := range incoming.Templates {
+		existing, ok := currentTemplates[t.Name]
+		if !ok {
+			changes = append(changes, ConfigChange{Section: "templates", Key: t.Name, Action: ConfigChangeCreate})
+			continue
+		}
+		fields := []struct {
+			name     string
+			from, to interface{}
+		}{
+			{"description", existing.Description, t.Description},
+			{"subject", existing.Subject, t.Subject},
+			{"body_text", existing.BodyText, t.BodyText},
+			{"body_html", existing.BodyHTML, t.BodyHTML},
+			{"type", existing.Type, t.Type},
+			{"event", existing.Event, t.Event},
+			{"recipient_type", existing.RecipientType, t.RecipientType},
+			{"is_active", existing.IsActive, t.IsActive},
+			{"variables", existing.Variables, t.Variables},
+		}
+		for _, f := range fields {
+			if f.from != f.to {
+				changes = append(changes, ConfigChange{
+					Section: "templates", Key: t.Name, Action: ConfigChangeUpdate,
+					Field: f.name, From: f.from, To: f.to,
+				})
+			}
+		}
+	}
+
+	return changes
+}
+
+// slotConfigKey returns the identity of an availability slot inside a document
+func slotConfigKey(slot AvailabilitySlotConfig) string {
+	key := fmt.Sprintf("%s/%d/%s-%s", slot.EmployeeNumber, slot.DayOfWeek, slot.StartTime, slot.EndTime)
+	if slot.SpecificDate != nil {
+		key += "/" + slot.SpecificDate.Format("2006-01-02")
+	}
+	return key
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+)
+
+// OperationDay is a calendar day at an operation, bounded in the operation's timezone rather
+// than the server's, so "today" means the same at the dock as in the API
+type OperationDay struct {
+	OperationID uint      `json:"operation_id"`
+	Timezone    string    `json:"timezone"`
+	Date        string    `json:"date"`  // "YYYY-MM-DD" in the operation's timezone
+	Start       time.Time `json:"start"` // local midnight
+	End         time.Time `json:"end"`   // local midnight of the next day
+}
+
+// operationDayAt returns the day at the operation that the instant t falls on
+func operationDayAt(operation *models.Operation, t time.Time) OperationDay {
+	location := operation.Location()
+	start, end := clock.DayBounds(t, location)
+	return OperationDay{
+		OperationID: operation.ID,
+		Timezone:    location.String(),
+		Date:        start.Format("2006-01-02"),
+		Start:       start,
+		End:         end,
+	}
+}
+
+// operationDayOn returns the day at the operation with the calendar date of date, whatever
+// timezone date is in
+func operationDayOn(operation *models.Operation, date time.Time) OperationDay {
+	location := operation.Location()
+	return operationDayAt(operation, time.Date(date.Year(), date.Month(), date.Day(), 12, 0, 0, 0, location))
+}
+
+// operationToday returns the current day at the operation
+func operationToday(operation *models.Operation, c clock.Clock) OperationDay {
+	return operationDayAt(operation, c.Now())
+}
+
+// Contains reports whether an instant falls on the day
+func (d OperationDay) Contains(t time.Time) bool {
+	return !t.Before(d.Start) && t.Before(d.End)
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+)
+
+// Limits of the nonce partners sign requests with
+const (
+	minPartnerNonceLength = 16
+	maxPartnerNonceLength = 128
+)
+
+// Partner request signature errors
+var (
+	ErrPartnerSignatureMissing = errors.New("partner requests must carry X-Partner-Key, X-Partner-Timestamp, X-Partner-Nonce and X-Partner-Signature headers")
+	ErrPartnerUnknownKey       = errors.New("unknown or revoked partner key")
+	ErrPartnerInvalidSignature = errors.New("invalid partner request signature")
+	ErrPartnerStaleRequest     = errors.New("partner request timestamp is outside the accepted window")
+	ErrPartnerInvalidNonce     = errors.New("partner request nonce must be 16 to 128 characters")
+	ErrPartnerReplayedRequest  = errors.New("partner request nonce was already used")
+	ErrPartnerRevoked          = errors.New("partner credential is revoked")
+)
+
+// SignedPartnerRequest is a request a partner system signed with its secret
+type SignedPartnerRequest struct {
+	KeyID     string
+	Timestamp string // Unix seconds
+	Nonce     string // unique per request, 16 to 128 characters
+	Signature string // hex HMAC-SHA256 of the signing string, optionally prefixed with "sha256="
+	Method    string
+	Path      string // path and query string, as sent
+	Body      []byte
+}
+
+// PartnerSigningString returns the string partners sign: the timestamp, nonce, method, path with
+// query string and the hex SHA-256 of the body, one per line
+func PartnerSigningString(method, path, timestamp, nonce string, body []byte) string {
+	sum := sha256.Sum256(body)
+	return strings.Join([]string{timestamp, nonce, strings.ToUpper(method), path, hex.EncodeToString(sum[:])}, "\n")
+}
+
+// PartnerService defines the interface for partner API credentials and the verification of the
+// requests partners sign with them
+type PartnerService interface {
+	CreateCredential(name string, createdByID uint) (*models.PartnerCredential, string, error)
+	ListCredentials() ([]models.PartnerCredential, error)
+	RotateSecret(id uint) (*models.PartnerCredential, string, error)
+	RevokeCredential(id uint) (*models.PartnerCredential, error)
+	Verify(request SignedPartnerRequest) (*models.PartnerCredential, error)
+	PruneNonces() (int64, error)
+}
+
+// partnerService implements the PartnerService interface
+type partnerService struct {
+	partnerRepo repository.PartnerRepository
+	config      config.PartnerConfig
+	clock       clock.Clock
+}
+
+// NewPartnerService creates a new partner service
+func NewPartnerService(
+	partnerRepo repository.PartnerRepository,
+	config config.PartnerConfig,
+	clock clock.Clock,
+) PartnerService {
+	return &partnerService{
+		partnerRepo: partnerRepo,
+		config:      config,
+		clock:       clock,
+	}
+}
+
+// CreateCredential issues a key ID and secret for a partner. The secret is only returned here.
+func (s *partnerService) CreateCredential(name string, createdByID uint) (*models.PartnerCredential, string, error) {
+	keyID, err := newPartnerToken("pk_", 12)
+	if err != nil {
+		return nil, "", err
+	}
+	secret, err := newPartnerToken("", 32)
+	if err != nil {
+		return nil, "", err
+	}
+
+	credential := &models.PartnerCredential{
+		Name:        name,
+		KeyID:       keyID,
+		Secret:      secret,
+		CreatedByID: createdByID,
+	}
+	if err := credential.Validate(); err != nil {
+		return nil, "", err
+	}
+	if err := s.partnerRepo.Create(credential); err != nil {
+		return nil, "", err
+	}
+	return credential, secret, nil
+}
+
+// ListCredentials returns every partner credential, without their secrets
+func (s *partnerService) ListCredentials() ([]models.PartnerCredential, error) {
+	return s.partnerRepo.List()
+}
+
+// RotateSecret issues a new secret for a partner. The previous one is still accepted for the
+// configured grace period so the partner can switch without failed requests.
+func (s *partnerService) RotateSecret(id uint) (*models.PartnerCredential, string, error) {
+	credential, err := s.partnerRepo.FindByID(id)
+	if err != nil {
+		return nil, "", err
+	}
+	if credential.RevokedAt != nil {
+		return nil, "", ErrPartnerRevoked
+	}
+	secret, err := newPartnerToken("", 32)
+	if err != nil {
+		return nil, "", err
+	}
+
+	credential.PreviousSecret = ""
+	credential.PreviousSecretUntil = nil
+	if s.config.RotationGrace > 0 {
+		until := s.clock.Now().Add(s.config.RotationGrace)
+		credential.PreviousSecret = credential.Secret
+		credential.PreviousSecretUntil = &until
+	}
+	credential.Secret = secret
+	if err := s.partnerRepo.Update(credential); err != nil {
+		return nil, "", err
+	}
+	return credential, secret, nil
+}
+
+// RevokeCredential stops a partner's secrets from being accepted. Revoking is final; issue a new
+// credential to let the partner back in.
+func (s *partnerService) RevokeCredential(id uint) (*models.PartnerCredential, error) {
+	credential, err := s.partnerRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if credential.RevokedAt != nil {
+		return credential, nil
+	}
+
+	now := s.clock.Now()
+	credential.RevokedAt = &now
+	credential.PreviousSecret = ""
+	credential.PreviousSecretUntil = nil
+	if err := s.partnerRepo.Update(credential); err != nil {
+		return nil, err
+	}
+	return credential, nil
+}
+
+// Verify checks a partner request's signature and rejects requests signed too far from the
+// server time or whose nonce was already used, so a captured request can't be replayed. The
+// nonce is only recorded once the signature is valid.
+func (s *partnerService) Verify(request SignedPartnerRequest) (*models.PartnerCredential, error) {
+	if request.KeyID == "" || request.Timestamp == "" || request.Nonce == "" || request.Signature == "" {
+		return nil, ErrPartnerSignatureMissing
+	}
+	if len(request.Nonce) < minPartnerNonceLength || len(request.Nonce) > maxPartnerNonceLength {
+		return nil, ErrPartnerInvalidNonce
+	}
+
+	credential, err := s.partnerRepo.FindByKeyID(request.KeyID)
+	if err != nil {
+		if errors.Is(err, repository.ErrPartnerCredentialNotFound) {
+			return nil, ErrPartnerUnknownKey
+		}
+		return nil, err
+	}
+	if credential.RevokedAt != nil {
+		return nil, ErrPartnerUnknownKey
+	}
+
+	now := s.clock.Now()
+	seconds, err := strconv.ParseInt(request.Timestamp, 10, 64)
+	if err != nil {
+		return nil, ErrPartnerStaleRequest
+	}
+	signedAt := time.Unix(seconds, 0)
+	if signedAt.Before(now.Add(-s.config.SignatureTolerance)) || signedAt.After(now.Add(s.config.SignatureTolerance)) {
+		return nil, ErrPartnerStaleRequest
+	}
+
+	signature, err := hex.DecodeString(strings.TrimPrefix(strings.ToLower(request.Signature), "sha256="))
+	if err != nil {
+		return nil, ErrPartnerInvalidSignature
+	}
+	signingString := PartnerSigningString(request.Method, request.Path, request.Timestamp, request.Nonce, request.Body)
+	valid := false
+	for _, secret := range credential.Secrets(now) {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(signingString))
+		if hmac.Equal(signature, mac.Sum(nil)) {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return nil, ErrPartnerInvalidSignature
+	}
+
+	// Nonces are kept until no timestamp they could be sent with is accepted anymore
+	fresh, err := s.partnerRepo.UseNonce(&models.PartnerNonce{
+		KeyID:     credential.KeyID,
+		Nonce:     request.Nonce,
+		ExpiresAt: now.Add(2 * s.config.SignatureTolerance),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !fresh {
+		return nil, ErrPartnerReplayedRequest
+	}
+
+	if err := s.partnerRepo.TouchLastUsed(credential.ID, now); err != nil {
+		log.Printf("Failed to record the use of partner credential %d: %v", credential.ID, err)
+	}
+	return credential, nil
+}
+
+// PruneNonces deletes the nonces of requests whose timestamps are no longer accepted
+func (s *partnerService) PruneNonces() (int64, error) {
+	return s.partnerRepo.PruneNonces(s.clock.Now())
+}
+
+// newPartnerToken returns a random URL-safe token of n bytes with a prefix
+func newPartnerToken(prefix string, n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return prefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+)
+
+// portalURL returns the portal base URL that links about an operation should point at: the
+// operation's own portal domain when one is configured, the fallback otherwise
+func portalURL(operationRepo repository.OperationRepository, operationID uint, fallback string) string {
+	if operationRepo == nil || operationID == 0 {
+		return fallback
+	}
+
+	operation, err := operationRepo.FindByID(operationID)
+	if err != nil || strings.TrimSpace(operation.PortalURL) == "" {
+		return fallback
+	}
+	return strings.TrimRight(operation.PortalURL, "/")
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/metrics"
+	"github.com/bernardofernandezz/scheduling-api/pkg/circuitbreaker"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+	"github.com/bernardofernandezz/scheduling-api/pkg/httpclient"
+)
+
+// External provider names, used as circuit breaker and metric labels
+const (
+	// ProviderEmail is the email delivery provider
+	ProviderEmail = "email"
+
+	// ProviderSMS is the SMS delivery provider
+	ProviderSMS = "sms"
+
+	// ProviderPush is the push notification provider
+	ProviderPush = "push"
+
+	// ProviderGoogleCalendar is the Google Calendar API
+	ProviderGoogleCalendar = "google_calendar"
+
+	// ProviderMicrosoftCalendar is the Microsoft Graph calendar API
+	ProviderMicrosoftCalendar = "microsoft_calendar"
+
+	// ProviderGeocoding is the address geocoding service
+	ProviderGeocoding = "geocoding"
+
+	// ProviderHolidays is the public holiday service
+	ProviderHolidays = "holidays"
+
+	// ProviderPayments is the payment provider collecting booking deposits
+	ProviderPayments = "payments"
+)
+
+// NewProviderBreakers creates the circuit breaker registry shared by all external provider integrations
+func NewProviderBreakers(cfg config.CircuitBreakerConfig) *circuitbreaker.Registry {
+	registry := circuitbreaker.NewRegistry(circuitbreaker.Settings{
+		FailureThreshold: cfg.FailureThreshold,
+		OpenTimeout:      time.Duration(cfg.OpenSeconds) * time.Second,
+		OnStateChange: func(name string, from, to circuitbreaker.State) {
+			log.Printf("Circuit breaker %s changed state from %s to %s", name, from, to)
+			metrics.CircuitBreakerState.WithLabelValues(name).Set(float64(to))
+			metrics.CircuitBreakerTransitions.WithLabelValues(name, to.String()).Inc()
+		},
+	})
+
+	// Register known providers up front so they show up before their first call
+	for _, name := range []string{ProviderEmail, ProviderSMS, ProviderPush, ProviderGoogleCalendar, ProviderMicrosoftCalendar, ProviderGeocoding, ProviderHolidays, ProviderPayments} {
+		registry.Get(name)
+		metrics.CircuitBreakerState.WithLabelValues(name).Set(float64(circuitbreaker.StateClosed))
+	}
+
+	return registry
+}
+
+// NewOutboundClient creates the HTTP client shared by all external provider integrations, so
+// calls to the same host share its rate limit wherever they come from
+func NewOutboundClient(cfg config.OutboundHTTPConfig) *http.Client {
+	hostLimits := make(map[string]httpclient.Limit, len(cfg.RateLimits))
+	for host, rate := range cfg.RateLimits {
+		hostLimits[host] = httpclient.Limit{PerSecond: rate, Burst: int(rate)}
+	}
+
+	return httpclient.New(httpclient.Settings{
+		Timeout:      cfg.Timeout,
+		MaxRetries:   cfg.MaxRetries,
+		BaseDelay:    cfg.RetryBaseDelay,
+		MaxDelay:     cfg.RetryMaxDelay,
+		Jitter:       cfg.RetryJitter,
+		HostLimits:   hostLimits,
+		DefaultLimit: httpclient.Limit{PerSecond: cfg.DefaultRateLimit, Burst: int(cfg.DefaultRateLimit)},
+		OnAttempt: func(host string, status int, err error, duration time.Duration) {
+			code := "error"
+			if err == nil {
+				code = strconv.Itoa(status)
+			}
+			metrics.OutboundRequests.WithLabelValues(host, code).Inc()
+			metrics.OutboundRequestDuration.WithLabelValues(host).Observe(duration.Seconds())
+		},
+		OnRetry: func(host string, attempt int, delay time.Duration) {
+			log.Printf("Retrying request to %s (retry %d) in %s", host, attempt, delay)
+			metrics.OutboundRetries.WithLabelValues(host).Inc()
+		},
+		OnThrottle: func(host string, wait time.Duration) {
+			metrics.OutboundThrottleWait.WithLabelValues(host).Add(wait.Seconds())
+		},
+	})
+}
+
+// callProvider runs a provider call through its circuit breaker. When the breaker is open
+// the call is skipped, the fallback is recorded and the time at which the provider may be
+// retried is returned instead of an error.
+func callProvider(breakers *circuitbreaker.Registry, clock clock.Clock, provider, fallback string, call func() error) (time.Time, error) {
+	if breakers == nil {
+		return time.Time{}, call()
+	}
+
+	breaker := breakers.Get(provider)
+	err := breaker.Execute(call)
+	if errors.Is(err, circuitbreaker.ErrOpen) {
+		metrics.CircuitBreakerFallbacks.WithLabelValues(provider, fallback).Inc()
+		retryAt := breaker.RetryAt()
+		if retryAt.IsZero() {
+			// A half-open trial call is in flight; try again shortly
+			retryAt = clock.Now().Add(30 * time.Second)
+		}
+		return retryAt, nil
+	}
+	return time.Time{}, err
+}
@@ -0,0 +1,681 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+	"github.com/bernardofernandezz/scheduling-api/pkg/i18n"
+)
+
+// Quick-add errors
+var (
+	ErrQuickAddText = errors.New("quick-add text is required and must be at most 500 characters")
+)
+
+const (
+	// maxQuickAddText is the longest quick-add text read
+	maxQuickAddText = 500
+
+	// quickAddDuration is how long a quick-add appointment lasts when the text names no duration
+	quickAddDuration = time.Hour
+
+	// maxQuickAddCandidates is how many suppliers or operations a name is matched against
+	maxQuickAddCandidates = 5
+)
+
+// Quick-add fields, named after the fields of the appointment creation request
+const (
+	QuickAddSupplier  = "supplier_id"
+	QuickAddOperation = "operation_id"
+	QuickAddProduct   = "product_id"
+	QuickAddType      = "type"
+	QuickAddStart     = "scheduled_start"
+	QuickAddQuantity  = "quantity_to_deliver"
+)
+
+var (
+	quickAddSKUPattern      = regexp.MustCompile(`(?i)\bsku\s*[:#]?\s+([a-z0-9][a-z0-9-]*)|\b([a-z]+-[a-z0-9-]*[0-9][a-z0-9-]*)\b`)
+	quickAddDurationPattern = regexp.MustCompile(`(?i)\b(?:for|por)\s+(\d{1,3})\s*(h|hours?|horas?|min|mins|minutes?|minutos?)\b`)
+	quickAddISODatePattern  = regexp.MustCompile(`\b(\d{4})-(\d{1,2})-(\d{1,2})\b`)
+	quickAddDatePattern     = regexp.MustCompile(`\b(\d{1,2})/(\d{1,2})(?:/(\d{4}|\d{2}))?\b`)
+	quickAddTimePattern     = regexp.MustCompile(`(?i)\b(\d{1,2})(?::(\d{2})\s*(am|pm)?|h(\d{2})?|\s*(am|pm))\b`)
+	quickAddQuantityPattern = regexp.MustCompile(`(?i)\b(\d{1,6})\s*(boxes|box|pallets|pallet|cases|case|units|unit|pieces|pcs|caixas|caixa|paletes|palete|unidades|volumes)?\b`)
+	quickAddWordPattern     = regexp.MustCompile(`[\p{L}\p{N}&.'-]+`)
+)
+
+// quickAddWeekdays are the weekday names read in English and Portuguese
+var quickAddWeekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday, "domingo": time.Sunday, "dom": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday, "segunda": time.Monday, "seg": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday, "terça": time.Tuesday, "terca": time.Tuesday, "ter": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday, "quarta": time.Wednesday, "qua": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thurs": time.Thursday, "quinta": time.Thursday, "qui": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday, "sexta": time.Friday, "sex": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday, "sábado": time.Saturday, "sabado": time.Saturday, "sáb": time.Saturday, "sab": time.Saturday,
+}
+
+// quickAddRelativeDays are the words naming a day counted from today
+var quickAddRelativeDays = map[string]int{
+	"today": 0, "hoje": 0, "tomorrow": 1, "amanhã": 1, "amanha": 1,
+}
+
+// quickAddTypes are the words naming an appointment type
+var quickAddTypes = map[string]models.AppointmentType{
+	"delivery": models.AppointmentTypeDelivery, "entrega": models.AppointmentTypeDelivery,
+	"pickup": models.AppointmentTypePickup, "coleta": models.AppointmentTypePickup, "retirada": models.AppointmentTypePickup,
+	"visit": models.AppointmentTypeServiceVisit, "visita": models.AppointmentTypeServiceVisit,
+}
+
+// quickAddSupplierWords start a part naming the supplier
+var quickAddSupplierWords = map[string]bool{
+	"supplier": true, "vendor": true, "fornecedor": true,
+}
+
+// quickAddOperationWords mark a part naming the operation, e.g. "SP warehouse"
+var quickAddOperationWords = map[string]bool{
+	"warehouse": true, "dc": true, "depot": true, "operation": true, "site": true, "branch": true,
+	"armazém": true, "armazem": true, "cd": true, "operação": true, "operacao": true, "filial": true,
+}
+
+// quickAddFillerWords are read past
+var quickAddFillerWords = map[string]bool{
+	"next": true, "this": true, "on": true, "at": true, "the": true, "of": true,
+	"próxima": true, "proxima": true, "próximo": true, "proximo": true, "feira": true, "dia": true,
+	"em": true, "no": true, "na": true, "às": true, "as": true, "de": true, "do": true, "da": true,
+}
+
+// QuickAddCandidate is a record a name in a quick-add text may mean
+type QuickAddCandidate struct {
+	ID         uint    `json:"id"`
+	Label      string  `json:"label"`
+	Confidence float64 `json:"confidence"`
+}
+
+// QuickAddField is what was read for a field of a quick-add appointment
+type QuickAddField struct {
+	Field      string              `json:"field"`
+	Text       string              `json:"text"`                 // the words it was read from
+	Label      string              `json:"label,omitempty"`      // what they were read as, e.g. the supplier's company name
+	Confidence float64             `json:"confidence"`           // from 0, nothing matched, to 1, an exact match
+	Candidates []QuickAddCandidate `json:"candidates,omitempty"` // records the words may mean, the best first
+}
+
+// QuickAddAppointment is the appointment a quick-add text describes, shaped like the body of
+// POST /appointments. Fields nothing was read for are left empty; the type is a delivery unless
+// the text names another.
+type QuickAddAppointment struct {
+	SupplierID        uint                   `json:"supplier_id"`
+	OperationID       uint                   `json:"operation_id"`
+	Type              models.AppointmentType `json:"type"`
+	ProductID         *uint                  `json:"product_id"`
+	ScheduledStart    *time.Time             `json:"scheduled_start"`
+	ScheduledEnd      *time.Time             `json:"scheduled_end"`
+	QuantityToDeliver int                    `json:"quantity_to_deliver"`
+	Notes             string                 `json:"notes"`
+}
+
+// QuickAddDraft is an appointment read from a quick-add text, for staff to check and complete
+// before booking it. Nothing is booked or held.
+type QuickAddDraft struct {
+	Text        string              `json:"text"`
+	Appointment QuickAddAppointment `json:"appointment"`
+	Fields      []QuickAddField     `json:"fields"`
+	Unresolved  []string            `json:"unresolved"` // fields the text names, or the booking needs, that nothing was found for
+	Unparsed    []string            `json:"unparsed"`   // parts of the text nothing was read from, kept in the notes
+	Warnings    []string            `json:"warnings"`
+	Confidence  float64             `json:"confidence"` // lowest confidence of the supplier, operation and schedule
+	Timezone    string              `json:"timezone"`   // timezone the times were read in
+}
+
+// QuickAddService defines the interface for reading appointments from short free-text
+// descriptions, such as those staff take down over the phone
+type QuickAddService interface {
+	Parse(ctx context.Context, text string) (*QuickAddDraft, error)
+}
+
+// quickAddService implements the QuickAddService interface
+type quickAddService struct {
+	supplierRepo  repository.SupplierRepository
+	productRepo   repository.ProductRepository
+	operationRepo repository.OperationRepository
+	clock         clock.Clock
+}
+
+// NewQuickAddService creates a new quick-add service
+func NewQuickAddService(
+	supplierRepo repository.SupplierRepository,
+	productRepo repository.ProductRepository,
+	operationRepo repository.OperationRepository,
+	clock clock.Clock,
+) QuickAddService {
+	return &quickAddService{
+		supplierRepo:  supplierRepo,
+		productRepo:   productRepo,
+		operationRepo: operationRepo,
+		clock:         clock,
+	}
+}
+
+// quickAddWhen is what a quick-add text says about when the appointment is
+type quickAddWhen struct {
+	text     []string
+	date     *time.Time // day named by a date, in UTC
+	weekday  *time.Weekday
+	relative *int
+	hour     int
+	minute   int
+	hasTime  bool
+	duration time.Duration
+}
+
+// quickAddParse collects what the parts of a quick-add text say before names are looked up
+type quickAddParse struct {
+	when          quickAddWhen
+	sku           string
+	quantity      int
+	quantityText  string
+	quantityUnit  bool
+	typ           models.AppointmentType
+	typeText      string
+	supplierText  string
+	operationText string
+	others        []string // names that may be the supplier or the operation
+	unparsed      []string
+}
+
+// Parse reads an appointment from a text of comma-separated parts, such as
+// "Supplier Acme, 40 boxes SKU-123, SP warehouse, Tuesday 9am". Suppliers are matched by company
+// name, products by SKU and operations by code, name, city or state. Times are read in the
+// operation's timezone, or the request's when no operation is named, and dates like 05/06 in the
+// order of the request's locale.
+func (s *quickAddService) Parse(ctx context.Context, text string) (*QuickAddDraft, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || len(text) > maxQuickAddText {
+		return nil, ErrQuickAddText
+	}
+	preferences := i18n.PreferencesFrom(ctx)
+
+	parsed := &quickAddParse{}
+	for _, part := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ';' || r == '\n' }) {
+		if part = strings.TrimSpace(part); part != "" {
+			parsed.read(part, preferences.Locale == "en-US")
+		}
+	}
+
+	draft := &QuickAddDraft{
+		Text:        text,
+		Appointment: QuickAddAppointment{Type: models.AppointmentTypeDelivery},
+		Fields:      []QuickAddField{},
+		Unresolved:  []string{},
+		Unparsed:    append([]string{}, parsed.unparsed...),
+		Warnings:    []string{},
+	}
+
+	operations, err := s.operationRepo.List()
+	if err != nil {
+		return nil, err
+	}
+	var operation *models.Operation
+	var supplier, operationField *QuickAddField
+	if parsed.operationText != "" {
+		operation, operationField = matchQuickAddOperation(operations, parsed.operationText)
+	}
+	if parsed.supplierText != "" {
+		if supplier, err = s.matchSupplier(parsed.supplierText, draft); err != nil {
+			return nil, err
+		}
+	}
+
+	// Names without a word telling what they are go to the operation when one matches well
+	// and to the supplier otherwise
+	for _, name := range parsed.others {
+		if operationField == nil {
+			if matched, field := matchQuickAddOperation(operations, name); matched != nil && field.Confidence >= 0.7 {
+				operation, operationField = matched, field
+				continue
+			}
+		}
+		if supplier == nil {
+			if supplier, err = s.matchSupplier(name, draft); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		draft.Unparsed = append(draft.Unparsed, name)
+	}
+
+	product, err := s.readProduct(parsed, &supplier, draft)
+	if err != nil {
+		return nil, err
+	}
+	if supplier != nil {
+		draft.Fields = append(draft.Fields, *supplier)
+	}
+	if operationField != nil {
+		draft.Fields = append(draft.Fields, *operationField)
+		if operation != nil {
+			draft.Appointment.OperationID = operation.ID
+		}
+	}
+	if product != nil {
+		draft.Fields = append(draft.Fields, *product)
+	}
+	if parsed.typeText != "" {
+		draft.Appointment.Type = parsed.typ
+		draft.Fields = append(draft.Fields, QuickAddField{Field: QuickAddType, Text: parsed.typeText, Label: string(parsed.typ), Confidence: 1})
+	}
+	if parsed.quantityText != "" {
+		confidence := 0.6
+		if parsed.quantityUnit {
+			confidence = 1
+		}
+		draft.Appointment.QuantityToDeliver = parsed.quantity
+		draft.Fields = append(draft.Fields, QuickAddField{
+			Field: QuickAddQuantity, Text: parsed.quantityText, Label: strconv.Itoa(parsed.quantity), Confidence: confidence,
+		})
+	}
+
+	location := preferences.Location
+	if operation != nil {
+		location = operation.Location()
+	}
+	draft.Timezone = location.String()
+	s.readSchedule(parsed.when, operation, location, draft)
+
+	draft.Appointment.Notes = strings.Join(draft.Unparsed, ", ")
+	draft.Confidence = 1
+	for _, field := range []string{QuickAddSupplier, QuickAddOperation, QuickAddStart} {
+		confidence := 0.0
+		for _, read := range draft.Fields {
+			if read.Field == field {
+				confidence = read.Confidence
+			}
+		}
+		if confidence == 0 {
+			draft.Unresolved = appendMissing(draft.Unresolved, field)
+		}
+		draft.Confidence = math.Min(draft.Confidence, confidence)
+	}
+	return draft, nil
+}
+
+// read takes what one part of a quick-add text says: the product, quantity and time are picked
+// out first and the words left name the supplier or operation
+func (p *quickAddParse) read(part string, monthFirst bool) {
+	rest := part
+	take := func(pattern *regexp.Regexp, accept func(match []string) bool) {
+		for _, loc := range pattern.FindAllStringSubmatchIndex(rest, -1) {
+			match := make([]string, len(loc)/2)
+			for i := range match {
+				if loc[2*i] >= 0 {
+					match[i] = rest[loc[2*i]:loc[2*i+1]]
+				}
+			}
+			if accept(match) {
+				rest = rest[:loc[0]] + " " + rest[loc[1]:]
+				return
+			}
+		}
+	}
+
+	take(quickAddSKUPattern, func(match []string) bool {
+		if p.sku != "" {
+			return false
+		}
+		p.sku = match[1] + match[2]
+		return true
+	})
+	take(quickAddDurationPattern, func(match []string) bool {
+		n, _ := strconv.Atoi(match[1])
+		unit := time.Minute
+		if strings.HasPrefix(strings.ToLower(match[2]), "h") {
+			unit = time.Hour
+		}
+		if p.when.duration != 0 || n == 0 {
+			return false
+		}
+		p.when.duration = time.Duration(n) * unit
+		p.when.text = append(p.when.text, match[0])
+		return true
+	})
+	take(quickAddISODatePattern, func(match []string) bool {
+		return p.when.setDate(match[0], atoi(match[1]), atoi(match[2]), atoi(match[3]))
+	})
+	take(quickAddDatePattern, func(match []string) bool {
+		day, month := atoi(match[1]), atoi(match[2])
+		if monthFirst {
+			day, month = month, day
+		}
+		year := 0
+		if match[3] != "" {
+			year = atoi(match[3])
+			if year < 100 {
+				year += 2000
+			}
+		}
+		return p.when.setDate(match[0], year, month, day)
+	})
+	take(quickAddTimePattern, func(match []string) bool {
+		hour, minute := atoi(match[1]), atoi(match[2]+match[4])
+		meridiem := strings.ToLower(match[3] + match[5])
+		if meridiem != "" && (hour < 1 || hour > 12) {
+			return false
+		}
+		if meridiem == "am" && hour == 12 {
+			hour = 0
+		} else if meridiem == "pm" && hour < 12 {
+			hour += 12
+		}
+		if p.when.hasTime || hour > 23 || minute > 59 {
+			return false
+		}
+		p.when.hour, p.when.minute, p.when.hasTime = hour, minute, true
+		p.when.text = append(p.when.text, match[0])
+		return true
+	})
+	take(quickAddQuantityPattern, func(match []string) bool {
+		if p.quantityText != "" {
+			return false
+		}
+		p.quantity = atoi(match[1])
+		p.quantityText = strings.TrimSpace(match[0])
+		p.quantityUnit = match[2] != ""
+		return true
+	})
+
+	// What's left are words: date and type words, fillers, and the name of a supplier or
+	// operation. Parts naming the supplier are read as its name, which may hold any word.
+	var name []string
+	words := quickAddWordPattern.FindAllString(rest, -1)
+	supplierNamed := len(words) > 0 && quickAddSupplierWords[strings.ToLower(words[0])]
+	operationNamed := false
+	if supplierNamed {
+		words = words[1:]
+	}
+	for _, word := range words {
+		lower := strings.ToLower(strings.Trim(word, ".'-"))
+		if supplierNamed {
+			name = append(name, word)
+			continue
+		}
+		undated := p.when.weekday == nil && p.when.date == nil && p.when.relative == nil
+		if weekday, ok := quickAddWeekdays[lower]; ok && undated {
+			p.when.weekday = &weekday
+			p.when.text = append(p.when.text, word)
+			continue
+		}
+		if days, ok := quickAddRelativeDays[lower]; ok && undated {
+			p.when.relative = &days
+			p.when.text = append(p.when.text, word)
+			continue
+		}
+		if typ, ok := quickAddTypes[lower]; ok && p.typeText == "" {
+			p.typ, p.typeText = typ, word
+			continue
+		}
+		if quickAddOperationWords[lower] {
+			operationNamed = true
+			continue
+		}
+		if quickAddFillerWords[lower] && len(name) == 0 {
+			continue
+		}
+		name = append(name, word)
+	}
+	if len(name) == 0 {
+		return
+	}
+
+	text := strings.Join(name, " ")
+	switch {
+	case supplierNamed && p.supplierText == "":
+		p.supplierText = text
+	case operationNamed && p.operationText == "":
+		p.operationText = text
+	case !supplierNamed && !operationNamed:
+		p.others = append(p.others, text)
+	default:
+		p.unparsed = append(p.unparsed, part)
+	}
+}
+
+// setDate takes a date named in a quick-add text; year 0 means the next time the day comes
+func (w *quickAddWhen) setDate(text string, year, month, day int) bool {
+	if w.date != nil || w.weekday != nil || w.relative != nil || month < 1 || month > 12 || day < 1 {
+		return false
+	}
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if date.Day() != day {
+		return false
+	}
+	w.date = &date
+	w.text = append(w.text, text)
+	return true
+}
+
+// matchSupplier looks up the supplier a name means by company name
+func (s *quickAddService) matchSupplier(name string, draft *QuickAddDraft) (*QuickAddField, error) {
+	suppliers, err := s.supplierRepo.SearchByName(name, maxQuickAddCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	field := &QuickAddField{Field: QuickAddSupplier, Text: name}
+	lower := strings.ToLower(name)
+	for _, supplier := range suppliers {
+		company := strings.ToLower(supplier.CompanyName)
+		confidence := 0.6
+		if company == lower {
+			confidence = 1
+		} else if strings.HasPrefix(company, lower) {
+			confidence = 0.8
+		}
+		field.Candidates = append(field.Candidates, QuickAddCandidate{ID: supplier.ID, Label: supplier.CompanyName, Confidence: confidence})
+	}
+	if best := pickQuickAddCandidate(field); best != nil {
+		draft.Appointment.SupplierID = best.ID
+	}
+	return field, nil
+}
+
+// matchQuickAddOperation finds the operation a name means by code, name, city or state
+func matchQuickAddOperation(operations []models.Operation, name string) (*models.Operation, *QuickAddField) {
+	field := &QuickAddField{Field: QuickAddOperation, Text: name}
+	lower := strings.ToLower(name)
+	for _, operation := range operations {
+		confidence := 0.0
+		switch {
+		case strings.EqualFold(operation.Code, name), strings.EqualFold(operation.Name, name):
+			confidence = 1
+		case strings.Contains(strings.ToLower(operation.Name), lower):
+			confidence = 0.8
+		case strings.EqualFold(operation.City, name), strings.EqualFold(operation.State, name):
+			confidence = 0.7
+		default:
+			continue
+		}
+		field.Candidates = append(field.Candidates, QuickAddCandidate{
+			ID: operation.ID, Label: fmt.Sprintf("%s (%s)", operation.Name, operation.Code), Confidence: confidence,
+		})
+	}
+
+	best := pickQuickAddCandidate(field)
+	if best == nil {
+		return nil, field
+	}
+	for i := range operations {
+		if operations[i].ID == best.ID {
+			return &operations[i], field
+		}
+	}
+	return nil, field
+}
+
+// pickQuickAddCandidate ranks the candidates of a field and reads it as the best one. Several
+// equally good candidates halve the confidence. Returns nil when there is none.
+func pickQuickAddCandidate(field *QuickAddField) *QuickAddCandidate {
+	sort.SliceStable(field.Candidates, func(i, j int) bool {
+		return field.Candidates[i].Confidence > field.Candidates[j].Confidence
+	})
+	if len(field.Candidates) > maxQuickAddCandidates {
+		field.Candidates = field.Candidates[:maxQuickAddCandidates]
+	}
+	if len(field.Candidates) == 0 {
+		return nil
+	}
+
+	best := field.Candidates[0]
+	field.Label = best.Label
+	field.Confidence = best.Confidence
+	if len(field.Candidates) > 1 && field.Candidates[1].Confidence == best.Confidence {
+		field.Confidence = best.Confidence / 2
+	}
+	return &best
+}
+
+// readProduct looks up the product by the SKU the text names. The product's supplier is taken
+// when the text names no supplier that was found; a product of another supplier is warned about.
+func (s *quickAddService) readProduct(parsed *quickAddParse, supplier **QuickAddField, draft *QuickAddDraft) (*QuickAddField, error) {
+	if parsed.sku == "" {
+		return nil, nil
+	}
+	field := &QuickAddField{Field: QuickAddProduct, Text: parsed.sku}
+	product, err := s.productRepo.FindBySKU(parsed.sku)
+	if err != nil {
+		if err.Error() != "product not found" {
+			return nil, err
+		}
+		draft.Unresolved = appendMissing(draft.Unresolved, QuickAddProduct)
+		return field, nil
+	}
+
+	field.Label = fmt.Sprintf("%s (%s)", product.Name, product.SKU)
+	field.Confidence = 1
+	if !product.Active {
+		field.Confidence = 0.5
+		draft.Warnings = append(draft.Warnings, fmt.Sprintf("product %s is inactive", product.SKU))
+	}
+	draft.Appointment.ProductID = &product.ID
+
+	switch {
+	case *supplier == nil || draft.Appointment.SupplierID == 0:
+		read := &QuickAddField{Field: QuickAddSupplier, Text: parsed.sku, Confidence: 0.9}
+		if *supplier != nil {
+			// The name didn't match any supplier, so it may be misspelt or the product's
+			read.Text, read.Confidence = (*supplier).Text, 0.5
+			draft.Warnings = append(draft.Warnings, fmt.Sprintf("no supplier matches %q; the supplier of product %s was taken", read.Text, product.SKU))
+		}
+		if owner, err := s.supplierRepo.FindByID(product.SupplierID); err == nil {
+			read.Label = owner.CompanyName
+		}
+		draft.Appointment.SupplierID = product.SupplierID
+		*supplier = read
+	case draft.Appointment.SupplierID != product.SupplierID:
+		field.Confidence = math.Min(field.Confidence, 0.4)
+		draft.Warnings = append(draft.Warnings, fmt.Sprintf("product %s is supplied by supplier %d, not %s", product.SKU, product.SupplierID, (*supplier).Label))
+	}
+	return field, nil
+}
+
+// readSchedule works out the start and end from what the text says about when. A weekday is
+// the next one to come, a time without a day is the next time it comes, and a day without a
+// time starts when the operation opens.
+func (s *quickAddService) readSchedule(when quickAddWhen, operation *models.Operation, location *time.Location, draft *QuickAddDraft) {
+	if len(when.text) == 0 {
+		return
+	}
+	sort.SliceStable(when.text, func(i, j int) bool {
+		return strings.Index(draft.Text, when.text[i]) < strings.Index(draft.Text, when.text[j])
+	})
+	field := QuickAddField{Field: QuickAddStart, Text: strings.Join(when.text, " ")}
+	now := s.clock.Now().In(location)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+
+	day := today
+	confidence := 1.0
+	switch {
+	case when.date != nil:
+		year := when.date.Year()
+		if year == 0 {
+			year = now.Year()
+		}
+		day = time.Date(year, when.date.Month(), when.date.Day(), 0, 0, 0, 0, location)
+		if when.date.Year() == 0 && day.Before(today) {
+			day = day.AddDate(1, 0, 0)
+		}
+	case when.relative != nil:
+		day = today.AddDate(0, 0, *when.relative)
+	case when.weekday != nil:
+		day = today.AddDate(0, 0, (int(*when.weekday)-int(now.Weekday())+7)%7)
+		confidence = 0.9
+	default:
+		confidence = 0.6
+	}
+
+	hour, minute := when.hour, when.minute
+	if !when.hasTime {
+		if operation == nil {
+			field.Label = day.Format("2006-01-02")
+			draft.Fields = append(draft.Fields, field)
+			return
+		}
+		opening, err := time.Parse("15:04", operation.OpeningTime)
+		if err != nil {
+			field.Label = day.Format("2006-01-02")
+			draft.Fields = append(draft.Fields, field)
+			return
+		}
+		hour, minute = opening.Hour(), opening.Minute()
+		confidence = math.Min(confidence, 0.5)
+	}
+
+	start := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, location)
+	if !start.After(now) {
+		switch {
+		case when.weekday != nil:
+			start = start.AddDate(0, 0, 7)
+		case when.date == nil && when.relative == nil:
+			start = start.AddDate(0, 0, 1)
+		default:
+			confidence = math.Min(confidence, 0.3)
+			draft.Warnings = append(draft.Warnings, "the appointment would start in the past")
+		}
+	}
+
+	duration := when.duration
+	if duration == 0 {
+		duration = quickAddDuration
+	}
+	end := start.Add(duration)
+	draft.Appointment.ScheduledStart = &start
+	draft.Appointment.ScheduledEnd = &end
+	field.Label = start.Format(time.RFC3339)
+	field.Confidence = confidence
+	draft.Fields = append(draft.Fields, field)
+}
+
+// appendMissing adds a field to a list of unresolved fields once
+func appendMissing(fields []string, field string) []string {
+	for _, existing := range fields {
+		if existing == field {
+			return fields
+		}
+	}
+	return append(fields, field)
+}
+
+// atoi reads a number matched by a pattern, 0 when the group didn't match
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
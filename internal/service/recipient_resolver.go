@@ -0,0 +1,174 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/i18n"
+)
+
+// Recipient is the person behind a supplier, employee, admin or watcher, with what is needed to
+// contact them and to format messages for them
+type Recipient struct {
+	Type    models.NotificationRecipientType
+	ID      uint
+	UserID  uint
+	Name    string
+	Company string // Supplier company name; empty for other recipients
+	Email   string
+	Phone   string // Number from the notification preferences, the one SMS are sent to
+
+	// Locale and Timezone come from the notification preferences and, for the timezone of
+	// employees who set none, their operation, falling back to i18n.DefaultLocale and UTC
+	Locale   string
+	Timezone *time.Location
+
+	// Preferences are the user's notification preferences, nil when they never set any
+	Preferences *models.NotificationPreference
+}
+
+// DisplayName returns the company name of suppliers and the user name of everyone else
+func (r *Recipient) DisplayName() string {
+	if r.Company != "" {
+		return r.Company
+	}
+	return r.Name
+}
+
+// RecipientResolver finds who a (recipient type, ID) pair refers to. Notifications, calendar sync
+// and exports use it so they agree on where contact details, locale and timezone come from.
+type RecipientResolver interface {
+	Resolve(recipientType models.NotificationRecipientType, id uint) (*Recipient, error)
+	ResolveUser(user *models.User) *Recipient
+}
+
+// recipientResolver implements the RecipientResolver interface
+type recipientResolver struct {
+	userRepo       repository.UserRepository
+	employeeRepo   repository.EmployeeRepository
+	supplierRepo   repository.SupplierRepository
+	preferenceRepo repository.NotificationPreferenceRepository
+	operationRepo  repository.OperationRepository
+}
+
+// NewRecipientResolver creates a new recipient resolver
+func NewRecipientResolver(
+	userRepo repository.UserRepository,
+	employeeRepo repository.EmployeeRepository,
+	supplierRepo repository.SupplierRepository,
+	preferenceRepo repository.NotificationPreferenceRepository,
+	operationRepo repository.OperationRepository,
+) RecipientResolver {
+	return &recipientResolver{
+		userRepo:       userRepo,
+		employeeRepo:   employeeRepo,
+		supplierRepo:   supplierRepo,
+		preferenceRepo: preferenceRepo,
+		operationRepo:  operationRepo,
+	}
+}
+
+// Resolve returns the recipient of a type and ID. Supplier and employee IDs are resolved to
+// their user; admin and watcher IDs are user IDs.
+func (r *recipientResolver) Resolve(recipientType models.NotificationRecipientType, id uint) (*Recipient, error) {
+	recipient := &Recipient{
+		Type:     recipientType,
+		ID:       id,
+		Locale:   i18n.DefaultLocale,
+		Timezone: time.UTC,
+	}
+
+	userID := id
+	switch recipientType {
+	case models.RecipientSupplier:
+		supplier, err := r.supplierRepo.GetByID(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get supplier: %w", err)
+		}
+		userID = supplier.UserID
+		recipient.Company = supplier.CompanyName
+
+	case models.RecipientEmployee:
+		employee, err := r.employeeRepo.GetByID(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get employee: %w", err)
+		}
+		userID = employee.UserID
+		recipient.Timezone = r.employeeLocation(employee)
+
+	case models.RecipientAdmin, models.RecipientWatcher:
+
+	default:
+		return nil, fmt.Errorf("unknown recipient type %q", recipientType)
+	}
+
+	user, err := r.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s user: %w", recipientType, err)
+	}
+	r.applyUser(recipient, user)
+	return recipient, nil
+}
+
+// ResolveUser returns the recipient behind a signed-in user, whose record is already loaded.
+// Suppliers and employees resolve to their supplier and employee; users without one are treated
+// as admins.
+func (r *recipientResolver) ResolveUser(user *models.User) *Recipient {
+	recipient := &Recipient{
+		Type:     models.RecipientAdmin,
+		ID:       user.ID,
+		Locale:   i18n.DefaultLocale,
+		Timezone: time.UTC,
+	}
+
+	switch user.Role {
+	case "supplier":
+		if supplier, err := r.supplierRepo.FindByUserID(user.ID); err == nil {
+			recipient.Type, recipient.ID = models.RecipientSupplier, supplier.ID
+			recipient.Company = supplier.CompanyName
+		}
+	case "employee":
+		if employee, err := r.employeeRepo.FindByUserID(user.ID); err == nil {
+			recipient.Type, recipient.ID = models.RecipientEmployee, employee.ID
+			recipient.Timezone = r.employeeLocation(employee)
+		}
+	}
+
+	r.applyUser(recipient, user)
+	return recipient
+}
+
+// applyUser fills in the recipient's contact details from their user and notification
+// preferences. A timezone set in the preferences overrides the operation's.
+func (r *recipientResolver) applyUser(recipient *Recipient, user *models.User) {
+	recipient.UserID = user.ID
+	recipient.Name = user.Name
+	recipient.Email = user.Email
+
+	prefs, err := r.preferenceRepo.GetByUserID(user.ID)
+	if err != nil || prefs == nil {
+		return
+	}
+	recipient.Preferences = prefs
+	recipient.Phone = prefs.PhoneNumber
+	if prefs.Locale != "" {
+		recipient.Locale = prefs.Locale
+	}
+	if prefs.Timezone != "" {
+		recipient.Timezone = i18n.LoadLocation(prefs.Timezone)
+	}
+}
+
+// employeeLocation returns the timezone of the operation an employee belongs to, UTC when unknown
+func (r *recipientResolver) employeeLocation(employee *models.Employee) *time.Location {
+	if r.operationRepo == nil || employee.Operation == "" {
+		return time.UTC
+	}
+	operation, err := r.operationRepo.FindByCode(employee.Operation)
+	if err != nil {
+		return time.UTC
+	}
+	return i18n.LoadLocation(operation.Timezone)
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+)
+
+// Region errors
+var (
+	ErrRegionNotFound     = errors.New("region not found")
+	ErrRegionForbidden    = errors.New("you don't manage this region")
+	ErrRegionCodeTaken    = errors.New("region code is already in use")
+	ErrRegionOperation    = errors.New("region operation not found")
+	ErrRegionManager      = errors.New("region managers must be staff with an employee record")
+	ErrRegionRangeTooLong = errors.New("the range can span at most 366 days")
+)
+
+// maxRegionStatisticsRange is the longest period region statistics are counted for at once
+const maxRegionStatisticsRange = 366 * 24 * time.Hour
+
+// defaultRegionStatisticsRange is the period region statistics count when none is given
+const defaultRegionStatisticsRange = 30 * 24 * time.Hour
+
+// RegionStatistics rolls up the appointments of a region's operations in a period
+type RegionStatistics struct {
+	RegionID   uint                                   `json:"region_id"`
+	Code       string                                 `json:"code"`
+	Name       string                                 `json:"name"`
+	StartDate  time.Time                              `json:"start_date"`
+	EndDate    time.Time                              `json:"end_date"`
+	Totals     repository.AppointmentCounts           `json:"totals"`
+	Operations []repository.RegionOperationStatistics `json:"operations"` // operations with appointments in the period
+}
+
+// RegionService defines the interface for regions: admins group operations and name the
+// employees managing them, who then follow the region's appointments without being admins
+type RegionService interface {
+	Create(region *models.Region) (*models.Region, error)
+	Update(id uint, region *models.Region) (*models.Region, error)
+	Delete(id uint) error
+	List(user *models.User) ([]models.Region, error)
+	Get(user *models.User, id uint) (*models.Region, error)
+	Appointments(user *models.User, id uint, filters repository.AppointmentFilters) ([]models.Appointment, int64, error)
+	Statistics(user *models.User, id uint, from, to *time.Time) (*RegionStatistics, error)
+}
+
+// regionService implements the RegionService interface
+type regionService struct {
+	regionRepo    repository.RegionRepository
+	operationRepo repository.OperationRepository
+	employeeRepo  repository.EmployeeRepository
+	clock         clock.Clock
+}
+
+// NewRegionService creates a new region service
+func NewRegionService(
+	regionRepo repository.RegionRepository,
+	operationRepo repository.OperationRepository,
+	employeeRepo repository.EmployeeRepository,
+	clock clock.Clock,
+) RegionService {
+	return &regionService{
+		regionRepo:    regionRepo,
+		operationRepo: operationRepo,
+		employeeRepo:  employeeRepo,
+		clock:         clock,
+	}
+}
+
+// Create creates a region
+func (s *regionService) Create(region *models.Region) (*models.Region, error) {
+	region.ID = 0
+	if err := s.validate(region); err != nil {
+		return nil, err
+	}
+	if err := s.regionRepo.Create(region); err != nil {
+		return nil, err
+	}
+	return s.regionRepo.FindByID(region.ID)
+}
+
+// Update replaces a region's code, name, operations and managers
+func (s *regionService) Update(id uint, region *models.Region) (*models.Region, error) {
+	existing, err := s.find(id)
+	if err != nil {
+		return nil, err
+	}
+	region.ID = existing.ID
+	region.CreatedAt = existing.CreatedAt
+	if err := s.validate(region); err != nil {
+		return nil, err
+	}
+	if err := s.regionRepo.Update(region); err != nil {
+		return nil, err
+	}
+	return s.regionRepo.FindByID(region.ID)
+}
+
+// Delete deletes a region; its managers lose access to its operations
+func (s *regionService) Delete(id uint) error {
+	if err := s.regionRepo.Delete(id); err != nil {
+		if errors.Is(err, repository.ErrRegionNotFound) {
+			return ErrRegionNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// List returns the regions a user can follow: every region for admins, the ones they manage for
+// anyone else
+func (s *regionService) List(user *models.User) ([]models.Region, error) {
+	if user.Role == "admin" {
+		return s.regionRepo.FindAll()
+	}
+	return s.regionRepo.FindByManager(user.ID)
+}
+
+// Get returns a region the user can follow
+func (s *regionService) Get(user *models.User, id uint) (*models.Region, error) {
+	region, err := s.find(id)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != "admin" {
+		manages, err := s.regionRepo.IsManager(region.ID, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !manages {
+			return nil, ErrRegionForbidden
+		}
+	}
+	return region, nil
+}
+
+// Appointments lists the appointments of a region's operations
+func (s *regionService) Appointments(user *models.User, id uint, filters repository.AppointmentFilters) ([]models.Appointment, int64, error) {
+	region, err := s.Get(user, id)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.regionRepo.FindAppointments(region.OperationIDs, filters)
+}
+
+// Statistics counts the appointments of a region's operations scheduled to start in a period, per
+// operation and in total. Without dates it counts the last 30 days.
+func (s *regionService) Statistics(user *models.User, id uint, from, to *time.Time) (*RegionStatistics, error) {
+	region, err := s.Get(user, id)
+	if err != nil {
+		return nil, err
+	}
+
+	end := s.clock.Now()
+	if to != nil {
+		end = *to
+	}
+	start := end.Add(-defaultRegionStatisticsRange)
+	if from != nil {
+		start = *from
+	}
+	if !end.After(start) {
+		return nil, ErrInvalidSlotRange
+	}
+	if end.Sub(start) > maxRegionStatisticsRange {
+		return nil, ErrRegionRangeTooLong
+	}
+
+	operations, err := s.regionRepo.Statistics(region.OperationIDs, start, end)
+	if err != nil {
+		return nil, err
+	}
+	statistics := &RegionStatistics{
+		RegionID:   region.ID,
+		Code:       region.Code,
+		Name:       region.Name,
+		StartDate:  start,
+		EndDate:    end,
+		Operations: operations,
+	}
+	for _, operation := range operations {
+		statistics.Totals.Add(operation.AppointmentCounts)
+	}
+	return statistics, nil
+}
+
+// find loads a region
+func (s *regionService) find(id uint) (*models.Region, error) {
+	region, err := s.regionRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrRegionNotFound) {
+			return nil, ErrRegionNotFound
+		}
+		return nil, err
+	}
+	return region, nil
+}
+
+// validate checks a region, its code being free and its operations and managers existing, and
+// drops repeated operations and managers
+func (s *regionService) validate(region *models.Region) error {
+	if err := region.Validate(); err != nil {
+		return err
+	}
+
+	regions, err := s.regionRepo.FindAll()
+	if err != nil {
+		return err
+	}
+	for _, other := range regions {
+		if other.Code == region.Code && other.ID != region.ID {
+			return ErrRegionCodeTaken
+		}
+	}
+
+	region.OperationIDs = uniqueIDs(region.OperationIDs)
+	for _, operationID := range region.OperationIDs {
+		if _, err := s.operationRepo.FindByID(operationID); err != nil {
+			return fmt.Errorf("%w: %d", ErrRegionOperation, operationID)
+		}
+	}
+	region.ManagerIDs = uniqueIDs(region.ManagerIDs)
+	for _, userID := range region.ManagerIDs {
+		if _, err := s.employeeRepo.FindByUserID(userID); err != nil {
+			return fmt.Errorf("%w: user %d", ErrRegionManager, userID)
+		}
+	}
+	return nil
+}
+
+// uniqueIDs returns the IDs without repetitions, in their first order
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,374 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+)
+
+// Runtime setting errors
+var (
+	ErrSettingNotFound     = errors.New("setting not found")
+	ErrInvalidSettingValue = errors.New("invalid value for setting")
+)
+
+// Keys of the settings admins may change at runtime
+const (
+	SettingRateLimitRequests        = "rate_limit.requests"
+	SettingPendingExpiryWarningLead = "pending_expiry.warning_lead"
+	SettingAutoCompleteUndoWindow   = "auto_complete.undo_window"
+	SettingDelayRescheduleTolerance = "delays.reschedule_tolerance"
+	SettingSlotWatchWaveSize        = "slot_watches.wave_size"
+	SettingSlotWatchWaveInterval    = "slot_watches.wave_interval"
+	SettingSlotWatchCooldown        = "slot_watches.cooldown"
+	SettingCapacityWarningPercent   = "capacity.warning_percent"
+)
+
+// SettingDefinition describes a setting admins may change at runtime
+type SettingDefinition struct {
+	Key         string `json:"key"`
+	Type        string `json:"type"` // models.SettingTypeInt or SettingTypeDuration
+	Description string `json:"description"`
+	Default     string `json:"default"` // configured value, in effect while no override is saved
+	min         int64  // smallest value accepted: a count, or nanoseconds for durations
+}
+
+// SettingStatus is a setting with the value in effect on this replica
+type SettingStatus struct {
+	SettingDefinition
+	Value       string     `json:"value"`
+	Overridden  bool       `json:"overridden"` // false while the configured default applies
+	UpdatedByID *uint      `json:"updated_by_id,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+// SettingsService defines the interface for operational parameters changed at runtime. Values are
+// cached, so reading them is cheap enough for every request.
+type SettingsService interface {
+	List() []SettingStatus
+	Get(key string) (*SettingStatus, error)
+	Set(key, value string, userID uint) (*SettingStatus, error)
+	Reset(key string, userID uint) (*SettingStatus, error)
+	Reload() error
+	Int(key string) int
+	Duration(key string) time.Duration
+	OnChange(key string, listener func(value string))
+}
+
+// settingsService implements the SettingsService interface
+type settingsService struct {
+	settingRepo repository.SettingRepository
+	definitions []SettingDefinition
+
+	mu        sync.RWMutex
+	overrides map[string]models.Setting // overrides in effect on this replica, by key
+	listeners map[string][]func(value string)
+}
+
+// NewSettingsService creates a new runtime settings service. Settings default to their configured
+// values until Reload reads the saved overrides.
+func NewSettingsService(
+	settingRepo repository.SettingRepository,
+	cfg *config.Config,
+) SettingsService {
+	return &settingsService{
+		settingRepo: settingRepo,
+		definitions: settingDefinitions(cfg),
+		overrides:   make(map[string]models.Setting),
+		listeners:   make(map[string][]func(value string)),
+	}
+}
+
+// settingDefinitions lists the settings admins may change at runtime with their configured values
+func settingDefinitions(cfg *config.Config) []SettingDefinition {
+	return []SettingDefinition{
+		{
+			Key:         SettingRateLimitRequests,
+			Type:        models.SettingTypeInt,
+			Description: fmt.Sprintf("Requests a client IP may make every %s on public routes; signed-in routes allow 5 times as many", cfg.RateLimit.Period),
+			Default:     strconv.Itoa(cfg.RateLimit.Requests),
+			min:         1,
+		},
+		{
+			Key:         SettingPendingExpiryWarningLead,
+			Type:        models.SettingTypeDuration,
+			Description: "How long before a pending appointment expires its participants are warned",
+			Default:     cfg.PendingExpiry.WarningLead.String(),
+		},
+		{
+			Key:         SettingAutoCompleteUndoWindow,
+			Type:        models.SettingTypeDuration,
+			Description: "How long staff can undo an automatic completion",
+			Default:     cfg.AutoComplete.UndoWindow.String(),
+		},
+		{
+			Key:         SettingDelayRescheduleTolerance,
+			Type:        models.SettingTypeDuration,
+			Description: "Largest declared delay an appointment is moved automatically for, 0 disables auto-rescheduling",
+			Default:     cfg.Delays.RescheduleTolerance.String(),
+		},
+		{
+			Key:         SettingSlotWatchWaveSize,
+			Type:        models.SettingTypeInt,
+			Description: "Slot watchers notified per wave of a freed capacity broadcast",
+			Default:     strconv.Itoa(cfg.SlotWatches.WaveSize),
+			min:         1,
+		},
+		{
+			Key:         SettingSlotWatchWaveInterval,
+			Type:        models.SettingTypeDuration,
+			Description: "Time between slot watch waves, and the head start of a waitlisted supplier offered the slot",
+			Default:     cfg.SlotWatches.WaveInterval.String(),
+		},
+		{
+			Key:         SettingSlotWatchCooldown,
+			Type:        models.SettingTypeDuration,
+			Description: "Shortest time between two notifications of the same slot watch",
+			Default:     cfg.SlotWatches.Cooldown.String(),
+		},
+		{
+			Key:         SettingCapacityWarningPercent,
+			Type:        models.SettingTypeInt,
+			Description: "Percent of a capacity limit above which bookings are flagged and the operation manager told, 0 disables",
+			Default:     strconv.Itoa(cfg.CapacityWarnings.Percent),
+		},
+	}
+}
+
+// List returns every runtime setting with the value in effect
+func (s *settingsService) List() []SettingStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	statuses := make([]SettingStatus, 0, len(s.definitions))
+	for _, definition := range s.definitions {
+		statuses = append(statuses, s.status(definition))
+	}
+	return statuses
+}
+
+// Get returns a runtime setting with the value in effect
+func (s *settingsService) Get(key string) (*SettingStatus, error) {
+	definition, ok := s.definition(key)
+	if !ok {
+		return nil, ErrSettingNotFound
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	status := s.status(definition)
+	return &status, nil
+}
+
+// Set overrides the configured value of a setting. The change applies here at once and on the
+// other replicas when they next reload the settings.
+func (s *settingsService) Set(key, value string, userID uint) (*SettingStatus, error) {
+	definition, ok := s.definition(key)
+	if !ok {
+		return nil, ErrSettingNotFound
+	}
+	normalized, err := definition.normalize(value)
+	if err != nil {
+		return nil, err
+	}
+
+	setting := &models.Setting{Key: key, Value: normalized, UpdatedByID: &userID}
+	if err := s.settingRepo.Save(setting); err != nil {
+		return nil, fmt.Errorf("failed to save setting %s: %w", key, err)
+	}
+
+	log.Printf("Setting %s changed to %s by user %d", key, normalized, userID)
+	s.apply(map[string]models.Setting{key: *setting}, nil)
+	return s.Get(key)
+}
+
+// Reset removes the override of a setting, bringing back its configured value
+func (s *settingsService) Reset(key string, userID uint) (*SettingStatus, error) {
+	if _, ok := s.definition(key); !ok {
+		return nil, ErrSettingNotFound
+	}
+	if err := s.settingRepo.Delete(key); err != nil {
+		return nil, fmt.Errorf("failed to reset setting %s: %w", key, err)
+	}
+
+	log.Printf("Setting %s reset to its configured value by user %d", key, userID)
+	s.apply(nil, []string{key})
+	return s.Get(key)
+}
+
+// Reload applies the overrides saved on any replica that changed since they were last applied.
+// Overrides of unknown settings or with values no longer valid are ignored.
+func (s *settingsService) Reload() error {
+	saved, err := s.settingRepo.List()
+	if err != nil {
+		return err
+	}
+
+	found := make(map[string]bool, len(saved))
+	changed := make(map[string]models.Setting)
+	for _, setting := range saved {
+		definition, ok := s.definition(setting.Key)
+		if !ok {
+			continue
+		}
+		if _, err := definition.normalize(setting.Value); err != nil {
+			log.Printf("Ignoring the saved value of setting %s: %v", setting.Key, err)
+			continue
+		}
+		found[setting.Key] = true
+
+		s.mu.RLock()
+		current, ok := s.overrides[setting.Key]
+		s.mu.RUnlock()
+		if !ok || current.Value != setting.Value || !current.UpdatedAt.Equal(setting.UpdatedAt) {
+			changed[setting.Key] = setting
+		}
+	}
+
+	var removed []string
+	s.mu.RLock()
+	for key := range s.overrides {
+		if !found[key] {
+			removed = append(removed, key)
+		}
+	}
+	s.mu.RUnlock()
+
+	s.apply(changed, removed)
+	return nil
+}
+
+// Int returns the value in effect of an integer setting
+func (s *settingsService) Int(key string) int {
+	value, _ := strconv.Atoi(s.value(key))
+	return value
+}
+
+// Duration returns the value in effect of a duration setting
+func (s *settingsService) Duration(key string) time.Duration {
+	value, _ := time.ParseDuration(s.value(key))
+	return value
+}
+
+// OnChange registers a listener called with the new value whenever the value in effect of a
+// setting changes, whether here or on another replica. Listeners run synchronously and mustn't
+// block.
+func (s *settingsService) OnChange(key string, listener func(value string)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listeners[key] = append(s.listeners[key], listener)
+}
+
+// apply puts changed overrides in effect and removes others, then notifies the listeners of the
+// settings whose value in effect changed
+func (s *settingsService) apply(changed map[string]models.Setting, removed []string) {
+	type notification struct {
+		value     string
+		listeners []func(value string)
+	}
+	var notifications []notification
+
+	s.mu.Lock()
+	update := func(key string, setting *models.Setting) {
+		definition, _ := s.definition(key)
+		before := s.valueLocked(definition)
+		if setting != nil {
+			s.overrides[key] = *setting
+		} else {
+			delete(s.overrides, key)
+		}
+		if after := s.valueLocked(definition); after != before {
+			notifications = append(notifications, notification{value: after, listeners: s.listeners[key]})
+		}
+	}
+	for key, setting := range changed {
+		setting := setting
+		update(key, &setting)
+	}
+	for _, key := range removed {
+		update(key, nil)
+	}
+	s.mu.Unlock()
+
+	for _, notification := range notifications {
+		for _, listener := range notification.listeners {
+			listener(notification.value)
+		}
+	}
+}
+
+// value returns the value in effect of a setting, "" for unknown settings
+func (s *settingsService) value(key string) string {
+	definition, ok := s.definition(key)
+	if !ok {
+		return ""
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.valueLocked(definition)
+}
+
+// valueLocked returns the value in effect of a setting; the caller holds the lock
+func (s *settingsService) valueLocked(definition SettingDefinition) string {
+	if override, ok := s.overrides[definition.Key]; ok {
+		return override.Value
+	}
+	return definition.Default
+}
+
+// status describes a setting; the caller holds the lock
+func (s *settingsService) status(definition SettingDefinition) SettingStatus {
+	status := SettingStatus{SettingDefinition: definition, Value: definition.Default}
+	if override, ok := s.overrides[definition.Key]; ok {
+		updatedAt := override.UpdatedAt
+		status.Value = override.Value
+		status.Overridden = true
+		status.UpdatedByID = override.UpdatedByID
+		status.UpdatedAt = &updatedAt
+	}
+	return status
+}
+
+// definition returns the definition of a setting
+func (s *settingsService) definition(key string) (SettingDefinition, bool) {
+	for _, definition := range s.definitions {
+		if definition.Key == key {
+			return definition, true
+		}
+	}
+	return SettingDefinition{}, false
+}
+
+// normalize checks a value fits the setting and returns it in canonical form, e.g. 1h30m0s for 90m
+func (d SettingDefinition) normalize(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	switch d.Type {
+	case models.SettingTypeInt:
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return "", fmt.Errorf("%w %s: expected a whole number", ErrInvalidSettingValue, d.Key)
+		}
+		if int64(number) < d.min {
+			return "", fmt.Errorf("%w %s: must be at least %d", ErrInvalidSettingValue, d.Key, d.min)
+		}
+		return strconv.Itoa(number), nil
+	case models.SettingTypeDuration:
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return "", fmt.Errorf("%w %s: expected a duration such as 30m or 12h", ErrInvalidSettingValue, d.Key)
+		}
+		if int64(duration) < d.min {
+			return "", fmt.Errorf("%w %s: must be at least %s", ErrInvalidSettingValue, d.Key, time.Duration(d.min))
+		}
+		return duration.String(), nil
+	}
+	return "", fmt.Errorf("%w %s", ErrInvalidSettingValue, d.Key)
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+)
+
+// Skill errors
+var (
+	ErrEmployeeUnqualified = errors.New("employee lacks the skills this appointment requires")
+	ErrNoQualifiedEmployee = errors.New("no qualified employee is available for this slot")
+)
+
+// SkillService defines the interface for employee skills and the skills operations and product
+// categories require of the employee an appointment is booked with
+type SkillService interface {
+	GetEmployeeSkills(employeeID uint) ([]string, error)
+	SetEmployeeSkills(employeeID uint, skills []string) ([]string, error)
+	ListRequirements(operationID *uint) ([]models.SkillRequirement, error)
+	SetRequirements(operationID *uint, requirements []models.SkillRequirement) ([]models.SkillRequirement, error)
+}
+
+// skillService implements the SkillService interface
+type skillService struct {
+	skillRepo     repository.SkillRepository
+	employeeRepo  repository.EmployeeRepository
+	operationRepo repository.OperationRepository
+}
+
+// NewSkillService creates a new skill service
+func NewSkillService(
+	skillRepo repository.SkillRepository,
+	employeeRepo repository.EmployeeRepository,
+	operationRepo repository.OperationRepository,
+) SkillService {
+	return &skillService{
+		skillRepo:     skillRepo,
+		employeeRepo:  employeeRepo,
+		operationRepo: operationRepo,
+	}
+}
+
+// GetEmployeeSkills returns the skills of an employee in alphabetical order
+func (s *skillService) GetEmployeeSkills(employeeID uint) ([]string, error) {
+	if _, err := s.employeeRepo.FindByID(employeeID); err != nil {
+		return nil, err
+	}
+	skills, err := s.skillRepo.FindByEmployee(employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(skills))
+	for _, skill := range skills {
+		names = append(names, skill.Skill)
+	}
+	return names, nil
+}
+
+// SetEmployeeSkills replaces the skills of an employee. Skills are normalized and duplicates
+// dropped.
+func (s *skillService) SetEmployeeSkills(employeeID uint, skills []string) ([]string, error) {
+	if _, err := s.employeeRepo.FindByID(employeeID); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(skills))
+	normalized := make([]string, 0, len(skills))
+	for i, skill := range skills {
+		skill = models.NormalizeSkill(skill)
+		if err := models.ValidateSkill(skill); err != nil {
+			return nil, fmt.Errorf("skill %d: %w", i+1, err)
+		}
+		if !seen[skill] {
+			seen[skill] = true
+			normalized = append(normalized, skill)
+		}
+	}
+	sort.Strings(normalized)
+
+	if err := s.skillRepo.ReplaceEmployeeSkills(employeeID, normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// ListRequirements returns the requirements of an operation, or the ones applying at every
+// operation when operationID is nil
+func (s *skillService) ListRequirements(operationID *uint) ([]models.SkillRequirement, error) {
+	if operationID != nil {
+		if _, err := s.operationRepo.FindByID(*operationID); err != nil {
+			return nil, err
+		}
+	}
+	return s.skillRepo.FindRequirementsByScope(operationID)
+}
+
+// SetRequirements replaces the requirements of an operation, or the ones applying at every
+// operation when operationID is nil. Appointments already booked are left as they are.
+func (s *skillService) SetRequirements(operationID *uint, requirements []models.SkillRequirement) ([]models.SkillRequirement, error) {
+	if operationID != nil {
+		if _, err := s.operationRepo.FindByID(*operationID); err != nil {
+			return nil, err
+		}
+	}
+
+	seen := make(map[string]bool)
+	for i := range requirements {
+		requirement := &requirements[i]
+		requirement.OperationID = operationID
+		requirement.Skill = models.NormalizeSkill(requirement.Skill)
+		requirement.ProductCategory = strings.TrimSpace(requirement.ProductCategory)
+		if err := requirement.Validate(); err != nil {
+			return nil, fmt.Errorf("requirement %d: %w", i+1, err)
+		}
+		key := strings.ToLower(requirement.ProductCategory) + " " + requirement.Skill
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate requirement of %s for category %q", requirement.Skill, requirement.ProductCategory)
+		}
+		seen[key] = true
+	}
+
+	if err := s.skillRepo.ReplaceRequirements(operationID, requirements); err != nil {
+		return nil, err
+	}
+	return s.skillRepo.FindRequirementsByScope(operationID)
+}
+
+// requiredSkills returns the skills an appointment at an operation for a product, if any,
+// requires of its employee, in alphabetical order
+func requiredSkills(skillRepo repository.SkillRepository, productRepo repository.ProductRepository, operationID uint, productID *uint) ([]string, error) {
+	requirements, err := skillRepo.FindApplicableRequirements(operationID)
+	if err != nil || len(requirements) == 0 {
+		return nil, err
+	}
+
+	category := ""
+	if productID != nil {
+		product, err := productRepo.FindByID(*productID)
+		if err != nil {
+			return nil, err
+		}
+		category = product.Category
+	}
+
+	seen := make(map[string]bool)
+	var skills []string
+	for _, requirement := range requirements {
+		if requirement.AppliesTo(operationID, category) && !seen[requirement.Skill] {
+			seen[requirement.Skill] = true
+			skills = append(skills, requirement.Skill)
+		}
+	}
+	sort.Strings(skills)
+	return skills, nil
+}
+
+// missingSkills returns the required skills an employee doesn't hold
+func missingSkills(skillRepo repository.SkillRepository, employeeID uint, required []string) ([]string, error) {
+	if len(required) == 0 {
+		return nil, nil
+	}
+	held, err := skillRepo.FindByEmployee(employeeID)
+	if err != nil {
+		return nil, err
+	}
+	return lackedSkills(held, required), nil
+}
+
+// lackedSkills returns the required skills missing from the ones held
+func lackedSkills(held []models.EmployeeSkill, required []string) []string {
+	holds := make(map[string]bool, len(held))
+	for _, skill := range held {
+		holds[skill.Skill] = true
+	}
+
+	var missing []string
+	for _, skill := range required {
+		if !holds[skill] {
+			missing = append(missing, skill)
+		}
+	}
+	return missing
+}
+
+// unqualifiedError describes the skills an employee is missing
+func unqualifiedError(missing []string) error {
+	return fmt.Errorf("%w: %s", ErrEmployeeUnqualified, strings.Join(missing, ", "))
+}
@@ -0,0 +1,454 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+)
+
+// Slot watch errors
+var (
+	ErrSlotWatchNotFound   = errors.New("slot watch not found")
+	ErrSlotWatchPastDate   = errors.New("only today or a later date can be watched")
+	ErrSlotWatchDuplicate  = errors.New("the supplier already watches this operation, date and type")
+	ErrSlotWatchSupplier   = errors.New("only suppliers can watch slots and book opened slots")
+	ErrSlotOpeningNotFound = errors.New("slot opening not found")
+	ErrSlotOpeningClosed   = errors.New("the opened slot is no longer available")
+	ErrSlotOpeningTaken    = errors.New("every place of the opened slot was already taken")
+	ErrSlotOpeningType     = errors.New("the opened slot is for another appointment type")
+	ErrSlotOutsideOpening  = errors.New("the appointment must fit in the opened time")
+)
+
+// maxDueOpenings is the most openings a broadcast run sends a wave for
+const maxDueOpenings = 100
+
+// defaultSlotWaveSize is the wave size used when none is configured
+const defaultSlotWaveSize = 10
+
+// SlotBooking is what a supplier books through a slot opening. Zero times book the whole opened
+// time, and no employee books with the one whose appointment was cancelled, if any.
+type SlotBooking struct {
+	EmployeeID        uint
+	Type              models.AppointmentType
+	ProductID         *uint
+	ScheduledStart    time.Time
+	ScheduledEnd      time.Time
+	QuantityToDeliver int
+	Notes             string
+}
+
+// SlotWatchService defines the interface for suppliers watching an operation for freed capacity
+// and the openings broadcast to them
+type SlotWatchService interface {
+	Watch(watch *models.SlotWatch) (*models.SlotWatch, error)
+	ListBySupplier(supplierID uint) ([]models.SlotWatch, error)
+	GetWatch(id uint) (*models.SlotWatch, error)
+	Unwatch(id uint) error
+	SupplierFor(user *models.User) (uint, error)
+	Open(opening *models.SlotOpening) (*models.SlotOpening, error)
+	OpenCancelled(appointment *models.Appointment, offeredSupplierID *uint) (*models.SlotOpening, error)
+	Broadcast(ctx context.Context) (int, error)
+	GetOpening(id uint) (*models.SlotOpening, error)
+	OpeningFor(id, supplierID uint) (*models.SlotOpening, error)
+	Claim(openingID, supplierID uint, booking SlotBooking) (*models.Appointment, error)
+	Release(openingID uint)
+}
+
+// slotWatchService implements the SlotWatchService interface
+type slotWatchService struct {
+	slotWatchRepo       repository.SlotWatchRepository
+	supplierRepo        repository.SupplierRepository
+	operationRepo       repository.OperationRepository
+	notificationService NotificationService
+	settingsService     SettingsService
+	config              *config.Config
+	clock               clock.Clock
+}
+
+// NewSlotWatchService creates a new slot watch service
+func NewSlotWatchService(
+	slotWatchRepo repository.SlotWatchRepository,
+	supplierRepo repository.SupplierRepository,
+	operationRepo repository.OperationRepository,
+	notificationService NotificationService,
+	settingsService SettingsService,
+	config *config.Config,
+	clock clock.Clock,
+) SlotWatchService {
+	return &slotWatchService{
+		slotWatchRepo:       slotWatchRepo,
+		supplierRepo:        supplierRepo,
+		operationRepo:       operationRepo,
+		notificationService: notificationService,
+		settingsService:     settingsService,
+		config:              config,
+		clock:               clock,
+	}
+}
+
+// Watch starts a supplier watching an operation for freed capacity, on one date or on every date
+// and for one appointment type or every type. A watched date can't be in the past at the
+// operation.
+func (s *slotWatchService) Watch(watch *models.SlotWatch) (*models.SlotWatch, error) {
+	if err := watch.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := s.supplierRepo.FindByID(watch.SupplierID); err != nil {
+		return nil, errors.New("invalid supplier: " + err.Error())
+	}
+	operation, err := s.operationRepo.FindByID(watch.OperationID)
+	if err != nil {
+		return nil, errors.New("invalid operation: " + err.Error())
+	}
+
+	if watch.Date != nil {
+		date := civilDate(*watch.Date, time.UTC)
+		if date.Before(civilDate(s.clock.Now(), operation.Location())) {
+			return nil, ErrSlotWatchPastDate
+		}
+		watch.Date = &date
+	}
+
+	existing, err := s.slotWatchRepo.FindSame(watch)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrSlotWatchDuplicate
+	}
+
+	watch.ID = 0
+	watch.LastNotifiedAt = nil
+	if err := s.slotWatchRepo.Create(watch); err != nil {
+		return nil, err
+	}
+	return watch, nil
+}
+
+// ListBySupplier returns a supplier's slot watches
+func (s *slotWatchService) ListBySupplier(supplierID uint) ([]models.SlotWatch, error) {
+	return s.slotWatchRepo.FindBySupplier(supplierID)
+}
+
+// GetWatch gets a slot watch by ID
+func (s *slotWatchService) GetWatch(id uint) (*models.SlotWatch, error) {
+	watch, err := s.slotWatchRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrSlotWatchNotFound
+	}
+	return watch, nil
+}
+
+// Unwatch stops a slot watch. Openings it was already notified of can still be booked.
+func (s *slotWatchService) Unwatch(id uint) error {
+	if _, err := s.GetWatch(id); err != nil {
+		return err
+	}
+	return s.slotWatchRepo.Delete(id)
+}
+
+// SupplierFor returns the supplier record of a supplier user
+func (s *slotWatchService) SupplierFor(user *models.User) (uint, error) {
+	supplier, err := s.supplierRepo.FindByUserID(user.ID)
+	if err != nil {
+		return 0, ErrSlotWatchSupplier
+	}
+	return supplier.ID, nil
+}
+
+// Open records freed capacity and notifies the first wave of its watchers. When a waitlisted
+// supplier was offered the slot, the first wave waits a wave interval to give them a head start.
+// Openings whose time already passed aren't recorded; nil is returned for them.
+func (s *slotWatchService) Open(opening *models.SlotOpening) (*models.SlotOpening, error) {
+	operation, err := s.operationRepo.FindByID(opening.OperationID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	if !opening.WindowEnd.After(now) {
+		return nil, nil
+	}
+	opening.ID = 0
+	opening.Booked = 0
+	opening.Status = models.SlotOpeningBroadcasting
+	opening.Waves = 0
+	opening.Notified = 0
+	opening.NotifiedThroughID = 0
+	opening.NextWaveAt = nil
+	if err := s.slotWatchRepo.CreateOpening(opening); err != nil {
+		return nil, err
+	}
+
+	if opening.OfferedSupplierID != nil {
+		next := now.Add(s.settings().WaveInterval)
+		opening.NextWaveAt = &next
+		return opening, s.slotWatchRepo.RecordWave(opening)
+	}
+	if _, err := s.sendWave(opening, operation, now); err != nil {
+		return opening, err
+	}
+	return opening, nil
+}
+
+// OpenCancelled broadcasts the slot of a cancelled appointment to the suppliers watching its
+// operation and date, except the one who cancelled. offeredSupplierID is the waitlisted supplier
+// the slot was offered to first, if any. Slots already started aren't broadcast.
+func (s *slotWatchService) OpenCancelled(appointment *models.Appointment, offeredSupplierID *uint) (*models.SlotOpening, error) {
+	if !appointment.ScheduledStart.After(s.clock.Now()) {
+		return nil, nil
+	}
+	operation, err := s.operationRepo.FindByID(appointment.OperationID)
+	if err != nil {
+		return nil, err
+	}
+
+	appointmentID, employeeID := appointment.ID, appointment.EmployeeID
+	return s.Open(&models.SlotOpening{
+		OperationID:        operation.ID,
+		Date:               civilDate(appointment.ScheduledStart, operation.Location()),
+		Type:               appointment.Type,
+		Cause:              models.SlotOpeningCancellation,
+		AppointmentID:      &appointmentID,
+		EmployeeID:         &employeeID,
+		WindowStart:        appointment.ScheduledStart,
+		WindowEnd:          appointment.ScheduledEnd,
+		Places:             1,
+		ExcludedSupplierID: appointment.SupplierID,
+		OfferedSupplierID:  offeredSupplierID,
+	})
+}
+
+// Broadcast closes the openings whose time passed and sends the waves that are due, skipping
+// openings whose places were all taken. It returns how many watchers were notified.
+func (s *slotWatchService) Broadcast(ctx context.Context) (int, error) {
+	now := s.clock.Now()
+	expired, err := s.slotWatchRepo.ExpireOpenings(now)
+	if err != nil {
+		return 0, err
+	}
+	if expired > 0 {
+		log.Printf("Closed %d slot openings whose time passed", expired)
+	}
+
+	openings, err := s.slotWatchRepo.DueOpenings(now, maxDueOpenings)
+	if err != nil {
+		return 0, err
+	}
+	notified := 0
+	for i := range openings {
+		if err := ctx.Err(); err != nil {
+			return notified, err
+		}
+		opening := &openings[i]
+		operation, err := s.operationRepo.FindByID(opening.OperationID)
+		if err != nil {
+			log.Printf("Failed to load the operation of slot opening %d: %v", opening.ID, err)
+			continue
+		}
+		sent, err := s.sendWave(opening, operation, now)
+		notified += sent
+		if err != nil {
+			log.Printf("Failed to send the next wave of slot opening %d: %v", opening.ID, err)
+		}
+	}
+	return notified, nil
+}
+
+// GetOpening gets a slot opening by ID
+func (s *slotWatchService) GetOpening(id uint) (*models.SlotOpening, error) {
+	opening, err := s.slotWatchRepo.FindOpening(id)
+	if err != nil {
+		return nil, ErrSlotOpeningNotFound
+	}
+	return opening, nil
+}
+
+// OpeningFor gets a slot opening for a supplier. Openings no wave reached the supplier with are
+// answered as if they did not exist, so later waves can't jump the queue.
+func (s *slotWatchService) OpeningFor(id, supplierID uint) (*models.SlotOpening, error) {
+	opening, err := s.GetOpening(id)
+	if err != nil {
+		return nil, err
+	}
+	reached, err := s.slotWatchRepo.Notified(opening, supplierID)
+	if err != nil {
+		return nil, err
+	}
+	if !reached {
+		return nil, ErrSlotOpeningNotFound
+	}
+	return opening, nil
+}
+
+// Claim takes one of an opening's places for a supplier a wave reached, first come first served,
+// and returns the pending appointment to book in it. The caller creates the appointment, and
+// gives the place back with Release if that fails. Without an employee the caller assigns one.
+func (s *slotWatchService) Claim(openingID, supplierID uint, booking SlotBooking) (*models.Appointment, error) {
+	opening, err := s.OpeningFor(openingID, supplierID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.clock.Now()
+	if !opening.Bookable(now) {
+		if opening.Full() {
+			return nil, ErrSlotOpeningTaken
+		}
+		return nil, ErrSlotOpeningClosed
+	}
+
+	appointmentType := booking.Type
+	if appointmentType == "" {
+		appointmentType = opening.Type
+	}
+	if appointmentType == "" {
+		appointmentType = models.AppointmentTypeDelivery
+	}
+	if opening.Type != "" && appointmentType != opening.Type {
+		return nil, ErrSlotOpeningType
+	}
+
+	start, end := booking.ScheduledStart, booking.ScheduledEnd
+	if start.IsZero() {
+		start = opening.WindowStart
+	}
+	if end.IsZero() {
+		end = opening.WindowEnd
+	}
+	if start.Before(opening.WindowStart) || end.After(opening.WindowEnd) || !end.After(start) {
+		return nil, ErrSlotOutsideOpening
+	}
+
+	employeeID := booking.EmployeeID
+	if employeeID == 0 && opening.EmployeeID != nil {
+		employeeID = *opening.EmployeeID
+	}
+
+	claimed, err := s.slotWatchRepo.ClaimOpening(opening.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, ErrSlotOpeningTaken
+	}
+
+	return &models.Appointment{
+		SupplierID:        supplierID,
+		EmployeeID:        employeeID,
+		OperationID:       opening.OperationID,
+		Type:              appointmentType,
+		ProductID:         booking.ProductID,
+		ScheduledStart:    start,
+		ScheduledEnd:      end,
+		QuantityToDeliver: booking.QuantityToDeliver,
+		Notes:             booking.Notes,
+		Status:            models.StatusPending,
+	}, nil
+}
+
+// Release gives back a place claimed for a booking that failed
+func (s *slotWatchService) Release(openingID uint) {
+	if err := s.slotWatchRepo.ReleaseOpening(openingID); err != nil {
+		log.Printf("Failed to release a place of slot opening %d: %v", openingID, err)
+	}
+}
+
+// sendWave notifies the next watchers of an opening, oldest watch first, and schedules the wave
+// after them. A supplier with several matching watches hears once, and a watch notified within
+// the cooldown is passed over. It returns how many watchers were notified.
+func (s *slotWatchService) sendWave(opening *models.SlotOpening, operation *models.Operation, now time.Time) (int, error) {
+	settings := s.settings()
+	size := settings.WaveSize
+	if size <= 0 {
+		size = defaultSlotWaveSize
+	}
+	watches, err := s.slotWatchRepo.Watchers(opening, size)
+	if err != nil {
+		return 0, err
+	}
+
+	var notified []uint
+	suppliers := make(map[uint]bool)
+	for i := range watches {
+		watch := &watches[i]
+		opening.NotifiedThroughID = watch.ID
+		if suppliers[watch.SupplierID] {
+			continue
+		}
+		suppliers[watch.SupplierID] = true
+		if watch.LastNotifiedAt != nil && now.Sub(*watch.LastNotifiedAt) < settings.Cooldown {
+			continue
+		}
+		s.notifyOpening(opening, operation, watch)
+		notified = append(notified, watch.ID)
+	}
+	if err := s.slotWatchRepo.MarkNotified(notified, now); err != nil {
+		return len(notified), err
+	}
+
+	opening.Waves++
+	opening.Notified += len(notified)
+	opening.NextWaveAt = nil
+	if len(watches) < size {
+		opening.Status = models.SlotOpeningNotified
+	} else {
+		next := now.Add(settings.WaveInterval)
+		opening.NextWaveAt = &next
+	}
+	return len(notified), s.slotWatchRepo.RecordWave(opening)
+}
+
+// notifyOpening emails a watching supplier the opening's first-come booking link
+func (s *slotWatchService) notifyOpening(opening *models.SlotOpening, operation *models.Operation, watch *models.SlotWatch) {
+	if s.notificationService == nil {
+		return
+	}
+
+	fallback := ""
+	if s.config != nil && s.config.Notification != nil {
+		fallback = s.config.Notification.LinkBaseURL
+	}
+	link := buildLink(portalURL(s.operationRepo, operation.ID, fallback), "slot-openings", opening.ID)
+	location := operation.Location()
+	kind := "any appointment"
+	if opening.Type != "" {
+		kind = "a " + strings.ToLower(opening.Type.Label())
+	}
+
+	notification := &models.Notification{
+		Type:          models.NotificationTypeEmail,
+		Status:        models.NotificationStatusPending,
+		Event:         models.EventSlotOpened,
+		RecipientType: models.RecipientSupplier,
+		RecipientID:   watch.SupplierID,
+		Subject:       fmt.Sprintf("A slot opened at %s on %s", operation.Name, opening.DateKey()),
+		Body: fmt.Sprintf("Room for %s opened at %s on %s between %s and %s. Other suppliers were told too and it goes to whoever books first: %s",
+			kind, operation.Name, opening.DateKey(),
+			opening.WindowStart.In(location).Format("15:04"), opening.WindowEnd.In(location).Format("15:04"), link),
+	}
+	if err := s.notificationService.EnqueueNotification(notification, QueueAppointmentNotifications, 2); err != nil {
+		log.Printf("Failed to enqueue slot opening %d for slot watch %d: %v", opening.ID, watch.ID, err)
+	}
+}
+
+// settings returns the slot watch configuration, with the waves as currently set at runtime
+func (s *slotWatchService) settings() config.SlotWatchConfig {
+	if s.config == nil {
+		return config.SlotWatchConfig{}
+	}
+	settings := s.config.SlotWatches
+	if s.settingsService != nil {
+		settings.WaveSize = s.settingsService.Int(SettingSlotWatchWaveSize)
+		settings.WaveInterval = s.settingsService.Duration(SettingSlotWatchWaveInterval)
+		settings.Cooldown = s.settingsService.Duration(SettingSlotWatchCooldown)
+	}
+	return settings
+}
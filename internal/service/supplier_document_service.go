@@ -0,0 +1,260 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+)
+
+// ErrSupplierDocumentsLapsed is returned when a booking is blocked by missing or expired documents
+var ErrSupplierDocumentsLapsed = errors.New("supplier has missing or expired required documents")
+
+// DocumentCompliance describes the state of one required document for a supplier
+type DocumentCompliance struct {
+	DocumentType  models.SupplierDocumentType `json:"document_type"`
+	BlockBookings bool                        `json:"block_bookings"`
+	Valid         bool                        `json:"valid"`
+	ExpiresAt     *time.Time                  `json:"expires_at,omitempty"`
+}
+
+// SupplierDocumentService defines the interface for supplier compliance document operations
+type SupplierDocumentService interface {
+	AddDocument(document *models.SupplierDocument) error
+	GetDocument(id uint) (*models.SupplierDocument, error)
+	ListDocuments(supplierID uint) ([]models.SupplierDocument, error)
+	UpdateDocument(document *models.SupplierDocument) error
+	DeleteDocument(id uint) error
+	GetRequirements(operationID uint) ([]models.SupplierDocumentRequirement, error)
+	SetRequirements(operationID uint, requirements []models.SupplierDocumentRequirement) error
+	CheckCompliance(supplierID, operationID uint, at time.Time) ([]DocumentCompliance, error)
+	EnsureCanBook(supplierID, operationID uint, at time.Time) error
+	IsSupplierUser(supplierID, userID uint) bool
+	NotifyExpiring() (int, error)
+}
+
+// supplierDocumentService implements the SupplierDocumentService interface
+type supplierDocumentService struct {
+	documentRepo        repository.SupplierDocumentRepository
+	requirementRepo     repository.DocumentRequirementRepository
+	supplierRepo        repository.SupplierRepository
+	notificationService NotificationService
+	config              *config.Config
+	clock               clock.Clock
+}
+
+// NewSupplierDocumentService creates a new supplier document service
+func NewSupplierDocumentService(
+	documentRepo repository.SupplierDocumentRepository,
+	requirementRepo repository.DocumentRequirementRepository,
+	supplierRepo repository.SupplierRepository,
+	notificationService NotificationService,
+	config *config.Config,
+	clock clock.Clock,
+) SupplierDocumentService {
+	return &supplierDocumentService{
+		documentRepo:        documentRepo,
+		requirementRepo:     requirementRepo,
+		supplierRepo:        supplierRepo,
+		notificationService: notificationService,
+		config:              config,
+		clock:               clock,
+	}
+}
+
+// AddDocument registers a compliance document for a supplier
+func (s *supplierDocumentService) AddDocument(document *models.SupplierDocument) error {
+	if _, err := s.supplierRepo.FindByID(document.SupplierID); err != nil {
+		return errors.New("invalid supplier: " + err.Error())
+	}
+	if err := document.Validate(); err != nil {
+		return err
+	}
+	return s.documentRepo.Create(document)
+}
+
+// GetDocument gets a supplier document by ID
+func (s *supplierDocumentService) GetDocument(id uint) (*models.SupplierDocument, error) {
+	return s.documentRepo.FindByID(id)
+}
+
+// ListDocuments lists the documents of a supplier
+func (s *supplierDocumentService) ListDocuments(supplierID uint) ([]models.SupplierDocument, error) {
+	return s.documentRepo.FindBySupplier(supplierID)
+}
+
+// UpdateDocument updates a supplier document, e.g. after a renewal
+func (s *supplierDocumentService) UpdateDocument(document *models.SupplierDocument) error {
+	existing, err := s.documentRepo.FindByID(document.ID)
+	if err != nil {
+		return err
+	}
+	if err := document.Validate(); err != nil {
+		return err
+	}
+
+	// A renewed document needs a fresh expiry warning
+	if !document.ExpiresAt.Equal(existing.ExpiresAt) {
+		document.ExpiryNotifiedAt = nil
+	} else {
+		document.ExpiryNotifiedAt = existing.ExpiryNotifiedAt
+	}
+	document.CreatedAt = existing.CreatedAt
+
+	return s.documentRepo.Update(document)
+}
+
+// DeleteDocument deletes a supplier document
+func (s *supplierDocumentService) DeleteDocument(id uint) error {
+	if _, err := s.documentRepo.FindByID(id); err != nil {
+		return err
+	}
+	return s.documentRepo.Delete(id)
+}
+
+// GetRequirements gets the document requirements of an operation
+func (s *supplierDocumentService) GetRequirements(operationID uint) ([]models.SupplierDocumentRequirement, error) {
+	return s.requirementRepo.FindByOperation(operationID)
+}
+
+// SetRequirements replaces the document requirements of an operation
+func (s *supplierDocumentService) SetRequirements(operationID uint, requirements []models.SupplierDocumentRequirement) error {
+	seen := make(map[models.SupplierDocumentType]bool)
+	for _, requirement := range requirements {
+		if !requirement.DocumentType.IsValid() {
+			return fmt.Errorf("invalid document type %q", requirement.DocumentType)
+		}
+		if seen[requirement.DocumentType] {
+			return fmt.Errorf("duplicate requirement %q", requirement.DocumentType)
+		}
+		seen[requirement.DocumentType] = true
+	}
+	return s.requirementRepo.ReplaceForOperation(operationID, requirements)
+}
+
+// CheckCompliance reports, for each document required by an operation, whether the supplier
+// holds a valid one at the given time. A zero time is now.
+func (s *supplierDocumentService) CheckCompliance(supplierID, operationID uint, at time.Time) ([]DocumentCompliance, error) {
+	if at.IsZero() {
+		at = s.clock.Now()
+	}
+	requirements, err := s.requirementRepo.FindByOperation(operationID)
+	if err != nil {
+		return nil, err
+	}
+
+	compliance := make([]DocumentCompliance, 0, len(requirements))
+	for _, requirement := range requirements {
+		document, err := s.documentRepo.FindLatestByType(supplierID, requirement.DocumentType)
+		if err != nil {
+			return nil, err
+		}
+
+		entry := DocumentCompliance{
+			DocumentType:  requirement.DocumentType,
+			BlockBookings: requirement.BlockBookings,
+		}
+		if document != nil {
+			expiresAt := document.ExpiresAt
+			entry.ExpiresAt = &expiresAt
+			entry.Valid = document.IsValidAt(at)
+		}
+		compliance = append(compliance, entry)
+	}
+	return compliance, nil
+}
+
+// EnsureCanBook returns ErrSupplierDocumentsLapsed if a document the operation blocks bookings on
+// is missing or will have expired at the appointment time
+func (s *supplierDocumentService) EnsureCanBook(supplierID, operationID uint, at time.Time) error {
+	compliance, err := s.CheckCompliance(supplierID, operationID, at)
+	if err != nil {
+		return err
+	}
+
+	var lapsed []string
+	for _, entry := range compliance {
+		if entry.BlockBookings && !entry.Valid {
+			lapsed = append(lapsed, string(entry.DocumentType))
+		}
+	}
+	if len(lapsed) > 0 {
+		return fmt.Errorf("%w: %s", ErrSupplierDocumentsLapsed, strings.Join(lapsed, ", "))
+	}
+	return nil
+}
+
+// IsSupplierUser reports whether the user is the account of the supplier
+func (s *supplierDocumentService) IsSupplierUser(supplierID, userID uint) bool {
+	supplier, err := s.supplierRepo.FindByID(supplierID)
+	if err != nil {
+		return false
+	}
+	return supplier.UserID == userID
+}
+
+// NotifyExpiring warns suppliers about documents expiring within the configured notice period.
+// Each document is warned about at most once per reminder interval. It returns the number of
+// notifications queued.
+func (s *supplierDocumentService) NotifyExpiring() (int, error) {
+	if s.notificationService == nil {
+		return 0, errors.New("notification service is not configured")
+	}
+
+	notice := 30 * 24 * time.Hour
+	remindEvery := 7 * 24 * time.Hour
+	if s.config != nil && s.config.SupplierDocuments.ExpiryNotice > 0 {
+		notice = s.config.SupplierDocuments.ExpiryNotice
+	}
+	if s.config != nil && s.config.SupplierDocuments.ReminderInterval > 0 {
+		remindEvery = s.config.SupplierDocuments.ReminderInterval
+	}
+
+	now := s.clock.Now()
+	documents, err := s.documentRepo.FindExpiringBefore(now.Add(notice), now.Add(-remindEvery))
+	if err != nil {
+		return 0, err
+	}
+
+	queued := 0
+	for i := range documents {
+		document := &documents[i]
+
+		subject := fmt.Sprintf("Your %s document expires on %s", document.Type, document.ExpiresAt.Format("2006-01-02"))
+		if !document.IsValidAt(now) {
+			subject = fmt.Sprintf("Your %s document expired on %s", document.Type, document.ExpiresAt.Format("2006-01-02"))
+		}
+		body := subject + ". Please upload a renewed document to keep booking deliveries."
+		if document.Number != "" {
+			body = fmt.Sprintf("%s (document number %s). Please upload a renewed document to keep booking deliveries.", subject, document.Number)
+		}
+
+		notification := &models.Notification{
+			Type:          models.NotificationTypeEmail,
+			Status:        models.NotificationStatusPending,
+			Event:         models.EventSupplierDocumentExpiring,
+			RecipientType: models.RecipientSupplier,
+			RecipientID:   document.SupplierID,
+			Subject:       subject,
+			Body:          body,
+		}
+		if err := s.notificationService.EnqueueNotification(notification, QueueSupplierNotifications, 1); err != nil {
+			log.Printf("Failed to enqueue expiry warning for supplier document %d: %v", document.ID, err)
+			continue
+		}
+
+		document.ExpiryNotifiedAt = &now
+		if err := s.documentRepo.Update(document); err != nil {
+			log.Printf("Failed to mark supplier document %d as notified: %v", document.ID, err)
+		}
+		queued++
+	}
+
+	return queued, nil
+}
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+)
+
+// Sync errors
+var (
+	ErrInvalidSyncCursor = errors.New("since must be an RFC 3339 timestamp or a cursor returned by a previous sync")
+	ErrSyncNoProfile     = errors.New("no supplier or employee profile to sync for this user")
+)
+
+// SyncTombstones lists the records deleted since the previous sync, for clients to drop
+type SyncTombstones struct {
+	Appointments  []uint `json:"appointments"`
+	Notifications []uint `json:"notifications"`
+}
+
+// SyncResult is a page of the appointments and notifications a user's app holds that changed
+// since a cursor
+type SyncResult struct {
+	Appointments  []models.Appointment  `json:"-"`
+	Notifications []models.Notification `json:"notifications"`
+	Deleted       SyncTombstones        `json:"deleted"`
+	Cursor        string                `json:"cursor"`   // pass as since to get the changes after this page
+	HasMore       bool                  `json:"has_more"` // more changes are waiting; sync again right away
+	ServerTime    time.Time             `json:"server_time"`
+}
+
+// syncCursor is the position a sync stopped at in each stream of changes
+type syncCursor struct {
+	Appointments  syncCursorPosition `json:"a"`
+	Notifications syncCursorPosition `json:"n"`
+}
+
+// syncCursorPosition is a repository.SyncPosition in cursor form
+type syncCursorPosition struct {
+	ChangedAt int64 `json:"t"` // Unix nanoseconds
+	ID        uint  `json:"i"`
+}
+
+// SyncService defines the interface for the delta sync of the mobile apps' offline copy
+type SyncService interface {
+	Changes(ctx context.Context, user *models.User, since string, wait time.Duration) (*SyncResult, error)
+}
+
+// syncService implements the SyncService interface
+type syncService struct {
+	syncRepo     repository.SyncRepository
+	supplierRepo repository.SupplierRepository
+	employeeRepo repository.EmployeeRepository
+	config       config.SyncConfig
+	clock        clock.Clock
+}
+
+// NewSyncService creates a new sync service
+func NewSyncService(
+	syncRepo repository.SyncRepository,
+	supplierRepo repository.SupplierRepository,
+	employeeRepo repository.EmployeeRepository,
+	config config.SyncConfig,
+	clock clock.Clock,
+) SyncService {
+	return &syncService{
+		syncRepo:     syncRepo,
+		supplierRepo: supplierRepo,
+		employeeRepo: employeeRepo,
+		config:       config,
+		clock:        clock,
+	}
+}
+
+// Changes returns the user's appointments and notifications changed since a timestamp or cursor,
+// with tombstones for the deleted ones. Without since every record is returned and deleted ones
+// are left out. When nothing changed, the request is held for up to wait, capped by the
+// configured maximum, until something does.
+func (s *syncService) Changes(ctx context.Context, user *models.User, since string, wait time.Duration) (*SyncResult, error) {
+	cursor, full, err := parseSyncCursor(since)
+	if err != nil {
+		return nil, err
+	}
+	supplierID, employeeID, recipients, err := s.syncScope(user)
+	if err != nil {
+		return nil, err
+	}
+
+	if wait > s.config.MaxWait {
+		wait = s.config.MaxWait
+	}
+	deadline := s.clock.Now().Add(wait)
+	for {
+		result, err := s.page(cursor, full, supplierID, employeeID, recipients)
+		if err != nil {
+			return nil, err
+		}
+		empty := len(result.Appointments) == 0 && len(result.Notifications) == 0 &&
+			len(result.Deleted.Appointments) == 0 && len(result.Deleted.Notifications) == 0
+		if !empty || s.config.PollInterval <= 0 || !s.clock.Now().Add(s.config.PollInterval).Before(deadline) {
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return result, nil
+		case <-time.After(s.config.PollInterval):
+		}
+	}
+}
+
+// page reads one page of changes after a cursor
+func (s *syncService) page(cursor syncCursor, full bool, supplierID, employeeID uint, recipients []repository.NotificationRecipientKey) (*SyncResult, error) {
+	limit := s.config.PageSize
+	if limit <= 0 {
+		limit = 200
+	}
+	result := &SyncResult{
+		Appointments:  []models.Appointment{},
+		Notifications: []models.Notification{},
+		Deleted:       SyncTombstones{Appointments: []uint{}, Notifications: []uint{}},
+		ServerTime:    s.clock.Now(),
+	}
+
+	// One extra record tells whether another page follows
+	appointments, err := s.syncRepo.AppointmentChanges(supplierID, employeeID, cursor.Appointments.position(), !full, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(appointments) > limit {
+		appointments, result.HasMore = appointments[:limit], true
+	}
+	for _, appointment := range appointments {
+		if appointment.DeletedAt.Valid {
+			result.Deleted.Appointments = append(result.Deleted.Appointments, appointment.ID)
+		} else {
+			result.Appointments = append(result.Appointments, appointment)
+		}
+		cursor.Appointments = newSyncCursorPosition(appointment.UpdatedAt, appointment.DeletedAt.Time, appointment.ID)
+	}
+
+	notifications, err := s.syncRepo.NotificationChanges(recipients, cursor.Notifications.position(), !full, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(notifications) > limit {
+		notifications, result.HasMore = notifications[:limit], true
+	}
+	for _, notification := range notifications {
+		if notification.DeletedAt.Valid {
+			result.Deleted.Notifications = append(result.Deleted.Notifications, notification.ID)
+		} else {
+			result.Notifications = append(result.Notifications, notification)
+		}
+		cursor.Notifications = newSyncCursorPosition(notification.UpdatedAt, notification.DeletedAt.Time, notification.ID)
+	}
+
+	result.Cursor = cursor.encode()
+	return result, nil
+}
+
+// syncScope returns whose appointments and notifications a user syncs: suppliers their own
+// appointments, employees the ones booked with them and admins every appointment, each with the
+// notifications addressed to them
+func (s *syncService) syncScope(user *models.User) (uint, uint, []repository.NotificationRecipientKey, error) {
+	recipients := []repository.NotificationRecipientKey{{Type: models.RecipientWatcher, ID: user.ID}}
+	switch user.Role {
+	case "supplier":
+		supplier, err := s.supplierRepo.FindByUserID(user.ID)
+		if err != nil {
+			return 0, 0, nil, ErrSyncNoProfile
+		}
+		return supplier.ID, 0, append(recipients, repository.NotificationRecipientKey{Type: models.RecipientSupplier, ID: supplier.ID}), nil
+	case "employee":
+		employee, err := s.employeeRepo.FindByUserID(user.ID)
+		if err != nil {
+			return 0, 0, nil, ErrSyncNoProfile
+		}
+		return 0, employee.ID, append(recipients, repository.NotificationRecipientKey{Type: models.RecipientEmployee, ID: employee.ID}), nil
+	default:
+		return 0, 0, append(recipients, repository.NotificationRecipientKey{Type: models.RecipientAdmin, ID: user.ID}), nil
+	}
+}
+
+// parseSyncCursor reads since, which is empty for a full sync, an RFC 3339 timestamp or a cursor
+func parseSyncCursor(since string) (syncCursor, bool, error) {
+	var cursor syncCursor
+	if since == "" {
+		return cursor, true, nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, since); err == nil {
+		position := syncCursorPosition{ChangedAt: t.UnixNano()}
+		return syncCursor{Appointments: position, Notifications: position}, false, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(since)
+	if err != nil {
+		return cursor, false, ErrInvalidSyncCursor
+	}
+	if err := json.Unmarshal(decoded, &cursor); err != nil {
+		return cursor, false, ErrInvalidSyncCursor
+	}
+	return cursor, false, nil
+}
+
+// newSyncCursorPosition returns the position of a record, which changed when it was last updated
+// or deleted, whichever came later
+func newSyncCursorPosition(updatedAt, deletedAt time.Time, id uint) syncCursorPosition {
+	changedAt := updatedAt
+	if deletedAt.After(changedAt) {
+		changedAt = deletedAt
+	}
+	return syncCursorPosition{ChangedAt: changedAt.UnixNano(), ID: id}
+}
+
+// position returns the cursor position as a repository position
+func (p syncCursorPosition) position() repository.SyncPosition {
+	return repository.SyncPosition{ChangedAt: time.Unix(0, p.ChangedAt), ID: p.ID}
+}
+
+// encode returns the cursor in the opaque form clients pass back as since
+func (c syncCursor) encode() string {
+	encoded, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
@@ -0,0 +1,343 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+	"github.com/bernardofernandezz/scheduling-api/pkg/jobs"
+	"github.com/bernardofernandezz/scheduling-api/pkg/leader"
+)
+
+// ErrQueueItemNotLocked is returned when releasing a queue item no worker has locked
+var ErrQueueItemNotLocked = errors.New("queue item is not locked")
+
+// defaultLockedItemsLimit is how many locked queue items are listed when no limit is given
+const defaultLockedItemsLimit = 100
+
+// LockedQueueItem is a queue item a worker has locked for sending
+type LockedQueueItem struct {
+	ID             uint                      `json:"id"`
+	QueueName      string                    `json:"queue_name"`
+	Priority       int                       `json:"priority"`
+	NotificationID uint                      `json:"notification_id"`
+	Status         models.NotificationStatus `json:"status"`
+	ProcessorID    string                    `json:"processor_id"`
+	LockedUntil    time.Time                 `json:"locked_until"`
+	Stuck          bool                      `json:"stuck"` // the lock expired without the item being processed
+}
+
+// JobStatus is a background job as this replica sees it, with its latest scheduled run on any
+// replica
+type JobStatus struct {
+	jobs.Status
+	LastScheduledRun *models.JobRun `json:"last_scheduled_run,omitempty"`
+}
+
+// JobUpdate changes how a background job is scheduled; nil fields are left as they are
+type JobUpdate struct {
+	Schedule *string // cron expression, descriptor or interval; empty restores the configured schedule
+	Enabled  *bool
+}
+
+// SystemService defines the interface for admin introspection of the notification queue,
+// its workers and the background jobs
+type SystemService interface {
+	QueueDepths() ([]repository.QueueDepth, error)
+	Workers() WorkerStats
+	Leader() *leader.Status
+	LockedItems(limit int) ([]LockedQueueItem, error)
+	ReleaseLock(id uint) (*models.NotificationQueue, error)
+	ReleaseStuckLocks() (int64, error)
+	Jobs() ([]JobStatus, error)
+	UpdateJob(name string, update JobUpdate, userID uint) (*JobStatus, error)
+	ApplyJobSchedules() error
+	PruneJobRuns() (int64, error)
+	MissingTemplates() ([]MissingTemplate, error)
+}
+
+// systemService implements the SystemService interface
+type systemService struct {
+	queueRepo           repository.NotificationQueueRepository
+	notificationService NotificationService
+	jobRepo             repository.JobRepository
+	scheduler           *jobs.Scheduler
+	elector             *leader.Elector
+	jobRunRetention     time.Duration
+	clock               clock.Clock
+
+	// Last update time of each schedule override applied to the scheduler
+	appliedMu       sync.Mutex
+	appliedSchedule map[string]time.Time
+}
+
+// NewSystemService creates a new system service
+func NewSystemService(
+	queueRepo repository.NotificationQueueRepository,
+	notificationService NotificationService,
+	jobRepo repository.JobRepository,
+	scheduler *jobs.Scheduler,
+	elector *leader.Elector,
+	jobRunRetention time.Duration,
+	clock clock.Clock,
+) SystemService {
+	return &systemService{
+		queueRepo:           queueRepo,
+		notificationService: notificationService,
+		jobRepo:             jobRepo,
+		scheduler:           scheduler,
+		elector:             elector,
+		jobRunRetention:     jobRunRetention,
+		clock:               clock,
+		appliedSchedule:     make(map[string]time.Time),
+	}
+}
+
+// QueueDepths returns the number of pending and in-flight items per queue and priority
+func (s *systemService) QueueDepths() ([]repository.QueueDepth, error) {
+	return s.queueRepo.CountByQueue()
+}
+
+// Workers returns the notification worker pools of this instance
+func (s *systemService) Workers() WorkerStats {
+	return s.notificationService.WorkerStats()
+}
+
+// Leader returns the leader election as this instance sees it, nil when election is disabled
+func (s *systemService) Leader() *leader.Status {
+	if s.elector == nil {
+		return nil
+	}
+	status := s.elector.Status()
+	return &status
+}
+
+// LockedItems lists the queue items workers have locked, stuck ones first
+func (s *systemService) LockedItems(limit int) ([]LockedQueueItem, error) {
+	if limit <= 0 {
+		limit = defaultLockedItemsLimit
+	}
+
+	items, err := s.queueRepo.FindLocked(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	locked := make([]LockedQueueItem, 0, len(items))
+	for _, item := range items {
+		entry := LockedQueueItem{
+			ID:             item.ID,
+			QueueName:      item.QueueName,
+			Priority:       item.Priority,
+			NotificationID: item.NotificationID,
+			Status:         item.Status,
+		}
+		if item.ProcessorID != nil {
+			entry.ProcessorID = *item.ProcessorID
+		}
+		if item.LockedUntil != nil {
+			entry.LockedUntil = *item.LockedUntil
+			entry.Stuck = item.LockedUntil.Before(now)
+		}
+		locked = append(locked, entry)
+	}
+	return locked, nil
+}
+
+// ReleaseLock puts a locked queue item back in the queue, whether or not its lock expired.
+// Releasing an item a live worker is still sending may deliver it twice.
+func (s *systemService) ReleaseLock(id uint) (*models.NotificationQueue, error) {
+	item, err := s.queueRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if item.Status != models.NotificationStatusSending {
+		return nil, ErrQueueItemNotLocked
+	}
+
+	released, err := s.queueRepo.ReleaseLocks([]uint{id})
+	if err != nil {
+		return nil, err
+	}
+	if released == 0 {
+		// Processed between the lookup and the release
+		return nil, ErrQueueItemNotLocked
+	}
+	return s.queueRepo.GetByID(id)
+}
+
+// ReleaseStuckLocks puts every item whose lock expired back in the queue and returns how many
+func (s *systemService) ReleaseStuckLocks() (int64, error) {
+	return s.queueRepo.ReleaseExpiredLocks(s.clock.Now())
+}
+
+// Jobs returns the state and last run of every background job
+func (s *systemService) Jobs() ([]JobStatus, error) {
+	if s.scheduler == nil {
+		return []JobStatus{}, nil
+	}
+
+	runs, err := s.jobRepo.LastRuns()
+	if err != nil {
+		return nil, err
+	}
+	lastRuns := make(map[string]models.JobRun, len(runs))
+	for _, run := range runs {
+		lastRuns[run.Job] = run
+	}
+
+	statuses := s.scheduler.Statuses()
+	result := make([]JobStatus, 0, len(statuses))
+	for _, status := range statuses {
+		job := JobStatus{Status: status}
+		if run, ok := lastRuns[status.Name]; ok {
+			job.LastScheduledRun = &run
+		}
+		result = append(result, job)
+	}
+	return result, nil
+}
+
+// UpdateJob changes the schedule or enabled flag of a job. The change applies here at once and
+// on the other replicas when they next reload the schedules.
+func (s *systemService) UpdateJob(name string, update JobUpdate, userID uint) (*JobStatus, error) {
+	current, err := s.jobStatus(name)
+	if err != nil {
+		return nil, err
+	}
+
+	override, err := s.jobSchedule(name)
+	if err != nil {
+		return nil, err
+	}
+	if override == nil {
+		override = &models.JobSchedule{Name: name, Enabled: current.Enabled}
+	}
+	if update.Schedule != nil {
+		override.Schedule = *update.Schedule
+	}
+	if update.Enabled != nil {
+		override.Enabled = *update.Enabled
+	}
+	override.UpdatedByID = &userID
+
+	schedule, err := parseJobSchedule(override.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.scheduler.Configure(name, schedule, override.Enabled); err != nil {
+		return nil, err
+	}
+	if err := s.jobRepo.SaveSchedule(override); err != nil {
+		return nil, err
+	}
+	s.markApplied(override)
+
+	return s.jobStatus(name)
+}
+
+// ApplyJobSchedules applies the schedule overrides saved through the admin API that changed
+// since they were last applied. Overrides of jobs this replica doesn't run are ignored.
+func (s *systemService) ApplyJobSchedules() error {
+	overrides, err := s.jobRepo.ListSchedules()
+	if err != nil {
+		return err
+	}
+
+	for i := range overrides {
+		override := &overrides[i]
+		if !s.needsApply(override) {
+			continue
+		}
+		schedule, err := parseJobSchedule(override.Schedule)
+		if err != nil {
+			log.Printf("Ignoring the schedule of job %s: %v", override.Name, err)
+			s.markApplied(override)
+			continue
+		}
+		err = s.scheduler.Configure(override.Name, schedule, override.Enabled)
+		if err != nil && !errors.Is(err, jobs.ErrJobNotFound) {
+			log.Printf("Failed to apply the schedule of job %s: %v", override.Name, err)
+		}
+		s.markApplied(override)
+	}
+	return nil
+}
+
+// PruneJobRuns deletes the recorded job runs older than the retention period
+func (s *systemService) PruneJobRuns() (int64, error) {
+	if s.jobRunRetention <= 0 {
+		return 0, nil
+	}
+	return s.jobRepo.PruneRuns(s.clock.Now().Add(-s.jobRunRetention))
+}
+
+// jobStatus returns the status of one job
+func (s *systemService) jobStatus(name string) (*JobStatus, error) {
+	statuses, err := s.Jobs()
+	if err != nil {
+		return nil, err
+	}
+	for i := range statuses {
+		if statuses[i].Name == name {
+			return &statuses[i], nil
+		}
+	}
+	return nil, jobs.ErrJobNotFound
+}
+
+// jobSchedule returns the schedule override of a job, nil if it has none
+func (s *systemService) jobSchedule(name string) (*models.JobSchedule, error) {
+	overrides, err := s.jobRepo.ListSchedules()
+	if err != nil {
+		return nil, err
+	}
+	for i := range overrides {
+		if overrides[i].Name == name {
+			return &overrides[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// needsApply reports whether an override changed since it was last applied
+func (s *systemService) needsApply(override *models.JobSchedule) bool {
+	s.appliedMu.Lock()
+	defer s.appliedMu.Unlock()
+
+	applied, ok := s.appliedSchedule[override.Name]
+	return !ok || !applied.Equal(override.UpdatedAt)
+}
+
+// markApplied remembers the version of an override the scheduler runs with
+func (s *systemService) markApplied(override *models.JobSchedule) {
+	s.appliedMu.Lock()
+	defer s.appliedMu.Unlock()
+
+	s.appliedSchedule[override.Name] = override.UpdatedAt
+}
+
+// parseJobSchedule parses a schedule override; an empty one is nil, restoring the configured
+// schedule
+func parseJobSchedule(spec string) (jobs.Schedule, error) {
+	if spec == "" {
+		return nil, nil
+	}
+	schedule, err := jobs.ParseSchedule(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule: %w", err)
+	}
+	return schedule, nil
+}
+
+// MissingTemplates reports the notification combinations without a template and how often
+// the fallback was used for them
+func (s *systemService) MissingTemplates() ([]MissingTemplate, error) {
+	return s.notificationService.MissingTemplates()
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+)
+
+// DefaultTemplateVersion is the version of the built-in notification templates. Bump it whenever
+// their content changes so installed system templates are updated on the next start.
+const DefaultTemplateVersion = 2
+
+// defaultTemplateContent is the wording of the built-in templates of an event
+type defaultTemplateContent struct {
+	subject   string
+	message   string   // one or two sentences, shared by every channel
+	variables []string // template data the subject and message use
+	linked    bool     // whether the notification links to the appointment
+}
+
+// defaultTemplateContents holds the wording of the built-in templates per event
+var defaultTemplateContents = map[models.NotificationEvent]defaultTemplateContent{
+	models.EventAppointmentCreated: {
+		subject:   "Appointment {{.reference}} scheduled",
+		message:   `Appointment {{.reference}} was scheduled for {{formatDateTime .scheduled_start "long"}}, delivering {{formatQuantity .quantity_to_deliver}} {{pluralize .quantity_to_deliver "unit" "units"}}.`,
+		variables: []string{"reference", "appointment_id", "scheduled_start", "quantity_to_deliver"},
+		linked:    true,
+	},
+	models.EventAppointmentUpdated: {
+		subject:   "Appointment {{.reference}} updated",
+		message:   `Appointment {{.reference}} was updated. It is scheduled for {{formatDateTime .scheduled_start "long"}} and is {{.status}}.`,
+		variables: []string{"reference", "appointment_id", "scheduled_start", "status"},
+		linked:    true,
+	},
+	models.EventAppointmentCancelled: {
+		subject:   "Appointment {{.reference}} cancelled",
+		message:   `Appointment {{.reference}} on {{formatDateTime .scheduled_start "long"}} was cancelled.{{if .cancellation_reason}} Reason: {{.cancellation_reason}}.{{end}}`,
+		variables: []string{"reference", "appointment_id", "scheduled_start", "cancellation_reason"},
+		linked:    true,
+	},
+	models.EventAppointmentConfirmed: {
+		subject:   "Appointment {{.reference}} confirmed",
+		message:   `Appointment {{.reference}} on {{formatDateTime .scheduled_start "long"}} is confirmed.`,
+		variables: []string{"reference", "appointment_id", "scheduled_start"},
+		linked:    true,
+	},
+	models.EventAppointmentCompleted: {
+		subject:   "Appointment {{.reference}} completed",
+		message:   `Appointment {{.reference}} was completed. Thank you.`,
+		variables: []string{"reference", "appointment_id"},
+		linked:    true,
+	},
+	models.EventAppointmentReminder: {
+		subject:   `Reminder: appointment {{.reference}} on {{formatDate .scheduled_start "medium"}}`,
+		message:   `Appointment {{.reference}} is coming up on {{formatDateTime .scheduled_start "long"}}.`,
+		variables: []string{"reference", "appointment_id", "scheduled_start"},
+		linked:    true,
+	},
+	models.EventSupplierDocumentExpiring: {
+		subject:   "A compliance document is expiring",
+		message:   `{{default "A compliance document" .document_name}} expires {{if .expires_at}}on {{formatDate .expires_at "long"}}{{else}}soon{{end}}. Upload a renewed copy to keep booking appointments.`,
+		variables: []string{"document_name", "expires_at"},
+	},
+	models.EventAppointmentArriving: {
+		subject:   "Appointment {{.reference}} is arriving",
+		message:   `The driver for appointment {{.reference}} is approaching the operation.`,
+		variables: []string{"reference", "appointment_id"},
+		linked:    true,
+	},
+	models.EventAppointmentDelayed: {
+		subject:   "Appointment {{.reference}} is delayed",
+		message:   `Appointment {{.reference}} scheduled for {{formatDateTime .scheduled_start "long"}} is running late.{{if .eta}} New ETA: {{formatTime .eta}}.{{end}}`,
+		variables: []string{"reference", "appointment_id", "scheduled_start", "eta"},
+		linked:    true,
+	},
+	models.EventFeedbackRequested: {
+		subject:   "How did appointment {{.reference}} go?",
+		message:   `Appointment {{.reference}} is complete. Rate it from 1 to 5 and tell us what could be better.`,
+		variables: []string{"reference", "appointment_id"},
+		linked:    true,
+	},
+	models.EventAppointmentOverdue: {
+		subject:   "Appointment {{.reference}} needs to be closed",
+		message:   `Appointment {{.reference}}, scheduled for {{formatDateTime .scheduled_start "long"}}, is still confirmed after it ended.{{if eq .status "completed"}} It was marked completed automatically; undo it if that is wrong.{{else}} Complete or cancel it.{{end}}`,
+		variables: []string{"reference", "appointment_id", "scheduled_start", "status"},
+		linked:    true,
+	},
+	models.EventAppointmentExpiring: {
+		subject:   "Appointment {{.reference}} expires unless confirmed",
+		message:   `Appointment {{.reference}}, scheduled for {{formatDateTime .scheduled_start "long"}}, is still pending. Unless it is confirmed before {{formatDateTime .expires_at "long"}} it is cancelled and its slot released.`,
+		variables: []string{"reference", "appointment_id", "scheduled_start", "expires_at"},
+		linked:    true,
+	},
+	models.EventBookingInvitation: {
+		subject:   "Book your appointment at {{.operation_name}}",
+		message:   `You are invited to book an appointment at {{.operation_name}}. The link works until {{formatDateTime .expires_at "long"}}: {{.booking_link}}`,
+		variables: []string{"operation_name", "expires_at", "booking_link"},
+	},
+	models.EventFeeCharged: {
+		subject:   "Fee charged for appointment {{.reference}}",
+		message:   `A {{.fee_kind}} fee of {{.amount}} {{.currency}} was charged for appointment {{.reference}} on {{formatDateTime .scheduled_start "long"}}. You can dispute it from the appointment.`,
+		variables: []string{"reference", "appointment_id", "scheduled_start", "fee_kind", "amount", "currency"},
+		linked:    true,
+	},
+	models.EventWaitlistSlotOpened: {
+		subject:   "A slot opened at {{.operation_name}}",
+		message:   `A slot opened at {{.operation_name}} on {{formatDateTime .scheduled_start "long"}}, the day you are waitlisted for. Book it before it is taken: {{.booking_link}}`,
+		variables: []string{"operation_name", "scheduled_start", "scheduled_end", "booking_link"},
+	},
+	models.EventSlotOpened: {
+		subject:   "A slot opened at {{.operation_name}}",
+		message:   `Room opened at {{.operation_name}} on {{formatDate .window_start "long"}} between {{formatTime .window_start}} and {{formatTime .window_end}}. Other suppliers were told too and it goes to whoever books first: {{.booking_link}}`,
+		variables: []string{"operation_name", "window_start", "window_end", "booking_link"},
+	},
+	models.EventShiftHandover: {
+		subject:   "Shift handover at {{.operation_name}}",
+		message:   `{{.author_name}} left a handover note at {{.operation_name}} for {{.date}}: {{.note}}`,
+		variables: []string{"operation_name", "date", "author_name", "note"},
+	},
+	models.EventCapacityWarning: {
+		subject:   `{{.operation_name}} is almost full on {{formatDate .window_start "long"}}`,
+		message:   `Appointment {{.reference}} takes {{.limit}} at {{.operation_name}} to {{.booked}} of {{.capacity}} between {{formatTime .window_start}} and {{formatTime .window_end}} on {{formatDate .window_start "long"}}.`,
+		variables: []string{"reference", "appointment_id", "operation_name", "limit", "booked", "capacity", "window_start", "window_end"},
+		linked:    true,
+	},
+}
+
+// defaultTemplateEvents lists the events built-in templates are installed for, in install order
+var defaultTemplateEvents = []models.NotificationEvent{
+	models.EventAppointmentCreated,
+	models.EventAppointmentUpdated,
+	models.EventAppointmentCancelled,
+	models.EventAppointmentConfirmed,
+	models.EventAppointmentCompleted,
+	models.EventAppointmentReminder,
+	models.EventSupplierDocumentExpiring,
+	models.EventAppointmentArriving,
+	models.EventAppointmentDelayed,
+	models.EventFeedbackRequested,
+	models.EventAppointmentOverdue,
+	models.EventAppointmentExpiring,
+	models.EventBookingInvitation,
+	models.EventFeeCharged,
+	models.EventWaitlistSlotOpened,
+	models.EventSlotOpened,
+	models.EventShiftHandover,
+	models.EventCapacityWarning,
+}
+
+// defaultTemplateRecipients and defaultTemplateChannels are the recipients and channels
+// built-in templates are installed for
+var (
+	defaultTemplateRecipients = []models.NotificationRecipientType{models.RecipientSupplier, models.RecipientEmployee, models.RecipientAdmin}
+	defaultTemplateChannels   = []models.NotificationType{models.NotificationTypeEmail, models.NotificationTypeSMS, models.NotificationTypePush}
+)
+
+// TemplateSeedResult counts what seeding the built-in templates did
+type TemplateSeedResult struct {
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Kept    int `json:"kept"` // up to date, customized or replaced by a custom template
+}
+
+// DefaultNotificationTemplates returns a built-in template for every event, recipient and channel
+func DefaultNotificationTemplates() []models.NotificationTemplate {
+	templates := make([]models.NotificationTemplate, 0, len(defaultTemplateEvents)*len(defaultTemplateRecipients)*len(defaultTemplateChannels))
+	for _, event := range defaultTemplateEvents {
+		content := defaultTemplateContents[event]
+		variables := content.variables
+		if content.linked {
+			variables = append(append([]string{}, variables...), "operation_id")
+		}
+		variablesJSON, _ := json.Marshal(variables)
+
+		for _, recipient := range defaultTemplateRecipients {
+			for _, channel := range defaultTemplateChannels {
+				template := models.NotificationTemplate{
+					Name:          fmt.Sprintf("%s_%s_%s", event, recipient, channel),
+					Description:   fmt.Sprintf("Built-in %s to the %s when %s", channel, recipient, strings.ReplaceAll(string(event), "_", " ")),
+					Subject:       content.subject,
+					BodyText:      content.message,
+					Type:          channel,
+					Event:         event,
+					RecipientType: recipient,
+					IsActive:      true,
+					IsSystem:      true,
+					SystemVersion: DefaultTemplateVersion,
+					Variables:     string(variablesJSON),
+				}
+				if content.linked {
+					template.BodyText += ` {{link "appointments" .appointment_id}}`
+				}
+				if channel == models.NotificationTypeEmail {
+					template.BodyHTML = defaultTemplateHTML(content)
+				}
+				templates = append(templates, template)
+			}
+		}
+	}
+	return templates
+}
+
+// defaultTemplateHTML returns the HTML email body of a built-in template
+func defaultTemplateHTML(content defaultTemplateContent) string {
+	body := "<p>" + content.message + "</p>"
+	if content.linked {
+		body += `<p><a href="{{link "appointments" .appointment_id}}">View appointment</a></p>`
+	}
+	return body
+}
+
+// SeedNotificationTemplates installs the built-in templates a fresh install lacks and updates
+// system templates installed by an older release. Templates saved through the config import and
+// combinations already covered by a custom template are left alone.
+func SeedNotificationTemplates(templateRepo repository.NotificationTemplateRepository) (TemplateSeedResult, error) {
+	var result TemplateSeedResult
+
+	for _, template := range DefaultNotificationTemplates() {
+		template := template
+
+		existing, err := templateRepo.GetByName(template.Name)
+		if err == nil {
+			if !existing.IsSystem || existing.SystemVersion >= DefaultTemplateVersion {
+				result.Kept++
+				continue
+			}
+			existing.Description = template.Description
+			existing.Subject = template.Subject
+			existing.BodyText = template.BodyText
+			existing.BodyHTML = template.BodyHTML
+			existing.Variables = template.Variables
+			existing.SystemVersion = DefaultTemplateVersion
+			if err := templateRepo.Update(existing); err != nil {
+				return result, fmt.Errorf("failed to update template %s: %w", template.Name, err)
+			}
+			result.Updated++
+			continue
+		}
+
+		// A custom template under another name already covers this combination
+		if custom, err := templateRepo.GetByEvent(template.Event, template.RecipientType, template.Type); err == nil && custom != nil {
+			result.Kept++
+			continue
+		}
+
+		if err := templateRepo.Create(&template); err != nil {
+			return result, fmt.Errorf("failed to create template %s: %w", template.Name, err)
+		}
+		result.Created++
+	}
+
+	return result, nil
+}
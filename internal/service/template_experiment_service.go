@@ -0,0 +1,246 @@
+package service
+
+import (
+	"errors"
+	"math"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+)
+
+// Template experiment errors
+var (
+	ErrExperimentNotFound   = errors.New("template experiment not found")
+	ErrExperimentRunning    = errors.New("an experiment is already running for this event, recipient type and notification type")
+	ErrExperimentStopped    = errors.New("template experiment is already stopped")
+	ErrExperimentTemplate   = errors.New("experiment template not found")
+	ErrExperimentMismatch   = errors.New("experiment templates must be for the experiment's event, recipient type and notification type")
+	ErrInvalidTrackingToken = errors.New("invalid tracking token")
+)
+
+// experimentSignificance is the p-value under which a difference between variants is reported as
+// significant
+const experimentSignificance = 0.05
+
+// TemplateVariantReport is what became of the notifications sent with one variant
+type TemplateVariantReport struct {
+	repository.TemplateVariantResults
+	TemplateID   uint    `json:"template_id"`
+	TemplateName string  `json:"template_name"`
+	OpenRate     float64 `json:"open_rate"`    // opened emails per email sent
+	ConfirmRate  float64 `json:"confirm_rate"` // confirmed appointments per notification sent
+	NoShowRate   float64 `json:"no_show_rate"` // no-shows per appointment that came or didn't
+}
+
+// VariantComparison compares a rate of the two variants with a two-proportion z-test
+type VariantComparison struct {
+	Metric      string  `json:"metric"`
+	A           float64 `json:"a"`
+	B           float64 `json:"b"`
+	Difference  float64 `json:"difference"` // B minus A
+	PValue      float64 `json:"p_value"`
+	Significant bool    `json:"significant"` // the difference is unlikely to be chance (p < 0.05)
+}
+
+// TemplateExperimentReport is an experiment with the results of its variants side by side
+type TemplateExperimentReport struct {
+	Experiment models.TemplateExperiment `json:"experiment"`
+	Variants   []TemplateVariantReport   `json:"variants"`
+	Comparison []VariantComparison       `json:"comparison"`
+}
+
+// TemplateExperimentService defines the interface for A/B tests of notification templates: the
+// notifications of an event are split between two templates and their open, confirmation and
+// no-show rates compared, to learn which copy works best
+type TemplateExperimentService interface {
+	Create(experiment *models.TemplateExperiment) (*models.TemplateExperiment, error)
+	List() ([]models.TemplateExperiment, error)
+	Get(id uint) (*TemplateExperimentReport, error)
+	Stop(id uint) (*TemplateExperimentReport, error)
+	RecordOpen(token string) error
+}
+
+// templateExperimentService implements the TemplateExperimentService interface
+type templateExperimentService struct {
+	experimentRepo repository.TemplateExperimentRepository
+	templateRepo   repository.NotificationTemplateRepository
+	config         *config.Config
+	clock          clock.Clock
+}
+
+// NewTemplateExperimentService creates a new template experiment service
+func NewTemplateExperimentService(
+	experimentRepo repository.TemplateExperimentRepository,
+	templateRepo repository.NotificationTemplateRepository,
+	config *config.Config,
+	clock clock.Clock,
+) TemplateExperimentService {
+	return &templateExperimentService{
+		experimentRepo: experimentRepo,
+		templateRepo:   templateRepo,
+		config:         config,
+		clock:          clock,
+	}
+}
+
+// Create starts an experiment. Both templates must be for the experiment's event, recipient type
+// and notification type, and no other experiment may be running for them.
+func (s *templateExperimentService) Create(experiment *models.TemplateExperiment) (*models.TemplateExperiment, error) {
+	if err := experiment.Validate(); err != nil {
+		return nil, err
+	}
+	for _, templateID := range []uint{experiment.VariantATemplateID, experiment.VariantBTemplateID} {
+		template, err := s.templateRepo.GetByID(templateID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotificationTemplateNotFound) {
+				return nil, ErrExperimentTemplate
+			}
+			return nil, err
+		}
+		if template.Event != experiment.Event || template.RecipientType != experiment.RecipientType || template.Type != experiment.Type {
+			return nil, ErrExperimentMismatch
+		}
+	}
+
+	if _, err := s.experimentRepo.FindRunning(experiment.Event, experiment.RecipientType, experiment.Type); err == nil {
+		return nil, ErrExperimentRunning
+	} else if !errors.Is(err, repository.ErrTemplateExperimentNotFound) {
+		return nil, err
+	}
+
+	experiment.ID = 0
+	experiment.Status = models.ExperimentRunning
+	experiment.StartedAt = s.clock.Now()
+	experiment.EndedAt = nil
+	if err := s.experimentRepo.Create(experiment); err != nil {
+		return nil, err
+	}
+	return experiment, nil
+}
+
+// List returns every experiment, the most recent first
+func (s *templateExperimentService) List() ([]models.TemplateExperiment, error) {
+	return s.experimentRepo.FindAll()
+}
+
+// Get returns an experiment with the results of its variants
+func (s *templateExperimentService) Get(id uint) (*TemplateExperimentReport, error) {
+	experiment, err := s.find(id)
+	if err != nil {
+		return nil, err
+	}
+	return s.report(experiment)
+}
+
+// Stop stops an experiment; the event's notifications go back to its template. Notifications
+// already sent keep counting towards the results.
+func (s *templateExperimentService) Stop(id uint) (*TemplateExperimentReport, error) {
+	experiment, err := s.find(id)
+	if err != nil {
+		return nil, err
+	}
+	if experiment.Status != models.ExperimentRunning {
+		return nil, ErrExperimentStopped
+	}
+
+	now := s.clock.Now()
+	experiment.Status = models.ExperimentStopped
+	experiment.EndedAt = &now
+	if err := s.experimentRepo.Update(experiment); err != nil {
+		return nil, err
+	}
+	return s.report(experiment)
+}
+
+// RecordOpen records that the email a tracking image token was issued for was opened
+func (s *templateExperimentService) RecordOpen(token string) error {
+	notificationID, ok := parseOpenTrackingToken(s.config.Auth.JWTSecret, token)
+	if !ok {
+		return ErrInvalidTrackingToken
+	}
+	return s.experimentRepo.MarkOpened(notificationID, s.clock.Now())
+}
+
+// find loads an experiment
+func (s *templateExperimentService) find(id uint) (*models.TemplateExperiment, error) {
+	experiment, err := s.experimentRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrTemplateExperimentNotFound) {
+			return nil, ErrExperimentNotFound
+		}
+		return nil, err
+	}
+	return experiment, nil
+}
+
+// report counts the results of an experiment's variants and compares their rates
+func (s *templateExperimentService) report(experiment *models.TemplateExperiment) (*TemplateExperimentReport, error) {
+	results, err := s.experimentRepo.Results(experiment.ID)
+	if err != nil {
+		return nil, err
+	}
+	byVariant := make(map[string]repository.TemplateVariantResults, len(results))
+	for _, result := range results {
+		byVariant[result.Variant] = result
+	}
+
+	report := &TemplateExperimentReport{Experiment: *experiment}
+	for _, variant := range []string{models.VariantA, models.VariantB} {
+		result := byVariant[variant]
+		result.Variant = variant
+		variantReport := TemplateVariantReport{
+			TemplateVariantResults: result,
+			TemplateID:             experiment.TemplateID(variant),
+			OpenRate:               variantRate(result.Opened, result.EmailsSent),
+			ConfirmRate:            variantRate(result.Confirmed, result.Sent),
+			NoShowRate:             variantRate(result.NoShows, result.Completed+result.NoShows),
+		}
+		if template, err := s.templateRepo.GetByID(variantReport.TemplateID); err == nil {
+			variantReport.TemplateName = template.Name
+		}
+		report.Variants = append(report.Variants, variantReport)
+	}
+
+	a, b := byVariant[models.VariantA], byVariant[models.VariantB]
+	report.Comparison = []VariantComparison{
+		compareVariants("open_rate", a.Opened, a.EmailsSent, b.Opened, b.EmailsSent),
+		compareVariants("confirm_rate", a.Confirmed, a.Sent, b.Confirmed, b.Sent),
+		compareVariants("no_show_rate", a.NoShows, a.Completed+a.NoShows, b.NoShows, b.Completed+b.NoShows),
+	}
+	return report, nil
+}
+
+// variantRate returns count out of total, 0 when there's nothing to count
+func variantRate(count, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(count) / float64(total)
+}
+
+// compareVariants compares the proportions of the two variants with a two-sided two-proportion
+// z-test. Without samples on both sides, or without any variation, the p-value is 1.
+func compareVariants(metric string, countA, totalA, countB, totalB int64) VariantComparison {
+	comparison := VariantComparison{
+		Metric: metric,
+		A:      variantRate(countA, totalA),
+		B:      variantRate(countB, totalB),
+		PValue: 1,
+	}
+	comparison.Difference = comparison.B - comparison.A
+	if totalA == 0 || totalB == 0 {
+		return comparison
+	}
+
+	pooled := float64(countA+countB) / float64(totalA+totalB)
+	standardError := math.Sqrt(pooled * (1 - pooled) * (1/float64(totalA) + 1/float64(totalB)))
+	if standardError == 0 {
+		return comparison
+	}
+	z := comparison.Difference / standardError
+	comparison.PValue = math.Erfc(math.Abs(z) / math.Sqrt2)
+	comparison.Significant = comparison.PValue < experimentSignificance
+	return comparison
+}
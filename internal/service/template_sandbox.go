@@ -0,0 +1,201 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/pkg/i18n"
+)
+
+// Defaults for template rendering limits
+const (
+	defaultTemplateTimeout  = 2 * time.Second
+	defaultTemplateMaxBytes = 64 * 1024
+)
+
+// ErrTemplateTimeout is returned when a template takes too long to render
+var ErrTemplateTimeout = errors.New("template rendering timed out")
+
+// ErrTemplateOutputTooLarge is returned when a template produces more output than allowed
+var ErrTemplateOutputTooLarge = errors.New("template output exceeds size limit")
+
+// templateExecutor is implemented by both text and HTML templates
+type templateExecutor interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+// templateFuncs returns the curated helper functions available to notification templates:
+//
+//	{{currency .amount "BRL"}}
+//	{{pluralize .quantity_to_deliver "pallet" "pallets"}}
+//	{{upper .status}} {{lower .status}}
+//	{{default "-" .notes}}
+//	{{link "appointments" .appointment_id}}
+//
+// plus the locale-aware formatting helpers of the formatter. Links are built on baseURL,
+// the portal of the operation the notification is about.
+func (s *notificationService) templateFuncs(formatter *i18n.Formatter, baseURL string) map[string]interface{} {
+	funcs := formatter.FuncMap()
+
+	funcs["currency"] = funcs["formatCurrency"]
+	funcs["upper"] = strings.ToUpper
+	funcs["lower"] = strings.ToLower
+	funcs["trim"] = strings.TrimSpace
+	funcs["pluralize"] = func(count interface{}, singular, plural string) (string, error) {
+		number, err := i18n.ToFloat(count)
+		if err != nil {
+			return "", err
+		}
+		if number == 1 {
+			return singular, nil
+		}
+		return plural, nil
+	}
+	funcs["default"] = func(fallback, value interface{}) interface{} {
+		if isEmptyTemplateValue(value) {
+			return fallback
+		}
+		return value
+	}
+	funcs["link"] = func(segments ...interface{}) string {
+		return buildLink(baseURL, segments...)
+	}
+
+	return funcs
+}
+
+// linkBaseURL returns the portal base URL for the operation referenced by the template data,
+// falling back to the configured default portal
+func (s *notificationService) linkBaseURL(data map[string]interface{}) string {
+	fallback := ""
+	if s.config != nil && s.config.Notification != nil {
+		fallback = s.config.Notification.LinkBaseURL
+	}
+
+	// Template data round-trips through JSON, so IDs arrive as float64
+	id, err := i18n.ToFloat(data["operation_id"])
+	if err != nil || id <= 0 {
+		return fallback
+	}
+	return portalURL(s.operationRepo, uint(id), fallback)
+}
+
+// buildLink joins path segments onto a portal base URL, escaping each segment
+func buildLink(base string, segments ...interface{}) string {
+	parts := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		value := fmt.Sprint(segment)
+		// IDs decoded from JSON template data are floats; print whole numbers without decimals
+		if number, ok := segment.(float64); ok && number == float64(int64(number)) {
+			value = fmt.Sprint(int64(number))
+		}
+		parts = append(parts, url.PathEscape(value))
+	}
+
+	return strings.TrimRight(base, "/") + "/" + strings.Join(parts, "/")
+}
+
+// isEmptyTemplateValue reports whether a template value should be replaced by a default
+func isEmptyTemplateValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case bool:
+		return !v
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	number, err := i18n.ToFloat(value)
+	return err == nil && number == 0
+}
+
+// executeSandboxed renders a template with a deadline and an output size limit so a bad
+// template cannot hang a worker or produce an unbounded message. When the deadline passes,
+// the output writer starts failing, which aborts rendering at the next write.
+func (s *notificationService) executeSandboxed(tmpl templateExecutor, data interface{}) (string, error) {
+	timeout := defaultTemplateTimeout
+	maxBytes := defaultTemplateMaxBytes
+	if s.config != nil && s.config.Notification != nil {
+		if s.config.Notification.TemplateTimeout > 0 {
+			timeout = s.config.Notification.TemplateTimeout
+		}
+		if s.config.Notification.TemplateMaxBytes > 0 {
+			maxBytes = s.config.Notification.TemplateMaxBytes
+		}
+	}
+
+	output := &sandboxWriter{limit: maxBytes}
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("template panicked: %v", r)
+			}
+		}()
+		done <- tmpl.Execute(output, data)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			if errors.Is(err, ErrTemplateOutputTooLarge) {
+				return "", ErrTemplateOutputTooLarge
+			}
+			return "", err
+		}
+		return output.String(), nil
+	case <-timer.C:
+		output.abort()
+		return "", ErrTemplateTimeout
+	}
+}
+
+// sandboxWriter buffers template output up to a limit and can be aborted
+type sandboxWriter struct {
+	mu      sync.Mutex
+	buf     strings.Builder
+	limit   int
+	aborted bool
+}
+
+// Write implements io.Writer
+func (w *sandboxWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.aborted {
+		return 0, ErrTemplateTimeout
+	}
+	if w.buf.Len()+len(p) > w.limit {
+		return 0, ErrTemplateOutputTooLarge
+	}
+	return w.buf.Write(p)
+}
+
+// String returns the rendered output
+func (w *sandboxWriter) String() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.buf.String()
+}
+
+// abort makes every further write fail
+func (w *sandboxWriter) abort() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.aborted = true
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+)
+
+// API usage errors
+var (
+	ErrInvalidUsageGroup    = errors.New("invalid usage grouping: use consumer, route or consumer_route")
+	ErrInvalidUsageConsumer = errors.New("invalid consumer type: use user, partner or anonymous")
+)
+
+const (
+	// defaultUsagePeriod is how far back the usage summary goes without a start date
+	defaultUsagePeriod = 24 * time.Hour
+
+	// maxUsageRows is the most rows the usage summary returns
+	maxUsageRows = 1000
+)
+
+// UsageSample is one API request counted for usage analytics
+type UsageSample struct {
+	ConsumerType string // models.UsageConsumerUser, UsageConsumerPartner or UsageConsumerAnonymous
+	ConsumerID   uint
+	Method       string
+	Route        string // route template the request matched
+	Status       int
+	Latency      time.Duration
+	At           time.Time
+}
+
+// UsageService defines the interface for API usage analytics
+type UsageService interface {
+	Record(sample UsageSample)
+	Flush() (int, error)
+	Summary(filters repository.UsageFilters) ([]repository.UsageRow, error)
+	Prune() (int64, error)
+}
+
+// usageKey identifies the hourly row a request is counted in
+type usageKey struct {
+	hour         time.Time
+	consumerType string
+	consumerID   uint
+	method       string
+	route        string
+}
+
+// usageService implements the UsageService interface
+type usageService struct {
+	usageRepo repository.UsageRepository
+	config    config.UsageConfig
+	clock     clock.Clock
+
+	mu      sync.Mutex
+	pending map[usageKey]*models.APIUsage // counted since the last flush
+}
+
+// NewUsageService creates a new API usage service
+func NewUsageService(
+	usageRepo repository.UsageRepository,
+	config config.UsageConfig,
+	clock clock.Clock,
+) UsageService {
+	return &usageService{
+		usageRepo: usageRepo,
+		config:    config,
+		clock:     clock,
+		pending:   make(map[usageKey]*models.APIUsage),
+	}
+}
+
+// Record counts a request. It only touches memory, so it is cheap enough to call on every request;
+// the counts reach the database on the next flush.
+func (s *usageService) Record(sample UsageSample) {
+	key := usageKey{
+		hour:         sample.At.UTC().Truncate(time.Hour),
+		consumerType: sample.ConsumerType,
+		consumerID:   sample.ConsumerID,
+		method:       sample.Method,
+		route:        sample.Route,
+	}
+	latency := sample.Latency.Milliseconds()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	usage, ok := s.pending[key]
+	if !ok {
+		usage = &models.APIUsage{
+			Hour:         key.hour,
+			ConsumerType: key.consumerType,
+			ConsumerID:   key.consumerID,
+			Method:       key.method,
+			Route:        key.route,
+		}
+		s.pending[key] = usage
+	}
+	usage.Requests++
+	switch {
+	case sample.Status >= 500:
+		usage.ServerErrors++
+	case sample.Status >= 400:
+		usage.ClientErrors++
+	}
+	usage.LatencyMillis += latency
+	if latency > usage.MaxLatencyMillis {
+		usage.MaxLatencyMillis = latency
+	}
+}
+
+// Flush adds the requests counted since the last flush to their hourly rows and returns how many
+// rows were written. Counts that fail to be written are kept for the next flush.
+func (s *usageService) Flush() (int, error) {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[usageKey]*models.APIUsage)
+	s.mu.Unlock()
+
+	if len(pending) == 0 {
+		return 0, nil
+	}
+	rows := make([]models.APIUsage, 0, len(pending))
+	for _, usage := range pending {
+		rows = append(rows, *usage)
+	}
+
+	if err := s.usageRepo.Add(rows); err != nil {
+		s.restore(pending)
+		return 0, err
+	}
+	return len(rows), nil
+}
+
+// Summary sums the API usage of a period per consumer, route or both, the busiest first. Without
+// a start date it covers the last day. Usage counted since a replica's last flush isn't included.
+func (s *usageService) Summary(filters repository.UsageFilters) ([]repository.UsageRow, error) {
+	switch filters.GroupBy {
+	case "":
+		filters.GroupBy = repository.UsageGroupConsumerRoute
+	case repository.UsageGroupConsumer, repository.UsageGroupRoute, repository.UsageGroupConsumerRoute:
+	default:
+		return nil, ErrInvalidUsageGroup
+	}
+	switch filters.ConsumerType {
+	case "", models.UsageConsumerUser, models.UsageConsumerPartner, models.UsageConsumerAnonymous:
+	default:
+		return nil, ErrInvalidUsageConsumer
+	}
+	if filters.StartDate == nil {
+		start := s.clock.Now().Add(-defaultUsagePeriod)
+		filters.StartDate = &start
+	}
+	if filters.Limit <= 0 || filters.Limit > maxUsageRows {
+		filters.Limit = maxUsageRows
+	}
+
+	return s.usageRepo.Summarize(filters)
+}
+
+// Prune deletes the hourly usage older than the retention
+func (s *usageService) Prune() (int64, error) {
+	if s.config.Retention <= 0 {
+		return 0, nil
+	}
+	deleted, err := s.usageRepo.DeleteBefore(s.clock.Now().Add(-s.config.Retention))
+	if err != nil {
+		return 0, err
+	}
+	if deleted > 0 {
+		log.Printf("Pruned %d hourly API usage rows", deleted)
+	}
+	return deleted, nil
+}
+
+// restore puts back counts a flush failed to write, adding them to those counted meanwhile
+func (s *usageService) restore(failed map[usageKey]*models.APIUsage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, usage := range failed {
+		current, ok := s.pending[key]
+		if !ok {
+			s.pending[key] = usage
+			continue
+		}
+		current.Requests += usage.Requests
+		current.ClientErrors += usage.ClientErrors
+		current.ServerErrors += usage.ServerErrors
+		current.LatencyMillis += usage.LatencyMillis
+		if usage.MaxLatencyMillis > current.MaxLatencyMillis {
+			current.MaxLatencyMillis = usage.MaxLatencyMillis
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+)
+
+// defaultHiddenFields are hidden unless a rule shows them: suppliers don't see employees' phone
+// numbers
+var defaultHiddenFields = map[string][]string{
+	"supplier": {models.FieldEmployeePhone},
+}
+
+// VisibilityPolicy tells which response fields each role may see
+type VisibilityPolicy struct {
+	hidden map[string]map[string]bool // role -> field -> hidden
+}
+
+// DefaultVisibilityPolicy returns the policy that applies when no rules are configured
+func DefaultVisibilityPolicy() *VisibilityPolicy {
+	policy := &VisibilityPolicy{hidden: map[string]map[string]bool{}}
+	for role, fields := range defaultHiddenFields {
+		for _, field := range fields {
+			policy.set(role, field, true)
+		}
+	}
+	return policy
+}
+
+// Hidden reports whether a field is hidden from a role
+func (p *VisibilityPolicy) Hidden(role, field string) bool {
+	if p == nil {
+		p = DefaultVisibilityPolicy()
+	}
+	return p.hidden[role][field]
+}
+
+// set hides or shows a field to a role
+func (p *VisibilityPolicy) set(role, field string, hidden bool) {
+	if p.hidden[role] == nil {
+		p.hidden[role] = map[string]bool{}
+	}
+	p.hidden[role][field] = hidden
+}
+
+// VisibilityService defines the interface for the rules deciding which fields of appointment
+// responses each role sees
+type VisibilityService interface {
+	PolicyFor(operationID uint) (*VisibilityPolicy, error)
+	ListRules(operationID *uint) ([]models.FieldVisibilityRule, error)
+	SetRules(operationID *uint, rules []models.FieldVisibilityRule) ([]models.FieldVisibilityRule, error)
+}
+
+// visibilityService implements the VisibilityService interface
+type visibilityService struct {
+	visibilityRepo repository.VisibilityRepository
+	operationRepo  repository.OperationRepository
+}
+
+// NewVisibilityService creates a new visibility service
+func NewVisibilityService(
+	visibilityRepo repository.VisibilityRepository,
+	operationRepo repository.OperationRepository,
+) VisibilityService {
+	return &visibilityService{
+		visibilityRepo: visibilityRepo,
+		operationRepo:  operationRepo,
+	}
+}
+
+// PolicyFor returns the policy at an operation: the defaults, overridden by the global rules,
+// overridden by the operation's own rules
+func (s *visibilityService) PolicyFor(operationID uint) (*VisibilityPolicy, error) {
+	rules, err := s.visibilityRepo.FindApplicable(operationID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := DefaultVisibilityPolicy()
+	for _, rule := range rules {
+		policy.set(rule.Role, rule.Field, rule.Hidden)
+	}
+	return policy, nil
+}
+
+// ListRules returns the rules of an operation, or the global rules when operationID is nil
+func (s *visibilityService) ListRules(operationID *uint) ([]models.FieldVisibilityRule, error) {
+	if operationID != nil {
+		if _, err := s.operationRepo.FindByID(*operationID); err != nil {
+			return nil, err
+		}
+	}
+	return s.visibilityRepo.FindByScope(operationID)
+}
+
+// SetRules replaces the rules of an operation, or the global rules when operationID is nil
+func (s *visibilityService) SetRules(operationID *uint, rules []models.FieldVisibilityRule) ([]models.FieldVisibilityRule, error) {
+	if operationID != nil {
+		if _, err := s.operationRepo.FindByID(*operationID); err != nil {
+			return nil, err
+		}
+	}
+
+	seen := make(map[string]bool)
+	for i := range rules {
+		if err := rules[i].Validate(); err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+		key := rules[i].Role + " " + rules[i].Field
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate rule for %s %s", rules[i].Role, rules[i].Field)
+		}
+		seen[key] = true
+	}
+
+	if err := s.visibilityRepo.ReplaceForScope(operationID, rules); err != nil {
+		return nil, err
+	}
+	return s.visibilityRepo.FindByScope(operationID)
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/config"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/repository"
+	"github.com/bernardofernandezz/scheduling-api/pkg/clock"
+)
+
+// Waitlist errors
+var (
+	ErrWaitlistEntryNotFound = errors.New("waitlist entry not found")
+	ErrWaitlistPastDate      = errors.New("only today or a later date can be waitlisted")
+	ErrWaitlistDuplicate     = errors.New("the supplier is already waitlisted for this date")
+	ErrWaitlistNotWaiting    = errors.New("only waiting entries can be withdrawn")
+	ErrWaitlistRange         = errors.New("waitlists can be listed for at most 92 days at a time")
+	ErrWaitlistSupplier      = errors.New("only suppliers can join a waitlist")
+)
+
+// maxWaitlistDays is the longest period of an operation's waitlist listed at once
+const maxWaitlistDays = 92
+
+// defaultWaitlistDays is how many days of an operation's waitlist are listed without a to date
+const defaultWaitlistDays = 30
+
+// WaitlistService defines the interface for suppliers waiting for a slot on a booked-up date
+type WaitlistService interface {
+	Join(entry *models.WaitlistEntry) (*models.WaitlistEntry, error)
+	ListBySupplier(supplierID uint) ([]models.WaitlistEntry, error)
+	ListByOperation(operationID uint, from, to time.Time) ([]models.WaitlistEntry, error)
+	GetByID(id uint) (*models.WaitlistEntry, error)
+	Withdraw(id uint) (*models.WaitlistEntry, error)
+	Promote(appointment *models.Appointment) (*models.WaitlistEntry, error)
+	SupplierFor(user *models.User) (uint, error)
+}
+
+// waitlistService implements the WaitlistService interface
+type waitlistService struct {
+	waitlistRepo        repository.WaitlistRepository
+	supplierRepo        repository.SupplierRepository
+	operationRepo       repository.OperationRepository
+	notificationService NotificationService
+	config              *config.Config
+	clock               clock.Clock
+}
+
+// NewWaitlistService creates a new waitlist service
+func NewWaitlistService(
+	waitlistRepo repository.WaitlistRepository,
+	supplierRepo repository.SupplierRepository,
+	operationRepo repository.OperationRepository,
+	notificationService NotificationService,
+	config *config.Config,
+	clock clock.Clock,
+) WaitlistService {
+	return &waitlistService{
+		waitlistRepo:        waitlistRepo,
+		supplierRepo:        supplierRepo,
+		operationRepo:       operationRepo,
+		notificationService: notificationService,
+		config:              config,
+		clock:               clock,
+	}
+}
+
+// Join puts a supplier on an operation's waitlist for a date. The date is a calendar day at the
+// operation and can't be in the past there.
+func (s *waitlistService) Join(entry *models.WaitlistEntry) (*models.WaitlistEntry, error) {
+	if entry.Type == "" {
+		entry.Type = models.AppointmentTypeDelivery
+	}
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := s.supplierRepo.FindByID(entry.SupplierID); err != nil {
+		return nil, errors.New("invalid supplier: " + err.Error())
+	}
+	operation, err := s.operationRepo.FindByID(entry.OperationID)
+	if err != nil {
+		return nil, errors.New("invalid operation: " + err.Error())
+	}
+
+	entry.Date = civilDate(entry.Date, time.UTC)
+	today := civilDate(s.clock.Now(), operation.Location())
+	if entry.Date.Before(today) {
+		return nil, ErrWaitlistPastDate
+	}
+
+	existing, err := s.waitlistRepo.FindWaiting(entry.SupplierID, entry.OperationID, entry.Date, entry.Type)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrWaitlistDuplicate
+	}
+
+	entry.ID = 0
+	entry.Status = models.WaitlistWaiting
+	entry.OfferedAt = nil
+	entry.OfferedAppointmentID = nil
+	if err := s.waitlistRepo.Create(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// ListBySupplier returns a supplier's waitlist entries, the nearest date first
+func (s *waitlistService) ListBySupplier(supplierID uint) ([]models.WaitlistEntry, error) {
+	return s.waitlistRepo.FindBySupplier(supplierID)
+}
+
+// ListByOperation returns an operation's waitlist between two dates, both included. A zero from
+// is now and a zero to is defaultWaitlistDays after from.
+func (s *waitlistService) ListByOperation(operationID uint, from, to time.Time) ([]models.WaitlistEntry, error) {
+	if from.IsZero() {
+		from = s.clock.Now().UTC()
+	}
+	if to.IsZero() {
+		to = from.AddDate(0, 0, defaultWaitlistDays)
+	}
+	if to.Before(from) || to.Sub(from) > maxWaitlistDays*24*time.Hour {
+		return nil, ErrWaitlistRange
+	}
+	if _, err := s.operationRepo.FindByID(operationID); err != nil {
+		return nil, err
+	}
+	return s.waitlistRepo.FindByOperation(operationID, from, to)
+}
+
+// GetByID gets a waitlist entry by ID
+func (s *waitlistService) GetByID(id uint) (*models.WaitlistEntry, error) {
+	entry, err := s.waitlistRepo.FindByID(id)
+	if err != nil {
+		return nil, ErrWaitlistEntryNotFound
+	}
+	return entry, nil
+}
+
+// Withdraw takes a supplier off the waitlist. Entries already offered a slot are kept as they
+// are, since the offer went out.
+func (s *waitlistService) Withdraw(id uint) (*models.WaitlistEntry, error) {
+	entry, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Status != models.WaitlistWaiting {
+		return nil, ErrWaitlistNotWaiting
+	}
+
+	entry.Status = models.WaitlistWithdrawn
+	if err := s.waitlistRepo.Update(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Promote offers the slot of a cancelled appointment to the supplier waiting longest for its
+// date and type at the operation, and tells them it opened. Slots already started aren't offered;
+// nil is returned when nobody was promoted.
+func (s *waitlistService) Promote(appointment *models.Appointment) (*models.WaitlistEntry, error) {
+	if !appointment.ScheduledStart.After(s.clock.Now()) {
+		return nil, nil
+	}
+	operation, err := s.operationRepo.FindByID(appointment.OperationID)
+	if err != nil {
+		return nil, err
+	}
+
+	date := civilDate(appointment.ScheduledStart, operation.Location())
+	entry, err := s.waitlistRepo.NextWaiting(operation.ID, date, appointment.Type, appointment.SupplierID)
+	if err != nil || entry == nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	entry.Status = models.WaitlistOffered
+	entry.OfferedAt = &now
+	entry.OfferedAppointmentID = &appointment.ID
+	if err := s.waitlistRepo.Update(entry); err != nil {
+		return nil, err
+	}
+
+	s.notifySlotOpened(entry, appointment, operation)
+	return entry, nil
+}
+
+// SupplierFor returns the supplier record of a supplier user
+func (s *waitlistService) SupplierFor(user *models.User) (uint, error) {
+	supplier, err := s.supplierRepo.FindByUserID(user.ID)
+	if err != nil {
+		return 0, ErrWaitlistSupplier
+	}
+	return supplier.ID, nil
+}
+
+// notifySlotOpened emails a promoted supplier the slot that opened on the date they waited for
+func (s *waitlistService) notifySlotOpened(entry *models.WaitlistEntry, appointment *models.Appointment, operation *models.Operation) {
+	if s.notificationService == nil {
+		return
+	}
+
+	fallback := ""
+	if s.config != nil && s.config.Notification != nil {
+		fallback = s.config.Notification.LinkBaseURL
+	}
+	link := buildLink(portalURL(s.operationRepo, operation.ID, fallback), "waitlist", entry.ID)
+	location := operation.Location()
+
+	notification := &models.Notification{
+		Type:          models.NotificationTypeEmail,
+		Status:        models.NotificationStatusPending,
+		Event:         models.EventWaitlistSlotOpened,
+		RecipientType: models.RecipientSupplier,
+		RecipientID:   entry.SupplierID,
+		Subject:       fmt.Sprintf("A slot opened at %s", operation.Name),
+		Body: fmt.Sprintf("A %s slot opened at %s on %s from %s to %s, the day you are waitlisted for. Book it before it is taken: %s",
+			strings.ToLower(entry.Type.Label()), operation.Name, entry.DateKey(),
+			appointment.ScheduledStart.In(location).Format("15:04"), appointment.ScheduledEnd.In(location).Format("15:04"), link),
+	}
+	if err := s.notificationService.EnqueueNotification(notification, QueueAppointmentNotifications, 2); err != nil {
+		log.Printf("Failed to enqueue the slot offer of waitlist entry %d: %v", entry.ID, err)
+	}
+}
@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// Token types, in the token_type claim
+const (
+	AccessToken  = "access"
+	RefreshToken = "refresh"
+)
+
+// Claims are the claims of the tokens the API issues. user_id and jti are what revocations know
+// a token by.
+type Claims struct {
+	UserID    uint   `json:"user_id"`
+	Role      string `json:"role"`
+	TokenType string `json:"token_type"`
+	jwt.RegisteredClaims
+}
+
+// JWTManager issues and verifies the API's HMAC-signed tokens
+type JWTManager struct {
+	secretKey            string
+	tokenDuration        time.Duration
+	refreshTokenDuration time.Duration
+}
+
+// NewJWTManager creates a JWT manager issuing access tokens valid for tokenDuration and refresh
+// tokens valid for refreshTokenDuration
+func NewJWTManager(secretKey string, tokenDuration, refreshTokenDuration time.Duration) *JWTManager {
+	return &JWTManager{
+		secretKey:            secretKey,
+		tokenDuration:        tokenDuration,
+		refreshTokenDuration: refreshTokenDuration,
+	}
+}
+
+// GenerateToken issues an access token for a user
+func (m *JWTManager) GenerateToken(user *models.User) (string, error) {
+	return m.generate(user, AccessToken, m.tokenDuration)
+}
+
+// GenerateRefreshToken issues a refresh token for a user, which only gets new tokens
+func (m *JWTManager) GenerateRefreshToken(user *models.User) (string, error) {
+	return m.generate(user, RefreshToken, m.refreshTokenDuration)
+}
+
+// Verify checks a token's signature and expiry and returns its claims
+func (m *JWTManager) Verify(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+		}
+		return []byte(m.secretKey), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if claims.UserID == 0 {
+		return nil, errors.New("token has no user")
+	}
+	return claims, nil
+}
+
+// generate signs a token of a type for a user
+func (m *JWTManager) generate(user *models.User, tokenType string, duration time.Duration) (string, error) {
+	now := time.Now()
+	claims := Claims{
+		UserID:    user.ID,
+		Role:      user.Role,
+		TokenType: tokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Subject:   strconv.FormatUint(uint64(user.ID), 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(duration)),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(m.secretKey))
+}
@@ -5,11 +5,13 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
 	"github.com/bernardofernandezz/scheduling-api/internal/service"
 )
 
-// AuthMiddleware creates a middleware for authenticating requests
-func AuthMiddleware(userService service.UserService) gin.HandlerFunc {
+// AuthMiddleware creates a middleware for authenticating requests. Tokens revoked before they
+// expire, e.g. by a password change, are refused.
+func AuthMiddleware(userService service.UserService, revocations service.TokenRevocationService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get Authorization header
 		authHeader := c.GetHeader("Authorization")
@@ -35,6 +37,9 @@ func AuthMiddleware(userService service.UserService) gin.HandlerFunc {
 			c.Abort()
 			return
 		}
+		if !checkRevoked(c, revocations, user.ID, IdentifyToken(tokenString)) {
+			return
+		}
 
 		// Set user in context
 		c.Set("user", user)
@@ -48,5 +53,26 @@ func RoleMiddleware(roles ...string) gin.HandlerFunc {
 		// Get user from context
 		user, exists := c.Get("user")
 		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+			c.Abort()
+			return
+		}
+
+		// Check if user has required role
+		u, ok := user.(*models.User)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not found in context"})
+			c.Abort()
+			return
+		}
+		for _, role := range roles {
+			if u.Role == role {
+				c.Next()
+				return
+			}
+		}
 
+		c.JSON(http.StatusForbidden, gin.H{"error": "You don't have permission to access this endpoint"})
+		c.Abort()
+	}
+}
@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// Headers partner systems sign requests with
+const (
+	PartnerKeyHeader       = "X-Partner-Key"
+	PartnerTimestampHeader = "X-Partner-Timestamp"
+	PartnerNonceHeader     = "X-Partner-Nonce"
+	PartnerSignatureHeader = "X-Partner-Signature"
+)
+
+// PartnerSignatureMiddleware creates a middleware for authenticating partner systems by the HMAC
+// signature of their requests. The verified credential is set in the context as "partner".
+func PartnerSignatureMiddleware(partnerService service.PartnerService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var body []byte
+		if c.Request.Body != nil && c.Request.Body != http.NoBody {
+			var err error
+			body, err = io.ReadAll(c.Request.Body)
+			c.Request.Body.Close()
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Failed to read request body"})
+				return
+			}
+			c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		credential, err := partnerService.Verify(service.SignedPartnerRequest{
+			KeyID:     c.GetHeader(PartnerKeyHeader),
+			Timestamp: c.GetHeader(PartnerTimestampHeader),
+			Nonce:     c.GetHeader(PartnerNonceHeader),
+			Signature: c.GetHeader(PartnerSignatureHeader),
+			Method:    c.Request.Method,
+			Path:      c.Request.URL.RequestURI(),
+			Body:      body,
+		})
+		if err != nil {
+			status := http.StatusUnauthorized
+			if !isPartnerSignatureError(err) {
+				status = http.StatusInternalServerError
+			}
+			c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.Set("partner", credential)
+		c.Next()
+	}
+}
+
+// isPartnerSignatureError reports whether a verification error is the partner's fault
+func isPartnerSignatureError(err error) bool {
+	for _, target := range []error{
+		service.ErrPartnerSignatureMissing,
+		service.ErrPartnerUnknownKey,
+		service.ErrPartnerInvalidSignature,
+		service.ErrPartnerStaleRequest,
+		service.ErrPartnerInvalidNonce,
+		service.ErrPartnerReplayedRequest,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
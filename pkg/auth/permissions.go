@@ -0,0 +1,195 @@
+package auth
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+)
+
+// User roles
+const (
+	RoleAdmin    = "admin"
+	RoleEmployee = "employee"
+	RoleSupplier = "supplier"
+)
+
+// Roles lists every user role
+var Roles = []string{RoleAdmin, RoleEmployee, RoleSupplier}
+
+// Permission is what a caller needs to call a route
+type Permission string
+
+const (
+	// PermissionPublic routes need no credentials
+	PermissionPublic Permission = "public"
+	// PermissionPartner routes need a request signed by a partner credential
+	PermissionPartner Permission = "partner"
+	// PermissionAuthenticated routes are open to every signed-in user; handlers scope what each sees
+	PermissionAuthenticated Permission = "authenticated"
+	// PermissionStaff routes are for admins and employees
+	PermissionStaff Permission = "staff"
+	// PermissionFeedback routes are for the two parties of an appointment, suppliers and employees
+	PermissionFeedback Permission = "feedback"
+	// PermissionAdmin routes are for admins
+	PermissionAdmin Permission = "admin"
+)
+
+// permissionRoles are the roles each permission that needs a signed-in user is granted to
+var permissionRoles = map[Permission][]string{
+	PermissionAuthenticated: Roles,
+	PermissionStaff:         {RoleAdmin, RoleEmployee},
+	PermissionFeedback:      {RoleEmployee, RoleSupplier},
+	PermissionAdmin:         {RoleAdmin},
+}
+
+// RequiresUser reports whether a permission needs a signed-in user
+func (p Permission) RequiresUser() bool {
+	_, ok := permissionRoles[p]
+	return ok
+}
+
+// Allows reports whether a role is granted a permission
+func (p Permission) Allows(role string) bool {
+	for _, granted := range permissionRoles[p] {
+		if granted == role {
+			return true
+		}
+	}
+	return false
+}
+
+// RouteRule is the permission a route requires. Paths are relative to the API version group, e.g.
+// "/admin/partners/:id", so one rule covers /api, /api/v1 and /api/v2.
+type RouteRule struct {
+	Method     string
+	Path       string
+	Permission Permission
+}
+
+// RoutePermissions is the declared permission of every API route. The Authorize middleware
+// enforces it and the routes package tests check every registered route against it per role.
+type RoutePermissions struct {
+	rules map[string]RouteRule
+}
+
+// NewRoutePermissions creates the route permissions from their rules, rejecting duplicate routes
+// and unknown permissions
+func NewRoutePermissions(rules []RouteRule) (*RoutePermissions, error) {
+	permissions := &RoutePermissions{rules: make(map[string]RouteRule, len(rules))}
+	for _, rule := range rules {
+		key := routeKey(rule.Method, rule.Path)
+		if _, exists := permissions.rules[key]; exists {
+			return nil, fmt.Errorf("route %s is declared twice", key)
+		}
+		if rule.Permission != PermissionPublic && rule.Permission != PermissionPartner && !rule.Permission.RequiresUser() {
+			return nil, fmt.Errorf("route %s requires unknown permission %q", key, rule.Permission)
+		}
+		permissions.rules[key] = rule
+	}
+	return permissions, nil
+}
+
+// Lookup returns the permission a route requires
+func (p *RoutePermissions) Lookup(method, path string) (Permission, bool) {
+	rule, ok := p.rules[routeKey(method, path)]
+	return rule.Permission, ok
+}
+
+// Rules returns every rule, sorted by path and method
+func (p *RoutePermissions) Rules() []RouteRule {
+	rules := make([]RouteRule, 0, len(p.rules))
+	for _, rule := range p.rules {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Path != rules[j].Path {
+			return rules[i].Path < rules[j].Path
+		}
+		return rules[i].Method < rules[j].Method
+	})
+	return rules
+}
+
+// Verify checks the routes registered under each API version group against the rules. It
+// returns a problem for every registered route without a rule and every rule without a route, so
+// a new endpoint can't ship without declaring who may call it.
+func (p *RoutePermissions) Verify(routes gin.RoutesInfo, basePaths []string) []string {
+	var problems []string
+	declared := make(map[string]bool, len(p.rules))
+	for _, route := range routes {
+		for _, basePath := range basePaths {
+			path, ok := relativePath(route.Path, basePath)
+			if !ok {
+				continue
+			}
+			key := routeKey(route.Method, path)
+			if _, exists := p.rules[key]; !exists {
+				problems = append(problems, fmt.Sprintf("%s %s has no declared permission", route.Method, route.Path))
+			}
+			declared[key] = true
+		}
+	}
+	for _, rule := range p.Rules() {
+		if key := routeKey(rule.Method, rule.Path); !declared[key] {
+			problems = append(problems, fmt.Sprintf("%s is declared but not registered", key))
+		}
+	}
+	return problems
+}
+
+// Authorize creates a middleware for checking the signed-in user's role grants the permission
+// declared for the route. It runs after AuthMiddleware on the routes of the API version group at
+// basePath. Routes without a declared permission are refused.
+func Authorize(permissions *RoutePermissions, basePath string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		path, _ := relativePath(c.FullPath(), basePath)
+		permission, ok := permissions.Lookup(c.Request.Method, path)
+		if !ok {
+			log.Printf("No permission declared for %s %s, refusing the request", c.Request.Method, c.FullPath())
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "You don't have permission to access this endpoint"})
+			return
+		}
+
+		value, exists := c.Get("user")
+		user, isUser := value.(*models.User)
+		if !exists || !isUser {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+			return
+		}
+		if !permission.Allows(user.Role) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error":               "You don't have permission to access this endpoint",
+				"required_permission": permission,
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// relativePath returns a route path relative to an API version group, reporting whether the
+// route belongs to the group directly rather than to a longer group such as /api/v1 under /api
+func relativePath(path, basePath string) (string, bool) {
+	basePath = strings.TrimRight(basePath, "/")
+	if path != basePath && !strings.HasPrefix(path, basePath+"/") {
+		return "", false
+	}
+	rest := strings.TrimPrefix(path, basePath)
+	if rest == "" {
+		rest = "/"
+	}
+	if strings.HasPrefix(rest, "/v") && len(rest) > 2 && rest[2] >= '0' && rest[2] <= '9' {
+		return "", false
+	}
+	return rest, true
+}
+
+// routeKey identifies a route by method and path
+func routeKey(method, path string) string {
+	return strings.ToUpper(method) + " " + path
+}
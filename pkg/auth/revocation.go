@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/bernardofernandezz/scheduling-api/internal/models"
+	"github.com/bernardofernandezz/scheduling-api/internal/service"
+)
+
+// TokenIdentity is what revocations know an issued token by
+type TokenIdentity struct {
+	ID        string    // jti, or the SHA-256 of tokens without one
+	UserID    uint      // from the user_id or sub claim, 0 when neither holds one
+	IssuedAt  time.Time // zero when the token has no iat
+	ExpiresAt time.Time // zero when the token has no exp
+}
+
+// IdentifyToken reads the identity of a token. The signature isn't checked: callers either
+// validated the token already or only use the identity to refuse it.
+func IdentifyToken(tokenString string) TokenIdentity {
+	var identity TokenIdentity
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(tokenString, claims); err == nil {
+		identity.ID, _ = claims["jti"].(string)
+		if issuedAt, err := claims.GetIssuedAt(); err == nil && issuedAt != nil {
+			identity.IssuedAt = issuedAt.Time
+		}
+		if expiresAt, err := claims.GetExpirationTime(); err == nil && expiresAt != nil {
+			identity.ExpiresAt = expiresAt.Time
+		}
+		switch userID := claims["user_id"].(type) {
+		case float64:
+			identity.UserID = uint(userID)
+		default:
+			if subject, _ := claims["sub"].(string); subject != "" {
+				if id, err := strconv.ParseUint(subject, 10, 32); err == nil {
+					identity.UserID = uint(id)
+				}
+			}
+		}
+	}
+	if identity.ID == "" {
+		sum := sha256.Sum256([]byte(tokenString))
+		identity.ID = hex.EncodeToString(sum[:])
+	}
+	return identity
+}
+
+// checkRevoked refuses a token that was revoked, writing the response. Failing to read the
+// revocations refuses the token too, so a revocation can't be skipped while the database is
+// unreachable.
+func checkRevoked(c *gin.Context, revocations service.TokenRevocationService, userID uint, identity TokenIdentity) bool {
+	revoked, err := revocations.IsRevoked(userID, identity.ID, identity.IssuedAt)
+	if err != nil {
+		log.Printf("Failed to check the revocation of a token of user %d: %v", userID, err)
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Failed to check the token"})
+		c.Abort()
+		return false
+	}
+	if revoked {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": service.ErrTokenRevoked.Error()})
+		c.Abort()
+		return false
+	}
+	return true
+}
+
+// RefreshRevocationMiddleware refuses refresh requests whose refresh token was revoked, so a
+// revoked session can't get new access tokens
+func RefreshRevocationMiddleware(revocations service.TokenRevocationService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+			c.Abort()
+			return
+		}
+		// The handler binds the body again
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		var req struct {
+			RefreshToken string `json:"refresh_token"`
+		}
+		if json.Unmarshal(body, &req) != nil || req.RefreshToken == "" {
+			c.Next()
+			return
+		}
+		identity := IdentifyToken(req.RefreshToken)
+		if !checkRevoked(c, revocations, identity.UserID, identity) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// RevokeUserOnSuccess revokes every token of the signed-in user once the handler succeeded, e.g.
+// after the user changed their password
+func RevokeUserOnSuccess(revocations service.TokenRevocationService, reason string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+		if c.Writer.Status() >= http.StatusMultipleChoices {
+			return
+		}
+		value, exists := c.Get("user")
+		if !exists {
+			return
+		}
+		if user, ok := value.(*models.User); ok {
+			if err := revocations.RevokeUser(user.ID, reason); err != nil {
+				log.Printf("Failed to revoke the tokens of user %d after %s: %v", user.ID, reason, err)
+			}
+		}
+	}
+}
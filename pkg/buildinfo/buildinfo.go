@@ -0,0 +1,90 @@
+// Package buildinfo describes the build of the running binary, so support can tell which release
+// and commit served a request. The release, commit and build date are set when building:
+//
+//	go build -ldflags "-X github.com/bernardofernandezz/scheduling-api/pkg/buildinfo.Version=v1.4.0 \
+//	    -X github.com/bernardofernandezz/scheduling-api/pkg/buildinfo.Commit=$(git rev-parse HEAD) \
+//	    -X github.com/bernardofernandezz/scheduling-api/pkg/buildinfo.Date=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// Binaries built without them, e.g. with go run, fall back to the commit and time Go records
+// from the checkout.
+package buildinfo
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+)
+
+// Set at build time with -ldflags "-X"
+var (
+	Version = "dev"
+	Commit  = ""
+	Date    = ""
+)
+
+// Info describes the build of the running binary
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+	GoVersion string `json:"go_version"`
+	Modified  bool   `json:"modified,omitempty"` // built from a checkout with uncommitted changes
+}
+
+var (
+	once    sync.Once
+	current Info
+)
+
+// Get returns the build of the running binary
+func Get() Info {
+	once.Do(func() {
+		current = Info{
+			Version:   Version,
+			Commit:    Commit,
+			BuildDate: Date,
+			GoVersion: runtime.Version(),
+		}
+		if build, ok := debug.ReadBuildInfo(); ok {
+			for _, setting := range build.Settings {
+				switch setting.Key {
+				case "vcs.revision":
+					if current.Commit == "" {
+						current.Commit = setting.Value
+					}
+				case "vcs.time":
+					if current.BuildDate == "" {
+						current.BuildDate = setting.Value
+					}
+				case "vcs.modified":
+					current.Modified = setting.Value == "true"
+				}
+			}
+		}
+		if current.Commit == "" {
+			current.Commit = "unknown"
+		}
+	})
+	return current
+}
+
+// ShortCommit returns the first 12 characters of the commit, as git abbreviates it
+func (i Info) ShortCommit() string {
+	if len(i.Commit) > 12 {
+		return i.Commit[:12]
+	}
+	return i.Commit
+}
+
+// String describes the build in one line for logs, e.g. "v1.4.0 (3f2a9c1d7e4b, 2026-10-01T12:00:00Z)"
+func (i Info) String() string {
+	commit := i.ShortCommit()
+	if i.Modified {
+		commit += "-dirty"
+	}
+	if i.BuildDate == "" {
+		return fmt.Sprintf("%s (%s)", i.Version, commit)
+	}
+	return fmt.Sprintf("%s (%s, %s)", i.Version, commit, i.BuildDate)
+}
@@ -0,0 +1,279 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrOpen is returned when a call is rejected because the breaker is open
+var ErrOpen = errors.New("circuit breaker is open")
+
+// State represents the state of a circuit breaker
+type State int
+
+const (
+	// StateClosed lets all calls through and counts consecutive failures
+	StateClosed State = iota
+
+	// StateHalfOpen lets a single trial call through to probe the provider
+	StateHalfOpen
+
+	// StateOpen rejects all calls until the open timeout elapses
+	StateOpen
+)
+
+// String returns the name of the state
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half_open"
+	case StateOpen:
+		return "open"
+	}
+	return "unknown"
+}
+
+// Settings configures a circuit breaker
+type Settings struct {
+	// FailureThreshold is the number of consecutive failures that opens the breaker
+	FailureThreshold int
+
+	// OpenTimeout is how long the breaker stays open before allowing a trial call
+	OpenTimeout time.Duration
+
+	// OnStateChange is called whenever the breaker changes state
+	OnStateChange func(name string, from, to State)
+}
+
+// Snapshot is a point-in-time view of a circuit breaker
+type Snapshot struct {
+	Name                string     `json:"name"`
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	TotalFailures       int64      `json:"total_failures"`
+	TotalRejections     int64      `json:"total_rejections"`
+	LastError           string     `json:"last_error,omitempty"`
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+	RetryAt             *time.Time `json:"retry_at,omitempty"`
+}
+
+// Breaker is a consecutive-failure circuit breaker protecting one external provider
+type Breaker struct {
+	name     string
+	settings Settings
+
+	mu                  sync.Mutex
+	state               State
+	consecutiveFailures int
+	totalFailures       int64
+	totalRejections     int64
+	lastError           string
+	openedAt            time.Time
+	trialInFlight       bool
+}
+
+// New creates a new circuit breaker
+func New(name string, settings Settings) *Breaker {
+	if settings.FailureThreshold <= 0 {
+		settings.FailureThreshold = 5
+	}
+	if settings.OpenTimeout <= 0 {
+		settings.OpenTimeout = time.Minute
+	}
+	return &Breaker{name: name, settings: settings}
+}
+
+// Name returns the name of the breaker
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// Execute runs fn if the breaker allows it and records the outcome
+func (b *Breaker) Execute(fn func() error) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+
+	err := fn()
+	b.record(err)
+	return err
+}
+
+// RetryAt returns when an open breaker will allow the next trial call.
+// It returns the zero time if the breaker is not open.
+func (b *Breaker) RetryAt() time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state != StateOpen {
+		return time.Time{}
+	}
+	return b.openedAt.Add(b.settings.OpenTimeout)
+}
+
+// State returns the current state of the breaker
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refresh()
+	return b.state
+}
+
+// Reset forces the breaker back to the closed state
+func (b *Breaker) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.consecutiveFailures = 0
+	b.trialInFlight = false
+	b.setState(StateClosed)
+}
+
+// Snapshot returns a point-in-time view of the breaker
+func (b *Breaker) Snapshot() Snapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refresh()
+	snapshot := Snapshot{
+		Name:                b.name,
+		State:               b.state.String(),
+		ConsecutiveFailures: b.consecutiveFailures,
+		TotalFailures:       b.totalFailures,
+		TotalRejections:     b.totalRejections,
+		LastError:           b.lastError,
+	}
+	if b.state != StateClosed {
+		openedAt := b.openedAt
+		retryAt := b.openedAt.Add(b.settings.OpenTimeout)
+		snapshot.OpenedAt = &openedAt
+		snapshot.RetryAt = &retryAt
+	}
+	return snapshot
+}
+
+// allow checks whether a call may proceed
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refresh()
+	switch b.state {
+	case StateOpen:
+		b.totalRejections++
+		return ErrOpen
+	case StateHalfOpen:
+		// Only one trial call at a time while half-open
+		if b.trialInFlight {
+			b.totalRejections++
+			return ErrOpen
+		}
+		b.trialInFlight = true
+	}
+	return nil
+}
+
+// record records the outcome of a call
+func (b *Breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trialInFlight = false
+	if err == nil {
+		b.consecutiveFailures = 0
+		b.setState(StateClosed)
+		return
+	}
+
+	b.consecutiveFailures++
+	b.totalFailures++
+	b.lastError = err.Error()
+
+	if b.state == StateHalfOpen || b.consecutiveFailures >= b.settings.FailureThreshold {
+		b.openedAt = time.Now()
+		b.setState(StateOpen)
+	}
+}
+
+// refresh moves an open breaker to half-open once its timeout has elapsed
+func (b *Breaker) refresh() {
+	if b.state == StateOpen && time.Since(b.openedAt) >= b.settings.OpenTimeout {
+		b.setState(StateHalfOpen)
+	}
+}
+
+// setState changes the state and fires the state change callback
+func (b *Breaker) setState(state State) {
+	if b.state == state {
+		return
+	}
+	from := b.state
+	b.state = state
+	if b.settings.OnStateChange != nil {
+		b.settings.OnStateChange(b.name, from, state)
+	}
+}
+
+// Registry holds the circuit breakers of all external providers
+type Registry struct {
+	settings Settings
+
+	mu       sync.RWMutex
+	breakers map[string]*Breaker
+}
+
+// NewRegistry creates a registry whose breakers share the given settings
+func NewRegistry(settings Settings) *Registry {
+	return &Registry{
+		settings: settings,
+		breakers: make(map[string]*Breaker),
+	}
+}
+
+// Get returns the breaker for a provider, creating it on first use
+func (r *Registry) Get(name string) *Breaker {
+	r.mu.RLock()
+	breaker, exists := r.breakers[name]
+	r.mu.RUnlock()
+	if exists {
+		return breaker
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if breaker, exists = r.breakers[name]; !exists {
+		breaker = New(name, r.settings)
+		r.breakers[name] = breaker
+	}
+	return breaker
+}
+
+// Lookup returns the breaker for a provider if it has been registered
+func (r *Registry) Lookup(name string) (*Breaker, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	breaker, exists := r.breakers[name]
+	return breaker, exists
+}
+
+// Snapshots returns the state of all registered breakers sorted by name
+func (r *Registry) Snapshots() []Snapshot {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	snapshots := make([]Snapshot, 0, len(r.breakers))
+	for _, breaker := range r.breakers {
+		snapshots = append(snapshots, breaker.Snapshot())
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Name < snapshots[j].Name
+	})
+	return snapshots
+}
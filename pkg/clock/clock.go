@@ -0,0 +1,53 @@
+// Package clock abstracts the current time so time-based logic can be run against a fixed instant
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the current time
+type Clock interface {
+	Now() time.Time
+}
+
+// System is the clock of the operating system
+type System struct{}
+
+// Now returns the current local time
+func (System) Now() time.Time {
+	return time.Now()
+}
+
+// Frozen is a clock that stands still until it is set or advanced, for checking lead-time,
+// reminder and expiry logic at a known instant. It is safe for concurrent use.
+type Frozen struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFrozen creates a clock frozen at now
+func NewFrozen(now time.Time) *Frozen {
+	return &Frozen{now: now}
+}
+
+// Now returns the instant the clock is frozen at
+func (f *Frozen) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Set moves the clock to now
+func (f *Frozen) Set(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = now
+}
+
+// Advance moves the clock forward by d
+func (f *Frozen) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
@@ -0,0 +1,21 @@
+package clock
+
+import "time"
+
+// StartOfDay returns midnight at the start of the calendar day t falls on in location
+func StartOfDay(t time.Time, location *time.Location) time.Time {
+	local := t.In(location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+}
+
+// DayBounds returns the start of the calendar day t falls on in location and the start of the
+// next one. Across daylight saving changes the day is 23 or 25 hours long.
+func DayBounds(t time.Time, location *time.Location) (time.Time, time.Time) {
+	start := StartOfDay(t, location)
+	return start, start.AddDate(0, 0, 1)
+}
+
+// Today returns the bounds of the current day in location
+func Today(c Clock, location *time.Location) (time.Time, time.Time) {
+	return DayBounds(c.Now(), location)
+}
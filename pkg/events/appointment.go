@@ -0,0 +1,43 @@
+package events
+
+import "time"
+
+// AppointmentCreatedData is the data of com.scheduling.appointment.created v1
+type AppointmentCreatedData struct {
+	ID                uint      `json:"id"`
+	BookingCode       string    `json:"booking_code"`
+	Type              string    `json:"type"`
+	Status            string    `json:"status"`
+	OperationID       uint      `json:"operation_id"`
+	SupplierID        uint      `json:"supplier_id"`
+	EmployeeID        uint      `json:"employee_id"`
+	ProductID         *uint     `json:"product_id"`
+	QuantityToDeliver int       `json:"quantity_to_deliver"`
+	ScheduledStart    time.Time `json:"scheduled_start"`
+	ScheduledEnd      time.Time `json:"scheduled_end"`
+	ExternalRef       string    `json:"external_ref,omitempty"`
+	PurchaseOrder     string    `json:"purchase_order,omitempty"`
+}
+
+// AppointmentStatusChangedData is the data of com.scheduling.appointment.status_changed v1
+type AppointmentStatusChangedData struct {
+	ID               uint      `json:"id"`
+	BookingCode      string    `json:"booking_code"`
+	PreviousStatus   string    `json:"previous_status"`
+	Status           string    `json:"status"`
+	Reason           string    `json:"reason,omitempty"`
+	ChangedAt        time.Time `json:"changed_at"`
+	ChangedByID      *uint     `json:"changed_by_id"` // nil when a background job changed it
+	ReceivedQuantity *int      `json:"received_quantity,omitempty"`
+}
+
+// AppointmentRescheduledData is the data of com.scheduling.appointment.rescheduled v1
+type AppointmentRescheduledData struct {
+	ID             uint      `json:"id"`
+	BookingCode    string    `json:"booking_code"`
+	PreviousStart  time.Time `json:"previous_start"`
+	PreviousEnd    time.Time `json:"previous_end"`
+	ScheduledStart time.Time `json:"scheduled_start"`
+	ScheduledEnd   time.Time `json:"scheduled_end"`
+	Reason         string    `json:"reason,omitempty"`
+}
@@ -0,0 +1,140 @@
+package events
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// schemaFiles are the published schemas, named <event type>.v<version>.json
+//
+//go:embed schemas/*.json
+var schemaFiles embed.FS
+
+// Schema is a published version of the JSON Schema an event type's data follows
+type Schema struct {
+	Type        string          `json:"type"`
+	Version     int             `json:"version"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Document    json.RawMessage `json:"-"`
+
+	root *node
+}
+
+// Path returns where the schema is published, relative to the schema base URL
+func (s *Schema) Path() string {
+	return s.Type + "/v" + strconv.Itoa(s.Version)
+}
+
+// Validate checks a JSON document against the schema, returning what is wrong with it
+func (s *Schema) Validate(document []byte) []string {
+	var value interface{}
+	if err := json.Unmarshal(document, &value); err != nil {
+		return []string{"not valid JSON: " + err.Error()}
+	}
+	var problems []string
+	s.root.validate("", value, &problems)
+	return problems
+}
+
+// Catalog holds the published schema versions of every event type
+type Catalog struct {
+	schemas map[string][]*Schema // by event type, oldest version first
+}
+
+// NewCatalog loads the schemas built into the binary
+func NewCatalog() (*Catalog, error) {
+	entries, err := schemaFiles.ReadDir("schemas")
+	if err != nil {
+		return nil, err
+	}
+
+	catalog := &Catalog{schemas: make(map[string][]*Schema)}
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), ".json")
+		i := strings.LastIndex(name, ".v")
+		if i < 0 {
+			return nil, fmt.Errorf("schema file %s is not named <type>.v<version>.json", entry.Name())
+		}
+		version, err := strconv.Atoi(name[i+2:])
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("schema file %s has an invalid version", entry.Name())
+		}
+
+		document, err := schemaFiles.ReadFile(path.Join("schemas", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var root node
+		if err := json.Unmarshal(document, &root); err != nil {
+			return nil, fmt.Errorf("schema file %s: %w", entry.Name(), err)
+		}
+
+		eventType := name[:i]
+		catalog.schemas[eventType] = append(catalog.schemas[eventType], &Schema{
+			Type:        eventType,
+			Version:     version,
+			Title:       root.Title,
+			Description: root.Description,
+			Document:    document,
+			root:        &root,
+		})
+	}
+	for _, versions := range catalog.schemas {
+		sort.Slice(versions, func(i, j int) bool { return versions[i].Version < versions[j].Version })
+	}
+	return catalog, nil
+}
+
+// List returns every published schema, by event type and version
+func (c *Catalog) List() []*Schema {
+	var schemas []*Schema
+	for _, versions := range c.schemas {
+		schemas = append(schemas, versions...)
+	}
+	sort.Slice(schemas, func(i, j int) bool {
+		if schemas[i].Type != schemas[j].Type {
+			return schemas[i].Type < schemas[j].Type
+		}
+		return schemas[i].Version < schemas[j].Version
+	})
+	return schemas
+}
+
+// Find returns a version of an event type's schema
+func (c *Catalog) Find(eventType string, version int) (*Schema, bool) {
+	for _, schema := range c.schemas[eventType] {
+		if schema.Version == version {
+			return schema, true
+		}
+	}
+	return nil, false
+}
+
+// Latest returns the newest version of an event type's schema
+func (c *Catalog) Latest(eventType string) (*Schema, bool) {
+	versions := c.schemas[eventType]
+	if len(versions) == 0 {
+		return nil, false
+	}
+	return versions[len(versions)-1], true
+}
+
+// ForDataSchema returns the schema an event's dataschema URL names, which ends in
+// <type>/v<version>
+func (c *Catalog) ForDataSchema(eventType, dataSchema string) (*Schema, bool) {
+	i := strings.LastIndex(dataSchema, "/v")
+	if i < 0 || !strings.HasSuffix(dataSchema[:i], "/"+eventType) {
+		return nil, false
+	}
+	version, err := strconv.Atoi(dataSchema[i+2:])
+	if err != nil {
+		return nil, false
+	}
+	return c.Find(eventType, version)
+}
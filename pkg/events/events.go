@@ -0,0 +1,127 @@
+// Package events defines the CloudEvents (https://cloudevents.io) envelope of the events the API
+// publishes and the versioned JSON Schemas their data follows, so consumers can code against a
+// stable contract.
+//
+// Compatibility policy: a schema version only ever gains optional properties and enum values.
+// Removing or renaming a property, making one required or changing its type publishes a new
+// version of the event's schema, and both versions are published side by side until consumers
+// have moved over. The event type itself never changes; dataschema names the version.
+package events
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SpecVersion is the CloudEvents version of the envelope
+const SpecVersion = "1.0"
+
+// Event types
+const (
+	AppointmentCreated       = "com.scheduling.appointment.created"
+	AppointmentStatusChanged = "com.scheduling.appointment.status_changed"
+	AppointmentRescheduled   = "com.scheduling.appointment.rescheduled"
+)
+
+// Event is a CloudEvent in structured JSON mode
+type Event struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	DataSchema      string          `json:"dataschema"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// New creates an event of a type with data following the latest version of the type's schema.
+// The data is checked against the schema, so an event the contract doesn't allow is never sent.
+// schemaBaseURL is where the schemas are published, e.g. https://api.example.com/api/schemas.
+func (c *Catalog) New(eventType, source, subject, schemaBaseURL string, at time.Time, data interface{}) (*Event, error) {
+	schema, ok := c.Latest(eventType)
+	if !ok {
+		return nil, fmt.Errorf("unknown event type %s", eventType)
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	if problems := schema.Validate(encoded); len(problems) > 0 {
+		return nil, fmt.Errorf("%s data does not match schema v%d: %s", eventType, schema.Version, strings.Join(problems, "; "))
+	}
+
+	id, err := newEventID()
+	if err != nil {
+		return nil, err
+	}
+	return &Event{
+		SpecVersion:     SpecVersion,
+		ID:              id,
+		Source:          source,
+		Type:            eventType,
+		Subject:         subject,
+		Time:            at.UTC(),
+		DataContentType: "application/json",
+		DataSchema:      strings.TrimRight(schemaBaseURL, "/") + "/" + schema.Path(),
+		Data:            encoded,
+	}, nil
+}
+
+// Validate checks an event's envelope and its data against the schema version its dataschema
+// names, returning what is wrong with it
+func (c *Catalog) Validate(event *Event) []string {
+	var problems []string
+	if event.SpecVersion != SpecVersion {
+		problems = append(problems, fmt.Sprintf("specversion must be %q", SpecVersion))
+	}
+	if event.ID == "" {
+		problems = append(problems, "id is required")
+	}
+	if event.Source == "" {
+		problems = append(problems, "source is required")
+	}
+	if event.Time.IsZero() {
+		problems = append(problems, "time is required")
+	}
+	if event.DataContentType != "application/json" {
+		problems = append(problems, `datacontenttype must be "application/json"`)
+	}
+
+	schema, ok := c.ForDataSchema(event.Type, event.DataSchema)
+	if !ok {
+		return append(problems, fmt.Sprintf("no published schema for type %q and dataschema %q", event.Type, event.DataSchema))
+	}
+	for _, problem := range schema.Validate(event.Data) {
+		problems = append(problems, "data: "+problem)
+	}
+	return problems
+}
+
+// ErrInvalidEvent is returned for events that can't be decoded
+var ErrInvalidEvent = errors.New("event must be a CloudEvent in structured JSON mode")
+
+// Decode reads a structured mode CloudEvent
+func Decode(body []byte) (*Event, error) {
+	var event Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEvent, err)
+	}
+	return &event, nil
+}
+
+// newEventID returns a random event ID
+func newEventID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
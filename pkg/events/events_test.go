@@ -0,0 +1,160 @@
+package events
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSchemaBaseURL is where the test events say their schemas are published
+const testSchemaBaseURL = "https://api.example.com/api/schemas"
+
+var (
+	eventTime        = time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)
+	changedByID      = uint(9)
+	productID        = uint(4)
+	receivedQuantity = 12
+	startsAt         = eventTime.Add(25 * time.Hour)
+	previousStart    = eventTime.Add(49 * time.Hour)
+)
+
+// eventData is the data of each event type, minimal with only the required properties and full
+// with every property set
+var eventData = map[string]struct {
+	minimal, full interface{}
+}{
+	AppointmentCreated: {
+		minimal: AppointmentCreatedData{
+			ID: 7, BookingCode: "GRU-2024-000007", Type: "delivery", Status: "pending",
+			OperationID: 3, SupplierID: 1, EmployeeID: 2,
+			ScheduledStart: startsAt, ScheduledEnd: startsAt.Add(time.Hour),
+		},
+		full: AppointmentCreatedData{
+			ID: 7, BookingCode: "GRU-2024-000007", Type: "delivery", Status: "pending",
+			OperationID: 3, SupplierID: 1, EmployeeID: 2, ProductID: &productID, QuantityToDeliver: 12,
+			ScheduledStart: startsAt, ScheduledEnd: startsAt.Add(time.Hour),
+			ExternalRef: "ERP-1001", PurchaseOrder: "PO-2024-55",
+		},
+	},
+	AppointmentStatusChanged: {
+		minimal: AppointmentStatusChangedData{
+			ID: 7, BookingCode: "GRU-2024-000007", PreviousStatus: "pending", Status: "cancelled",
+			ChangedAt: eventTime,
+		},
+		full: AppointmentStatusChangedData{
+			ID: 7, BookingCode: "GRU-2024-000007", PreviousStatus: "confirmed", Status: "partially_completed",
+			Reason: "short delivery", ChangedAt: eventTime, ChangedByID: &changedByID, ReceivedQuantity: &receivedQuantity,
+		},
+	},
+	AppointmentRescheduled: {
+		minimal: AppointmentRescheduledData{
+			ID: 7, BookingCode: "GRU-2024-000007",
+			PreviousStart: previousStart, PreviousEnd: previousStart.Add(time.Hour),
+			ScheduledStart: startsAt, ScheduledEnd: startsAt.Add(time.Hour),
+		},
+		full: AppointmentRescheduledData{
+			ID: 7, BookingCode: "GRU-2024-000007",
+			PreviousStart: previousStart, PreviousEnd: previousStart.Add(time.Hour),
+			ScheduledStart: startsAt, ScheduledEnd: startsAt.Add(time.Hour), Reason: "dock closed",
+		},
+	},
+}
+
+func newTestCatalog(t *testing.T) *Catalog {
+	t.Helper()
+	catalog, err := NewCatalog()
+	require.NoError(t, err)
+	return catalog
+}
+
+func TestEveryPublishedSchemaHasEventData(t *testing.T) {
+	catalog := newTestCatalog(t)
+	for _, schema := range catalog.List() {
+		_, ok := eventData[schema.Type]
+		assert.True(t, ok, "no test data for %s", schema.Type)
+	}
+	for eventType := range eventData {
+		_, ok := catalog.Latest(eventType)
+		assert.True(t, ok, "no published schema for %s", eventType)
+	}
+}
+
+func TestNewEventsMatchTheirSchemas(t *testing.T) {
+	catalog := newTestCatalog(t)
+	for eventType, data := range eventData {
+		for name, value := range map[string]interface{}{"minimal": data.minimal, "full": data.full} {
+			t.Run(eventType+" "+name, func(t *testing.T) {
+				event, err := catalog.New(eventType, "/api/appointments", "7", testSchemaBaseURL, eventTime, value)
+				require.NoError(t, err)
+
+				assert.Equal(t, eventType, event.Type)
+				assert.Equal(t, testSchemaBaseURL+"/"+eventType+"/v1", event.DataSchema)
+				assert.Empty(t, catalog.Validate(event))
+
+				// The event survives the trip through a webhook body
+				body, err := json.Marshal(event)
+				require.NoError(t, err)
+				decoded, err := Decode(body)
+				require.NoError(t, err)
+				assert.Empty(t, catalog.Validate(decoded))
+			})
+		}
+	}
+}
+
+func TestEventDataAndSchemasDescribeTheSameProperties(t *testing.T) {
+	catalog := newTestCatalog(t)
+	for eventType, data := range eventData {
+		schema, ok := catalog.Latest(eventType)
+		require.True(t, ok, eventType)
+
+		encoded, err := json.Marshal(data.full)
+		require.NoError(t, err)
+		var fields map[string]interface{}
+		require.NoError(t, json.Unmarshal(encoded, &fields))
+
+		assert.Equal(t, sortedKeys(schema.root.Properties), sortedKeys(fields), eventType)
+	}
+}
+
+func TestNewRefusesDataOutsideTheSchema(t *testing.T) {
+	catalog := newTestCatalog(t)
+
+	data := eventData[AppointmentStatusChanged].minimal.(AppointmentStatusChangedData)
+	data.Status = "archived"
+	data.BookingCode = ""
+	_, err := catalog.New(AppointmentStatusChanged, "/api/appointments", "7", testSchemaBaseURL, eventTime, data)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status must be one of")
+	assert.Contains(t, err.Error(), "booking_code must be at least 1 characters")
+
+	_, err = catalog.New("com.scheduling.appointment.deleted", "/api/appointments", "7", testSchemaBaseURL, eventTime, data)
+	assert.EqualError(t, err, "unknown event type com.scheduling.appointment.deleted")
+}
+
+func TestValidateReportsEnvelopeProblems(t *testing.T) {
+	catalog := newTestCatalog(t)
+	event, err := catalog.New(AppointmentCreated, "/api/appointments", "7", testSchemaBaseURL, eventTime, eventData[AppointmentCreated].minimal)
+	require.NoError(t, err)
+
+	event.SpecVersion = "0.3"
+	event.DataSchema = testSchemaBaseURL + "/" + AppointmentCreated + "/v2"
+	assert.Equal(t, []string{
+		`specversion must be "1.0"`,
+		`no published schema for type "com.scheduling.appointment.created" and dataschema "` + event.DataSchema + `"`,
+	}, catalog.Validate(event))
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,153 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// node is the part of JSON Schema the published schemas use: types, required and nested
+// properties, array items, enums, date-time formats, minimum lengths and minimum values
+type node struct {
+	Title                string           `json:"title"`
+	Description          string           `json:"description"`
+	Types                schemaTypes      `json:"type"`
+	Required             []string         `json:"required"`
+	Properties           map[string]*node `json:"properties"`
+	AdditionalProperties *bool            `json:"additionalProperties"`
+	Items                *node            `json:"items"`
+	Enum                 []interface{}    `json:"enum"`
+	Format               string           `json:"format"`
+	MinLength            *int             `json:"minLength"`
+	Minimum              *float64         `json:"minimum"`
+}
+
+// schemaTypes is the type keyword, a single type or a list of them
+type schemaTypes []string
+
+// UnmarshalJSON reads a single type or a list of types
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = schemaTypes{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("type must be a string or a list of strings")
+	}
+	*t = list
+	return nil
+}
+
+// validate appends to problems what is wrong with a decoded JSON value at a path
+func (n *node) validate(at string, value interface{}, problems *[]string) {
+	where := at
+	if where == "" {
+		where = "document"
+	}
+
+	if len(n.Types) > 0 && !n.Types.allow(value) {
+		*problems = append(*problems, fmt.Sprintf("%s must be of type %v", where, []string(n.Types)))
+		return
+	}
+	if len(n.Enum) > 0 && !inEnum(n.Enum, value) {
+		*problems = append(*problems, fmt.Sprintf("%s must be one of %v", where, n.Enum))
+	}
+
+	switch v := value.(type) {
+	case string:
+		if n.MinLength != nil && len([]rune(v)) < *n.MinLength {
+			*problems = append(*problems, fmt.Sprintf("%s must be at least %d characters", where, *n.MinLength))
+		}
+		if n.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, v); err != nil {
+				*problems = append(*problems, fmt.Sprintf("%s must be an RFC 3339 date-time", where))
+			}
+		}
+	case float64:
+		if n.Minimum != nil && v < *n.Minimum {
+			*problems = append(*problems, fmt.Sprintf("%s must be at least %v", where, *n.Minimum))
+		}
+	case []interface{}:
+		if n.Items != nil {
+			for i, item := range v {
+				n.Items.validate(fmt.Sprintf("%s[%d]", at, i), item, problems)
+			}
+		}
+	case map[string]interface{}:
+		for _, name := range n.Required {
+			if _, ok := v[name]; !ok {
+				*problems = append(*problems, fmt.Sprintf("%s is required", join(at, name)))
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			property, known := n.Properties[name]
+			if !known {
+				if n.AdditionalProperties != nil && !*n.AdditionalProperties {
+					*problems = append(*problems, fmt.Sprintf("%s is not allowed", join(at, name)))
+				}
+				continue
+			}
+			property.validate(join(at, name), v[name], problems)
+		}
+	}
+}
+
+// allow reports whether a decoded JSON value has one of the types
+func (t schemaTypes) allow(value interface{}) bool {
+	for _, kind := range t {
+		switch v := value.(type) {
+		case nil:
+			if kind == "null" {
+				return true
+			}
+		case bool:
+			if kind == "boolean" {
+				return true
+			}
+		case string:
+			if kind == "string" {
+				return true
+			}
+		case float64:
+			if kind == "number" || (kind == "integer" && v == math.Trunc(v)) {
+				return true
+			}
+		case []interface{}:
+			if kind == "array" {
+				return true
+			}
+		case map[string]interface{}:
+			if kind == "object" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// inEnum reports whether a decoded JSON value is one of the enum values
+func inEnum(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if allowed == value {
+			return true
+		}
+	}
+	return false
+}
+
+// join returns the path of a property
+func join(at, name string) string {
+	if at == "" {
+		return name
+	}
+	return at + "." + name
+}
@@ -0,0 +1,91 @@
+// Package freebusy reads when people are busy in their work calendars through the free/busy
+// APIs of Google Calendar and Microsoft 365. Only busy periods are read, never what the events
+// are, so connecting a calendar doesn't share meeting titles or attendees.
+package freebusy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// ErrUnauthorized is returned when the provider refuses the token, e.g. because the user revoked
+// the access or the refresh token expired
+var ErrUnauthorized = errors.New("calendar access was revoked or expired")
+
+// Period is a time the calendar's owner is busy
+type Period struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Token is the OAuth token a calendar is read with. Providers refresh expired access tokens with
+// the refresh token and return the new one, which the caller should keep.
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	Expiry       time.Time // zero when unknown; the token is then refreshed once the provider refuses it
+}
+
+// Provider reads the busy periods of a calendar
+type Provider interface {
+	Name() string
+	Busy(ctx context.Context, token Token, calendarID string, from, to time.Time) ([]Period, Token, error)
+}
+
+// authorizedClient sends requests with an OAuth access token, refreshing it through the OAuth
+// configuration when it expired or the provider refuses it
+type authorizedClient struct {
+	oauth  *oauth2.Config
+	client *http.Client
+}
+
+// do sends the request build returns with a valid access token. A refused token is refreshed and
+// the request sent once more. The token used last is returned with the response.
+func (a *authorizedClient) do(ctx context.Context, token Token, build func() (*http.Request, error)) (*http.Response, Token, error) {
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, a.client)
+	current := &oauth2.Token{AccessToken: token.AccessToken, RefreshToken: token.RefreshToken, Expiry: token.Expiry, TokenType: "Bearer"}
+
+	for attempt := 0; ; attempt++ {
+		valid, err := a.oauth.TokenSource(ctx, current).Token()
+		if err != nil {
+			return nil, token, fmt.Errorf("%w: %v", ErrUnauthorized, err)
+		}
+		token = Token{AccessToken: valid.AccessToken, RefreshToken: valid.RefreshToken, Expiry: valid.Expiry}
+
+		req, err := build()
+		if err != nil {
+			return nil, token, err
+		}
+		valid.SetAuthHeader(req)
+		resp, err := a.client.Do(req)
+		if err != nil {
+			return nil, token, err
+		}
+		if resp.StatusCode != http.StatusUnauthorized {
+			return resp, token, nil
+		}
+
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if attempt > 0 || valid.RefreshToken == "" {
+			return nil, token, ErrUnauthorized
+		}
+		// Refused before its known expiry, e.g. revoked: without an access token it is refreshed
+		current = &oauth2.Token{RefreshToken: valid.RefreshToken}
+	}
+}
+
+// newHTTPClient creates the client a provider sends its requests with, through transport,
+// http.DefaultTransport when nil
+func newHTTPClient(timeout time.Duration, transport http.RoundTripper) *http.Client {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &http.Client{Timeout: timeout, Transport: transport}
+}
@@ -0,0 +1,101 @@
+package freebusy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// Google reads busy periods through the Google Calendar freeBusy query
+// (https://developers.google.com/calendar/api/v3/reference/freebusy/query). Tokens need the
+// calendar.freebusy or calendar.readonly scope.
+type Google struct {
+	baseURL string
+	auth    *authorizedClient
+}
+
+// NewGoogle creates a Google Calendar provider refreshing tokens with the OAuth client of the
+// application and sending its requests through transport, http.DefaultTransport when nil
+func NewGoogle(clientID, clientSecret, baseURL string, timeout time.Duration, transport http.RoundTripper) *Google {
+	return &Google{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		auth: &authorizedClient{
+			oauth:  &oauth2.Config{ClientID: clientID, ClientSecret: clientSecret, Endpoint: google.Endpoint},
+			client: newHTTPClient(timeout, transport),
+		},
+	}
+}
+
+// Name returns the provider name
+func (g *Google) Name() string {
+	return "google"
+}
+
+// Busy returns the busy periods of a Google calendar, "primary" when calendarID is empty
+func (g *Google) Busy(ctx context.Context, token Token, calendarID string, from, to time.Time) ([]Period, Token, error) {
+	if calendarID == "" {
+		calendarID = "primary"
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"timeMin": from.UTC().Format(time.RFC3339),
+		"timeMax": to.UTC().Format(time.RFC3339),
+		"items":   []map[string]string{{"id": calendarID}},
+	})
+	if err != nil {
+		return nil, token, err
+	}
+
+	resp, token, err := g.auth.do(ctx, token, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.baseURL+"/calendar/v3/freeBusy", bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
+	if err != nil {
+		return nil, token, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, token, fmt.Errorf("google calendar returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Calendars map[string]struct {
+			Busy []struct {
+				Start time.Time `json:"start"`
+				End   time.Time `json:"end"`
+			} `json:"busy"`
+			Errors []struct {
+				Reason string `json:"reason"`
+			} `json:"errors"`
+		} `json:"calendars"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, token, fmt.Errorf("failed to decode google calendar response: %w", err)
+	}
+
+	calendar, ok := result.Calendars[calendarID]
+	if !ok {
+		return nil, token, fmt.Errorf("google calendar did not return calendar %s", calendarID)
+	}
+	if len(calendar.Errors) > 0 {
+		// notFound and the like are answered with 200 and an error per calendar
+		return nil, token, fmt.Errorf("google calendar %s: %s", calendarID, calendar.Errors[0].Reason)
+	}
+
+	periods := make([]Period, 0, len(calendar.Busy))
+	for _, busy := range calendar.Busy {
+		periods = append(periods, Period{Start: busy.Start, End: busy.End})
+	}
+	return periods, token, nil
+}
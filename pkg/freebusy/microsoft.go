@@ -0,0 +1,132 @@
+package freebusy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// graphTimeLayout is how Microsoft Graph writes a date and time without a zone
+const graphTimeLayout = "2006-01-02T15:04:05.9999999"
+
+// microsoftBusyStatuses are the schedule statuses that make someone unavailable; free and working
+// elsewhere don't
+var microsoftBusyStatuses = map[string]bool{"busy": true, "tentative": true, "oof": true}
+
+// Microsoft reads busy periods of Microsoft 365 and Outlook.com calendars through the Graph
+// getSchedule action (https://learn.microsoft.com/graph/api/calendar-getschedule). Tokens need
+// the Calendars.Read scope.
+type Microsoft struct {
+	baseURL string
+	auth    *authorizedClient
+}
+
+// NewMicrosoft creates a Microsoft 365 provider refreshing tokens with the application registered
+// in the tenant, "common" for accounts of any organization, and sending its requests through
+// transport, http.DefaultTransport when nil
+func NewMicrosoft(clientID, clientSecret, tenant, baseURL string, timeout time.Duration, transport http.RoundTripper) *Microsoft {
+	if tenant == "" {
+		tenant = "common"
+	}
+	login := "https://login.microsoftonline.com/" + tenant + "/oauth2/v2.0"
+	return &Microsoft{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		auth: &authorizedClient{
+			oauth: &oauth2.Config{
+				ClientID:     clientID,
+				ClientSecret: clientSecret,
+				Endpoint:     oauth2.Endpoint{AuthURL: login + "/authorize", TokenURL: login + "/token"},
+			},
+			client: newHTTPClient(timeout, transport),
+		},
+	}
+}
+
+// Name returns the provider name
+func (m *Microsoft) Name() string {
+	return "microsoft"
+}
+
+// Busy returns the busy periods of a mailbox's calendar. calendarID is the mailbox address,
+// which getSchedule requires; tentative meetings and time out of office count as busy.
+func (m *Microsoft) Busy(ctx context.Context, token Token, calendarID string, from, to time.Time) ([]Period, Token, error) {
+	if calendarID == "" {
+		return nil, token, fmt.Errorf("microsoft calendar needs the mailbox address")
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"schedules":                []string{calendarID},
+		"startTime":                map[string]string{"dateTime": from.UTC().Format("2006-01-02T15:04:05"), "timeZone": "UTC"},
+		"endTime":                  map[string]string{"dateTime": to.UTC().Format("2006-01-02T15:04:05"), "timeZone": "UTC"},
+		"availabilityViewInterval": 15,
+	})
+	if err != nil {
+		return nil, token, err
+	}
+
+	resp, token, err := m.auth.do(ctx, token, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.baseURL+"/v1.0/me/calendar/getSchedule", bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Prefer", `outlook.timezone="UTC"`)
+		return req, nil
+	})
+	if err != nil {
+		return nil, token, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, token, fmt.Errorf("microsoft graph returned status %d", resp.StatusCode)
+	}
+
+	type graphTime struct {
+		DateTime string `json:"dateTime"`
+	}
+	var result struct {
+		Value []struct {
+			ScheduleItems []struct {
+				Status string    `json:"status"`
+				Start  graphTime `json:"start"`
+				End    graphTime `json:"end"`
+			} `json:"scheduleItems"`
+			Error *struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		} `json:"value"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, token, fmt.Errorf("failed to decode microsoft graph response: %w", err)
+	}
+	if len(result.Value) == 0 {
+		return nil, token, fmt.Errorf("microsoft graph did not return the schedule of %s", calendarID)
+	}
+	schedule := result.Value[0]
+	if schedule.Error != nil {
+		return nil, token, fmt.Errorf("microsoft calendar %s: %s", calendarID, schedule.Error.Message)
+	}
+
+	periods := make([]Period, 0, len(schedule.ScheduleItems))
+	for _, item := range schedule.ScheduleItems {
+		if !microsoftBusyStatuses[strings.ToLower(item.Status)] {
+			continue
+		}
+		start, err := time.ParseInLocation(graphTimeLayout, item.Start.DateTime, time.UTC)
+		if err != nil {
+			return nil, token, fmt.Errorf("invalid microsoft graph time %q: %w", item.Start.DateTime, err)
+		}
+		end, err := time.ParseInLocation(graphTimeLayout, item.End.DateTime, time.UTC)
+		if err != nil {
+			return nil, token, fmt.Errorf("invalid microsoft graph time %q: %w", item.End.DateTime, err)
+		}
+		periods = append(periods, Period{Start: start, End: end})
+	}
+	return periods, token, nil
+}
@@ -0,0 +1,107 @@
+package geo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrAddressNotFound is returned when a geocoder has no result for an address
+var ErrAddressNotFound = errors.New("address not found")
+
+// Geocoder resolves postal addresses to coordinates
+type Geocoder interface {
+	Geocode(ctx context.Context, address string) (Point, error)
+}
+
+// NominatimGeocoder geocodes addresses with an OpenStreetMap Nominatim server
+type NominatimGeocoder struct {
+	baseURL   string
+	userAgent string
+	client    *http.Client
+}
+
+// NewNominatimGeocoder creates a Nominatim geocoder sending its requests through transport,
+// http.DefaultTransport when nil. Nominatim's usage policy requires an identifying user agent.
+func NewNominatimGeocoder(baseURL, userAgent string, timeout time.Duration, transport http.RoundTripper) *NominatimGeocoder {
+	return &NominatimGeocoder{
+		baseURL:   strings.TrimRight(baseURL, "/"),
+		userAgent: userAgent,
+		client:    &http.Client{Timeout: timeout, Transport: transport},
+	}
+}
+
+// Geocode implements Geocoder
+func (g *NominatimGeocoder) Geocode(ctx context.Context, address string) (Point, error) {
+	query := url.Values{}
+	query.Set("q", address)
+	query.Set("format", "json")
+	query.Set("limit", "1")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.baseURL+"/search?"+query.Encode(), nil)
+	if err != nil {
+		return Point{}, err
+	}
+	req.Header.Set("User-Agent", g.userAgent)
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return Point{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Point{}, fmt.Errorf("geocoder returned status %d", resp.StatusCode)
+	}
+
+	var results []struct {
+		Lat string `json:"lat"`
+		Lon string `json:"lon"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return Point{}, fmt.Errorf("failed to decode geocoder response: %w", err)
+	}
+	if len(results) == 0 {
+		return Point{}, ErrAddressNotFound
+	}
+
+	lat, err := strconv.ParseFloat(results[0].Lat, 64)
+	if err != nil {
+		return Point{}, fmt.Errorf("invalid latitude %q: %w", results[0].Lat, err)
+	}
+	lng, err := strconv.ParseFloat(results[0].Lon, 64)
+	if err != nil {
+		return Point{}, fmt.Errorf("invalid longitude %q: %w", results[0].Lon, err)
+	}
+	return Point{Latitude: lat, Longitude: lng}, nil
+}
+
+// TravelEstimator estimates road travel times from straight-line distances
+type TravelEstimator struct {
+	// AverageSpeedKmh is the average truck speed between sites
+	AverageSpeedKmh float64
+
+	// RoadFactor is how much longer the road distance is than the straight line, e.g. 1.3
+	RoadFactor float64
+}
+
+// Estimate returns the expected travel time between two points
+func (e TravelEstimator) Estimate(from, to Point) time.Duration {
+	speed := e.AverageSpeedKmh
+	if speed <= 0 {
+		speed = 50
+	}
+	factor := e.RoadFactor
+	if factor < 1 {
+		factor = 1
+	}
+
+	km := Distance(from, to) / 1000 * factor
+	return time.Duration(km / speed * float64(time.Hour))
+}
@@ -0,0 +1,70 @@
+package holidays
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// BrasilAPI lists national holidays from BrasilAPI (https://brasilapi.com.br), which follows
+// changes in the law without a new release. BrasilAPI has no state holidays, so those come from
+// the embedded dataset.
+type BrasilAPI struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewBrasilAPI creates a BrasilAPI provider sending its requests through transport,
+// http.DefaultTransport when nil
+func NewBrasilAPI(baseURL string, timeout time.Duration, transport http.RoundTripper) *BrasilAPI {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &BrasilAPI{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: timeout, Transport: transport},
+	}
+}
+
+// Holidays returns the national holidays of a year from BrasilAPI and the state holidays of the
+// region
+func (b *BrasilAPI) Holidays(ctx context.Context, year int, region string) ([]Holiday, error) {
+	endpoint := fmt.Sprintf("%s/api/feriados/v1/%d", b.baseURL, year)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("holiday provider returned status %d", resp.StatusCode)
+	}
+
+	var results []struct {
+		Date string `json:"date"`
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("failed to decode holiday provider response: %w", err)
+	}
+
+	list := make([]Holiday, 0, len(results))
+	for _, result := range results {
+		day, err := time.Parse("2006-01-02", result.Date)
+		if err != nil {
+			return nil, fmt.Errorf("invalid holiday date %q: %w", result.Date, err)
+		}
+		list = append(list, Holiday{Date: day, Name: result.Name, Scope: ScopeNational})
+	}
+	list = append(list, stateHolidays(year, region)...)
+	return sortHolidays(list), nil
+}
@@ -0,0 +1,114 @@
+package holidays
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// fixedHoliday is a holiday on the same day every year
+type fixedHoliday struct {
+	month time.Month
+	day   int
+	name  string
+}
+
+// nationalFixed are the Brazilian national holidays on a fixed date
+var nationalFixed = []fixedHoliday{
+	{time.January, 1, "Confraternização Universal"},
+	{time.April, 21, "Tiradentes"},
+	{time.May, 1, "Dia do Trabalho"},
+	{time.September, 7, "Independência do Brasil"},
+	{time.October, 12, "Nossa Senhora Aparecida"},
+	{time.November, 2, "Finados"},
+	{time.November, 15, "Proclamação da República"},
+	{time.November, 20, "Dia Nacional de Zumbi e da Consciência Negra"},
+	{time.December, 25, "Natal"},
+}
+
+// stateFixed are the state holidays on a fixed date, by state code
+var stateFixed = map[string][]fixedHoliday{
+	"AC": {{time.June, 15, "Aniversário do Acre"}},
+	"AL": {{time.September, 16, "Emancipação Política de Alagoas"}},
+	"AM": {{time.September, 5, "Elevação do Amazonas à Categoria de Província"}},
+	"AP": {{time.March, 19, "Dia de São José"}},
+	"BA": {{time.July, 2, "Independência da Bahia"}},
+	"CE": {{time.March, 25, "Data Magna do Ceará"}},
+	"MA": {{time.July, 28, "Adesão do Maranhão à Independência"}},
+	"MS": {{time.October, 11, "Criação do Estado de Mato Grosso do Sul"}},
+	"PA": {{time.August, 15, "Adesão do Pará à Independência"}},
+	"PB": {{time.August, 5, "Fundação do Estado da Paraíba"}},
+	"PE": {{time.March, 6, "Revolução Pernambucana"}},
+	"PI": {{time.October, 19, "Dia do Piauí"}},
+	"PR": {{time.December, 19, "Emancipação Política do Paraná"}},
+	"RJ": {{time.April, 23, "Dia de São Jorge"}},
+	"RN": {{time.October, 3, "Mártires de Cunhaú e Uruaçu"}},
+	"RO": {{time.January, 4, "Criação do Estado de Rondônia"}},
+	"RR": {{time.October, 5, "Criação do Estado de Roraima"}},
+	"RS": {{time.September, 20, "Revolução Farroupilha"}},
+	"SE": {{time.July, 8, "Emancipação Política de Sergipe"}},
+	"SP": {{time.July, 9, "Revolução Constitucionalista de 1932"}},
+	"TO": {{time.October, 5, "Criação do Estado do Tocantins"}},
+}
+
+// Embedded lists Brazilian holidays from a dataset built into the binary: the national holidays,
+// including the ones moving with Easter, and the main fixed holiday of each state. It needs no
+// network access; municipal holidays and one-off dates are left to manual overrides.
+type Embedded struct{}
+
+// NewEmbedded creates a provider using the built-in Brazilian holiday dataset
+func NewEmbedded() *Embedded {
+	return &Embedded{}
+}
+
+// Holidays returns the national holidays of a year and the state holidays of the region
+func (e *Embedded) Holidays(ctx context.Context, year int, region string) ([]Holiday, error) {
+	list := nationalHolidays(year)
+	list = append(list, stateHolidays(year, region)...)
+	return sortHolidays(list), nil
+}
+
+// nationalHolidays returns the Brazilian national holidays of a year
+func nationalHolidays(year int) []Holiday {
+	list := make([]Holiday, 0, len(nationalFixed)+3)
+	for _, fixed := range nationalFixed {
+		list = append(list, Holiday{Date: date(year, fixed.month, fixed.day), Name: fixed.name, Scope: ScopeNational})
+	}
+
+	easter := easterSunday(year)
+	list = append(list,
+		Holiday{Date: easter.AddDate(0, 0, -47), Name: "Carnaval", Scope: ScopeNational},
+		Holiday{Date: easter.AddDate(0, 0, -2), Name: "Sexta-feira Santa", Scope: ScopeNational},
+		Holiday{Date: easter.AddDate(0, 0, 60), Name: "Corpus Christi", Scope: ScopeNational},
+	)
+	return list
+}
+
+// stateHolidays returns the holidays of a state in a year
+func stateHolidays(year int, region string) []Holiday {
+	var list []Holiday
+	for _, fixed := range stateFixed[strings.ToUpper(strings.TrimSpace(region))] {
+		list = append(list, Holiday{Date: date(year, fixed.month, fixed.day), Name: fixed.name, Scope: ScopeState})
+	}
+	return list
+}
+
+// easterSunday returns the date of Easter Sunday in the Gregorian calendar (anonymous Gregorian
+// algorithm)
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return date(year, time.Month(month), day)
+}
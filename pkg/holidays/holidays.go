@@ -0,0 +1,53 @@
+// Package holidays lists public holidays from pluggable providers, so operations can close on
+// them without staff entering every date by hand.
+package holidays
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// Scope tells whether a holiday is observed nationwide or in one state
+type Scope string
+
+const (
+	// ScopeNational is a holiday observed in the whole country
+	ScopeNational Scope = "national"
+
+	// ScopeState is a holiday observed in one state
+	ScopeState Scope = "state"
+)
+
+// Holiday is a public holiday. Date is the civil date at midnight UTC.
+type Holiday struct {
+	Date  time.Time `json:"date"`
+	Name  string    `json:"name"`
+	Scope Scope     `json:"scope"`
+}
+
+// Provider lists the holidays of a year observed in a region: the national holidays plus those
+// of the region, a state code such as "SP". An empty region returns the national ones only.
+type Provider interface {
+	Holidays(ctx context.Context, year int, region string) ([]Holiday, error)
+}
+
+// date returns a civil date at midnight UTC
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// sortHolidays orders holidays by date, dropping a later holiday falling on a date already
+// listed so each date appears once
+func sortHolidays(list []Holiday) []Holiday {
+	sort.SliceStable(list, func(i, j int) bool { return list[i].Date.Before(list[j].Date) })
+
+	unique := list[:0]
+	for _, holiday := range list {
+		if len(unique) > 0 && unique[len(unique)-1].Date.Equal(holiday.Date) {
+			continue
+		}
+		unique = append(unique, holiday)
+	}
+	return unique
+}
@@ -0,0 +1,318 @@
+// Package httpclient wraps outbound HTTP calls to third-party APIs with per-host rate limiting,
+// retries with jittered backoff and per-attempt timeouts. It is an http.RoundTripper, so it can
+// sit under any client, including the ones built by provider SDKs.
+package httpclient
+
+import (
+	"context"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Limit is the request rate allowed to a host
+type Limit struct {
+	PerSecond float64 // 0 or less means unlimited
+	Burst     int     // requests that may go out at once after a quiet period, at least 1
+}
+
+// Settings configures a transport
+type Settings struct {
+	// Timeout bounds each attempt, from sending the request to reading the whole response body
+	Timeout time.Duration
+
+	// MaxRetries is the number of times a failed request is retried. Throttled requests (429)
+	// are retried whatever their method; network errors and 502, 503 and 504 responses only for
+	// idempotent requests, so a message is never sent twice.
+	MaxRetries int
+
+	// BaseDelay is the delay before the first retry, doubled on each further retry up to
+	// MaxDelay. A Retry-After header sent by the host takes precedence, capped by MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// Jitter is the fraction of the delay randomly added or removed (0-1)
+	Jitter float64
+
+	// HostLimits are the rate limits of specific hosts; other hosts get DefaultLimit
+	HostLimits   map[string]Limit
+	DefaultLimit Limit
+
+	// OnAttempt is called after each attempt with its status code, or the error when no
+	// response was received
+	OnAttempt func(host string, status int, err error, duration time.Duration)
+
+	// OnRetry is called before waiting to retry a request
+	OnRetry func(host string, attempt int, delay time.Duration)
+
+	// OnThrottle is called when a request waits for the rate limit of its host
+	OnThrottle func(host string, wait time.Duration)
+}
+
+// Transport is an http.RoundTripper applying rate limits, retries and timeouts to the requests
+// it sends through its base transport
+type Transport struct {
+	base     http.RoundTripper
+	settings Settings
+
+	mu       sync.Mutex
+	limiters map[string]*limiter
+}
+
+// NewTransport creates a transport sending requests through base, http.DefaultTransport when nil
+func NewTransport(base http.RoundTripper, settings Settings) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{
+		base:     base,
+		settings: settings,
+		limiters: make(map[string]*limiter),
+	}
+}
+
+// New creates an HTTP client sending its requests through a new transport
+func New(settings Settings) *http.Client {
+	return &http.Client{Transport: NewTransport(nil, settings)}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	host := req.URL.Hostname()
+
+	for attempt := 0; ; attempt++ {
+		if err := t.waitForLimit(ctx, host); err != nil {
+			return nil, err
+		}
+
+		attemptReq := req
+		if attempt > 0 && req.Body != nil && req.Body != http.NoBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = body
+		}
+
+		started := time.Now()
+		resp, err := t.send(attemptReq)
+		if t.settings.OnAttempt != nil {
+			status := 0
+			if resp != nil {
+				status = resp.StatusCode
+			}
+			t.settings.OnAttempt(host, status, err, time.Since(started))
+		}
+
+		if attempt >= t.settings.MaxRetries || !t.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt, resp)
+		if resp != nil {
+			// Drain the body so the connection can be reused
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+			resp.Body.Close()
+		}
+		if t.settings.OnRetry != nil {
+			t.settings.OnRetry(host, attempt+1, delay)
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// send makes one attempt, bounded by the attempt timeout until the response body is closed
+func (t *Transport) send(req *http.Request) (*http.Response, error) {
+	if t.settings.Timeout <= 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.settings.Timeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// shouldRetry tells whether an attempt failed in a way worth retrying
+func (t *Transport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	// The body can't be sent again
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	if err != nil {
+		return isIdempotent(req)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req)
+	}
+	return false
+}
+
+// backoff returns how long to wait before retrying after an attempt
+func (t *Transport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if delay, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			if t.settings.MaxDelay > 0 && delay > t.settings.MaxDelay {
+				delay = t.settings.MaxDelay
+			}
+			return delay
+		}
+	}
+
+	delay := float64(t.settings.BaseDelay) * math.Pow(2, float64(attempt))
+	if t.settings.MaxDelay > 0 && delay > float64(t.settings.MaxDelay) {
+		delay = float64(t.settings.MaxDelay)
+	}
+	if t.settings.Jitter > 0 {
+		delay += delay * t.settings.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(delay)
+}
+
+// waitForLimit blocks until the rate limit of a host lets a request through
+func (t *Transport) waitForLimit(ctx context.Context, host string) error {
+	l := t.limiter(host)
+	if l == nil {
+		return nil
+	}
+
+	wait := l.reserve(time.Now())
+	if wait <= 0 {
+		return nil
+	}
+	if t.settings.OnThrottle != nil {
+		t.settings.OnThrottle(host, wait)
+	}
+	return sleep(ctx, wait)
+}
+
+// limiter returns the rate limiter of a host, nil when the host is unlimited
+func (t *Transport) limiter(host string) *limiter {
+	limit, exists := t.settings.HostLimits[host]
+	if !exists {
+		limit = t.settings.DefaultLimit
+	}
+	if limit.PerSecond <= 0 {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	l, exists := t.limiters[host]
+	if !exists {
+		l = newLimiter(limit)
+		t.limiters[host] = l
+	}
+	return l
+}
+
+// limiter is a token bucket. Requests reserve a token even when none is left and wait until it
+// is refilled, so waiting requests go out in the order they arrived.
+type limiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newLimiter creates a limiter with a full bucket
+func newLimiter(limit Limit) *limiter {
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &limiter{rate: limit.PerSecond, burst: burst, tokens: burst}
+}
+
+// reserve takes a token and returns how long to wait before using it
+func (l *limiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancelOnClose releases the attempt timeout once the response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// isIdempotent tells whether sending a request twice has the same effect as sending it once
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		delay := time.Until(at)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+// sleep waits for a duration or until the context is done
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
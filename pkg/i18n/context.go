@@ -0,0 +1,40 @@
+package i18n
+
+import (
+	"context"
+	"time"
+)
+
+// Preferences are the locale and timezone something is presented in: an API response, an
+// export, a notification
+type Preferences struct {
+	Locale   string
+	Location *time.Location
+}
+
+// DefaultPreferences returns DefaultLocale and UTC, what is used when nothing better is known
+func DefaultPreferences() Preferences {
+	return Preferences{Locale: DefaultLocale, Location: time.UTC}
+}
+
+// Formatter returns a formatter for the preferences
+func (p Preferences) Formatter() *Formatter {
+	return NewFormatter(p.Locale, p.Location)
+}
+
+// preferencesKey is the context key holding the preferences
+type preferencesKey struct{}
+
+// WithPreferences returns a copy of ctx carrying the preferences, so the layers below a request
+// format for the caller without being passed the request
+func WithPreferences(ctx context.Context, preferences Preferences) context.Context {
+	return context.WithValue(ctx, preferencesKey{}, preferences)
+}
+
+// PreferencesFrom returns the preferences ctx carries, DefaultPreferences when it carries none
+func PreferencesFrom(ctx context.Context) Preferences {
+	if preferences, ok := ctx.Value(preferencesKey{}).(Preferences); ok {
+		return preferences
+	}
+	return DefaultPreferences()
+}
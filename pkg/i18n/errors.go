@@ -0,0 +1,256 @@
+package i18n
+
+import "strings"
+
+// errorPrefixes maps the start of the English error messages the API returns to their codes.
+// Handlers and services keep writing plain English; LocalizeError matches their messages here.
+var errorPrefixes = []struct {
+	prefix string
+	code   string
+}{
+	{"invalid request", "invalid_request"},
+	{"invalid configuration document", "invalid_request"},
+	{"authentication required", "authentication_required"},
+	{"authorization header required", "authentication_required"},
+	{"invalid authorization format", "authentication_required"},
+	{"invalid token", "invalid_token"},
+	{"token has expired", "invalid_token"},
+	{"token has been revoked", "token_revoked"},
+	{"you don't have permission", "forbidden"},
+	{"you do not have permission", "forbidden"},
+	{"only staff", "forbidden"},
+	{"only dock staff", "forbidden"},
+	{"only admins", "forbidden"},
+	{"only administrators", "forbidden"},
+	{"only suppliers and employees", "forbidden"},
+	{"only suppliers can", "forbidden"},
+	{"suppliers can only", "forbidden"},
+	{"rate limit exceeded", "rate_limited"},
+	{"endpoint not found", "not_found"},
+	{"invalid appointment id", "invalid_id"},
+	{"invalid operation id", "invalid_id"},
+	{"invalid supplier id", "invalid_id"},
+	{"invalid employee id", "invalid_id"},
+	{"invalid incident id", "invalid_id"},
+	{"invalid exception id", "invalid_id"},
+	{"invalid visitor id", "invalid_id"},
+	{"invalid document id", "invalid_id"},
+	{"invalid queue item id", "invalid_id"},
+	{"invalid invitation id", "invalid_id"},
+	{"invalid watcher id", "invalid_id"},
+	{"invalid billing code id", "invalid_id"},
+	{"invalid fee id", "invalid_id"},
+	{"invalid slot watch id", "invalid_id"},
+	{"invalid slot opening id", "invalid_id"},
+	{"invalid consumer id", "invalid_id"},
+	{"appointment not found", "appointment_not_found"},
+	{"operation not found", "operation_not_found"},
+	{"supplier not found", "supplier_not_found"},
+	{"employee not found", "employee_not_found"},
+	{"product not found", "product_not_found"},
+	{"invalid supplier:", "invalid_supplier"},
+	{"invalid employee:", "invalid_employee"},
+	{"invalid operation:", "invalid_operation"},
+	{"invalid product:", "invalid_product"},
+	{"invalid phone number", "invalid_phone"},
+	{"availability slot ", "invalid_availability_slot"},
+	{"duplicate rule for", "invalid_visibility_rule"},
+	{"invalid schedule", "invalid_schedule"},
+	{"job not found", "job_not_found"},
+	{"appointment conflicts with an existing appointment", "appointment_conflict"},
+	{"updated appointment conflicts with an existing appointment", "appointment_conflict"},
+	{"appointment must be within operation hours", "outside_operation_hours"},
+	{"appointment must be scheduled for a future date", "appointment_in_past"},
+	{"scheduled start time must be before scheduled end time", "invalid_time_range"},
+	{"start time must be before end time", "invalid_time_range"},
+	{"end must be after start", "invalid_time_range"},
+	{"start_date must be before end_date", "invalid_time_range"},
+	{"appointment is already cancelled or completed", "appointment_closed"},
+	{"appointment is cancelled or completed", "appointment_closed"},
+	{"cannot update cancelled or completed appointments", "appointment_closed"},
+	{"operation capacity reached for this appointment type", "type_capacity_reached"},
+	{"operation capacity reached for this time band", "band_capacity_reached"},
+	{"only today or a later date can have its capacity changed", "capacity_past_date"},
+	{"capacity calendars can be shown for at most", "capacity_range"},
+	{"capacity bands of the same type cannot overlap", "capacity_overlap"},
+	{"supplier limit of simultaneous appointments reached", "supplier_limit_reached"},
+	{"supplier has missing or expired required documents", "documents_missing"},
+	{"visitor limit reached", "visitor_limit_reached"},
+	{"invalid check-in code", "invalid_check_in_code"},
+	{"check-in code has expired", "check_in_code_expired"},
+	{"appointment is already checked in", "already_checked_in"},
+	{"check-in is only allowed on the day of the appointment", "check_in_outside_window"},
+	{"linked inbound delivery has not been completed yet", "inbound_not_completed"},
+	{"feedback can only be left on completed appointments", "feedback_not_open"},
+	{"feedback was already left on this appointment", "feedback_already_given"},
+	{"incident is already resolved", "incident_resolved"},
+	{"the automatic completion can no longer be undone", "undo_window_expired"},
+	{"unsupported api version", "unsupported_api_version"},
+	{"failed to read request body", "request_body_unreadable"},
+	{"invalid date format", "invalid_date"},
+	{"invalid start_date format", "invalid_date"},
+	{"invalid end_date format", "invalid_date"},
+	{"date range cannot exceed", "date_range_too_long"},
+	{"the range can span at most", "date_range_too_long"},
+	{"rating must be between 1 and 5", "rating_out_of_range"},
+	{"resolution is required", "resolution_required"},
+	{"notifications are already paused", "notifications_paused"},
+	{"notifications are not paused", "notifications_not_paused"},
+	{"booking invitation not found", "invitation_not_found"},
+	{"booking invitation has expired", "invitation_unavailable"},
+	{"booking invitation was already used", "invitation_unavailable"},
+	{"booking invitation was revoked", "invitation_unavailable"},
+	{"the slot is outside the invitation's booking window", "slot_outside_window"},
+	{"the slot is no longer open", "slot_not_open"},
+	{"watcher not found", "watcher_not_found"},
+	{"billing code not found", "billing_code_not_found"},
+	{"billing code already exists", "billing_code_exists"},
+	{"unknown or inactive", "invalid_billing_code"},
+	{"fee not found", "fee_not_found"},
+	{"only charged fees can be disputed", "fee_not_disputable"},
+	{"only disputed fees can be upheld", "fee_not_disputed"},
+	{"fee is already waived", "fee_waived"},
+	{"only confirmed appointments can be marked no-show", "no_show_not_allowed"},
+	{"an appointment can't be marked no-show before its scheduled start", "no_show_too_early"},
+	{"the user is already watching this appointment", "already_watching"},
+	{"operation is closed on this date", "operation_closed"},
+	{"blackout date not found", "blackout_not_found"},
+	{"imported holidays cannot be deleted", "blackout_imported"},
+	{"operation does not import public holidays", "holidays_not_imported"},
+	{"no holiday provider is configured", "holidays_disabled"},
+	{"holiday provider request failed", "holiday_sync_failed"},
+	{"date must be in yyyy-mm-dd format", "invalid_date"},
+	{"invalid year", "invalid_year"},
+	{"invalid days", "invalid_days"},
+	{"days must be between", "invalid_days"},
+	{"waitlist entry not found", "waitlist_not_found"},
+	{"only today or a later date can be waitlisted", "waitlist_past_date"},
+	{"the supplier is already waitlisted for this date", "waitlist_duplicate"},
+	{"only waiting entries can be withdrawn", "waitlist_not_waiting"},
+	{"waitlists can be listed for at most", "waitlist_range"},
+	{"only suppliers can join a waitlist", "waitlist_supplier"},
+	{"employee lacks the skills this appointment requires", "employee_unqualified"},
+	{"no qualified employee is available for this slot", "no_qualified_employee"},
+	{"only employees have a day to show", "not_employee"},
+	{"since must be an rfc 3339 timestamp or a cursor", "invalid_sync_cursor"},
+	{"no supplier or employee profile to sync", "sync_no_profile"},
+	{"partner requests must carry", "partner_signature_missing"},
+	{"unknown or revoked partner key", "partner_unknown_key"},
+	{"invalid partner request signature", "partner_bad_signature"},
+	{"partner request timestamp is outside", "partner_stale_request"},
+	{"partner request nonce must be", "partner_invalid_nonce"},
+	{"partner request nonce was already used", "partner_replayed"},
+	{"partner credential is revoked", "partner_revoked"},
+	{"partner credential not found", "partner_not_found"},
+	{"schema not found", "schema_not_found"},
+	{"event must be a cloudevent", "invalid_event"},
+	{"bi export is disabled", "bi_export_disabled"},
+	{"a bi export is already running", "bi_export_running"},
+	{"bi export run not found", "bi_export_not_found"},
+	{"slot watch not found", "slot_watch_not_found"},
+	{"only today or a later date can be watched", "slot_watch_past_date"},
+	{"the supplier already watches this operation", "slot_watch_duplicate"},
+	{"only suppliers can watch slots", "slot_watch_supplier"},
+	{"slot opening not found", "slot_opening_not_found"},
+	{"the opened slot is no longer available", "slot_opening_closed"},
+	{"every place of the opened slot was already taken", "slot_opening_taken"},
+	{"the opened slot is for another appointment type", "slot_opening_type"},
+	{"the appointment must fit in the opened time", "slot_outside_opening"},
+	{"invalid usage grouping", "invalid_usage_group"},
+	{"invalid consumer type", "invalid_usage_consumer"},
+	{"setting not found", "setting_not_found"},
+	{"invalid value for setting", "invalid_setting_value"},
+	{"value is required", "setting_value_required"},
+	{"unsupported label format", "unsupported_label_format"},
+	{"invalid label count", "invalid_label_count"},
+	{"invalid label template", "invalid_label_template"},
+	{"calendar feed not found", "calendar_feed_not_found"},
+	{"calendar feed limit reached", "calendar_feed_limit"},
+	{"only staff with an employee record can subscribe", "calendar_feed_assignments"},
+	{"feed name is required", "feed_name_required"},
+	{"feed name must be at most", "feed_name_too_long"},
+	{"invalid broadcast message", "invalid_broadcast"},
+	{"broadcast audience is empty", "broadcast_audience_empty"},
+	{"broadcast audience too large", "broadcast_audience_limit"},
+	{"invalid broadcast send time", "broadcast_send_at"},
+	{"invalid broadcast audience", "broadcast_audience"},
+	{"invalid broadcast channel", "broadcast_channel"},
+	{"operation is required for the operation_suppliers", "broadcast_operation"},
+	{"from and to are required for the suppliers_in_range", "broadcast_range"},
+	{"broadcast range", "broadcast_range"},
+	{"broadcast subject and body are required", "broadcast_message"},
+	{"operation requires a deposit but no payment provider", "deposits_unavailable"},
+	{"payment provider request failed", "deposit_request"},
+	{"invalid payment event", "invalid_payment_event"},
+	{"deposit not found", "deposit_not_found"},
+	{"deposit cannot be negative", "deposit_negative"},
+	{"deposit has more decimal places", "deposit_decimals"},
+	{"unsupported locale", "unsupported_locale"},
+	{"unknown timezone", "unknown_timezone"},
+	{"quick-add text is required", "quick_add_text"},
+	{"unknown denial grouping", "denial_group"},
+	{"unknown denial reason", "denial_reason"},
+	{"draft not found", "draft_not_found"},
+	{"draft limit reached", "draft_limit_reached"},
+	{"draft must be a JSON object", "draft_payload"},
+	{"draft is too large", "draft_too_large"},
+	{"draft key must be", "draft_key"},
+	{"no work calendar connected", "calendar_not_connected"},
+	{"calendar provider is not configured", "calendar_provider_unconfigured"},
+	{"only staff with an employee record can connect a work calendar", "calendar_employee"},
+	{"work calendar could not be read", "calendar_unreadable"},
+	{"calendar provider must be", "calendar_provider"},
+	{"microsoft calendars need the mailbox address", "calendar_mailbox"},
+	{"calendar access token or refresh token is required", "calendar_token"},
+	{"region not found", "region_not_found"},
+	{"you don't manage this region", "region_forbidden"},
+	{"region code is already in use", "region_code_taken"},
+	{"region operation not found", "region_operation"},
+	{"region managers must be staff", "region_manager"},
+	{"region codes are up to", "region_code"},
+	{"region name is required", "region_name"},
+	{"invalid experiment id", "invalid_id"},
+	{"invalid user id", "invalid_id"},
+	{"template experiment not found", "experiment_not_found"},
+	{"an experiment is already running", "experiment_running"},
+	{"template experiment is already stopped", "experiment_stopped"},
+	{"experiment template not found", "experiment_template"},
+	{"experiment templates must be for", "experiment_mismatch"},
+	{"experiment name is required", "experiment_name"},
+	{"experiment event, recipient type and notification type are required", "experiment_target"},
+	{"experiment variants need a template each", "experiment_template"},
+	{"experiment variants must use different templates", "experiment_same_template"},
+	{"experiment split must be between", "experiment_split"},
+}
+
+// LocalizedError is an API error message translated for a client
+type LocalizedError struct {
+	Code    string `json:"code"`    // machine code, stable across releases and locales
+	Message string `json:"message"` // the message in the client's locale
+}
+
+// LocalizeError translates an English API error message. The longest known prefix decides the
+// code; details after a colon, such as validation output, are kept as they are. Unknown
+// messages get the code "unknown" and are returned untranslated.
+func LocalizeError(locale, message string) LocalizedError {
+	lower := strings.ToLower(strings.TrimSpace(message))
+
+	code, matched := "", 0
+	for _, entry := range errorPrefixes {
+		if len(entry.prefix) > matched && strings.HasPrefix(lower, entry.prefix) {
+			code, matched = entry.code, len(entry.prefix)
+		}
+	}
+	if code == "" {
+		return LocalizedError{Code: "unknown", Message: message}
+	}
+
+	translated, ok := Message(locale, "error."+code)
+	if !ok {
+		return LocalizedError{Code: code, Message: message}
+	}
+	if i := strings.Index(message, ": "); i >= 0 {
+		translated += message[i:]
+	}
+	return LocalizedError{Code: code, Message: translated}
+}
@@ -0,0 +1,346 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultLocale is used when a recipient has no locale or an unsupported one
+const DefaultLocale = "en-US"
+
+// Date styles accepted by FormatDate
+const (
+	// StyleShort formats dates numerically, e.g. 01/02/2006
+	StyleShort = "short"
+
+	// StyleMedium formats dates with the month name, e.g. January 2, 2006
+	StyleMedium = "medium"
+
+	// StyleLong formats dates with the weekday and month name, e.g. Monday, January 2, 2006
+	StyleLong = "long"
+)
+
+// localeData holds the formatting rules of a locale
+type localeData struct {
+	months       [12]string
+	weekdays     [7]string // starting on Sunday
+	shortLayout  string    // Go layout for numeric dates
+	mediumFormat string    // placeholders: {weekday} {day} {month} {year}
+	longFormat   string
+	timeLayout   string // Go layout for times
+	decimalSep   string
+	groupSep     string
+	currency     string // placeholders: {symbol} {amount}
+}
+
+var locales = map[string]*localeData{
+	"en-US": {
+		months: [12]string{"January", "February", "March", "April", "May", "June",
+			"July", "August", "September", "October", "November", "December"},
+		weekdays:     [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		shortLayout:  "01/02/2006",
+		mediumFormat: "{month} {day}, {year}",
+		longFormat:   "{weekday}, {month} {day}, {year}",
+		timeLayout:   "3:04 PM",
+		decimalSep:   ".",
+		groupSep:     ",",
+		currency:     "{symbol}{amount}",
+	},
+	"pt-BR": {
+		months: [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho",
+			"julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		weekdays:     [7]string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+		shortLayout:  "02/01/2006",
+		mediumFormat: "{day} de {month} de {year}",
+		longFormat:   "{weekday}, {day} de {month} de {year}",
+		timeLayout:   "15:04",
+		decimalSep:   ",",
+		groupSep:     ".",
+		currency:     "{symbol} {amount}",
+	},
+	"es-ES": {
+		months: [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio",
+			"julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		weekdays:     [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		shortLayout:  "02/01/2006",
+		mediumFormat: "{day} de {month} de {year}",
+		longFormat:   "{weekday}, {day} de {month} de {year}",
+		timeLayout:   "15:04",
+		decimalSep:   ",",
+		groupSep:     ".",
+		currency:     "{amount} {symbol}",
+	},
+}
+
+// currencySymbols maps ISO 4217 codes to their symbols; other codes are printed as is
+var currencySymbols = map[string]string{
+	"BRL": "R$",
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"ARS": "$",
+	"MXN": "$",
+}
+
+// Formatter formats dates, times and numbers for a locale and timezone
+type Formatter struct {
+	locale   string
+	data     *localeData
+	location *time.Location
+}
+
+// NewFormatter creates a formatter. Unsupported locales fall back to another locale of the
+// same language, then to DefaultLocale; a nil location means UTC.
+func NewFormatter(locale string, location *time.Location) *Formatter {
+	resolved := ResolveLocale(locale)
+	if location == nil {
+		location = time.UTC
+	}
+	return &Formatter{
+		locale:   resolved,
+		data:     locales[resolved],
+		location: location,
+	}
+}
+
+// ResolveLocale returns the supported locale closest to the requested one
+func ResolveLocale(locale string) string {
+	locale = strings.ReplaceAll(strings.TrimSpace(locale), "_", "-")
+	for supported := range locales {
+		if strings.EqualFold(supported, locale) {
+			return supported
+		}
+	}
+
+	language := strings.ToLower(strings.SplitN(locale, "-", 2)[0])
+	for _, supported := range []string{"en-US", "pt-BR", "es-ES"} {
+		if strings.HasPrefix(strings.ToLower(supported), language+"-") {
+			return supported
+		}
+	}
+	return DefaultLocale
+}
+
+// LoadLocation loads a timezone by name, returning UTC for empty or unknown names
+func LoadLocation(name string) *time.Location {
+	if name == "" {
+		return time.UTC
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// Locale returns the locale used by the formatter
+func (f *Formatter) Locale() string {
+	return f.locale
+}
+
+// FormatDate formats the date part of t in the given style
+func (f *Formatter) FormatDate(t time.Time, style string) string {
+	t = t.In(f.location)
+	switch style {
+	case StyleShort:
+		return t.Format(f.data.shortLayout)
+	case StyleMedium:
+		return f.expand(f.data.mediumFormat, t)
+	default:
+		return f.expand(f.data.longFormat, t)
+	}
+}
+
+// FormatTime formats the time of day of t
+func (f *Formatter) FormatTime(t time.Time) string {
+	return t.In(f.location).Format(f.data.timeLayout)
+}
+
+// FormatDateTime formats the date in the given style followed by the time of day
+func (f *Formatter) FormatDateTime(t time.Time, style string) string {
+	return f.FormatDate(t, style) + " " + f.FormatTime(t)
+}
+
+// FormatNumber formats a number with a fixed number of decimals and locale separators
+func (f *Formatter) FormatNumber(value float64, decimals int) string {
+	if decimals < 0 {
+		decimals = 0
+	}
+	formatted := strconv.FormatFloat(value, 'f', decimals, 64)
+
+	sign := ""
+	if strings.HasPrefix(formatted, "-") {
+		sign, formatted = "-", formatted[1:]
+	}
+
+	integer, fraction := formatted, ""
+	if i := strings.IndexByte(formatted, '.'); i >= 0 {
+		integer, fraction = formatted[:i], formatted[i+1:]
+	}
+
+	var grouped strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			grouped.WriteString(f.data.groupSep)
+		}
+		grouped.WriteRune(digit)
+	}
+
+	if fraction == "" {
+		return sign + grouped.String()
+	}
+	return sign + grouped.String() + f.data.decimalSep + fraction
+}
+
+// FormatQuantity formats a quantity with up to three decimals, dropping trailing zeros
+func (f *Formatter) FormatQuantity(value float64) string {
+	formatted := f.FormatNumber(value, 3)
+	if strings.Contains(formatted, f.data.decimalSep) {
+		formatted = strings.TrimRight(formatted, "0")
+		formatted = strings.TrimSuffix(formatted, f.data.decimalSep)
+	}
+	return formatted
+}
+
+// FormatCurrency formats an amount of money in the given ISO 4217 currency
+func (f *Formatter) FormatCurrency(value float64, code string) string {
+	code = strings.ToUpper(code)
+	symbol, ok := currencySymbols[code]
+	if !ok {
+		symbol = code
+	}
+
+	amount := f.FormatNumber(value, 2)
+	sign := ""
+	if strings.HasPrefix(amount, "-") {
+		sign, amount = "-", amount[1:]
+	}
+
+	return sign + strings.NewReplacer("{symbol}", symbol, "{amount}", amount).Replace(f.data.currency)
+}
+
+// FuncMap returns the template functions of the formatter:
+//
+//	{{formatDate .scheduled_start "long"}}
+//	{{formatTime .scheduled_start}}
+//	{{formatDateTime .scheduled_start "short"}}
+//	{{formatNumber .amount 2}}
+//	{{formatQuantity .quantity_to_deliver}}
+//	{{formatCurrency .amount "BRL"}}
+//
+// Dates may be time values or RFC 3339 strings, numbers any numeric value or numeric string.
+func (f *Formatter) FuncMap() map[string]interface{} {
+	return map[string]interface{}{
+		"formatDate": func(value interface{}, style ...string) (string, error) {
+			t, err := ToTime(value)
+			if err != nil {
+				return "", err
+			}
+			if len(style) == 0 {
+				return f.FormatDate(t, StyleLong), nil
+			}
+			return f.FormatDate(t, style[0]), nil
+		},
+		"formatTime": func(value interface{}) (string, error) {
+			t, err := ToTime(value)
+			if err != nil {
+				return "", err
+			}
+			return f.FormatTime(t), nil
+		},
+		"formatDateTime": func(value interface{}, style ...string) (string, error) {
+			t, err := ToTime(value)
+			if err != nil {
+				return "", err
+			}
+			if len(style) == 0 {
+				return f.FormatDateTime(t, StyleMedium), nil
+			}
+			return f.FormatDateTime(t, style[0]), nil
+		},
+		"formatNumber": func(value interface{}, decimals int) (string, error) {
+			number, err := ToFloat(value)
+			if err != nil {
+				return "", err
+			}
+			return f.FormatNumber(number, decimals), nil
+		},
+		"formatQuantity": func(value interface{}) (string, error) {
+			number, err := ToFloat(value)
+			if err != nil {
+				return "", err
+			}
+			return f.FormatQuantity(number), nil
+		},
+		"formatCurrency": func(value interface{}, code string) (string, error) {
+			number, err := ToFloat(value)
+			if err != nil {
+				return "", err
+			}
+			return f.FormatCurrency(number, code), nil
+		},
+	}
+}
+
+// expand fills the placeholders of a date format
+func (f *Formatter) expand(format string, t time.Time) string {
+	return strings.NewReplacer(
+		"{weekday}", f.data.weekdays[t.Weekday()],
+		"{day}", strconv.Itoa(t.Day()),
+		"{month}", f.data.months[t.Month()-1],
+		"{year}", strconv.Itoa(t.Year()),
+	).Replace(format)
+}
+
+// ToTime converts a template value to a time
+func ToTime(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case *time.Time:
+		if v != nil {
+			return *v, nil
+		}
+	case string:
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date %q: %w", v, err)
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("cannot format %T as a date", value)
+}
+
+// ToFloat converts a template value to a number
+func ToFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case uint:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case uint32:
+		return float64(v), nil
+	case json.Number:
+		return v.Float64()
+	case string:
+		number, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number %q: %w", v, err)
+		}
+		return number, nil
+	}
+	return 0, fmt.Errorf("cannot format %T as a number", value)
+}
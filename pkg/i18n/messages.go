@@ -0,0 +1,566 @@
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Label kinds, the enums with translated labels
+const (
+	KindStatus           = "status"
+	KindType             = "type"
+	KindEvent            = "event"
+	KindIncidentCategory = "incident_category"
+	KindIncidentSeverity = "incident_severity"
+	KindExceptionReason  = "exception_reason"
+)
+
+// labelKinds lists the label kinds in the order they are reported
+var labelKinds = []string{KindStatus, KindType, KindEvent, KindIncidentCategory, KindIncidentSeverity, KindExceptionReason}
+
+// messageCatalogs holds the API messages of the locales the API speaks. Labels are keyed
+// "<kind>.<code>", e.g. "status.pending"; errors "error.<code>". Locales with formatting rules
+// but no catalog, such as es-ES, get English messages.
+var messageCatalogs = map[string]map[string]string{
+	"en-US": {
+		"status.pending":             "Pending",
+		"status.confirmed":           "Confirmed",
+		"status.cancelled":           "Cancelled",
+		"status.completed":           "Completed",
+		"status.rescheduled":         "Rescheduled",
+		"status.partially_completed": "Partially completed",
+		"status.no_show":             "No-show",
+		"status.reserved":            "Awaiting deposit",
+
+		"type.delivery":      "Delivery",
+		"type.pickup":        "Pickup",
+		"type.service_visit": "Service visit",
+
+		"event.appointment_created":            "Appointment scheduled",
+		"event.appointment_updated":            "Appointment updated",
+		"event.appointment_cancelled":          "Appointment cancelled",
+		"event.appointment_confirmed":          "Appointment confirmed",
+		"event.appointment_completed":          "Appointment completed",
+		"event.appointment_reminder":           "Appointment reminder",
+		"event.supplier_document_expiring":     "Document expiring",
+		"event.appointment_arriving":           "Driver arriving",
+		"event.appointment_delayed":            "Appointment delayed",
+		"event.appointment_feedback_requested": "Feedback requested",
+		"event.appointment_overdue":            "Appointment left open",
+		"event.appointment_expiring":           "Appointment expiring",
+		"event.booking_invitation":             "Booking invitation",
+		"event.fee_charged":                    "Fee charged",
+		"event.waitlist_slot_opened":           "Waitlist slot opened",
+		"event.slot_opened":                    "Slot opened",
+		"event.shift_handover":                 "Shift handover",
+		"event.broadcast":                      "Announcement",
+
+		"incident_category.damaged_goods":  "Damaged goods",
+		"incident_category.wrong_quantity": "Wrong quantity",
+		"incident_category.wrong_product":  "Wrong product",
+		"incident_category.late_arrival":   "Late arrival",
+		"incident_category.documentation":  "Documentation",
+		"incident_category.other":          "Other",
+
+		"incident_severity.low":      "Low",
+		"incident_severity.medium":   "Medium",
+		"incident_severity.high":     "High",
+		"incident_severity.critical": "Critical",
+
+		"exception_reason.vacation":   "Vacation",
+		"exception_reason.sick_leave": "Sick leave",
+		"exception_reason.training":   "Training",
+		"exception_reason.other":      "Other",
+
+		"error.invalid_request":                "Invalid request",
+		"error.authentication_required":        "Authentication required",
+		"error.invalid_token":                  "Invalid or expired token",
+		"error.forbidden":                      "You don't have permission to do this",
+		"error.rate_limited":                   "Too many requests. Please try again later.",
+		"error.not_found":                      "Not found",
+		"error.invalid_id":                     "Invalid ID",
+		"error.appointment_not_found":          "Appointment not found",
+		"error.operation_not_found":            "Operation not found",
+		"error.supplier_not_found":             "Supplier not found",
+		"error.employee_not_found":             "Employee not found",
+		"error.product_not_found":              "Product not found",
+		"error.invalid_supplier":               "Invalid supplier",
+		"error.invalid_employee":               "Invalid employee",
+		"error.invalid_operation":              "Invalid operation",
+		"error.invalid_product":                "Invalid product",
+		"error.invalid_phone":                  "Invalid phone number",
+		"error.invalid_availability_slot":      "Invalid availability slot",
+		"error.invalid_visibility_rule":        "Invalid visibility rule",
+		"error.invalid_schedule":               "Invalid job schedule",
+		"error.job_not_found":                  "Job not found",
+		"error.appointment_conflict":           "The appointment conflicts with an existing appointment",
+		"error.outside_operation_hours":        "The appointment must be within operation hours",
+		"error.appointment_in_past":            "The appointment must be scheduled for a future date",
+		"error.invalid_time_range":             "The start must be before the end",
+		"error.appointment_closed":             "The appointment is already cancelled or completed",
+		"error.type_capacity_reached":          "The operation has no room left for this appointment type",
+		"error.band_capacity_reached":          "The operation has no room left in this time band",
+		"error.capacity_past_date":             "Only today or a later date can have its capacity changed",
+		"error.capacity_range":                 "Capacity calendars can be shown for at most 92 days at a time",
+		"error.capacity_overlap":               "Capacity bands of the same type cannot overlap",
+		"error.supplier_limit_reached":         "The supplier already has the most simultaneous appointments this operation allows",
+		"error.documents_missing":              "The supplier has missing or expired required documents",
+		"error.visitor_limit_reached":          "Visitor limit reached for this appointment",
+		"error.invalid_check_in_code":          "Invalid check-in code",
+		"error.check_in_code_expired":          "The check-in code has expired",
+		"error.already_checked_in":             "The appointment is already checked in",
+		"error.check_in_outside_window":        "Check-in is only allowed on the day of the appointment",
+		"error.inbound_not_completed":          "The linked inbound delivery has not been completed yet",
+		"error.feedback_not_open":              "Feedback can only be left on completed appointments",
+		"error.feedback_already_given":         "Feedback was already left on this appointment",
+		"error.incident_resolved":              "The incident is already resolved",
+		"error.undo_window_expired":            "The automatic completion can no longer be undone",
+		"error.unsupported_api_version":        "Unsupported API version",
+		"error.request_body_unreadable":        "Failed to read request body",
+		"error.invalid_date":                   "Invalid date",
+		"error.date_range_too_long":            "The date range is too long",
+		"error.rating_out_of_range":            "The rating must be between 1 and 5",
+		"error.resolution_required":            "A resolution is required",
+		"error.notifications_paused":           "Notifications are already paused",
+		"error.notifications_not_paused":       "Notifications are not paused",
+		"error.invitation_not_found":           "Booking invitation not found",
+		"error.invitation_unavailable":         "This booking link has expired or was already used",
+		"error.slot_outside_window":            "The slot is outside the invitation's booking window",
+		"error.slot_not_open":                  "The slot is no longer open",
+		"error.watcher_not_found":              "Watcher not found",
+		"error.already_watching":               "The user is already watching this appointment",
+		"error.billing_code_not_found":         "Billing code not found",
+		"error.billing_code_exists":            "This billing code already exists",
+		"error.invalid_billing_code":           "The cost center or billing code is unknown or inactive",
+		"error.fee_not_found":                  "Fee not found",
+		"error.fee_not_disputable":             "Only charged fees can be disputed",
+		"error.fee_not_disputed":               "Only disputed fees can be upheld",
+		"error.fee_waived":                     "This fee was already waived",
+		"error.no_show_not_allowed":            "Only confirmed appointments can be marked no-show",
+		"error.no_show_too_early":              "An appointment can't be marked no-show before it starts",
+		"error.operation_closed":               "The operation is closed on this date",
+		"error.blackout_not_found":             "Blackout date not found",
+		"error.blackout_imported":              "Imported holidays can't be deleted; set the date open instead",
+		"error.holidays_not_imported":          "This operation doesn't import public holidays",
+		"error.holidays_disabled":              "No holiday provider is configured",
+		"error.holiday_sync_failed":            "The holiday provider could not be reached",
+		"error.invalid_year":                   "Invalid year",
+		"error.invalid_days":                   "Days must be between 1 and 92",
+		"error.waitlist_not_found":             "Waitlist entry not found",
+		"error.waitlist_past_date":             "Only today or a later date can be waitlisted",
+		"error.waitlist_duplicate":             "The supplier is already waitlisted for this date",
+		"error.waitlist_not_waiting":           "Only waiting entries can be withdrawn",
+		"error.waitlist_range":                 "Waitlists can be listed for at most 92 days at a time",
+		"error.waitlist_supplier":              "Only suppliers can join a waitlist",
+		"error.employee_unqualified":           "The employee lacks the skills this appointment requires",
+		"error.no_qualified_employee":          "No qualified employee is available for this slot",
+		"error.not_employee":                   "Only employees have a day to show",
+		"error.invalid_sync_cursor":            "since must be an RFC 3339 timestamp or a cursor returned by a previous sync",
+		"error.sync_no_profile":                "No supplier or employee profile to sync for this user",
+		"error.partner_signature_missing":      "Partner requests must carry X-Partner-Key, X-Partner-Timestamp, X-Partner-Nonce and X-Partner-Signature headers",
+		"error.partner_unknown_key":            "Unknown or revoked partner key",
+		"error.partner_bad_signature":          "Invalid partner request signature",
+		"error.partner_stale_request":          "Partner request timestamp is outside the accepted window",
+		"error.partner_invalid_nonce":          "Partner request nonce must be 16 to 128 characters",
+		"error.partner_replayed":               "Partner request nonce was already used",
+		"error.partner_revoked":                "Partner credential is revoked",
+		"error.partner_not_found":              "Partner credential not found",
+		"error.schema_not_found":               "Schema not found",
+		"error.invalid_event":                  "The event must be a CloudEvent in structured JSON mode",
+		"error.bi_export_disabled":             "BI export is disabled",
+		"error.bi_export_running":              "A BI export is already running",
+		"error.bi_export_not_found":            "BI export run not found",
+		"error.slot_watch_not_found":           "Slot watch not found",
+		"error.slot_watch_past_date":           "Only today or a later date can be watched",
+		"error.slot_watch_duplicate":           "The supplier already watches this operation, date and type",
+		"error.slot_watch_supplier":            "Only suppliers can watch slots and book opened slots",
+		"error.slot_opening_not_found":         "Slot opening not found",
+		"error.slot_opening_closed":            "The opened slot is no longer available",
+		"error.slot_opening_taken":             "Every place of the opened slot was already taken",
+		"error.slot_opening_type":              "The opened slot is for another appointment type",
+		"error.slot_outside_opening":           "The appointment must fit in the opened time",
+		"error.invalid_usage_group":            "Invalid usage grouping",
+		"error.invalid_usage_consumer":         "Invalid consumer type",
+		"error.setting_not_found":              "Setting not found",
+		"error.invalid_setting_value":          "Invalid value for setting",
+		"error.setting_value_required":         "A value is required",
+		"error.unsupported_label_format":       "Unsupported label format",
+		"error.invalid_label_count":            "Print between 1 and 100 labels",
+		"error.invalid_label_template":         "Invalid label template",
+		"error.calendar_feed_not_found":        "Calendar feed not found",
+		"error.calendar_feed_limit":            "Calendar feed limit reached: delete a feed before adding another",
+		"error.calendar_feed_assignments":      "Only staff with an employee record can subscribe to their assignments",
+		"error.feed_name_required":             "Feed name is required",
+		"error.feed_name_too_long":             "Feed name must be at most 100 characters",
+		"error.invalid_broadcast":              "Invalid broadcast message: the subject or body template doesn't render",
+		"error.broadcast_audience_empty":       "Nobody matches the broadcast audience",
+		"error.broadcast_audience_limit":       "The broadcast audience is too large: narrow it down",
+		"error.broadcast_send_at":              "Invalid send time: it can't be in the past or too far ahead",
+		"error.broadcast_audience":             "Invalid broadcast audience: use operation_suppliers, employees or suppliers_in_range",
+		"error.broadcast_channel":              "Invalid broadcast channel: use email or sms",
+		"error.broadcast_operation":            "An operation is required to message its suppliers",
+		"error.broadcast_range":                "Invalid range: from and to are required, with to after from and at most 92 days apart",
+		"error.broadcast_message":              "Broadcast subject and body are required",
+		"error.deposits_unavailable":           "This operation requires a deposit, but deposits can't be collected right now",
+		"error.deposit_request":                "The payment provider couldn't be reached: try booking again shortly",
+		"error.invalid_payment_event":          "Invalid payment event",
+		"error.deposit_not_found":              "Deposit not found",
+		"error.deposit_negative":               "Deposit cannot be negative",
+		"error.deposit_decimals":               "Deposit has more decimal places than its currency allows",
+		"error.unsupported_locale":             "Unsupported locale: use en-US or pt-BR",
+		"error.unknown_timezone":               "Unknown timezone: use an IANA name such as America/Sao_Paulo",
+		"error.quick_add_text":                 "Describe the appointment in at most 500 characters",
+		"error.denial_group":                   "Group denials by operation, reason, weekday, hour or date, each at most once",
+		"error.denial_reason":                  "Unknown denial reason: use conflict, capacity, lead_time, quota or closed",
+		"error.draft_not_found":                "Draft not found or expired",
+		"error.draft_limit_reached":            "You have the most drafts allowed: delete one before saving another",
+		"error.draft_payload":                  "The draft must be a JSON object",
+		"error.draft_too_large":                "The draft is too large to save",
+		"error.draft_key":                      "Draft keys are 1 to 64 letters, digits, dashes or underscores",
+		"error.calendar_not_connected":         "You have not connected a work calendar",
+		"error.calendar_provider_unconfigured": "This calendar provider is not set up on the server",
+		"error.calendar_employee":              "Only staff with an employee record can connect a work calendar",
+		"error.calendar_unreadable":            "Your work calendar could not be read with this access",
+		"error.calendar_provider":              "The calendar provider must be google or microsoft",
+		"error.calendar_mailbox":               "Microsoft calendars need the mailbox address as the calendar",
+		"error.calendar_token":                 "An access token or refresh token is required",
+		"error.region_not_found":               "Region not found",
+		"error.region_forbidden":               "You don't manage this region",
+		"error.region_code_taken":              "Another region already uses this code",
+		"error.region_operation":               "An operation of the region was not found",
+		"error.region_manager":                 "Region managers must be staff with an employee record",
+		"error.region_code":                    "Region codes are up to 30 letters, digits, hyphens and underscores",
+		"error.region_name":                    "The region name is required",
+		"error.experiment_not_found":           "Template experiment not found",
+		"error.experiment_running":             "An experiment is already running for this event, recipient type and notification type",
+		"error.experiment_stopped":             "This template experiment is already stopped",
+		"error.experiment_template":            "An experiment template was not found",
+		"error.experiment_mismatch":            "Experiment templates must be for the experiment's event, recipient type and notification type",
+		"error.experiment_name":                "The experiment name is required",
+		"error.experiment_target":              "The experiment event, recipient type and notification type are required",
+		"error.experiment_same_template":       "Experiment variants must use different templates",
+		"error.experiment_split":               "The experiment split must be between 0 and 100 percent",
+		"error.token_revoked":                  "This session was signed out; sign in again",
+	},
+	"pt-BR": {
+		"status.pending":             "Pendente",
+		"status.confirmed":           "Confirmado",
+		"status.cancelled":           "Cancelado",
+		"status.completed":           "Concluído",
+		"status.rescheduled":         "Reagendado",
+		"status.partially_completed": "Concluído parcialmente",
+		"status.no_show":             "Não compareceu",
+		"status.reserved":            "Aguardando sinal",
+
+		"type.delivery":      "Entrega",
+		"type.pickup":        "Coleta",
+		"type.service_visit": "Visita técnica",
+
+		"event.appointment_created":            "Agendamento criado",
+		"event.appointment_updated":            "Agendamento atualizado",
+		"event.appointment_cancelled":          "Agendamento cancelado",
+		"event.appointment_confirmed":          "Agendamento confirmado",
+		"event.appointment_completed":          "Agendamento concluído",
+		"event.appointment_reminder":           "Lembrete de agendamento",
+		"event.supplier_document_expiring":     "Documento vencendo",
+		"event.appointment_arriving":           "Motorista chegando",
+		"event.appointment_delayed":            "Agendamento atrasado",
+		"event.appointment_feedback_requested": "Avaliação solicitada",
+		"event.appointment_overdue":            "Agendamento em aberto",
+		"event.appointment_expiring":           "Agendamento prestes a expirar",
+		"event.booking_invitation":             "Convite para agendamento",
+		"event.fee_charged":                    "Taxa cobrada",
+		"event.waitlist_slot_opened":           "Vaga aberta na lista de espera",
+		"event.slot_opened":                    "Horário liberado",
+		"event.shift_handover":                 "Passagem de turno",
+		"event.broadcast":                      "Comunicado",
+
+		"incident_category.damaged_goods":  "Mercadoria avariada",
+		"incident_category.wrong_quantity": "Quantidade incorreta",
+		"incident_category.wrong_product":  "Produto incorreto",
+		"incident_category.late_arrival":   "Chegada atrasada",
+		"incident_category.documentation":  "Documentação",
+		"incident_category.other":          "Outro",
+
+		"incident_severity.low":      "Baixa",
+		"incident_severity.medium":   "Média",
+		"incident_severity.high":     "Alta",
+		"incident_severity.critical": "Crítica",
+
+		"exception_reason.vacation":   "Férias",
+		"exception_reason.sick_leave": "Licença médica",
+		"exception_reason.training":   "Treinamento",
+		"exception_reason.other":      "Outro",
+
+		"error.invalid_request":                "Requisição inválida",
+		"error.authentication_required":        "Autenticação obrigatória",
+		"error.invalid_token":                  "Token inválido ou expirado",
+		"error.forbidden":                      "Você não tem permissão para fazer isso",
+		"error.rate_limited":                   "Muitas requisições. Tente novamente mais tarde.",
+		"error.not_found":                      "Não encontrado",
+		"error.invalid_id":                     "ID inválido",
+		"error.appointment_not_found":          "Agendamento não encontrado",
+		"error.operation_not_found":            "Operação não encontrada",
+		"error.supplier_not_found":             "Fornecedor não encontrado",
+		"error.employee_not_found":             "Funcionário não encontrado",
+		"error.product_not_found":              "Produto não encontrado",
+		"error.invalid_supplier":               "Fornecedor inválido",
+		"error.invalid_employee":               "Funcionário inválido",
+		"error.invalid_operation":              "Operação inválida",
+		"error.invalid_product":                "Produto inválido",
+		"error.invalid_phone":                  "Número de telefone inválido",
+		"error.invalid_availability_slot":      "Horário de disponibilidade inválido",
+		"error.invalid_visibility_rule":        "Regra de visibilidade inválida",
+		"error.invalid_schedule":               "Agendamento de tarefa inválido",
+		"error.job_not_found":                  "Tarefa não encontrada",
+		"error.appointment_conflict":           "O agendamento conflita com um agendamento existente",
+		"error.outside_operation_hours":        "O agendamento deve estar dentro do horário de funcionamento da operação",
+		"error.appointment_in_past":            "O agendamento deve ser para uma data futura",
+		"error.invalid_time_range":             "O início deve ser anterior ao fim",
+		"error.appointment_closed":             "O agendamento já foi cancelado ou concluído",
+		"error.type_capacity_reached":          "A operação não tem mais vagas para este tipo de agendamento",
+		"error.band_capacity_reached":          "A operação não tem mais vagas nesta faixa de horário",
+		"error.capacity_past_date":             "Somente hoje ou uma data futura pode ter a capacidade alterada",
+		"error.capacity_range":                 "O calendário de capacidade pode ser consultado por no máximo 92 dias por vez",
+		"error.capacity_overlap":               "Faixas de capacidade do mesmo tipo não podem se sobrepor",
+		"error.supplier_limit_reached":         "O fornecedor já tem o máximo de agendamentos simultâneos que a operação permite",
+		"error.documents_missing":              "O fornecedor tem documentos obrigatórios ausentes ou vencidos",
+		"error.visitor_limit_reached":          "Limite de visitantes atingido para este agendamento",
+		"error.invalid_check_in_code":          "Código de check-in inválido",
+		"error.check_in_code_expired":          "O código de check-in expirou",
+		"error.already_checked_in":             "O check-in deste agendamento já foi feito",
+		"error.check_in_outside_window":        "O check-in só é permitido no dia do agendamento",
+		"error.inbound_not_completed":          "A entrega vinculada ainda não foi concluída",
+		"error.feedback_not_open":              "Só é possível avaliar agendamentos concluídos",
+		"error.feedback_already_given":         "Este agendamento já foi avaliado",
+		"error.incident_resolved":              "A ocorrência já foi resolvida",
+		"error.undo_window_expired":            "A conclusão automática não pode mais ser desfeita",
+		"error.unsupported_api_version":        "Versão da API não suportada",
+		"error.request_body_unreadable":        "Não foi possível ler o corpo da requisição",
+		"error.invalid_date":                   "Data inválida",
+		"error.date_range_too_long":            "O período é longo demais",
+		"error.rating_out_of_range":            "A nota deve estar entre 1 e 5",
+		"error.resolution_required":            "A resolução é obrigatória",
+		"error.notifications_paused":           "As notificações já estão pausadas",
+		"error.notifications_not_paused":       "As notificações não estão pausadas",
+		"error.invitation_not_found":           "Convite para agendamento não encontrado",
+		"error.invitation_unavailable":         "Este link de agendamento expirou ou já foi usado",
+		"error.slot_outside_window":            "O horário está fora do período do convite",
+		"error.slot_not_open":                  "O horário não está mais disponível",
+		"error.watcher_not_found":              "Observador não encontrado",
+		"error.already_watching":               "O usuário já acompanha este agendamento",
+		"error.billing_code_not_found":         "Código de faturamento não encontrado",
+		"error.billing_code_exists":            "Este código de faturamento já existe",
+		"error.invalid_billing_code":           "O centro de custo ou código de faturamento é desconhecido ou está inativo",
+		"error.fee_not_found":                  "Taxa não encontrada",
+		"error.fee_not_disputable":             "Somente taxas cobradas podem ser contestadas",
+		"error.fee_not_disputed":               "Somente taxas contestadas podem ser mantidas",
+		"error.fee_waived":                     "Esta taxa já foi dispensada",
+		"error.no_show_not_allowed":            "Somente agendamentos confirmados podem ser marcados como não comparecimento",
+		"error.no_show_too_early":              "Um agendamento não pode ser marcado como não comparecimento antes do início",
+		"error.operation_closed":               "A operação está fechada nesta data",
+		"error.blackout_not_found":             "Data de bloqueio não encontrada",
+		"error.blackout_imported":              "Feriados importados não podem ser excluídos; marque a data como aberta",
+		"error.holidays_not_imported":          "Esta operação não importa feriados",
+		"error.holidays_disabled":              "Nenhum provedor de feriados está configurado",
+		"error.holiday_sync_failed":            "Não foi possível consultar o provedor de feriados",
+		"error.invalid_year":                   "Ano inválido",
+		"error.invalid_days":                   "O número de dias deve estar entre 1 e 92",
+		"error.waitlist_not_found":             "Entrada da lista de espera não encontrada",
+		"error.waitlist_past_date":             "Somente hoje ou uma data futura pode entrar na lista de espera",
+		"error.waitlist_duplicate":             "O fornecedor já está na lista de espera desta data",
+		"error.waitlist_not_waiting":           "Somente entradas em espera podem ser retiradas",
+		"error.waitlist_range":                 "A lista de espera pode ser consultada por no máximo 92 dias por vez",
+		"error.waitlist_supplier":              "Somente fornecedores podem entrar na lista de espera",
+		"error.employee_unqualified":           "O funcionário não tem as habilidades exigidas por este agendamento",
+		"error.no_qualified_employee":          "Nenhum funcionário qualificado está disponível para este horário",
+		"error.not_employee":                   "Somente funcionários têm um dia para mostrar",
+		"error.invalid_sync_cursor":            "since deve ser um horário RFC 3339 ou um cursor retornado por uma sincronização anterior",
+		"error.sync_no_profile":                "Nenhum perfil de fornecedor ou funcionário para sincronizar para este usuário",
+		"error.partner_signature_missing":      "Requisições de parceiros devem trazer os cabeçalhos X-Partner-Key, X-Partner-Timestamp, X-Partner-Nonce e X-Partner-Signature",
+		"error.partner_unknown_key":            "Chave de parceiro desconhecida ou revogada",
+		"error.partner_bad_signature":          "Assinatura da requisição do parceiro inválida",
+		"error.partner_stale_request":          "O horário da requisição do parceiro está fora da janela aceita",
+		"error.partner_invalid_nonce":          "O nonce da requisição do parceiro deve ter de 16 a 128 caracteres",
+		"error.partner_replayed":               "O nonce da requisição do parceiro já foi usado",
+		"error.partner_revoked":                "A credencial do parceiro está revogada",
+		"error.partner_not_found":              "Credencial de parceiro não encontrada",
+		"error.schema_not_found":               "Schema não encontrado",
+		"error.invalid_event":                  "O evento deve ser um CloudEvent no modo JSON estruturado",
+		"error.bi_export_disabled":             "A exportação para BI está desativada",
+		"error.bi_export_running":              "Uma exportação para BI já está em andamento",
+		"error.bi_export_not_found":            "Execução da exportação para BI não encontrada",
+		"error.slot_watch_not_found":           "Acompanhamento de horários não encontrado",
+		"error.slot_watch_past_date":           "Somente hoje ou uma data futura pode ser acompanhada",
+		"error.slot_watch_duplicate":           "O fornecedor já acompanha esta operação, data e tipo",
+		"error.slot_watch_supplier":            "Somente fornecedores podem acompanhar e reservar horários liberados",
+		"error.slot_opening_not_found":         "Horário liberado não encontrado",
+		"error.slot_opening_closed":            "O horário liberado não está mais disponível",
+		"error.slot_opening_taken":             "Todas as vagas do horário liberado já foram ocupadas",
+		"error.slot_opening_type":              "O horário liberado é para outro tipo de agendamento",
+		"error.slot_outside_opening":           "O agendamento deve caber no horário liberado",
+		"error.invalid_usage_group":            "Agrupamento de uso inválido",
+		"error.invalid_usage_consumer":         "Tipo de consumidor inválido",
+		"error.setting_not_found":              "Configuração não encontrada",
+		"error.invalid_setting_value":          "Valor inválido para a configuração",
+		"error.setting_value_required":         "Um valor é obrigatório",
+		"error.unsupported_label_format":       "Formato de etiqueta não suportado",
+		"error.invalid_label_count":            "Imprima entre 1 e 100 etiquetas",
+		"error.invalid_label_template":         "Modelo de etiqueta inválido",
+		"error.calendar_feed_not_found":        "Feed de calendário não encontrado",
+		"error.calendar_feed_limit":            "Limite de feeds de calendário atingido: exclua um feed antes de adicionar outro",
+		"error.calendar_feed_assignments":      "Somente funcionários com cadastro podem assinar seus próprios agendamentos",
+		"error.feed_name_required":             "O nome do feed é obrigatório",
+		"error.feed_name_too_long":             "O nome do feed deve ter no máximo 100 caracteres",
+		"error.invalid_broadcast":              "Mensagem inválida: o modelo do assunto ou do corpo não pode ser gerado",
+		"error.broadcast_audience_empty":       "Ninguém corresponde ao público do comunicado",
+		"error.broadcast_audience_limit":       "O público do comunicado é grande demais: restrinja-o",
+		"error.broadcast_send_at":              "Horário de envio inválido: não pode estar no passado nem muito à frente",
+		"error.broadcast_audience":             "Público inválido: use operation_suppliers, employees ou suppliers_in_range",
+		"error.broadcast_channel":              "Canal inválido: use email ou sms",
+		"error.broadcast_operation":            "Informe a operação para enviar aos seus fornecedores",
+		"error.broadcast_range":                "Período inválido: from e to são obrigatórios, com to depois de from e no máximo 92 dias de diferença",
+		"error.broadcast_message":              "O assunto e o corpo do comunicado são obrigatórios",
+		"error.deposits_unavailable":           "Esta operação exige um sinal, mas não é possível recebê-lo no momento",
+		"error.deposit_request":                "Não foi possível contatar o provedor de pagamento: tente agendar novamente em instantes",
+		"error.invalid_payment_event":          "Evento de pagamento inválido",
+		"error.deposit_not_found":              "Sinal não encontrado",
+		"error.deposit_negative":               "O sinal não pode ser negativo",
+		"error.deposit_decimals":               "O sinal tem mais casas decimais do que a moeda permite",
+		"error.unsupported_locale":             "Idioma não suportado: use en-US ou pt-BR",
+		"error.unknown_timezone":               "Fuso horário desconhecido: use um nome IANA como America/Sao_Paulo",
+		"error.quick_add_text":                 "Descreva o agendamento em no máximo 500 caracteres",
+		"error.denial_group":                   "Agrupe as recusas por operation, reason, weekday, hour ou date, cada um no máximo uma vez",
+		"error.denial_reason":                  "Motivo de recusa desconhecido: use conflict, capacity, lead_time, quota ou closed",
+		"error.draft_not_found":                "Rascunho não encontrado ou expirado",
+		"error.draft_limit_reached":            "Você já tem o máximo de rascunhos permitido: exclua um antes de salvar outro",
+		"error.draft_payload":                  "O rascunho deve ser um objeto JSON",
+		"error.draft_too_large":                "O rascunho é grande demais para ser salvo",
+		"error.draft_key":                      "Chaves de rascunho têm de 1 a 64 letras, dígitos, hífens ou sublinhados",
+		"error.calendar_not_connected":         "Você não conectou uma agenda de trabalho",
+		"error.calendar_provider_unconfigured": "Este provedor de agenda não está configurado no servidor",
+		"error.calendar_employee":              "Somente funcionários com cadastro de colaborador podem conectar uma agenda de trabalho",
+		"error.calendar_unreadable":            "Não foi possível ler sua agenda de trabalho com este acesso",
+		"error.calendar_provider":              "O provedor de agenda deve ser google ou microsoft",
+		"error.calendar_mailbox":               "Agendas da Microsoft precisam do endereço da caixa de correio como agenda",
+		"error.calendar_token":                 "É necessário um token de acesso ou de atualização",
+		"error.region_not_found":               "Região não encontrada",
+		"error.region_forbidden":               "Você não gerencia esta região",
+		"error.region_code_taken":              "Outra região já usa este código",
+		"error.region_operation":               "Uma operação da região não foi encontrada",
+		"error.region_manager":                 "Gerentes de região devem ser funcionários com cadastro de colaborador",
+		"error.region_code":                    "Códigos de região têm até 30 letras, dígitos, hífens e sublinhados",
+		"error.region_name":                    "O nome da região é obrigatório",
+		"error.experiment_not_found":           "Experimento de modelo não encontrado",
+		"error.experiment_running":             "Já há um experimento em andamento para este evento, tipo de destinatário e tipo de notificação",
+		"error.experiment_stopped":             "Este experimento de modelo já foi encerrado",
+		"error.experiment_template":            "Um modelo do experimento não foi encontrado",
+		"error.experiment_mismatch":            "Os modelos do experimento devem ser do evento, tipo de destinatário e tipo de notificação do experimento",
+		"error.experiment_name":                "O nome do experimento é obrigatório",
+		"error.experiment_target":              "O evento, o tipo de destinatário e o tipo de notificação do experimento são obrigatórios",
+		"error.experiment_same_template":       "As variantes do experimento devem usar modelos diferentes",
+		"error.experiment_split":               "A divisão do experimento deve estar entre 0 e 100 por cento",
+		"error.token_revoked":                  "Esta sessão foi encerrada; entre novamente",
+	},
+}
+
+// MessageLocale returns the locale whose catalog serves a requested locale: the closest
+// supported locale when it has a catalog, DefaultLocale otherwise
+func MessageLocale(locale string) string {
+	resolved := ResolveLocale(locale)
+	if _, ok := messageCatalogs[resolved]; ok {
+		return resolved
+	}
+	return DefaultLocale
+}
+
+// MessageLocales lists the locales with a message catalog, DefaultLocale first
+func MessageLocales() []string {
+	supported := []string{DefaultLocale}
+	for locale := range messageCatalogs {
+		if locale != DefaultLocale {
+			supported = append(supported, locale)
+		}
+	}
+	sort.Strings(supported[1:])
+	return supported
+}
+
+// Message returns the message of a key in a locale, falling back to English, and whether the
+// key is known
+func Message(locale, key string) (string, bool) {
+	if message, ok := messageCatalogs[MessageLocale(locale)][key]; ok {
+		return message, true
+	}
+	message, ok := messageCatalogs[DefaultLocale][key]
+	return message, ok
+}
+
+// Label returns the label of an enum code, e.g. Label("pt-BR", KindStatus, "pending") is
+// "Pendente". Unknown codes are returned as they are.
+func Label(locale, kind, code string) string {
+	if label, ok := Message(locale, kind+"."+code); ok {
+		return label
+	}
+	return code
+}
+
+// Labels returns every label of a locale by kind and code, for clients that render enums
+func Labels(locale string) map[string]map[string]string {
+	labels := make(map[string]map[string]string, len(labelKinds))
+	for _, kind := range labelKinds {
+		labels[kind] = make(map[string]string)
+	}
+	for key := range messageCatalogs[DefaultLocale] {
+		kind, code, ok := strings.Cut(key, ".")
+		if _, known := labels[kind]; !ok || !known {
+			continue
+		}
+		labels[kind][code] = Label(locale, kind, code)
+	}
+	return labels
+}
+
+// NegotiateLocale picks the catalog locale best matching an Accept-Language header, honouring
+// quality values, and DefaultLocale when nothing matches
+func NegotiateLocale(acceptLanguage string) string {
+	locale, _ := MatchLocale(acceptLanguage)
+	return locale
+}
+
+// MatchLocale is NegotiateLocale reporting whether the header named a language with a catalog,
+// so callers can tell a client asking for English from one asking for nothing they can serve
+func MatchLocale(acceptLanguage string) (string, bool) {
+	best, bestQuality := DefaultLocale, 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if !strings.HasPrefix(param, "q=") {
+				continue
+			}
+			if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+				quality = q
+			}
+		}
+		if quality <= bestQuality {
+			continue
+		}
+
+		// Only languages with a catalog count; anything else would fall back to English anyway
+		resolved := ResolveLocale(tag)
+		language := strings.ToLower(strings.SplitN(strings.ReplaceAll(tag, "_", "-"), "-", 2)[0])
+		if _, ok := messageCatalogs[resolved]; !ok || !strings.HasPrefix(strings.ToLower(resolved), language+"-") {
+			continue
+		}
+		best, bestQuality = resolved, quality
+	}
+	return best, bestQuality > 0
+}
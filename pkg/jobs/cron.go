@@ -0,0 +1,275 @@
+package jobs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule tells when a job runs next
+type Schedule interface {
+	// Next returns the first run time strictly after t
+	Next(t time.Time) time.Time
+	String() string
+}
+
+// Every returns a schedule running at a fixed interval. Runs fall on multiples of the interval
+// since the Unix epoch, so replicas started at different times agree on when a run is due.
+func Every(interval time.Duration) Schedule {
+	return everySchedule{interval: interval}
+}
+
+// everySchedule runs at a fixed interval
+type everySchedule struct {
+	interval time.Duration
+}
+
+// Next returns the first multiple of the interval after t
+func (s everySchedule) Next(t time.Time) time.Time {
+	return t.Truncate(s.interval).Add(s.interval)
+}
+
+func (s everySchedule) String() string {
+	return "@every " + s.interval.String()
+}
+
+// cronDescriptors are the predefined schedules accepted in place of the five fields
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseSchedule parses a job schedule. It accepts the five-field cron syntax (minute, hour, day of
+// month, month, day of week) with lists, ranges, steps and month and weekday names, the @hourly,
+// @daily, @weekly, @monthly and @yearly descriptors, "@every <duration>" and a bare duration such
+// as "15m". Cron schedules run in the local timezone unless prefixed with CRON_TZ=<IANA name>.
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, fmt.Errorf("empty schedule")
+	}
+
+	location := time.Local
+	if strings.HasPrefix(spec, "CRON_TZ=") || strings.HasPrefix(spec, "TZ=") {
+		prefix, rest, _ := strings.Cut(spec, " ")
+		_, name, _ := strings.Cut(prefix, "=")
+		loaded, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, fmt.Errorf("unknown schedule timezone %q", name)
+		}
+		location = loaded
+		spec = strings.TrimSpace(rest)
+	}
+
+	if strings.HasPrefix(spec, "@every ") {
+		return parseEvery(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+	}
+	if descriptor, ok := cronDescriptors[spec]; ok {
+		spec = descriptor
+	} else if strings.HasPrefix(spec, "@") {
+		return nil, fmt.Errorf("unknown schedule descriptor %q", spec)
+	}
+	if !strings.Contains(spec, " ") {
+		return parseEvery(spec)
+	}
+	return parseCron(spec, location)
+}
+
+// parseEvery parses the interval of an @every schedule
+func parseEvery(value string) (Schedule, error) {
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule interval %q", value)
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("schedule interval must be positive")
+	}
+	return Every(interval), nil
+}
+
+// cronField describes one of the five cron fields
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = cronField{name: "minute", min: 0, max: 59}
+	hourField   = cronField{name: "hour", min: 0, max: 23}
+	domField    = cronField{name: "day of month", min: 1, max: 31}
+	monthField  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dowField = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// cronSchedule is a parsed five-field cron expression. Each field is a bit set of the values
+// it matches.
+type cronSchedule struct {
+	spec                          string
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+	location                      *time.Location
+}
+
+// parseCron parses a five-field cron expression
+func parseCron(spec string, location *time.Location) (Schedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron schedule %q must have 5 fields, has %d", spec, len(fields))
+	}
+
+	schedule := &cronSchedule{spec: spec, location: location}
+	var err error
+	if schedule.minute, err = minuteField.parse(fields[0]); err != nil {
+		return nil, err
+	}
+	if schedule.hour, err = hourField.parse(fields[1]); err != nil {
+		return nil, err
+	}
+	if schedule.dom, err = domField.parse(fields[2]); err != nil {
+		return nil, err
+	}
+	if schedule.month, err = monthField.parse(fields[3]); err != nil {
+		return nil, err
+	}
+	if schedule.dow, err = dowField.parse(fields[4]); err != nil {
+		return nil, err
+	}
+	// Sunday may be written as 7
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow |= 1
+	}
+	schedule.domStar = starred(fields[2])
+	schedule.dowStar = starred(fields[4])
+	return schedule, nil
+}
+
+// starred reports whether a day field is written with * or ?, stepped or not, which leaves the
+// day to the other day field as in robfig/cron: "0 0 */2 * mon" runs on Mondays that fall on odd
+// days, not on every odd day and every Monday
+func starred(field string) bool {
+	for _, part := range strings.Split(field, ",") {
+		rangePart, _, _ := strings.Cut(part, "/")
+		if rangePart == "*" || rangePart == "?" {
+			return true
+		}
+	}
+	return false
+}
+
+// parse parses a comma-separated list of values, ranges and steps into a bit set
+func (f cronField) parse(value string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(value, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			parsed, err := strconv.Atoi(stepPart)
+			if err != nil || parsed <= 0 {
+				return 0, fmt.Errorf("invalid %s step %q", f.name, stepPart)
+			}
+			step = parsed
+		}
+
+		low, high := f.min, f.max
+		switch {
+		case rangePart == "*" || rangePart == "?":
+		case strings.Contains(rangePart, "-"):
+			lowPart, highPart, _ := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = f.value(lowPart); err != nil {
+				return 0, err
+			}
+			if high, err = f.value(highPart); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("invalid %s range %q", f.name, rangePart)
+			}
+		default:
+			parsed, err := f.value(rangePart)
+			if err != nil {
+				return 0, err
+			}
+			low = parsed
+			if !hasStep {
+				high = parsed
+			}
+		}
+
+		for v := low; v <= high; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// value parses a single number or name of the field
+func (f cronField) value(value string) (int, error) {
+	if v, ok := f.names[strings.ToLower(value)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid %s %q", f.name, value)
+	}
+	return v, nil
+}
+
+// Next returns the first minute after t matching the expression, or the zero time if none
+// does within five years (e.g. February 30th)
+func (s *cronSchedule) Next(t time.Time) time.Time {
+	original := t.Location()
+	t = t.In(s.location).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.location)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t.In(original)
+	}
+	return time.Time{}
+}
+
+// dayMatches applies the cron day rule: when both day fields are restricted, either may match,
+// and otherwise both must
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+func (s *cronSchedule) String() string {
+	if s.location != time.Local {
+		return "CRON_TZ=" + s.location.String() + " " + s.spec
+	}
+	return s.spec
+}
@@ -0,0 +1,103 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cronStart is the time the schedule tests start from, a Monday morning
+var cronStart = time.Date(2024, time.March, 4, 10, 7, 0, 0, time.UTC)
+
+// at returns a time in UTC on a day of 2024
+func at(month time.Month, day, hour, minute int) time.Time {
+	return time.Date(2024, month, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestScheduleNext(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		want []time.Time // the next runs after cronStart, in order
+	}{
+		{"range", "CRON_TZ=UTC 0 9-11 * * *", []time.Time{at(3, 4, 11, 0), at(3, 5, 9, 0), at(3, 5, 10, 0)}},
+		{"step", "CRON_TZ=UTC */15 * * * *", []time.Time{at(3, 4, 10, 15), at(3, 4, 10, 30), at(3, 4, 10, 45), at(3, 4, 11, 0)}},
+		{"step from a value", "CRON_TZ=UTC 5/20 * * * *", []time.Time{at(3, 4, 10, 25), at(3, 4, 10, 45), at(3, 4, 11, 5)}},
+		{"stepped range", "CRON_TZ=UTC 0 8-18/4 * * *", []time.Time{at(3, 4, 12, 0), at(3, 4, 16, 0), at(3, 5, 8, 0)}},
+		{"list", "CRON_TZ=UTC 30 9,13 * * *", []time.Time{at(3, 4, 13, 30), at(3, 5, 9, 30), at(3, 5, 13, 30)}},
+		{"weekday names", "CRON_TZ=UTC 0 9 * * mon,wed,FRI", []time.Time{at(3, 6, 9, 0), at(3, 8, 9, 0), at(3, 11, 9, 0)}},
+		{"sunday as 7", "CRON_TZ=UTC 0 0 * * 7", []time.Time{at(3, 10, 0, 0), at(3, 17, 0, 0)}},
+		{"month names", "CRON_TZ=UTC 0 0 1 jan,jul *", []time.Time{at(7, 1, 0, 0), at(7, 1, 0, 0).AddDate(0, 6, 0)}},
+		{"day of month only", "CRON_TZ=UTC 0 6 15 * *", []time.Time{at(3, 15, 6, 0), at(4, 15, 6, 0)}},
+		{"day of week only", "CRON_TZ=UTC 0 6 * * sat", []time.Time{at(3, 9, 6, 0), at(3, 16, 6, 0)}},
+		{"both days restricted run on either", "CRON_TZ=UTC 0 0 13 * fri", []time.Time{at(3, 8, 0, 0), at(3, 13, 0, 0), at(3, 15, 0, 0)}},
+		{"stepped day of month runs on both", "CRON_TZ=UTC 0 0 */2 * mon", []time.Time{at(3, 11, 0, 0), at(3, 25, 0, 0), at(4, 1, 0, 0)}},
+		{"stepped day of week runs on both", "CRON_TZ=UTC 0 0 1-7 * */2", []time.Time{at(3, 5, 0, 0), at(3, 7, 0, 0), at(4, 2, 0, 0)}},
+		{"question mark", "CRON_TZ=UTC 0 0 ? * sun", []time.Time{at(3, 10, 0, 0)}},
+		{"descriptor", "CRON_TZ=UTC @weekly", []time.Time{at(3, 10, 0, 0), at(3, 17, 0, 0)}},
+		{"every", "@every 15m", []time.Time{at(3, 4, 10, 15), at(3, 4, 10, 30)}},
+		{"bare duration", "2h", []time.Time{at(3, 4, 12, 0), at(3, 4, 14, 0)}},
+		{"timezone", "CRON_TZ=America/Sao_Paulo 0 9 * * *", []time.Time{at(3, 4, 12, 0), at(3, 5, 12, 0)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.spec)
+			require.NoError(t, err)
+
+			next := cronStart
+			for _, want := range tt.want {
+				got := schedule.Next(next)
+				assert.Equal(t, want, got.UTC(), "after %s", next)
+				next = got
+			}
+		})
+	}
+}
+
+func TestScheduleNextWithoutMatchingDay(t *testing.T) {
+	schedule, err := ParseSchedule("CRON_TZ=UTC 0 0 30 feb *")
+	require.NoError(t, err)
+	assert.True(t, schedule.Next(cronStart).IsZero())
+}
+
+func TestParseScheduleRefusesInvalidSpecs(t *testing.T) {
+	tests := []struct {
+		spec string
+		want string
+	}{
+		{"", "empty schedule"},
+		{"0 9 * *", `cron schedule "0 9 * *" must have 5 fields, has 4`},
+		{"60 * * * *", `invalid minute "60"`},
+		{"0 24 * * *", `invalid hour "24"`},
+		{"0 0 0 * *", `invalid day of month "0"`},
+		{"0 0 * 13 *", `invalid month "13"`},
+		{"0 0 * * 8", `invalid day of week "8"`},
+		{"0 0 * * funday", `invalid day of week "funday"`},
+		{"0 11-9 * * *", `invalid hour range "11-9"`},
+		{"*/0 * * * *", `invalid minute step "0"`},
+		{"@fortnightly", `unknown schedule descriptor "@fortnightly"`},
+		{"CRON_TZ=Mars/Olympus 0 0 * * *", `unknown schedule timezone "Mars/Olympus"`},
+		{"-5m", "schedule interval must be positive"},
+		{"soon", `invalid schedule interval "soon"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			_, err := ParseSchedule(tt.spec)
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+}
+
+func TestScheduleString(t *testing.T) {
+	for spec, want := range map[string]string{
+		"@every 90s":               "@every 1m30s",
+		"CRON_TZ=UTC */10 * * * *": "CRON_TZ=UTC */10 * * * *",
+		"CRON_TZ=UTC @daily":       "CRON_TZ=UTC 0 0 * * *",
+	} {
+		schedule, err := ParseSchedule(spec)
+		require.NoError(t, err, spec)
+		assert.Equal(t, want, schedule.String())
+	}
+}
@@ -0,0 +1,330 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrJobNotFound is returned when running a job that has not been registered
+var ErrJobNotFound = errors.New("job not found")
+
+// RunFunc is the work done by a job
+type RunFunc func(ctx context.Context) error
+
+// Coordinator lets replicas share the scheduled runs of a job. Claim is called before each
+// scheduled run and only the replica it returns true to runs it; Finish records the outcome.
+type Coordinator interface {
+	Claim(ctx context.Context, job string, scheduledFor time.Time) (bool, error)
+	Finish(ctx context.Context, job string, scheduledFor time.Time, duration time.Duration, runErr error) error
+}
+
+// Status is a point-in-time view of a job
+type Status struct {
+	Name         string     `json:"name"`
+	Schedule     string     `json:"schedule"`
+	Interval     string     `json:"interval,omitempty"` // set for fixed-interval schedules
+	Enabled      bool       `json:"enabled"`
+	Local        bool       `json:"local,omitempty"` // runs on every replica
+	Running      bool       `json:"running"`
+	Runs         int64      `json:"runs"`
+	Failures     int64      `json:"failures"`
+	NextRunAt    *time.Time `json:"next_run_at,omitempty"`
+	LastRunAt    *time.Time `json:"last_run_at,omitempty"`
+	LastDuration string     `json:"last_duration,omitempty"`
+	LastError    string     `json:"last_error,omitempty"`
+}
+
+// job is a registered job and its run history
+type job struct {
+	name  string
+	run   RunFunc
+	local bool // runs on every replica, without asking the coordinator
+
+	mu              sync.Mutex
+	schedule        Schedule
+	defaultSchedule Schedule // the schedule the job was registered with
+	enabled         bool
+	changed         chan struct{} // signalled when the schedule or enabled flag changes
+	nextRunAt       time.Time
+	running         bool
+	runs            int64
+	failures        int64
+	lastRunAt       time.Time
+	lastDuration    time.Duration
+	lastError       string
+}
+
+// Scheduler runs registered jobs on their schedules. A job never overlaps with itself:
+// a run that comes due while the previous run is still going is skipped. With a coordinator,
+// each scheduled run happens on one replica only.
+type Scheduler struct {
+	mu          sync.RWMutex
+	jobs        map[string]*job
+	coordinator Coordinator
+}
+
+// NewScheduler creates an empty scheduler
+func NewScheduler() *Scheduler {
+	return &Scheduler{jobs: make(map[string]*job)}
+}
+
+// Register adds a job running every interval; an interval of 0 or less disables it.
+// Registering a name twice replaces the job.
+func (s *Scheduler) Register(name string, interval time.Duration, run RunFunc) {
+	if interval <= 0 {
+		s.RegisterSchedule(name, nil, run)
+		return
+	}
+	s.RegisterSchedule(name, Every(interval), run)
+}
+
+// RegisterSchedule adds a job running on a schedule; a nil schedule disables it.
+// Registering a name twice replaces the job.
+func (s *Scheduler) RegisterSchedule(name string, schedule Schedule, run RunFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs[name] = &job{
+		name:            name,
+		run:             run,
+		schedule:        schedule,
+		defaultSchedule: schedule,
+		enabled:         schedule != nil,
+		changed:         make(chan struct{}, 1),
+	}
+}
+
+// RegisterLocal adds a job running every interval on every replica, even with a coordinator.
+// It is meant for upkeep of the replica itself, such as reloading settings.
+func (s *Scheduler) RegisterLocal(name string, interval time.Duration, run RunFunc) {
+	s.Register(name, interval, run)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[name].local = true
+}
+
+// SetCoordinator makes scheduled runs go through a coordinator. Call it before Start.
+func (s *Scheduler) SetCoordinator(coordinator Coordinator) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.coordinator = coordinator
+}
+
+// Configure changes the schedule and enabled flag of a job, taking effect immediately. A nil
+// schedule restores the one the job was registered with.
+func (s *Scheduler) Configure(name string, schedule Schedule, enabled bool) error {
+	s.mu.RLock()
+	j, exists := s.jobs[name]
+	s.mu.RUnlock()
+	if !exists {
+		return ErrJobNotFound
+	}
+
+	j.mu.Lock()
+	if schedule == nil {
+		schedule = j.defaultSchedule
+	}
+	if enabled && schedule == nil {
+		j.mu.Unlock()
+		return fmt.Errorf("job %s has no schedule", name)
+	}
+	j.schedule = schedule
+	j.enabled = enabled
+	j.mu.Unlock()
+
+	select {
+	case j.changed <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Start runs every registered job on its schedule until the context is cancelled. Disabled
+// jobs wait until they are enabled.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, j := range s.jobs {
+		go s.loop(ctx, j)
+	}
+}
+
+// RunNow runs a job immediately, outside of its schedule and without asking the coordinator
+func (s *Scheduler) RunNow(ctx context.Context, name string) error {
+	s.mu.RLock()
+	j, exists := s.jobs[name]
+	s.mu.RUnlock()
+	if !exists {
+		return ErrJobNotFound
+	}
+
+	return s.execute(ctx, j)
+}
+
+// Statuses returns the state of all jobs sorted by name
+func (s *Scheduler) Statuses() []Status {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	statuses := make([]Status, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		statuses = append(statuses, j.status())
+	}
+	sort.Slice(statuses, func(i, k int) bool {
+		return statuses[i].Name < statuses[k].Name
+	})
+	return statuses
+}
+
+// loop runs a job each time its schedule comes due, re-reading the schedule after every run
+// and whenever it is reconfigured
+func (s *Scheduler) loop(ctx context.Context, j *job) {
+	var timer *time.Timer
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	for {
+		next := j.planNext(time.Now())
+
+		var due <-chan time.Time
+		if !next.IsZero() {
+			timer = time.NewTimer(time.Until(next))
+			due = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-j.changed:
+			if timer != nil {
+				timer.Stop()
+			}
+		case <-due:
+			s.runScheduled(ctx, j, next)
+		}
+	}
+}
+
+// runScheduled runs a job for one scheduled time, once the coordinator lets this replica have it
+func (s *Scheduler) runScheduled(ctx context.Context, j *job, scheduledFor time.Time) {
+	s.mu.RLock()
+	coordinator := s.coordinator
+	s.mu.RUnlock()
+	if j.local {
+		coordinator = nil
+	}
+
+	if coordinator != nil {
+		claimed, err := coordinator.Claim(ctx, j.name, scheduledFor)
+		if err != nil {
+			log.Printf("Job %s: failed to claim the run scheduled for %s: %v", j.name, scheduledFor.Format(time.RFC3339), err)
+			return
+		}
+		if !claimed {
+			return
+		}
+	}
+
+	started := time.Now()
+	err := s.execute(ctx, j)
+	if err != nil && !errors.Is(err, errAlreadyRunning) {
+		log.Printf("Job %s failed: %v", j.name, err)
+	}
+
+	if coordinator != nil {
+		if finishErr := coordinator.Finish(ctx, j.name, scheduledFor, time.Since(started), err); finishErr != nil {
+			log.Printf("Job %s: failed to record the run scheduled for %s: %v", j.name, scheduledFor.Format(time.RFC3339), finishErr)
+		}
+	}
+}
+
+// errAlreadyRunning is returned when a job is triggered while it is still running
+var errAlreadyRunning = errors.New("job is already running")
+
+// execute runs a job once and records the outcome
+func (s *Scheduler) execute(ctx context.Context, j *job) (err error) {
+	j.mu.Lock()
+	if j.running {
+		j.mu.Unlock()
+		return errAlreadyRunning
+	}
+	j.running = true
+	j.mu.Unlock()
+
+	started := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+
+		j.mu.Lock()
+		defer j.mu.Unlock()
+
+		j.running = false
+		j.runs++
+		j.lastRunAt = started
+		j.lastDuration = time.Since(started)
+		j.lastError = ""
+		if err != nil {
+			j.failures++
+			j.lastError = err.Error()
+		}
+	}()
+
+	return j.run(ctx)
+}
+
+// planNext works out and remembers when the job runs next, the zero time when it doesn't
+func (j *job) planNext(now time.Time) time.Time {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.nextRunAt = time.Time{}
+	if j.enabled && j.schedule != nil {
+		j.nextRunAt = j.schedule.Next(now)
+	}
+	return j.nextRunAt
+}
+
+// status returns a point-in-time view of the job
+func (j *job) status() Status {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	status := Status{
+		Name:      j.name,
+		Enabled:   j.enabled,
+		Local:     j.local,
+		Running:   j.running,
+		Runs:      j.runs,
+		Failures:  j.failures,
+		LastError: j.lastError,
+	}
+	if j.schedule != nil {
+		status.Schedule = j.schedule.String()
+		if every, ok := j.schedule.(everySchedule); ok {
+			status.Interval = every.interval.String()
+		}
+	}
+	if !j.nextRunAt.IsZero() {
+		nextRunAt := j.nextRunAt
+		status.NextRunAt = &nextRunAt
+	}
+	if !j.lastRunAt.IsZero() {
+		lastRunAt := j.lastRunAt
+		status.LastRunAt = &lastRunAt
+		status.LastDuration = j.lastDuration.String()
+	}
+	return status
+}
@@ -0,0 +1,177 @@
+// Package leader elects one instance among the replicas of the service to run singleton work,
+// such as scheduled jobs. Leadership is a lock only one instance holds at a time; the others keep
+// trying so one of them takes over when the leader stops or loses the lock.
+package leader
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Lock is a lock only one instance can hold at a time. Implementations must release it on their
+// own when the holder dies, e.g. by tying it to a database session.
+type Lock interface {
+	// TryAcquire takes the lock without waiting, reporting whether it was taken
+	TryAcquire(ctx context.Context) (bool, error)
+	// Check returns an error when the held lock was lost
+	Check(ctx context.Context) error
+	// Release gives the held lock up
+	Release(ctx context.Context) error
+}
+
+// Status is a point-in-time view of the election from this instance
+type Status struct {
+	Identity  string     `json:"identity"`
+	Leader    bool       `json:"leader"`
+	Since     *time.Time `json:"since,omitempty"` // when this instance became the leader
+	LastError string     `json:"last_error,omitempty"`
+}
+
+// Elector campaigns for a lock and tells whether this instance currently leads
+type Elector struct {
+	lock     Lock
+	identity string
+	interval time.Duration
+
+	mu        sync.RWMutex
+	leading   bool
+	since     time.Time
+	lastError string
+	onChange  []func(leading bool)
+}
+
+// New creates an elector for an instance, trying for the lock and checking it holds it every
+// interval
+func New(lock Lock, identity string, interval time.Duration) *Elector {
+	if interval <= 0 {
+		interval = 15 * time.Second
+	}
+	return &Elector{lock: lock, identity: identity, interval: interval}
+}
+
+// OnChange registers a function called whenever this instance gains or loses leadership. Call it
+// before Run.
+func (e *Elector) OnChange(fn func(leading bool)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.onChange = append(e.onChange, fn)
+}
+
+// IsLeader reports whether this instance currently leads. A nil elector always leads, for single
+// instance setups.
+func (e *Elector) IsLeader() bool {
+	if e == nil {
+		return true
+	}
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.leading
+}
+
+// Identity returns the name of this instance
+func (e *Elector) Identity() string {
+	return e.identity
+}
+
+// Status returns the election state as seen by this instance
+func (e *Elector) Status() Status {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	status := Status{Identity: e.identity, Leader: e.leading, LastError: e.lastError}
+	if e.leading {
+		since := e.since
+		status.Since = &since
+	}
+	return status
+}
+
+// Run campaigns until the context is cancelled, then gives leadership up
+func (e *Elector) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		e.campaign(ctx)
+
+		select {
+		case <-ctx.Done():
+			e.resign()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// campaign takes the lock when nobody holds it, or checks the lock is still held when leading
+func (e *Elector) campaign(ctx context.Context) {
+	if e.IsLeader() {
+		if err := e.lock.Check(ctx); err != nil {
+			log.Printf("Leader %s lost the lock: %v", e.identity, err)
+			e.setLeading(false, err)
+			// The lock may still be held if only the check failed
+			if releaseErr := e.lock.Release(ctx); releaseErr != nil {
+				log.Printf("Leader %s: failed to release the lock: %v", e.identity, releaseErr)
+			}
+		}
+		return
+	}
+
+	acquired, err := e.lock.TryAcquire(ctx)
+	if err != nil {
+		e.setError(err)
+		return
+	}
+	if acquired {
+		log.Printf("Instance %s is now the leader", e.identity)
+		e.setLeading(true, nil)
+	}
+}
+
+// resign gives leadership up on shutdown so another instance takes over at once
+func (e *Elector) resign() {
+	if !e.IsLeader() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := e.lock.Release(ctx); err != nil {
+		log.Printf("Leader %s: failed to release the lock: %v", e.identity, err)
+	}
+	e.setLeading(false, nil)
+}
+
+// setLeading records a change of leadership and notifies the listeners
+func (e *Elector) setLeading(leading bool, err error) {
+	e.mu.Lock()
+	changed := e.leading != leading
+	e.leading = leading
+	if leading {
+		e.since = time.Now()
+	}
+	e.lastError = ""
+	if err != nil {
+		e.lastError = err.Error()
+	}
+	listeners := e.onChange
+	e.mu.Unlock()
+
+	if changed {
+		for _, fn := range listeners {
+			fn(leading)
+		}
+	}
+}
+
+// setError records the last failure to take the lock
+func (e *Elector) setError(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.lastError = err.Error()
+}
@@ -0,0 +1,48 @@
+// Package objectstore writes files to object storage, such as an S3 or Google Cloud Storage
+// bucket, or to a local directory in development.
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrInvalidKey is returned for object keys that are empty or climb out of the store
+var ErrInvalidKey = errors.New("invalid object key")
+
+// Store writes objects by key. Writing a key again replaces the object.
+type Store interface {
+	Put(ctx context.Context, key string, body []byte, contentType string) error
+}
+
+// Filesystem stores objects as files under a directory, keys becoming relative paths
+type Filesystem struct {
+	dir string
+}
+
+// NewFilesystem creates a store writing under dir
+func NewFilesystem(dir string) *Filesystem {
+	return &Filesystem{dir: dir}
+}
+
+// Put writes an object to its file, creating the directories it needs
+func (f *Filesystem) Put(ctx context.Context, key string, body []byte, contentType string) error {
+	clean := filepath.Clean(filepath.FromSlash(key))
+	if key == "" || filepath.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, ".."+string(filepath.Separator)) {
+		return ErrInvalidKey
+	}
+	path := filepath.Join(f.dir, clean)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	// Write next to the file and rename so readers never see half an object
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, body, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,63 @@
+// Package payments collects booking deposits through pluggable payment providers. A payment is
+// created when an appointment is reserved and the provider reports what became of it to a
+// webhook.
+package payments
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// ErrInvalidSignature is returned for webhook requests the provider didn't sign
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// Request is a payment to collect
+type Request struct {
+	Reference   string // unique per payment; requests retried with the same reference create one payment
+	Amount      int64  // in the currency's minor units, e.g. cents
+	Currency    string // ISO 4217 code
+	Description string
+	Metadata    map[string]string
+}
+
+// Payment is a payment created with a provider
+type Payment struct {
+	ID           string // the provider's ID, which webhook events refer to
+	ClientSecret string // lets the payer's client complete the payment, e.g. show the Pix QR code
+	Status       string // the provider's own status
+}
+
+// EventType tells what became of a payment
+type EventType string
+
+const (
+	// EventSucceeded is a payment the payer completed
+	EventSucceeded EventType = "succeeded"
+
+	// EventFailed is an attempt to pay that failed; the payer may try again
+	EventFailed EventType = "failed"
+
+	// EventCanceled is a payment that can no longer be completed
+	EventCanceled EventType = "canceled"
+)
+
+// Event is a webhook notification about a payment. Notifications about anything else are
+// returned with an empty Type.
+type Event struct {
+	ID        string
+	Type      EventType
+	PaymentID string
+	Amount    int64
+	Currency  string
+	Message   string // why the attempt failed
+}
+
+// Provider creates payments and reads the webhook events about them
+type Provider interface {
+	Name() string
+	CreatePayment(ctx context.Context, request Request) (*Payment, error)
+	CancelPayment(ctx context.Context, id string) error
+	ParseEvent(header http.Header, body []byte, now time.Time) (*Event, error)
+}
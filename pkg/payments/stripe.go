@@ -0,0 +1,208 @@
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// stripeSignatureTolerance is how old a signed webhook request may be, so a captured one can't be
+// replayed later
+const stripeSignatureTolerance = 5 * time.Minute
+
+// Stripe collects payments through Stripe PaymentIntents (https://stripe.com/docs/api), by card
+// or, for BRL amounts, Pix
+type Stripe struct {
+	baseURL       string
+	secretKey     string
+	webhookSecret string
+	methods       []string
+	client        *http.Client
+}
+
+// NewStripe creates a Stripe provider offering the payment methods, e.g. "card" and "pix", and
+// sending its requests through transport, http.DefaultTransport when nil
+func NewStripe(baseURL, secretKey, webhookSecret string, methods []string, timeout time.Duration, transport http.RoundTripper) *Stripe {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &Stripe{
+		baseURL:       strings.TrimRight(baseURL, "/"),
+		secretKey:     secretKey,
+		webhookSecret: webhookSecret,
+		methods:       methods,
+		client:        &http.Client{Timeout: timeout, Transport: transport},
+	}
+}
+
+// Name returns the provider name recorded with the payments it creates
+func (s *Stripe) Name() string {
+	return "stripe"
+}
+
+// stripeIntent is the part of a PaymentIntent read back
+type stripeIntent struct {
+	ID               string `json:"id"`
+	ClientSecret     string `json:"client_secret"`
+	Status           string `json:"status"`
+	Amount           int64  `json:"amount"`
+	Currency         string `json:"currency"`
+	LastPaymentError *struct {
+		Message string `json:"message"`
+	} `json:"last_payment_error"`
+}
+
+// CreatePayment creates a PaymentIntent. The reference is sent as the idempotency key, so a
+// retried request returns the intent already created.
+func (s *Stripe) CreatePayment(ctx context.Context, request Request) (*Payment, error) {
+	form := url.Values{}
+	form.Set("amount", strconv.FormatInt(request.Amount, 10))
+	form.Set("currency", strings.ToLower(request.Currency))
+	if request.Description != "" {
+		form.Set("description", request.Description)
+	}
+	for _, method := range s.methods {
+		form.Add("payment_method_types[]", method)
+	}
+	for key, value := range request.Metadata {
+		form.Set("metadata["+key+"]", value)
+	}
+
+	var intent stripeIntent
+	if err := s.post(ctx, "/v1/payment_intents", form, request.Reference, &intent); err != nil {
+		return nil, err
+	}
+	return &Payment{ID: intent.ID, ClientSecret: intent.ClientSecret, Status: intent.Status}, nil
+}
+
+// CancelPayment cancels a PaymentIntent that wasn't paid, so it can't be paid later
+func (s *Stripe) CancelPayment(ctx context.Context, id string) error {
+	return s.post(ctx, "/v1/payment_intents/"+url.PathEscape(id)+"/cancel", url.Values{}, "", nil)
+}
+
+// ParseEvent checks the Stripe-Signature of a webhook request, which must have been signed within
+// stripeSignatureTolerance of now, and reads the PaymentIntent event it carries
+func (s *Stripe) ParseEvent(header http.Header, body []byte, now time.Time) (*Event, error) {
+	if err := s.verifySignature(header.Get("Stripe-Signature"), body, now); err != nil {
+		return nil, err
+	}
+
+	var notification struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+		Data struct {
+			Object stripeIntent `json:"object"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &notification); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook event: %w", err)
+	}
+
+	intent := notification.Data.Object
+	event := &Event{
+		ID:        notification.ID,
+		PaymentID: intent.ID,
+		Amount:    intent.Amount,
+		Currency:  strings.ToUpper(intent.Currency),
+	}
+	switch notification.Type {
+	case "payment_intent.succeeded":
+		event.Type = EventSucceeded
+	case "payment_intent.payment_failed":
+		event.Type = EventFailed
+		if intent.LastPaymentError != nil {
+			event.Message = intent.LastPaymentError.Message
+		}
+	case "payment_intent.canceled":
+		event.Type = EventCanceled
+	}
+	return event, nil
+}
+
+// verifySignature checks a Stripe-Signature header, "t=<unix time>,v1=<hex HMAC-SHA256>", signs
+// the body with the webhook secret within the tolerance of now
+func (s *Stripe) verifySignature(signature string, body []byte, now time.Time) error {
+	if s.webhookSecret == "" || signature == "" {
+		return ErrInvalidSignature
+	}
+
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(signature, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+	if age := now.Sub(time.Unix(seconds, 0)); age > stripeSignatureTolerance || age < -stripeSignatureTolerance {
+		return fmt.Errorf("%w: timestamp outside the tolerance", ErrInvalidSignature)
+	}
+
+	mac := hmac.New(sha256.New, []byte(s.webhookSecret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+	for _, candidate := range signatures {
+		decoded, err := hex.DecodeString(candidate)
+		if err == nil && hmac.Equal(decoded, expected) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// post sends a form to the Stripe API and decodes the response into result, when given
+func (s *Stripe) post(ctx context.Context, path string, form url.Values, idempotencyKey string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.secretKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var failure struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&failure) == nil && failure.Error.Message != "" {
+			return fmt.Errorf("payment provider returned status %d: %s", resp.StatusCode, failure.Error.Message)
+		}
+		return fmt.Errorf("payment provider returned status %d", resp.StatusCode)
+	}
+	if result == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode payment provider response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+// Package phone parses, validates and normalizes phone numbers to E.164. It is a small port of
+// libphonenumber covering the countries the portal serves.
+package phone
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrInvalidNumber is returned for numbers that don't fit their country's numbering plan
+	ErrInvalidNumber = errors.New("invalid phone number")
+
+	// ErrUnknownRegion is returned for regions and calling codes the package has no plan for
+	ErrUnknownRegion = errors.New("unsupported phone number region")
+)
+
+// Number is a parsed phone number
+type Number struct {
+	Region         string // ISO 3166-1 alpha-2 code, e.g. "BR"
+	CallingCode    string // e.g. "55"
+	NationalNumber string // national significant number, without trunk or carrier prefixes
+}
+
+// E164 formats the number as +<calling code><national number>
+func (n Number) E164() string {
+	return "+" + n.CallingCode + n.NationalNumber
+}
+
+// Parse reads a phone number as typed by a person. Numbers starting with + or 00 are read as
+// international; others as dialled within defaultRegion, with or without the trunk prefix.
+// Spaces, dots, dashes, slashes and parentheses are ignored.
+func Parse(raw, defaultRegion string) (Number, error) {
+	digits, international, err := stripFormatting(raw)
+	if err != nil {
+		return Number{}, err
+	}
+
+	if international {
+		r, national := regionForCallingCode(digits)
+		if r == nil {
+			return Number{}, ErrUnknownRegion
+		}
+		return r.number(national)
+	}
+
+	r, ok := regions[strings.ToUpper(defaultRegion)]
+	if !ok {
+		return Number{}, ErrUnknownRegion
+	}
+	// Numbers stored without the +, e.g. 5511912345678
+	if strings.HasPrefix(digits, r.callingCode) {
+		if number, err := r.number(digits[len(r.callingCode):]); err == nil {
+			return number, nil
+		}
+	}
+	return r.number(r.stripNationalPrefix(digits))
+}
+
+// Normalize parses a phone number and returns it in E.164
+func Normalize(raw, defaultRegion string) (string, error) {
+	number, err := Parse(raw, defaultRegion)
+	if err != nil {
+		return "", err
+	}
+	return number.E164(), nil
+}
+
+// Valid reports whether a number parses in the default region
+func Valid(raw, defaultRegion string) bool {
+	_, err := Parse(raw, defaultRegion)
+	return err == nil
+}
+
+// SupportedRegion reports whether numbers can be parsed for a region
+func SupportedRegion(code string) bool {
+	_, ok := regions[strings.ToUpper(code)]
+	return ok
+}
+
+// number validates a national significant number against the region's plan
+func (r *region) number(national string) (Number, error) {
+	if !r.national.MatchString(national) {
+		return Number{}, ErrInvalidNumber
+	}
+	return Number{Region: r.code, CallingCode: r.callingCode, NationalNumber: national}, nil
+}
+
+// stripNationalPrefix removes the trunk prefix, and the carrier selection code after it, from
+// a number dialled within the region
+func (r *region) stripNationalPrefix(digits string) string {
+	if r.trunkPrefix == "" || !strings.HasPrefix(digits, r.trunkPrefix) {
+		return digits
+	}
+	rest := digits[len(r.trunkPrefix):]
+	if r.national.MatchString(rest) {
+		return rest
+	}
+	if r.carrierCode != nil {
+		if carrier := r.carrierCode.FindString(rest); carrier != "" && r.national.MatchString(rest[len(carrier):]) {
+			return rest[len(carrier):]
+		}
+	}
+	return rest
+}
+
+// stripFormatting keeps the digits of a number and reports whether it was written in
+// international form
+func stripFormatting(raw string) (string, bool, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", false, ErrInvalidNumber
+	}
+
+	international := strings.HasPrefix(raw, "+")
+	if international {
+		raw = raw[1:]
+	}
+
+	var digits strings.Builder
+	for _, c := range raw {
+		switch {
+		case c >= '0' && c <= '9':
+			digits.WriteRune(c)
+		case c == ' ' || c == '-' || c == '.' || c == '/' || c == '(' || c == ')':
+		default:
+			return "", false, ErrInvalidNumber
+		}
+	}
+
+	result := digits.String()
+	if !international && strings.HasPrefix(result, "00") {
+		international = true
+		result = result[2:]
+	}
+	if len(result) < 4 || len(result) > 15 {
+		return "", false, ErrInvalidNumber
+	}
+	return result, international, nil
+}
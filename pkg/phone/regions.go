@@ -0,0 +1,92 @@
+package phone
+
+import "regexp"
+
+// region holds the numbering plan of one country, following the metadata libphonenumber keeps
+// for it: the calling code, the national trunk prefix dialled before domestic numbers and the
+// pattern national significant numbers match
+type region struct {
+	code        string
+	callingCode string
+	trunkPrefix string
+	// carrierCode matches a carrier selection code dialled between the trunk prefix and the
+	// number, as in Brazil's 0 XX 11 91234-5678
+	carrierCode *regexp.Regexp
+	national    *regexp.Regexp
+}
+
+// regions are the countries numbers can be parsed for, keyed by ISO 3166-1 alpha-2 code
+var regions = map[string]*region{
+	"BR": {
+		code:        "BR",
+		callingCode: "55",
+		trunkPrefix: "0",
+		carrierCode: regexp.MustCompile(`^\d{2}`),
+		// Two-digit area code without zeros, then eight-digit landlines or nine-digit mobiles
+		national: regexp.MustCompile(`^[1-9][1-9](?:[2-5]\d{7}|9\d{8})$`),
+	},
+	"US": {
+		code:        "US",
+		callingCode: "1",
+		trunkPrefix: "1",
+		// NANP area code and exchange never start with 0 or 1
+		national: regexp.MustCompile(`^[2-9]\d{2}[2-9]\d{6}$`),
+	},
+	"PT": {
+		code:        "PT",
+		callingCode: "351",
+		national:    regexp.MustCompile(`^[239]\d{8}$`),
+	},
+	"ES": {
+		code:        "ES",
+		callingCode: "34",
+		national:    regexp.MustCompile(`^[5-9]\d{8}$`),
+	},
+	"AR": {
+		code:        "AR",
+		callingCode: "54",
+		trunkPrefix: "0",
+		// Mobiles carry a 9 before the area code when dialled from abroad
+		national: regexp.MustCompile(`^(?:9)?[1-9]\d{9}$`),
+	},
+	"CL": {
+		code:        "CL",
+		callingCode: "56",
+		national:    regexp.MustCompile(`^[2-9]\d{8}$`),
+	},
+	"UY": {
+		code:        "UY",
+		callingCode: "598",
+		trunkPrefix: "0",
+		national:    regexp.MustCompile(`^[249]\d{7}$`),
+	},
+	"PY": {
+		code:        "PY",
+		callingCode: "595",
+		trunkPrefix: "0",
+		national:    regexp.MustCompile(`^[2-9]\d{6,8}$`),
+	},
+	"CO": {
+		code:        "CO",
+		callingCode: "57",
+		national:    regexp.MustCompile(`^[36]\d{9}$`),
+	},
+	"MX": {
+		code:        "MX",
+		callingCode: "52",
+		national:    regexp.MustCompile(`^[1-9]\d{9}$`),
+	},
+}
+
+// regionForCallingCode returns the region of the calling code an international number starts
+// with. Calling codes are prefix-free, so at most one matches.
+func regionForCallingCode(digits string) (*region, string) {
+	for length := 1; length <= 3 && length <= len(digits); length++ {
+		for _, r := range regions {
+			if r.callingCode == digits[:length] {
+				return r, digits[length:]
+			}
+		}
+	}
+	return nil, ""
+}
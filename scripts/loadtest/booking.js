@@ -0,0 +1,86 @@
+// k6 scenario for the booking endpoints under contention: every virtual user books into the same
+// few slots, so creates race for the same rows. Thresholds are the p95 performance budget; k6
+// exits non-zero when one is missed. The Go runner in cmd/loadtest runs the same scenario.
+//
+//   k6 run -e BASE_URL=http://localhost:8080/api/v1 -e TOKEN=... scripts/loadtest/booking.js
+import http from 'k6/http';
+import { check } from 'k6';
+import { Trend } from 'k6/metrics';
+
+const baseURL = __ENV.BASE_URL || 'http://localhost:8080/api/v1';
+const token = __ENV.TOKEN;
+const ids = {
+  supplier_id: Number(__ENV.SUPPLIER_ID || 1),
+  employee_id: Number(__ENV.EMPLOYEE_ID || 1),
+  operation_id: Number(__ENV.OPERATION_ID || 1),
+  product_id: Number(__ENV.PRODUCT_ID || 1),
+};
+const slots = Number(__ENV.SLOTS || 4);
+const createRatio = Number(__ENV.CREATE_RATIO || 0.3);
+
+const availabilityLatency = new Trend('check_availability_duration', true);
+const createLatency = new Trend('create_duration', true);
+
+export const options = {
+  scenarios: {
+    contention: {
+      executor: 'constant-vus',
+      vus: Number(__ENV.VUS || 20),
+      duration: __ENV.DURATION || '30s',
+    },
+  },
+  thresholds: {
+    check_availability_duration: [`p(95)<${__ENV.AVAILABILITY_P95_MS || 150}`],
+    create_duration: [`p(95)<${__ENV.CREATE_P95_MS || 300}`],
+    'checks{kind:no_server_error}': ['rate==1'],
+  },
+};
+
+// firstSlot is 09:00 on the next weekday
+function firstSlot() {
+  const day = new Date();
+  day.setDate(day.getDate() + 1);
+  while (day.getDay() === 0 || day.getDay() === 6) {
+    day.setDate(day.getDate() + 1);
+  }
+  day.setHours(9, 0, 0, 0);
+  return day;
+}
+
+export function setup() {
+  if (!token) {
+    throw new Error('TOKEN is required: a bearer token of a supplier or admin user');
+  }
+  return { first: firstSlot().getTime() };
+}
+
+export default function (data) {
+  const start = new Date(data.first + Math.floor(Math.random() * slots) * 3600 * 1000);
+  const end = new Date(start.getTime() + 3600 * 1000);
+  const params = {
+    headers: { 'Content-Type': 'application/json', Authorization: `Bearer ${token}` },
+  };
+
+  let res;
+  if (Math.random() < createRatio) {
+    res = http.post(`${baseURL}/appointments`, JSON.stringify({
+      ...ids,
+      scheduled_start: start.toISOString(),
+      scheduled_end: end.toISOString(),
+      quantity_to_deliver: 1,
+      notes: 'load test',
+    }), params);
+    createLatency.add(res.timings.duration);
+  } else {
+    res = http.post(`${baseURL}/appointments/check-availability`, JSON.stringify({
+      operation_id: ids.operation_id,
+      employee_id: ids.employee_id,
+      scheduled_start: start.toISOString(),
+      scheduled_end: end.toISOString(),
+    }), params);
+    availabilityLatency.add(res.timings.duration);
+  }
+
+  // Taken slots are answered with 400 under contention; only server errors count against the run
+  check(res, { 'no server error': (r) => r.status > 0 && r.status < 500 }, { kind: 'no_server_error' });
+}